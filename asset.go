@@ -297,6 +297,18 @@ func NewCreateAccountInputType(driver Driver, variant string) TxVariantInputType
 	return TxVariantInputType(fmt.Sprintf("drivers/%s/create-account/%s", driver, variant))
 }
 
+func NewApprovalInputType(driver Driver, variant string) TxVariantInputType {
+	return TxVariantInputType(fmt.Sprintf("drivers/%s/approval/%s", driver, variant))
+}
+
+func NewAccessKeyInputType(driver Driver, variant string) TxVariantInputType {
+	return TxVariantInputType(fmt.Sprintf("drivers/%s/access-key/%s", driver, variant))
+}
+
+func NewNftTransferInputType(driver Driver, variant string) TxVariantInputType {
+	return TxVariantInputType(fmt.Sprintf("drivers/%s/nft-transfer/%s", driver, variant))
+}
+
 func NewCallingInputType(driver Driver) TxVariantInputType {
 	return TxVariantInputType(fmt.Sprintf("drivers/%s/calling/%s", driver, driver))
 }
@@ -495,6 +507,33 @@ type AdditionalNativeAsset struct {
 	Aliases  []string            `yaml:"aliases,omitempty"`
 }
 
+// An IBC channel from this chain to another chain
+type IbcChannel struct {
+	// The destination chain
+	Chain NativeAsset `yaml:"chain,omitempty"`
+	// The channel on this chain, e.g. "channel-141"
+	Channel string `yaml:"channel,omitempty"`
+	// The port on this chain, defaults to "transfer"
+	Port string `yaml:"port,omitempty"`
+}
+
+func (ch *IbcChannel) GetPort() string {
+	if ch.Port == "" {
+		return "transfer"
+	}
+	return ch.Port
+}
+
+// Lookup the IBC channel used to send to the destination chain
+func (chain *ChainBaseConfig) GetIbcChannel(destination NativeAsset) (*IbcChannel, bool) {
+	for _, ch := range chain.IbcChannels {
+		if ch.Chain == destination {
+			return ch, true
+		}
+	}
+	return nil, false
+}
+
 func NewAdditionalNativeAsset(assetId, bridgedAsset string, contractId ContractAddress, decimals int32, feeLimit AmountHumanReadable, aliases ...string) *AdditionalNativeAsset {
 	return &AdditionalNativeAsset{
 		assetId,
@@ -744,6 +783,9 @@ type ChainBaseConfig struct {
 	// Optional address configuration
 	Address AddressConfig `yaml:"address,omitempty"`
 
+	// IBC channels that connect this chain to other chains (cosmos only).
+	IbcChannels []*IbcChannel `yaml:"ibc_channels,omitempty"`
+
 	// Annotations for what the chain currently supports.
 	// This must accurately reflect the implementation and is unit-tested.
 	Support ChainSupport `yaml:"support"`
@@ -827,6 +869,13 @@ type ChainClientConfig struct {
 	// Currently this is used for EVM L2 chains that have an additional "l1" fee.
 	GasPriceOracleAddress string `yaml:"gas_price_oracle_address,omitempty"`
 
+	// ERC-4337 bundler RPC, used to estimate, submit and track UserOperations from smart accounts.
+	// If a paymaster is used, the bundler is expected to also serve the ERC-7677 paymaster methods.
+	BundlerURL string `yaml:"bundler_url,omitempty"`
+
+	// Address lookup tables that may be used to compress transactions (Solana v0 transactions).
+	AddressLookupTables []string `yaml:"address_lookup_tables,omitempty"`
+
 	// Rate limit setting on RPC requests for client, in requests/second.
 	RateLimit rate.Limit `yaml:"rate_limit,omitempty"`
 	// Period between requests (alternative to `rate_limit`)
@@ -0,0 +1,103 @@
+package builder
+
+import (
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+)
+
+type AccessKeyAction string
+
+const (
+	// Add a new access key to the account
+	AccessKeyActionAdd AccessKeyAction = "add"
+	// Delete an existing access key from the account
+	AccessKeyActionDelete AccessKeyAction = "delete"
+)
+
+func (action AccessKeyAction) Valid() bool {
+	switch action {
+	case AccessKeyActionAdd, AccessKeyActionDelete:
+		return true
+	}
+	return false
+}
+
+// AccessKeyPermission restricts an access key to calling methods on a single contract.
+type AccessKeyPermission struct {
+	// Contract the key may call
+	Receiver xc.ContractAddress
+	// Methods the key may call, all methods if empty
+	MethodNames []string
+	// Maximum amount of fees the key may spend, unlimited if zero
+	Allowance xc.AmountBlockchain
+}
+
+type AccessKeyArgs struct {
+	appliedOptions []BuilderOption
+	options        builderOptions
+	action         AccessKeyAction
+	account        xc.Address
+	key            []byte
+	permission     *AccessKeyPermission
+}
+
+var _ TransactionOptions = &AccessKeyArgs{}
+
+// Access key relevant arguments
+func (args *AccessKeyArgs) GetAction() AccessKeyAction { return args.action }
+func (args *AccessKeyArgs) GetFrom() xc.Address        { return args.account }
+func (args *AccessKeyArgs) GetKey() []byte             { return append([]byte(nil), args.key...) }
+
+// GetPermission returns the restriction for the key, or false if the key has full access
+func (args *AccessKeyArgs) GetPermission() (AccessKeyPermission, bool) {
+	if args.permission == nil {
+		return AccessKeyPermission{}, false
+	}
+	return *args.permission, true
+}
+func (args *AccessKeyArgs) GetMemo() (string, bool)         { return args.options.GetMemo() }
+func (args *AccessKeyArgs) GetTimestamp() (int64, bool)     { return args.options.GetTimestamp() }
+func (args *AccessKeyArgs) GetPublicKey() ([]byte, bool)    { return args.options.GetPublicKey() }
+func (args *AccessKeyArgs) GetFromIdentity() (string, bool) { return args.options.GetFromIdentity() }
+func (args *AccessKeyArgs) GetTransactionAttempts() []string {
+	return args.options.GetTransactionAttempts()
+}
+func (args *AccessKeyArgs) GetPriority() (xc.GasFeePriority, bool) {
+	return args.options.GetPriority()
+}
+
+// Create arguments to add or delete the `key` on the account.  A nil permission grants full access
+// when adding, and is ignored when deleting.
+func NewAccessKeyArgs(chain xc.NativeAsset, action AccessKeyAction, account xc.Address, key []byte, permission *AccessKeyPermission, options ...BuilderOption) (AccessKeyArgs, error) {
+	builderOptions := newBuilderOptions()
+	if action == AccessKeyActionDelete {
+		permission = nil
+	}
+	args := AccessKeyArgs{
+		appliedOptions: options,
+		options:        builderOptions,
+		action:         action,
+		account:        account,
+		key:            append([]byte(nil), key...),
+		permission:     permission,
+	}
+	for _, opt := range options {
+		err := opt(&args.options)
+		if err != nil {
+			return args, err
+		}
+	}
+
+	if !action.Valid() {
+		return args, fmt.Errorf("invalid access key action: %s", action)
+	}
+	if len(key) == 0 {
+		return args, fmt.Errorf("access key actions require a key")
+	}
+	if permission != nil && permission.Receiver == "" {
+		return args, fmt.Errorf("restricted access keys require a receiver contract")
+	}
+
+	return args, nil
+}
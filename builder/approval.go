@@ -0,0 +1,95 @@
+package builder
+
+import (
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+)
+
+type ApprovalAction string
+
+const (
+	// Set the allowance of the spender to the amount
+	ApprovalActionApprove ApprovalAction = "approve"
+	// Add the amount to the current allowance of the spender
+	ApprovalActionIncrease ApprovalAction = "increase"
+	// Set the allowance of the spender to zero
+	ApprovalActionRevoke ApprovalAction = "revoke"
+)
+
+func (action ApprovalAction) Valid() bool {
+	switch action {
+	case ApprovalActionApprove, ApprovalActionIncrease, ApprovalActionRevoke:
+		return true
+	}
+	return false
+}
+
+type ApprovalArgs struct {
+	appliedOptions []BuilderOption
+	options        builderOptions
+	action         ApprovalAction
+	owner          xc.Address
+	spender        xc.Address
+	contract       xc.ContractAddress
+	amount         xc.AmountBlockchain
+}
+
+var _ TransactionOptions = &ApprovalArgs{}
+
+// Approval relevant arguments
+func (args *ApprovalArgs) GetAction() ApprovalAction       { return args.action }
+func (args *ApprovalArgs) GetFrom() xc.Address             { return args.owner }
+func (args *ApprovalArgs) GetSpender() xc.Address          { return args.spender }
+func (args *ApprovalArgs) GetContract() xc.ContractAddress { return args.contract }
+func (args *ApprovalArgs) GetAmount() xc.AmountBlockchain  { return args.amount }
+func (args *ApprovalArgs) GetMemo() (string, bool)         { return args.options.GetMemo() }
+func (args *ApprovalArgs) GetTimestamp() (int64, bool)     { return args.options.GetTimestamp() }
+func (args *ApprovalArgs) GetPublicKey() ([]byte, bool)    { return args.options.GetPublicKey() }
+func (args *ApprovalArgs) GetDecimals() (int, bool)        { return args.options.GetDecimals() }
+func (args *ApprovalArgs) GetFromIdentity() (string, bool) { return args.options.GetFromIdentity() }
+func (args *ApprovalArgs) GetTransactionAttempts() []string {
+	return args.options.GetTransactionAttempts()
+}
+func (args *ApprovalArgs) GetPriority() (xc.GasFeePriority, bool) {
+	return args.options.GetPriority()
+}
+
+// Create arguments to change the allowance that the spender has over the owner's tokens.
+// The amount is ignored when revoking.
+func NewApprovalArgs(chain xc.NativeAsset, action ApprovalAction, owner xc.Address, spender xc.Address, contract xc.ContractAddress, amount xc.AmountBlockchain, options ...BuilderOption) (ApprovalArgs, error) {
+	builderOptions := newBuilderOptions()
+	if action == ApprovalActionRevoke {
+		amount = xc.NewAmountBlockchainFromUint64(0)
+	}
+	args := ApprovalArgs{
+		appliedOptions: options,
+		options:        builderOptions,
+		action:         action,
+		owner:          owner,
+		spender:        spender,
+		contract:       contract,
+		amount:         amount,
+	}
+	for _, opt := range options {
+		err := opt(&args.options)
+		if err != nil {
+			return args, err
+		}
+	}
+
+	if !action.Valid() {
+		return args, fmt.Errorf("invalid approval action: %s", action)
+	}
+	if contract == "" {
+		return args, fmt.Errorf("approvals require a token contract")
+	}
+	if spender == "" {
+		return args, fmt.Errorf("approvals require a spender")
+	}
+	if action == ApprovalActionIncrease && amount.IsZero() {
+		return args, fmt.Errorf("increasing an allowance requires a non-zero amount")
+	}
+
+	return args, nil
+}
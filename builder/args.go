@@ -1,6 +1,9 @@
 package builder
 
 import (
+	"fmt"
+	"time"
+
 	xc "github.com/cordialsys/crosschain"
 )
 
@@ -36,6 +39,36 @@ type builderOptions struct {
 
 	// On some chains a nonce account may be specified for the transaction (solana)
 	nonceAccount *string
+
+	// The owner (signer) of a smart account, when sending from an ERC-4337 smart account.
+	smartAccountOwner *xc.Address
+	// Factory that deploys the smart account with its first UserOperation
+	smartAccountFactory *SmartAccountFactory
+
+	// Execute on behalf of the from-address with an x/authz grant held by this address (cosmos)
+	authzGrantee *xc.Address
+	// Pay fees from a x/feegrant allowance given by this address (cosmos)
+	feeGranter *xc.Address
+
+	// Timeouts on the destination chain for IBC transfers
+	ibcTimeoutHeight    *IbcHeight
+	ibcTimeoutTimestamp *uint64
+
+	// Max amount of the native asset that may be burned to pay for missing resources (tron)
+	maxBurn *xc.AmountBlockchain
+
+	// Labelled transaction metadata, in the order it was added (cardano)
+	txMetadata []TxMetadata
+	// Spend extra utxos holding native assets, merging them into the change (cardano)
+	consolidateDust bool
+}
+
+// TxMetadata is a labelled metadata entry attached to a transaction, e.g. CIP-20 messages (label 674)
+// or CIP-25 NFT metadata (label 721) on cardano.  The value may be a string, bytes, integer,
+// or a list or map of these.
+type TxMetadata struct {
+	Label uint64
+	Value any
 }
 
 func newBuilderOptions() builderOptions {
@@ -75,6 +108,24 @@ func (opts *builderOptions) GetTransactionAttempts() []string {
 func (opts *builderOptions) GetNonceAccount() (string, bool) {
 	return get(opts.nonceAccount)
 }
+func (opts *builderOptions) GetSmartAccountOwner() (xc.Address, bool) {
+	return get(opts.smartAccountOwner)
+}
+func (opts *builderOptions) GetSmartAccountFactory() (SmartAccountFactory, bool) {
+	return get(opts.smartAccountFactory)
+}
+func (opts *builderOptions) GetAuthzGrantee() (xc.Address, bool) {
+	return get(opts.authzGrantee)
+}
+func (opts *builderOptions) GetFeeGranter() (xc.Address, bool) {
+	return get(opts.feeGranter)
+}
+func (opts *builderOptions) GetMaxBurn() (xc.AmountBlockchain, bool) {
+	return get(opts.maxBurn)
+}
+func (opts *builderOptions) GetTxMetadata() []TxMetadata {
+	return opts.txMetadata
+}
 
 // Other options
 func (opts *builderOptions) GetValidator() (string, bool)      { return get(opts.validator) }
@@ -83,6 +134,9 @@ func (opts *builderOptions) GetStakeAccount() (string, bool)   { return get(opts
 func (opts *builderOptions) InclusiveFeeSpendingEnabled() bool {
 	return opts.inclusiveFeeSpending
 }
+func (opts *builderOptions) ConsolidateDustEnabled() bool {
+	return opts.consolidateDust
+}
 func (opts *builderOptions) GetFromIdentity() (string, bool)     { return get(opts.fromIdentity) }
 func (opts *builderOptions) GetFeePayerIdentity() (string, bool) { return get(opts.feePayerIdentity) }
 func (opts *builderOptions) GetToIdentity() (string, bool)       { return get(opts.toIdentity) }
@@ -251,6 +305,108 @@ func OptionNonceAccount(nonceAccount string) BuilderOption {
 	}
 }
 
+// Send from an ERC-4337 smart account (the from-address) that is owned by the given signer.
+// The transaction will be built as a UserOperation and submitted through a bundler.
+func OptionSmartAccountOwner(owner xc.Address) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.smartAccountOwner = &owner
+		return nil
+	}
+}
+
+// SmartAccountFactory deploys an ERC-4337 smart account that does not exist yet.  The
+// EntryPoint calls the factory with the data, which must create the from-address.
+type SmartAccountFactory struct {
+	Factory xc.Address
+	Data    []byte
+}
+
+// Deploy the smart account through the given factory with the first UserOperation it sends.
+// Only used together with OptionSmartAccountOwner.
+func OptionSmartAccountFactory(factory xc.Address, factoryData []byte) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.smartAccountFactory = &SmartAccountFactory{
+			Factory: factory,
+			Data:    factoryData,
+		}
+		return nil
+	}
+}
+
+// Execute the transaction on behalf of the from-address, using an x/authz grant that the
+// from-address gave to the grantee.  The grantee signs the transaction, so the public key
+// option should be the grantee's.
+func OptionAuthzGrantee(grantee xc.Address) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.authzGrantee = &grantee
+		return nil
+	}
+}
+
+// Pay the fees using an x/feegrant allowance that the granter gave to the signer.
+// Unlike a fee-payer, the granter does not need to sign.
+func OptionFeeGranter(granter xc.Address) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.feeGranter = &granter
+		return nil
+	}
+}
+
+// Timeout an IBC transfer if it is not received by the given height on the destination chain.
+func OptionIbcTimeoutHeight(revisionNumber uint64, revisionHeight uint64) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.ibcTimeoutHeight = &IbcHeight{
+			RevisionNumber: revisionNumber,
+			RevisionHeight: revisionHeight,
+		}
+		return nil
+	}
+}
+
+// Timeout an IBC transfer if it is not received by the given time on the destination chain.
+func OptionIbcTimeoutTimestamp(timeout time.Time) BuilderOption {
+	return func(opts *builderOptions) error {
+		if timeout.IsZero() {
+			return fmt.Errorf("IBC timeout timestamp must be set")
+		}
+		nanos := uint64(timeout.UnixNano())
+		opts.ibcTimeoutTimestamp = &nanos
+		return nil
+	}
+}
+
+// Refuse to build a transfer that would burn more than the given amount of the native asset
+// to pay for the energy and bandwidth that the sender does not have (tron).
+func OptionMaxBurn(maxBurn xc.AmountBlockchain) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.maxBurn = &maxBurn
+		return nil
+	}
+}
+
+// Attach labelled metadata to the transaction (cardano).  May be repeated for different labels.
+func OptionTxMetadata(label uint64, value any) BuilderOption {
+	return func(opts *builderOptions) error {
+		for _, existing := range opts.txMetadata {
+			if existing.Label == label {
+				return fmt.Errorf("metadata label %d is set more than once", label)
+			}
+		}
+		opts.txMetadata = append(opts.txMetadata, TxMetadata{Label: label, Value: value})
+		return nil
+	}
+}
+
+// Spend up to a limited number of the sender's other utxos holding native assets, so their assets are
+// merged into the change output rather than each locking the minimum UTXO value (cardano).
+// This makes the transaction larger and its fee higher, so it is off by default.
+func OptionConsolidateDust(consolidateDust bool) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.consolidateDust = consolidateDust
+		return nil
+	}
+}
+
 // Previously the crosschain abstraction would require callers to set options
 // directly on the transaction input, if the interface was implemented on the input type.
 // However, wasn't very clear or easy to use.  This function bridges the gap, to allow
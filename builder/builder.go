@@ -50,6 +50,22 @@ type Staking interface {
 	MethodsUsed() []xc.StakingMethod
 }
 
+type Approval interface {
+	Approve(args ApprovalArgs, input xc.ApprovalTxInput) (xc.Tx, error)
+}
+
+type NftTransfer interface {
+	NftTransfer(args NftTransferArgs, input xc.NftTransferTxInput) (xc.Tx, error)
+}
+
+type AccessKeyManagement interface {
+	ManageAccessKey(args AccessKeyArgs, input xc.AccessKeyTxInput) (xc.Tx, error)
+}
+
+type IbcTransfer interface {
+	IbcTransfer(args IbcTransferArgs, input xc.TxInput) (xc.Tx, error)
+}
+
 type AccountCreation interface {
 	CreateAccount(createAccountArgs CreateAccountArgs, input xc.CreateAccountTxInput) (xc.Tx, error)
 }
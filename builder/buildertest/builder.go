@@ -73,3 +73,5 @@ var OptionMemo = builder.OptionMemo
 var OptionTxInput = builder.WithTxInputOptions
 var OptionFeePayer = builder.OptionFeePayer
 var OptionInclusiveFeeSpending = builder.OptionInclusiveFeeSpending
+var OptionSmartAccountOwner = builder.OptionSmartAccountOwner
+var OptionSmartAccountFactory = builder.OptionSmartAccountFactory
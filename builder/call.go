@@ -42,3 +42,11 @@ func NewCallArgs(chain *xc.ChainBaseConfig, options ...BuilderOption) (CallArgs,
 func (args *CallArgs) GetNonceAccount() (string, bool) {
 	return args.options.GetNonceAccount()
 }
+
+func (args *CallArgs) GetSmartAccountOwner() (xc.Address, bool) {
+	return args.options.GetSmartAccountOwner()
+}
+
+func (args *CallArgs) GetSmartAccountFactory() (SmartAccountFactory, bool) {
+	return args.options.GetSmartAccountFactory()
+}
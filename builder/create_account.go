@@ -33,6 +33,9 @@ func (args *CreateAccountArgs) GetChain() xc.NativeAsset { return args.chain }
 func (args *CreateAccountArgs) GetAddress() xc.Address   { return args.address }
 func (args *CreateAccountArgs) PublicKeyBytes() []byte   { return append([]byte(nil), args.publicKey...) }
 func (args *CreateAccountArgs) GetMemo() (string, bool)  { return args.options.GetMemo() }
+func (args *CreateAccountArgs) GetContract() (xc.ContractAddress, bool) {
+	return args.options.GetContract()
+}
 func (args *CreateAccountArgs) GetTimestamp() (int64, bool) {
 	return args.options.GetTimestamp()
 }
@@ -0,0 +1,91 @@
+package builder
+
+import (
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+)
+
+// Height on the destination chain after which an IBC transfer times out
+type IbcHeight struct {
+	RevisionNumber uint64
+	RevisionHeight uint64
+}
+
+type IbcTransferArgs struct {
+	appliedOptions []BuilderOption
+	options        builderOptions
+	from           xc.Address
+	to             xc.Address
+	amount         xc.AmountBlockchain
+	destination    xc.NativeAsset
+	port           string
+	channel        string
+}
+
+var _ TransactionOptions = &IbcTransferArgs{}
+
+// IBC transfer relevant arguments
+func (args *IbcTransferArgs) GetFrom() xc.Address            { return args.from }
+func (args *IbcTransferArgs) GetTo() xc.Address              { return args.to }
+func (args *IbcTransferArgs) GetAmount() xc.AmountBlockchain { return args.amount }
+func (args *IbcTransferArgs) GetDestination() xc.NativeAsset { return args.destination }
+func (args *IbcTransferArgs) GetPort() string                { return args.port }
+func (args *IbcTransferArgs) GetChannel() string             { return args.channel }
+func (args *IbcTransferArgs) GetMemo() (string, bool)        { return args.options.GetMemo() }
+func (args *IbcTransferArgs) GetTimestamp() (int64, bool)    { return args.options.GetTimestamp() }
+func (args *IbcTransferArgs) GetPublicKey() ([]byte, bool)   { return args.options.GetPublicKey() }
+func (args *IbcTransferArgs) GetContract() (xc.ContractAddress, bool) {
+	return args.options.GetContract()
+}
+func (args *IbcTransferArgs) GetDecimals() (int, bool) { return args.options.GetDecimals() }
+func (args *IbcTransferArgs) GetPriority() (xc.GasFeePriority, bool) {
+	return args.options.GetPriority()
+}
+func (args *IbcTransferArgs) GetTimeoutHeight() (IbcHeight, bool) {
+	return get(args.options.ibcTimeoutHeight)
+}
+
+// Timeout as unix timestamp in nanoseconds
+func (args *IbcTransferArgs) GetTimeoutTimestamp() (uint64, bool) {
+	return get(args.options.ibcTimeoutTimestamp)
+}
+
+// Create arguments for an IBC (ICS-20) transfer to an address on the destination chain.
+// The port and channel are looked up from the `ibc_channels` configured on the source chain.
+func NewIbcTransferArgs(chain *xc.ChainBaseConfig, from xc.Address, to xc.Address, amount xc.AmountBlockchain, destination xc.NativeAsset, options ...BuilderOption) (IbcTransferArgs, error) {
+	builderOptions := newBuilderOptions()
+	args := IbcTransferArgs{
+		appliedOptions: options,
+		options:        builderOptions,
+		from:           from,
+		to:             to,
+		amount:         amount,
+		destination:    destination,
+	}
+	for _, opt := range options {
+		err := opt(&args.options)
+		if err != nil {
+			return args, err
+		}
+	}
+
+	channel, ok := chain.GetIbcChannel(destination)
+	if !ok {
+		return args, fmt.Errorf("no IBC channel is configured from %s to %s", chain.Chain, destination)
+	}
+	if channel.Channel == "" {
+		return args, fmt.Errorf("IBC channel from %s to %s is missing a channel id", chain.Chain, destination)
+	}
+	args.port = channel.GetPort()
+	args.channel = channel.Channel
+
+	if to == "" {
+		return args, fmt.Errorf("IBC transfers require a receiver")
+	}
+	if amount.IsZero() {
+		return args, fmt.Errorf("IBC transfers require a non-zero amount")
+	}
+
+	return args, nil
+}
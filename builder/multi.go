@@ -85,10 +85,11 @@ func NewMultiTransferArgs(chain *xc.ChainBaseConfig, spenders []*Sender, receive
 			return nil, errors.New("only one spender is supported for account-based chains")
 		}
 		_, ok := builderOptions.GetFeePayer()
-		if !ok {
+		_, isUserOperation := builderOptions.GetSmartAccountOwner()
+		if !ok && !isUserOperation {
 			return nil, errors.New("separate fee-payer must be set for multi-transfers on EVM-based chains")
 		}
-	case xc.DriverSolana:
+	case xc.DriverSolana, xc.DriverAptos, xc.DriverEGLD:
 		if len(spenders) != 1 {
 			return nil, errors.New("only one spender is supported for account-based chains")
 		}
@@ -190,6 +191,22 @@ func (args *MultiTransferArgs) GetNonceAccount() (string, bool) {
 	return args.options.GetNonceAccount()
 }
 
+func (args *MultiTransferArgs) GetSmartAccountOwner() (xc.Address, bool) {
+	return args.options.GetSmartAccountOwner()
+}
+
+func (args *MultiTransferArgs) GetSmartAccountFactory() (SmartAccountFactory, bool) {
+	return args.options.GetSmartAccountFactory()
+}
+
+func (args *MultiTransferArgs) GetTxMetadata() []TxMetadata {
+	return args.options.GetTxMetadata()
+}
+
+func (args *MultiTransferArgs) ConsolidateDustEnabled() bool {
+	return args.options.ConsolidateDustEnabled()
+}
+
 func (args *MultiTransferArgs) AsUtxoTransfers() ([]*TransferArgs, error) {
 	transfers := make([]*TransferArgs, len(args.spenders))
 	if len(args.spenders) != len(args.receivers) {
@@ -0,0 +1,174 @@
+package builder
+
+import (
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+)
+
+type NftStandard string
+
+const (
+	// EVM ERC-721, transferred using `safeTransferFrom`
+	NftStandardErc721 NftStandard = "erc721"
+	// EVM ERC-1155, transferred using `safeTransferFrom` or `safeBatchTransferFrom`
+	NftStandardErc1155 NftStandard = "erc1155"
+	// Solana Metaplex NFT, including programmable NFTs (pNFT).  The token id is the mint address.
+	NftStandardMetaplex NftStandard = "metaplex"
+	// Sui object.  The token id is the object id.
+	NftStandardSuiObject NftStandard = "sui-object"
+	// Aptos digital asset (token v2).  The token id is the object address.
+	NftStandardAptosDigitalAsset NftStandard = "aptos-digital-asset"
+	// MultiversX NFT or SFT.  The contract is the collection and the token id is the nonce (hex) or full identifier.
+	NftStandardEsdt NftStandard = "esdt"
+)
+
+// The NFT standard that is used when none is specified
+func DefaultNftStandard(driver xc.Driver) (NftStandard, bool) {
+	switch driver {
+	case xc.DriverEVM, xc.DriverEVMLegacy:
+		return NftStandardErc721, true
+	case xc.DriverSolana:
+		return NftStandardMetaplex, true
+	case xc.DriverSui:
+		return NftStandardSuiObject, true
+	case xc.DriverAptos:
+		return NftStandardAptosDigitalAsset, true
+	case xc.DriverEGLD:
+		return NftStandardEsdt, true
+	}
+	return "", false
+}
+
+func (standard NftStandard) Valid() bool {
+	switch standard {
+	case NftStandardErc721, NftStandardErc1155, NftStandardMetaplex, NftStandardSuiObject, NftStandardAptosDigitalAsset, NftStandardEsdt:
+		return true
+	}
+	return false
+}
+
+// Only ERC-1155 and ESDT tokens are semi-fungible and may be sent with an amount other than 1.
+func (standard NftStandard) SupportsAmount() bool {
+	return standard == NftStandardErc1155 || standard == NftStandardEsdt
+}
+
+// EVM and ESDT standards identify the NFT using both the contract and the token id.
+func (standard NftStandard) RequiresContract() bool {
+	return standard == NftStandardErc721 || standard == NftStandardErc1155 || standard == NftStandardEsdt
+}
+
+// ERC-1155 and ESDT transfers may include several token ids of the contract.
+func (standard NftStandard) SupportsMultipleTokens() bool {
+	return standard == NftStandardErc1155 || standard == NftStandardEsdt
+}
+
+type NftToken struct {
+	TokenId string
+	// Defaults to 1
+	Amount xc.AmountBlockchain
+}
+
+func NewNftToken(tokenId string) NftToken {
+	return NftToken{TokenId: tokenId, Amount: xc.NewAmountBlockchainFromUint64(1)}
+}
+
+type NftTransferArgs struct {
+	appliedOptions []BuilderOption
+	options        builderOptions
+	standard       NftStandard
+	from           xc.Address
+	to             xc.Address
+	contract       xc.ContractAddress
+	tokens         []NftToken
+}
+
+var _ TransactionOptions = &NftTransferArgs{}
+
+// NFT transfer relevant arguments
+func (args *NftTransferArgs) GetStandard() NftStandard        { return args.standard }
+func (args *NftTransferArgs) GetFrom() xc.Address             { return args.from }
+func (args *NftTransferArgs) GetTo() xc.Address               { return args.to }
+func (args *NftTransferArgs) GetContract() xc.ContractAddress { return args.contract }
+func (args *NftTransferArgs) GetTokens() []NftToken           { return args.tokens }
+func (args *NftTransferArgs) GetMemo() (string, bool)         { return args.options.GetMemo() }
+func (args *NftTransferArgs) GetTimestamp() (int64, bool)     { return args.options.GetTimestamp() }
+func (args *NftTransferArgs) GetPublicKey() ([]byte, bool)    { return args.options.GetPublicKey() }
+func (args *NftTransferArgs) GetFromIdentity() (string, bool) { return args.options.GetFromIdentity() }
+func (args *NftTransferArgs) GetToIdentity() (string, bool)   { return args.options.GetToIdentity() }
+func (args *NftTransferArgs) GetNonceAccount() (string, bool) { return args.options.GetNonceAccount() }
+func (args *NftTransferArgs) GetTransactionAttempts() []string {
+	return args.options.GetTransactionAttempts()
+}
+func (args *NftTransferArgs) GetPriority() (xc.GasFeePriority, bool) {
+	return args.options.GetPriority()
+}
+func (args *NftTransferArgs) GetFeePayer() (xc.Address, bool) {
+	return args.options.GetFeePayer()
+}
+func (args *NftTransferArgs) GetFeePayerPublicKey() ([]byte, bool) {
+	return args.options.GetFeePayerPublicKey()
+}
+func (args *NftTransferArgs) GetFeePayerIdentity() (string, bool) {
+	return args.options.GetFeePayerIdentity()
+}
+
+// The first (and usually only) token being transferred
+func (args *NftTransferArgs) GetTokenId() string {
+	return args.tokens[0].TokenId
+}
+
+// Create arguments to transfer one or more NFTs from the same contract or collection.
+// Only ERC-1155 supports transferring more than one token id, or an amount other than 1.
+func NewNftTransferArgs(chain *xc.ChainBaseConfig, standard NftStandard, from xc.Address, to xc.Address, contract xc.ContractAddress, tokens []NftToken, options ...BuilderOption) (NftTransferArgs, error) {
+	builderOptions := newBuilderOptions()
+	if standard == "" {
+		standard, _ = DefaultNftStandard(chain.Driver)
+	}
+	normalizedTokens := make([]NftToken, len(tokens))
+	for i, token := range tokens {
+		if token.Amount.IsZero() {
+			token.Amount = xc.NewAmountBlockchainFromUint64(1)
+		}
+		normalizedTokens[i] = token
+	}
+	args := NftTransferArgs{
+		appliedOptions: options,
+		options:        builderOptions,
+		standard:       standard,
+		from:           from,
+		to:             to,
+		contract:       contract,
+		tokens:         normalizedTokens,
+	}
+	for _, opt := range options {
+		err := opt(&args.options)
+		if err != nil {
+			return args, err
+		}
+	}
+
+	if !standard.Valid() {
+		return args, fmt.Errorf("invalid nft standard: %s", standard)
+	}
+	if standard.RequiresContract() && contract == "" {
+		return args, fmt.Errorf("%s transfers require a token contract", standard)
+	}
+	if len(normalizedTokens) == 0 {
+		return args, fmt.Errorf("nft transfers require a token id")
+	}
+	if len(normalizedTokens) > 1 && !standard.SupportsMultipleTokens() {
+		return args, fmt.Errorf("%s transfers support a single token id", standard)
+	}
+	one := xc.NewAmountBlockchainFromUint64(1)
+	for _, token := range normalizedTokens {
+		if token.TokenId == "" {
+			return args, fmt.Errorf("nft transfers require a token id")
+		}
+		if !standard.SupportsAmount() && token.Amount.Cmp(&one) != 0 {
+			return args, fmt.Errorf("%s transfers must have an amount of 1", standard)
+		}
+	}
+
+	return args, nil
+}
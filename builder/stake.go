@@ -43,6 +43,8 @@ func (args *StakeArgs) GetFromIdentity() (string, bool) { return args.options.Ge
 func (args *StakeArgs) GetNonceAccount() (string, bool) {
 	return args.options.GetNonceAccount()
 }
+func (args *StakeArgs) GetAuthzGrantee() (xc.Address, bool) { return args.options.GetAuthzGrantee() }
+func (args *StakeArgs) GetFeeGranter() (xc.Address, bool)   { return args.options.GetFeeGranter() }
 
 func NewStakeArgs(chain xc.NativeAsset, from xc.Address, options ...BuilderOption) (StakeArgs, error) {
 	builderOptions := builderOptions{}
@@ -89,6 +91,9 @@ func NewStakeArgs(chain xc.NativeAsset, from xc.Address, options ...BuilderOptio
 		}
 	case xc.DriverTron:
 		return args, nil
+	case xc.DriverInternetComputerProtocol:
+		// Neurons are dissolved and disbursed in full, so the amount is only needed to stake
+		return args, nil
 	default:
 		_, ok := args.GetAmount()
 		if !ok {
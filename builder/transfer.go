@@ -69,6 +69,34 @@ func (args *TransferArgs) GetNonceAccount() (string, bool) {
 	return args.options.GetNonceAccount()
 }
 
+func (args *TransferArgs) GetSmartAccountOwner() (xc.Address, bool) {
+	return args.options.GetSmartAccountOwner()
+}
+
+func (args *TransferArgs) GetSmartAccountFactory() (SmartAccountFactory, bool) {
+	return args.options.GetSmartAccountFactory()
+}
+
+func (args *TransferArgs) GetAuthzGrantee() (xc.Address, bool) {
+	return args.options.GetAuthzGrantee()
+}
+
+func (args *TransferArgs) GetFeeGranter() (xc.Address, bool) {
+	return args.options.GetFeeGranter()
+}
+
+func (args *TransferArgs) GetMaxBurn() (xc.AmountBlockchain, bool) {
+	return args.options.GetMaxBurn()
+}
+
+func (args *TransferArgs) GetTxMetadata() []TxMetadata {
+	return args.options.GetTxMetadata()
+}
+
+func (args *TransferArgs) ConsolidateDustEnabled() bool {
+	return args.options.ConsolidateDustEnabled()
+}
+
 func NewTransferArgs(chain *xc.ChainBaseConfig, from xc.Address, to xc.Address, amount xc.AmountBlockchain, options ...BuilderOption) (TransferArgs, error) {
 	builderOptions := newBuilderOptions()
 	appliedOptions := options
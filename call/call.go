@@ -12,8 +12,12 @@ type CallMethodMeta struct {
 const EthSendTransaction Method = "eth_sendTransaction"
 const EthSignTransaction Method = "eth_signTransaction"
 const PersonalSign Method = "personal_sign"
+const EthSignTypedDataV4 Method = "eth_signTypedData_v4"
 const OfferAccept Method = "offer_accept"
 const SettlementComplete Method = "settlement_complete"
+const OfferReject Method = "offer_reject"
+const OfferWithdraw Method = "offer_withdraw"
+const OfferExpire Method = "offer_expire"
 const SolanaSignIn Method = "solana:signIn"
 const SolanaSignMessage Method = "solana:signMessage"
 const SolanaSignTransaction Method = "solana:signTransaction"
@@ -38,6 +42,12 @@ var CallMethods = []CallMethodMeta{
 		NeedsBroadcast: false,
 		Valid:          true,
 	},
+	{
+		Method:         EthSignTypedDataV4,
+		IsTransaction:  false,
+		NeedsBroadcast: false,
+		Valid:          true,
+	},
 	{
 		Method:         OfferAccept,
 		IsTransaction:  true,
@@ -50,6 +60,24 @@ var CallMethods = []CallMethodMeta{
 		NeedsBroadcast: true,
 		Valid:          true,
 	},
+	{
+		Method:         OfferReject,
+		IsTransaction:  true,
+		NeedsBroadcast: true,
+		Valid:          true,
+	},
+	{
+		Method:         OfferWithdraw,
+		IsTransaction:  true,
+		NeedsBroadcast: true,
+		Valid:          true,
+	},
+	{
+		Method:         OfferExpire,
+		IsTransaction:  true,
+		NeedsBroadcast: true,
+		Valid:          true,
+	},
 	{
 		Method:         SolanaSignIn,
 		IsTransaction:  false,
@@ -4,3 +4,9 @@ type SomeContractCall struct {
 	// All requests hopefully should be setting `contract_id`.
 	ContractID string `json:"contract_id"`
 }
+
+// Rejecting or withdrawing an offer may record a reason for the counterparty.
+type OfferCall struct {
+	ContractID string `json:"contract_id"`
+	Reason     string `json:"reason,omitempty"`
+}
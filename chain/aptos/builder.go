@@ -2,7 +2,6 @@ package aptos
 
 import (
 	"errors"
-	"strings"
 
 	transactionbuilder "github.com/coming-chat/go-aptos/transaction_builder"
 	xc "github.com/cordialsys/crosschain"
@@ -17,12 +16,15 @@ type TxBuilder struct {
 
 var _ xcbuilder.FullTransferBuilder = TxBuilder{}
 var _ xcbuilder.BuilderSupportsFeePayer = TxBuilder{}
+var _ xcbuilder.MultiTransfer = TxBuilder{}
 
 func (txBuilder TxBuilder) SupportsFeePayer() xcbuilder.FeePayerType {
 	return xcbuilder.FeePayerNoConflicts
 }
 
 var AptosModuleId *transactionbuilder.ModuleId
+var PrimaryFungibleStoreModuleId *transactionbuilder.ModuleId
+var FungibleAssetMetadataTypeTag *transactionbuilder.TypeTagStruct
 
 func init() {
 	var err error
@@ -30,6 +32,14 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	PrimaryFungibleStoreModuleId, err = transactionbuilder.NewModuleIdFromString("0x1::primary_fungible_store")
+	if err != nil {
+		panic(err)
+	}
+	FungibleAssetMetadataTypeTag, err = transactionbuilder.NewTypeTagStructFromString("0x1::fungible_asset::Metadata")
+	if err != nil {
+		panic(err)
+	}
 	// // There may not be a use for this module anymore.
 	// coinModuleId, err = transactionbuilder.NewModuleIdFromString("0x1::coin")
 	// if err != nil {
@@ -131,7 +141,7 @@ func (txb *TxBuilder) NewTokenTransfer(feePayer xc.Address, from xc.Address, to
 	// 1. Coin: This was the first, and seems they are phasing it out.
 	// 2. Fungible Asset: This is newer.  It has a simpler contract address (no '::' namespacing)
 	var payload transactionbuilder.TransactionPayloadEntryFunction
-	if strings.Contains(string(contract), "::") {
+	if input.GetTokenStandard(contract) == tx_input.TokenStandardCoin {
 		// This is a coin standard transfer
 		typeTag, err := transactionbuilder.NewTypeTagStructFromString(string(contract))
 		if err != nil {
@@ -146,17 +156,16 @@ func (txb *TxBuilder) NewTokenTransfer(feePayer xc.Address, from xc.Address, to
 			},
 		}
 	} else {
-		// This is a fungible asset transfer
+		// This is a fungible asset transfer, from and to the primary stores of the accounts
 		contractAddr, err := DecodeAddress(string(contract))
 		if err != nil {
 			return &Tx{}, err
 		}
 
 		payload = transactionbuilder.TransactionPayloadEntryFunction{
-			ModuleName:   *AptosModuleId,
-			FunctionName: "transfer_fungible_assets",
-			// no type parameters anymore
-			TyArgs: []transactionbuilder.TypeTag{},
+			ModuleName:   *PrimaryFungibleStoreModuleId,
+			FunctionName: "transfer",
+			TyArgs:       []transactionbuilder.TypeTag{*FungibleAssetMetadataTypeTag},
 			Args: [][]byte{
 				contractAddr[:],
 				to_addr[:],
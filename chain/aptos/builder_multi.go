@@ -0,0 +1,134 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+
+	transactionbuilder "github.com/coming-chat/go-aptos/transaction_builder"
+	"github.com/coming-chat/lcs"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/aptos/tx_input"
+)
+
+// MultiTransfer sends one asset from a single account to many receivers, using the batch
+// entry functions of `0x1::aptos_account`.
+func (txBuilder TxBuilder) MultiTransfer(args xcbuilder.MultiTransferArgs, input xc.MultiTransferInput) (xc.Tx, error) {
+	multiInput, ok := input.(*tx_input.MultiTransferInput)
+	if !ok {
+		return &Tx{}, errors.New("xc.MultiTransferInput is not from an aptos chain")
+	}
+	spenders := args.Spenders()
+	if len(spenders) != 1 {
+		return &Tx{}, errors.New("only one spender is supported for aptos multi-transfers")
+	}
+	from := spenders[0].GetFrom()
+	feePayer, ok := args.GetFeePayer()
+	if !ok {
+		feePayer = from
+	}
+	contract, err := GetMultiTransferContract(args)
+	if err != nil {
+		return &Tx{}, err
+	}
+
+	from_addr, err := DecodeAddress(string(from))
+	if err != nil {
+		return &Tx{}, err
+	}
+	recipients := []transactionbuilder.AccountAddress{}
+	amounts := []uint64{}
+	for _, receiver := range args.Receivers() {
+		to_addr, err := DecodeAddress(string(receiver.GetTo()))
+		if err != nil {
+			return &Tx{}, err
+		}
+		amount := receiver.GetAmount()
+		if !amount.Int().IsUint64() {
+			return &Tx{}, fmt.Errorf("amount %s to %s does not fit in a u64", amount.String(), receiver.GetTo())
+		}
+		recipients = append(recipients, transactionbuilder.AccountAddress(to_addr))
+		amounts = append(amounts, amount.Int().Uint64())
+	}
+	recipientsBytes, err := lcs.Marshal(recipients)
+	if err != nil {
+		return &Tx{}, err
+	}
+	amountsBytes, err := lcs.Marshal(amounts)
+	if err != nil {
+		return &Tx{}, err
+	}
+
+	txInput := &multiInput.TxInput
+	var payload transactionbuilder.TransactionPayloadEntryFunction
+	if contract == "" {
+		payload = transactionbuilder.TransactionPayloadEntryFunction{
+			ModuleName:   *AptosModuleId,
+			FunctionName: "batch_transfer",
+			Args: [][]byte{
+				recipientsBytes, amountsBytes,
+			},
+		}
+	} else if txInput.GetTokenStandard(contract) == tx_input.TokenStandardCoin {
+		typeTag, err := transactionbuilder.NewTypeTagStructFromString(string(contract))
+		if err != nil {
+			return nil, err
+		}
+		payload = transactionbuilder.TransactionPayloadEntryFunction{
+			ModuleName:   *AptosModuleId,
+			FunctionName: "batch_transfer_coins",
+			TyArgs:       []transactionbuilder.TypeTag{*typeTag},
+			Args: [][]byte{
+				recipientsBytes, amountsBytes,
+			},
+		}
+	} else {
+		contractAddr, err := DecodeAddress(string(contract))
+		if err != nil {
+			return &Tx{}, err
+		}
+		payload = transactionbuilder.TransactionPayloadEntryFunction{
+			ModuleName:   *AptosModuleId,
+			FunctionName: "batch_transfer_fungible_assets",
+			TyArgs:       []transactionbuilder.TypeTag{},
+			Args: [][]byte{
+				contractAddr[:], recipientsBytes, amountsBytes,
+			},
+		}
+	}
+
+	tx := &Tx{
+		rawTx: transactionbuilder.RawTransaction{
+			Sender:         from_addr,
+			SequenceNumber: txInput.SequenceNumber,
+			Payload:        payload,
+			MaxGasAmount:   txInput.GasLimit,
+			GasUnitPrice:   txInput.GasPrice,
+			// ~1 hour expiration
+			ExpirationTimestampSecs: txInput.Timestamp + 60*60,
+			ChainId:                 uint8(txInput.ChainId),
+		},
+		Input: txInput,
+	}
+	if feePayer != from {
+		tx.extraFeePayer = feePayer
+	}
+	return tx, nil
+}
+
+// GetMultiTransferContract returns the asset of a multi-transfer, which must be the same for all receivers.
+// It's empty for the native asset.
+func GetMultiTransferContract(args xcbuilder.MultiTransferArgs) (xc.ContractAddress, error) {
+	receivers := args.Receivers()
+	if len(receivers) == 0 {
+		return "", errors.New("aptos multi-transfer requires at least one receiver")
+	}
+	contract, _ := receivers[0].GetContract()
+	for i, receiver := range receivers[1:] {
+		other, _ := receiver.GetContract()
+		if other != contract {
+			return "", fmt.Errorf("aptos multi-transfers must send one asset, receiver %d sends %s instead of %s", i+1, other, contract)
+		}
+	}
+	return contract, nil
+}
@@ -0,0 +1,67 @@
+package aptos
+
+import (
+	"errors"
+
+	transactionbuilder "github.com/coming-chat/go-aptos/transaction_builder"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/aptos/tx_input"
+)
+
+var _ xcbuilder.NftTransfer = TxBuilder{}
+
+// NftTransfer transfers ownership of a digital asset (token v2), which is an object.
+func (txBuilder TxBuilder) NftTransfer(args xcbuilder.NftTransferArgs, input xc.NftTransferTxInput) (xc.Tx, error) {
+	nftInput, ok := input.(*tx_input.NftTransferInput)
+	if !ok {
+		return &Tx{}, errors.New("xc.NftTransferTxInput is not from an aptos chain")
+	}
+	if args.GetStandard() != xcbuilder.NftStandardAptosDigitalAsset {
+		return &Tx{}, errors.New("unsupported nft standard for aptos")
+	}
+	from_addr, err := DecodeAddress(string(args.GetFrom()))
+	if err != nil {
+		return &Tx{}, err
+	}
+	to_addr, err := DecodeAddress(string(args.GetTo()))
+	if err != nil {
+		return &Tx{}, err
+	}
+	object_addr, err := DecodeAddress(args.GetTokenId())
+	if err != nil {
+		return &Tx{}, err
+	}
+	moduleName, err := transactionbuilder.NewModuleIdFromString("0x1::object")
+	if err != nil {
+		return &Tx{}, err
+	}
+	// Every object has an ObjectCore, so it can be used to transfer any digital asset
+	objectCore, err := transactionbuilder.NewTypeTagStructFromString("0x1::object::ObjectCore")
+	if err != nil {
+		return &Tx{}, err
+	}
+	payload := transactionbuilder.TransactionPayloadEntryFunction{
+		ModuleName:   *moduleName,
+		FunctionName: "transfer",
+		TyArgs:       []transactionbuilder.TypeTag{*objectCore},
+		Args: [][]byte{
+			object_addr[:], to_addr[:],
+		},
+	}
+
+	txInput := &nftInput.TxInput
+	return &Tx{
+		rawTx: transactionbuilder.RawTransaction{
+			Sender:         from_addr,
+			SequenceNumber: txInput.SequenceNumber,
+			Payload:        payload,
+			MaxGasAmount:   txInput.GasLimit,
+			GasUnitPrice:   txInput.GasPrice,
+			// ~1 hour expiration
+			ExpirationTimestampSecs: txInput.Timestamp + 60*60,
+			ChainId:                 uint8(txInput.ChainId),
+		},
+		Input: txInput,
+	}, nil
+}
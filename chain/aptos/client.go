@@ -6,9 +6,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/coming-chat/go-aptos/aptosclient"
@@ -34,6 +34,9 @@ var _ xclient.Client = &Client{}
 
 const DefaultGasLimit = 10000
 
+// Used when the node has no gas estimate, 0.000001 APT per gas unit
+const DefaultGasPrice = 100
+
 // NewClient returns a new Aptos Client
 func NewClient(cfgI *xc.ChainConfig) (*Client, error) {
 	cfg := cfgI.GetChain()
@@ -64,69 +67,83 @@ func reserialize(data interface{}, into interface{}) error {
 	return json.Unmarshal(bz, into)
 }
 
-type GasScheduleEntry struct {
-	Key string `json:"key"`
-	Val string `json:"val"`
-}
-
-type GasSchedule struct {
-	Entries []GasScheduleEntry `json:"entries"`
-}
-
-func (gasSchedule *GasSchedule) Get(key string) (uint64, error) {
-	for _, entry := range gasSchedule.Entries {
-		if entry.Key == key {
-			val, err := strconv.ParseUint(entry.Val, 10, 64)
-			if err != nil {
-				return 0, fmt.Errorf("could not parse %s: %s: %v", key, entry.Val, err)
-			}
-			return val, nil
-		}
+func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+	builder, err := NewTxBuilder(client.Asset.GetChain().Base())
+	if err != nil {
+		return &tx_input.TxInput{}, fmt.Errorf("could not create tx builder: %v", err)
 	}
-	return 0, fmt.Errorf("could not get %s", key)
+	publicKey, _ := args.GetPublicKey()
+	feePayerPublicKey, _ := args.GetFeePayerPublicKey()
+	contract, _ := args.GetContract()
+	return client.fetchSimulatedInput(ctx, args.GetFrom(), publicKey, feePayerPublicKey, contract, func(input *tx_input.TxInput) (xc.Tx, error) {
+		return builder.Transfer(args, input)
+	})
 }
 
-func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
-	ledger, err := client.AptosClient.LedgerInfo()
+var _ xclient.MultiTransferClient = &Client{}
+
+func (client *Client) FetchMultiTransferInput(ctx context.Context, args xcbuilder.MultiTransferArgs) (xc.MultiTransferInput, error) {
+	builder, err := NewTxBuilder(client.Asset.GetChain().Base())
 	if err != nil {
-		return &tx_input.TxInput{}, err
+		return nil, fmt.Errorf("could not create tx builder: %v", err)
 	}
-	acc, err := client.AptosClient.GetAccount(string(args.GetFrom()))
-	if err != nil {
-		return &tx_input.TxInput{}, err
+	spenders := args.Spenders()
+	if len(spenders) != 1 {
+		return nil, fmt.Errorf("only one spender is supported for aptos multi-transfers")
 	}
-	gasScheduleResource, err := client.AptosClient.GetAccountResource("0x1", "0x1::gas_schedule::GasScheduleV2", 0)
+	contract, err := GetMultiTransferContract(args)
 	if err != nil {
-		return &tx_input.TxInput{}, err
+		return nil, err
 	}
-	gas_price, err := client.EstimateGas(ctx, ledger)
+	publicKey := spenders[0].GetPublicKey()
+	feePayerPublicKey, _ := args.GetFeePayerPublicKey()
+	input, err := client.fetchSimulatedInput(ctx, spenders[0].GetFrom(), publicKey, feePayerPublicKey, contract, func(input *tx_input.TxInput) (xc.Tx, error) {
+		return builder.MultiTransfer(args, &tx_input.MultiTransferInput{TxInput: *input})
+	})
 	if err != nil {
-		return &tx_input.TxInput{}, err
+		return nil, err
 	}
+	return &tx_input.MultiTransferInput{TxInput: *input}, nil
+}
 
-	gasSchedule := GasSchedule{}
-	err = reserialize(gasScheduleResource.Data, &gasSchedule)
-	if err != nil {
-		return &tx_input.TxInput{}, err
+// Tokens are either legacy coins, identified by a move struct like `0x1::aptos_coin::AptosCoin`,
+// or fungible assets, identified by the address of their metadata object.
+func (client *Client) FetchTokenStandard(ctx context.Context, contract xc.ContractAddress) (tx_input.TokenStandard, error) {
+	if strings.Contains(string(contract), "::") {
+		if _, err := client.AptosClient.GetCoinInfo(string(contract)); err != nil {
+			return "", fmt.Errorf("could not get coin info for %s: %v", contract, err)
+		}
+		return tx_input.TokenStandardCoin, nil
 	}
-	scheduleMinGasUnits, err := gasSchedule.Get("txn.min_transaction_gas_units")
+	isFungibleAsset, err := client.AptosClient.IsAccountHasResource(string(contract), "0x1::fungible_asset::Metadata", 0)
 	if err != nil {
-		return &tx_input.TxInput{}, err
+		return "", fmt.Errorf("could not get fungible asset metadata for %s: %v", contract, err)
+	}
+	if !isFungibleAsset {
+		return "", fmt.Errorf("%s is neither a coin nor a fungible asset", contract)
 	}
-	scheduleMinGasPrice, err := gasSchedule.Get("txn.min_price_per_gas_unit")
+	return tx_input.TokenStandardFungibleAsset, nil
+}
+
+// Fetch the base input, and then simulate the tx built by buildTx to set an accurate gas limit.
+// Simulation is skipped if the public key is not known.
+func (client *Client) fetchSimulatedInput(ctx context.Context, from xc.Address, pubkey []byte, feePayerPublicKey []byte, contract xc.ContractAddress, buildTx func(input *tx_input.TxInput) (xc.Tx, error)) (*tx_input.TxInput, error) {
+	ledger, err := client.AptosClient.LedgerInfo()
 	if err != nil {
 		return &tx_input.TxInput{}, err
 	}
-	scheduleScalingFactor, err := gasSchedule.Get("txn.gas_unit_scaling_factor")
+	acc, err := client.AptosClient.GetAccount(string(from))
 	if err != nil {
 		return &tx_input.TxInput{}, err
 	}
-	calculatedMinGasUnits := uint64(math.Ceil(float64(scheduleMinGasUnits) / float64(scheduleScalingFactor)))
-
-	builder, err := NewTxBuilder(client.Asset.GetChain().Base())
+	gasPrice, err := client.AptosClient.EstimateGasPrice()
 	if err != nil {
-		return &tx_input.TxInput{}, fmt.Errorf("could not create tx builder: %v", err)
+		return &tx_input.TxInput{}, fmt.Errorf("could not estimate gas price: %v", err)
 	}
+	if gasPrice == 0 {
+		gasPrice = DefaultGasPrice
+	}
+
 	defaultGasLimit := DefaultGasLimit
 	if client.Asset.GetChain().GasLimitDefault > 0 {
 		defaultGasLimit = client.Asset.GetChain().GasLimitDefault
@@ -139,13 +156,20 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		ChainId:        ledger.ChainId,
 		GasLimit:       uint64(defaultGasLimit),
 		Timestamp:      ledger.LedgerTimestamp,
-		GasPrice:       gas_price.Uint64(),
+		GasPrice:       gasPrice,
+	}
+	isToken := contract != "" && !client.Asset.GetChain().IsChain(contract)
+	if isToken {
+		input.TokenStandard, err = client.FetchTokenStandard(ctx, contract)
+		if err != nil {
+			return &tx_input.TxInput{}, err
+		}
 	}
 
 	// If the public key is set, we can simulate the tx and get
 	// an accurate gas limit.
-	if pubkey, ok := args.GetPublicKey(); ok {
-		txI, err := builder.Transfer(args, input)
+	if len(pubkey) > 0 {
+		txI, err := buildTx(input)
 		if err != nil {
 			return &tx_input.TxInput{}, fmt.Errorf("could not create tx: %v", err)
 		}
@@ -162,10 +186,10 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 			zero[0] = byte(i)
 			privateKey := ed25519.NewKeyFromSeed(zero[:])
 			signatureData := ed25519.Sign(privateKey, hashes[0].Payload)
-			address := args.GetFrom()
+			address := from
 			publicKeyForSigner := pubkey
 			if hash.Signer != "" && hash.Signer != address {
-				publicKeyForSigner, _ = args.GetFeePayerPublicKey()
+				publicKeyForSigner = feePayerPublicKey
 				address = hash.Signer
 			}
 			signatures = append(signatures, &xc.SignatureResponse{
@@ -191,7 +215,7 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		log := logrus.WithFields(logrus.Fields{
 			"gas_limit":  input.GasLimit,
 			"public_key": hex.EncodeToString(pubkey),
-			"from":       args.GetFrom(),
+			"from":       from,
 		})
 		var success bool
 		if len(output) > 0 {
@@ -200,7 +224,7 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 			if success {
 				input.GasLimit = output[0].GasUsed
 				// increase limit by ~10% for tokens it can vary sometimes.
-				if _, ok := args.GetContract(); ok {
+				if isToken {
 					input.GasLimit = (input.GasLimit * 1100) / 1000
 				}
 			}
@@ -209,17 +233,10 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		log.WithField("success", success).Debug("simulated tx")
 	} else {
 		logrus.WithFields(logrus.Fields{
-			"from": args.GetFrom(),
+			"from": from,
 		}).Debug("cannot simulate tx, public key is not known")
 	}
-	estimatedGasPrice := input.GasPrice
 	estimatedGasLimit := input.GasLimit
-	if input.GasLimit < calculatedMinGasUnits {
-		input.GasLimit = scheduleMinGasUnits
-	}
-	if input.GasPrice < scheduleMinGasPrice {
-		input.GasPrice = scheduleMinGasPrice
-	}
 	if input.SequenceNumber == 0 {
 		// The estimated gas sometimes is too low for the first txn, so we add a buffer.
 		// Experimentally I found it was short by ~20 units, but couldn't find a good way to account for it,
@@ -227,15 +244,10 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		input.GasLimit += 250
 	}
 	logrus.WithFields(logrus.Fields{
-		"original_gas_limit":            estimatedGasLimit,
-		"gas_limit":                     input.GasLimit,
-		"original_gas_price":            estimatedGasPrice,
-		"gas_price":                     input.GasPrice,
-		"calculated_min_gas_units":      calculatedMinGasUnits,
-		"txn.min_transaction_gas_units": scheduleMinGasUnits,
-		"txn.min_price_per_gas_unit":    scheduleMinGasPrice,
-		"txn.gas_unit_scaling_factor":   scheduleScalingFactor,
-		"multiplier":                    client.Asset.GetChain().ChainGasMultiplier,
+		"original_gas_limit": estimatedGasLimit,
+		"gas_limit":          input.GasLimit,
+		"gas_price":          input.GasPrice,
+		"multiplier":         client.Asset.GetChain().ChainGasMultiplier,
 	}).Debug("gas limit")
 
 	gasMultiplier := client.Asset.GetChain().ChainGasMultiplier
@@ -402,66 +414,6 @@ func (client *Client) FetchNativeBalance(ctx context.Context, address xc.Address
 	return xc.AmountBlockchain(*balance), nil
 }
 
-func (client *Client) EstimateGas(ctx context.Context, ledgerInfo *aptostypes.LedgerInfo) (xc.AmountBlockchain, error) {
-
-	// estimate using last 1 blocks
-	zero := xc.NewAmountBlockchainFromUint64(0)
-	height := ledgerInfo.BlockHeight
-	if height < 500 {
-		return zero, fmt.Errorf("the chain is too young")
-	}
-	attempts := 10
-
-	// let's download the last 50 transactions
-	transactions := []aptostypes.Transaction{}
-	for len(transactions) < 50 && height > 0 {
-		block, err := client.AptosClient.GetBlockByHeight(fmt.Sprintf("%d", height), true)
-		height = height - 1
-		if err != nil {
-			// Sometimes a block doesn't exist..
-			// so we'll tolerate up to 10 times of this in a row.
-			attempts = attempts - 1
-			if attempts <= 0 {
-				return zero, err
-			}
-			continue
-		}
-		l1 := len(transactions)
-		for _, tx := range block.Transactions {
-			if tx.GasUnitPrice != 0 {
-				transactions = append(transactions, tx)
-			}
-		}
-		l2 := len(transactions)
-		if l1 == l2 {
-			// if the block was empty, count as a failed attempt so we will terminate
-			attempts = attempts - 1
-			if attempts <= 0 {
-				break
-			}
-			continue
-		}
-		attempts = 10
-	}
-	totalUnitPrice := uint64(0)
-	for _, tx := range transactions {
-		totalUnitPrice += tx.GasUnitPrice
-	}
-
-	// use default of 0.000001 fee per gas
-	if totalUnitPrice == 0 {
-		return xc.NewAmountBlockchainFromUint64(100), nil
-	}
-
-	averUnitPrice := float32(totalUnitPrice) / float32(len(transactions))
-	// pay 25% premium
-	// averUnitPrice = averUnitPrice * 1.25
-	// truncate
-	unit_price := xc.NewAmountBlockchainFromUint64(uint64(averUnitPrice))
-
-	return unit_price, nil
-}
-
 type FungibleAssetMetadata struct {
 	Decimals   int    `json:"decimals"`
 	IconUri    string `json:"icon_uri"`
@@ -520,3 +472,20 @@ func (client *Client) FetchBlock(ctx context.Context, args *xclient.BlockArgs) (
 	}
 	return block, nil
 }
+
+var _ xclient.NftClient = &Client{}
+
+func (client *Client) FetchNftTransferInput(ctx context.Context, args xcbuilder.NftTransferArgs) (xc.NftTransferTxInput, error) {
+	builder, err := NewTxBuilder(client.Asset.GetChain().Base())
+	if err != nil {
+		return nil, fmt.Errorf("could not create tx builder: %v", err)
+	}
+	publicKey, _ := args.GetPublicKey()
+	input, err := client.fetchSimulatedInput(ctx, args.GetFrom(), publicKey, nil, "", func(input *tx_input.TxInput) (xc.Tx, error) {
+		return builder.NftTransfer(args, &tx_input.NftTransferInput{TxInput: *input})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tx_input.NftTransferInput{TxInput: *input}, nil
+}
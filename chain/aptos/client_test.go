@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"testing"
 
+	transactionbuilder "github.com/coming-chat/go-aptos/transaction_builder"
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/builder/buildertest"
 	"github.com/cordialsys/crosschain/chain/aptos/tx_input"
 	"github.com/cordialsys/crosschain/client"
@@ -28,29 +30,8 @@ func (s *AptosTestSuite) TestNewClient() {
 	require.Nil(err)
 }
 
-// A subset of the gas schedule, just enough to test the gas estimation
-// See the full schedule here:
-// https://api.mainnet.aptoslabs.com/v1/accounts/0x1/resource/0x1::gas_schedule::GasScheduleV2
-var gasSchedule = `{
-  "type": "0x1::gas_schedule::GasScheduleV2",
-  "data": {
-    "entries": [
-      {
-        "key": "txn.min_transaction_gas_units",
-        "val": "2760000"
-      },
-      {
-        "key": "txn.min_price_per_gas_unit",
-        "val": "100"
-      },
-      {
-        "key": "txn.gas_unit_scaling_factor",
-        "val": "1000000"
-      }
-    ],
-    "feature_version": "36"
-  }
-}`
+// https://api.mainnet.aptoslabs.com/v1/estimate_gas_price
+var gasEstimate = `{"deprioritized_gas_estimate":100,"gas_estimate":100,"prioritized_gas_estimate":150}`
 
 func TestFetchTxInput(t *testing.T) {
 	require := require.New(t)
@@ -69,7 +50,7 @@ func TestFetchTxInput(t *testing.T) {
 				`{"chain_id":58,"epoch":"61","ledger_version":"3524910","oldest_ledger_version":"0","ledger_timestamp":"1683057860656414","node_role":"full_node","oldest_block_height":"0","block_height":"1317171","git_hash":"57f8b499aead5adf38276acb585cd2c0de398568"}`,
 				`{"chain_id":58,"epoch":"61","ledger_version":"3524910","oldest_ledger_version":"0","ledger_timestamp":"1683057860656414","node_role":"full_node","oldest_block_height":"0","block_height":"1317171","git_hash":"57f8b499aead5adf38276acb585cd2c0de398568"}`,
 				`{"sequence_number":"2","authentication_key":"0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f682"}`,
-				gasSchedule,
+				gasEstimate,
 			},
 			from: "0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f682",
 			input: &tx_input.TxInput{
@@ -89,7 +70,7 @@ func TestFetchTxInput(t *testing.T) {
 				`{"chain_id":58,"epoch":"61","ledger_version":"3524910","oldest_ledger_version":"0","ledger_timestamp":"1683057860656414","node_role":"full_node","oldest_block_height":"0","block_height":"1317171","git_hash":"57f8b499aead5adf38276acb585cd2c0de398568"}`,
 				`{"chain_id":58,"epoch":"61","ledger_version":"3524910","oldest_ledger_version":"0","ledger_timestamp":"1683057860656414","node_role":"full_node","oldest_block_height":"0","block_height":"1317171","git_hash":"57f8b499aead5adf38276acb585cd2c0de398568"}`,
 				`{"message":"Account not found by Address(0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f681) and Ledger version(3545185)","error_code":"account_not_found","vm_error_code":null}`,
-				gasSchedule,
+				gasEstimate,
 			},
 			from:  "0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f680",
 			input: &tx_input.TxInput{},
@@ -101,12 +82,6 @@ func TestFetchTxInput(t *testing.T) {
 		t.Run(fmt.Sprintf("case_%d", i), func(t *testing.T) {
 
 			resp := `{"chain_id":38,"epoch":"133","ledger_version":"13087045","oldest_ledger_version":"0","ledger_timestamp":"1669676013555573","node_role":"full_node","oldest_block_height":"0","block_height":"5435983","git_hash":"2c74a456298fcd520241a562119b6fe30abdaae2"}`
-
-			// satisfy the gas estimate to go with default value
-			blockNotFound := `{"message":"block not found","error_code":"block_not_found","vm_error_code":null}`
-			for i := 0; i < 20; i++ {
-				v.resp = append(v.resp, blockNotFound)
-			}
 			server, close := testtypes.MockHTTP(t, resp, 200)
 			asset := v.asset.GetChain()
 			asset.URL = server.URL
@@ -532,6 +507,21 @@ func (s *AptosTestSuite) TestNewTokenTransfer() {
 		hex.EncodeToString(ser),
 	)
 
+	// Setting the coin standard explicitly builds the same 0x1::aptos_account::transfer_coins tx
+	coinInput := *input
+	coinInput.TokenStandard = tx_input.TokenStandardCoin
+	tf, err = builder.NewTokenTransfer(from, from, to, amount, xc.ContractAddress("0x1::Coin::USDC"), &coinInput)
+	require.NoError(err)
+	err = tf.SetSignatures(&xc.SignatureResponse{
+		Signature: sig,
+		PublicKey: pubkey,
+		Address:   from,
+	})
+	require.NoError(err)
+	serCoin, err := tf.Serialize()
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(ser), hex.EncodeToString(serCoin))
+
 	// Use new fungible asset contract address
 	tf, err = builder.NewTokenTransfer(from, from, to, amount, xc.ContractAddress("0x112233445566778899112233445566778899112233445566778899"), input)
 	require.NoError(err)
@@ -543,8 +533,9 @@ func (s *AptosTestSuite) TestNewTokenTransfer() {
 	require.NoError(err)
 	ser2, err := tf.Serialize()
 	require.NoError(err)
+	// 0x1::primary_fungible_store::transfer<0x1::fungible_asset::Metadata>(metadata, to, amount)
 	require.Equal(
-		"a589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab8503000000000000000200000000000000000000000000000000000000000000000000000000000000010d6170746f735f6163636f756e74187472616e736665725f66756e6769626c655f617373657473000320000000000011223344556677889911223344556677889911223344556677889920bb89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab00080100000000000000d0070000000000000a000000000000009984000000000000010020010203040506070801020304050607080102030405060708010203040506070840000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+		"a589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab850300000000000000020000000000000000000000000000000000000000000000000000000000000001167072696d6172795f66756e6769626c655f73746f7265087472616e73666572010700000000000000000000000000000000000000000000000000000000000000010e66756e6769626c655f6173736574084d65746164617461000320000000000011223344556677889911223344556677889911223344556677889920bb89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab00080100000000000000d0070000000000000a000000000000009984000000000000010020010203040506070801020304050607080102030405060708010203040506070840000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
 		hex.EncodeToString(ser2),
 	)
 	require.NotEqual(ser, ser2)
@@ -606,3 +597,161 @@ func (s *AptosTestSuite) TestFeePayerNewTokenTransfer() {
 		hex.EncodeToString(ser),
 	)
 }
+
+func (s *AptosTestSuite) TestNewNftTransfer() {
+	require := s.Require()
+
+	asset := xc.NewChainConfig("APTOS").WithNet("devnet").WithDriver(xc.DriverAptos)
+	builder, _ := NewTxBuilder(asset.Base())
+	from := xc.Address("0xa589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab85")
+	to := xc.Address("0xbb89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab00")
+	object := "0xcc89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab11"
+	input := &tx_input.NftTransferInput{
+		TxInput: tx_input.TxInput{
+			TxInputEnvelope: *xc.NewTxInputEnvelope(xc.DriverAptos),
+			SequenceNumber:  3,
+			GasLimit:        2000,
+			GasPrice:        10,
+			Timestamp:       12345,
+			ChainId:         1,
+		},
+	}
+	args, err := xcbuilder.NewNftTransferArgs(asset.Base(), "", from, to, "", []xcbuilder.NftToken{xcbuilder.NewNftToken(object)})
+	require.NoError(err)
+	require.Equal(xcbuilder.NftStandardAptosDigitalAsset, args.GetStandard())
+
+	tf, err := builder.NftTransfer(args, input)
+	require.NoError(err)
+	payload := tf.(*Tx).rawTx.Payload.(transactionbuilder.TransactionPayloadEntryFunction)
+	require.EqualValues("transfer", payload.FunctionName)
+	require.EqualValues("object", payload.ModuleName.Name)
+	require.Len(payload.TyArgs, 1)
+	require.Len(payload.Args, 2)
+	require.Equal(object[2:], hex.EncodeToString(payload.Args[0]))
+	require.Equal(string(to[2:]), hex.EncodeToString(payload.Args[1]))
+}
+
+func (s *AptosTestSuite) TestNewMultiTransfer() {
+	require := s.Require()
+
+	asset := xc.NewChainConfig("APTOS").WithNet("devnet").WithDriver(xc.DriverAptos)
+	builder, _ := NewTxBuilder(asset.Base())
+	from := xc.Address("0xa589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab85")
+	to1 := xc.Address("0xbb89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab00")
+	to2 := xc.Address("0xcc89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab11")
+	input := tx_input.NewMultiTransferInput()
+	input.SequenceNumber = 3
+	input.GasLimit = 2000
+	input.GasPrice = 10
+	input.ChainId = 1
+
+	newArgs := func(options ...xcbuilder.BuilderOption) xcbuilder.MultiTransferArgs {
+		sender, err := xcbuilder.NewSender(from, []byte{})
+		require.NoError(err)
+		receiver1, err := xcbuilder.NewReceiver(to1, xc.NewAmountBlockchainFromUint64(1), options...)
+		require.NoError(err)
+		receiver2, err := xcbuilder.NewReceiver(to2, xc.NewAmountBlockchainFromUint64(2), options...)
+		require.NoError(err)
+		args, err := xcbuilder.NewMultiTransferArgs(asset.Base(), []*xcbuilder.Sender{sender}, []*xcbuilder.Receiver{receiver1, receiver2})
+		require.NoError(err)
+		return *args
+	}
+	// vector<address> and vector<u64>
+	recipients := "02" + string(to1[2:]) + string(to2[2:])
+	amounts := "02" + "0100000000000000" + "0200000000000000"
+
+	vectors := []struct {
+		contract      xc.ContractAddress
+		function      string
+		tyArgs        int
+		args          []string
+		fungibleAsset bool
+	}{
+		{"", "batch_transfer", 0, []string{recipients, amounts}, false},
+		{"0x1::Coin::USDC", "batch_transfer_coins", 1, []string{recipients, amounts}, false},
+		{
+			"0x112233445566778899112233445566778899112233445566778899", "batch_transfer_fungible_assets", 0,
+			[]string{"0000000000112233445566778899112233445566778899112233445566778899", recipients, amounts}, true,
+		},
+	}
+	for _, v := range vectors {
+		options := []xcbuilder.BuilderOption{}
+		if v.contract != "" {
+			options = append(options, xcbuilder.OptionContractAddress(v.contract))
+		}
+		tf, err := builder.MultiTransfer(newArgs(options...), input)
+		require.NoError(err)
+		payload := tf.(*Tx).rawTx.Payload.(transactionbuilder.TransactionPayloadEntryFunction)
+		require.EqualValues("aptos_account", payload.ModuleName.Name)
+		require.EqualValues(v.function, payload.FunctionName)
+		require.Len(payload.TyArgs, v.tyArgs)
+		require.Len(payload.Args, len(v.args))
+		for i, arg := range v.args {
+			require.Equal(arg, hex.EncodeToString(payload.Args[i]))
+		}
+	}
+
+	// all receivers must send the same asset
+	sender, _ := xcbuilder.NewSender(from, []byte{})
+	native, _ := xcbuilder.NewReceiver(to1, xc.NewAmountBlockchainFromUint64(1))
+	token, _ := xcbuilder.NewReceiver(to2, xc.NewAmountBlockchainFromUint64(1), xcbuilder.OptionContractAddress("0x1::Coin::USDC"))
+	args, err := xcbuilder.NewMultiTransferArgs(asset.Base(), []*xcbuilder.Sender{sender}, []*xcbuilder.Receiver{native, token})
+	require.NoError(err)
+	_, err = builder.MultiTransfer(*args, input)
+	require.ErrorContains(err, "must send one asset")
+
+	// amounts are u64 on chain and must not be truncated
+	tooLarge, _ := xcbuilder.NewReceiver(to1, xc.NewAmountBlockchainFromStr("18446744073709551616"))
+	args, err = xcbuilder.NewMultiTransferArgs(asset.Base(), []*xcbuilder.Sender{sender}, []*xcbuilder.Receiver{native, tooLarge})
+	require.NoError(err)
+	_, err = builder.MultiTransfer(*args, input)
+	require.ErrorContains(err, "does not fit in a u64")
+}
+
+func TestFetchTokenStandard(t *testing.T) {
+	vectors := []struct {
+		contract xc.ContractAddress
+		resp     string
+		status   int
+		standard tx_input.TokenStandard
+		err      string
+	}{
+		{
+			contract: "0x1::aptos_coin::AptosCoin",
+			resp:     `{"type":"0x1::coin::CoinInfo<0x1::aptos_coin::AptosCoin>","data":{"decimals":8,"name":"Aptos Coin","symbol":"APT","supply":{"vec":[]}}}`,
+			status:   200,
+			standard: tx_input.TokenStandardCoin,
+		},
+		{
+			contract: "0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b",
+			resp:     `{"type":"0x1::fungible_asset::Metadata","data":{"decimals":6,"icon_uri":"","name":"USDC","project_uri":"","symbol":"USDC"}}`,
+			status:   200,
+			standard: tx_input.TokenStandardFungibleAsset,
+		},
+		{
+			contract: "0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b",
+			resp:     `{"message":"Resource not found","error_code":"resource_not_found","vm_error_code":null}`,
+			status:   404,
+			err:      "neither a coin nor a fungible asset",
+		},
+	}
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("case_%d", i), func(t *testing.T) {
+			ledger := `{"chain_id":58,"epoch":"61","ledger_version":"3524910","oldest_ledger_version":"0","ledger_timestamp":"1683057860656414","node_role":"full_node","oldest_block_height":"0","block_height":"1317171","git_hash":"57f8b499aead5adf38276acb585cd2c0de398568"}`
+			server, close := testtypes.MockHTTP(t, ledger, 200)
+			defer close()
+			server.Response = []string{ledger, v.resp}
+			server.StatusCodes = []int{200, v.status}
+			client, err := NewClient(xc.NewChainConfig(xc.APTOS).WithUrl(server.URL))
+			require.NoError(t, err)
+
+			standard, err := client.FetchTokenStandard(context.Background(), v.contract)
+			if v.err != "" {
+				require.ErrorContains(t, err, v.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, v.standard, standard)
+			}
+		})
+	}
+}
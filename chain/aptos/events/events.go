@@ -37,6 +37,13 @@ type FungibleAssetEvent struct {
 	Store  string `json:"store"`
 }
 
+// Emitted when the owner of an object changes
+type ObjectTransferEvent struct {
+	Object string `json:"object"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
 func ParseEvents(tx *aptostypes.Transaction, txHash xc.TxHash) (sources []*txinfo.LegacyTxInfoEndpoint, destinations []*txinfo.LegacyTxInfoEndpoint, err error) {
 	log := logrus.WithField("txhash", txHash)
 
@@ -150,6 +157,41 @@ func ParseEvents(tx *aptostypes.Transaction, txHash xc.TxHash) (sources []*txinf
 			} else {
 				destinations = append(destinations, endpoint)
 			}
+		case "0x1::object::TransferEvent", "0x1::object::Transfer":
+			transfer := &ObjectTransferEvent{}
+			err := reserializeJson(event.Data, transfer)
+			if err != nil {
+				log.WithError(err).Error("could not deserialize object transfer event")
+				continue
+			}
+			// fungible stores are objects too, but their movements are reported by the fungible asset events
+			isFungibleStore := false
+			for _, change := range changes {
+				if change.Change.Address == transfer.Object {
+					if _, ok := change.AsFungibleStore(); ok {
+						isFungibleStore = true
+					}
+				}
+			}
+			if isFungibleStore {
+				continue
+			}
+			// digital assets (NFTs) are identified by their object address
+			eventMeta := txinfo.NewEventFromIndex(uint64(i), txinfo.MovementVariantNft)
+			sources = append(sources, &txinfo.LegacyTxInfoEndpoint{
+				ContractAddress: xc.ContractAddress(transfer.Object),
+				TokenId:         transfer.Object,
+				Address:         xc.Address(transfer.From),
+				Amount:          xc.NewAmountBlockchainFromUint64(1),
+				Event:           eventMeta,
+			})
+			destinations = append(destinations, &txinfo.LegacyTxInfoEndpoint{
+				ContractAddress: xc.ContractAddress(transfer.Object),
+				TokenId:         transfer.Object,
+				Address:         xc.Address(transfer.To),
+				Amount:          xc.NewAmountBlockchainFromUint64(1),
+				Event:           eventMeta,
+			})
 		default:
 			// skip / unknown.
 			log.WithField("event", event.Type).Debug("unknown event")
@@ -0,0 +1,25 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+)
+
+// MultiTransferInput is for a batch transfer of one asset to many receivers
+type MultiTransferInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &MultiTransferInput{}
+var _ xc.MultiTransferInput = &MultiTransferInput{}
+
+func NewMultiTransferInput() *MultiTransferInput {
+	return &MultiTransferInput{
+		TxInput: *NewTxInput(),
+	}
+}
+
+func (*MultiTransferInput) MultiTransfer() {}
+
+func (*MultiTransferInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewMultiTransferInputType(xc.DriverAptos, "batch")
+}
@@ -0,0 +1,18 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+)
+
+type NftTransferInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &NftTransferInput{}
+var _ xc.NftTransferTxInput = &NftTransferInput{}
+
+func (*NftTransferInput) NftTransferring() {}
+
+func (*NftTransferInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewNftTransferInputType(xc.DriverAptos, "digital-asset")
+}
@@ -1,11 +1,23 @@
 package tx_input
 
 import (
+	"strings"
+
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/factory/drivers/registry"
 	"github.com/shopspring/decimal"
 )
 
+// There are two token standards on Aptos
+type TokenStandard string
+
+const (
+	// Coins are identified by their type, e.g. `0x1::aptos_coin::AptosCoin`
+	TokenStandardCoin TokenStandard = "coin"
+	// Fungible assets are identified by the address of their metadata object
+	TokenStandardFungibleAsset TokenStandard = "fungible_asset"
+)
+
 type TxInput struct {
 	xc.TxInputEnvelope
 	SequenceNumber uint64 `json:"sequence_number,omitempty"`
@@ -13,12 +25,16 @@ type TxInput struct {
 	GasPrice       uint64 `json:"gas_price,omitempty"`
 	Timestamp      uint64 `json:"timestamp,omitempty"`
 	ChainId        int    `json:"chain_id,omitempty"`
+	// Standard of the token being transferred, if any. When not set, it's inferred from the contract.
+	TokenStandard TokenStandard `json:"token_standard,omitempty"`
 }
 
 var _ xc.TxInput = &TxInput{}
 
 func init() {
 	registry.RegisterTxBaseInput(&TxInput{})
+	registry.RegisterTxVariantInput(&NftTransferInput{})
+	registry.RegisterTxVariantInput(&MultiTransferInput{})
 }
 
 func NewTxInput() *TxInput {
@@ -28,6 +44,19 @@ func NewTxInput() *TxInput {
 		},
 	}
 }
+
+// GetTokenStandard returns the standard of a token contract
+func (input *TxInput) GetTokenStandard(contract xc.ContractAddress) TokenStandard {
+	if input.TokenStandard != "" {
+		return input.TokenStandard
+	}
+	// Fungible assets have a plain address, while coin types are namespaced
+	if strings.Contains(string(contract), "::") {
+		return TokenStandardCoin
+	}
+	return TokenStandardFungibleAsset
+}
+
 func (input *TxInput) GetDriver() xc.Driver {
 	return xc.DriverAptos
 }
@@ -15,6 +15,7 @@ import (
 
 	xc "github.com/cordialsys/crosschain"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
+	xccall "github.com/cordialsys/crosschain/call"
 	cantonaddress "github.com/cordialsys/crosschain/chain/canton/address"
 	cantonkc "github.com/cordialsys/crosschain/chain/canton/keycloak"
 	cantontx "github.com/cordialsys/crosschain/chain/canton/tx"
@@ -1821,16 +1822,26 @@ func (client *Client) prepareWalletOfferAccept(ctx context.Context, partyID stri
 	if created == nil || created.GetTemplateId() == nil {
 		return nil, fmt.Errorf("wallet offer contract is missing created event data")
 	}
+	cmd := buildWalletTransferOfferAcceptCommand(created.GetTemplateId(), created.GetContractId())
+	return client.prepareWalletOfferCommand(ctx, partyID, cmd)
+}
+
+func (client *Client) prepareWalletOfferCommand(ctx context.Context, partyID string, cmd *v2.Command) (*interactive.PrepareSubmissionResponse, error) {
 	commandID := cantonproto.NewCommandID()
 	synchronizerID, err := client.resolveSynchronizerID(ctx, partyID, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve synchronizer for wallet offer accept: %w", err)
+		return nil, fmt.Errorf("failed to resolve synchronizer for wallet offer %s: %w", cmd.GetExercise().GetChoice(), err)
 	}
-	cmd := buildWalletTransferOfferAcceptCommand(created.GetTemplateId(), created.GetContractId())
 	return client.LedgerClient.PrepareSubmissionRequest(ctx, cmd, commandID, partyID, synchronizerID)
 }
 
 func (client *Client) prepareTokenOfferAccept(ctx context.Context, partyID string, target *v2.ActiveContract) (*interactive.PrepareSubmissionResponse, error) {
+	return client.prepareTokenInstructionChoice(ctx, partyID, target, TokenInstructionAccept)
+}
+
+// prepareTokenInstructionChoice exercises a choice of the token-standard `TransferInstruction` interface,
+// using the registry's choice context when available and falling back to an empty context.
+func (client *Client) prepareTokenInstructionChoice(ctx context.Context, partyID string, target *v2.ActiveContract, choice TokenInstructionChoice) (*interactive.PrepareSubmissionResponse, error) {
 	created := target.GetCreatedEvent()
 	if created == nil {
 		return nil, fmt.Errorf("token offer contract is missing created event data")
@@ -1853,40 +1864,40 @@ func (client *Client) prepareTokenOfferAccept(ctx context.Context, partyID strin
 		if err != nil {
 			return nil, err
 		}
-		choiceContext, err := client.LedgerClient.GetTokenTransferInstructionAcceptContextAt(ctx, registryToken, registryBaseURL, created.GetContractId())
+		choiceContext, err := client.LedgerClient.GetTokenTransferInstructionChoiceContextAt(ctx, registryToken, registryBaseURL, created.GetContractId(), choice)
 		if err != nil {
 			return nil, err
 		}
 		packageMap, err := client.LedgerClient.ListKnownPackageIDsByName(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve package id map for token accept disclosures: %w", err)
+			return nil, fmt.Errorf("failed to resolve package id map for token %s disclosures: %w", choice, err)
 		}
 		disclosedContracts, registrySynchronizerID, err := tokenDisclosedContractsToProto(choiceContext.DisclosedContracts, packageMap)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert token accept disclosures: %w", err)
+			return nil, fmt.Errorf("failed to convert token %s disclosures: %w", choice, err)
 		}
-		cmd, err := buildTokenTransferInstructionAcceptCommand(transferPackageID, created.GetContractId(), choiceContext.ChoiceContextData)
+		cmd, err := buildTokenTransferInstructionCommand(transferPackageID, created.GetContractId(), choice, choiceContext.ChoiceContextData)
 		if err != nil {
 			return nil, err
 		}
 		commandID := cantonproto.NewCommandID()
 		synchronizerID, err := client.resolveSynchronizerID(ctx, partyID, registrySynchronizerID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve token accept synchronizer: %w", err)
+			return nil, fmt.Errorf("failed to resolve token %s synchronizer: %w", choice, err)
 		}
 		prepareReq := cantonproto.NewPrepareRequest(commandID, synchronizerID, []string{partyID}, []string{partyID}, []*v2.Command{cmd}, disclosedContracts)
 		return client.LedgerClient.PrepareSubmission(ctx, prepareReq)
 	}
 
 	tryFallback := func() (*interactive.PrepareSubmissionResponse, error) {
-		cmd, err := buildTokenTransferInstructionAcceptCommand(transferPackageID, created.GetContractId(), map[string]any{"values": map[string]any{}})
+		cmd, err := buildTokenTransferInstructionCommand(transferPackageID, created.GetContractId(), choice, map[string]any{"values": map[string]any{}})
 		if err != nil {
 			return nil, err
 		}
 		commandID := cantonproto.NewCommandID()
 		synchronizerID, err := client.resolveSynchronizerID(ctx, partyID, "")
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve token accept synchronizer: %w", err)
+			return nil, fmt.Errorf("failed to resolve token %s synchronizer: %w", choice, err)
 		}
 		prepareReq := cantonproto.NewPrepareRequest(commandID, synchronizerID, []string{partyID}, []string{partyID}, []*v2.Command{cmd}, nil)
 		return client.LedgerClient.PrepareSubmission(ctx, prepareReq)
@@ -1896,11 +1907,11 @@ func (client *Client) prepareTokenOfferAccept(ctx context.Context, partyID strin
 	if err == nil {
 		return prepareResp, nil
 	}
-	client.logger().WithError(err).WithField("contract_id", created.GetContractId()).Warn("registry-backed token accept preparation failed, falling back to ledger-only accept")
+	client.logger().WithError(err).WithField("contract_id", created.GetContractId()).Warnf("registry-backed token %s preparation failed, falling back to ledger-only %s", choice, choice)
 
 	prepareResp, fallbackErr := tryFallback()
 	if fallbackErr != nil {
-		return nil, fmt.Errorf("failed token offer accept via registry (%v) and fallback (%w)", err, fallbackErr)
+		return nil, fmt.Errorf("failed token offer %s via registry (%v) and fallback (%w)", choice, err, fallbackErr)
 	}
 	return prepareResp, nil
 }
@@ -2046,7 +2057,22 @@ func (client *Client) FetchCallInput(ctx context.Context, call xc.TxCall, args x
 	}
 
 	templateID := created.GetTemplateId()
-	prepareResp, err := client.prepareCallForTemplate(ctx, partyID, target, contracts)
+	var prepareResp *interactive.PrepareSubmissionResponse
+	switch call.GetMethod() {
+	case xccall.OfferReject:
+		prepareResp, err = client.prepareOfferReject(ctx, partyID, target)
+	case xccall.OfferWithdraw:
+		var payload xccall.OfferCall
+		if err := json.Unmarshal(call.GetMsg(), &payload); err != nil {
+			return nil, fmt.Errorf("could not parse offer withdraw call: %w", err)
+		}
+		prepareResp, err = client.prepareOfferWithdraw(ctx, partyID, target, payload.Reason)
+	case xccall.OfferExpire:
+		prepareResp, err = client.prepareOfferExpire(ctx, partyID, target, time.Now())
+	default:
+		// accepting offers and completing settlements are determined by the contract
+		prepareResp, err = client.prepareCallForTemplate(ctx, partyID, target, contracts)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("unsupported Canton call target %s (%s:%s): %w", contractID, templateID.GetModuleName(), templateID.GetEntityName(), err)
 	}
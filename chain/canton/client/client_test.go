@@ -1191,6 +1191,160 @@ func TestFetchCallInputReturnsUnsupportedTargetError(t *testing.T) {
 	require.ErrorContains(t, err, "unsupported Canton call target")
 }
 
+func newOfferActionTestClient(contracts ...*v2.ActiveContract) (*Client, *interactiveSubmissionStub) {
+	responses := make([]*v2.GetActiveContractsResponse, 0, len(contracts))
+	for _, contract := range contracts {
+		responses = append(responses, activeContractResponse(contract))
+	}
+	interactiveStub := &interactiveSubmissionStub{prepareResp: &interactive.PrepareSubmissionResponse{
+		PreparedTransaction:  &interactive.PreparedTransaction{Transaction: &interactive.DamlTransaction{}},
+		HashingSchemeVersion: interactive.HashingSchemeVersion_HASHING_SCHEME_VERSION_V2,
+	}}
+	client := &Client{
+		Asset: &xc.ChainConfig{
+			ChainBaseConfig:   &xc.ChainBaseConfig{Chain: xc.CANTON, Driver: xc.DriverCanton},
+			ChainClientConfig: &xc.ChainClientConfig{},
+		},
+		LedgerClient: &GrpcLedgerClient{
+			AuthToken:                   "token",
+			StateClient:                 &stateServiceStub{ledgerEnd: 7, activeContractsResponses: responses},
+			InteractiveSubmissionClient: interactiveStub,
+			Logger:                      logrus.NewEntry(logrus.New()),
+		},
+	}
+	return client, interactiveStub
+}
+
+func fetchOfferActionInput(t *testing.T, client *Client, method xccall.Method, contractID string, reason string, party string) error {
+	payload, err := json.Marshal(xccall.OfferCall{ContractID: contractID, Reason: reason})
+	require.NoError(t, err)
+	callTx, err := cantoncall.NewCall(client.Asset.Base(), method, payload, []xc.Address{xc.Address(party)})
+	require.NoError(t, err)
+	callArgs, err := builder.NewCallArgs(client.Asset.Base())
+	require.NoError(t, err)
+	_, err = client.FetchCallInput(context.Background(), callTx, callArgs)
+	return err
+}
+
+func TestFetchCallInputOfferReject(t *testing.T) {
+	t.Parallel()
+
+	sender := "sender::1220aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	receiver := "receiver::1220bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	client, interactiveStub := newOfferActionTestClient(
+		testWalletOfferContract("wallet-offer-cid", "TransferOffer", sender, receiver, cantonAmuletOfferAmount("12.5"), "tracking", time.Unix(1710000000, 0).UTC()),
+		testWalletTransferRecordContract("settlement-cid", "AcceptedTransferOffer", sender, receiver, "issuer-party", "XC", "1.0", "tracking", time.Unix(1710000000, 0).UTC()),
+	)
+
+	require.NoError(t, fetchOfferActionInput(t, client, xccall.OfferReject, "wallet-offer-cid", "", receiver))
+	exercise := interactiveStub.lastPrepareReq.GetCommands()[0].GetExercise()
+	require.Equal(t, "wallet-offer-cid", exercise.GetContractId())
+	require.Equal(t, "TransferOffer_Reject", exercise.GetChoice())
+	require.Equal(t, []string{receiver}, interactiveStub.lastPrepareReq.GetActAs())
+
+	err := fetchOfferActionInput(t, client, xccall.OfferReject, "wallet-offer-cid", "", sender)
+	require.ErrorContains(t, err, "only the receiver")
+
+	err = fetchOfferActionInput(t, client, xccall.OfferReject, "settlement-cid", "", receiver)
+	require.ErrorContains(t, err, "unsupported template for rejecting")
+}
+
+func TestFetchCallInputOfferRejectTokenInstruction(t *testing.T) {
+	t.Parallel()
+
+	sender := "sender::1220aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	receiver := "receiver::1220bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	InstrumentAdmin := "cbtc-network::12201b1741b63e2494e4214cf0bedc3d5a224da53b3bf4d76dba468f8e97eb15508f"
+	client, interactiveStub := newOfferActionTestClient(
+		testUtilitiesTransferOfferContract("utility-offer-cid", sender, receiver, InstrumentAdmin, "CBTC", "0.0010000000", time.Unix(1710003600, 0).UTC()),
+	)
+	client.CantonCfg = &CantonConfig{
+		TokenRegistryURLs: map[cantonclientconfig.TokenRegistryKey]string{
+			cantonclientconfig.TokenRegistryKey(InstrumentAdmin + "#CBTC"): "https://utilities.example/registrar",
+		},
+	}
+	client.LedgerClient.PackageManagementClient = &packageManagementStub{
+		resp: &admin.ListKnownPackagesResponse{
+			PackageDetails: []*admin.PackageDetails{
+				{Name: "splice-api-token-transfer-instruction-v1", PackageId: "transfer-package-id"},
+			},
+		},
+	}
+	expectedChoice := "reject"
+	client.LedgerClient.HttpClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "https://utilities.example/registrar/registry/transfer-instruction/v1/utility-offer-cid/choice-contexts/"+expectedChoice, req.URL.String())
+		return httpJSONResponse(http.StatusOK, `{"choiceContextData":{"values":{}},"disclosedContracts":[]}`), nil
+	})}
+
+	require.NoError(t, fetchOfferActionInput(t, client, xccall.OfferReject, "utility-offer-cid", "", receiver))
+	exercise := interactiveStub.lastPrepareReq.GetCommands()[0].GetExercise()
+	require.Equal(t, "utility-offer-cid", exercise.GetContractId())
+	require.Equal(t, "TransferInstruction_Reject", exercise.GetChoice())
+	require.Equal(t, "Splice.Api.Token.TransferInstructionV1", exercise.GetTemplateId().GetModuleName())
+
+	// an expired instruction is expired by rejecting it, or withdrawing it when it's our own
+	require.NoError(t, fetchOfferActionInput(t, client, xccall.OfferExpire, "utility-offer-cid", "", receiver))
+	require.Equal(t, "TransferInstruction_Reject", interactiveStub.lastPrepareReq.GetCommands()[0].GetExercise().GetChoice())
+	expectedChoice = "withdraw"
+	require.NoError(t, fetchOfferActionInput(t, client, xccall.OfferExpire, "utility-offer-cid", "", sender))
+	require.Equal(t, "TransferInstruction_Withdraw", interactiveStub.lastPrepareReq.GetCommands()[0].GetExercise().GetChoice())
+
+	err := fetchOfferActionInput(t, client, xccall.OfferExpire, "utility-offer-cid", "", "other::1220cccc")
+	require.ErrorContains(t, err, "only the sender")
+}
+
+func TestFetchCallInputOfferWithdraw(t *testing.T) {
+	t.Parallel()
+
+	sender := "sender::1220aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	receiver := "receiver::1220bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	client, interactiveStub := newOfferActionTestClient(
+		testWalletOfferContract("wallet-offer-cid", "TransferOffer", sender, receiver, cantonAmuletOfferAmount("12.5"), "tracking", time.Unix(1710000000, 0).UTC()),
+		testWalletTransferRecordContract("settlement-cid", "AcceptedTransferOffer", sender, receiver, "issuer-party", "XC", "1.0", "tracking", time.Unix(1710000000, 0).UTC()),
+	)
+
+	require.NoError(t, fetchOfferActionInput(t, client, xccall.OfferWithdraw, "wallet-offer-cid", "sent to the wrong party", sender))
+	exercise := interactiveStub.lastPrepareReq.GetCommands()[0].GetExercise()
+	require.Equal(t, "TransferOffer_Withdraw", exercise.GetChoice())
+	reason, ok := GetRecordFieldValue(exercise.GetChoiceArgument().GetRecord(), "reason")
+	require.True(t, ok)
+	require.Equal(t, "sent to the wrong party", reason.GetText())
+
+	err := fetchOfferActionInput(t, client, xccall.OfferWithdraw, "wallet-offer-cid", "", receiver)
+	require.ErrorContains(t, err, "only the sender")
+
+	// accepted offers are aborted by the sender, or withdrawn by the receiver
+	require.NoError(t, fetchOfferActionInput(t, client, xccall.OfferWithdraw, "settlement-cid", "", sender))
+	require.Equal(t, "AcceptedTransferOffer_Abort", interactiveStub.lastPrepareReq.GetCommands()[0].GetExercise().GetChoice())
+	require.NoError(t, fetchOfferActionInput(t, client, xccall.OfferWithdraw, "settlement-cid", "", receiver))
+	require.Equal(t, "AcceptedTransferOffer_Withdraw", interactiveStub.lastPrepareReq.GetCommands()[0].GetExercise().GetChoice())
+}
+
+func TestFetchCallInputOfferExpire(t *testing.T) {
+	t.Parallel()
+
+	sender := "sender::1220aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	receiver := "receiver::1220bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	client, interactiveStub := newOfferActionTestClient(
+		testWalletOfferContract("expired-cid", "TransferOffer", sender, receiver, cantonAmuletOfferAmount("12.5"), "tracking", time.Unix(1710000000, 0).UTC()),
+		testWalletOfferContract("pending-cid", "TransferOffer", sender, receiver, cantonAmuletOfferAmount("12.5"), "tracking", time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)),
+		testWalletTransferRecordContract("settlement-cid", "AcceptedTransferOffer", sender, receiver, "issuer-party", "XC", "1.0", "tracking", time.Unix(1710000000, 0).UTC()),
+	)
+
+	require.NoError(t, fetchOfferActionInput(t, client, xccall.OfferExpire, "expired-cid", "", receiver))
+	exercise := interactiveStub.lastPrepareReq.GetCommands()[0].GetExercise()
+	require.Equal(t, "TransferOffer_Expire", exercise.GetChoice())
+	actor, ok := GetRecordFieldValue(exercise.GetChoiceArgument().GetRecord(), "actor")
+	require.True(t, ok)
+	require.Equal(t, receiver, actor.GetParty())
+
+	require.NoError(t, fetchOfferActionInput(t, client, xccall.OfferExpire, "settlement-cid", "", sender))
+	require.Equal(t, "AcceptedTransferOffer_Expire", interactiveStub.lastPrepareReq.GetCommands()[0].GetExercise().GetChoice())
+
+	err := fetchOfferActionInput(t, client, xccall.OfferExpire, "pending-cid", "", sender)
+	require.ErrorContains(t, err, "has not expired yet")
+}
+
 func TestFetchCallInputReturnsNotVisibleError(t *testing.T) {
 	t.Parallel()
 
@@ -340,6 +340,52 @@ func buildWalletTransferOfferAcceptCommand(templateID *v2.Identifier, contractID
 	}
 }
 
+// The receiver declines a wallet transfer offer
+func buildWalletTransferOfferRejectCommand(templateID *v2.Identifier, contractID string) *v2.Command {
+	return &v2.Command{
+		Command: &v2.Command_Exercise{
+			Exercise: &v2.ExerciseCommand{
+				TemplateId:     templateID,
+				ContractId:     contractID,
+				Choice:         "TransferOffer_Reject",
+				ChoiceArgument: cantonproto.EmptyRecordValue(),
+			},
+		},
+	}
+}
+
+// Cancels a wallet offer, using `TransferOffer_Withdraw`, `AcceptedTransferOffer_Withdraw` or `AcceptedTransferOffer_Abort`
+func buildWalletOfferWithdrawCommand(templateID *v2.Identifier, contractID string, choice string, reason string) *v2.Command {
+	return &v2.Command{
+		Command: &v2.Command_Exercise{
+			Exercise: &v2.ExerciseCommand{
+				TemplateId: templateID,
+				ContractId: contractID,
+				Choice:     choice,
+				ChoiceArgument: cantonproto.RecordValue(
+					cantonproto.Field("reason", cantonproto.TextValue(reason)),
+				),
+			},
+		},
+	}
+}
+
+// Archives a wallet offer past its expiry, using `TransferOffer_Expire` or `AcceptedTransferOffer_Expire`
+func buildWalletOfferExpireCommand(templateID *v2.Identifier, contractID string, choice string, actor string) *v2.Command {
+	return &v2.Command{
+		Command: &v2.Command_Exercise{
+			Exercise: &v2.ExerciseCommand{
+				TemplateId: templateID,
+				ContractId: contractID,
+				Choice:     choice,
+				ChoiceArgument: cantonproto.RecordValue(
+					cantonproto.Field("actor", cantonproto.PartyValue(actor)),
+				),
+			},
+		},
+	}
+}
+
 func BuildTransferPreapprovalExerciseCommand(
 	args xcbuilder.TransferArgs,
 	amuletRules AmuletRules,
@@ -611,14 +657,15 @@ func BuildTokenStandardTransferCommandForInstrument(
 	}, nil
 }
 
-func buildTokenTransferInstructionAcceptCommand(
+func buildTokenTransferInstructionCommand(
 	transferPackageID string,
 	contractID string,
+	choice TokenInstructionChoice,
 	choiceContextData map[string]any,
 ) (*v2.Command, error) {
 	choiceContextValue, err := tokenChoiceContextToValue(choiceContextData)
 	if err != nil {
-		return nil, fmt.Errorf("build token accept choice context: %w", err)
+		return nil, fmt.Errorf("build token %s choice context: %w", choice, err)
 	}
 
 	return &v2.Command{
@@ -630,7 +677,7 @@ func buildTokenTransferInstructionAcceptCommand(
 					EntityName: "TransferInstruction",
 				},
 				ContractId: contractID,
-				Choice:     "TransferInstruction_Accept",
+				Choice:     choice.DamlChoice(),
 				ChoiceArgument: cantonproto.RecordValue(
 					cantonproto.Field("extraArgs", cantonproto.RecordValue(
 						cantonproto.Field("context", choiceContextValue),
@@ -104,6 +104,28 @@ func isHTTPStatus(err error, statusCode int) bool {
 	return errors.As(err, &statusErr) && statusErr.statusCode == statusCode
 }
 
+// Choices of a token-standard transfer instruction, named as in the registry's choice-context API
+type TokenInstructionChoice string
+
+const (
+	TokenInstructionAccept   TokenInstructionChoice = "accept"
+	TokenInstructionReject   TokenInstructionChoice = "reject"
+	TokenInstructionWithdraw TokenInstructionChoice = "withdraw"
+)
+
+// Choice name on the `TransferInstruction` interface
+func (choice TokenInstructionChoice) DamlChoice() string {
+	switch choice {
+	case TokenInstructionAccept:
+		return "TransferInstruction_Accept"
+	case TokenInstructionReject:
+		return "TransferInstruction_Reject"
+	case TokenInstructionWithdraw:
+		return "TransferInstruction_Withdraw"
+	}
+	return ""
+}
+
 type TokenChoiceContext struct {
 	ChoiceContextData  map[string]any                   `json:"choiceContextData"`
 	DisclosedContracts []TokenRegistryDisclosedContract `json:"disclosedContracts"`
@@ -1288,20 +1310,23 @@ func (c *GrpcLedgerClient) GetTokenMetadataRegistryInfoAt(
 	return &result, nil
 }
 
-func (c *GrpcLedgerClient) GetTokenTransferInstructionAcceptContextAt(
+// GetTokenTransferInstructionChoiceContextAt fetches the registry context for exercising
+// a choice ("accept", "reject" or "withdraw") on a token-standard transfer instruction.
+func (c *GrpcLedgerClient) GetTokenTransferInstructionChoiceContextAt(
 	ctx context.Context,
 	token string,
 	registryBaseURL string,
 	contractID string,
+	choice TokenInstructionChoice,
 ) (*TokenChoiceContext, error) {
 	if contractID == "" {
 		return nil, errors.New("empty contract id")
 	}
-	path := fmt.Sprintf("/registry/transfer-instruction/v1/%s/choice-contexts/accept", url.PathEscape(contractID))
+	path := fmt.Sprintf("/registry/transfer-instruction/v1/%s/choice-contexts/%s", url.PathEscape(contractID), choice)
 	var result TokenChoiceContext
 	useProxy := strings.TrimRight(registryBaseURL, "/") == strings.TrimRight(c.ScanAPIURL, "/") && c.ScanProxyURL != ""
 	if err := c.doRegistryRequestWithMethod(ctx, token, registryBaseURL, useProxy, http.MethodPost, path, map[string]any{}, &result); err != nil {
-		return nil, fmt.Errorf("fetching token transfer instruction accept context: %w", err)
+		return nil, fmt.Errorf("fetching token transfer instruction %s context: %w", choice, err)
 	}
 	return &result, nil
 }
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v2 "github.com/cordialsys/crosschain/chain/canton/types/com/daml/ledger/api/v2"
+	"github.com/cordialsys/crosschain/chain/canton/types/com/daml/ledger/api/v2/interactive"
+)
+
+// prepareOfferReject declines an incoming wallet transfer offer or token-standard transfer instruction.
+func (client *Client) prepareOfferReject(ctx context.Context, partyID string, target *v2.ActiveContract) (*interactive.PrepareSubmissionResponse, error) {
+	created := target.GetCreatedEvent()
+	templateID := created.GetTemplateId()
+	_, to, _, _, _, _, ok := extractLedgerOffer(created)
+	if !ok {
+		return nil, fmt.Errorf("could not extract transfer fields")
+	}
+	if string(to) != partyID {
+		return nil, fmt.Errorf("only the receiver %s can reject the offer", to)
+	}
+
+	switch {
+	case isWalletTransferOfferTemplate(templateID):
+		cmd := buildWalletTransferOfferRejectCommand(templateID, created.GetContractId())
+		return client.prepareWalletOfferCommand(ctx, partyID, cmd)
+	case isTokenTransferOfferTemplate(templateID):
+		return client.prepareTokenInstructionChoice(ctx, partyID, target, TokenInstructionReject)
+	}
+	return nil, fmt.Errorf("unsupported template for rejecting")
+}
+
+// prepareOfferWithdraw cancels an offer. The sender may withdraw its outgoing offers, including accepted
+// offers pending settlement, and the receiver may withdraw its acceptance of an offer.
+func (client *Client) prepareOfferWithdraw(ctx context.Context, partyID string, target *v2.ActiveContract, reason string) (*interactive.PrepareSubmissionResponse, error) {
+	created := target.GetCreatedEvent()
+	templateID := created.GetTemplateId()
+	from, to, _, _, _, _, ok := extractLedgerOffer(created)
+	if !ok {
+		return nil, fmt.Errorf("could not extract transfer fields")
+	}
+	isSender := string(from) == partyID
+
+	switch {
+	case isWalletTransferOfferTemplate(templateID):
+		if !isSender {
+			return nil, fmt.Errorf("only the sender %s can withdraw the offer", from)
+		}
+		cmd := buildWalletOfferWithdrawCommand(templateID, created.GetContractId(), "TransferOffer_Withdraw", reason)
+		return client.prepareWalletOfferCommand(ctx, partyID, cmd)
+	case isTokenTransferOfferTemplate(templateID):
+		if !isSender {
+			return nil, fmt.Errorf("only the sender %s can withdraw the offer", from)
+		}
+		return client.prepareTokenInstructionChoice(ctx, partyID, target, TokenInstructionWithdraw)
+	case isSettlementTemplate(templateID):
+		choice := "AcceptedTransferOffer_Abort"
+		if !isSender {
+			if string(to) != partyID {
+				return nil, fmt.Errorf("only the sender %s or receiver %s can withdraw the accepted offer", from, to)
+			}
+			choice = "AcceptedTransferOffer_Withdraw"
+		}
+		cmd := buildWalletOfferWithdrawCommand(templateID, created.GetContractId(), choice, reason)
+		return client.prepareWalletOfferCommand(ctx, partyID, cmd)
+	}
+	return nil, fmt.Errorf("unsupported template for withdrawing")
+}
+
+// prepareOfferExpire archives a wallet offer, or accepted offer, that is past its expiry.
+// Token-standard transfer instructions have no expiry choice, so once expired they are
+// rejected by the receiver or withdrawn by the sender instead.
+func (client *Client) prepareOfferExpire(ctx context.Context, partyID string, target *v2.ActiveContract, now time.Time) (*interactive.PrepareSubmissionResponse, error) {
+	created := target.GetCreatedEvent()
+	templateID := created.GetTemplateId()
+
+	var choice string
+	switch {
+	case isWalletTransferOfferTemplate(templateID):
+		choice = "TransferOffer_Expire"
+	case isSettlementTemplate(templateID):
+		choice = "AcceptedTransferOffer_Expire"
+	case isTokenTransferOfferTemplate(templateID):
+		// rejected or withdrawn once expired
+	default:
+		return nil, fmt.Errorf("unsupported template for expiring")
+	}
+
+	from, to, _, _, expiresAt, _, ok := extractLedgerOffer(created)
+	if !ok {
+		return nil, fmt.Errorf("could not extract transfer fields")
+	}
+	if expiresAt == nil {
+		return nil, fmt.Errorf("offer has no expiry")
+	}
+	if now.Before(*expiresAt) {
+		return nil, fmt.Errorf("offer has not expired yet, it expires at %s", expiresAt.Format(time.RFC3339))
+	}
+
+	if isTokenTransferOfferTemplate(templateID) {
+		switch partyID {
+		case string(to):
+			return client.prepareTokenInstructionChoice(ctx, partyID, target, TokenInstructionReject)
+		case string(from):
+			return client.prepareTokenInstructionChoice(ctx, partyID, target, TokenInstructionWithdraw)
+		}
+		return nil, fmt.Errorf("only the sender %s or receiver %s can expire the token transfer instruction", from, to)
+	}
+	cmd := buildWalletOfferExpireCommand(templateID, created.GetContractId(), choice, partyID)
+	return client.prepareWalletOfferCommand(ctx, partyID, cmd)
+}
@@ -14,6 +14,7 @@ type TxInput = cardanoinput.TxInput
 
 var _ xcbuilder.FullTransferBuilder = &TxBuilder{}
 var _ xcbuilder.Staking = &TxBuilder{}
+var _ xcbuilder.MultiTransfer = &TxBuilder{}
 
 // NewTxBuilder creates a new Template TxBuilder
 func NewTxBuilder(cfgI *xc.ChainBaseConfig) (TxBuilder, error) {
@@ -25,6 +26,11 @@ func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInpu
 	return tx.NewTransfer(args, input)
 }
 
+// MultiTransfer sends lovelace and native assets to several receivers, one output per receiver address
+func (txBuilder TxBuilder) MultiTransfer(args xcbuilder.MultiTransferArgs, input xc.MultiTransferInput) (xc.Tx, error) {
+	return tx.NewMultiTransfer(args, input)
+}
+
 func (txBuilder TxBuilder) Stake(args xcbuilder.StakeArgs, input xc.StakeTxInput) (xc.Tx, error) {
 	return tx.NewStake(args, input)
 }
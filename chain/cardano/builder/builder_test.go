@@ -82,3 +82,84 @@ func TestNewNativeTransfer(t *testing.T) {
 
 	require.Zero(t, xcInputAmount.Uint64())
 }
+
+func TestMultiTransfer(t *testing.T) {
+	cfg := xc.NewChainConfig(xc.ADA).WithNet("preprod").WithDecimals(6)
+	fromAddr := xc.Address("addr_test1vzjddf57t45k7a04kpr65lakpjmx50pwy7v0eje3t73c02s5zecy5")
+	toAddr1 := xc.Address("addr_test1qrfp5xelv2mu7k8zyvwm0c8t5xm55wanwhtd4fgjgtf3ck0rplhn7x9jyhwqg70fwv0ujpmyumqk5td9e9hnsejtlxnq3yqf25")
+	toAddr2 := xc.Address("addr_test1qzyf6t5qq037n0srm4r84w3hchgvmgu45ks9pf5f27qhnykxp572eea783cccv2fmpqs5s6va4n7pusy097meenje88s6p7x42")
+	tokenA := xc.ContractAddress("2682a9b99553406c39f693bb450d6001954e3504c96238c6b96ad79a474554")
+	tokenB := xc.ContractAddress("553cbd1862bfb5ff69b6ebdcbb6165beb47171a04b841c79c72e75a94e4654")
+
+	sender, err := xcbuilder.NewSender(fromAddr, make([]byte, 32))
+	require.NoError(t, err)
+	receivers := []*xcbuilder.Receiver{}
+	for _, receiver := range []struct {
+		to       xc.Address
+		amount   uint64
+		contract xc.ContractAddress
+	}{
+		{toAddr1, 2_000_000, ""},
+		{toAddr1, 40, tokenA},
+		{toAddr2, 5, tokenB},
+	} {
+		options := []xcbuilder.BuilderOption{}
+		if receiver.contract != "" {
+			options = append(options, xcbuilder.OptionContractAddress(receiver.contract))
+		}
+		r, err := xcbuilder.NewReceiver(receiver.to, xc.NewAmountBlockchainFromUint64(receiver.amount), options...)
+		require.NoError(t, err)
+		receivers = append(receivers, r)
+	}
+	args, err := xcbuilder.NewMultiTransferArgs(cfg.Base(), []*xcbuilder.Sender{sender}, receivers, xcbuilder.OptionMemo("batch"))
+	require.NoError(t, err)
+
+	input := &tx_input.MultiTransferInput{
+		TxInput: tx_input.TxInput{
+			Utxos: []types.Utxo{
+				{
+					Address: string(fromAddr),
+					Amounts: []types.Amount{
+						{Unit: "lovelace", Quantity: "10000000"},
+						{Unit: string(tokenA), Quantity: "100"},
+						{Unit: string(tokenB), Quantity: "5"},
+					},
+					TxHash: "72cfa181469b48402a50c6652d45c789897ae5025bb01f569a7bd01bffd12bc1",
+					Index:  1,
+				},
+			},
+			Slot:           90_751_416,
+			Fee:            200_000,
+			ProtocolParams: types.ProtocolParameters{CoinsPerUtxoSize: "4310"},
+		},
+	}
+
+	builder, err := builder.NewTxBuilder(cfg.Base())
+	require.NoError(t, err)
+	transfer, err := builder.MultiTransfer(*args, input)
+	require.NoError(t, err)
+	cardanoTx := transfer.(*tx.Tx)
+	require.NotNil(t, cardanoTx.Metadata)
+	require.NotNil(t, cardanoTx.Body.AuxiliaryDataHash)
+
+	// one output per receiver address, then the change
+	require.Len(t, cardanoTx.Body.Outputs, 3)
+	bundle1, bundle2, change := cardanoTx.Body.Outputs[0], cardanoTx.Body.Outputs[1], cardanoTx.Body.Outputs[2]
+	require.EqualValues(t, 2_000_000, bundle1.TokenAmounts.NativeAmount)
+	require.EqualValues(t, 40, bundle1.TokenAmounts.GetContractAmount(tokenA))
+
+	minValue, err := tx.CalcMinUtxoValue(bundle2, 4310)
+	require.NoError(t, err)
+	require.Equal(t, minValue, bundle2.TokenAmounts.NativeAmount)
+	require.EqualValues(t, 5, bundle2.TokenAmounts.GetContractAmount(tokenB))
+
+	// spent assets are not carried with a zero quantity
+	require.Equal(t, []xc.ContractAddress{tokenA}, change.TokenAmounts.Contracts())
+	require.EqualValues(t, 60, change.TokenAmounts.GetContractAmount(tokenA))
+	require.EqualValues(t, 10_000_000-2_000_000-minValue-200_000, change.TokenAmounts.NativeAmount)
+
+	// too little lovelace left for the assets in the change
+	input.Fee = 10_000_000 - 2_000_000 - minValue - 100_000
+	_, err = builder.MultiTransfer(*args, input)
+	require.ErrorContains(t, err, "needs at least")
+}
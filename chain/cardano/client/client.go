@@ -32,6 +32,8 @@ const (
 	ApiVersion        = "/api/v0"
 	EndpointAddresses = "addresses"
 	EndpointAccounts  = "accounts"
+	// Maximum number of extra utxos holding native assets that a transfer consolidates
+	MaxDustUtxos = 10
 )
 
 // Client for Template
@@ -46,6 +48,7 @@ type Client struct {
 }
 
 var _ xclient.Client = &Client{}
+var _ xclient.MultiTransferClient = &Client{}
 
 // NewClient returns a new Template Client
 func NewClient(cfgI *xc.ChainConfig) (*Client, error) {
@@ -96,48 +99,84 @@ func (client *Client) FetchUtxos(ctx context.Context, address xc.Address, contra
 	return response, err
 }
 
-// 1. Sort utxos by amount descending
-// 2. Get minimum utxo set that can cover `targetAmount`
-func GetMinUtxoSet(utxos []types.Utxo, targetAmount tx.TokenAmounts, contract xc.ContractAddress) []types.Utxo {
-	slices.SortFunc(utxos, func(lhs types.Utxo, rhs types.Utxo) int {
-		amountL := lhs.GetAssetAmount(contract)
-		amountR := rhs.GetAssetAmount(contract)
-
-		return amountR.Cmp(&amountL)
-	})
-
+// SelectUtxos selects utxos covering every asset of `target`, native assets first and then lovelace.
+// For each asset, the utxos holding the most of it are preferred.
+func SelectUtxos(utxos []types.Utxo, target tx.TokenAmounts) []types.Utxo {
+	remaining := slices.Clone(utxos)
 	utxoSet := make([]types.Utxo, 0)
 	amounts := tx.TokenAmounts{}
-	for _, utxo := range utxos {
-		for _, amount := range utxo.Amounts {
-			contract := amount.Unit
-			amnt := amount.Quantity
-			amounts.AddAmount(xc.ContractAddress(contract), xc.NewAmountBlockchainFromStr(amnt).Uint64())
+	contracts := append(target.Contracts(), types.Lovelace)
+	for _, contract := range contracts {
+		required := target.GetContractAmount(contract)
+		slices.SortStableFunc(remaining, func(lhs types.Utxo, rhs types.Utxo) int {
+			amountL := lhs.GetAssetAmount(contract)
+			amountR := rhs.GetAssetAmount(contract)
+
+			return amountR.Cmp(&amountL)
+		})
+		for len(remaining) > 0 && amounts.GetContractAmount(contract) < required {
+			utxo := remaining[0]
+			if assetAmount := utxo.GetAssetAmount(contract); assetAmount.IsZero() {
+				// no remaining utxo holds the asset
+				break
+			}
+			remaining = remaining[1:]
+			for _, amount := range utxo.Amounts {
+				amounts.AddAmount(xc.ContractAddress(amount.Unit), xc.NewAmountBlockchainFromStr(amount.Quantity).Uint64())
+			}
+			utxoSet = append(utxoSet, utxo)
 		}
+	}
+	return utxoSet
+}
 
-		utxoSet = append(utxoSet, utxo)
-		if amounts.CanCover(targetAmount) {
-			break
+// ConsolidateDust adds up to MaxDustUtxos of the unselected utxos holding native assets, so that their
+// assets are merged into the change output rather than each locking the minimum UTXO value.
+// The utxos holding the least lovelace are consolidated first.
+func ConsolidateDust(selected []types.Utxo, utxos []types.Utxo) []types.Utxo {
+	dust := make([]types.Utxo, 0)
+	for _, utxo := range utxos {
+		isSelected := slices.ContainsFunc(selected, func(other types.Utxo) bool {
+			return other.TxHash == utxo.TxHash && other.Index == utxo.Index
+		})
+		if !isSelected && utxo.HasNativeAssets() {
+			dust = append(dust, utxo)
 		}
 	}
-	return utxoSet
+	slices.SortStableFunc(dust, func(lhs types.Utxo, rhs types.Utxo) int {
+		amountL := lhs.GetAssetAmount(types.Lovelace)
+		amountR := rhs.GetAssetAmount(types.Lovelace)
+
+		return amountL.Cmp(&amountR)
+	})
+	if len(dust) > MaxDustUtxos {
+		dust = dust[:MaxDustUtxos]
+	}
+	return append(selected, dust...)
 }
 
 func (client *Client) fetchBaseInput(ctx context.Context, amount xc.AmountBlockchain, contract xc.ContractAddress, from xc.Address, protocolParams types.ProtocolParameters) (*tx_input.TxInput, error) {
 	if contract == "" {
 		contract = types.Lovelace
 	}
+	targetAmounts := tx.TokenAmounts{}
+	targetAmounts.AddAmount(contract, amount.Uint64())
+	return client.fetchInputFor(ctx, from, targetAmounts, false, protocolParams)
+}
 
-	utxos, err := client.FetchUtxos(ctx, from, contract)
+// fetchInputFor selects utxos of `from` covering `targetAmounts` and the gas budget
+func (client *Client) fetchInputFor(ctx context.Context, from xc.Address, targetAmounts tx.TokenAmounts, consolidateDust bool, protocolParams types.ProtocolParameters) (*tx_input.TxInput, error) {
+	allUtxos, err := client.FetchUtxos(ctx, from, types.Lovelace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch utxos: %w", err)
 	}
 
 	gasBudget := client.ClientCfg.GasBudgetDefault.ToBlockchain(NativeDecimals).Uint64()
-	targetAmounts := tx.TokenAmounts{}
 	targetAmounts.AddAmount(types.Lovelace, gasBudget)
-	targetAmounts.AddAmount(contract, amount.Uint64())
-	utxos = GetMinUtxoSet(utxos, targetAmounts, contract)
+	utxos := SelectUtxos(allUtxos, targetAmounts)
+	if consolidateDust {
+		utxos = ConsolidateDust(utxos, allUtxos)
+	}
 
 	var latestBlock types.Block
 	err = client.Get(ctx, "/blocks/latest", &latestBlock)
@@ -155,47 +194,104 @@ func (client *Client) fetchBaseInput(ctx context.Context, amount xc.AmountBlockc
 	}, nil
 }
 
-// FetchTransferInput returns tx input for a Cardano transfer
-func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+// fetchPaymentInput selects utxos to pay the bundles, including the lovelace needed to
+// meet the minimum UTXO value of outputs carrying native assets.  Dust utxos are only
+// consolidated when requested, see xcbuilder.OptionConsolidateDust.
+func (client *Client) fetchPaymentInput(ctx context.Context, from xc.Address, bundles []tx.Bundle, consolidateDust bool) (*tx_input.TxInput, error) {
 	protocolParams, err := client.FetchProtocolParameters(ctx)
 	if err != nil {
 		return nil, clienterrors.ProtocolParamsf(err)
 	}
 
-	contract, _ := args.GetContract()
-	baseInput, err := client.fetchBaseInput(
-		ctx,
-		args.GetAmount(),
-		contract,
-		args.GetFrom(),
-		protocolParams,
-	)
-	if err != nil {
-		return nil, clienterrors.BaseInputf(err)
+	targetAmounts := tx.TokenAmounts{}
+	for _, bundle := range bundles {
+		output, err := tx.NewOutput(bundle.To)
+		if err != nil {
+			return nil, err
+		}
+		output.TokenAmounts = bundle.Amounts
+		minValue, err := tx.CalcMinUtxoValue(output, protocolParams.GetCoinsPerUtxoByte())
+		if err != nil {
+			return nil, err
+		}
+		targetAmounts.AddAmount(types.Lovelace, max(minValue, bundle.Amounts.NativeAmount))
+		for _, contract := range bundle.Amounts.Contracts() {
+			targetAmounts.AddAmount(contract, bundle.Amounts.GetContractAmount(contract))
+		}
 	}
 
-	transfer, err := tx.NewTransfer(args, baseInput)
+	baseInput, err := client.fetchInputFor(ctx, from, targetAmounts, consolidateDust, protocolParams)
 	if err != nil {
-		return nil, clienterrors.FeeEstimationf(err)
+		return nil, clienterrors.BaseInputf(err)
 	}
-	err = transfer.SetSignatures([]*xc.SignatureResponse{
+	return baseInput, nil
+}
+
+// estimateFee sets the fee of the input, from the size of the transaction signed with placeholder signatures
+func estimateFee(txInput *tx_input.TxInput, transfer xc.Tx) error {
+	err := transfer.SetSignatures([]*xc.SignatureResponse{
 		{
 			Signature: make([]byte, 64),
 			PublicKey: make([]byte, 32),
 		},
 	}...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to set signatures: %w", err)
+		return fmt.Errorf("failed to set signatures: %w", err)
+	}
+
+	err = txInput.CalculateTxFee(transfer)
+	if err != nil {
+		return clienterrors.CalculateTxFee(err)
+	}
+	return nil
+}
+
+// FetchTransferInput returns tx input for a Cardano transfer
+func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+	contract, _ := args.GetContract()
+	amounts := tx.TokenAmounts{}
+	amounts.AddAmount(contract, args.GetAmount().Uint64())
+	baseInput, err := client.fetchPaymentInput(ctx, args.GetFrom(), []tx.Bundle{{To: args.GetTo(), Amounts: amounts}}, args.ConsolidateDustEnabled())
+	if err != nil {
+		return nil, err
 	}
 
-	err = baseInput.CalculateTxFee(transfer)
+	transfer, err := tx.NewTransfer(args, baseInput)
+	if err != nil {
+		return nil, clienterrors.FeeEstimationf(err)
+	}
+	err = estimateFee(baseInput, transfer)
 	if err != nil {
-		return nil, clienterrors.CalculateTxFee(err)
+		return nil, err
 	}
 
 	return baseInput, nil
 }
 
+// FetchMultiTransferInput returns tx input for sending lovelace and native assets to several receivers
+func (client *Client) FetchMultiTransferInput(ctx context.Context, args xcbuilder.MultiTransferArgs) (xc.MultiTransferInput, error) {
+	spenders := args.Spenders()
+	if len(spenders) != 1 {
+		return nil, fmt.Errorf("cardano multi-transfers support exactly one spender, got %d", len(spenders))
+	}
+	baseInput, err := client.fetchPaymentInput(ctx, spenders[0].GetFrom(), tx.NewBundles(args.Receivers()), args.ConsolidateDustEnabled())
+	if err != nil {
+		return nil, err
+	}
+	multiInput := &tx_input.MultiTransferInput{TxInput: *baseInput}
+
+	transfer, err := tx.NewMultiTransfer(args, multiInput)
+	if err != nil {
+		return nil, clienterrors.FeeEstimationf(err)
+	}
+	err = estimateFee(&multiInput.TxInput, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	return multiInput, nil
+}
+
 // Deprecated method - use FetchTransferInput
 func (client *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, to xc.Address) (xc.TxInput, error) {
 	// No way to pass the amount in the input using legacy interface, so we estimate using min amount.
@@ -271,28 +367,27 @@ func (client *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinf
 	if err != nil {
 		return txinfo.TxInfo{}, fmt.Errorf("failed to fetch transaction utxos: %w", err)
 	}
+	// Every asset of every utxo is reported, with the utxo it belongs to as the event:
+	// `tx_hash#index` for inputs, and the output index for outputs.
 	contractToMovement := NewContractToMovement()
 	for _, input := range transactionUtxos.Inputs {
 		addr := xc.Address(input.Address)
+		eventId := fmt.Sprintf("%s#%d", input.TxHash, input.Index)
 		for _, amount := range input.Amounts {
-			contract := xc.ContractAddress(amount.Unit)
-			if contract == types.Lovelace {
-				contract = types.Ada
-			}
+			contract, variant := movementContract(amount)
 			contractMovement := contractToMovement.GetOrInit(contract)
-			contractMovement.AddSource(addr, xc.NewAmountBlockchainFromStr(amount.Quantity), nil)
+			balanceChange := contractMovement.AddSource(addr, xc.NewAmountBlockchainFromStr(amount.Quantity), nil)
+			balanceChange.AddEventMeta(txinfo.NewEvent(eventId, variant))
 		}
 	}
 
 	for _, output := range transactionUtxos.Outputs {
 		addr := xc.Address(output.Address)
 		for _, amount := range output.Amounts {
-			contract := xc.ContractAddress(amount.Unit)
-			if contract == types.Lovelace {
-				contract = types.Ada
-			}
+			contract, variant := movementContract(amount)
 			contractMovement := contractToMovement.GetOrInit(contract)
-			contractMovement.AddDestination(addr, xc.NewAmountBlockchainFromStr(amount.Quantity), nil)
+			balanceChange := contractMovement.AddDestination(addr, xc.NewAmountBlockchainFromStr(amount.Quantity), nil)
+			balanceChange.AddEventMeta(txinfo.NewEventFromIndex(uint64(output.Index), variant))
 		}
 	}
 
@@ -316,6 +411,13 @@ func (client *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinf
 	return *txInfo, nil
 }
 
+func movementContract(amount types.Amount) (xc.ContractAddress, txinfo.MovementVariant) {
+	if amount.Unit == types.Lovelace {
+		return types.Ada, txinfo.MovementVariantNative
+	}
+	return xc.ContractAddress(amount.Unit), txinfo.MovementVariantToken
+}
+
 func (client *Client) FetchBalance(ctx context.Context, args *xclient.BalanceArgs) (xc.AmountBlockchain, error) {
 	path := fmt.Sprintf("/%s/%s", EndpointAddresses, string(args.Address()))
 	var getAddressInfoResponse types.GetAddressInfoResponse
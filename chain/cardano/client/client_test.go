@@ -464,11 +464,13 @@ func TestFetchTxInfo(t *testing.T) {
 								Balance:   xc.NewAmountBlockchainFromUint64(10_000),
 								XAddress:  "chains/ADA/addresses/addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
 								AddressId: "addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
+								Event:     txinfo.NewEvent("bcda48666026306d1b958256cb94ad4cef0fe14d1dc44165b25947642e1eae1e#0", txinfo.MovementVariantToken),
 							},
 							{
 								Balance:   xc.NewAmountBlockchainFromUint64(194_449_999),
 								XAddress:  "chains/ADA/addresses/addr_test1qzyf6t5qq037n0srm4r84w3hchgvmgu45ks9pf5f27qhnykxp572eea783cccv2fmpqs5s6va4n7pusy097meenje88s6p7x42",
 								AddressId: "addr_test1qzyf6t5qq037n0srm4r84w3hchgvmgu45ks9pf5f27qhnykxp572eea783cccv2fmpqs5s6va4n7pusy097meenje88s6p7x42",
+								Event:     txinfo.NewEvent("bcda48666026306d1b958256cb94ad4cef0fe14d1dc44165b25947642e1eae1e#1", txinfo.MovementVariantToken),
 							},
 						},
 						[]*txinfo.BalanceChange{
@@ -476,11 +478,13 @@ func TestFetchTxInfo(t *testing.T) {
 								Balance:   xc.NewAmountBlockchainFromUint64(210_000),
 								XAddress:  "chains/ADA/addresses/addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
 								AddressId: "addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
+								Event:     txinfo.NewEventFromIndex(0, txinfo.MovementVariantToken),
 							},
 							{
 								Balance:   xc.NewAmountBlockchainFromUint64(194_249_999),
 								XAddress:  "chains/ADA/addresses/addr_test1qzyf6t5qq037n0srm4r84w3hchgvmgu45ks9pf5f27qhnykxp572eea783cccv2fmpqs5s6va4n7pusy097meenje88s6p7x42",
 								AddressId: "addr_test1qzyf6t5qq037n0srm4r84w3hchgvmgu45ks9pf5f27qhnykxp572eea783cccv2fmpqs5s6va4n7pusy097meenje88s6p7x42",
+								Event:     txinfo.NewEventFromIndex(1, txinfo.MovementVariantToken),
 							},
 						},
 						nil,
@@ -493,6 +497,7 @@ func TestFetchTxInfo(t *testing.T) {
 								Balance:   xc.NewAmountBlockchainFromUint64(1),
 								XAddress:  "chains/ADA/addresses/addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
 								AddressId: "addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
+								Event:     txinfo.NewEvent("bcda48666026306d1b958256cb94ad4cef0fe14d1dc44165b25947642e1eae1e#0", txinfo.MovementVariantToken),
 							},
 						},
 						[]*txinfo.BalanceChange{
@@ -500,6 +505,7 @@ func TestFetchTxInfo(t *testing.T) {
 								Balance:   xc.NewAmountBlockchainFromUint64(1),
 								XAddress:  "chains/ADA/addresses/addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
 								AddressId: "addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
+								Event:     txinfo.NewEventFromIndex(0, txinfo.MovementVariantToken),
 							},
 						},
 						nil,
@@ -512,16 +518,19 @@ func TestFetchTxInfo(t *testing.T) {
 								Balance:   xc.NewAmountBlockchainFromUint64(1_796_623),
 								XAddress:  "chains/ADA/addresses/addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
 								AddressId: "addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
+								Event:     txinfo.NewEvent("bcda48666026306d1b958256cb94ad4cef0fe14d1dc44165b25947642e1eae1e#0", txinfo.MovementVariantNative),
 							},
 							{
 								Balance:   xc.NewAmountBlockchainFromUint64(19_758_398_403),
 								XAddress:  "chains/ADA/addresses/addr_test1qzyf6t5qq037n0srm4r84w3hchgvmgu45ks9pf5f27qhnykxp572eea783cccv2fmpqs5s6va4n7pusy097meenje88s6p7x42",
 								AddressId: "addr_test1qzyf6t5qq037n0srm4r84w3hchgvmgu45ks9pf5f27qhnykxp572eea783cccv2fmpqs5s6va4n7pusy097meenje88s6p7x42",
+								Event:     txinfo.NewEvent("bcda48666026306d1b958256cb94ad4cef0fe14d1dc44165b25947642e1eae1e#1", txinfo.MovementVariantNative),
 							},
 							{
 								Balance:   xc.NewAmountBlockchainFromUint64(489_053_618),
 								XAddress:  "chains/ADA/addresses/addr_test1qqdqfz660junmjs96qxyh760e9h6zme5jrvectx4tznhk8q72rs5hptlwsvhwphrfrkuyftnxwv6ld2r8yag3gmaz82sx05amf",
 								AddressId: "addr_test1qqdqfz660junmjs96qxyh760e9h6zme5jrvectx4tznhk8q72rs5hptlwsvhwphrfrkuyftnxwv6ld2r8yag3gmaz82sx05amf",
+								Event:     txinfo.NewEvent("6cda8edb61295ddd017ec83f330f049e0704a622b78484a3361865e12837fbd3#3", txinfo.MovementVariantNative),
 							},
 						},
 						[]*txinfo.BalanceChange{
@@ -529,11 +538,13 @@ func TestFetchTxInfo(t *testing.T) {
 								Balance:   xc.NewAmountBlockchainFromUint64(1_796_623),
 								XAddress:  "chains/ADA/addresses/addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
 								AddressId: "addr_test1wr54sl5p9yuvaknwv8kjyg7k7n6h02rccyh7s7ntuqs49rsfy5283",
+								Event:     txinfo.NewEventFromIndex(0, txinfo.MovementVariantNative),
 							},
 							{
 								Balance:   xc.NewAmountBlockchainFromUint64(19_756_996_210),
 								XAddress:  "chains/ADA/addresses/addr_test1qzyf6t5qq037n0srm4r84w3hchgvmgu45ks9pf5f27qhnykxp572eea783cccv2fmpqs5s6va4n7pusy097meenje88s6p7x42",
 								AddressId: "addr_test1qzyf6t5qq037n0srm4r84w3hchgvmgu45ks9pf5f27qhnykxp572eea783cccv2fmpqs5s6va4n7pusy097meenje88s6p7x42",
+								Event:     txinfo.NewEventFromIndex(1, txinfo.MovementVariantNative),
 							},
 						},
 						nil,
@@ -693,3 +704,132 @@ func NewMovement(
 	movement.Event = event
 	return movement
 }
+
+func newUtxo(hash string, index uint16, lovelace string, assets ...types.Amount) types.Utxo {
+	return types.Utxo{
+		Address: "addr_test1vzjddf57t45k7a04kpr65lakpjmx50pwy7v0eje3t73c02s5zecy5",
+		Amounts: append([]types.Amount{{Unit: types.Lovelace, Quantity: lovelace}}, assets...),
+		TxHash:  hash,
+		Index:   index,
+	}
+}
+
+func TestSelectUtxos(t *testing.T) {
+	tokenA := "2682a9b99553406c39f693bb450d6001954e3504c96238c6b96ad79a474554"
+	tokenB := "553cbd1862bfb5ff69b6ebdcbb6165beb47171a04b841c79c72e75a94e4654"
+	utxos := []types.Utxo{
+		newUtxo("00", 0, "50000000"),
+		newUtxo("01", 0, "1500000", types.Amount{Unit: tokenA, Quantity: "10"}),
+		newUtxo("02", 0, "1200000", types.Amount{Unit: tokenA, Quantity: "100"}),
+		newUtxo("03", 0, "1100000", types.Amount{Unit: tokenB, Quantity: "1"}),
+		newUtxo("04", 0, "2000000"),
+	}
+
+	target := tx.TokenAmounts{}
+	target.AddAmount(xc.ContractAddress(tokenA), 50)
+	target.AddAmount(types.Lovelace, 5_000_000)
+	selected := client.SelectUtxos(utxos, target)
+	// the largest holding of the token, then the largest lovelace utxo
+	require.Len(t, selected, 2)
+	require.Equal(t, "02", selected[0].TxHash)
+	require.Equal(t, "00", selected[1].TxHash)
+
+	// the remaining token utxos are consolidated, least lovelace first
+	consolidated := client.ConsolidateDust(selected, utxos)
+	require.Len(t, consolidated, 4)
+	require.Equal(t, "03", consolidated[2].TxHash)
+	require.Equal(t, "01", consolidated[3].TxHash)
+
+	// cannot cover the asset
+	target = tx.TokenAmounts{}
+	target.AddAmount(xc.ContractAddress(tokenB), 2)
+	selected = client.SelectUtxos(utxos, target)
+	require.Equal(t, "03", selected[0].TxHash)
+}
+
+func TestFetchMultiTransferInput(t *testing.T) {
+	tokenA := "2682a9b99553406c39f693bb450d6001954e3504c96238c6b96ad79a474554"
+	utxos := `[
+		{"address":"addr_test1vzjddf57t45k7a04kpr65lakpjmx50pwy7v0eje3t73c02s5zecy5","tx_hash":"72cfa181469b48402a50c6652d45c789897ae5025bb01f569a7bd01bffd12bc1","output_index":0,"amount":[{"unit":"lovelace","quantity":"20000000"}]},
+		{"address":"addr_test1vzjddf57t45k7a04kpr65lakpjmx50pwy7v0eje3t73c02s5zecy5","tx_hash":"72cfa181469b48402a50c6652d45c789897ae5025bb01f569a7bd01bffd12bc1","output_index":1,"amount":[{"unit":"lovelace","quantity":"1200000"},{"unit":"` + tokenA + `","quantity":"100"}]}
+	]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if strings.Contains(r.URL.Path, "utxo") {
+			_, err = w.Write([]byte(utxos))
+		} else if strings.Contains(r.URL.Path, "block") {
+			_, err = w.Write([]byte(`{"time":1746434616,"height":3446505,"slot":90751416}`))
+		} else {
+			_, err = w.Write([]byte(`{"min_fee_a":44,"min_fee_b":155381,"coins_per_utxo_size":"4310","key_deposit":"2000000"}`))
+		}
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	cfg := NewTestConfig()
+	cardanoClient, _ := client.NewClient(cfg)
+	cardanoClient.Url = server.URL
+
+	sender, err := xcbuilder.NewSender("addr_test1vzjddf57t45k7a04kpr65lakpjmx50pwy7v0eje3t73c02s5zecy5", make([]byte, 32))
+	require.NoError(t, err)
+	receiver1, err := xcbuilder.NewReceiver("addr_test1qrfp5xelv2mu7k8zyvwm0c8t5xm55wanwhtd4fgjgtf3ck0rplhn7x9jyhwqg70fwv0ujpmyumqk5td9e9hnsejtlxnq3yqf25", xc.NewAmountBlockchainFromUint64(5_000_000))
+	require.NoError(t, err)
+	receiver2, err := xcbuilder.NewReceiver("addr_test1qrfp5xelv2mu7k8zyvwm0c8t5xm55wanwhtd4fgjgtf3ck0rplhn7x9jyhwqg70fwv0ujpmyumqk5td9e9hnsejtlxnq3yqf25", xc.NewAmountBlockchainFromUint64(30), xcbuilder.OptionContractAddress(xc.ContractAddress(tokenA)))
+	require.NoError(t, err)
+	args, err := xcbuilder.NewMultiTransferArgs(cfg.Base(), []*xcbuilder.Sender{sender}, []*xcbuilder.Receiver{receiver1, receiver2})
+	require.NoError(t, err)
+
+	input, err := cardanoClient.FetchMultiTransferInput(context.Background(), *args)
+	require.NoError(t, err)
+	multiInput := input.(*tx_input.MultiTransferInput)
+	require.Len(t, multiInput.Utxos, 2)
+	require.EqualValues(t, 90_751_416, multiInput.Slot)
+	require.Greater(t, multiInput.Fee, uint64(155381))
+}
+
+func TestFetchTransferInputConsolidateDust(t *testing.T) {
+	tokenA := "2682a9b99553406c39f693bb450d6001954e3504c96238c6b96ad79a474554"
+	utxos := `[
+		{"address":"addr_test1vzjddf57t45k7a04kpr65lakpjmx50pwy7v0eje3t73c02s5zecy5","tx_hash":"72cfa181469b48402a50c6652d45c789897ae5025bb01f569a7bd01bffd12bc1","output_index":0,"amount":[{"unit":"lovelace","quantity":"20000000"}]},
+		{"address":"addr_test1vzjddf57t45k7a04kpr65lakpjmx50pwy7v0eje3t73c02s5zecy5","tx_hash":"72cfa181469b48402a50c6652d45c789897ae5025bb01f569a7bd01bffd12bc1","output_index":1,"amount":[{"unit":"lovelace","quantity":"1200000"},{"unit":"` + tokenA + `","quantity":"100"}]}
+	]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if strings.Contains(r.URL.Path, "utxo") {
+			_, err = w.Write([]byte(utxos))
+		} else if strings.Contains(r.URL.Path, "block") {
+			_, err = w.Write([]byte(`{"time":1746434616,"height":3446505,"slot":90751416}`))
+		} else {
+			_, err = w.Write([]byte(`{"min_fee_a":44,"min_fee_b":155381,"coins_per_utxo_size":"4310","key_deposit":"2000000"}`))
+		}
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	cfg := NewTestConfig()
+	cardanoClient, _ := client.NewClient(cfg)
+	cardanoClient.Url = server.URL
+
+	from := xc.Address("addr_test1vzjddf57t45k7a04kpr65lakpjmx50pwy7v0eje3t73c02s5zecy5")
+	to := xc.Address("addr_test1qrfp5xelv2mu7k8zyvwm0c8t5xm55wanwhtd4fgjgtf3ck0rplhn7x9jyhwqg70fwv0ujpmyumqk5td9e9hnsejtlxnq3yqf25")
+	amount := xc.NewAmountBlockchainFromUint64(5_000_000)
+
+	// only the utxos needed for the transfer are spent by default
+	args, err := xcbuilder.NewTransferArgs(cfg.Base(), from, to, amount, xcbuilder.OptionPublicKey(make([]byte, 32)))
+	require.NoError(t, err)
+	input, err := cardanoClient.FetchTransferInput(context.Background(), args)
+	require.NoError(t, err)
+	defaultInput := input.(*tx_input.TxInput)
+	require.Len(t, defaultInput.Utxos, 1)
+	require.EqualValues(t, 0, defaultInput.Utxos[0].Index)
+
+	// the token utxo is merged into the change when requested
+	args, err = xcbuilder.NewTransferArgs(cfg.Base(), from, to, amount, xcbuilder.OptionPublicKey(make([]byte, 32)), xcbuilder.OptionConsolidateDust(true))
+	require.NoError(t, err)
+	input, err = cardanoClient.FetchTransferInput(context.Background(), args)
+	require.NoError(t, err)
+	consolidatedInput := input.(*tx_input.TxInput)
+	require.Len(t, consolidatedInput.Utxos, 2)
+	require.EqualValues(t, 1, consolidatedInput.Utxos[1].Index)
+	require.Greater(t, consolidatedInput.Fee, defaultInput.Fee)
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strconv"
 
 	xc "github.com/cordialsys/crosschain"
 )
@@ -65,9 +66,21 @@ type ProtocolParameters struct {
 	FixedFee         uint64 `json:"min_fee_b"`
 	MinUtxoValue     string `json:"min_utxo"`
 	CoinsPerUtxoWord string `json:"coins_per_utxo_word"`
+	CoinsPerUtxoSize string `json:"coins_per_utxo_size"`
 	KeyDeposit       string `json:"key_deposit"`
 }
 
+// GetCoinsPerUtxoByte returns the Babbage min-UTXO coefficient, or 0 if it is not known.
+// Since Babbage, `coins_per_utxo_word` reports the same per-byte cost.
+func (p ProtocolParameters) GetCoinsPerUtxoByte() uint64 {
+	for _, value := range []string{p.CoinsPerUtxoSize, p.CoinsPerUtxoWord} {
+		if coins, err := strconv.ParseUint(value, 10, 64); err == nil && coins > 0 {
+			return coins
+		}
+	}
+	return 0
+}
+
 type Utxo struct {
 	Address string   `json:"address"`
 	Amounts []Amount `json:"amount"`
@@ -85,6 +98,15 @@ func (u *Utxo) GetAssetAmount(contract xc.ContractAddress) xc.AmountBlockchain {
 	return xc.NewAmountBlockchainFromUint64(0)
 }
 
+func (u *Utxo) HasNativeAssets() bool {
+	for _, amount := range u.Amounts {
+		if amount.Unit != Lovelace {
+			return true
+		}
+	}
+	return false
+}
+
 type TransactionUtxos struct {
 	Inputs  []Utxo `json:"inputs"`
 	Outputs []Utxo `json:"outputs"`
@@ -0,0 +1,172 @@
+package tx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"unicode/utf8"
+
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/fxamacker/cbor/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// CIP-20 transaction message label
+	MetadataLabelMessage = 674
+	// CIP-25 NFT metadata label
+	MetadataLabelNft = 721
+	// Strings and byte strings in metadata are limited to 64 bytes
+	MaxMetadataChunkSize = 64
+)
+
+// Deterministic encoding so that the auxiliary data hash is reproducible
+var metadataEncMode, _ = cbor.CoreDetEncOptions().EncMode()
+
+// Metadata is the transaction auxiliary data: a map of labels to metadatum values
+type Metadata struct {
+	entries map[uint64]any
+}
+
+func NewMetadata() *Metadata {
+	return &Metadata{entries: map[uint64]any{}}
+}
+
+// NewMetadataFromArgs combines the CIP-20 memo and any labelled metadata.  Returns nil if there is none.
+func NewMetadataFromArgs(memo string, entries []xcbuilder.TxMetadata) (*Metadata, error) {
+	if memo == "" && len(entries) == 0 {
+		return nil, nil
+	}
+	metadata := NewMetadata()
+	if memo != "" {
+		metadata.SetMessage(memo)
+	}
+	for _, entry := range entries {
+		if _, ok := metadata.entries[entry.Label]; ok {
+			return nil, fmt.Errorf("metadata label %d conflicts with the memo", entry.Label)
+		}
+		if err := metadata.Set(entry.Label, entry.Value); err != nil {
+			return nil, err
+		}
+	}
+	return metadata, nil
+}
+
+// SetMessage sets a CIP-20 message, split into chunks of at most 64 bytes
+func (m *Metadata) SetMessage(message string) {
+	chunks := []any{}
+	for len(message) > MaxMetadataChunkSize {
+		// avoid splitting a multi-byte character
+		end := MaxMetadataChunkSize
+		for end > 0 && !utf8.RuneStart(message[end]) {
+			end--
+		}
+		chunks = append(chunks, message[:end])
+		message = message[end:]
+	}
+	chunks = append(chunks, message)
+	m.entries[MetadataLabelMessage] = map[string]any{"msg": chunks}
+}
+
+func (m *Metadata) Set(label uint64, value any) error {
+	normalized, err := normalizeMetadatum(value)
+	if err != nil {
+		return fmt.Errorf("invalid metadata for label %d: %w", label, err)
+	}
+	m.entries[label] = normalized
+	return nil
+}
+
+func (m *Metadata) Get(label uint64) (any, bool) {
+	value, ok := m.entries[label]
+	return value, ok
+}
+
+func (m *Metadata) MarshalCBOR() ([]byte, error) {
+	return metadataEncMode.Marshal(m.entries)
+}
+
+// Hash is the auxiliary data hash committed to in the transaction body
+func (m *Metadata) Hash() ([]byte, error) {
+	bz, err := m.MarshalCBOR()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	hash := blake2b.Sum256(bz)
+	return hash[:], nil
+}
+
+// normalizeMetadatum validates a value against the metadatum rules, converting JSON values where needed
+func normalizeMetadatum(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if len(v) > MaxMetadataChunkSize {
+			return nil, fmt.Errorf("string %q is longer than %d bytes", v, MaxMetadataChunkSize)
+		}
+		return v, nil
+	case []byte:
+		if len(v) > MaxMetadataChunkSize {
+			return nil, fmt.Errorf("bytes are longer than %d bytes", MaxMetadataChunkSize)
+		}
+		return v, nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > math.MaxInt64 {
+			return nil, fmt.Errorf("number %v is not an integer", v)
+		}
+		return int64(v), nil
+	case json.Number:
+		i, err := v.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("number %v is not an integer", v)
+		}
+		return i, nil
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			normalized, err := normalizeMetadatum(item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = normalized
+		}
+		return list, nil
+	case []string:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = item
+		}
+		return normalizeMetadatum(list)
+	case map[string]any:
+		normalized := map[any]any{}
+		for key, item := range v {
+			normalizedKey, err := normalizeMetadatum(key)
+			if err != nil {
+				return nil, err
+			}
+			normalizedItem, err := normalizeMetadatum(item)
+			if err != nil {
+				return nil, err
+			}
+			normalized[normalizedKey] = normalizedItem
+		}
+		return normalized, nil
+	case map[any]any:
+		normalized := map[any]any{}
+		for key, item := range v {
+			normalizedKey, err := normalizeMetadatum(key)
+			if err != nil {
+				return nil, err
+			}
+			normalizedItem, err := normalizeMetadatum(item)
+			if err != nil {
+				return nil, err
+			}
+			normalized[normalizedKey] = normalizedItem
+		}
+		return normalized, nil
+	default:
+		return nil, fmt.Errorf("unsupported metadata value of type %T", value)
+	}
+}
@@ -1,9 +1,12 @@
 package tx
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"strings"
 
 	xc "github.com/cordialsys/crosschain"
@@ -41,6 +44,8 @@ const (
 	PolicyIdSize = 28
 	// Size of the UTXO entry without any coin values
 	UtxoEntrySizeWithoutVal = 27
+	// Babbage min-UTXO: bytes added to the size of the serialized output to account for the UTXO entry
+	UtxoEntryOverhead = 160
 
 	PoolHrm = "pool"
 )
@@ -120,7 +125,7 @@ type Tx struct {
 	Body                        *TxBody
 	Witness                     *Witness
 	Valid                       bool
-	Memo                        *string
+	Metadata                    *Metadata
 	RequiresAdditionalSignature bool `cbor:"-"`
 }
 
@@ -145,7 +150,8 @@ type TxBody struct {
 	TTL          uint32        `cbor:"3,keyasint,omitempty"`
 	Certificates []Certificate `cbor:"4,keyasint,omitempty"`
 	Withdrawal   *Withdrawal   `cbor:"5,keyasint,omitempty"`
-	MemoHash     []byte        `cbor:"7,keyasint,omitempty"`
+	// Hash of the auxiliary data (metadata)
+	AuxiliaryDataHash []byte `cbor:"7,keyasint,omitempty"`
 }
 
 func (txBody *TxBody) MarshalCBOR() ([]byte, error) {
@@ -156,16 +162,19 @@ func (txBody *TxBody) MarshalCBOR() ([]byte, error) {
 		TTL          uint32      `cbor:"3,keyasint,omitempty,omitzero"`
 		Certificates *cbor.Tag   `cbor:"4,keyasint,omitempty"`
 		Withdrawal   *Withdrawal `cbor:"5,keyasint,omitempty"`
+		// Hash of the auxiliary data (metadata)
+		AuxiliaryDataHash []byte `cbor:"7,keyasint,omitempty"`
 	}
 	txBodyData := Body{
 		Inputs: cbor.Tag{
 			Number:  258,
 			Content: txBody.Inputs,
 		},
-		Outputs:    txBody.Outputs,
-		Fee:        txBody.Fee,
-		TTL:        txBody.TTL,
-		Withdrawal: txBody.Withdrawal,
+		Outputs:           txBody.Outputs,
+		Fee:               txBody.Fee,
+		TTL:               txBody.TTL,
+		Withdrawal:        txBody.Withdrawal,
+		AuxiliaryDataHash: txBody.AuxiliaryDataHash,
 	}
 	if len(txBody.Certificates) > 0 {
 		txBodyData.Certificates = &cbor.Tag{
@@ -227,13 +236,10 @@ type ordered interface {
 
 func MarshalCBORBtreeMap[key ordered, value any](m *btree.Map[key, value]) ([]byte, error) {
 	// Append raw `object` CBOR header
-	mapBytes := []byte{0xA0}
-	// Ensure that map size is less than 15 - shouldn't happend, but we have to check
-	len := m.Len()
-	if len > 0x0000_1111 {
-		return nil, fmt.Errorf("map size is too large: %d", len)
+	mapBytes, err := cborMapHeader(m.Len())
+	if err != nil {
+		return nil, err
 	}
-	mapBytes[0] |= byte(len)
 
 	iter := m.Iter()
 	for ok := iter.First(); ok; ok = iter.Next() {
@@ -254,6 +260,20 @@ func MarshalCBORBtreeMap[key ordered, value any](m *btree.Map[key, value]) ([]by
 	return mapBytes, nil
 }
 
+// cborMapHeader encodes the major type and length of a CBOR map
+func cborMapHeader(length int) ([]byte, error) {
+	switch {
+	case length < 24:
+		return []byte{0xA0 | byte(length)}, nil
+	case length <= math.MaxUint8:
+		return []byte{0xB8, byte(length)}, nil
+	case length <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16([]byte{0xB9}, uint16(length)), nil
+	default:
+		return nil, fmt.Errorf("map size is too large: %d", length)
+	}
+}
+
 type TokenNameHexToAmount struct {
 	*btree.Map[TokenName, uint64]
 }
@@ -293,7 +313,7 @@ type TokenAmounts struct {
 
 func (ta *TokenAmounts) AddAmount(contract xc.ContractAddress, amount uint64) {
 	if contract == types.Lovelace || contract == "" {
-		ta.NativeAmount = amount
+		ta.NativeAmount += amount
 		return
 	}
 
@@ -325,6 +345,9 @@ func (ta *TokenAmounts) GetAmount(policyId PolicyHash, tokenName TokenName) uint
 	if policyId == "" {
 		return ta.NativeAmount
 	}
+	if ta.PolicyIdToAmounts == nil {
+		return 0
+	}
 
 	policyAssets, ok := ta.PolicyIdToAmounts.Get(policyId)
 	if ok {
@@ -337,6 +360,23 @@ func (ta *TokenAmounts) GetAmount(policyId PolicyHash, tokenName TokenName) uint
 	return 0
 }
 
+func (ta *TokenAmounts) GetContractAmount(contract xc.ContractAddress) uint64 {
+	return ta.GetAmount(ContractAddressToPolicyAndName(contract))
+}
+
+// Contracts lists the native assets held, excluding lovelace
+func (ta *TokenAmounts) Contracts() []xc.ContractAddress {
+	contracts := []xc.ContractAddress{}
+	policyIter := ta.PolicyIdToAmounts.Iter()
+	for ok := policyIter.First(); ok; ok = policyIter.Next() {
+		assetIter := policyIter.Value().Iter()
+		for ok := assetIter.First(); ok; ok = assetIter.Next() {
+			contracts = append(contracts, xc.ContractAddress(string(policyIter.Key())+string(assetIter.Key())))
+		}
+	}
+	return contracts
+}
+
 // Returns true when `ta` can cover all `required` amounts
 func (ta TokenAmounts) CanCover(required TokenAmounts) bool {
 	// Cannot cover native amount
@@ -495,19 +535,60 @@ func CalcMinAdaValue(policyHashToAmounts *PolicyIdToAmounts) xc.AmountBlockchain
 	return utxoEntrySize
 }
 
+// CalcMinUtxoValue calculates the minimum lovelace an output must hold, per the Babbage rules:
+// (160 + size of the serialized output) * coinsPerUtxoByte
+// https://cips.cardano.org/cip/CIP-55
+// Falls back to the Alonzo calculation for token outputs when coinsPerUtxoByte is not known.
+func CalcMinUtxoValue(output *Output, coinsPerUtxoByte uint64) (uint64, error) {
+	if coinsPerUtxoByte == 0 {
+		if output.TokenAmounts.PolicyIdToAmounts == nil {
+			return 0, nil
+		}
+		return CalcMinAdaValue(output.TokenAmounts.PolicyIdToAmounts).Uint64(), nil
+	}
+
+	// The size of the output depends on the encoding of its lovelace, so iterate until it's stable
+	candidate := *output
+	candidate.TokenAmounts.NativeAmount = 0
+	for i := 0; i < 8; i++ {
+		bz, err := cbor.Marshal(&candidate)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal output: %w", err)
+		}
+		minValue := (UtxoEntryOverhead + uint64(len(bz))) * coinsPerUtxoByte
+		if minValue == candidate.TokenAmounts.NativeAmount {
+			break
+		}
+		candidate.TokenAmounts.NativeAmount = minValue
+	}
+	return candidate.TokenAmounts.NativeAmount, nil
+}
+
 // Create output that represents amount sent to receiver address
-func (tx Tx) CreateTransferOutput(to xc.Address, amount xc.AmountBlockchain, contract xc.ContractAddress) error {
-	receiverAddress := to
-	output, err := NewOutput(receiverAddress)
+func (tx Tx) CreateTransferOutput(to xc.Address, amount xc.AmountBlockchain, contract xc.ContractAddress, coinsPerUtxoByte uint64) error {
+	amounts := TokenAmounts{}
+	amounts.AddAmount(contract, amount.Uint64())
+	return tx.CreateBundleOutput(to, amounts, coinsPerUtxoByte)
+}
+
+// CreateBundleOutput creates a single output sending lovelace and any number of native assets to the receiver.
+// Outputs carrying native assets are topped up to the minimum UTXO value.
+func (tx Tx) CreateBundleOutput(to xc.Address, amounts TokenAmounts, coinsPerUtxoByte uint64) error {
+	output, err := NewOutput(to)
 	if err != nil {
 		return fmt.Errorf("failed to create transfer output: %w", err)
 	}
+	output.TokenAmounts = amounts
 
-	isNative := contract == "" || contract == types.Lovelace
-	output.AddAmount(contract, amount.Uint64())
-	if !isNative {
-		minLovelace := CalcMinAdaValue(output.TokenAmounts.PolicyIdToAmounts)
-		output.AddAmount(xc.ContractAddress(types.Lovelace), minLovelace.Uint64())
+	minValue, err := CalcMinUtxoValue(output, coinsPerUtxoByte)
+	if err != nil {
+		return err
+	}
+	if output.TokenAmounts.NativeAmount < minValue {
+		if output.TokenAmounts.PolicyIdToAmounts == nil {
+			return fmt.Errorf("output of %d lovelace is below the minimum UTXO value of %d lovelace", output.TokenAmounts.NativeAmount, minValue)
+		}
+		output.TokenAmounts.NativeAmount = minValue
 	}
 
 	tx.Body.Outputs = append(tx.Body.Outputs, output)
@@ -548,7 +629,7 @@ func (tx Tx) CreateChangeOutput(utxos []types.Utxo, from xc.Address) error {
 		if nativeOutput.IsZero() {
 			return errors.New("outputs with 0 lovelace are invalid")
 		}
-		totalOutputs[types.Lovelace] = nativeOutput
+		totalOutputs[types.Lovelace] = addAmount(totalOutputs[types.Lovelace], nativeOutput)
 		policyIter := output.TokenAmounts.PolicyIdToAmounts.Iter()
 		for ok := policyIter.First(); ok; ok = policyIter.Next() {
 			policyId := policyIter.Key()
@@ -558,7 +639,7 @@ func (tx Tx) CreateChangeOutput(utxos []types.Utxo, from xc.Address) error {
 				assetName := amountsIter.Key()
 				tokenAmount := amountsIter.Value()
 				contract := xc.ContractAddress(string(policyId) + string(assetName))
-				totalOutputs[contract] = xc.NewAmountBlockchainFromUint64(tokenAmount)
+				totalOutputs[contract] = addAmount(totalOutputs[contract], xc.NewAmountBlockchainFromUint64(tokenAmount))
 			}
 		}
 	}
@@ -578,6 +659,10 @@ func (tx Tx) CreateChangeOutput(utxos []types.Utxo, from xc.Address) error {
 		if changeAmount.Cmp(&zeroAmount) == -1 {
 			return fmt.Errorf("negative change amount for contract %s", contract)
 		}
+		if changeAmount.IsZero() && contract != types.Lovelace {
+			// multi-assets must not hold zero quantities
+			continue
+		}
 		changeOutput.AddAmount(contract, changeAmount.Uint64())
 	}
 
@@ -589,6 +674,34 @@ func (tx Tx) CreateChangeOutput(utxos []types.Utxo, from xc.Address) error {
 	return nil
 }
 
+func addAmount(total xc.AmountBlockchain, amount xc.AmountBlockchain) xc.AmountBlockchain {
+	return total.Add(&amount)
+}
+
+// ValidateChangeOutput checks that a change output carrying native assets holds enough lovelace,
+// which has to be checked after the fee and any deposits are deducted.
+func (tx Tx) ValidateChangeOutput(coinsPerUtxoByte uint64) error {
+	outLen := len(tx.Body.Outputs)
+	if outLen == 0 {
+		return errors.New("missing change output")
+	}
+	change := tx.Body.Outputs[outLen-1]
+	if change.TokenAmounts.PolicyIdToAmounts == nil || change.TokenAmounts.PolicyIdToAmounts.Len() == 0 {
+		return nil
+	}
+	minValue, err := CalcMinUtxoValue(change, coinsPerUtxoByte)
+	if err != nil {
+		return err
+	}
+	if change.TokenAmounts.NativeAmount < minValue {
+		return fmt.Errorf(
+			"change output holds %d lovelace, but needs at least %d lovelace for its native assets",
+			change.TokenAmounts.NativeAmount, minValue,
+		)
+	}
+	return nil
+}
+
 func DebugAmounts(
 	inputAmounts map[xc.ContractAddress]xc.AmountBlockchain,
 	outputAmounts map[xc.ContractAddress]xc.AmountBlockchain,
@@ -623,14 +736,26 @@ func DebugAmounts(
 	log.WithFields(change).Debug("change amounts, before fee")
 }
 
+// SetMemo attaches the memo as a CIP-20 message
 func (tx *Tx) SetMemo(memo string) error {
-	tx.Memo = &memo
-	bytes, err := cbor.Marshal(memo)
+	metadata := NewMetadata()
+	metadata.SetMessage(memo)
+	return tx.SetMetadata(metadata)
+}
+
+// SetMetadata attaches the metadata and commits to it in the transaction body
+func (tx *Tx) SetMetadata(metadata *Metadata) error {
+	if metadata == nil {
+		tx.Metadata = nil
+		tx.Body.AuxiliaryDataHash = nil
+		return nil
+	}
+	hash, err := metadata.Hash()
 	if err != nil {
-		return errors.New("failed to marshal memo")
+		return err
 	}
-	hash := blake2b.Sum256(bytes)
-	tx.Body.MemoHash = hash[:]
+	tx.Metadata = metadata
+	tx.Body.AuxiliaryDataHash = hash
 	return nil
 }
 
@@ -817,31 +942,82 @@ func NewTransfer(args builder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
 		return nil, fmt.Errorf("invalid input type")
 	}
 
-	tx := NewTx()
-	inputMemo, ok := args.GetMemo()
-	if ok {
-		err := tx.SetMemo(inputMemo)
-		if err != nil {
-			return nil, fmt.Errorf("failed to set memo: %w", err)
+	contract, _ := args.GetContract()
+	amounts := TokenAmounts{}
+	amounts.AddAmount(contract, args.GetAmount().Uint64())
+	memo, _ := args.GetMemo()
+	return newPayment(txInput, args.GetFrom(), []Bundle{{To: args.GetTo(), Amounts: amounts}}, memo, args.GetTxMetadata())
+}
+
+// Bundle is the lovelace and native assets sent to a single receiver output
+type Bundle struct {
+	To      xc.Address
+	Amounts TokenAmounts
+}
+
+// NewBundles groups the receivers of a multi-transfer into one output per address
+func NewBundles(receivers []*builder.Receiver) []Bundle {
+	bundles := []Bundle{}
+	for _, receiver := range receivers {
+		contract, _ := receiver.GetContract()
+		index := slices.IndexFunc(bundles, func(bundle Bundle) bool {
+			return bundle.To == receiver.GetTo()
+		})
+		if index < 0 {
+			bundles = append(bundles, Bundle{To: receiver.GetTo()})
+			index = len(bundles) - 1
 		}
+		bundles[index].Amounts.AddAmount(contract, receiver.GetAmount().Uint64())
 	}
+	return bundles
+}
 
-	err := tx.SetUtxos(txInput.Utxos)
+// NewMultiTransfer sends lovelace and native assets from a single spender to any number of receivers.
+// Amounts sent to the same address are combined into a single output.
+func NewMultiTransfer(args builder.MultiTransferArgs, input xc.MultiTransferInput) (xc.Tx, error) {
+	multiInput, ok := input.(*tx_input.MultiTransferInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type")
+	}
+	spenders := args.Spenders()
+	if len(spenders) != 1 {
+		return nil, fmt.Errorf("cardano multi-transfers support exactly one spender, got %d", len(spenders))
+	}
+	if len(args.Receivers()) == 0 {
+		return nil, errors.New("no receivers")
+	}
+
+	memo, _ := args.GetMemo()
+	return newPayment(&multiInput.TxInput, spenders[0].GetFrom(), NewBundles(args.Receivers()), memo, args.GetTxMetadata())
+}
+
+func newPayment(txInput *tx_input.TxInput, from xc.Address, bundles []Bundle, memo string, entries []builder.TxMetadata) (*Tx, error) {
+	tx := NewTx()
+	metadata, err := NewMetadataFromArgs(memo, entries)
 	if err != nil {
-		return nil, ErrFailedToSetUtxos(err)
+		return nil, err
+	}
+	err = tx.SetMetadata(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set metadata: %w", err)
 	}
 
-	// Recipient output
-	to := args.GetTo()
-	amount := args.GetAmount()
-	contract, _ := args.GetContract()
-	err = tx.CreateTransferOutput(to, amount, contract)
+	err = tx.SetUtxos(txInput.Utxos)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transfer output: %w", err)
+		return nil, ErrFailedToSetUtxos(err)
 	}
 
-	// Change output
-	err = tx.CreateChangeOutput(txInput.Utxos, args.GetFrom())
+	// Recipient outputs
+	coinsPerUtxoByte := txInput.ProtocolParams.GetCoinsPerUtxoByte()
+	for _, bundle := range bundles {
+		err = tx.CreateBundleOutput(bundle.To, bundle.Amounts, coinsPerUtxoByte)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create transfer output: %w", err)
+		}
+	}
+
+	// Change output, which also collects any native assets of the consumed utxos
+	err = tx.CreateChangeOutput(txInput.Utxos, from)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create change output: %w", err)
 	}
@@ -852,6 +1028,10 @@ func NewTransfer(args builder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to set tx fee: %w", err)
 	}
+	err = tx.ValidateChangeOutput(coinsPerUtxoByte)
+	if err != nil {
+		return nil, err
+	}
 	return tx, nil
 }
 
@@ -2,6 +2,7 @@ package tx_test
 
 import (
 	"encoding/hex"
+	"strings"
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
@@ -415,3 +416,105 @@ func TestCalcMinAdaValue(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadata(t *testing.T) {
+	metadata, err := tx.NewMetadataFromArgs("hi", nil)
+	require.NoError(t, err)
+	bz, err := metadata.MarshalCBOR()
+	require.NoError(t, err)
+	// {674: {"msg": ["hi"]}}
+	require.Equal(t, "a11902a2a1636d736781626869", hex.EncodeToString(bz))
+
+	// long messages are split into chunks of at most 64 bytes
+	metadata, err = tx.NewMetadataFromArgs(strings.Repeat("a", 100), nil)
+	require.NoError(t, err)
+	message, ok := metadata.Get(tx.MetadataLabelMessage)
+	require.True(t, ok)
+	chunks := message.(map[string]any)["msg"].([]any)
+	require.Len(t, chunks, 2)
+	require.Len(t, chunks[0], 64)
+	require.Len(t, chunks[1], 36)
+
+	// CIP-25 metadata alongside the message
+	nft := map[string]any{"policy": map[string]any{"name": map[string]any{"name": "NFT", "image": "ipfs://abc"}}}
+	metadata, err = tx.NewMetadataFromArgs("hi", []xcbuilder.TxMetadata{{Label: tx.MetadataLabelNft, Value: nft}})
+	require.NoError(t, err)
+	_, ok = metadata.Get(tx.MetadataLabelNft)
+	require.True(t, ok)
+
+	_, err = tx.NewMetadataFromArgs("hi", []xcbuilder.TxMetadata{{Label: tx.MetadataLabelMessage, Value: "other"}})
+	require.ErrorContains(t, err, "conflicts with the memo")
+	_, err = tx.NewMetadataFromArgs("", []xcbuilder.TxMetadata{{Label: 1, Value: strings.Repeat("a", 65)}})
+	require.ErrorContains(t, err, "longer than 64 bytes")
+	_, err = tx.NewMetadataFromArgs("", []xcbuilder.TxMetadata{{Label: 1, Value: 1.5}})
+	require.ErrorContains(t, err, "not an integer")
+
+	metadata, err = tx.NewMetadataFromArgs("", nil)
+	require.NoError(t, err)
+	require.Nil(t, metadata)
+}
+
+func TestSetMetadata(t *testing.T) {
+	transfer := newTx(t)
+	require.Nil(t, transfer.Body.AuxiliaryDataHash)
+
+	metadata := tx.NewMetadata()
+	require.NoError(t, metadata.Set(1, []any{"a", 1}))
+	require.NoError(t, transfer.SetMetadata(metadata))
+	expectedHash, err := metadata.Hash()
+	require.NoError(t, err)
+	require.Len(t, expectedHash, 32)
+	require.Equal(t, expectedHash, transfer.Body.AuxiliaryDataHash)
+
+	// the metadata hash is committed to in the tx hash
+	hashWithMetadata := transfer.Hash()
+	require.NoError(t, transfer.SetMetadata(nil))
+	require.NotEqual(t, hashWithMetadata, transfer.Hash())
+}
+
+func TestCalcMinUtxoValue(t *testing.T) {
+	output, err := tx.NewOutput("addr_test1vzjddf57t45k7a04kpr65lakpjmx50pwy7v0eje3t73c02s5zecy5")
+	require.NoError(t, err)
+
+	// [address (2 + 29 bytes), coin (5 bytes)] in a 1 byte array header
+	minValue, err := tx.CalcMinUtxoValue(output, 4310)
+	require.NoError(t, err)
+	require.EqualValues(t, (160+37)*4310, minValue)
+
+	// no Babbage parameter, native outputs have no minimum
+	minValue, err = tx.CalcMinUtxoValue(output, 0)
+	require.NoError(t, err)
+	require.Zero(t, minValue)
+
+	output.AddAmount("00000000000000000000000000000000000000000000000000000000", 5)
+	tokenMinValue, err := tx.CalcMinUtxoValue(output, 4310)
+	require.NoError(t, err)
+	require.Greater(t, tokenMinValue, (160+37)*uint64(4310))
+
+	// Alonzo fallback for tokens
+	minValue, err = tx.CalcMinUtxoValue(output, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 1_407_406, minValue)
+}
+
+func TestCreateBundleOutput(t *testing.T) {
+	transaction := tx.NewTx()
+	to := xc.Address("addr_test1vzjddf57t45k7a04kpr65lakpjmx50pwy7v0eje3t73c02s5zecy5")
+
+	amounts := tx.TokenAmounts{}
+	amounts.AddAmount("00000000000000000000000000000000000000000000000000000000aa", 5)
+	amounts.AddAmount("11111111111111111111111111111111111111111111111111111111", 7)
+	require.NoError(t, transaction.CreateBundleOutput(to, amounts, 4310))
+	output := transaction.Body.Outputs[0]
+	minValue, err := tx.CalcMinUtxoValue(output, 4310)
+	require.NoError(t, err)
+	// topped up to the minimum
+	require.Equal(t, minValue, output.TokenAmounts.NativeAmount)
+	require.EqualValues(t, 5, output.TokenAmounts.GetContractAmount("00000000000000000000000000000000000000000000000000000000aa"))
+	require.EqualValues(t, 7, output.TokenAmounts.GetContractAmount("11111111111111111111111111111111111111111111111111111111"))
+
+	// lovelace-only outputs below the minimum are rejected
+	amounts = tx.TokenAmounts{}
+	amounts.AddAmount(types.Lovelace, 100_000)
+	require.ErrorContains(t, transaction.CreateBundleOutput(to, amounts, 4310), "below the minimum UTXO value")
+}
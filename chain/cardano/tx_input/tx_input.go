@@ -32,6 +32,7 @@ func init() {
 	registry.RegisterTxVariantInput(&StakingInput{})
 	registry.RegisterTxVariantInput(&UnstakingInput{})
 	registry.RegisterTxVariantInput(&WithdrawInput{})
+	registry.RegisterTxVariantInput(&MultiTransferInput{})
 }
 
 func NewTxInput() *TxInput {
@@ -145,3 +146,16 @@ func (*WithdrawInput) Withdrawing() {}
 func (*WithdrawInput) GetVariant() xc.TxVariantInputType {
 	return xc.NewWithdrawingInputType(xc.DriverCardano, string(xc.Native))
 }
+
+// MultiTransferInput is the input for sending lovelace and native assets to several receivers at once
+type MultiTransferInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &MultiTransferInput{}
+var _ xc.MultiTransferInput = &MultiTransferInput{}
+
+func (*MultiTransferInput) MultiTransfer() {}
+func (*MultiTransferInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewMultiTransferInputType(xc.DriverCardano, string(xc.Native))
+}
@@ -7,8 +7,10 @@ import (
 	stdmath "math"
 	"math/big"
 	"sort"
+	"strings"
 
 	"cosmossdk.io/math"
+	"cosmossdk.io/x/authz"
 	banktypes "cosmossdk.io/x/bank/types"
 	xc "github.com/cordialsys/crosschain"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
@@ -20,6 +22,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 
 	wasmtypes "github.com/cordialsys/crosschain/chain/cosmos/types/CosmWasm/wasmd/x/wasm/types"
+	ibctransfertypes "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v8/modules/apps/transfer/types"
 )
 
 // TxBuilder for Cosmos
@@ -120,12 +123,23 @@ func (txBuilder TxBuilder) GetDenom(contract xc.ContractAddress) string {
 	asset := txBuilder.Asset
 	denom := asset.ChainCoin
 	if contract != "" {
-		denom = string(contract)
+		denom = IbcDenom(string(contract))
 	}
 
 	return denom
 }
 
+// IBC assets may be referenced by their full trace path, e.g. "transfer/channel-0/uatom".
+// This converts such a path to the "ibc/<hash>" denomination that x/bank uses, and
+// returns any other denomination unchanged.
+func IbcDenom(denom string) string {
+	parts := strings.Split(denom, "/")
+	if len(parts) < 3 || !strings.HasPrefix(parts[1], "channel-") {
+		return denom
+	}
+	return ibctransfertypes.HashDenom(denom)
+}
+
 // Returns the amount in blockchain that is percentage of amount.
 // E.g. amount = 100, tax = 0.05, returns 5.
 func GetTaxFrom(amount xc.AmountBlockchain, tax float64) xc.AmountBlockchain {
@@ -186,6 +200,10 @@ func (txBuilder TxBuilder) calculateFees(amount xc.AmountBlockchain, contractMay
 
 // createTxWithMsg creates a new Tx given Cosmos Msg
 func (txBuilder TxBuilder) createTxWithMsg(input *tx_input.TxInput, msg types.Msg, args tx.TxArgs, fees types.Coins) (xc.Tx, error) {
+	if args.AuthzGrantee != "" {
+		execMsg := authz.NewMsgExec(string(args.AuthzGrantee), []types.Msg{msg})
+		msg = &execMsg
+	}
 	return tx.NewTx(
 		txBuilder.Asset,
 		args,
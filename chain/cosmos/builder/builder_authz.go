@@ -0,0 +1,90 @@
+package builder
+
+import (
+	"errors"
+	"time"
+
+	"cosmossdk.io/x/authz"
+	banktypes "cosmossdk.io/x/bank/types"
+	"cosmossdk.io/x/feegrant"
+	stakingtypes "cosmossdk.io/x/staking/types"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// Authorization for the grantee to send up to the spend limit, optionally only to the allowed addresses.
+func NewSendAuthorization(spendLimit types.Coins, allowList ...xc.Address) *banktypes.SendAuthorization {
+	allowed := make([]string, len(allowList))
+	for i, addr := range allowList {
+		allowed[i] = string(addr)
+	}
+	return &banktypes.SendAuthorization{
+		SpendLimit: spendLimit,
+		AllowList:  allowed,
+	}
+}
+
+// Authorization for the grantee to delegate, undelegate or redelegate, optionally limited to
+// the max tokens and the given validators.
+func NewStakeAuthorization(authorizationType stakingtypes.AuthorizationType, maxTokens *types.Coin, validators ...string) *stakingtypes.StakeAuthorization {
+	authorization := &stakingtypes.StakeAuthorization{
+		MaxTokens:         maxTokens,
+		AuthorizationType: authorizationType,
+	}
+	if len(validators) > 0 {
+		authorization.Validators = &stakingtypes.StakeAuthorization_AllowList{
+			AllowList: &stakingtypes.StakeAuthorization_Validators{Address: validators},
+		}
+	}
+	return authorization
+}
+
+// x/authz MsgGrant, signed by the granter
+func (txBuilder TxBuilder) Grant(granter xc.Address, grantee xc.Address, authorization authz.Authorization, expiration *time.Time, publicKey []byte, input xc.TxInput) (xc.Tx, error) {
+	if authorization == nil {
+		return nil, errors.New("authorization is required")
+	}
+	msg, err := authz.NewMsgGrant(string(granter), string(grantee), authorization, expiration)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.createSignedByTx(input, msg, publicKey)
+}
+
+// x/authz MsgRevoke, signed by the granter
+func (txBuilder TxBuilder) Revoke(granter xc.Address, grantee xc.Address, msgTypeUrl string, publicKey []byte, input xc.TxInput) (xc.Tx, error) {
+	if msgTypeUrl == "" {
+		return nil, errors.New("msg type url is required to revoke an authorization")
+	}
+	msg := authz.NewMsgRevoke(string(granter), string(grantee), msgTypeUrl)
+	return txBuilder.createSignedByTx(input, &msg, publicKey)
+}
+
+// x/feegrant MsgGrantAllowance, signed by the granter
+func (txBuilder TxBuilder) GrantAllowance(granter xc.Address, grantee xc.Address, allowance feegrant.FeeAllowanceI, publicKey []byte, input xc.TxInput) (xc.Tx, error) {
+	if allowance == nil {
+		return nil, errors.New("allowance is required")
+	}
+	msg, err := feegrant.NewMsgGrantAllowance(allowance, string(granter), string(grantee))
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.createSignedByTx(input, msg, publicKey)
+}
+
+// x/feegrant MsgRevokeAllowance, signed by the granter
+func (txBuilder TxBuilder) RevokeAllowance(granter xc.Address, grantee xc.Address, publicKey []byte, input xc.TxInput) (xc.Tx, error) {
+	msg := feegrant.NewMsgRevokeAllowance(string(granter), string(grantee))
+	return txBuilder.createSignedByTx(input, &msg, publicKey)
+}
+
+func (txBuilder TxBuilder) createSignedByTx(input xc.TxInput, msg types.Msg, publicKey []byte) (xc.Tx, error) {
+	txInput, ok := input.(*tx_input.TxInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	fees := txBuilder.calculateFees(xc.NewAmountBlockchainFromUint64(0), "", txInput, false)
+	return txBuilder.createTxWithMsg(txInput, msg, tx.TxArgs{FromPublicKey: publicKey}, fees)
+}
@@ -0,0 +1,125 @@
+package builder_test
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"cosmossdk.io/x/authz"
+	banktypes "cosmossdk.io/x/bank/types"
+	"cosmossdk.io/x/feegrant"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/cosmos/builder"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
+	"github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferWithAuthzGrantee(t *testing.T) {
+	chain := xc.NewChainConfig(xc.ATOM).WithChainCoin("uatom").WithChainPrefix("cosmos").Base()
+	granter := xc.Address("cosmos1hdvf6vv5amc7wp84js0ls27apekwxpr0cz4t6e")
+	grantee := xc.Address("cosmos1a8p9r5ugs7vxq5xggr3kq3fv5ym8xhzj0fk3z6")
+	to := xc.Address("cosmos1ztjf4sp6ah8afsvlsne6zhehd9pgj54q6tnefy")
+	feeGranter := xc.Address("cosmos1hsk6jryyqjfhp5dhc55tc9jtckygx0eph6dd02")
+
+	txBuilder, err := builder.NewTxBuilder(chain)
+	require.NoError(t, err)
+
+	input := tx_input.NewTxInput()
+	input.AssetType = tx_input.BANK
+	input.GasPrice = 0.1
+	input.GasLimit = 200_000
+
+	args, err := xcbuilder.NewTransferArgs(chain, granter, to, xc.NewAmountBlockchainFromUint64(1000),
+		xcbuilder.OptionAuthzGrantee(grantee),
+		xcbuilder.OptionFeeGranter(feeGranter),
+	)
+	require.NoError(t, err)
+
+	xcTx, err := txBuilder.Transfer(args, input)
+	require.NoError(t, err)
+	cosmosTx := xcTx.(*tx.Tx)
+	require.Len(t, cosmosTx.Msgs, 1)
+	exec := cosmosTx.Msgs[0].(*authz.MsgExec)
+	require.Equal(t, string(grantee), exec.Grantee)
+	innerMsgs, err := exec.GetMessages()
+	require.NoError(t, err)
+	require.Len(t, innerMsgs, 1)
+	send := innerMsgs[0].(*banktypes.MsgSend)
+	require.Equal(t, string(granter), send.FromAddress)
+	require.Equal(t, string(to), send.ToAddress)
+	require.EqualValues(t, 1000, send.Amount.AmountOf("uatom").Uint64())
+
+	sighashes, err := xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+	require.Equal(t, grantee, sighashes[0].Signer)
+
+	signDoc, err := cosmosTx.BuildUnsigned()
+	require.NoError(t, err)
+	authInfo := sdktx.AuthInfo{}
+	require.NoError(t, authInfo.Unmarshal(signDoc.AuthInfoBytes))
+	require.Equal(t, string(feeGranter), authInfo.Fee.Granter)
+	require.Equal(t, "", authInfo.Fee.Payer)
+}
+
+func TestGrants(t *testing.T) {
+	chain := xc.NewChainConfig(xc.ATOM).WithChainCoin("uatom").WithChainPrefix("cosmos").Base()
+	granter := xc.Address("cosmos1hdvf6vv5amc7wp84js0ls27apekwxpr0cz4t6e")
+	grantee := xc.Address("cosmos1a8p9r5ugs7vxq5xggr3kq3fv5ym8xhzj0fk3z6")
+
+	txBuilder, err := builder.NewTxBuilder(chain)
+	require.NoError(t, err)
+
+	input := tx_input.NewTxInput()
+	input.GasPrice = 0.1
+	input.GasLimit = 100_000
+	expiration := time.Unix(1_800_000_000, 0).UTC()
+	spendLimit := types.NewCoins(types.NewCoin("uatom", sdkmath.NewInt(5000)))
+
+	t.Run("grant", func(t *testing.T) {
+		xcTx, err := txBuilder.Grant(granter, grantee, builder.NewSendAuthorization(spendLimit, "cosmos1ztjf4sp6ah8afsvlsne6zhehd9pgj54q6tnefy"), &expiration, nil, input)
+		require.NoError(t, err)
+		cosmosTx := xcTx.(*tx.Tx)
+		msg := cosmosTx.Msgs[0].(*authz.MsgGrant)
+		require.Equal(t, string(granter), msg.Granter)
+		require.Equal(t, string(grantee), msg.Grantee)
+		require.Equal(t, expiration, *msg.Grant.Expiration)
+		authorization := msg.Grant.Authorization.GetCachedValue().(*banktypes.SendAuthorization)
+		require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", authorization.MsgTypeURL())
+		require.EqualValues(t, 10_000, cosmosTx.Fees.AmountOf("uatom").Uint64())
+
+		_, err = txBuilder.Grant(granter, grantee, nil, nil, nil, input)
+		require.ErrorContains(t, err, "authorization is required")
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		xcTx, err := txBuilder.Revoke(granter, grantee, "/cosmos.bank.v1beta1.MsgSend", nil, input)
+		require.NoError(t, err)
+		msg := xcTx.(*tx.Tx).Msgs[0].(*authz.MsgRevoke)
+		require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", msg.MsgTypeUrl)
+
+		_, err = txBuilder.Revoke(granter, grantee, "", nil, input)
+		require.Error(t, err)
+	})
+
+	t.Run("grant allowance", func(t *testing.T) {
+		allowance := &feegrant.BasicAllowance{SpendLimit: spendLimit, Expiration: &expiration}
+		xcTx, err := txBuilder.GrantAllowance(granter, grantee, allowance, nil, input)
+		require.NoError(t, err)
+		msg := xcTx.(*tx.Tx).Msgs[0].(*feegrant.MsgGrantAllowance)
+		require.Equal(t, string(granter), msg.Granter)
+		require.Equal(t, string(grantee), msg.Grantee)
+		require.IsType(t, &feegrant.BasicAllowance{}, msg.Allowance.GetCachedValue())
+	})
+
+	t.Run("revoke allowance", func(t *testing.T) {
+		xcTx, err := txBuilder.RevokeAllowance(granter, grantee, nil, input)
+		require.NoError(t, err)
+		msg := xcTx.(*tx.Tx).Msgs[0].(*feegrant.MsgRevokeAllowance)
+		require.Equal(t, string(grantee), msg.Grantee)
+	})
+}
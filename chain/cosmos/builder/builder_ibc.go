@@ -0,0 +1,56 @@
+package builder
+
+import (
+	"errors"
+
+	"cosmossdk.io/math"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input/gas"
+	"github.com/cosmos/cosmos-sdk/types"
+
+	ibctransfertypes "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	ibcclienttypes "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v8/modules/core/02-client/types"
+)
+
+var _ xcbuilder.IbcTransfer = &TxBuilder{}
+
+// ibc-go MsgTransfer (ICS-20) of a x/bank asset to another chain
+func (txBuilder TxBuilder) IbcTransfer(args xcbuilder.IbcTransferArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput := input.(*tx_input.TxInput)
+	if txInput.AssetType == tx_input.CW20 {
+		return nil, errors.New("IBC transfers of cw20 tokens are not supported")
+	}
+	if txInput.GasLimit == 0 {
+		txInput.GasLimit = gas.TokenTransferGasLimit
+	}
+
+	contractMaybe, _ := args.GetContract()
+	denom := txBuilder.GetDenom(contractMaybe)
+	memo, _ := args.GetMemo()
+
+	msg := &ibctransfertypes.MsgTransfer{
+		SourcePort:    args.GetPort(),
+		SourceChannel: args.GetChannel(),
+		Token:         types.NewCoin(denom, math.NewIntFromBigInt(args.GetAmount().Int())),
+		Sender:        string(args.GetFrom()),
+		Receiver:      string(args.GetTo()),
+		Memo:          memo,
+	}
+	timeoutHeight, hasHeight := args.GetTimeoutHeight()
+	timeoutTimestamp, hasTimestamp := args.GetTimeoutTimestamp()
+	if hasHeight || hasTimestamp {
+		msg.TimeoutHeight = ibcclienttypes.NewHeight(timeoutHeight.RevisionNumber, timeoutHeight.RevisionHeight)
+		msg.TimeoutTimestamp = timeoutTimestamp
+	} else {
+		msg.TimeoutTimestamp = txInput.IbcTimeoutTimestamp
+	}
+	if msg.TimeoutHeight.IsZero() && msg.TimeoutTimestamp == 0 {
+		return nil, errors.New("IBC transfer requires a timeout height or timestamp")
+	}
+
+	fees := txBuilder.calculateFees(args.GetAmount(), contractMaybe, txInput, false)
+	return txBuilder.createTxWithMsg(txInput, msg, tx.NewTxArgsFromIbcTransferArgs(args, txInput), fees)
+}
@@ -0,0 +1,105 @@
+package builder_test
+
+import (
+	"testing"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/cosmos/builder"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
+	"github.com/stretchr/testify/require"
+
+	ibctransfertypes "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v8/modules/apps/transfer/types"
+)
+
+func TestIbcDenom(t *testing.T) {
+	require.Equal(t,
+		"ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+		builder.IbcDenom("transfer/channel-0/uatom"),
+	)
+	require.Equal(t, "uatom", builder.IbcDenom("uatom"))
+	require.Equal(t, "factory/osmo1abc/token", builder.IbcDenom("factory/osmo1abc/token"))
+	require.Equal(t,
+		"ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+		builder.IbcDenom("ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"),
+	)
+}
+
+func TestIbcTransfer(t *testing.T) {
+	chain := xc.NewChainConfig(xc.ATOM).WithChainCoin("uatom").WithChainPrefix("cosmos").Base()
+	chain.IbcChannels = []*xc.IbcChannel{
+		{Chain: xc.TIA, Channel: "channel-141"},
+	}
+	from := xc.Address("cosmos1hdvf6vv5amc7wp84js0ls27apekwxpr0cz4t6e")
+	to := xc.Address("celestia1hdvf6vv5amc7wp84js0ls27apekwxpr0d2aeh5")
+	amount := xc.NewAmountBlockchainFromUint64(1000)
+
+	txBuilder, err := builder.NewTxBuilder(chain)
+	require.NoError(t, err)
+
+	input := tx_input.NewTxInput()
+	input.AssetType = tx_input.BANK
+	input.GasPrice = 0.1
+	input.GasLimit = 200_000
+	input.IbcTimeoutTimestamp = 1_700_000_000_000_000_000
+
+	t.Run("default timeout", func(t *testing.T) {
+		args, err := xcbuilder.NewIbcTransferArgs(chain, from, to, amount, xc.TIA, xcbuilder.OptionMemo("123"))
+		require.NoError(t, err)
+		require.Equal(t, "transfer", args.GetPort())
+		require.Equal(t, "channel-141", args.GetChannel())
+
+		xcTx, err := txBuilder.IbcTransfer(args, input)
+		require.NoError(t, err)
+		cosmosTx := xcTx.(*tx.Tx)
+		require.Len(t, cosmosTx.Msgs, 1)
+		msg := cosmosTx.Msgs[0].(*ibctransfertypes.MsgTransfer)
+		require.Equal(t, "transfer", msg.SourcePort)
+		require.Equal(t, "channel-141", msg.SourceChannel)
+		require.Equal(t, "uatom", msg.Token.Denom)
+		require.EqualValues(t, 1000, msg.Token.Amount.Uint64())
+		require.Equal(t, string(from), msg.Sender)
+		require.Equal(t, string(to), msg.Receiver)
+		require.Equal(t, "123", msg.Memo)
+		require.True(t, msg.TimeoutHeight.IsZero())
+		require.EqualValues(t, input.IbcTimeoutTimestamp, msg.TimeoutTimestamp)
+		require.EqualValues(t, 20_000, cosmosTx.Fees.AmountOf("uatom").Uint64())
+
+		_, err = xcTx.Sighashes()
+		require.NoError(t, err)
+	})
+
+	t.Run("timeout options", func(t *testing.T) {
+		timeout := time.Unix(1_800_000_000, 0)
+		args, err := xcbuilder.NewIbcTransferArgs(chain, from, to, amount, xc.TIA,
+			xcbuilder.OptionIbcTimeoutHeight(1, 5_000_000),
+			xcbuilder.OptionIbcTimeoutTimestamp(timeout),
+			xcbuilder.OptionContractAddress("transfer/channel-0/uosmo"),
+		)
+		require.NoError(t, err)
+
+		xcTx, err := txBuilder.IbcTransfer(args, input)
+		require.NoError(t, err)
+		msg := xcTx.(*tx.Tx).Msgs[0].(*ibctransfertypes.MsgTransfer)
+		require.EqualValues(t, 1, msg.TimeoutHeight.RevisionNumber)
+		require.EqualValues(t, 5_000_000, msg.TimeoutHeight.RevisionHeight)
+		require.EqualValues(t, timeout.UnixNano(), msg.TimeoutTimestamp)
+		require.Equal(t, ibctransfertypes.HashDenom("transfer/channel-0/uosmo"), msg.Token.Denom)
+	})
+
+	t.Run("no timeout", func(t *testing.T) {
+		args, err := xcbuilder.NewIbcTransferArgs(chain, from, to, amount, xc.TIA)
+		require.NoError(t, err)
+		noTimeout := *input
+		noTimeout.IbcTimeoutTimestamp = 0
+		_, err = txBuilder.IbcTransfer(args, &noTimeout)
+		require.ErrorContains(t, err, "timeout")
+	})
+
+	t.Run("no channel", func(t *testing.T) {
+		_, err := xcbuilder.NewIbcTransferArgs(chain, from, to, amount, xc.INJ)
+		require.ErrorContains(t, err, "no IBC channel")
+	})
+}
@@ -132,6 +132,11 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	if err != nil {
 		return nil, err
 	}
+	if grantee, ok := args.GetAuthzGrantee(); ok {
+		if err := client.useGranteeAccount(ctx, baseTxInput, grantee); err != nil {
+			return nil, err
+		}
+	}
 	res, err := client.Simulate(ctx, *baseTxInput, func(input xc.TxInput) (xc.Tx, error) {
 		txBuilder, err := builder.NewTxBuilder(client.Asset.GetChain().Base())
 		if err != nil {
@@ -142,20 +147,27 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	if err != nil {
 		return nil, err
 	}
+	client.applySimulatedGas(ctx, baseTxInput, res, args.GetFrom(), contract)
+
+	return baseTxInput, nil
+}
+
+// Set the gas limit on the input from the simulation result
+func (client *Client) applySimulatedGas(ctx context.Context, input *tx_input.TxInput, res *cosmostx.SimulateResponse, from xc.Address, contract xc.ContractAddress) {
 	if res.GasInfo.GasUsed > 0 {
-		baseTxInput.GasLimit = res.GasInfo.GasUsed
+		input.GasLimit = res.GasInfo.GasUsed
 		// Bump up by 20% generally because cosmos execution can vary a lot.
 		gasLimitMultiplier := DefaultGasLimitMultiplier
 		if client.Asset.GetChain().ChainGasLimitMultiplier > 0.001 {
 			gasLimitMultiplier = client.Asset.GetChain().ChainGasLimitMultiplier
 		}
-		baseTxInput.GasLimit = uint64(float64(baseTxInput.GasLimit) * gasLimitMultiplier)
+		input.GasLimit = uint64(float64(input.GasLimit) * gasLimitMultiplier)
 
 		logrus.WithFields(logrus.Fields{
 			"gas_limit_multiplier": gasLimitMultiplier,
 			"gas_used":             res.GasInfo.GasUsed,
 			"gas_wanted":           res.GasInfo.GasWanted,
-			"from":                 args.GetFrom(),
+			"from":                 from,
 			"contract":             contract,
 		}).Debug("simulated tx")
 	}
@@ -167,13 +179,11 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	// when used as the literal GasLimit. On standard cosmos chains the clamp
 	// is a no-op because sim.GasUsed is real execution gas, well under the
 	// ceiling.
-	if err := client.clampGasLimit(ctx, baseTxInput); err != nil {
+	if err := client.clampGasLimit(ctx, input); err != nil {
 		// A failure here just means we couldn't query the chain ceiling; the
 		// tx may still succeed if GasLimit happens to be small enough.
 		logrus.WithError(err).WithField("chain", client.Asset.GetChain().Chain).Debug("could not query block max_gas; submitting un-clamped")
 	}
-
-	return baseTxInput, nil
 }
 
 // clampGasLimit caps txInput.GasLimit at the chain's per-tx ceiling (block
@@ -417,6 +427,11 @@ func (client *Client) SubmitTx(ctx context.Context, tx1 xctypes.SubmitTxReq) err
 
 // FetchLegacyTxInfo returns tx info for a Cosmos tx
 func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (txinfo.LegacyTxInfo, error) {
+	result, _, err := client.fetchLegacyTxInfo(ctx, txHash)
+	return result, err
+}
+
+func (client *Client) fetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (txinfo.LegacyTxInfo, ParsedEvents, error) {
 	result := txinfo.LegacyTxInfo{
 		Fee:           xc.AmountBlockchain{},
 		BlockIndex:    0,
@@ -430,7 +445,7 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 
 	hash, err := hex.DecodeString(string(txHash))
 	if err != nil {
-		return result, err
+		return result, ParsedEvents{}, err
 	}
 
 	resultRaw := new(localtypes.ResultTx)
@@ -449,25 +464,26 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 	}, resultRaw)
 	if err != nil {
 		if strings.Contains(strings.ToLower(err.Error()), "not found") {
-			return result, errors.TransactionNotFoundf("%v", err)
+			return result, ParsedEvents{}, errors.TransactionNotFoundf("%v", err)
 		}
-		return result, fmt.Errorf("could not download tx: %v", err)
+		return result, ParsedEvents{}, fmt.Errorf("could not download tx: %v", err)
 	}
 
 	_ = client.Asset.GetChain().Limiter.Wait(ctx)
 	blockResultRaw, err := client.Ctx.Client.Block(ctx, &resultRaw.Height)
 	if err != nil {
-		return result, err
+		return result, ParsedEvents{}, err
 	}
 
 	_ = client.Asset.GetChain().Limiter.Wait(ctx)
 	abciInfo, err := client.Ctx.Client.ABCIInfo(ctx)
 	if err != nil {
-		return result, err
+		return result, ParsedEvents{}, err
 	}
 	chainCfg := client.Asset.GetChain()
 	memo := ""
 
+	execEvents := ParsedMsgEvents{}
 	decoder := client.Ctx.TxConfig.TxDecoder()
 	{
 		decodedTx, err := decoder(resultRaw.Tx)
@@ -479,6 +495,10 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 				result.Fee = xc.AmountBlockchain(*tf.GetFee()[0].Amount.BigInt())
 				feePayer, _ := sdk.Bech32ifyAddressBytes(string(client.Asset.GetChain().ChainPrefix), tf.FeePayer())
 				result.FeePayer = xc.Address(feePayer)
+				if granter := tf.FeeGranter(); len(granter) > 0 {
+					feeGranter, _ := sdk.Bech32ifyAddressBytes(string(client.Asset.GetChain().ChainPrefix), granter)
+					result.FeePayer = xc.Address(feeGranter)
+				}
 			default:
 				logrus.Warnf("could not determine transaction type for fee %T", tf)
 			}
@@ -486,10 +506,13 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 			if withMemo, ok := decodedTx.(types.TxWithMemo); ok {
 				memo = withMemo.GetMemo()
 			}
+			// Unwrap any messages executed on behalf of a granter
+			execEvents = ParseExecMsgs(decodedTx.GetMsgs())
 		}
 	}
 
 	events := ParseEvents(resultRaw.TxResult.Events)
+	events.MergeExecMsgs(execEvents)
 	for _, fee := range events.Fees {
 		result.Fee = fee.Amount
 		result.FeeContract = xc.ContractAddress(fee.Contract)
@@ -568,19 +591,25 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		result.ResetStakeEvents()
 	}
 
-	return result, nil
+	return result, events, nil
 }
 
 func (client *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinfo.TxInfo, error) {
 	txHashStr := args.TxHash()
-	legacyTx, err := client.FetchLegacyTxInfo(ctx, txHashStr)
+	legacyTx, events, err := client.fetchLegacyTxInfo(ctx, txHashStr)
 	if err != nil {
 		return txinfo.TxInfo{}, err
 	}
 	chain := client.Asset.GetChain()
 
 	// remap to new tx
-	return txinfo.TxInfoFromLegacy(chain, legacyTx, txinfo.Account), nil
+	txInfo := txinfo.TxInfoFromLegacy(chain, legacyTx, txinfo.Account)
+	if legacyTx.Status != xc.TxStatusFailure {
+		for _, send := range events.IbcSends {
+			txInfo.AddIbcTransfer(client.fetchIbcTransferState(ctx, send))
+		}
+	}
+	return txInfo, nil
 }
 
 // GetAccount returns a Cosmos account
@@ -679,7 +708,7 @@ func (client *Client) fetchBankModuleBalance(ctx context.Context, address xc.Add
 	}
 	denom := ""
 	// denom should be the contract if it's set.
-	denom = string(contractMaybe)
+	denom = builder.IbcDenom(string(contractMaybe))
 	if denom == "" {
 		// use the default chain coin (should be set for cosmos chains)
 		denom = client.Asset.GetChain().ChainCoin
@@ -725,18 +754,13 @@ func (client *Client) FetchDecimals(ctx context.Context, contract xc.ContractAdd
 	if client.Asset.GetChain().IsChain(contract) {
 		return int(client.Asset.GetChain().Decimals), nil
 	}
-	// check chain-coin if it's set
-	chainCfg := client.Asset.GetChain()
-	if chainCfg.ChainCoin == string(contract) {
-		return int(chainCfg.Decimals), nil
-	}
-	// check additional native assets
-	for _, asset := range chainCfg.NativeAssets {
-		if asset.AssetId == string(contract) || asset.ContractId == contract {
-			return int(asset.Decimals), nil
-		}
+	if decimals, ok := client.configuredDecimals(contract); ok {
+		return decimals, nil
 	}
 
+	// IBC assets may be referenced by their trace path (e.g. "transfer/channel-0/uatom")
+	contract = xc.ContractAddress(builder.IbcDenom(string(contract)))
+
 	_ = client.Asset.GetChain().Limiter.Wait(ctx)
 	queryClient := banktypes.NewQueryClient(client.Ctx)
 
@@ -786,9 +810,13 @@ func (client *Client) FetchDecimals(ctx context.Context, contract xc.ContractAdd
 		}
 
 		if strings.HasPrefix(string(contract), "ibc/") {
-			// Sometimes IBC assets don't have any registered metadata.
-			// Default to 6.
-			return 6, nil
+			// Sometimes IBC assets don't have any registered metadata, so we check
+			// the base denomination that the voucher is for.
+			baseDenom, err := client.FetchIbcBaseDenom(ctx, string(contract))
+			if err != nil {
+				return 0, err
+			}
+			return client.decimalsFromBaseDenom(ctx, baseDenom)
 		}
 
 		// return original bank error
@@ -797,15 +825,35 @@ func (client *Client) FetchDecimals(ctx context.Context, contract xc.ContractAdd
 	bz, _ := json.Marshal(denomMetaResponse.Metadata)
 	logrus.WithField("response", string(bz)).Debug("bank asset")
 
+	return metadataDecimals(denomMetaResponse.Metadata), nil
+}
+
+// Decimals of the chain coin or an additional native asset, if it's configured
+func (client *Client) configuredDecimals(contract xc.ContractAddress) (int, bool) {
+	// check chain-coin if it's set
+	chainCfg := client.Asset.GetChain()
+	if chainCfg.ChainCoin == string(contract) {
+		return int(chainCfg.Decimals), true
+	}
+	// check additional native assets
+	for _, asset := range chainCfg.NativeAssets {
+		if asset.AssetId == string(contract) || asset.ContractId == contract {
+			return int(asset.Decimals), true
+		}
+	}
+	return 0, false
+}
+
+func metadataDecimals(metadata banktypes.Metadata) int {
 	// The asset may be reported with a bunch of shorthand aliases with different exponents.
 	// We'll take the highest one, assuming that must be the difference from the machine amount.
 	maxDecimal := 0
-	for _, denom := range denomMetaResponse.Metadata.DenomUnits {
+	for _, denom := range metadata.DenomUnits {
 		if denom.Exponent > uint32(maxDecimal) {
 			maxDecimal = int(denom.Exponent)
 		}
 	}
-	return maxDecimal, nil
+	return maxDecimal
 }
 
 func (client *Client) FetchBlock(ctx context.Context, args *xclient.BlockArgs) (*txinfo.BlockWithTransactions, error) {
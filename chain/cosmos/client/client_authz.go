@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"cosmossdk.io/x/authz"
+	"cosmossdk.io/x/feegrant"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+type GrantModule string
+
+const (
+	GrantModuleAuthz    GrantModule = "authz"
+	GrantModuleFeegrant GrantModule = "feegrant"
+)
+
+// An x/authz authorization or x/feegrant allowance from the granter to the grantee
+type Grant struct {
+	Module  GrantModule `json:"module"`
+	Granter xc.Address  `json:"granter"`
+	Grantee xc.Address  `json:"grantee"`
+	// Type url of the authorization or allowance, e.g. "/cosmos.bank.v1beta1.SendAuthorization"
+	Type string `json:"type"`
+	// The message type that an authz grant permits the grantee to execute
+	MsgTypeUrl string     `json:"msg_type_url,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+	// The authorization or allowance, JSON encoded
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// The grantee signs transactions that are executed on behalf of the from-address,
+// so the account number and sequence must be for the grantee.
+func (client *Client) useGranteeAccount(ctx context.Context, input *tx_input.TxInput, grantee xc.Address) error {
+	_ = client.Asset.GetChain().Limiter.Wait(ctx)
+	account, err := client.GetAccount(ctx, grantee)
+	if err != nil || account == nil {
+		return fmt.Errorf("failed to get account data for grantee %v: %v", grantee, err)
+	}
+	input.AccountNumber = account.GetAccountNumber()
+	input.Sequence = account.GetSequence()
+	return nil
+}
+
+// Fetch the input for a transaction signed by the signer, such as a grant or a revoke.
+// The gas limit is estimated by simulating the transaction made by the builder.
+func (client *Client) FetchMsgInput(ctx context.Context, signer xc.Address, txBuilder txBuilder) (xc.TxInput, error) {
+	baseTxInput, err := client.FetchBaseTxInput(ctx, signer, "", "")
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Simulate(ctx, *baseTxInput, txBuilder)
+	if err != nil {
+		return nil, err
+	}
+	client.applySimulatedGas(ctx, baseTxInput, res, signer, "")
+	return baseTxInput, nil
+}
+
+// Fetch the authz grants and feegrant allowances given by the granter.  If the grantee is set,
+// only grants to the grantee are returned.
+func (client *Client) FetchGrants(ctx context.Context, granter xc.Address, grantee xc.Address) ([]*Grant, error) {
+	grants := []*Grant{}
+	pagination := &query.PageRequest{Limit: 1000}
+
+	authzClient := authz.NewQueryClient(client.Ctx)
+	_ = client.Asset.GetChain().Limiter.Wait(ctx)
+	if grantee != "" {
+		resp, err := authzClient.Grants(ctx, &authz.QueryGrantsRequest{
+			Granter:    string(granter),
+			Grantee:    string(grantee),
+			Pagination: pagination,
+		})
+		if err != nil && !isNotFound(err) {
+			return nil, fmt.Errorf("could not query authz grants: %v", err)
+		}
+		if resp != nil {
+			for _, g := range resp.Grants {
+				grant, err := client.newAuthzGrant(granter, grantee, g.Authorization, g.Expiration)
+				if err != nil {
+					return nil, err
+				}
+				grants = append(grants, grant)
+			}
+		}
+	} else {
+		resp, err := authzClient.GranterGrants(ctx, &authz.QueryGranterGrantsRequest{
+			Granter:    string(granter),
+			Pagination: pagination,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not query authz grants: %v", err)
+		}
+		for _, g := range resp.Grants {
+			grant, err := client.newAuthzGrant(xc.Address(g.Granter), xc.Address(g.Grantee), g.Authorization, g.Expiration)
+			if err != nil {
+				return nil, err
+			}
+			grants = append(grants, grant)
+		}
+	}
+
+	feegrantClient := feegrant.NewQueryClient(client.Ctx)
+	_ = client.Asset.GetChain().Limiter.Wait(ctx)
+	allowances := []*feegrant.Grant{}
+	if grantee != "" {
+		resp, err := feegrantClient.Allowance(ctx, &feegrant.QueryAllowanceRequest{
+			Granter: string(granter),
+			Grantee: string(grantee),
+		})
+		if err != nil && !isNotFound(err) {
+			return nil, fmt.Errorf("could not query fee allowance: %v", err)
+		}
+		if resp != nil && resp.Allowance != nil {
+			allowances = append(allowances, resp.Allowance)
+		}
+	} else {
+		resp, err := feegrantClient.AllowancesByGranter(ctx, &feegrant.QueryAllowancesByGranterRequest{
+			Granter:    string(granter),
+			Pagination: pagination,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not query fee allowances: %v", err)
+		}
+		allowances = append(allowances, resp.Allowances...)
+	}
+	for _, a := range allowances {
+		grant, err := client.newFeegrantGrant(a)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+
+	return grants, nil
+}
+
+func (client *Client) newAuthzGrant(granter xc.Address, grantee xc.Address, authorizationAny *codectypes.Any, expiration *time.Time) (*Grant, error) {
+	if authorizationAny == nil {
+		return nil, fmt.Errorf("authz grant from %s to %s is missing an authorization", granter, grantee)
+	}
+	var authorization authz.Authorization
+	if err := client.Ctx.InterfaceRegistry.UnpackAny(authorizationAny, &authorization); err != nil {
+		return nil, fmt.Errorf("could not decode authorization %s: %v", authorizationAny.TypeUrl, err)
+	}
+	details, err := client.Ctx.Codec.MarshalJSON(authorizationAny)
+	if err != nil {
+		return nil, err
+	}
+	return &Grant{
+		Module:     GrantModuleAuthz,
+		Granter:    granter,
+		Grantee:    grantee,
+		Type:       authorizationAny.TypeUrl,
+		MsgTypeUrl: authorization.MsgTypeURL(),
+		Expiration: expiration,
+		Details:    details,
+	}, nil
+}
+
+func (client *Client) newFeegrantGrant(grant *feegrant.Grant) (*Grant, error) {
+	if grant.Allowance == nil {
+		return nil, fmt.Errorf("fee grant from %s to %s is missing an allowance", grant.Granter, grant.Grantee)
+	}
+	var allowance feegrant.FeeAllowanceI
+	if err := client.Ctx.InterfaceRegistry.UnpackAny(grant.Allowance, &allowance); err != nil {
+		return nil, fmt.Errorf("could not decode allowance %s: %v", grant.Allowance.TypeUrl, err)
+	}
+	expiration, _ := allowance.ExpiresAt()
+	details, err := client.Ctx.Codec.MarshalJSON(grant.Allowance)
+	if err != nil {
+		return nil, err
+	}
+	return &Grant{
+		Module:     GrantModuleFeegrant,
+		Granter:    xc.Address(grant.Granter),
+		Grantee:    xc.Address(grant.Grantee),
+		Type:       grant.Allowance.TypeUrl,
+		Expiration: expiration,
+		Details:    details,
+	}, nil
+}
+
+func isNotFound(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "not found")
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	banktypes "cosmossdk.io/x/bank/types"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/cosmos/builder"
+	xclient "github.com/cordialsys/crosschain/client"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	"github.com/sirupsen/logrus"
+
+	ibctransfertypes "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v8/modules/apps/transfer/types"
+)
+
+// Default timeout for IBC transfers when none is set on the arguments
+const IbcTransferTimeout = 10 * time.Minute
+
+var _ xclient.IbcClient = &Client{}
+
+func (client *Client) FetchIbcTransferInput(ctx context.Context, args xcbuilder.IbcTransferArgs) (xc.TxInput, error) {
+	contract, _ := args.GetContract()
+	baseTxInput, err := client.FetchBaseTxInput(ctx, args.GetFrom(), contract, "")
+	if err != nil {
+		return nil, err
+	}
+	baseTxInput.IbcTimeoutTimestamp = uint64(time.Now().Add(IbcTransferTimeout).UnixNano())
+
+	res, err := client.Simulate(ctx, *baseTxInput, func(input xc.TxInput) (xc.Tx, error) {
+		txBuilder, err := builder.NewTxBuilder(client.Asset.GetChain().Base())
+		if err != nil {
+			return nil, err
+		}
+		return txBuilder.IbcTransfer(args, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	client.applySimulatedGas(ctx, baseTxInput, res, args.GetFrom(), contract)
+
+	return baseTxInput, nil
+}
+
+// Lookup the base denomination of an "ibc/<hash>" voucher denomination.
+// ibc-go v10 replaced the DenomTrace query with Denom, so both are tried.
+func (client *Client) FetchIbcBaseDenom(ctx context.Context, denom string) (string, error) {
+	hash := strings.TrimPrefix(denom, ibctransfertypes.DenomPrefix+"/")
+	queryClient := ibctransfertypes.NewQueryClient(client.Ctx)
+
+	_ = client.Asset.GetChain().Limiter.Wait(ctx)
+	traceResp, traceErr := queryClient.DenomTrace(ctx, &ibctransfertypes.QueryDenomTraceRequest{Hash: hash})
+	if traceErr == nil && traceResp.DenomTrace != nil {
+		return traceResp.DenomTrace.BaseDenom, nil
+	}
+
+	_ = client.Asset.GetChain().Limiter.Wait(ctx)
+	denomResp, denomErr := queryClient.Denom(ctx, &ibctransfertypes.QueryDenomRequest{Hash: hash})
+	if denomErr == nil && denomResp.Denom != nil {
+		return denomResp.Denom.Base, nil
+	}
+	return "", fmt.Errorf("could not resolve ibc denom %s: %v; %v", denom, traceErr, denomErr)
+}
+
+// Report an IBC transfer and whether it has since been acknowledged or timed out.
+func (client *Client) fetchIbcTransferState(ctx context.Context, send IbcSendPacketEvent) *txinfo.IbcTransfer {
+	transfer := &txinfo.IbcTransfer{
+		Sequence:           send.Sequence,
+		SourcePort:         send.SourcePort,
+		SourceChannel:      send.SourceChannel,
+		DestinationPort:    send.DestinationPort,
+		DestinationChannel: send.DestinationChannel,
+		Sender:             xc.Address(send.Sender),
+		Receiver:           xc.Address(send.Receiver),
+		Denom:              send.Denom,
+		Amount:             send.Amount,
+		Memo:               send.Memo,
+		State:              txinfo.IbcPacketPending,
+	}
+
+	// The relayer submits the acknowledgement or timeout back to this chain in a later transaction.
+	for _, eventType := range []string{"acknowledge_packet", "timeout_packet"} {
+		query := fmt.Sprintf("%s.packet_src_channel='%s' AND %s.packet_sequence='%d'", eventType, send.SourceChannel, eventType, send.Sequence)
+		_ = client.Asset.GetChain().Limiter.Wait(ctx)
+		res, err := client.Ctx.Client.TxSearch(ctx, query, false, nil, nil, "")
+		if err != nil {
+			logrus.WithError(err).WithField("query", query).Debug("could not search for ibc packet result")
+			return transfer
+		}
+		for _, tx := range res.Txs {
+			if tx.TxResult.Code != 0 {
+				// e.g. redundant relay
+				continue
+			}
+			events := ParseEvents(tx.TxResult.Events)
+			for _, result := range events.IbcResults {
+				if result.Sequence != send.Sequence || result.SourceChannel != send.SourceChannel || result.SourcePort != send.SourcePort {
+					continue
+				}
+				transfer.ResolvedBy = hex.EncodeToString(tx.Hash)
+				if result.TimedOut {
+					transfer.State = txinfo.IbcPacketTimedOut
+				} else if result.Error != "" {
+					transfer.State = txinfo.IbcPacketFailed
+					transfer.Error = result.Error
+				} else {
+					transfer.State = txinfo.IbcPacketAcknowledged
+				}
+				return transfer
+			}
+		}
+	}
+	return transfer
+}
+
+// Lookup the decimals of an IBC asset from its base denomination, using the asset configuration
+// or the bank denom metadata.
+func (client *Client) decimalsFromBaseDenom(ctx context.Context, baseDenom string) (int, error) {
+	if decimals, ok := client.configuredDecimals(xc.ContractAddress(baseDenom)); ok {
+		return decimals, nil
+	}
+	_ = client.Asset.GetChain().Limiter.Wait(ctx)
+	queryClient := banktypes.NewQueryClient(client.Ctx)
+	denomMetaResponse, err := queryClient.DenomMetadata(ctx, &banktypes.QueryDenomMetadataRequest{
+		Denom: baseDenom,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unknown decimals of ibc base denom %s, it has no denom metadata or asset config: %v", baseDenom, err)
+	}
+	return metadataDecimals(denomMetaResponse.Metadata), nil
+}
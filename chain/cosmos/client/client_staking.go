@@ -68,6 +68,11 @@ func (client *Client) FetchStakingInput(ctx context.Context, args xcbuilder.Stak
 	if err != nil {
 		return nil, err
 	}
+	if grantee, ok := args.GetAuthzGrantee(); ok {
+		if err := client.useGranteeAccount(ctx, baseTxInput, grantee); err != nil {
+			return nil, err
+		}
+	}
 
 	res, err := client.Simulate(ctx, *baseTxInput, func(input xc.TxInput) (xc.Tx, error) {
 		txBuilder, err := builder.NewTxBuilder(client.Asset.GetChain().Base())
@@ -92,6 +97,11 @@ func (client *Client) FetchUnstakingInput(ctx context.Context, args xcbuilder.St
 	if err != nil {
 		return nil, err
 	}
+	if grantee, ok := args.GetAuthzGrantee(); ok {
+		if err := client.useGranteeAccount(ctx, baseTxInput, grantee); err != nil {
+			return nil, err
+		}
+	}
 
 	res, err := client.Simulate(ctx, *baseTxInput, func(input xc.TxInput) (xc.Tx, error) {
 		txBuilder, err := builder.NewTxBuilder(client.Asset.GetChain().Base())
@@ -116,6 +126,11 @@ func (client *Client) FetchWithdrawInput(ctx context.Context, args xcbuilder.Sta
 	if err != nil {
 		return nil, err
 	}
+	if grantee, ok := args.GetAuthzGrantee(); ok {
+		if err := client.useGranteeAccount(ctx, baseTxInput, grantee); err != nil {
+			return nil, err
+		}
+	}
 
 	res, err := client.Simulate(ctx, *baseTxInput, func(input xc.TxInput) (xc.Tx, error) {
 		txBuilder, err := builder.NewTxBuilder(client.Asset.GetChain().Base())
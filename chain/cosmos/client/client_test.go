@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"testing"
 
+	banktypes "cosmossdk.io/x/bank/types"
 	xc "github.com/cordialsys/crosschain"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/cosmos/client"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx_input/gas"
+	ibctransfertypes "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v8/modules/apps/transfer/types"
 	xclient "github.com/cordialsys/crosschain/client"
 	"github.com/cordialsys/crosschain/client/errors"
 	txinfo "github.com/cordialsys/crosschain/client/tx_info"
@@ -785,3 +787,70 @@ func TestFetchBalance(t *testing.T) {
 		}
 	}
 }
+
+func TestFetchDecimalsIbc(t *testing.T) {
+	// responses are formatted with the id of their request
+	abciResponse := func(msg interface{ Marshal() ([]byte, error) }) string {
+		bz, err := msg.Marshal()
+		require.NoError(t, err)
+		return `{"jsonrpc":"2.0","id":%d,"result":{"response":{"code":0,"log":"","info":"","index":"0","key":null,"value":"` + base64.StdEncoding.EncodeToString(bz) + `","proofOps":null,"height":"1","codespace":""}}}`
+	}
+	notFound := `{"jsonrpc":"2.0","id":%d,"result":{"response":{"code":1,"log":"not found","info":"","index":"0","key":null,"value":"","proofOps":null,"height":"1","codespace":""}}}`
+	denomTrace := abciResponse(&ibctransfertypes.QueryDenomTraceResponse{
+		DenomTrace: &ibctransfertypes.DenomTrace{Path: "transfer/channel-0", BaseDenom: "uosmo"},
+	})
+	// the voucher has no metadata, and is not a cw20 or peggy asset
+	lookups := []string{notFound, notFound, notFound, denomTrace}
+
+	vectors := []struct {
+		name         string
+		nativeAssets []*xc.AdditionalNativeAsset
+		resp         []string
+		decimals     int
+		err          string
+	}{
+		{
+			name:         "asset config",
+			nativeAssets: []*xc.AdditionalNativeAsset{xc.NewAdditionalNativeAsset("OSMO", "", "uosmo", 6, xc.AmountHumanReadable{})},
+			resp:         lookups,
+			decimals:     6,
+		},
+		{
+			name: "bank metadata",
+			resp: append(lookups, abciResponse(&banktypes.QueryDenomMetadataResponse{Metadata: banktypes.Metadata{
+				Base:       "uosmo",
+				DenomUnits: []*banktypes.DenomUnit{{Denom: "uosmo", Exponent: 0}, {Denom: "osmo", Exponent: 6}},
+			}})),
+			decimals: 6,
+		},
+		{
+			name: "unknown",
+			resp: append(lookups, notFound),
+			err:  "unknown decimals of ibc base denom uosmo",
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			resp := make([]string, len(v.resp))
+			for i, r := range v.resp {
+				resp[i] = fmt.Sprintf(r, i)
+			}
+			server, close := testtypes.MockJSONRPC(t, resp)
+			defer close()
+
+			asset := xc.NewChainConfig(xc.ATOM).WithChainCoin("uatom").WithChainPrefix("cosmos").WithUrl(server.URL)
+			asset.NativeAssets = v.nativeAssets
+			asset.Limiter = rate.NewLimiter(rate.Inf, 1)
+			client, err := client.NewClient(asset)
+			require.NoError(t, err)
+
+			decimals, err := client.FetchDecimals(context.Background(), "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2")
+			if v.err != "" {
+				require.ErrorContains(t, err, v.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, v.decimals, decimals)
+			}
+		})
+	}
+}
@@ -2,8 +2,14 @@ package client
 
 import (
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
 	"strings"
 
+	"cosmossdk.io/x/authz"
+	banktypes "cosmossdk.io/x/bank/types"
+	stakingtypes "cosmossdk.io/x/staking/types"
 	comettypes "github.com/cometbft/cometbft/abci/types"
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cosmos/cosmos-sdk/types"
@@ -44,6 +50,41 @@ type UnbondEvent struct {
 	Contract  string
 }
 
+// ICS-20 packet sent to another chain
+type IbcSendPacketEvent struct {
+	EventIndex
+	Sequence           uint64
+	SourcePort         string
+	SourceChannel      string
+	DestinationPort    string
+	DestinationChannel string
+	Sender             string
+	Receiver           string
+	Denom              string
+	Amount             xc.AmountBlockchain
+	Memo               string
+}
+
+// Acknowledgement or timeout of a packet previously sent from this chain
+type IbcPacketResultEvent struct {
+	EventIndex
+	Sequence      uint64
+	SourcePort    string
+	SourceChannel string
+	TimedOut      bool
+	// Set if the receiving chain acknowledged the packet with an error
+	Error string
+}
+
+// ICS-20 packet data, JSON encoded in the "packet_data" attribute
+type fungibleTokenPacketData struct {
+	Denom    string `json:"denom"`
+	Amount   string `json:"amount"`
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	Memo     string `json:"memo"`
+}
+
 type Fee struct {
 	EventIndex
 	Amount xc.AmountBlockchain
@@ -64,6 +105,9 @@ type ParsedMsgEvents struct {
 	Withdraws []WithdrawRewardsEvent
 	Delegates []DelegateEvent
 	Unbonds   []UnbondEvent
+
+	IbcSends   []IbcSendPacketEvent
+	IbcResults []IbcPacketResultEvent
 }
 
 type ParsedTxEvents struct {
@@ -97,6 +141,11 @@ func getEventOrZero(events comettypes.Event, key string) string {
 	return v
 }
 
+func parseUint(value string) uint64 {
+	v, _ := strconv.ParseUint(value, 10, 64)
+	return v
+}
+
 func ParseEvents(events []comettypes.Event) ParsedEvents {
 	for _, event := range events {
 		// cosmos-sdk natively base64 encodes everything so we unwrap that in place
@@ -197,6 +246,57 @@ func parseMsgEvents(events []comettypes.Event) ParsedMsgEvents {
 			parseEvents.Unbonds = amounts
 		}
 
+		if event.Type == "send_packet" {
+			send := IbcSendPacketEvent{
+				EventIndex:         EventIndex{Index: i},
+				Sequence:           parseUint(getEventOrZero(event, "packet_sequence")),
+				SourcePort:         getEventOrZero(event, "packet_src_port"),
+				SourceChannel:      getEventOrZero(event, "packet_src_channel"),
+				DestinationPort:    getEventOrZero(event, "packet_dst_port"),
+				DestinationChannel: getEventOrZero(event, "packet_dst_channel"),
+				Amount:             xc.NewAmountBlockchainFromUint64(0),
+			}
+			packetData := []byte(getEventOrZero(event, "packet_data"))
+			if len(packetData) == 0 {
+				packetData, _ = hex.DecodeString(getEventOrZero(event, "packet_data_hex"))
+			}
+			var data fungibleTokenPacketData
+			if err := json.Unmarshal(packetData, &data); err == nil {
+				send.Sender = data.Sender
+				send.Receiver = data.Receiver
+				send.Denom = data.Denom
+				send.Amount = xc.NewAmountBlockchainFromStr(data.Amount)
+				send.Memo = data.Memo
+			}
+			parseEvents.IbcSends = append(parseEvents.IbcSends, send)
+		}
+		if event.Type == "ibc_transfer" && len(parseEvents.IbcSends) > 0 {
+			// emitted after send_packet; fills in the transfer if the packet data could not be decoded
+			send := &parseEvents.IbcSends[len(parseEvents.IbcSends)-1]
+			if send.Receiver == "" {
+				send.Sender = getEventOrZero(event, "sender")
+				send.Receiver = getEventOrZero(event, "receiver")
+				send.Denom = getEventOrZero(event, "denom")
+				send.Amount = xc.NewAmountBlockchainFromStr(getEventOrZero(event, "amount"))
+				send.Memo = getEventOrZero(event, "memo")
+			}
+		}
+		if event.Type == "acknowledge_packet" || event.Type == "timeout_packet" {
+			parseEvents.IbcResults = append(parseEvents.IbcResults, IbcPacketResultEvent{
+				EventIndex:    EventIndex{Index: i},
+				Sequence:      parseUint(getEventOrZero(event, "packet_sequence")),
+				SourcePort:    getEventOrZero(event, "packet_src_port"),
+				SourceChannel: getEventOrZero(event, "packet_src_channel"),
+				TimedOut:      event.Type == "timeout_packet",
+			})
+		}
+		if event.Type == "fungible_token_packet" && len(parseEvents.IbcResults) > 0 {
+			// emitted by the transfer module after acknowledge_packet
+			if ackErr, ok := getEvent(event, "error"); ok {
+				parseEvents.IbcResults[len(parseEvents.IbcResults)-1].Error = ackErr
+			}
+		}
+
 		if event.Type == "wasm" {
 			var amounts []TransferEvent
 			action, _ := getEvent(event, "action")
@@ -252,3 +352,93 @@ func parseTxEvents(events []comettypes.Event) ParsedTxEvents {
 	}
 	return parseEvents
 }
+
+// ParseExecMsgs parses the messages wrapped by any x/authz MsgExec.  The events emitted
+// by an exec'd message do not always identify the granter, so the inner messages are used instead.
+func ParseExecMsgs(msgs []types.Msg) ParsedMsgEvents {
+	parsed := ParsedMsgEvents{}
+	for i, msg := range msgs {
+		exec, ok := msg.(*authz.MsgExec)
+		if !ok {
+			continue
+		}
+		innerMsgs, err := exec.GetMessages()
+		if err != nil {
+			continue
+		}
+		for _, inner := range innerMsgs {
+			switch inner := inner.(type) {
+			case *banktypes.MsgSend:
+				for _, coin := range inner.Amount {
+					parsed.Transfers = append(parsed.Transfers, TransferEvent{
+						EventIndex: EventIndex{Index: i},
+						Amount:     xc.AmountBlockchain(*coin.Amount.BigInt()),
+						Contract:   coin.Denom,
+						Recipient:  inner.ToAddress,
+						Sender:     inner.FromAddress,
+					})
+				}
+			case *stakingtypes.MsgDelegate:
+				parsed.Delegates = append(parsed.Delegates, DelegateEvent{
+					EventIndex: EventIndex{Index: i},
+					Amount:     xc.AmountBlockchain(*inner.Amount.Amount.BigInt()),
+					Contract:   inner.Amount.Denom,
+					Validator:  inner.ValidatorAddress,
+					Delegator:  inner.DelegatorAddress,
+				})
+			case *stakingtypes.MsgUndelegate:
+				parsed.Unbonds = append(parsed.Unbonds, UnbondEvent{
+					EventIndex: EventIndex{Index: i},
+					Amount:     xc.AmountBlockchain(*inner.Amount.Amount.BigInt()),
+					Contract:   inner.Amount.Denom,
+					Validator:  inner.ValidatorAddress,
+					Delegator:  inner.DelegatorAddress,
+				})
+			}
+		}
+	}
+	return parsed
+}
+
+// Merge in the exec'd message events that are not already reported by the tx events
+func (parsed *ParsedMsgEvents) MergeExecMsgs(exec ParsedMsgEvents) {
+	for _, tf := range exec.Transfers {
+		found := false
+		for _, existing := range parsed.Transfers {
+			if existing.Sender == tf.Sender && existing.Recipient == tf.Recipient &&
+				existing.Contract == tf.Contract && existing.Amount.Cmp(&tf.Amount) == 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			parsed.Transfers = append(parsed.Transfers, tf)
+		}
+	}
+	for _, del := range exec.Delegates {
+		found := false
+		for _, existing := range parsed.Delegates {
+			if existing.Delegator == del.Delegator && existing.Validator == del.Validator &&
+				existing.Contract == del.Contract && existing.Amount.Cmp(&del.Amount) == 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			parsed.Delegates = append(parsed.Delegates, del)
+		}
+	}
+	for _, unbond := range exec.Unbonds {
+		found := false
+		for _, existing := range parsed.Unbonds {
+			if existing.Delegator == unbond.Delegator && existing.Validator == unbond.Validator &&
+				existing.Contract == unbond.Contract && existing.Amount.Cmp(&unbond.Amount) == 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			parsed.Unbonds = append(parsed.Unbonds, unbond)
+		}
+	}
+}
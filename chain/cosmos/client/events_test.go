@@ -0,0 +1,121 @@
+package client_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"cosmossdk.io/x/authz"
+	banktypes "cosmossdk.io/x/bank/types"
+	comettypes "github.com/cometbft/cometbft/abci/types"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/cosmos/client"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newEvent(eventType string, attrs ...string) comettypes.Event {
+	ev := comettypes.Event{Type: eventType}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		ev.Attributes = append(ev.Attributes, comettypes.EventAttribute{Key: attrs[i], Value: attrs[i+1]})
+	}
+	return ev
+}
+
+func TestParseIbcSendEvents(t *testing.T) {
+	events := client.ParseEvents([]comettypes.Event{
+		newEvent("tx", "fee", "5000uatom", "fee_payer", "cosmos1sender"),
+		newEvent("message", "action", "/ibc.applications.transfer.v1.MsgTransfer"),
+		newEvent("transfer", "recipient", "cosmos1escrow", "sender", "cosmos1sender", "amount", "1000uatom"),
+		newEvent("send_packet",
+			"packet_data", `{"amount":"1000","denom":"uatom","receiver":"osmo1receiver","sender":"cosmos1sender","memo":"123"}`,
+			"packet_timeout_height", "0-0",
+			"packet_timeout_timestamp", "1700000000000000000",
+			"packet_sequence", "42",
+			"packet_src_port", "transfer",
+			"packet_src_channel", "channel-141",
+			"packet_dst_port", "transfer",
+			"packet_dst_channel", "channel-0",
+		),
+		newEvent("ibc_transfer", "sender", "cosmos1sender", "receiver", "osmo1receiver", "amount", "1000", "denom", "uatom"),
+	})
+
+	require.Len(t, events.IbcSends, 1)
+	send := events.IbcSends[0]
+	require.EqualValues(t, 42, send.Sequence)
+	require.Equal(t, "transfer", send.SourcePort)
+	require.Equal(t, "channel-141", send.SourceChannel)
+	require.Equal(t, "channel-0", send.DestinationChannel)
+	require.Equal(t, "cosmos1sender", send.Sender)
+	require.Equal(t, "osmo1receiver", send.Receiver)
+	require.Equal(t, "uatom", send.Denom)
+	require.Equal(t, xc.NewAmountBlockchainFromUint64(1000), send.Amount)
+	require.Equal(t, "123", send.Memo)
+	require.Empty(t, events.IbcResults)
+
+	// packet data is not available (ics20-v2), fallback to the ibc_transfer event
+	events = client.ParseEvents([]comettypes.Event{
+		newEvent("message", "action", "/ibc.applications.transfer.v1.MsgTransfer"),
+		newEvent("send_packet", "packet_sequence", "7", "packet_src_port", "transfer", "packet_src_channel", "channel-1"),
+		newEvent("ibc_transfer", "sender", "cosmos1sender", "receiver", "osmo1receiver", "amount", "5", "denom", "uatom"),
+	})
+	require.Len(t, events.IbcSends, 1)
+	require.Equal(t, "osmo1receiver", events.IbcSends[0].Receiver)
+	require.Equal(t, xc.NewAmountBlockchainFromUint64(5), events.IbcSends[0].Amount)
+}
+
+func TestParseIbcResultEvents(t *testing.T) {
+	events := client.ParseEvents([]comettypes.Event{
+		newEvent("message", "action", "/ibc.core.channel.v1.MsgAcknowledgement"),
+		newEvent("acknowledge_packet", "packet_sequence", "42", "packet_src_port", "transfer", "packet_src_channel", "channel-141"),
+		newEvent("fungible_token_packet", "module", "transfer", "success", "\x01"),
+		newEvent("acknowledge_packet", "packet_sequence", "43", "packet_src_port", "transfer", "packet_src_channel", "channel-141"),
+		newEvent("fungible_token_packet", "module", "transfer", "error", "ABCI code: 5: error handling packet"),
+		newEvent("message", "action", "/ibc.core.channel.v1.MsgTimeout"),
+		newEvent("timeout_packet", "packet_sequence", "44", "packet_src_port", "transfer", "packet_src_channel", "channel-141"),
+	})
+
+	require.Len(t, events.IbcResults, 3)
+	require.EqualValues(t, 42, events.IbcResults[0].Sequence)
+	require.False(t, events.IbcResults[0].TimedOut)
+	require.Empty(t, events.IbcResults[0].Error)
+
+	require.EqualValues(t, 43, events.IbcResults[1].Sequence)
+	require.Equal(t, "ABCI code: 5: error handling packet", events.IbcResults[1].Error)
+
+	require.EqualValues(t, 44, events.IbcResults[2].Sequence)
+	require.True(t, events.IbcResults[2].TimedOut)
+	require.Equal(t, "channel-141", events.IbcResults[2].SourceChannel)
+}
+
+func TestParseExecMsgs(t *testing.T) {
+	send := &banktypes.MsgSend{
+		FromAddress: "cosmos1granter",
+		ToAddress:   "cosmos1recipient",
+		Amount:      types.NewCoins(types.NewCoin("uatom", sdkmath.NewInt(1000))),
+	}
+	exec := authz.NewMsgExec("cosmos1grantee", []types.Msg{send})
+	execEvents := client.ParseExecMsgs([]types.Msg{&exec})
+	require.Len(t, execEvents.Transfers, 1)
+	require.Equal(t, "cosmos1granter", execEvents.Transfers[0].Sender)
+	require.Equal(t, "cosmos1recipient", execEvents.Transfers[0].Recipient)
+	require.Equal(t, "uatom", execEvents.Transfers[0].Contract)
+	require.EqualValues(t, 1000, execEvents.Transfers[0].Amount.Uint64())
+
+	t.Run("already reported by events", func(t *testing.T) {
+		events := client.ParseEvents([]comettypes.Event{
+			newEvent("message", "action", "/cosmos.authz.v1beta1.MsgExec"),
+			newEvent("transfer", "recipient", "cosmos1recipient", "sender", "cosmos1granter", "amount", "1000uatom"),
+		})
+		events.MergeExecMsgs(execEvents)
+		require.Len(t, events.Transfers, 1)
+	})
+
+	t.Run("missing from events", func(t *testing.T) {
+		events := client.ParseEvents([]comettypes.Event{
+			newEvent("message", "action", "/cosmos.authz.v1beta1.MsgExec"),
+		})
+		events.MergeExecMsgs(execEvents)
+		require.Len(t, events.Transfers, 1)
+		require.Equal(t, "cosmos1granter", events.Transfers[0].Sender)
+	})
+}
@@ -11,6 +11,10 @@ type TxArgs struct {
 	FromPublicKey     []byte
 	FeePayer          xc.Address
 	FeePayerPublicKey []byte
+	// Pays the fees from a x/feegrant allowance, without signing
+	FeeGranter xc.Address
+	// If set, the messages are wrapped in a x/authz MsgExec signed by the grantee
+	AuthzGrantee xc.Address
 }
 
 func NewTxArgsFromTransferArgs(args xcbuilder.TransferArgs, input *tx_input.TxInput) TxArgs {
@@ -19,6 +23,8 @@ func NewTxArgsFromTransferArgs(args xcbuilder.TransferArgs, input *tx_input.TxIn
 	txArgs.FromPublicKey, _ = args.GetPublicKey()
 	txArgs.FeePayer, _ = args.GetFeePayer()
 	txArgs.FeePayerPublicKey, _ = args.GetFeePayerPublicKey()
+	txArgs.FeeGranter, _ = args.GetFeeGranter()
+	txArgs.AuthzGrantee, _ = args.GetAuthzGrantee()
 	return txArgs
 }
 func NewTxArgsFromStakingArgs(args xcbuilder.StakeArgs, input *tx_input.TxInput) TxArgs {
@@ -27,5 +33,14 @@ func NewTxArgsFromStakingArgs(args xcbuilder.StakeArgs, input *tx_input.TxInput)
 	txArgs.FromPublicKey, _ = args.GetPublicKey()
 	txArgs.FeePayer, _ = args.GetFeePayer()
 	txArgs.FeePayerPublicKey, _ = args.GetFeePayerPublicKey()
+	txArgs.FeeGranter, _ = args.GetFeeGranter()
+	txArgs.AuthzGrantee, _ = args.GetAuthzGrantee()
+	return txArgs
+}
+
+func NewTxArgsFromIbcTransferArgs(args xcbuilder.IbcTransferArgs, input *tx_input.TxInput) TxArgs {
+	txArgs := TxArgs{}
+	// The memo is set on the ICS-20 packet instead, so it is visible to the receiving chain
+	txArgs.FromPublicKey, _ = args.GetPublicKey()
 	return txArgs
 }
@@ -90,6 +90,11 @@ func (tx Tx) Sighashes() ([]*xc.SignatureRequest, error) {
 		}, nil
 	}
 
+	if tx.Args.AuthzGrantee != "" {
+		// the grantee signs on behalf of the from-address
+		return []*xc.SignatureRequest{xc.NewSignatureRequest(sighash, tx.Args.AuthzGrantee)}, nil
+	}
+
 	return []*xc.SignatureRequest{xc.NewSignatureRequest(sighash)}, nil
 }
 
@@ -185,6 +190,7 @@ func (tx Tx) BuildUnsigned() (*sdktx.SignDoc, error) {
 		Amount:   tx.Fees,
 		GasLimit: tx.Input.GasLimit,
 		Payer:    string(tx.Args.FeePayer),
+		Granter:  string(tx.Args.FeeGranter),
 	}
 	authInfo := sdktx.AuthInfo{SignerInfos: signerInfo, Fee: fee}
 
@@ -33,6 +33,8 @@ type TxInput struct {
 	GasLimit              uint64  `json:"gas_limit,omitempty"`
 	GasPrice              float64 `json:"gas_price,omitempty"`
 	TimeoutHeight         uint64  `json:"timeout_height"`
+	// Default timeout (unix nanoseconds) for IBC transfers, if no timeout is set on the arguments
+	IbcTimeoutTimestamp uint64 `json:"ibc_timeout_timestamp,omitempty"`
 
 	AssetType CosmoAssetType `json:"asset_type,omitempty"`
 	ChainId   string         `json:"chain_id,omitempty"`
@@ -14,6 +14,7 @@ import (
 	injethsecp256k1 "github.com/cordialsys/crosschain/chain/cosmos/types/InjectiveLabs/injective-core/injective-chain/crypto/ethsecp256k1"
 	injective "github.com/cordialsys/crosschain/chain/cosmos/types/InjectiveLabs/injective-core/injective-chain/types"
 	terraclassic "github.com/cordialsys/crosschain/chain/cosmos/types/classic-terra/core/v2/x/vesting/types"
+	ibctransfer "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v8/modules/apps/transfer/types"
 	"github.com/cordialsys/crosschain/chain/cosmos/types/evmos/evmos/v20/crypto/ethsecp256k1"
 	etherminttypes "github.com/cordialsys/crosschain/chain/cosmos/types/evmos/evmos/v20/types"
 	ethermintevm "github.com/cordialsys/crosschain/chain/cosmos/types/evmos/evmos/v20/x/evm/types"
@@ -31,6 +32,7 @@ func RegisterExternalInterfaces(registry codectypes.InterfaceRegistry) {
 	registerInterfacesCosmosExtra(registry)
 	registerInterfacesInjective(registry)
 	registerInterfacesWasmd(registry)
+	registerInterfacesIbc(registry)
 }
 func RegisterExternalLegacyAdmino(cdc *codec.LegacyAmino) {
 	registerLegacyAminoTerraClassic(cdc)
@@ -207,3 +209,11 @@ func registerInterfacesWasmd(registry codectypes.InterfaceRegistry) {
 	// 	&ContractMigrationAuthorization{},
 	// )
 }
+
+func registerInterfacesIbc(registry codectypes.InterfaceRegistry) {
+	// Copied from https://github.com/cosmos/ibc-go/blob/main/modules/apps/transfer/types/codec.go
+	registry.RegisterImplementations(
+		(*sdk.Msg)(nil),
+		&ibctransfer.MsgTransfer{},
+	)
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// DenomPrefix is the prefix used for internal SDK coin representation of ICS20 vouchers.
+	DenomPrefix = "ibc"
+	// PortID is the default port id that the transfer module binds to.
+	PortID = "transfer"
+)
+
+// String returns the Hop in the format:
+// <portID>/<channelID>
+func (h Hop) String() string {
+	return fmt.Sprintf("%s/%s", h.PortId, h.ChannelId)
+}
+
+// GetFullDenomPath returns the full denomination according to the ICS20 specification:
+// tracePath + "/" + baseDenom
+// If there exists no trace then the base denomination is returned.
+func (dt DenomTrace) GetFullDenomPath() string {
+	if dt.Path == "" {
+		return dt.BaseDenom
+	}
+	return dt.Path + "/" + dt.BaseDenom
+}
+
+// IBCDenom returns the ibc voucher denomination ("ibc/{hash(path/base)}") for the trace.
+func (dt DenomTrace) IBCDenom() string {
+	if dt.Path == "" {
+		return dt.BaseDenom
+	}
+	return HashDenom(dt.GetFullDenomPath())
+}
+
+// Path returns the full denomination according to the ICS20 specification.
+func (d Denom) Path() string {
+	parts := []string{}
+	for _, hop := range d.Trace {
+		parts = append(parts, hop.String())
+	}
+	parts = append(parts, d.Base)
+	return strings.Join(parts, "/")
+}
+
+// HashDenom returns the "ibc/{HASH}" voucher denomination for a full denomination path,
+// where HASH is the upper-case hex encoded sha256 of the path.
+func HashDenom(fullDenomPath string) string {
+	hash := sha256.Sum256([]byte(fullDenomPath))
+	return DenomPrefix + "/" + strings.ToUpper(hex.EncodeToString(hash[:]))
+}
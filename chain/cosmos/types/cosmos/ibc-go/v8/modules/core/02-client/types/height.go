@@ -0,0 +1,21 @@
+package types
+
+import "fmt"
+
+// NewHeight is a constructor for the IBC height type
+func NewHeight(revisionNumber, revisionHeight uint64) Height {
+	return Height{
+		RevisionNumber: revisionNumber,
+		RevisionHeight: revisionHeight,
+	}
+}
+
+// String returns a string representation of Height
+func (h Height) String() string {
+	return fmt.Sprintf("%d-%d", h.RevisionNumber, h.RevisionHeight)
+}
+
+// IsZero returns true if both the revision number and height are 0
+func (h Height) IsZero() bool {
+	return h.RevisionNumber == 0 && h.RevisionHeight == 0
+}
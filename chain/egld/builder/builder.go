@@ -2,6 +2,7 @@ package builder
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -16,6 +17,9 @@ type TxBuilder struct {
 }
 
 var _ xcbuilder.FullTransferBuilder = TxBuilder{}
+var _ xcbuilder.MultiTransfer = TxBuilder{}
+var _ xcbuilder.NftTransfer = TxBuilder{}
+var _ xcbuilder.BuilderSupportsFeePayer = TxBuilder{}
 
 func NewTxBuilder(cfgI *xc.ChainBaseConfig) (TxBuilder, error) {
 	return TxBuilder{
@@ -40,17 +44,8 @@ func (txBuilder TxBuilder) NewNativeTransfer(args xcbuilder.TransferArgs, input
 	amountBig := big.Int(args.GetAmount())
 	value := amountBig.String()
 
-	txObj := &tx.Tx{
-		Nonce:    egldInput.Nonce,
-		Value:    value,
-		Receiver: string(args.GetTo()),
-		Sender:   string(args.GetFrom()),
-		GasPrice: egldInput.GasPrice,
-		GasLimit: egldInput.GasLimit,
-		ChainID:  egldInput.ChainID,
-		Version:  egldInput.Version,
-	}
-
+	txObj := newTx(egldInput, args.GetFrom(), args.GetTo(), value, nil)
+	setRelayer(txObj, &args)
 	return txObj, nil
 }
 
@@ -60,6 +55,21 @@ func (txBuilder TxBuilder) NewTokenTransfer(args xcbuilder.TransferArgs, contrac
 		return nil, fmt.Errorf("invalid input type")
 	}
 
+	transfer, err := NewEsdtTransfer(contract, args.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Nonce != 0 {
+		// NFT/SFT/MetaESDT tokens are sent to the sender itself, which forwards them to the receiver
+		data, err := EsdtNftTransferData(args.GetTo(), transfer)
+		if err != nil {
+			return nil, err
+		}
+		txObj := newTx(egldInput, args.GetFrom(), args.GetFrom(), "0", data)
+		setRelayer(txObj, &args)
+		return txObj, nil
+	}
+
 	amountBig := big.Int(args.GetAmount())
 	amountHex := hex.EncodeToString(amountBig.Bytes())
 	if amountHex == "" {
@@ -72,21 +82,124 @@ func (txBuilder TxBuilder) NewTokenTransfer(args xcbuilder.TransferArgs, contrac
 	dataStr := fmt.Sprintf("ESDTTransfer@%s@%s", tokenHex, amountHex)
 
 	// ESDT transfers have 0 native value; transfer is encoded in data field
-	txObj := &tx.Tx{
-		Nonce:    egldInput.Nonce,
-		Value:    "0",
-		Receiver: string(args.GetTo()),
-		Sender:   string(args.GetFrom()),
-		GasPrice: egldInput.GasPrice,
-		GasLimit: egldInput.GasLimit,
-		Data:     []byte(dataStr),
-		ChainID:  egldInput.ChainID,
-		Version:  egldInput.Version,
+	txObj := newTx(egldInput, args.GetFrom(), args.GetTo(), "0", []byte(dataStr))
+	setRelayer(txObj, &args)
+	return txObj, nil
+}
+
+// MultiTransfer sends one or more tokens from a single account to a single receiver, using `MultiESDTNFTTransfer`.
+// Native EGLD may be included alongside ESDTs.
+func (txBuilder TxBuilder) MultiTransfer(args xcbuilder.MultiTransferArgs, input xc.MultiTransferInput) (xc.Tx, error) {
+	multiInput, ok := input.(*tx_input.MultiTransferInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type")
+	}
+	spenders := args.Spenders()
+	if len(spenders) != 1 {
+		return nil, errors.New("only one spender is supported for egld multi-transfers")
+	}
+	receivers := args.Receivers()
+	if len(receivers) == 0 {
+		return nil, errors.New("egld multi-transfer requires at least one receiver")
+	}
+	to := receivers[0].GetTo()
+	transfers := make([]EsdtTransfer, len(receivers))
+	for i, receiver := range receivers {
+		if receiver.GetTo() != to {
+			return nil, fmt.Errorf("egld multi-transfers must have one receiver, receiver %d is %s instead of %s", i, receiver.GetTo(), to)
+		}
+		contract, _ := receiver.GetContract()
+		transfer, err := NewEsdtTransfer(contract, receiver.GetAmount())
+		if err != nil {
+			return nil, err
+		}
+		transfers[i] = transfer
+	}
+
+	from := spenders[0].GetFrom()
+	data, err := MultiEsdtNftTransferData(to, transfers)
+	if err != nil {
+		return nil, err
+	}
+	txObj := newTx(&multiInput.TxInput, from, from, "0", data)
+	setRelayer(txObj, &args)
+	return txObj, nil
+}
+
+// NftTransfer sends NFTs or SFTs of a collection, using `ESDTNFTTransfer` for a single token
+// and `MultiESDTNFTTransfer` for several.
+func (txBuilder TxBuilder) NftTransfer(args xcbuilder.NftTransferArgs, input xc.NftTransferTxInput) (xc.Tx, error) {
+	nftInput, ok := input.(*tx_input.NftTransferInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type")
+	}
+	if args.GetStandard() != xcbuilder.NftStandardEsdt {
+		return nil, errors.New("unsupported nft standard for egld")
+	}
+	transfers := []EsdtTransfer{}
+	for _, token := range args.GetTokens() {
+		transfer, err := NewNftTransfer(args.GetContract(), token)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
 	}
 
+	var data []byte
+	var err error
+	if len(transfers) == 1 {
+		data, err = EsdtNftTransferData(args.GetTo(), transfers[0])
+	} else {
+		data, err = MultiEsdtNftTransferData(args.GetTo(), transfers)
+	}
+	if err != nil {
+		return nil, err
+	}
+	txObj := newTx(&nftInput.TxInput, args.GetFrom(), args.GetFrom(), "0", data)
+	setRelayer(txObj, &args)
 	return txObj, nil
 }
 
+// newTx creates a transaction, which is co-signed by the guardian of the sender when it's guarded.
+func newTx(input *tx_input.TxInput, from xc.Address, to xc.Address, value string, data []byte) *tx.Tx {
+	txObj := &tx.Tx{
+		Nonce:    input.Nonce,
+		Value:    value,
+		Receiver: string(to),
+		Sender:   string(from),
+		GasPrice: input.GasPrice,
+		GasLimit: input.GasLimit,
+		Data:     data,
+		ChainID:  input.ChainID,
+		Version:  input.Version,
+	}
+	if input.Guardian != "" {
+		txObj.Guardian = input.Guardian
+		txObj.Options |= tx.OptionGuarded
+		txObj.Version = max(txObj.Version, tx.VersionWithOptions)
+	}
+	return txObj
+}
+
+type feePayerArgs interface {
+	GetFeePayer() (xc.Address, bool)
+}
+
+// setRelayer makes the transaction a relayed (v3) transaction when another account pays the fee
+func setRelayer(txObj *tx.Tx, args feePayerArgs) {
+	feePayer, ok := args.GetFeePayer()
+	if !ok || string(feePayer) == txObj.Sender {
+		return
+	}
+	txObj.Relayer = string(feePayer)
+	txObj.Version = max(txObj.Version, tx.VersionWithOptions)
+}
+
+// The relayer may be any account, including one of the receivers
+func (txBuilder TxBuilder) SupportsFeePayer() xcbuilder.FeePayerType {
+	return xcbuilder.FeePayerNoConflicts
+}
+
 func (txBuilder TxBuilder) SupportsMemo() xc.MemoSupport {
 	return xc.MemoSupportNone
 }
@@ -323,3 +323,198 @@ func TestBuiltTransactionCanBeSigned(t *testing.T) {
 	require.NoError(err)
 	require.Contains(serializedJson, "signature")
 }
+
+func TestNewTokenTransferNft(t *testing.T) {
+	require := require.New(t)
+
+	cfg := &xc.ChainBaseConfig{Chain: xc.EGLD, Driver: xc.DriverEGLD}
+	txBuilder, err := builder.NewTxBuilder(cfg)
+	require.NoError(err)
+
+	input := tx_input.NewTxInput()
+	input.Nonce = 7
+	input.GasLimit = 1500000
+	input.GasPrice = 1000000000
+	input.ChainID = "1"
+	input.Version = 1
+
+	from := xc.Address("erd1r44w4rky0l29pynkp4hrmrjdhnmd5knrrmevarp6h2dg9cu74sas597hhl")
+	to := xc.Address("erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	contract := xc.ContractAddress("SFT-abcdef-0a")
+	args, err := xcbuilder.NewTransferArgs(cfg, from, to, xc.NewAmountBlockchainFromUint64(3), xcbuilder.OptionContractAddress(contract))
+	require.NoError(err)
+
+	txObj, err := txBuilder.Transfer(args, input)
+	require.NoError(err)
+	egldTx := txObj.(*tx.Tx)
+
+	// sent to the sender itself, which forwards to the receiver
+	require.Equal(string(from), egldTx.Receiver)
+	require.Equal("0", egldTx.Value)
+	toBytes, err := tx.DecodeAddress(to)
+	require.NoError(err)
+	require.Equal(
+		"ESDTNFTTransfer@"+hex.EncodeToString([]byte("SFT-abcdef"))+"@0a@03@"+hex.EncodeToString(toBytes),
+		string(egldTx.Data),
+	)
+}
+
+func TestMultiTransfer(t *testing.T) {
+	require := require.New(t)
+
+	cfg := &xc.ChainBaseConfig{Chain: xc.EGLD, Driver: xc.DriverEGLD}
+	txBuilder, err := builder.NewTxBuilder(cfg)
+	require.NoError(err)
+
+	input := tx_input.NewMultiTransferInput()
+	input.Nonce = 9
+	input.GasLimit = 2000000
+	input.GasPrice = 1000000000
+	input.ChainID = "1"
+
+	from := xc.Address("erd1r44w4rky0l29pynkp4hrmrjdhnmd5knrrmevarp6h2dg9cu74sas597hhl")
+	to := xc.Address("erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	sender, err := xcbuilder.NewSender(from, []byte{})
+	require.NoError(err)
+	egldReceiver, err := xcbuilder.NewReceiver(to, xc.NewAmountBlockchainFromUint64(256))
+	require.NoError(err)
+	tokenReceiver, err := xcbuilder.NewReceiver(to, xc.NewAmountBlockchainFromUint64(1000000), xcbuilder.OptionContractAddress("USDC-c76f1f"))
+	require.NoError(err)
+	nftReceiver, err := xcbuilder.NewReceiver(to, xc.NewAmountBlockchainFromUint64(1), xcbuilder.OptionContractAddress("NFT-123456-01"))
+	require.NoError(err)
+	args, err := xcbuilder.NewMultiTransferArgs(cfg, []*xcbuilder.Sender{sender}, []*xcbuilder.Receiver{egldReceiver, tokenReceiver, nftReceiver})
+	require.NoError(err)
+
+	txObj, err := txBuilder.MultiTransfer(*args, input)
+	require.NoError(err)
+	egldTx := txObj.(*tx.Tx)
+
+	require.Equal(string(from), egldTx.Sender)
+	require.Equal(string(from), egldTx.Receiver)
+	require.Equal("0", egldTx.Value)
+	require.Equal(uint64(9), egldTx.Nonce)
+	toBytes, err := tx.DecodeAddress(to)
+	require.NoError(err)
+	require.Equal(
+		"MultiESDTNFTTransfer@"+hex.EncodeToString(toBytes)+"@03"+
+			"@"+hex.EncodeToString([]byte("EGLD-000000"))+"@@0100"+
+			"@"+hex.EncodeToString([]byte("USDC-c76f1f"))+"@@0f4240"+
+			"@"+hex.EncodeToString([]byte("NFT-123456"))+"@01@01",
+		string(egldTx.Data),
+	)
+
+	// only one receiver per transaction
+	otherReceiver, err := xcbuilder.NewReceiver(from, xc.NewAmountBlockchainFromUint64(1))
+	require.NoError(err)
+	args, err = xcbuilder.NewMultiTransferArgs(cfg, []*xcbuilder.Sender{sender}, []*xcbuilder.Receiver{egldReceiver, otherReceiver})
+	require.NoError(err)
+	_, err = txBuilder.MultiTransfer(*args, input)
+	require.ErrorContains(err, "one receiver")
+}
+
+func TestNftTransfer(t *testing.T) {
+	require := require.New(t)
+
+	cfg := &xc.ChainBaseConfig{Chain: xc.EGLD, Driver: xc.DriverEGLD}
+	txBuilder, err := builder.NewTxBuilder(cfg)
+	require.NoError(err)
+
+	input := tx_input.NewNftTransferInput()
+	input.Nonce = 1
+	input.GasLimit = 1500000
+	input.GasPrice = 1000000000
+	input.ChainID = "1"
+
+	from := xc.Address("erd1r44w4rky0l29pynkp4hrmrjdhnmd5knrrmevarp6h2dg9cu74sas597hhl")
+	to := xc.Address("erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	toBytes, err := tx.DecodeAddress(to)
+	require.NoError(err)
+	collection := xc.ContractAddress("COL-abcdef")
+
+	args, err := xcbuilder.NewNftTransferArgs(cfg, "", from, to, collection, []xcbuilder.NftToken{xcbuilder.NewNftToken("2a")})
+	require.NoError(err)
+	require.Equal(xcbuilder.NftStandardEsdt, args.GetStandard())
+	txObj, err := txBuilder.NftTransfer(args, input)
+	require.NoError(err)
+	egldTx := txObj.(*tx.Tx)
+	require.Equal(string(from), egldTx.Receiver)
+	require.Equal(
+		"ESDTNFTTransfer@"+hex.EncodeToString([]byte(collection))+"@2a@01@"+hex.EncodeToString(toBytes),
+		string(egldTx.Data),
+	)
+
+	// several tokens, identified by nonce or full identifier
+	args, err = xcbuilder.NewNftTransferArgs(cfg, "", from, to, collection, []xcbuilder.NftToken{
+		xcbuilder.NewNftToken("2a"),
+		{TokenId: "COL-abcdef-0100", Amount: xc.NewAmountBlockchainFromUint64(5)},
+	})
+	require.NoError(err)
+	txObj, err = txBuilder.NftTransfer(args, input)
+	require.NoError(err)
+	egldTx = txObj.(*tx.Tx)
+	require.Equal(
+		"MultiESDTNFTTransfer@"+hex.EncodeToString(toBytes)+"@02"+
+			"@"+hex.EncodeToString([]byte(collection))+"@2a@01"+
+			"@"+hex.EncodeToString([]byte(collection))+"@0100@05",
+		string(egldTx.Data),
+	)
+
+	// relayed by another account
+	relayer := xc.Address("erd1quyqjzstpsxsurcszyfpx9q4zct3sxg6rvwp68slyqsjygeyy5nqhwwws4")
+	args, err = xcbuilder.NewNftTransferArgs(cfg, "", from, to, collection, []xcbuilder.NftToken{xcbuilder.NewNftToken("2a")}, xcbuilder.OptionFeePayer(relayer, []byte{}))
+	require.NoError(err)
+	txObj, err = txBuilder.NftTransfer(args, input)
+	require.NoError(err)
+	egldTx = txObj.(*tx.Tx)
+	require.Equal(string(relayer), egldTx.Relayer)
+	require.Equal(tx.VersionWithOptions, egldTx.Version)
+
+	// tokens must be of the collection
+	args, err = xcbuilder.NewNftTransferArgs(cfg, "", from, to, collection, []xcbuilder.NftToken{xcbuilder.NewNftToken("OTHER-abcdef-01")})
+	require.NoError(err)
+	_, err = txBuilder.NftTransfer(args, input)
+	require.ErrorContains(err, "is not of collection")
+}
+
+func TestRelayedGuardedTransfer(t *testing.T) {
+	require := require.New(t)
+
+	cfg := &xc.ChainBaseConfig{Chain: xc.EGLD, Driver: xc.DriverEGLD}
+	txBuilder, err := builder.NewTxBuilder(cfg)
+	require.NoError(err)
+	require.Equal(xcbuilder.FeePayerNoConflicts, txBuilder.SupportsFeePayer())
+
+	input := tx_input.NewTxInput()
+	input.Nonce = 4
+	input.GasLimit = 150000
+	input.GasPrice = 1000000000
+	input.ChainID = "1"
+	input.Version = 1
+	input.Guardian = "erd1pc83qygjzv2p29shrqv35xcur50p7gppyg3jgffxyu5zj23t9skswhsks6"
+
+	from := xc.Address("erd1r44w4rky0l29pynkp4hrmrjdhnmd5knrrmevarp6h2dg9cu74sas597hhl")
+	to := xc.Address("erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	relayer := xc.Address("erd1quyqjzstpsxsurcszyfpx9q4zct3sxg6rvwp68slyqsjygeyy5nqhwwws4")
+	args, err := xcbuilder.NewTransferArgs(cfg, from, to, xc.NewAmountBlockchainFromUint64(1000), xcbuilder.OptionFeePayer(relayer, []byte{}))
+	require.NoError(err)
+
+	txObj, err := txBuilder.Transfer(args, input)
+	require.NoError(err)
+	egldTx := txObj.(*tx.Tx)
+	require.Equal(string(relayer), egldTx.Relayer)
+	require.Equal(input.Guardian, egldTx.Guardian)
+	require.Equal(tx.OptionGuarded, egldTx.Options)
+	require.Equal(tx.VersionWithOptions, egldTx.Version)
+
+	sighashes, err := egldTx.Sighashes()
+	require.NoError(err)
+	require.Len(sighashes, 2)
+	require.Equal(relayer, sighashes[1].Signer)
+
+	// the sender paying its own fee is not relayed
+	args, err = xcbuilder.NewTransferArgs(cfg, from, to, xc.NewAmountBlockchainFromUint64(1000), xcbuilder.OptionFeePayer(from, []byte{}))
+	require.NoError(err)
+	txObj, err = txBuilder.Transfer(args, input)
+	require.NoError(err)
+	require.Empty(txObj.(*tx.Tx).Relayer)
+}
@@ -0,0 +1,121 @@
+package builder
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/egld/tx"
+)
+
+// Identifier of native EGLD in multi-token transfers
+const EgldTokenIdentifier = "EGLD-000000"
+
+// EsdtTransfer is one token of an ESDT transfer
+type EsdtTransfer struct {
+	// Ticker of a fungible token, or the collection of an NFT/SFT, e.g. "USDC-c76f1f" or "COL-abcdef"
+	Token string
+	// Non-zero for NFT/SFT/MetaESDT tokens
+	Nonce  uint64
+	Amount xc.AmountBlockchain
+}
+
+// ParseTokenIdentifier splits a token identifier into its ticker or collection, and nonce.
+// NFT/SFT identifiers have the nonce in hex appended to their collection, e.g. "COL-abcdef-0a".
+func ParseTokenIdentifier(identifier string) (string, uint64, error) {
+	parts := strings.Split(identifier, "-")
+	switch len(parts) {
+	case 2:
+		return identifier, 0, nil
+	case 3:
+		nonce, err := strconv.ParseUint(parts[2], 16, 64)
+		if err != nil || nonce == 0 {
+			return "", 0, fmt.Errorf("invalid nonce in token identifier '%s'", identifier)
+		}
+		return parts[0] + "-" + parts[1], nonce, nil
+	default:
+		return "", 0, fmt.Errorf("invalid token identifier '%s'", identifier)
+	}
+}
+
+// NewEsdtTransfer creates the transfer of a token identifier, which may be native EGLD when empty
+func NewEsdtTransfer(contract xc.ContractAddress, amount xc.AmountBlockchain) (EsdtTransfer, error) {
+	if contract == "" {
+		return EsdtTransfer{Token: EgldTokenIdentifier, Amount: amount}, nil
+	}
+	token, nonce, err := ParseTokenIdentifier(string(contract))
+	if err != nil {
+		return EsdtTransfer{}, err
+	}
+	return EsdtTransfer{Token: token, Nonce: nonce, Amount: amount}, nil
+}
+
+// NewNftTransfer creates the transfer of an NFT/SFT of a collection. The token id is either
+// the nonce in hex (e.g. "0a") or the full identifier (e.g. "COL-abcdef-0a").
+func NewNftTransfer(collection xc.ContractAddress, token xcbuilder.NftToken) (EsdtTransfer, error) {
+	if strings.Contains(token.TokenId, "-") {
+		transfer, err := NewEsdtTransfer(xc.ContractAddress(token.TokenId), token.Amount)
+		if err != nil {
+			return EsdtTransfer{}, err
+		}
+		if collection != "" && transfer.Token != string(collection) {
+			return EsdtTransfer{}, fmt.Errorf("token '%s' is not of collection '%s'", token.TokenId, collection)
+		}
+		if transfer.Nonce == 0 {
+			return EsdtTransfer{}, fmt.Errorf("token '%s' is not an nft", token.TokenId)
+		}
+		return transfer, nil
+	}
+	if collection == "" {
+		return EsdtTransfer{}, errors.New("collection is required when the token id is a nonce")
+	}
+	nonce, err := strconv.ParseUint(token.TokenId, 16, 64)
+	if err != nil || nonce == 0 {
+		return EsdtTransfer{}, fmt.Errorf("invalid nft nonce '%s'", token.TokenId)
+	}
+	return EsdtTransfer{Token: string(collection), Nonce: nonce, Amount: token.Amount}, nil
+}
+
+// Arguments are hex encoded, with numbers as minimal big-endian bytes (empty for zero)
+func encodeUint(value uint64) string {
+	return hex.EncodeToString(new(big.Int).SetUint64(value).Bytes())
+}
+
+func encodeAmount(amount xc.AmountBlockchain) string {
+	return hex.EncodeToString(amount.Int().Bytes())
+}
+
+// EsdtNftTransferData is the data of an `ESDTNFTTransfer`, which is sent to the sender itself.
+// Format: ESDTNFTTransfer@<collection>@<nonce>@<quantity>@<receiver>
+func EsdtNftTransferData(receiver xc.Address, transfer EsdtTransfer) ([]byte, error) {
+	receiverBytes, err := tx.DecodeAddress(receiver)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receiver address '%s': %v", receiver, err)
+	}
+	return []byte(fmt.Sprintf(
+		"ESDTNFTTransfer@%s@%s@%s@%s",
+		hex.EncodeToString([]byte(transfer.Token)),
+		encodeUint(transfer.Nonce),
+		encodeAmount(transfer.Amount),
+		hex.EncodeToString(receiverBytes),
+	)), nil
+}
+
+// MultiEsdtNftTransferData is the data of a `MultiESDTNFTTransfer`, which is sent to the sender itself.
+// Format: MultiESDTNFTTransfer@<receiver>@<count>(@<token>@<nonce>@<amount>)...
+func MultiEsdtNftTransferData(receiver xc.Address, transfers []EsdtTransfer) ([]byte, error) {
+	receiverBytes, err := tx.DecodeAddress(receiver)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receiver address '%s': %v", receiver, err)
+	}
+	data := fmt.Sprintf("MultiESDTNFTTransfer@%s@%s", hex.EncodeToString(receiverBytes), encodeUint(uint64(len(transfers))))
+	for _, transfer := range transfers {
+		data += fmt.Sprintf("@%s@%s@%s", hex.EncodeToString([]byte(transfer.Token)), encodeUint(transfer.Nonce), encodeAmount(transfer.Amount))
+	}
+	return []byte(data), nil
+}
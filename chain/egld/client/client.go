@@ -13,6 +13,8 @@ import (
 
 	xc "github.com/cordialsys/crosschain"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
+	egldbuilder "github.com/cordialsys/crosschain/chain/egld/builder"
+	egldtx "github.com/cordialsys/crosschain/chain/egld/tx"
 	"github.com/cordialsys/crosschain/chain/egld/tx_input"
 	"github.com/cordialsys/crosschain/chain/egld/types"
 	xclient "github.com/cordialsys/crosschain/client"
@@ -33,6 +35,8 @@ type Client struct {
 }
 
 var _ xclient.Client = &Client{}
+var _ xclient.MultiTransferClient = &Client{}
+var _ xclient.NftClient = &Client{}
 
 func NewClient(cfgI *xc.ChainConfig) (*Client, error) {
 	httpClient := cfgI.DefaultHttpClient()
@@ -131,31 +135,133 @@ func (client *Client) Post(ctx context.Context, url string, payload any, respons
 }
 
 func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+	input, config, err := client.fetchBaseInput(ctx, args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate gas limit based on transaction type
+	input.GasLimit = client.calculateGasLimit(config, args)
+	if contract, ok := args.GetContract(); ok {
+		if _, nonce, err := egldbuilder.ParseTokenIdentifier(string(contract)); err == nil && nonce != 0 {
+			input.GasLimit += EsdtNftTransferGas
+		}
+	}
+	feePayer, _ := args.GetFeePayer()
+	input.GasLimit += extraGasLimit(config, input, args.GetFrom(), feePayer)
+
+	return input, nil
+}
+
+// FetchMultiTransferInput estimates the gas of a `MultiESDTNFTTransfer` by building it
+func (client *Client) FetchMultiTransferInput(ctx context.Context, args xcbuilder.MultiTransferArgs) (xc.MultiTransferInput, error) {
+	spenders := args.Spenders()
+	if len(spenders) != 1 {
+		return nil, errors.New("only one spender is supported for egld multi-transfers")
+	}
+	from := spenders[0].GetFrom()
+	baseInput, config, err := client.fetchBaseInput(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	input := &tx_input.MultiTransferInput{TxInput: *baseInput}
+
+	builder, err := egldbuilder.NewTxBuilder(client.Asset.GetChain().Base())
+	if err != nil {
+		return nil, err
+	}
+	tx, err := builder.MultiTransfer(args, input)
+	if err != nil {
+		return nil, err
+	}
+	feePayer, _ := args.GetFeePayer()
+	input.GasLimit = dataGasLimit(config, tx) +
+		MultiEsdtNftTransferGasPerToken*uint64(len(args.Receivers())) + EsdtNftTransferGas +
+		extraGasLimit(config, baseInput, from, feePayer)
+	return input, nil
+}
+
+// FetchNftTransferInput estimates the gas of an `ESDTNFTTransfer` or `MultiESDTNFTTransfer` by building it
+func (client *Client) FetchNftTransferInput(ctx context.Context, args xcbuilder.NftTransferArgs) (xc.NftTransferTxInput, error) {
+	baseInput, config, err := client.fetchBaseInput(ctx, args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	input := &tx_input.NftTransferInput{TxInput: *baseInput}
+
+	builder, err := egldbuilder.NewTxBuilder(client.Asset.GetChain().Base())
+	if err != nil {
+		return nil, err
+	}
+	tx, err := builder.NftTransfer(args, input)
+	if err != nil {
+		return nil, err
+	}
+	feePayer, _ := args.GetFeePayer()
+	input.GasLimit = dataGasLimit(config, tx) + EsdtNftTransferGas + extraGasLimit(config, baseInput, args.GetFrom(), feePayer)
+	if tokens := len(args.GetTokens()); tokens > 1 {
+		input.GasLimit += MultiEsdtNftTransferGasPerToken * uint64(tokens)
+	}
+	return input, nil
+}
+
+// fetchBaseInput fetches the nonce and guardian of the sender, and the network parameters
+func (client *Client) fetchBaseInput(ctx context.Context, from xc.Address) (*tx_input.TxInput, *types.NetworkConfig, error) {
 	indexerUrl := client.Asset.IndexerUrl
-	path := fmt.Sprintf("%s/accounts/%s", indexerUrl, args.GetFrom())
+	path := fmt.Sprintf("%s/accounts/%s", indexerUrl, from)
 
 	var accountData types.Account
 	if err := client.Get(ctx, path, &accountData); err != nil {
-		return nil, fmt.Errorf("failed to fetch account info: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch account info: %w", err)
 	}
 
 	var networkConfig types.NetworkConfigResponse
 	path = fmt.Sprintf("%s/network/config", client.Asset.URL)
 	if err := client.Get(ctx, path, &networkConfig); err != nil {
-		return nil, fmt.Errorf("failed to fetch network config: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch network config: %w", err)
 	}
 
-	// Calculate gas limit based on transaction type
-	gasLimit := client.calculateGasLimit(&networkConfig.Data.Config, args)
-
 	input := tx_input.NewTxInput()
 	input.Nonce = accountData.Nonce
-	input.GasLimit = gasLimit
 	input.GasPrice = networkConfig.Data.Config.MinGasPrice
 	input.ChainID = networkConfig.Data.Config.ChainID
 	input.Version = 1
+	if accountData.IsGuarded {
+		if accountData.ActiveGuardianAddress == "" {
+			return nil, nil, fmt.Errorf("account %s is guarded but has no active guardian", from)
+		}
+		input.Guardian = accountData.ActiveGuardianAddress
+	}
 
-	return input, nil
+	return input, &networkConfig.Data.Config, nil
+}
+
+// Gas consumed by the built-in functions, on top of the gas for the data
+const (
+	// Base cost of `ESDTNFTTransfer` and `MultiESDTNFTTransfer`, which are executed by the sender itself
+	EsdtNftTransferGas uint64 = 1_000_000
+	// Additional cost of each token in a `MultiESDTNFTTransfer`
+	MultiEsdtNftTransferGasPerToken uint64 = 200_000
+	// Additional cost of the guardian signature
+	GuardedTxGas uint64 = 50_000
+)
+
+// extraGasLimit is the gas for the guardian and relayer, when the transaction has them
+func extraGasLimit(config *types.NetworkConfig, input *tx_input.TxInput, from xc.Address, feePayer xc.Address) uint64 {
+	gas := uint64(0)
+	if input.Guardian != "" {
+		gas += GuardedTxGas
+	}
+	// Relayed (v3) transactions pay the minimum gas limit again for the relayer
+	if feePayer != "" && feePayer != from {
+		gas += config.MinGasLimit
+	}
+	return gas
+}
+
+func dataGasLimit(config *types.NetworkConfig, tx xc.Tx) uint64 {
+	egldTx := tx.(*egldtx.Tx)
+	return config.MinGasLimit + config.GasPerDataByte*uint64(len(egldTx.Data))
 }
 
 func (client *Client) calculateGasLimit(config *types.NetworkConfig, args xcbuilder.TransferArgs) uint64 {
@@ -770,3 +770,125 @@ func TestFetchBlockMultiShard(t *testing.T) {
 	require.Equal("hash_shard2", blockInfo.SubBlocks[2].Hash)
 	require.Equal(0, len(blockInfo.SubBlocks[2].TransactionIds))
 }
+
+func newGuardedAccountServer(t *testing.T, from string, guardian string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/accounts/" + from:
+			_ = json.NewEncoder(w).Encode(types.Account{
+				Address:               from,
+				Nonce:                 12,
+				Balance:               "1000000000000000000",
+				IsGuarded:             true,
+				ActiveGuardianAddress: guardian,
+			})
+		case "/network/config":
+			configResp := types.NetworkConfigResponse{}
+			configResp.Data.Config.ChainID = "1"
+			configResp.Data.Config.MinGasPrice = 1000000000
+			configResp.Data.Config.MinGasLimit = 50000
+			configResp.Data.Config.GasPerDataByte = 1500
+			_ = json.NewEncoder(w).Encode(configResp)
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestFetchTransferInputGuardedRelayed(t *testing.T) {
+	require := require.New(t)
+
+	from := "erd1r44w4rky0l29pynkp4hrmrjdhnmd5knrrmevarp6h2dg9cu74sas597hhl"
+	guardian := "erd1pc83qygjzv2p29shrqv35xcur50p7gppyg3jgffxyu5zj23t9skswhsks6"
+	relayer := xc.Address("erd1quyqjzstpsxsurcszyfpx9q4zct3sxg6rvwp68slyqsjygeyy5nqhwwws4")
+	server := newGuardedAccountServer(t, from, guardian)
+	defer server.Close()
+
+	cfg := xc.NewChainConfig("EGLD").WithUrl(server.URL).WithIndexer("", server.URL)
+	egldClient, err := client.NewClient(cfg)
+	require.NoError(err)
+
+	args, err := xcbuilder.NewTransferArgs(
+		cfg.GetChain().Base(), xc.Address(from), "erd1receiver", xc.NewAmountBlockchainFromUint64(1),
+		xcbuilder.OptionFeePayer(relayer, []byte{}),
+	)
+	require.NoError(err)
+	input, err := egldClient.FetchTransferInput(context.Background(), args)
+	require.NoError(err)
+
+	egldInput := input.(*tx_input.TxInput)
+	require.Equal(uint64(12), egldInput.Nonce)
+	require.Equal(guardian, egldInput.Guardian)
+	// min gas, plus the guardian, plus the relayer
+	require.Equal(uint64(50000+client.GuardedTxGas+50000), egldInput.GasLimit)
+}
+
+func TestFetchNftTransferInputRelayed(t *testing.T) {
+	require := require.New(t)
+
+	from := "erd1r44w4rky0l29pynkp4hrmrjdhnmd5knrrmevarp6h2dg9cu74sas597hhl"
+	guardian := "erd1pc83qygjzv2p29shrqv35xcur50p7gppyg3jgffxyu5zj23t9skswhsks6"
+	to := xc.Address("erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	relayer := xc.Address("erd1quyqjzstpsxsurcszyfpx9q4zct3sxg6rvwp68slyqsjygeyy5nqhwwws4")
+	server := newGuardedAccountServer(t, from, guardian)
+	defer server.Close()
+
+	cfg := xc.NewChainConfig("EGLD").WithUrl(server.URL).WithIndexer("", server.URL)
+	egldClient, err := client.NewClient(cfg)
+	require.NoError(err)
+
+	tokens := []xcbuilder.NftToken{xcbuilder.NewNftToken("2a")}
+	args, err := xcbuilder.NewNftTransferArgs(cfg.GetChain().Base(), "", xc.Address(from), to, "COL-abcdef", tokens)
+	require.NoError(err)
+	input, err := egldClient.FetchNftTransferInput(context.Background(), args)
+	require.NoError(err)
+	selfPaidGas := input.(*tx_input.NftTransferInput).GasLimit
+
+	args, err = xcbuilder.NewNftTransferArgs(
+		cfg.GetChain().Base(), "", xc.Address(from), to, "COL-abcdef", tokens,
+		xcbuilder.OptionFeePayer(relayer, []byte{}),
+	)
+	require.NoError(err)
+	input, err = egldClient.FetchNftTransferInput(context.Background(), args)
+	require.NoError(err)
+
+	// the relayer pays the minimum gas limit again
+	require.Equal(selfPaidGas+50000, input.(*tx_input.NftTransferInput).GasLimit)
+}
+
+func TestFetchMultiTransferInput(t *testing.T) {
+	require := require.New(t)
+
+	from := "erd1r44w4rky0l29pynkp4hrmrjdhnmd5knrrmevarp6h2dg9cu74sas597hhl"
+	guardian := "erd1pc83qygjzv2p29shrqv35xcur50p7gppyg3jgffxyu5zj23t9skswhsks6"
+	to := xc.Address("erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	server := newGuardedAccountServer(t, from, guardian)
+	defer server.Close()
+
+	cfg := xc.NewChainConfig("EGLD").WithUrl(server.URL).WithIndexer("", server.URL)
+	egldClient, err := client.NewClient(cfg)
+	require.NoError(err)
+
+	sender, err := xcbuilder.NewSender(xc.Address(from), []byte{})
+	require.NoError(err)
+	receiver1, err := xcbuilder.NewReceiver(to, xc.NewAmountBlockchainFromUint64(10), xcbuilder.OptionContractAddress("USDC-c76f1f"))
+	require.NoError(err)
+	receiver2, err := xcbuilder.NewReceiver(to, xc.NewAmountBlockchainFromUint64(1), xcbuilder.OptionContractAddress("NFT-123456-01"))
+	require.NoError(err)
+	args, err := xcbuilder.NewMultiTransferArgs(cfg.GetChain().Base(), []*xcbuilder.Sender{sender}, []*xcbuilder.Receiver{receiver1, receiver2})
+	require.NoError(err)
+
+	input, err := egldClient.FetchMultiTransferInput(context.Background(), *args)
+	require.NoError(err)
+	multiInput := input.(*tx_input.MultiTransferInput)
+	require.Equal(uint64(12), multiInput.Nonce)
+	require.Equal(guardian, multiInput.Guardian)
+
+	// MultiESDTNFTTransfer@<receiver:64>@02@<USDC-c76f1f:22>@@0a@<NFT-123456:20>@01@01
+	dataLength := uint64(20 + 1 + 64 + 3 + 1 + 22 + 2 + 2 + 1 + 20 + 3 + 3)
+	require.Equal(
+		50000+1500*dataLength+2*client.MultiEsdtNftTransferGasPerToken+client.EsdtNftTransferGas+client.GuardedTxGas,
+		multiInput.GasLimit,
+	)
+}
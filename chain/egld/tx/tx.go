@@ -14,22 +14,37 @@ import (
 
 // Tx for EGLD (MultiversX)
 type Tx struct {
-	Nonce     uint64 `json:"nonce"`
-	Value     string `json:"value"`
-	Receiver  string `json:"receiver"`
-	Sender    string `json:"sender"`
-	GasPrice  uint64 `json:"gasPrice"`
-	GasLimit  uint64 `json:"gasLimit"`
-	Data      []byte `json:"data,omitempty"`
-	ChainID   string `json:"chainID"`
-	Version   uint32 `json:"version"`
-	Options   uint32 `json:"options,omitempty"`
-	Guardian  string `json:"guardian,omitempty"`
-	Signature string `json:"signature,omitempty"`
+	Nonce    uint64 `json:"nonce"`
+	Value    string `json:"value"`
+	Receiver string `json:"receiver"`
+	Sender   string `json:"sender"`
+	GasPrice uint64 `json:"gasPrice"`
+	GasLimit uint64 `json:"gasLimit"`
+	Data     []byte `json:"data,omitempty"`
+	ChainID  string `json:"chainID"`
+	Version  uint32 `json:"version"`
+	Options  uint32 `json:"options,omitempty"`
+	Guardian string `json:"guardian,omitempty"`
+	// Signature of the guardian, who co-signs for guarded accounts
+	GuardianSignature string `json:"guardianSignature,omitempty"`
+	// Relayer paying the fee of a relayed (v3) transaction
+	Relayer          string `json:"relayer,omitempty"`
+	RelayerSignature string `json:"relayerSignature,omitempty"`
+	Signature        string `json:"signature,omitempty"`
 }
 
+const (
+	// Version required for guarded and relayed transactions
+	VersionWithOptions uint32 = 2
+	// Option set on transactions co-signed by a guardian
+	OptionGuarded uint32 = 1 << 1
+)
+
 var _ xc.Tx = &Tx{}
 
+// The guardian co-signs after the sender
+var _ xc.TxAdditionalSighashes = &Tx{}
+
 // Hash returns the tx hash or id
 // For MultiversX, the hash is calculated as Blake2b hash of the protobuf-serialized transaction with signature included
 func (tx Tx) Hash() xc.TxHash {
@@ -111,12 +126,31 @@ func (tx *Tx) toProto(includeSignature bool) (*Transaction, error) {
 		protoTx.GuardAddr = guardianBytes
 	}
 
-	if includeSignature && tx.Signature != "" {
-		sigBytes, err := hex.DecodeString(tx.Signature)
+	if tx.Relayer != "" {
+		protoTx.Relayer, err = decodeBech32Address(tx.Relayer)
 		if err != nil {
 			return nil, err
 		}
-		protoTx.Signature = sigBytes
+	}
+
+	if includeSignature {
+		for _, sig := range []struct {
+			hex   string
+			field *[]byte
+		}{
+			{tx.Signature, &protoTx.Signature},
+			{tx.GuardianSignature, &protoTx.GuardSignature},
+			{tx.RelayerSignature, &protoTx.RelayerSignature},
+		} {
+			if sig.hex == "" {
+				continue
+			}
+			sigBytes, err := hex.DecodeString(sig.hex)
+			if err != nil {
+				return nil, err
+			}
+			*sig.field = sigBytes
+		}
 	}
 
 	return protoTx, nil
@@ -132,32 +166,72 @@ func decodeBech32Address(address string) ([]byte, error) {
 	return decoded, nil
 }
 
+// DecodeAddress decodes a bech32 address to its public key
+func DecodeAddress(address xc.Address) ([]byte, error) {
+	return decodeBech32Address(string(address))
+}
+
+// The sender, relayer and guardian all sign the JSON serialization of the transaction, without any signatures
+func (tx Tx) signingPayload() ([]byte, error) {
+	tx.Signature = ""
+	tx.GuardianSignature = ""
+	tx.RelayerSignature = ""
+	return json.Marshal(tx)
+}
+
 // Sighashes returns the tx payload to sign, aka sighash
 // MultiversX signs the JSON serialization of the transaction
 func (tx Tx) Sighashes() ([]*xc.SignatureRequest, error) {
-	jsonBytes, err := json.Marshal(tx)
+	jsonBytes, err := tx.signingPayload()
 	if err != nil {
 		return nil, err
 	}
 
-	return []*xc.SignatureRequest{
+	requests := []*xc.SignatureRequest{
 		xc.NewSignatureRequest(jsonBytes),
+	}
+	if tx.Relayer != "" {
+		requests = append(requests, xc.NewSignatureRequest(jsonBytes, xc.Address(tx.Relayer)))
+	}
+	return requests, nil
+}
+
+// AdditionalSighashes requests the guardian's signature, once the sender has signed
+func (tx Tx) AdditionalSighashes() ([]*xc.SignatureRequest, error) {
+	if tx.Guardian == "" || tx.GuardianSignature != "" || tx.Signature == "" {
+		return []*xc.SignatureRequest{}, nil
+	}
+	jsonBytes, err := tx.signingPayload()
+	if err != nil {
+		return nil, err
+	}
+	return []*xc.SignatureRequest{
+		xc.NewSignatureRequest(jsonBytes, xc.Address(tx.Guardian)),
 	}, nil
 }
 
-// SetSignatures adds a signature to Tx
+// SetSignatures adds the signatures to Tx, matching the relayer and guardian by their address
 func (tx *Tx) SetSignatures(signatures ...*xc.SignatureResponse) error {
 	if len(signatures) == 0 {
 		return errors.New("no signatures provided")
 	}
 
-	if len(signatures[0].Signature) != 64 {
-		return errors.New("invalid signature length: expected 64 bytes")
+	for _, signature := range signatures {
+		if len(signature.Signature) != 64 {
+			return errors.New("invalid signature length: expected 64 bytes")
+		}
+		// Convert signature bytes to hex string
+		sigHex := hex.EncodeToString(signature.Signature)
+		switch {
+		case tx.Relayer != "" && signature.Address == xc.Address(tx.Relayer):
+			tx.RelayerSignature = sigHex
+		case tx.Guardian != "" && signature.Address == xc.Address(tx.Guardian):
+			tx.GuardianSignature = sigHex
+		default:
+			tx.Signature = sigHex
+		}
 	}
 
-	// Convert signature bytes to hex string
-	tx.Signature = hex.EncodeToString(signatures[0].Signature)
-
 	return nil
 }
 
@@ -167,6 +241,12 @@ func (tx Tx) Serialize() ([]byte, error) {
 	if tx.Signature == "" {
 		return nil, errors.New("transaction not signed")
 	}
+	if tx.Relayer != "" && tx.RelayerSignature == "" {
+		return nil, errors.New("transaction not signed by the relayer")
+	}
+	if tx.Guardian != "" && tx.GuardianSignature == "" {
+		return nil, errors.New("transaction not signed by the guardian")
+	}
 
 	return json.Marshal(tx)
 }
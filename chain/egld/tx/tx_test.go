@@ -274,3 +274,83 @@ func TestTxSerialize(t *testing.T) {
 	require.Contains(txJson, "version")
 	require.Contains(txJson, "signature")
 }
+
+func TestTxRelayedAndGuarded(t *testing.T) {
+	require := require.New(t)
+
+	sender := "erd1r44w4rky0l29pynkp4hrmrjdhnmd5knrrmevarp6h2dg9cu74sas597hhl"
+	relayer := "erd1quyqjzstpsxsurcszyfpx9q4zct3sxg6rvwp68slyqsjygeyy5nqhwwws4"
+	guardian := "erd1pc83qygjzv2p29shrqv35xcur50p7gppyg3jgffxyu5zj23t9skswhsks6"
+	tx1 := &tx.Tx{
+		Nonce:    3,
+		Value:    "1000",
+		Receiver: "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th",
+		Sender:   sender,
+		GasPrice: 1000000000,
+		GasLimit: 150000,
+		ChainID:  "1",
+		Version:  tx.VersionWithOptions,
+		Options:  tx.OptionGuarded,
+		Guardian: guardian,
+		Relayer:  relayer,
+	}
+
+	// sender and relayer sign the same payload
+	sighashes, err := tx1.Sighashes()
+	require.NoError(err)
+	require.Len(sighashes, 2)
+	require.Equal(xc.Address(""), sighashes[0].Signer)
+	require.Equal(xc.Address(relayer), sighashes[1].Signer)
+	require.Equal(sighashes[0].Payload, sighashes[1].Payload)
+
+	var txJson map[string]interface{}
+	require.NoError(json.Unmarshal(sighashes[0].Payload, &txJson))
+	require.Equal(relayer, txJson["relayer"])
+	require.Equal(guardian, txJson["guardian"])
+	require.Equal(float64(2), txJson["options"])
+
+	// guardian signs only after the sender
+	additional, err := tx1.AdditionalSighashes()
+	require.NoError(err)
+	require.Empty(additional)
+
+	senderSig := make([]byte, 64)
+	relayerSig := make([]byte, 64)
+	guardianSig := make([]byte, 64)
+	senderSig[0], relayerSig[0], guardianSig[0] = 1, 2, 3
+	err = tx1.SetSignatures(
+		&xc.SignatureResponse{Signature: senderSig},
+		&xc.SignatureResponse{Signature: relayerSig, Address: xc.Address(relayer)},
+	)
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(senderSig), tx1.Signature)
+	require.Equal(hex.EncodeToString(relayerSig), tx1.RelayerSignature)
+
+	_, err = tx1.Serialize()
+	require.ErrorContains(err, "guardian")
+
+	additional, err = tx1.AdditionalSighashes()
+	require.NoError(err)
+	require.Len(additional, 1)
+	require.Equal(xc.Address(guardian), additional[0].Signer)
+	require.Equal(sighashes[0].Payload, additional[0].Payload)
+
+	err = tx1.SetSignatures(
+		&xc.SignatureResponse{Signature: senderSig},
+		&xc.SignatureResponse{Signature: relayerSig, Address: xc.Address(relayer)},
+		&xc.SignatureResponse{Signature: guardianSig, Address: xc.Address(guardian)},
+	)
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(guardianSig), tx1.GuardianSignature)
+
+	additional, err = tx1.AdditionalSighashes()
+	require.NoError(err)
+	require.Empty(additional)
+
+	serialized, err := tx1.Serialize()
+	require.NoError(err)
+	require.NoError(json.Unmarshal(serialized, &txJson))
+	require.Equal(hex.EncodeToString(relayerSig), txJson["relayerSignature"])
+	require.Equal(hex.EncodeToString(guardianSig), txJson["guardianSignature"])
+	require.Len(string(tx1.Hash()), 64)
+}
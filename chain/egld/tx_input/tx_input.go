@@ -27,6 +27,9 @@ type TxInput struct {
 
 	// Transaction version (minimum is 1)
 	Version uint32 `json:"version"`
+
+	// Active guardian of the sender, set when the account is guarded and the guardian must co-sign
+	Guardian string `json:"guardian,omitempty"`
 }
 
 var _ xc.TxInput = &TxInput{}
@@ -38,6 +41,8 @@ type GetAccountInfo interface {
 
 func init() {
 	registry.RegisterTxBaseInput(&TxInput{})
+	registry.RegisterTxVariantInput(&MultiTransferInput{})
+	registry.RegisterTxVariantInput(&NftTransferInput{})
 }
 
 func (input *TxInput) GetNonce() uint64 {
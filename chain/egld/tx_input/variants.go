@@ -0,0 +1,45 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+)
+
+// MultiTransferInput is for sending several tokens to a receiver using `MultiESDTNFTTransfer`
+type MultiTransferInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &MultiTransferInput{}
+var _ xc.MultiTransferInput = &MultiTransferInput{}
+
+func NewMultiTransferInput() *MultiTransferInput {
+	return &MultiTransferInput{
+		TxInput: *NewTxInput(),
+	}
+}
+
+func (*MultiTransferInput) MultiTransfer() {}
+
+func (*MultiTransferInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewMultiTransferInputType(xc.DriverEGLD, "esdt")
+}
+
+// NftTransferInput is for sending NFTs or SFTs of a collection
+type NftTransferInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &NftTransferInput{}
+var _ xc.NftTransferTxInput = &NftTransferInput{}
+
+func NewNftTransferInput() *NftTransferInput {
+	return &NftTransferInput{
+		TxInput: *NewTxInput(),
+	}
+}
+
+func (*NftTransferInput) NftTransferring() {}
+
+func (*NftTransferInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewNftTransferInputType(xc.DriverEGLD, "esdt")
+}
@@ -131,6 +131,10 @@ type SubmitTxRequest struct {
 	Options   uint32 `json:"options,omitempty"`
 	Guardian  string `json:"guardian,omitempty"`
 	Signature string `json:"signature,omitempty"`
+
+	GuardianSignature string `json:"guardianSignature,omitempty"`
+	Relayer           string `json:"relayer,omitempty"`
+	RelayerSignature  string `json:"relayerSignature,omitempty"`
 }
 
 type SubmitTxData struct {
@@ -143,6 +147,9 @@ type Account struct {
 	Address string `json:"address"`
 	Nonce   uint64 `json:"nonce"`
 	Balance string `json:"balance"`
+	// Set when a guardian must co-sign the account's transactions
+	IsGuarded             bool   `json:"isGuarded,omitempty"`
+	ActiveGuardianAddress string `json:"activeGuardianAddress,omitempty"`
 }
 
 type NetworkConfig struct {
@@ -229,18 +236,18 @@ type GatewayBlockResponse struct {
 }
 
 type GatewayBlock struct {
-	Nonce      uint64         `json:"nonce"`
-	Round      uint64         `json:"round"`
-	Epoch      uint64         `json:"epoch"`
-	Shard      uint32         `json:"shard"`
-	NumTxs     uint64         `json:"numTxs"`
-	Hash       string         `json:"hash"`
-	Timestamp  int64          `json:"timestamp"`
+	Nonce      uint64             `json:"nonce"`
+	Round      uint64             `json:"round"`
+	Epoch      uint64             `json:"epoch"`
+	Shard      uint32             `json:"shard"`
+	NumTxs     uint64             `json:"numTxs"`
+	Hash       string             `json:"hash"`
+	Timestamp  int64              `json:"timestamp"`
 	MiniBlocks []GatewayMiniBlock `json:"miniBlocks"`
 }
 
 type GatewayMiniBlock struct {
-	Hash         string                 `json:"hash"`
+	Hash         string               `json:"hash"`
 	Transactions []GatewayTransaction `json:"transactions"`
 }
 
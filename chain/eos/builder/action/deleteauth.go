@@ -0,0 +1,28 @@
+package action
+
+import (
+	eos "github.com/cordialsys/crosschain/chain/eos/eos-go"
+)
+
+// NewDeleteAuth removes a permission from an account.  The permission must not have any children or linked actions.
+func NewDeleteAuth(account, permission string, authorizer string) (*eos.Action, error) {
+	da := DeleteAuth{
+		Account:    eos.AccountName(account),
+		Permission: eos.PermissionName(permission),
+	}
+
+	return &eos.Action{
+		Account: eos.AccountName("eosio"),
+		Name:    eos.ActN("deleteauth"),
+		Authorization: []eos.PermissionLevel{
+			{Actor: eos.AccountName(account), Permission: eos.PermissionName(authorizer)},
+		},
+		ActionData: eos.NewActionData(da),
+	}, nil
+}
+
+// DeleteAuth represents the `deleteauth` struct on the `eosio` contract.
+type DeleteAuth struct {
+	Account    eos.AccountName    `json:"account"`
+	Permission eos.PermissionName `json:"permission"`
+}
@@ -0,0 +1,33 @@
+package action
+
+import (
+	eos "github.com/cordialsys/crosschain/chain/eos/eos-go"
+)
+
+// NewLinkAuth requires the `requirement` permission of the account to authorize the action on the contract.
+// An empty action type links all of the contract's actions.
+func NewLinkAuth(account, code, actionType, requirement string, authorizer string) (*eos.Action, error) {
+	la := LinkAuth{
+		Account:     eos.AccountName(account),
+		Code:        eos.AccountName(code),
+		Type:        eos.ActionName(actionType),
+		Requirement: eos.PermissionName(requirement),
+	}
+
+	return &eos.Action{
+		Account: eos.AccountName("eosio"),
+		Name:    eos.ActN("linkauth"),
+		Authorization: []eos.PermissionLevel{
+			{Actor: eos.AccountName(account), Permission: eos.PermissionName(authorizer)},
+		},
+		ActionData: eos.NewActionData(la),
+	}, nil
+}
+
+// LinkAuth represents the `linkauth` struct on the `eosio` contract.
+type LinkAuth struct {
+	Account     eos.AccountName    `json:"account"`
+	Code        eos.AccountName    `json:"code"`
+	Type        eos.ActionName     `json:"type"`
+	Requirement eos.PermissionName `json:"requirement"`
+}
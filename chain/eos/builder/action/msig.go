@@ -0,0 +1,84 @@
+package action
+
+import (
+	eos "github.com/cordialsys/crosschain/chain/eos/eos-go"
+)
+
+// The multisig contract
+const MsigContract = "eosio.msig"
+
+// NewPropose stores a transaction on the multisig contract, to be executed once the requested permissions approve it.
+func NewPropose(proposer, proposalName string, requested []eos.PermissionLevel, trx *eos.Transaction) (*eos.Action, error) {
+	p := Propose{
+		Proposer:     eos.AccountName(proposer),
+		ProposalName: eos.Name(proposalName),
+		Requested:    requested,
+		Trx:          trx,
+	}
+
+	return &eos.Action{
+		Account: eos.AccountName(MsigContract),
+		Name:    eos.ActN("propose"),
+		Authorization: []eos.PermissionLevel{
+			{Actor: eos.AccountName(proposer), Permission: eos.PermissionName("active")},
+		},
+		ActionData: eos.NewActionData(p),
+	}, nil
+}
+
+// NewApprove approves a proposal with one of the requested permissions, which also authorizes the action.
+func NewApprove(proposer, proposalName string, level eos.PermissionLevel) (*eos.Action, error) {
+	a := Approve{
+		Proposer:     eos.AccountName(proposer),
+		ProposalName: eos.Name(proposalName),
+		Level:        level,
+	}
+
+	return &eos.Action{
+		Account:       eos.AccountName(MsigContract),
+		Name:          eos.ActN("approve"),
+		Authorization: []eos.PermissionLevel{level},
+		ActionData:    eos.NewActionData(a),
+	}, nil
+}
+
+// NewExec executes an approved proposal.  Any account may execute it, paying for the resources.
+func NewExec(proposer, proposalName, executer string) (*eos.Action, error) {
+	e := Exec{
+		Proposer:     eos.AccountName(proposer),
+		ProposalName: eos.Name(proposalName),
+		Executer:     eos.AccountName(executer),
+	}
+
+	return &eos.Action{
+		Account: eos.AccountName(MsigContract),
+		Name:    eos.ActN("exec"),
+		Authorization: []eos.PermissionLevel{
+			{Actor: eos.AccountName(executer), Permission: eos.PermissionName("active")},
+		},
+		ActionData: eos.NewActionData(e),
+	}, nil
+}
+
+// Propose represents the `propose` struct on the `eosio.msig` contract.
+type Propose struct {
+	Proposer     eos.AccountName       `json:"proposer"`
+	ProposalName eos.Name              `json:"proposal_name"`
+	Requested    []eos.PermissionLevel `json:"requested"`
+	Trx          *eos.Transaction      `json:"trx"`
+}
+
+// Approve represents the `approve` struct on the `eosio.msig` contract.
+// The optional proposal hash extension is omitted.
+type Approve struct {
+	Proposer     eos.AccountName     `json:"proposer"`
+	ProposalName eos.Name            `json:"proposal_name"`
+	Level        eos.PermissionLevel `json:"level"`
+}
+
+// Exec represents the `exec` struct on the `eosio.msig` contract.
+type Exec struct {
+	Proposer     eos.AccountName `json:"proposer"`
+	ProposalName eos.Name        `json:"proposal_name"`
+	Executer     eos.AccountName `json:"executer"`
+}
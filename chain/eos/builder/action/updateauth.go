@@ -0,0 +1,70 @@
+package action
+
+import (
+	"bytes"
+	"sort"
+
+	eos "github.com/cordialsys/crosschain/chain/eos/eos-go"
+)
+
+// NewUpdateAuth creates or replaces the authority of an account's permission.
+// The action is authorized by the `authorizer` permission of the account, which must be the permission itself or one of its parents.
+func NewUpdateAuth(account, permission, parent string, auth eos.Authority, authorizer string) (*eos.Action, error) {
+	ua := UpdateAuth{
+		Account:    eos.AccountName(account),
+		Permission: eos.PermissionName(permission),
+		Parent:     eos.PermissionName(parent),
+		Auth:       SortAuthority(auth),
+	}
+
+	return &eos.Action{
+		Account: eos.AccountName("eosio"),
+		Name:    eos.ActN("updateauth"),
+		Authorization: []eos.PermissionLevel{
+			{Actor: eos.AccountName(account), Permission: eos.PermissionName(authorizer)},
+		},
+		ActionData: eos.NewActionData(ua),
+	}, nil
+}
+
+// UpdateAuth represents the `updateauth` struct on the `eosio` contract.
+type UpdateAuth struct {
+	Account    eos.AccountName    `json:"account"`
+	Permission eos.PermissionName `json:"permission"`
+	Parent     eos.PermissionName `json:"parent"`
+	Auth       eos.Authority      `json:"auth"`
+}
+
+// SortAuthority returns a copy of the authority with the keys, accounts and waits in the order
+// required by the chain, which rejects unsorted authorities.
+func SortAuthority(auth eos.Authority) eos.Authority {
+	sorted := eos.Authority{
+		Threshold: auth.Threshold,
+		Keys:      append([]eos.KeyWeight{}, auth.Keys...),
+		Accounts:  append([]eos.PermissionLevelWeight{}, auth.Accounts...),
+		Waits:     append([]eos.WaitWeight{}, auth.Waits...),
+	}
+	sort.SliceStable(sorted.Keys, func(i, j int) bool {
+		a, b := sorted.Keys[i].PublicKey, sorted.Keys[j].PublicKey
+		if a.Curve != b.Curve {
+			return a.Curve < b.Curve
+		}
+		return bytes.Compare(a.Content, b.Content) < 0
+	})
+	sort.SliceStable(sorted.Accounts, func(i, j int) bool {
+		a, b := sorted.Accounts[i].Permission, sorted.Accounts[j].Permission
+		if a.Actor != b.Actor {
+			return nameValue(string(a.Actor)) < nameValue(string(b.Actor))
+		}
+		return nameValue(string(a.Permission)) < nameValue(string(b.Permission))
+	})
+	sort.SliceStable(sorted.Waits, func(i, j int) bool {
+		return sorted.Waits[i].WaitSec < sorted.Waits[j].WaitSec
+	})
+	return sorted
+}
+
+func nameValue(name string) uint64 {
+	value, _ := eos.StringToName(name)
+	return value
+}
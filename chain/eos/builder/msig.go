@@ -0,0 +1,84 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/eos/builder/action"
+	"github.com/cordialsys/crosschain/chain/eos/eos-go"
+	"github.com/cordialsys/crosschain/chain/eos/tx_input"
+)
+
+// Propose proposes the actions on the multisig contract from the input's account.  The actions are executed
+// once the requested permissions approve the proposal, see Approve and Exec.
+// Each action must be authorized by permissions that the requested approvals satisfy, e.g. `account@active`
+// of a threshold controlled account, where the requested permissions are its authorities.
+func (txBuilder TxBuilder) Propose(input *tx_input.TxInput, proposalName string, requested []eos.PermissionLevel, actions []*eos.Action) (xc.Tx, error) {
+	if err := validateName(proposalName); err != nil {
+		return nil, err
+	}
+	if len(requested) == 0 {
+		return nil, fmt.Errorf("must request at least one approval")
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("must propose at least one action")
+	}
+	expiration := time.Unix(input.Timestamp, 0).Add(tx_input.ProposalExpirationPeriod)
+	proposed := &eos.Transaction{
+		TransactionHeader: eos.TransactionHeader{
+			Expiration: eos.JSONTime{Time: expiration},
+		},
+		ContextFreeActions: []*eos.Action{},
+		Actions:            actions,
+		Extensions:         []*eos.Extension{},
+	}
+	propose, err := action.NewPropose(input.FromAccount, proposalName, requested, proposed)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.newAccountTx(input, propose)
+}
+
+// Approve approves a proposal with the given permission of the input's account (`active` by default)
+func (txBuilder TxBuilder) Approve(input *tx_input.TxInput, proposer string, proposalName string, permission string) (xc.Tx, error) {
+	if err := validateName(proposalName); err != nil {
+		return nil, err
+	}
+	if permission == "" {
+		permission = ActivePermission
+	}
+	approve, err := action.NewApprove(proposer, proposalName, eos.PermissionLevel{
+		Actor:      eos.AccountName(input.FromAccount),
+		Permission: eos.PermissionName(permission),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.newAccountTx(input, approve)
+}
+
+// Exec executes an approved proposal, with the input's account paying for the resources
+func (txBuilder TxBuilder) Exec(input *tx_input.TxInput, proposer string, proposalName string) (xc.Tx, error) {
+	if err := validateName(proposalName); err != nil {
+		return nil, err
+	}
+	exec, err := action.NewExec(proposer, proposalName, input.FromAccount)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.newAccountTx(input, exec)
+}
+
+// Checks the name is a valid EOS name, (up to 12 characters, a-z, 1-5 and '.')
+func validateName(name string) error {
+	if name == "" || len(name) > 12 {
+		return fmt.Errorf("invalid name '%s', must be 1 to 12 characters", name)
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= '1' && c <= '5') && c != '.' {
+			return fmt.Errorf("invalid name '%s', may only contain a-z, 1-5 and '.'", name)
+		}
+	}
+	return nil
+}
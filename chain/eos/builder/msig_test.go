@@ -0,0 +1,108 @@
+package builder_test
+
+import (
+	"testing"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/eos/builder"
+	"github.com/cordialsys/crosschain/chain/eos/builder/action"
+	"github.com/cordialsys/crosschain/chain/eos/eos-go"
+	"github.com/cordialsys/crosschain/chain/eos/tx_input"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropose(t *testing.T) {
+	require := require.New(t)
+	builder1, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.EOS).Base())
+	input := newPermissionsInput()
+	input.FromAccount = "proposer1111"
+
+	// rotate the active authority of the threshold account
+	updateAuth, err := action.NewUpdateAuth("threshold111", "active", "owner", eos.Authority{
+		Threshold: 1,
+		Keys:      []eos.KeyWeight{{PublicKey: mustPublicKey(t, "EOS8muuPYjH9rEf4KrXzD5fnbcqzAms8pFbREGhQpUKZ3pSZGW9HN"), Weight: 1}},
+	}, "active")
+	require.NoError(err)
+	requested := []eos.PermissionLevel{
+		{Actor: "alice", Permission: "active"},
+		{Actor: "bob", Permission: "active"},
+	}
+
+	xcTx, err := builder1.Propose(input, "rotatekey", requested, []*eos.Action{updateAuth})
+	require.NoError(err)
+	actions := builtActions(t, xcTx)
+	require.Len(actions, 1)
+	require.EqualValues("eosio.msig", actions[0].Account)
+	require.EqualValues("propose", actions[0].Name)
+	require.Equal([]eos.PermissionLevel{{Actor: "proposer1111", Permission: "active"}}, actions[0].Authorization)
+
+	// decode what the contract receives
+	encoded, err := actions[0].ActionData.EncodeActionData()
+	require.NoError(err)
+	decoder := eos.NewDecoder(encoded)
+	proposer, err := decoder.ReadName()
+	require.NoError(err)
+	require.EqualValues("proposer1111", proposer)
+	proposalName, err := decoder.ReadName()
+	require.NoError(err)
+	require.EqualValues("rotatekey", proposalName)
+	decodedRequested := []eos.PermissionLevel{}
+	require.NoError(decoder.Decode(&decodedRequested))
+	require.Equal(requested, decodedRequested)
+	proposed := &eos.Transaction{}
+	require.NoError(decoder.Decode(proposed))
+
+	require.Equal(time.Unix(input.Timestamp, 0).Add(tx_input.ProposalExpirationPeriod).Unix(), proposed.Expiration.Unix())
+	require.Zero(proposed.RefBlockNum)
+	require.Zero(proposed.RefBlockPrefix)
+	require.Len(proposed.Actions, 1)
+	require.EqualValues("updateauth", proposed.Actions[0].Name)
+	require.Equal([]eos.PermissionLevel{{Actor: "threshold111", Permission: "active"}}, proposed.Actions[0].Authorization)
+	updateAuthData, err := updateAuth.ActionData.EncodeActionData()
+	require.NoError(err)
+	require.EqualValues(updateAuthData, proposed.Actions[0].HexData)
+
+	_, err = builder1.Propose(input, "Invalid", requested, []*eos.Action{updateAuth})
+	require.ErrorContains(err, "invalid name")
+	_, err = builder1.Propose(input, "rotatekey", nil, []*eos.Action{updateAuth})
+	require.ErrorContains(err, "at least one approval")
+	_, err = builder1.Propose(input, "rotatekey", requested, nil)
+	require.ErrorContains(err, "at least one action")
+}
+
+func TestApproveAndExec(t *testing.T) {
+	require := require.New(t)
+	builder1, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.EOS).Base())
+	input := newPermissionsInput()
+	input.FromAccount = "alice"
+
+	xcTx, err := builder1.Approve(input, "proposer1111", "rotatekey", "")
+	require.NoError(err)
+	actions := builtActions(t, xcTx)
+	require.Len(actions, 1)
+	require.EqualValues("approve", actions[0].Name)
+	require.Equal([]eos.PermissionLevel{{Actor: "alice", Permission: "active"}}, actions[0].Authorization)
+	require.Equal(action.Approve{
+		Proposer:     "proposer1111",
+		ProposalName: "rotatekey",
+		Level:        eos.PermissionLevel{Actor: "alice", Permission: "active"},
+	}, actions[0].Data)
+
+	// approving with another permission, which authorizes the action
+	xcTx, err = builder1.Approve(input, "proposer1111", "rotatekey", "owner")
+	require.NoError(err)
+	actions = builtActions(t, xcTx)
+	require.Equal([]eos.PermissionLevel{{Actor: "alice", Permission: "owner"}}, actions[len(actions)-1].Authorization)
+
+	xcTx, err = builder1.Exec(input, "proposer1111", "rotatekey")
+	require.NoError(err)
+	actions = builtActions(t, xcTx)
+	require.Len(actions, 1)
+	require.EqualValues("exec", actions[0].Name)
+	require.Equal(action.Exec{Proposer: "proposer1111", ProposalName: "rotatekey", Executer: "alice"}, actions[0].Data)
+	require.Equal([]eos.PermissionLevel{{Actor: "alice", Permission: "active"}}, actions[0].Authorization)
+
+	_, err = builder1.Exec(input, "proposer1111", "waytoolongname")
+	require.ErrorContains(err, "invalid name")
+}
@@ -0,0 +1,112 @@
+package builder
+
+import (
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/eos/builder/action"
+	"github.com/cordialsys/crosschain/chain/eos/eos-go"
+	"github.com/cordialsys/crosschain/chain/eos/tx"
+	"github.com/cordialsys/crosschain/chain/eos/tx_input"
+)
+
+const (
+	OwnerPermission  = "owner"
+	ActivePermission = "active"
+)
+
+// The permission of the account that can change the given permission.
+// The owner permission and the permissions under it can only be changed by owner, the others by active.
+func authorizerFor(permission, parent string) string {
+	if permission == OwnerPermission || (parent == OwnerPermission && permission != ActivePermission) {
+		return OwnerPermission
+	}
+	return ActivePermission
+}
+
+// UpdateAuth creates or replaces a permission of the input's account.
+// To change the permissions of an account controlled by a threshold, propose the `updateauth` action instead, see Propose.
+func (txBuilder TxBuilder) UpdateAuth(input *tx_input.TxInput, permission string, parent string, authority eos.Authority) (xc.Tx, error) {
+	if permission == OwnerPermission && parent != "" {
+		return nil, fmt.Errorf("the owner permission cannot have a parent")
+	}
+	if permission != OwnerPermission && parent == "" {
+		return nil, fmt.Errorf("permission '%s' must have a parent", permission)
+	}
+	if err := validateAuthority(authority); err != nil {
+		return nil, err
+	}
+	updateAuth, err := action.NewUpdateAuth(input.FromAccount, permission, parent, authority, authorizerFor(permission, parent))
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.newAccountTx(input, updateAuth)
+}
+
+// DeleteAuth removes a permission from the input's account
+func (txBuilder TxBuilder) DeleteAuth(input *tx_input.TxInput, permission string) (xc.Tx, error) {
+	if permission == OwnerPermission || permission == ActivePermission {
+		return nil, fmt.Errorf("the '%s' permission cannot be deleted", permission)
+	}
+	deleteAuth, err := action.NewDeleteAuth(input.FromAccount, permission, ActivePermission)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.newAccountTx(input, deleteAuth)
+}
+
+// LinkAuth requires the given permission of the input's account to authorize an action of a contract.
+// An empty action type links all of the contract's actions.
+func (txBuilder TxBuilder) LinkAuth(input *tx_input.TxInput, code string, actionType string, requirement string) (xc.Tx, error) {
+	if code == "" {
+		return nil, fmt.Errorf("must set the contract to link")
+	}
+	linkAuth, err := action.NewLinkAuth(input.FromAccount, code, actionType, requirement, ActivePermission)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.newAccountTx(input, linkAuth)
+}
+
+// Checks the authority can be satisfied
+func validateAuthority(authority eos.Authority) error {
+	if authority.Threshold == 0 {
+		return fmt.Errorf("authority threshold must be greater than 0")
+	}
+	total := uint64(0)
+	for _, key := range authority.Keys {
+		total += uint64(key.Weight)
+	}
+	for _, account := range authority.Accounts {
+		total += uint64(account.Weight)
+	}
+	for _, wait := range authority.Waits {
+		total += uint64(wait.Weight)
+	}
+	if total < uint64(authority.Threshold) {
+		return fmt.Errorf("authority threshold %d is greater than the total weight %d", authority.Threshold, total)
+	}
+	return nil
+}
+
+// Creates a transaction of the actions from the input's account.
+// RAM is only adjusted when the actions are authorized by the active permission, as the RAM actions need it.
+func (txBuilder TxBuilder) newAccountTx(input *tx_input.TxInput, actions ...*eos.Action) (xc.Tx, error) {
+	eosTx := newTransaction(input)
+	activeOnly := true
+	for _, act := range actions {
+		for _, auth := range act.Authorization {
+			if string(auth.Actor) != input.FromAccount || auth.Permission != ActivePermission {
+				activeOnly = false
+			}
+		}
+	}
+	if activeOnly {
+		err := buyOrSellRamIfNeeded(eosTx, input.FromAccount, input.FromAccount, input)
+		if err != nil {
+			return nil, err
+		}
+	}
+	eosTx.Actions = append(eosTx.Actions, actions...)
+	return tx.NewTx(txBuilder.Asset, input, eosTx, ""), nil
+}
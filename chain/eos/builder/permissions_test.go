@@ -0,0 +1,178 @@
+package builder_test
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/eos/builder"
+	"github.com/cordialsys/crosschain/chain/eos/builder/action"
+	"github.com/cordialsys/crosschain/chain/eos/eos-go"
+	"github.com/cordialsys/crosschain/chain/eos/eos-go/ecc"
+	"github.com/cordialsys/crosschain/chain/eos/tx"
+	"github.com/stretchr/testify/require"
+)
+
+func newPermissionsInput() *TxInput {
+	return &TxInput{
+		Timestamp:    time.Unix(1750000000, 0).Unix(),
+		ChainID:      []byte{1, 2, 3, 4, 6, 7, 8},
+		HeadBlockID:  make([]byte, 32),
+		FromAccount:  "threshold111",
+		AvailableRam: 2000,
+		TargetRam:    2000,
+	}
+}
+
+func mustPublicKey(t *testing.T, key string) ecc.PublicKey {
+	pub, err := ecc.NewPublicKey(key)
+	require.NoError(t, err)
+	return pub
+}
+
+func name(t *testing.T, s string) []byte {
+	value, err := eos.StringToName(s)
+	require.NoError(t, err)
+	return binary.LittleEndian.AppendUint64(nil, value)
+}
+
+func builtActions(t *testing.T, xcTx xc.Tx) []*eos.Action {
+	builtTx, err := xcTx.(*tx.Tx).BuildTx()
+	require.NoError(t, err)
+	return builtTx.Actions
+}
+
+func TestUpdateAuth(t *testing.T) {
+	builder1, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.EOS).Base())
+	keyA := mustPublicKey(t, "EOS8muuPYjH9rEf4KrXzD5fnbcqzAms8pFbREGhQpUKZ3pSZGW9HN")
+	keyB := mustPublicKey(t, "EOS8WvdPVMuZW8cqjN18DFfM8hGkfbswHL4zoyqNi6wQexja5bhqE")
+	authority := eos.Authority{
+		Threshold: 2,
+		Keys: []eos.KeyWeight{
+			{PublicKey: keyA, Weight: 1},
+			{PublicKey: keyB, Weight: 1},
+		},
+		Accounts: []eos.PermissionLevelWeight{
+			{Permission: eos.PermissionLevel{Actor: "bob", Permission: "active"}, Weight: 1},
+			{Permission: eos.PermissionLevel{Actor: "alice", Permission: "owner"}, Weight: 1},
+			{Permission: eos.PermissionLevel{Actor: "alice", Permission: "active"}, Weight: 1},
+		},
+	}
+
+	vectors := []struct {
+		permission string
+		parent     string
+		authority  eos.Authority
+		authorizer string
+		err        string
+	}{
+		{permission: "active", parent: "owner", authority: authority, authorizer: "active"},
+		{permission: "owner", parent: "", authority: authority, authorizer: "owner"},
+		{permission: "recovery", parent: "owner", authority: authority, authorizer: "owner"},
+		{permission: "trading", parent: "active", authority: authority, authorizer: "active"},
+		{permission: "owner", parent: "active", authority: authority, err: "cannot have a parent"},
+		{permission: "trading", parent: "", authority: authority, err: "must have a parent"},
+		{permission: "active", parent: "owner", authority: eos.Authority{Threshold: 0}, err: "greater than 0"},
+		{permission: "active", parent: "owner", authority: eos.Authority{Threshold: 5, Keys: authority.Keys}, err: "greater than the total weight 2"},
+	}
+	for _, v := range vectors {
+		t.Run(v.permission+"/"+v.parent, func(t *testing.T) {
+			require := require.New(t)
+			input := newPermissionsInput()
+			xcTx, err := builder1.UpdateAuth(input, v.permission, v.parent, v.authority)
+			if v.err != "" {
+				require.ErrorContains(err, v.err)
+				return
+			}
+			require.NoError(err)
+			actions := builtActions(t, xcTx)
+			require.Len(actions, 1)
+			require.EqualValues("updateauth", actions[0].Name)
+			require.Equal([]eos.PermissionLevel{{Actor: "threshold111", Permission: eos.PermissionName(v.authorizer)}}, actions[0].Authorization)
+
+			data := actions[0].Data.(action.UpdateAuth)
+			require.EqualValues(v.permission, data.Permission)
+			require.EqualValues(v.parent, data.Parent)
+			// sorted as required by the chain
+			require.Equal(keyB, data.Auth.Keys[0].PublicKey)
+			require.Equal(keyA, data.Auth.Keys[1].PublicKey)
+			require.Equal([]eos.PermissionLevel{
+				{Actor: "alice", Permission: "active"},
+				{Actor: "alice", Permission: "owner"},
+				{Actor: "bob", Permission: "active"},
+			}, []eos.PermissionLevel{
+				data.Auth.Accounts[0].Permission,
+				data.Auth.Accounts[1].Permission,
+				data.Auth.Accounts[2].Permission,
+			})
+			// the caller's authority is left as is
+			require.Equal(keyA, v.authority.Keys[0].PublicKey)
+		})
+	}
+}
+
+func TestUpdateAuthRam(t *testing.T) {
+	require := require.New(t)
+	builder1, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.EOS).Base())
+	authority := eos.Authority{
+		Threshold: 1,
+		Keys:      []eos.KeyWeight{{PublicKey: mustPublicKey(t, "EOS8muuPYjH9rEf4KrXzD5fnbcqzAms8pFbREGhQpUKZ3pSZGW9HN"), Weight: 1}},
+	}
+
+	input := newPermissionsInput()
+	input.AvailableRam = 100
+	xcTx, err := builder1.UpdateAuth(input, "trading", "active", authority)
+	require.NoError(err)
+	actions := builtActions(t, xcTx)
+	require.Len(actions, 2)
+	require.EqualValues("buyrambytes", actions[0].Name)
+	require.EqualValues("updateauth", actions[1].Name)
+
+	// no RAM action when authorized by owner, as it needs the active permission
+	xcTx, err = builder1.UpdateAuth(input, "owner", "", authority)
+	require.NoError(err)
+	actions = builtActions(t, xcTx)
+	require.Len(actions, 1)
+	require.EqualValues("updateauth", actions[0].Name)
+}
+
+func TestDeleteAuth(t *testing.T) {
+	require := require.New(t)
+	builder1, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.EOS).Base())
+
+	xcTx, err := builder1.DeleteAuth(newPermissionsInput(), "trading")
+	require.NoError(err)
+	actions := builtActions(t, xcTx)
+	require.Len(actions, 1)
+	require.EqualValues("deleteauth", actions[0].Name)
+	require.Equal(action.DeleteAuth{Account: "threshold111", Permission: "trading"}, actions[0].Data)
+
+	_, err = builder1.DeleteAuth(newPermissionsInput(), "active")
+	require.ErrorContains(err, "cannot be deleted")
+	_, err = builder1.DeleteAuth(newPermissionsInput(), "owner")
+	require.ErrorContains(err, "cannot be deleted")
+}
+
+func TestLinkAuth(t *testing.T) {
+	require := require.New(t)
+	builder1, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.EOS).Base())
+
+	xcTx, err := builder1.LinkAuth(newPermissionsInput(), "eosio.token", "transfer", "trading")
+	require.NoError(err)
+	actions := builtActions(t, xcTx)
+	require.Len(actions, 1)
+	require.EqualValues("eosio", actions[0].Account)
+	require.EqualValues("linkauth", actions[0].Name)
+	require.Equal([]eos.PermissionLevel{{Actor: "threshold111", Permission: "active"}}, actions[0].Authorization)
+
+	encoded, err := actions[0].ActionData.EncodeActionData()
+	require.NoError(err)
+	expected := append(name(t, "threshold111"), name(t, "eosio.token")...)
+	expected = append(expected, name(t, "transfer")...)
+	expected = append(expected, name(t, "trading")...)
+	require.Equal(expected, encoded)
+
+	_, err = builder1.LinkAuth(newPermissionsInput(), "", "transfer", "trading")
+	require.ErrorContains(err, "must set the contract")
+}
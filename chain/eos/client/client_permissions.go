@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cordialsys/crosschain/chain/eos/builder"
+	"github.com/cordialsys/crosschain/chain/eos/builder/action"
+	eos "github.com/cordialsys/crosschain/chain/eos/eos-go"
+)
+
+// Permission of an account, with the permissions under it
+type Permission struct {
+	Name          string             `json:"name"`
+	Parent        string             `json:"parent"`
+	Authority     eos.Authority      `json:"authority"`
+	LinkedActions []eos.LinkedAction `json:"linked_actions"`
+	Children      []*Permission      `json:"children"`
+}
+
+// Proposal pending on the multisig contract
+type Proposal struct {
+	Proposer    string           `json:"proposer"`
+	Name        string           `json:"name"`
+	Transaction *eos.Transaction `json:"transaction"`
+	// Permissions that have yet to approve
+	Requested []eos.PermissionLevel `json:"requested"`
+	// Permissions that have approved
+	Provided []eos.PermissionLevel `json:"provided"`
+}
+
+// FetchPermissions returns the permission tree of an account, rooted at its owner permission
+func (client *Client) FetchPermissions(ctx context.Context, account string) (*Permission, error) {
+	accountInfo, err := client.api.GetAccount(ctx, eos.AccountName(account))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account info for '%s': %v", account, err)
+	}
+	permissions := map[string]*Permission{}
+	for _, perm := range accountInfo.Permissions {
+		permissions[perm.PermName] = &Permission{
+			Name:          perm.PermName,
+			Parent:        perm.Parent,
+			Authority:     perm.RequiredAuth,
+			LinkedActions: perm.LinkedActions,
+			Children:      []*Permission{},
+		}
+	}
+	root, ok := permissions[builder.OwnerPermission]
+	if !ok {
+		return nil, fmt.Errorf("account '%s' has no owner permission", account)
+	}
+	// keep the order of the node's response
+	for _, perm := range accountInfo.Permissions {
+		if perm.PermName == builder.OwnerPermission {
+			continue
+		}
+		parent, ok := permissions[perm.Parent]
+		if !ok {
+			return nil, fmt.Errorf("permission '%s' of '%s' has unknown parent '%s'", perm.PermName, account, perm.Parent)
+		}
+		parent.Children = append(parent.Children, permissions[perm.PermName])
+	}
+	return root, nil
+}
+
+type proposalRow struct {
+	ProposalName      string       `json:"proposal_name"`
+	PackedTransaction eos.HexBytes `json:"packed_transaction"`
+}
+
+type approvalRow struct {
+	Level eos.PermissionLevel `json:"level"`
+}
+
+type approvalsRow struct {
+	ProposalName       string        `json:"proposal_name"`
+	RequestedApprovals []approvalRow `json:"requested_approvals"`
+	ProvidedApprovals  []approvalRow `json:"provided_approvals"`
+}
+
+// Before the approvals2 table, the approvals were just permission levels
+type legacyApprovalsRow struct {
+	ProposalName       string                `json:"proposal_name"`
+	RequestedApprovals []eos.PermissionLevel `json:"requested_approvals"`
+	ProvidedApprovals  []eos.PermissionLevel `json:"provided_approvals"`
+}
+
+// FetchProposals returns the proposals of a proposer that are pending on the multisig contract
+func (client *Client) FetchProposals(ctx context.Context, proposer string) ([]*Proposal, error) {
+	proposalRows := []proposalRow{}
+	if err := client.fetchTableRows(ctx, action.MsigContract, proposer, "proposal", &proposalRows); err != nil {
+		return nil, err
+	}
+	if len(proposalRows) == 0 {
+		return []*Proposal{}, nil
+	}
+	approvals := []approvalsRow{}
+	if err := client.fetchTableRows(ctx, action.MsigContract, proposer, "approvals2", &approvals); err != nil {
+		return nil, err
+	}
+	proposals := map[string]*Proposal{}
+	for _, row := range approvals {
+		proposal := &Proposal{Requested: []eos.PermissionLevel{}, Provided: []eos.PermissionLevel{}}
+		for _, approval := range row.RequestedApprovals {
+			proposal.Requested = append(proposal.Requested, approval.Level)
+		}
+		for _, approval := range row.ProvidedApprovals {
+			proposal.Provided = append(proposal.Provided, approval.Level)
+		}
+		proposals[row.ProposalName] = proposal
+	}
+	if len(approvals) < len(proposalRows) {
+		legacyApprovals := []legacyApprovalsRow{}
+		if err := client.fetchTableRows(ctx, action.MsigContract, proposer, "approvals", &legacyApprovals); err != nil {
+			return nil, err
+		}
+		for _, row := range legacyApprovals {
+			if _, ok := proposals[row.ProposalName]; ok {
+				continue
+			}
+			proposals[row.ProposalName] = &Proposal{
+				Requested: append([]eos.PermissionLevel{}, row.RequestedApprovals...),
+				Provided:  append([]eos.PermissionLevel{}, row.ProvidedApprovals...),
+			}
+		}
+	}
+
+	result := []*Proposal{}
+	for _, row := range proposalRows {
+		trx := &eos.Transaction{}
+		if err := eos.UnmarshalBinary(row.PackedTransaction, trx); err != nil {
+			return nil, fmt.Errorf("failed to decode proposal '%s': %v", row.ProposalName, err)
+		}
+		proposal, ok := proposals[row.ProposalName]
+		if !ok {
+			return nil, fmt.Errorf("no approvals found for proposal '%s'", row.ProposalName)
+		}
+		proposal.Proposer = proposer
+		proposal.Name = row.ProposalName
+		proposal.Transaction = trx
+		result = append(result, proposal)
+	}
+	return result, nil
+}
+
+// Fetches all of the rows of a contract table, following the pages
+func (client *Client) fetchTableRows(ctx context.Context, code string, scope string, table string, rows any) error {
+	all := []json.RawMessage{}
+	lowerBound := ""
+	for {
+		resp, err := client.api.GetTableRows(ctx, eos.GetTableRowsRequest{
+			Code:       code,
+			Scope:      scope,
+			Table:      table,
+			LowerBound: lowerBound,
+			Limit:      100,
+			JSON:       true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get '%s' rows of '%s': %v", table, code, err)
+		}
+		page := []json.RawMessage{}
+		if err := resp.JSONToStructs(&page); err != nil {
+			return fmt.Errorf("failed to decode '%s' rows of '%s': %v", table, code, err)
+		}
+		all = append(all, page...)
+		if !resp.More || resp.NextKey == "" || resp.NextKey == lowerBound {
+			break
+		}
+		lowerBound = resp.NextKey
+	}
+	bz, _ := json.Marshal(all)
+	return json.Unmarshal(bz, rows)
+}
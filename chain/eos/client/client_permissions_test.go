@@ -0,0 +1,123 @@
+package client_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/eos/builder/action"
+	"github.com/cordialsys/crosschain/chain/eos/client"
+	eos "github.com/cordialsys/crosschain/chain/eos/eos-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchPermissions(t *testing.T) {
+	require := require.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(strings.HasSuffix(r.URL.String(), "/v1/chain/get_account"), r.URL.String())
+		_, _ = w.Write([]byte(`{"account_name":"threshold111","permissions":[
+			{"perm_name":"active","parent":"owner","required_auth":{"threshold":2,"keys":[],"accounts":[{"permission":{"actor":"alice","permission":"active"},"weight":1},{"permission":{"actor":"bob","permission":"active"},"weight":1}],"waits":[]},"linked_actions":[]},
+			{"perm_name":"owner","parent":"","required_auth":{"threshold":1,"keys":[{"key":"EOS88T1B86LmH3GNhFfKx268N6LnFBVCGzLBWGLMZ9wAQDr6e3ezk","weight":1}],"accounts":[],"waits":[]},"linked_actions":[]},
+			{"perm_name":"trading","parent":"active","required_auth":{"threshold":1,"keys":[{"key":"EOS88T1B86LmH3GNhFfKx268N6LnFBVCGzLBWGLMZ9wAQDr6e3ezk","weight":1}],"accounts":[],"waits":[]},"linked_actions":[{"account":"eosio.token","action":"transfer"}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, _ := client.NewClient(xc.NewChainConfig(xc.EOS).WithUrl(server.URL))
+	owner, err := client.FetchPermissions(context.Background(), "threshold111")
+	require.NoError(err)
+	require.Equal("owner", owner.Name)
+	require.Len(owner.Children, 1)
+
+	active := owner.Children[0]
+	require.Equal("active", active.Name)
+	require.EqualValues(2, active.Authority.Threshold)
+	require.Len(active.Authority.Accounts, 2)
+	require.Len(active.Children, 1)
+
+	trading := active.Children[0]
+	require.Equal("trading", trading.Name)
+	require.Equal([]eos.LinkedAction{{Account: "eosio.token", Action: "transfer"}}, trading.LinkedActions)
+	require.Empty(trading.Children)
+}
+
+func TestFetchProposals(t *testing.T) {
+	require := require.New(t)
+
+	transfer, err := action.NewTransfer("threshold111", "carol", xc.NewAmountBlockchainFromUint64(10000), 4, "eosio.token", "EOS", "")
+	require.NoError(err)
+	proposed := &eos.Transaction{
+		TransactionHeader:  eos.TransactionHeader{Expiration: eos.JSONTime{Time: time.Unix(1750000000, 0).UTC()}},
+		ContextFreeActions: []*eos.Action{},
+		Actions:            []*eos.Action{transfer},
+		Extensions:         []*eos.Extension{},
+	}
+	packed, err := eos.MarshalBinary(proposed)
+	require.NoError(err)
+	packedHex := hex.EncodeToString(packed)
+
+	tables := map[string][]string{
+		// two pages
+		"proposal": {
+			`{"rows":[{"proposal_name":"payout","packed_transaction":"` + packedHex + `"}],"more":true,"next_key":"rotatekey"}`,
+			`{"rows":[{"proposal_name":"rotatekey","packed_transaction":"` + packedHex + `","earliest_exec_time":null}],"more":false,"next_key":""}`,
+		},
+		"approvals2": {
+			`{"rows":[{"version":1,"proposal_name":"payout","requested_approvals":[{"level":{"actor":"bob","permission":"active"},"time":"1970-01-01T00:00:00.000"}],"provided_approvals":[{"level":{"actor":"alice","permission":"active"},"time":"2025-06-21T20:18:42.000"}]}],"more":false,"next_key":""}`,
+		},
+		// created before the approvals2 table
+		"approvals": {
+			`{"rows":[{"proposal_name":"rotatekey","requested_approvals":[{"actor":"alice","permission":"active"},{"actor":"bob","permission":"active"}],"provided_approvals":[]}],"more":false,"next_key":""}`,
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(strings.HasSuffix(r.URL.String(), "/v1/chain/get_table_rows"), r.URL.String())
+		req := eos.GetTableRowsRequest{}
+		require.NoError(json.NewDecoder(r.Body).Decode(&req))
+		require.Equal("eosio.msig", req.Code)
+		require.Equal("proposer1111", req.Scope)
+		pages := tables[req.Table]
+		page := pages[0]
+		if req.LowerBound != "" {
+			require.Equal("rotatekey", req.LowerBound)
+			page = pages[1]
+		}
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	client, _ := client.NewClient(xc.NewChainConfig(xc.EOS).WithUrl(server.URL))
+	proposals, err := client.FetchProposals(context.Background(), "proposer1111")
+	require.NoError(err)
+	require.Len(proposals, 2)
+
+	require.Equal("payout", proposals[0].Name)
+	require.Equal("proposer1111", proposals[0].Proposer)
+	require.Equal([]eos.PermissionLevel{{Actor: "bob", Permission: "active"}}, proposals[0].Requested)
+	require.Equal([]eos.PermissionLevel{{Actor: "alice", Permission: "active"}}, proposals[0].Provided)
+	require.Len(proposals[0].Transaction.Actions, 1)
+	require.EqualValues("transfer", proposals[0].Transaction.Actions[0].Name)
+	require.Equal(int64(1750000000), proposals[0].Transaction.Expiration.Unix())
+
+	require.Equal("rotatekey", proposals[1].Name)
+	require.Len(proposals[1].Requested, 2)
+	require.Empty(proposals[1].Provided)
+}
+
+func TestFetchProposalsEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"rows":[],"more":false,"next_key":""}`))
+	}))
+	defer server.Close()
+
+	client, _ := client.NewClient(xc.NewChainConfig(xc.EOS).WithUrl(server.URL))
+	proposals, err := client.FetchProposals(context.Background(), "proposer1111")
+	require.NoError(t, err)
+	require.Empty(t, proposals)
+}
@@ -250,8 +250,9 @@ type GetTableRowsRequest struct {
 }
 
 type GetTableRowsResp struct {
-	More bool            `json:"more"`
-	Rows json.RawMessage `json:"rows"` // defer loading, as it depends on `JSON` being true/false.
+	More    bool            `json:"more"`
+	NextKey string          `json:"next_key"` // lower bound of the next page, when there are more rows
+	Rows    json.RawMessage `json:"rows"`     // defer loading, as it depends on `JSON` being true/false.
 }
 
 func (resp *GetTableRowsResp) JSONToStructs(v interface{}) error {
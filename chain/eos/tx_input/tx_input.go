@@ -12,6 +12,9 @@ import (
 const ExpirationPeriod = 5 * time.Minute
 const ExpirationGracePeriod = 90 * time.Second
 
+// Expiration period of transactions proposed to the multisig contract, matching cleos.
+const ProposalExpirationPeriod = 24 * time.Hour
+
 // The target RAM balance to try to maintain/float on transacting accounts.
 // Some transactions may require RAM if they add some new ledger entry (but not always).
 // Rather than try to simulate it to figure it out, we just maintain a target RAM balance.
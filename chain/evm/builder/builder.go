@@ -32,6 +32,8 @@ var _ xcbuilder.FullBuilder = &TxBuilder{}
 var _ xcbuilder.Staking = &TxBuilder{}
 var _ xcbuilder.BuilderSupportsFeePayer = &TxBuilder{}
 var _ xcbuilder.MultiTransfer = &TxBuilder{}
+var _ xcbuilder.Approval = &TxBuilder{}
+var _ xcbuilder.NftTransfer = &TxBuilder{}
 
 func NewEvmTxBuilder() *EvmTxBuilder {
 	return &EvmTxBuilder{}
@@ -50,10 +52,31 @@ func (txBuilder TxBuilder) SupportsFeePayer() xcbuilder.FeePayerType {
 
 // NewTransfer creates a new transfer for an Asset, either native or token
 func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
-	return tx.NewTx(txBuilder.Asset, args, input.(*tx_input.TxInput), false)
+	evmInput := input.(*tx_input.TxInput)
+	if evmInput.UserOperation != nil {
+		return txBuilder.UserOperation([]*xcbuilder.TransferArgs{&args}, evmInput)
+	}
+	return tx.NewTx(txBuilder.Asset, args, evmInput, false)
 }
 func (txBuilder TxBuilder) MultiTransfer(args xcbuilder.MultiTransferArgs, input xc.MultiTransferInput) (xc.Tx, error) {
-	return tx.NewMultiTx(txBuilder.Asset, args, &input.(*tx_input.MultiTransferInput).TxInput)
+	evmInput := &input.(*tx_input.MultiTransferInput).TxInput
+	if evmInput.UserOperation != nil {
+		transfers, err := args.AsAccountTransfers()
+		if err != nil {
+			return nil, err
+		}
+		return txBuilder.UserOperation(transfers, evmInput)
+	}
+	return tx.NewMultiTx(txBuilder.Asset, args, evmInput)
+}
+
+// Build an ERC-4337 UserOperation that has the smart account execute the transfers.
+func (txBuilder TxBuilder) UserOperation(transfers []*xcbuilder.TransferArgs, input *tx_input.TxInput) (xc.Tx, error) {
+	calls, err := tx.SmartAccountCallsFromTransfers(transfers)
+	if err != nil {
+		return nil, err
+	}
+	return tx.NewUserOperationTx(txBuilder.Asset, input, calls)
 }
 
 func (*EvmTxBuilder) BuildTxWithPayload(chain *xc.ChainBaseConfig, to xc.Address, value xc.AmountBlockchain, data []byte, inputRaw xc.TxInput) (xc.Tx, error) {
@@ -189,3 +212,31 @@ func (txBuilder TxBuilder) SupportsMemo() xc.MemoSupport {
 	// EVM does not support memo
 	return xc.MemoSupportNone
 }
+
+// Change the allowance of a spender over the owner's ERC-20 tokens
+func (txBuilder TxBuilder) Approve(args xcbuilder.ApprovalArgs, input xc.ApprovalTxInput) (xc.Tx, error) {
+	approvalInput, ok := input.(*tx_input.ApprovalInput)
+	if !ok {
+		return nil, fmt.Errorf("unsupported approval input type %T", input)
+	}
+	data, err := tx.BuildERC20ApprovalPayload(args.GetAction(), args.GetSpender(), args.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+	zero := xc.NewAmountBlockchainFromUint64(0)
+	return NewEvmTxBuilder().BuildTxWithPayload(txBuilder.Asset, xc.Address(args.GetContract()), zero, data, &approvalInput.TxInput)
+}
+
+// NftTransfer transfers ERC-721 or ERC-1155 tokens using `safeTransferFrom`
+func (txBuilder TxBuilder) NftTransfer(args xcbuilder.NftTransferArgs, input xc.NftTransferTxInput) (xc.Tx, error) {
+	nftInput, ok := input.(*tx_input.NftTransferInput)
+	if !ok {
+		return nil, fmt.Errorf("unsupported nft-transfer input type %T", input)
+	}
+	data, err := tx.BuildNftTransferPayload(args.GetStandard(), args.GetFrom(), args.GetTo(), args.GetTokens())
+	if err != nil {
+		return nil, err
+	}
+	zero := xc.NewAmountBlockchainFromUint64(0)
+	return NewEvmTxBuilder().BuildTxWithPayload(txBuilder.Asset, xc.Address(args.GetContract()), zero, data, &nftInput.TxInput)
+}
@@ -78,3 +78,34 @@ func TestUnstakingTx(t *testing.T) {
 
 	require.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(data))
 }
+
+func TestApprovalTx(t *testing.T) {
+	chain := xc.NewChainConfig("").WithChainID("5").Base()
+	txBuilder, err := builder.NewTxBuilder(chain)
+	require.NoError(t, err)
+	owner := xc.Address("0x273b437645Ba723299d07B1BdFFcf508bE64771f")
+	spender := xc.Address("0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1")
+	contract := xc.ContractAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	for _, tc := range []struct {
+		action   xcbuilder.ApprovalAction
+		amount   uint64
+		expected string
+	}{
+		{xcbuilder.ApprovalActionApprove, 100, "095ea7b30000000000000000000000005891906fef64a5ae924c7fc5ed48c0f64a55fce10000000000000000000000000000000000000000000000000000000000000064"},
+		{xcbuilder.ApprovalActionIncrease, 100, "395093510000000000000000000000005891906fef64a5ae924c7fc5ed48c0f64a55fce10000000000000000000000000000000000000000000000000000000000000064"},
+		{xcbuilder.ApprovalActionRevoke, 100, "095ea7b30000000000000000000000005891906fef64a5ae924c7fc5ed48c0f64a55fce10000000000000000000000000000000000000000000000000000000000000000"},
+	} {
+		args, err := xcbuilder.NewApprovalArgs(xc.ETH, tc.action, owner, spender, contract, xc.NewAmountBlockchainFromUint64(tc.amount))
+		require.NoError(t, err)
+		input := tx_input.NewApprovalInput()
+		input.GasLimit = 60_000
+		trans, err := txBuilder.Approve(args, input)
+		require.NoError(t, err)
+
+		ethTx := trans.(*tx.Tx).GetMockEthTx()
+		require.Equal(t, tc.expected, hex.EncodeToString(ethTx.Data()))
+		require.Equal(t, string(contract), ethTx.To().Hex())
+		require.EqualValues(t, 0, ethTx.Value().Uint64())
+	}
+}
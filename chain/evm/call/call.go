@@ -26,6 +26,8 @@ type TxCall struct {
 
 	input     *tx_input.CallInput
 	signature xc.TxSignature
+	// set when the call is executed by an ERC-4337 smart account
+	userOperation *evmtx.UserOperationTx
 }
 
 // Call is Method + https://docs.cordialapis.com/docs/treasury/jwufy9q517jj3-unsigned-evm-transaction flattened
@@ -57,7 +59,7 @@ func NewCall(cfg *xc.ChainBaseConfig, method call.Method, msg json.RawMessage, s
 	}
 	signingAddress := signingAddresses[0]
 
-	return &TxCall{cfg, method, msg, call, signingAddress, contractAddress, amount, data, input, signature}, nil
+	return &TxCall{cfg, method, msg, call, signingAddress, contractAddress, amount, data, input, signature, nil}, nil
 }
 
 func (c *TxCall) SigningAddresses() []xc.Address {
@@ -89,6 +91,21 @@ func (c *TxCall) SetInput(input xc.CallTxInput) error {
 		return fmt.Errorf("expected input type *tx_input.CallInput, got %T", input)
 	}
 	c.input = ci
+	if ci.UserOperation != nil {
+		toAddress, err := evmaddress.FromHex(xc.Address(c.contractAddress))
+		if err != nil {
+			return fmt.Errorf("invalid contract address: %w", err)
+		}
+		calls := []evmtx.SmartAccountCall{{
+			To:    toAddress,
+			Value: c.amount.ToBlockchain(c.cfg.Decimals).Int(),
+			Data:  c.data,
+		}}
+		c.userOperation, err = evmtx.NewUserOperationTx(c.cfg, &ci.TxInput, calls)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -121,6 +138,9 @@ func (tx *TxCall) BuildEthTx() (*gethtypes.Transaction, error) {
 }
 
 func (tx *TxCall) Hash() xc.TxHash {
+	if tx.userOperation != nil {
+		return tx.userOperation.Hash()
+	}
 	ethTx, err := tx.BuildEthTx()
 	if err != nil {
 		return ""
@@ -129,6 +149,9 @@ func (tx *TxCall) Hash() xc.TxHash {
 }
 
 func (tx *TxCall) Sighashes() ([]*xc.SignatureRequest, error) {
+	if tx.userOperation != nil {
+		return tx.userOperation.Sighashes()
+	}
 	ethTx, err := tx.BuildEthTx()
 	if err != nil {
 		return nil, err
@@ -138,11 +161,17 @@ func (tx *TxCall) Sighashes() ([]*xc.SignatureRequest, error) {
 }
 
 func (tx *TxCall) SetSignatures(signatures ...*xc.SignatureResponse) error {
+	if tx.userOperation != nil {
+		return tx.userOperation.SetSignatures(signatures...)
+	}
 	tx.signature = signatures[0].Signature
 	return nil
 }
 
 func (tx *TxCall) Serialize() ([]byte, error) {
+	if tx.userOperation != nil {
+		return tx.userOperation.Serialize()
+	}
 	ethTx, err := tx.BuildEthTx()
 	if err != nil {
 		return nil, err
@@ -150,6 +179,13 @@ func (tx *TxCall) Serialize() ([]byte, error) {
 	return ethTx.MarshalBinary()
 }
 
+func (tx *TxCall) GetMetadata() ([]byte, bool, error) {
+	if tx.userOperation != nil {
+		return tx.userOperation.GetMetadata()
+	}
+	return nil, false, nil
+}
+
 func (tx *TxCall) GetPayload() (xc.TxCallPayload, bool) {
 	return nil, false
 }
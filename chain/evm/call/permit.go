@@ -0,0 +1,293 @@
+package call
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/evm/address"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Canonical Uniswap Permit2 deployment, the same on all chains
+const Permit2Address = "0x000000000022D473030F116dDEE9F6B43aC78BA3"
+
+const PermitPrimaryType = "Permit"
+const PermitTransferFromPrimaryType = "PermitTransferFrom"
+
+var permitAbi abi.ABI
+
+func init() {
+	var err error
+	permitAbi, err = abi.JSON(strings.NewReader(`[{"type":"function","name":"permit","stateMutability":"nonpayable","inputs":[
+		{"name":"owner","type":"address"},
+		{"name":"spender","type":"address"},
+		{"name":"value","type":"uint256"},
+		{"name":"deadline","type":"uint256"},
+		{"name":"v","type":"uint8"},
+		{"name":"r","type":"bytes32"},
+		{"name":"s","type":"bytes32"}
+	],"outputs":[]}]`))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ERC-2612 permit, signed by the owner to approve the spender without a transaction.
+type Permit struct {
+	// The token contract, which is the verifying contract of the domain
+	Token        xc.ContractAddress  `json:"token"`
+	TokenName    string              `json:"token_name"`
+	TokenVersion string              `json:"token_version"`
+	ChainId      uint64              `json:"chain_id"`
+	Owner        xc.Address          `json:"owner"`
+	Spender      xc.Address          `json:"spender"`
+	Value        xc.AmountBlockchain `json:"value"`
+	// Current `nonces(owner)` of the token contract
+	Nonce    xc.AmountBlockchain `json:"nonce"`
+	Deadline uint64              `json:"deadline"`
+}
+
+// Permit2 signature transfer, signed by the owner to let the spender transfer tokens once.
+type PermitTransferFrom struct {
+	// The Permit2 contract, which is the verifying contract of the domain
+	Permit2 xc.ContractAddress  `json:"permit2"`
+	ChainId uint64              `json:"chain_id"`
+	Token   xc.ContractAddress  `json:"token"`
+	Amount  xc.AmountBlockchain `json:"amount"`
+	Spender xc.Address          `json:"spender"`
+	// Permit2 uses unordered nonces, any unused value may be used
+	Nonce    xc.AmountBlockchain `json:"nonce"`
+	Deadline uint64              `json:"deadline"`
+}
+
+func hexChainId(chainId uint64) *math.HexOrDecimal256 {
+	hexChainId := math.HexOrDecimal256(*new(big.Int).SetUint64(chainId))
+	return &hexChainId
+}
+
+func NewPermitTypedData(permit *Permit) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			PermitPrimaryType: []apitypes.Type{
+				{Name: "owner", Type: "address"},
+				{Name: "spender", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+		},
+		PrimaryType: PermitPrimaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:              permit.TokenName,
+			Version:           permit.TokenVersion,
+			ChainId:           hexChainId(permit.ChainId),
+			VerifyingContract: string(permit.Token),
+		},
+		Message: apitypes.TypedDataMessage{
+			"owner":    string(permit.Owner),
+			"spender":  string(permit.Spender),
+			"value":    permit.Value.String(),
+			"nonce":    permit.Nonce.String(),
+			"deadline": fmt.Sprint(permit.Deadline),
+		},
+	}
+}
+
+func NewPermitTransferFromTypedData(permit *PermitTransferFrom) apitypes.TypedData {
+	permit2 := permit.Permit2
+	if permit2 == "" {
+		permit2 = Permit2Address
+	}
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"TokenPermissions": []apitypes.Type{
+				{Name: "token", Type: "address"},
+				{Name: "amount", Type: "uint256"},
+			},
+			PermitTransferFromPrimaryType: []apitypes.Type{
+				{Name: "permitted", Type: "TokenPermissions"},
+				{Name: "spender", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+		},
+		PrimaryType: PermitTransferFromPrimaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Permit2",
+			ChainId:           hexChainId(permit.ChainId),
+			VerifyingContract: string(permit2),
+		},
+		Message: apitypes.TypedDataMessage{
+			"permitted": map[string]interface{}{
+				"token":  string(permit.Token),
+				"amount": permit.Amount.String(),
+			},
+			"spender":  string(permit.Spender),
+			"nonce":    permit.Nonce.String(),
+			"deadline": fmt.Sprint(permit.Deadline),
+		},
+	}
+}
+
+// Typed data messages may have integers as strings (decimal or hex) or JSON numbers
+func messageInteger(message map[string]interface{}, field string) (*big.Int, error) {
+	switch value := message[field].(type) {
+	case string:
+		parsed, ok := math.ParseBig256(value)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer for %s: %s", field, value)
+		}
+		return parsed, nil
+	case float64:
+		parsed, _ := new(big.Float).SetFloat64(value).Int(nil)
+		return parsed, nil
+	case json.Number:
+		parsed, ok := math.ParseBig256(value.String())
+		if !ok {
+			return nil, fmt.Errorf("invalid integer for %s: %s", field, value)
+		}
+		return parsed, nil
+	case *big.Int:
+		return value, nil
+	case nil:
+		return nil, fmt.Errorf("missing field %s", field)
+	default:
+		return nil, fmt.Errorf("invalid type %T for %s", value, field)
+	}
+}
+
+func messageAddress(message map[string]interface{}, field string) (xc.Address, error) {
+	value, ok := message[field].(string)
+	if !ok || !common.IsHexAddress(value) {
+		return "", fmt.Errorf("invalid address for %s", field)
+	}
+	return xc.Address(value), nil
+}
+
+func domainChainId(typedData apitypes.TypedData) uint64 {
+	if typedData.Domain.ChainId == nil {
+		return 0
+	}
+	return (*big.Int)(typedData.Domain.ChainId).Uint64()
+}
+
+// Decode ERC-2612 permit typed data
+func DecodePermit(typedData apitypes.TypedData) (*Permit, error) {
+	if typedData.PrimaryType != PermitPrimaryType {
+		return nil, fmt.Errorf("primary type is %s, not %s", typedData.PrimaryType, PermitPrimaryType)
+	}
+	message := typedData.Message
+	owner, err := messageAddress(message, "owner")
+	if err != nil {
+		return nil, err
+	}
+	spender, err := messageAddress(message, "spender")
+	if err != nil {
+		return nil, err
+	}
+	value, err := messageInteger(message, "value")
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := messageInteger(message, "nonce")
+	if err != nil {
+		return nil, err
+	}
+	deadline, err := messageInteger(message, "deadline")
+	if err != nil {
+		return nil, err
+	}
+	return &Permit{
+		Token:        xc.ContractAddress(typedData.Domain.VerifyingContract),
+		TokenName:    typedData.Domain.Name,
+		TokenVersion: typedData.Domain.Version,
+		ChainId:      domainChainId(typedData),
+		Owner:        owner,
+		Spender:      spender,
+		Value:        xc.AmountBlockchain(*value),
+		Nonce:        xc.AmountBlockchain(*nonce),
+		Deadline:     deadline.Uint64(),
+	}, nil
+}
+
+// Decode Permit2 `PermitTransferFrom` typed data
+func DecodePermitTransferFrom(typedData apitypes.TypedData) (*PermitTransferFrom, error) {
+	if typedData.PrimaryType != PermitTransferFromPrimaryType {
+		return nil, fmt.Errorf("primary type is %s, not %s", typedData.PrimaryType, PermitTransferFromPrimaryType)
+	}
+	message := typedData.Message
+	permitted, ok := message["permitted"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("missing field permitted")
+	}
+	token, err := messageAddress(permitted, "token")
+	if err != nil {
+		return nil, err
+	}
+	amount, err := messageInteger(permitted, "amount")
+	if err != nil {
+		return nil, err
+	}
+	spender, err := messageAddress(message, "spender")
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := messageInteger(message, "nonce")
+	if err != nil {
+		return nil, err
+	}
+	deadline, err := messageInteger(message, "deadline")
+	if err != nil {
+		return nil, err
+	}
+	return &PermitTransferFrom{
+		Permit2:  xc.ContractAddress(typedData.Domain.VerifyingContract),
+		ChainId:  domainChainId(typedData),
+		Token:    xc.ContractAddress(token),
+		Amount:   xc.AmountBlockchain(*amount),
+		Spender:  spender,
+		Nonce:    xc.AmountBlockchain(*nonce),
+		Deadline: deadline.Uint64(),
+	}, nil
+}
+
+// Calldata for submitting a signed ERC-2612 permit to the token contract
+func PermitCallData(permit *Permit, signature []byte) ([]byte, error) {
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	owner, err := address.FromHex(permit.Owner)
+	if err != nil {
+		return nil, err
+	}
+	spender, err := address.FromHex(permit.Spender)
+	if err != nil {
+		return nil, err
+	}
+	v := signature[64]
+	if v < 27 {
+		v += 27
+	}
+	var r, s [32]byte
+	copy(r[:], signature[:32])
+	copy(s[:], signature[32:64])
+	return permitAbi.Pack("permit", owner, spender, permit.Value.Int(), new(big.Int).SetUint64(permit.Deadline), v, r, s)
+}
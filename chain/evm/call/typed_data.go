@@ -0,0 +1,212 @@
+package call
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/call"
+	"github.com/cordialsys/crosschain/chain/evm/tx_input"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// TypedDataCall is an EIP-712 `eth_signTypedData_v4` request.  Nothing is sent on chain,
+// the signature is returned to the requester.
+type TypedDataCall struct {
+	cfg            *xc.ChainBaseConfig
+	method         call.Method
+	msg            json.RawMessage
+	Call           TypedDataMsg
+	TypedData      apitypes.TypedData
+	signingAddress xc.Address
+
+	input     *tx_input.CallInput
+	signature xc.TxSignature
+}
+
+type TypedDataMsg struct {
+	Method string `json:"method"`
+	// Optional account the signature is requested from
+	Account string `json:"account,omitempty"`
+	// EIP-712 typed data, as an object or as a JSON encoded string (as most dApps send it)
+	TypedData json.RawMessage `json:"typed_data"`
+}
+
+var _ xc.TxCall = &TypedDataCall{}
+
+// Create the call message for an `eth_signTypedData_v4` request
+func NewTypedDataMsg(account xc.Address, typedData apitypes.TypedData) (json.RawMessage, error) {
+	typedDataBz, err := json.Marshal(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(TypedDataMsg{
+		Method:    string(call.EthSignTypedDataV4),
+		Account:   string(account),
+		TypedData: typedDataBz,
+	})
+}
+
+func parseTypedData(raw json.RawMessage) (apitypes.TypedData, error) {
+	var typedData apitypes.TypedData
+	var asString string
+	if err := json.Unmarshal(raw, &asString); err == nil {
+		raw = json.RawMessage(asString)
+	}
+	if err := json.Unmarshal(raw, &typedData); err != nil {
+		return typedData, fmt.Errorf("could not parse typed data: %w", err)
+	}
+	return typedData, nil
+}
+
+func NewTypedDataCall(cfg *xc.ChainBaseConfig, method call.Method, msg json.RawMessage, signingAddresses []xc.Address) (*TypedDataCall, error) {
+	var typedDataMsg TypedDataMsg
+	if err := json.Unmarshal(msg, &typedDataMsg); err != nil {
+		return nil, fmt.Errorf("could not parse call: %w", err)
+	}
+	if len(typedDataMsg.TypedData) == 0 {
+		return nil, errors.New("typed data is missing")
+	}
+	typedData, err := parseTypedData(typedDataMsg.TypedData)
+	if err != nil {
+		return nil, err
+	}
+	if typedData.PrimaryType == "" {
+		return nil, errors.New("typed data is missing the primary type")
+	}
+	if _, ok := typedData.Types["EIP712Domain"]; !ok {
+		return nil, errors.New("typed data is missing the EIP712Domain type")
+	}
+	// Refuse to sign for another chain, as the signature could be replayed there.
+	if typedData.Domain.ChainId != nil {
+		if chainId, ok := cfg.ChainID.AsInt(); ok && domainChainId(typedData) != chainId {
+			return nil, fmt.Errorf("typed data is for chain-id %d, expected %d", domainChainId(typedData), chainId)
+		}
+	}
+	// Validate that it hashes
+	if _, _, err := apitypes.TypedDataAndHash(typedData); err != nil {
+		return nil, fmt.Errorf("invalid typed data: %w", err)
+	}
+
+	if len(signingAddresses) != 1 {
+		return nil, fmt.Errorf("expected exactly one signing address for EVM calls, got %d", len(signingAddresses))
+	}
+	signingAddress := signingAddresses[0]
+	if typedDataMsg.Account != "" && !strings.EqualFold(typedDataMsg.Account, string(signingAddress)) {
+		return nil, fmt.Errorf("typed data is requested to be signed by %s, not %s", typedDataMsg.Account, signingAddress)
+	}
+
+	return &TypedDataCall{
+		cfg:            cfg,
+		method:         method,
+		msg:            msg,
+		Call:           typedDataMsg,
+		TypedData:      typedData,
+		signingAddress: signingAddress,
+	}, nil
+}
+
+func (c *TypedDataCall) SigningAddresses() []xc.Address {
+	return []xc.Address{c.signingAddress}
+}
+
+func (c *TypedDataCall) ContractAddresses() []xc.ContractAddress {
+	contracts := []xc.ContractAddress{}
+	if c.TypedData.Domain.VerifyingContract != "" {
+		contracts = append(contracts, xc.ContractAddress(c.TypedData.Domain.VerifyingContract))
+	}
+	// Permit2 signatures authorize transfers of another token contract
+	if permit, err := DecodePermitTransferFrom(c.TypedData); err == nil {
+		contracts = append(contracts, permit.Token)
+	}
+	return contracts
+}
+
+func (c *TypedDataCall) GetMsg() json.RawMessage {
+	return c.msg
+}
+
+func (c *TypedDataCall) GetMethod() call.Method {
+	return c.method
+}
+
+func (c *TypedDataCall) IsRetryable() (bool, string) {
+	// Signing typed data is deterministic and has no on-chain effect.
+	return true, ""
+}
+
+func (c *TypedDataCall) SetInput(input xc.CallTxInput) error {
+	if input == nil {
+		return fmt.Errorf("input not set")
+	}
+	ci, ok := input.(*tx_input.CallInput)
+	if !ok {
+		return fmt.Errorf("expected input type *tx_input.CallInput, got %T", input)
+	}
+	c.input = ci
+	return nil
+}
+
+// Decode the typed data into a known format for review.  Returns nil if the primary type is not known.
+func (c *TypedDataCall) Decode() any {
+	if permit, err := DecodePermit(c.TypedData); err == nil {
+		return permit
+	}
+	if permit, err := DecodePermitTransferFrom(c.TypedData); err == nil {
+		return permit
+	}
+	return nil
+}
+
+func (c *TypedDataCall) typedDataHash() ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(c.TypedData)
+	return hash, err
+}
+
+// Hash returns the EIP-712 hash of the typed data
+func (c *TypedDataCall) Hash() xc.TxHash {
+	hash, err := c.typedDataHash()
+	if err != nil {
+		return ""
+	}
+	return xc.TxHash(common.BytesToHash(hash).Hex())
+}
+
+func (c *TypedDataCall) Sighashes() ([]*xc.SignatureRequest, error) {
+	hash, err := c.typedDataHash()
+	if err != nil {
+		return nil, err
+	}
+	return []*xc.SignatureRequest{xc.NewSignatureRequest(hash, c.signingAddress)}, nil
+}
+
+func (c *TypedDataCall) SetSignatures(signatures ...*xc.SignatureResponse) error {
+	if len(signatures) != 1 {
+		return fmt.Errorf("expected 1 signature, got %d", len(signatures))
+	}
+	signature := append(xc.TxSignature{}, signatures[0].Signature...)
+	if len(signature) != 65 {
+		return fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	// wallets return the legacy recovery id
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+	c.signature = signature
+	return nil
+}
+
+// Serialize returns the 65 byte signature, as returned by `eth_signTypedData_v4`
+func (c *TypedDataCall) Serialize() ([]byte, error) {
+	if len(c.signature) == 0 {
+		return nil, errors.New("typed data is not signed")
+	}
+	return c.signature, nil
+}
+
+func (c *TypedDataCall) GetPayload() (xc.TxCallPayload, bool) {
+	return nil, false
+}
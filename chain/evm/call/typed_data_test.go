@@ -0,0 +1,164 @@
+package call_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/call"
+	evmcall "github.com/cordialsys/crosschain/chain/evm/call"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// Example from https://eips.ethereum.org/EIPS/eip-712
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestTypedDataCall(t *testing.T) {
+	cfg := xc.NewChainConfig(xc.ETH).WithChainID("1").Base()
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := xc.Address(crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+
+	// typed data may be passed as an object or a JSON string
+	asString, err := json.Marshal(mailTypedData)
+	require.NoError(t, err)
+	for _, typedData := range []string{mailTypedData, string(asString)} {
+		msg := json.RawMessage(`{"method":"eth_signTypedData_v4","typed_data":` + typedData + `}`)
+		typedDataCall, err := evmcall.NewTypedDataCall(cfg, call.EthSignTypedDataV4, msg, []xc.Address{signer})
+		require.NoError(t, err)
+
+		require.Equal(t, xc.TxHash("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"), typedDataCall.Hash())
+		require.Equal(t, []xc.ContractAddress{"0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"}, typedDataCall.ContractAddresses())
+		require.Nil(t, typedDataCall.Decode())
+
+		sighashes, err := typedDataCall.Sighashes()
+		require.NoError(t, err)
+		require.Len(t, sighashes, 1)
+		require.Equal(t, signer, sighashes[0].Signer)
+
+		signature, err := crypto.Sign(sighashes[0].Payload, privateKey)
+		require.NoError(t, err)
+		require.NoError(t, typedDataCall.SetSignatures(&xc.SignatureResponse{Signature: signature}))
+
+		serialized, err := typedDataCall.Serialize()
+		require.NoError(t, err)
+		require.Len(t, serialized, 65)
+		require.GreaterOrEqual(t, serialized[64], byte(27))
+
+		recoverable := append([]byte{}, serialized...)
+		recoverable[64] -= 27
+		publicKey, err := crypto.SigToPub(sighashes[0].Payload, recoverable)
+		require.NoError(t, err)
+		require.Equal(t, string(signer), crypto.PubkeyToAddress(*publicKey).Hex())
+	}
+}
+
+func TestTypedDataCallInvalid(t *testing.T) {
+	cfg := xc.NewChainConfig(xc.ETH).WithChainID("1").Base()
+	signer := xc.Address("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+
+	// different chain
+	otherChain := xc.NewChainConfig(xc.ETH).WithChainID("10").Base()
+	msg := json.RawMessage(`{"method":"eth_signTypedData_v4","typed_data":` + mailTypedData + `}`)
+	_, err := evmcall.NewTypedDataCall(otherChain, call.EthSignTypedDataV4, msg, []xc.Address{signer})
+	require.ErrorContains(t, err, "chain-id")
+
+	// different account
+	msg = json.RawMessage(`{"method":"eth_signTypedData_v4","account":"0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB","typed_data":` + mailTypedData + `}`)
+	_, err = evmcall.NewTypedDataCall(cfg, call.EthSignTypedDataV4, msg, []xc.Address{signer})
+	require.ErrorContains(t, err, "requested to be signed by")
+
+	// missing
+	_, err = evmcall.NewTypedDataCall(cfg, call.EthSignTypedDataV4, json.RawMessage(`{"method":"eth_signTypedData_v4"}`), []xc.Address{signer})
+	require.ErrorContains(t, err, "missing")
+
+	// unsigned
+	msg = json.RawMessage(`{"method":"eth_signTypedData_v4","typed_data":` + mailTypedData + `}`)
+	typedDataCall, err := evmcall.NewTypedDataCall(cfg, call.EthSignTypedDataV4, msg, []xc.Address{signer})
+	require.NoError(t, err)
+	_, err = typedDataCall.Serialize()
+	require.ErrorContains(t, err, "not signed")
+}
+
+func TestPermit(t *testing.T) {
+	cfg := xc.NewChainConfig(xc.ETH).WithChainID("1").Base()
+	permit := &evmcall.Permit{
+		Token:        "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		TokenName:    "USD Coin",
+		TokenVersion: "2",
+		ChainId:      1,
+		Owner:        "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+		Spender:      "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+		Value:        xc.NewAmountBlockchainFromUint64(1_000_000),
+		Nonce:        xc.NewAmountBlockchainFromUint64(3),
+		Deadline:     1_900_000_000,
+	}
+	msg, err := evmcall.NewTypedDataMsg(permit.Owner, evmcall.NewPermitTypedData(permit))
+	require.NoError(t, err)
+
+	typedDataCall, err := evmcall.NewTypedDataCall(cfg, call.EthSignTypedDataV4, msg, []xc.Address{permit.Owner})
+	require.NoError(t, err)
+	require.Equal(t, permit, typedDataCall.Decode())
+	require.Equal(t, []xc.ContractAddress{permit.Token}, typedDataCall.ContractAddresses())
+
+	signature := make([]byte, 65)
+	calldata, err := evmcall.PermitCallData(permit, signature)
+	require.NoError(t, err)
+	// permit(address,address,uint256,uint256,uint8,bytes32,bytes32)
+	require.Equal(t, "d505accf", hex.EncodeToString(calldata[:4]))
+	require.Len(t, calldata, 4+7*32)
+}
+
+func TestPermitTransferFrom(t *testing.T) {
+	cfg := xc.NewChainConfig(xc.ETH).WithChainID("1").Base()
+	permit := &evmcall.PermitTransferFrom{
+		Permit2:  evmcall.Permit2Address,
+		ChainId:  1,
+		Token:    "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		Amount:   xc.NewAmountBlockchainFromUint64(1_000_000),
+		Spender:  "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+		Nonce:    xc.NewAmountBlockchainFromUint64(12345),
+		Deadline: 1_900_000_000,
+	}
+	owner := xc.Address("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+	msg, err := evmcall.NewTypedDataMsg(owner, evmcall.NewPermitTransferFromTypedData(permit))
+	require.NoError(t, err)
+
+	typedDataCall, err := evmcall.NewTypedDataCall(cfg, call.EthSignTypedDataV4, msg, []xc.Address{owner})
+	require.NoError(t, err)
+	require.Equal(t, permit, typedDataCall.Decode())
+	require.Equal(t, []xc.ContractAddress{evmcall.Permit2Address, permit.Token}, typedDataCall.ContractAddresses())
+	require.Len(t, typedDataCall.Hash(), 66)
+}
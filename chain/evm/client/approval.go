@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/evm/abi/erc20"
+	"github.com/cordialsys/crosschain/chain/evm/address"
+	"github.com/cordialsys/crosschain/chain/evm/builder"
+	"github.com/cordialsys/crosschain/chain/evm/tx"
+	"github.com/cordialsys/crosschain/chain/evm/tx_input"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+)
+
+var _ xclient.ApprovalClient = &Client{}
+
+func (client *Client) FetchAllowance(ctx context.Context, owner xc.Address, spender xc.Address, contract xc.ContractAddress) (xc.AmountBlockchain, error) {
+	zero := xc.NewAmountBlockchainFromUint64(0)
+	tokenAddress, err := address.FromHex(xc.Address(contract))
+	if err != nil {
+		return zero, fmt.Errorf("invalid contract address: %v", err)
+	}
+	ownerAddress, err := address.FromHex(owner)
+	if err != nil {
+		return zero, fmt.Errorf("invalid owner address: %v", err)
+	}
+	spenderAddress, err := address.FromHex(spender)
+	if err != nil {
+		return zero, fmt.Errorf("invalid spender address: %v", err)
+	}
+	instance, err := erc20.NewErc20(tokenAddress, client.EthClient)
+	if err != nil {
+		return zero, err
+	}
+	allowance, err := instance.Allowance(&bind.CallOpts{Context: ctx}, ownerAddress, spenderAddress)
+	if err != nil {
+		return zero, err
+	}
+	return xc.AmountBlockchain(*allowance), nil
+}
+
+func (client *Client) FetchApprovalInput(ctx context.Context, args xcbuilder.ApprovalArgs) (xc.ApprovalTxInput, error) {
+	txInput, err := client.FetchUnsimulatedInput(ctx, args.GetFrom(), "", args.GetTransactionAttempts())
+	if err != nil {
+		return nil, err
+	}
+	approvalInput := &tx_input.ApprovalInput{TxInput: *txInput}
+
+	txBuilder, err := builder.NewTxBuilder(client.Asset.GetChain().Base())
+	if err != nil {
+		return nil, fmt.Errorf("could not prepare to simulate: %v", err)
+	}
+	exampleTx, err := txBuilder.Approve(args, approvalInput)
+	if err != nil {
+		return nil, fmt.Errorf("could not prepare to simulate: %v", err)
+	}
+	gasLimit, err := client.SimulateGasWithLimit(ctx, args.GetFrom(), exampleTx.(*tx.Tx))
+	if err != nil {
+		return nil, err
+	}
+	approvalInput.GasLimit = gasLimit
+	return approvalInput, nil
+}
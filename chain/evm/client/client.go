@@ -206,6 +206,9 @@ func (client *Client) SubmitTx(ctx context.Context, tx xctypes.SubmitTxReq) erro
 	if err != nil {
 		return err
 	}
+	if metadata, ok := parseUserOperationMetadata(tx); ok {
+		return client.SubmitUserOperation(ctx, bz, metadata)
+	}
 	bzHex := hexutil.Encode(bz)
 	err1 := client.EthClient.Client().CallContext(ctx, nil, "eth_sendRawTransaction", bzHex)
 
@@ -309,6 +312,7 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHashStr xc.TxHash
 	result.Confirmations = latestHeader.Number.Int64() - receipt.BlockNumber.Int64()
 
 	tokenMovements := tx.ParseTokenLogs(receipt, xc.NativeAsset(nativeAsset.Chain))
+	nftMovements := tx.ParseNftLogs(receipt, xc.NativeAsset(nativeAsset.Chain))
 	// ethMovements, err := client.TraceEthMovements(ctx, txHash)
 	var ethMovements tx.SourcesAndDests
 	if os.Getenv("EVM_DEBUG_TRACE") == "1" {
@@ -374,6 +378,8 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHashStr xc.TxHash
 	}
 	result.Sources = append(ethMovements.Sources, tokenMovements.Sources...)
 	result.Destinations = append(ethMovements.Destinations, tokenMovements.Destinations...)
+	result.Sources = append(result.Sources, nftMovements.Sources...)
+	result.Destinations = append(result.Destinations, nftMovements.Destinations...)
 
 	// Look for stake/unstake events
 	for _, log := range receipt.Logs {
@@ -461,6 +467,10 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHashStr xc.TxHash
 
 func (client *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinfo.TxInfo, error) {
 	legacyTx, err := client.FetchLegacyTxInfo(ctx, args.TxHash())
+	if errors.Is(err, errors.TransactionNotFound) && client.Asset.GetChain().BundlerURL != "" {
+		// The hash may be for an ERC-4337 user-operation
+		legacyTx, err = client.FetchUserOperationLegacyTxInfo(ctx, args.TxHash())
+	}
 	if err != nil {
 		return txinfo.TxInfo{}, err
 	}
@@ -153,6 +153,14 @@ func (client *Client) GetNonce(ctx context.Context, from xc.Address) (uint64, er
 
 func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
 	feePayer, _ := args.GetFeePayer()
+	if owner, ok := args.GetSmartAccountOwner(); ok {
+		calls, err := tx.SmartAccountCallsFromTransfers([]*xcbuilder.TransferArgs{&args})
+		if err != nil {
+			return nil, err
+		}
+		// the fee-payer is the paymaster for user-operations
+		return client.FetchUserOperationInput(ctx, args.GetFrom(), owner, feePayer, smartAccountFactory(&args), calls)
+	}
 	txInput, err := client.FetchUnsimulatedInput(ctx, args.GetFrom(), feePayer, args.GetTransactionAttempts())
 	if err != nil {
 		return txInput, err
@@ -199,6 +207,22 @@ func (client *Client) FetchMultiTransferInput(ctx context.Context, args xcbuilde
 		return nil, fmt.Errorf("no spenders")
 	}
 
+	if owner, ok := args.GetSmartAccountOwner(); ok {
+		transfers, err := args.AsAccountTransfers()
+		if err != nil {
+			return nil, err
+		}
+		calls, err := tx.SmartAccountCallsFromTransfers(transfers)
+		if err != nil {
+			return nil, err
+		}
+		txInput, err := client.FetchUserOperationInput(ctx, spenders[0].GetFrom(), owner, feePayer, smartAccountFactory(&args), calls)
+		if err != nil {
+			return nil, err
+		}
+		return &tx_input.MultiTransferInput{TxInput: *txInput}, nil
+	}
+
 	txInput, err := client.FetchUnsimulatedInput(ctx, spenders[0].GetFrom(), feePayer, args.GetTransactionAttempts())
 	if err != nil {
 		return nil, err
@@ -353,9 +377,11 @@ func (client *Client) FetchCallInput(ctx context.Context, call xc.TxCall, args x
 	}
 	from := froms[0]
 
-	txInput, err := client.FetchUnsimulatedInput(ctx, from, feePayer, previousAttempts)
-	if err != nil {
-		return nil, err
+	if _, ok := call.(*evmcall.TypedDataCall); ok {
+		// typed-data is only signed, there is nothing to estimate
+		txInput := tx_input.NewTxInput()
+		txInput.FromAddress = from
+		return &tx_input.CallInput{TxInput: *txInput}, nil
 	}
 
 	evmCall := call.(*evmcall.TxCall)
@@ -364,6 +390,20 @@ func (client *Client) FetchCallInput(ctx context.Context, call xc.TxCall, args x
 	data := evmCall.Call.Data
 	value := evmCall.Call.Amount
 	wei := value.ToBlockchain(client.Asset.Decimals).Int()
+
+	if owner, ok := args.GetSmartAccountOwner(); ok {
+		calls := []tx.SmartAccountCall{{To: toAddr, Value: wei, Data: data}}
+		txInput, err := client.FetchUserOperationInput(ctx, from, owner, "", smartAccountFactory(&args), calls)
+		if err != nil {
+			return nil, err
+		}
+		return &tx_input.CallInput{TxInput: *txInput}, nil
+	}
+
+	txInput, err := client.FetchUnsimulatedInput(ctx, from, feePayer, previousAttempts)
+	if err != nil {
+		return nil, err
+	}
 	msg := ethereum.CallMsg{
 		From: fromAddr,
 		To:   &toAddr,
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/evm/builder"
+	"github.com/cordialsys/crosschain/chain/evm/tx"
+	"github.com/cordialsys/crosschain/chain/evm/tx_input"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+var _ xclient.NftClient = &Client{}
+
+func (client *Client) FetchNftTransferInput(ctx context.Context, args xcbuilder.NftTransferArgs) (xc.NftTransferTxInput, error) {
+	txInput, err := client.FetchUnsimulatedInput(ctx, args.GetFrom(), "", args.GetTransactionAttempts())
+	if err != nil {
+		return nil, err
+	}
+	nftInput := &tx_input.NftTransferInput{TxInput: *txInput}
+
+	txBuilder, err := builder.NewTxBuilder(client.Asset.GetChain().Base())
+	if err != nil {
+		return nil, fmt.Errorf("could not prepare to simulate: %v", err)
+	}
+	exampleTx, err := txBuilder.NftTransfer(args, nftInput)
+	if err != nil {
+		return nil, fmt.Errorf("could not prepare to simulate: %v", err)
+	}
+	gasLimit, err := client.SimulateGasWithLimit(ctx, args.GetFrom(), exampleTx.(*tx.Tx))
+	if err != nil {
+		return nil, err
+	}
+	nftInput.GasLimit = gasLimit
+	return nftInput, nil
+}
@@ -0,0 +1,377 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/evm/address"
+	"github.com/cordialsys/crosschain/chain/evm/tx"
+	"github.com/cordialsys/crosschain/chain/evm/tx_input"
+	"github.com/cordialsys/crosschain/client/errors"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	xctypes "github.com/cordialsys/crosschain/client/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+// Response of `eth_estimateUserOperationGas`
+type userOperationGasEstimate struct {
+	PreVerificationGas            hexutil.Uint64  `json:"preVerificationGas"`
+	VerificationGasLimit          hexutil.Uint64  `json:"verificationGasLimit"`
+	CallGasLimit                  hexutil.Uint64  `json:"callGasLimit"`
+	PaymasterVerificationGasLimit *hexutil.Uint64 `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       *hexutil.Uint64 `json:"paymasterPostOpGasLimit,omitempty"`
+}
+
+// Response of `pm_getPaymasterStubData` and `pm_getPaymasterData` (ERC-7677)
+type paymasterData struct {
+	Paymaster                     common.Address  `json:"paymaster"`
+	PaymasterData                 hexutil.Bytes   `json:"paymasterData"`
+	PaymasterVerificationGasLimit *hexutil.Uint64 `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       *hexutil.Uint64 `json:"paymasterPostOpGasLimit,omitempty"`
+}
+
+// Response of `eth_getUserOperationReceipt`
+type userOperationReceipt struct {
+	UserOpHash    common.Hash    `json:"userOpHash"`
+	EntryPoint    common.Address `json:"entryPoint"`
+	Sender        common.Address `json:"sender"`
+	Nonce         *hexutil.Big   `json:"nonce"`
+	Paymaster     common.Address `json:"paymaster"`
+	ActualGasCost *hexutil.Big   `json:"actualGasCost"`
+	ActualGasUsed *hexutil.Big   `json:"actualGasUsed"`
+	Success       bool           `json:"success"`
+	Reason        string         `json:"reason"`
+	Logs          []*types.Log   `json:"logs"`
+	Receipt       *struct {
+		TransactionHash common.Hash  `json:"transactionHash"`
+		BlockHash       common.Hash  `json:"blockHash"`
+		BlockNumber     *hexutil.Big `json:"blockNumber"`
+	} `json:"receipt"`
+}
+
+// Response of `eth_getUserOperationByHash`
+type userOperationByHash struct {
+	UserOperation *tx.RpcUserOperation `json:"userOperation"`
+	EntryPoint    common.Address       `json:"entryPoint"`
+}
+
+func (client *Client) bundlerClient() (*rpc.Client, error) {
+	bundlerUrl := client.Asset.GetChain().BundlerURL
+	if bundlerUrl == "" {
+		return nil, fmt.Errorf("no bundler_url is configured for %s", client.Asset.GetChain().Chain)
+	}
+	bundler, err := rpc.DialHTTPWithClient(bundlerUrl, client.Asset.GetChain().DefaultHttpClient())
+	if err != nil {
+		return nil, fmt.Errorf("dialing bundler url: %v", err)
+	}
+	return bundler, nil
+}
+
+// Fetch the EntryPoint nonce of the smart account, using the default (zero) nonce key.
+func (client *Client) GetUserOperationNonce(ctx context.Context, entryPoint common.Address, sender common.Address) (*big.Int, error) {
+	// getNonce(address sender, uint192 key)
+	data := crypto.Keccak256([]byte("getNonce(address,uint192)"))[:4]
+	data = append(data, common.LeftPadBytes(sender.Bytes(), 32)...)
+	data = append(data, make([]byte, 32)...)
+	result, err := client.EthClient.CallContract(ctx, ethereum.CallMsg{
+		To:   &entryPoint,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not get entry-point nonce: %v", err)
+	}
+	return new(big.Int).SetBytes(result), nil
+}
+
+// Fetch the input for a UserOperation that has the smart account `from` execute the given calls.
+// If a paymaster is set, it's sponsorship data is requested from the bundler via ERC-7677.
+// If a factory is set, the smart account is deployed by the UserOperation and may not exist yet.
+func (client *Client) FetchUserOperationInput(ctx context.Context, from xc.Address, owner xc.Address, paymaster xc.Address, factory *xcbuilder.SmartAccountFactory, calls []tx.SmartAccountCall) (*tx_input.TxInput, error) {
+	chain := client.Asset.GetChain()
+	bundler, err := client.bundlerClient()
+	if err != nil {
+		return nil, err
+	}
+	sender, err := address.FromHex(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smart account address: %v", err)
+	}
+	if factory == nil {
+		code, err := client.EthClient.CodeAt(ctx, sender, nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not check smart account code: %v", err)
+		}
+		if len(code) == 0 {
+			return nil, fmt.Errorf("smart account %s is not deployed, a factory must be set to deploy it", from)
+		}
+	} else if _, err := address.FromHex(factory.Factory); err != nil {
+		return nil, fmt.Errorf("invalid smart account factory address: %v", err)
+	}
+
+	// no fee-payer here, as the paymaster does not sign an eth transaction
+	txInput, err := client.FetchUnsimulatedInput(ctx, from, "", []string{})
+	if err != nil {
+		return nil, err
+	}
+	entryPoint := common.HexToAddress(tx.EntryPointV07Address)
+	nonce, err := client.GetUserOperationNonce(ctx, entryPoint, sender)
+	if err != nil {
+		return nil, err
+	}
+	// Track the sequence of the entry-point nonce as the nonce, so conflicts are detected
+	txInput.Nonce = new(big.Int).And(nonce, new(big.Int).SetUint64(^uint64(0))).Uint64()
+	txInput.GasLimit = 0
+	txInput.UserOperation = &tx_input.UserOperationInput{
+		EntryPoint: xc.Address(entryPoint.Hex()),
+		Owner:      owner,
+		Nonce:      xc.AmountBlockchain(*nonce),
+	}
+	if factory != nil {
+		txInput.UserOperation.Factory = factory.Factory
+		txInput.UserOperation.FactoryData = factory.Data
+	}
+	chainIdHex := hexutil.EncodeBig(txInput.ChainId.Int())
+
+	if paymaster != "" {
+		stub, err := client.requestPaymasterData(ctx, bundler, "pm_getPaymasterStubData", txInput, calls, chainIdHex)
+		if err != nil {
+			return nil, err
+		}
+		if err := applyPaymasterData(txInput.UserOperation, paymaster, stub); err != nil {
+			return nil, err
+		}
+	}
+
+	op, err := client.unsignedUserOperation(txInput, calls)
+	if err != nil {
+		return nil, err
+	}
+	var estimate userOperationGasEstimate
+	err = bundler.CallContext(ctx, &estimate, "eth_estimateUserOperationGas", op.ToRpc(), entryPoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not estimate user-operation gas: %v", err)
+	}
+	multiplier := chain.ChainGasLimitMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	txInput.UserOperation.PreVerificationGas = uint64(estimate.PreVerificationGas)
+	txInput.UserOperation.VerificationGasLimit = uint64(float64(estimate.VerificationGasLimit) * multiplier)
+	txInput.UserOperation.CallGasLimit = uint64(float64(estimate.CallGasLimit) * multiplier)
+	if estimate.PaymasterVerificationGasLimit != nil {
+		txInput.UserOperation.PaymasterVerificationGasLimit = uint64(*estimate.PaymasterVerificationGasLimit)
+	}
+	if estimate.PaymasterPostOpGasLimit != nil {
+		txInput.UserOperation.PaymasterPostOpGasLimit = uint64(*estimate.PaymasterPostOpGasLimit)
+	}
+
+	if paymaster != "" {
+		final, err := client.requestPaymasterData(ctx, bundler, "pm_getPaymasterData", txInput, calls, chainIdHex)
+		if err != nil {
+			return nil, err
+		}
+		if err := applyPaymasterData(txInput.UserOperation, paymaster, final); err != nil {
+			return nil, err
+		}
+	}
+
+	return txInput, nil
+}
+
+type smartAccountFactoryArgs interface {
+	GetSmartAccountFactory() (xcbuilder.SmartAccountFactory, bool)
+}
+
+// The factory option of the args, or nil if the smart account is already deployed
+func smartAccountFactory(args smartAccountFactoryArgs) *xcbuilder.SmartAccountFactory {
+	factory, ok := args.GetSmartAccountFactory()
+	if !ok {
+		return nil
+	}
+	return &factory
+}
+
+func (client *Client) unsignedUserOperation(txInput *tx_input.TxInput, calls []tx.SmartAccountCall) (*tx.UserOperation, error) {
+	userOpTx, err := tx.NewUserOperationTx(client.Asset.GetChain().Base(), txInput, calls)
+	if err != nil {
+		return nil, err
+	}
+	return userOpTx.UserOperation()
+}
+
+func (client *Client) requestPaymasterData(ctx context.Context, bundler *rpc.Client, method string, txInput *tx_input.TxInput, calls []tx.SmartAccountCall, chainIdHex string) (*paymasterData, error) {
+	op, err := client.unsignedUserOperation(txInput, calls)
+	if err != nil {
+		return nil, err
+	}
+	var result paymasterData
+	err = bundler.CallContext(ctx, &result, method, op.ToRpc(), txInput.UserOperation.EntryPoint, chainIdHex, map[string]any{})
+	if err != nil {
+		return nil, fmt.Errorf("could not get paymaster data (%s): %v", method, err)
+	}
+	return &result, nil
+}
+
+func applyPaymasterData(input *tx_input.UserOperationInput, paymaster xc.Address, data *paymasterData) error {
+	if !strings.EqualFold(data.Paymaster.Hex(), string(paymaster)) {
+		return fmt.Errorf("paymaster service returned paymaster %s, but expected fee-payer %s", data.Paymaster.Hex(), paymaster)
+	}
+	input.Paymaster = paymaster
+	input.PaymasterData = []byte(data.PaymasterData)
+	if data.PaymasterVerificationGasLimit != nil {
+		input.PaymasterVerificationGasLimit = uint64(*data.PaymasterVerificationGasLimit)
+	}
+	if data.PaymasterPostOpGasLimit != nil {
+		input.PaymasterPostOpGasLimit = uint64(*data.PaymasterPostOpGasLimit)
+	}
+	return nil
+}
+
+// User-operations carry the entry-point in the broadcast metadata, regular transactions have no metadata.
+func parseUserOperationMetadata(submitReq xctypes.SubmitTxReq) (*tx.UserOperationMetadata, bool) {
+	metadataBz, ok, err := submitReq.GetMetadata()
+	if err != nil || !ok {
+		return nil, false
+	}
+	var metadata tx.UserOperationMetadata
+	if err := json.Unmarshal(metadataBz, &metadata); err != nil || metadata.EntryPoint == "" {
+		return nil, false
+	}
+	return &metadata, true
+}
+
+// Submit a signed UserOperation to the bundler
+func (client *Client) SubmitUserOperation(ctx context.Context, userOpBz []byte, metadata *tx.UserOperationMetadata) error {
+	bundler, err := client.bundlerClient()
+	if err != nil {
+		return err
+	}
+	var op tx.RpcUserOperation
+	if err := json.Unmarshal(userOpBz, &op); err != nil {
+		return fmt.Errorf("invalid user-operation: %v", err)
+	}
+	var userOpHash common.Hash
+	return bundler.CallContext(ctx, &userOpHash, "eth_sendUserOperation", &op, metadata.EntryPoint)
+}
+
+// Fetch a UserOperation by it's hash from the bundler, and map it into the legacy tx-info.
+// The movements are taken from the calls the smart account executed, and the logs emitted by the UserOperation.
+func (client *Client) FetchUserOperationLegacyTxInfo(ctx context.Context, userOpHash xc.TxHash) (txinfo.LegacyTxInfo, error) {
+	nativeAsset := client.Asset.GetChain()
+	result := txinfo.LegacyTxInfo{
+		TxID: address.TrimPrefixes(string(userOpHash)),
+	}
+	bundler, err := client.bundlerClient()
+	if err != nil {
+		return result, err
+	}
+	hash := common.HexToHash(result.TxID)
+
+	var receipt *userOperationReceipt
+	err = bundler.CallContext(ctx, &receipt, "eth_getUserOperationReceipt", hash)
+	if err != nil {
+		return result, fmt.Errorf("could not get user-operation receipt: %v", err)
+	}
+	if receipt == nil || receipt.Receipt == nil {
+		return result, errors.TransactionNotFoundf("user-operation %s not found", userOpHash)
+	}
+	var userOp *userOperationByHash
+	err = bundler.CallContext(ctx, &userOp, "eth_getUserOperationByHash", hash)
+	if err != nil {
+		return result, fmt.Errorf("could not get user-operation: %v", err)
+	}
+
+	blockNumber := receipt.Receipt.BlockNumber.ToInt()
+	result.BlockIndex = blockNumber.Int64()
+	result.BlockHash = receipt.Receipt.BlockHash.Hex()
+	header, err := client.EthClient.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return result, fmt.Errorf("fetching block header: %v", err)
+	}
+	result.BlockTime = int64(header.Time)
+	latestHeader, err := client.EthClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return result, fmt.Errorf("fetching latest header: %v", err)
+	}
+	result.Confirmations = latestHeader.Number.Int64() - blockNumber.Int64()
+
+	result.From = xc.Address(receipt.Sender.Hex())
+	result.FeePayer = result.From
+	if receipt.Paymaster != (common.Address{}) {
+		result.FeePayer = xc.Address(receipt.Paymaster.Hex())
+	}
+	if receipt.ActualGasCost != nil {
+		result.Fee = xc.AmountBlockchain(*receipt.ActualGasCost.ToInt())
+	}
+	if !receipt.Success {
+		result.Status = xc.TxStatusFailure
+		result.Error = "user-operation reverted"
+		if receipt.Reason != "" {
+			result.Error = fmt.Sprintf("user-operation reverted: %s", receipt.Reason)
+		}
+	}
+
+	if userOp != nil && userOp.UserOperation != nil {
+		calls, err := tx.DecodeSmartAccountCalls(userOp.UserOperation.CallData)
+		if err != nil {
+			logrus.WithError(err).WithField("user_op_hash", userOpHash).Warn("could not decode smart account calls")
+		}
+		for _, call := range calls {
+			if call.Value == nil || call.Value.Sign() <= 0 {
+				continue
+			}
+			amount := xc.AmountBlockchain(*call.Value)
+			result.Sources = append(result.Sources, &txinfo.LegacyTxInfoEndpoint{
+				Address:     result.From,
+				NativeAsset: nativeAsset.Chain,
+				Amount:      amount,
+				Event:       txinfo.NewEvent("", txinfo.MovementVariantNative),
+			})
+			result.Destinations = append(result.Destinations, &txinfo.LegacyTxInfoEndpoint{
+				Address:     xc.Address(call.To.Hex()),
+				NativeAsset: nativeAsset.Chain,
+				Amount:      amount,
+				Event:       txinfo.NewEvent("", txinfo.MovementVariantNative),
+			})
+		}
+	}
+	tokenMovements := tx.ParseTokenLogs(&types.Receipt{Logs: receipt.Logs}, nativeAsset.Chain)
+	result.Sources = append(result.Sources, tokenMovements.Sources...)
+	result.Destinations = append(result.Destinations, tokenMovements.Destinations...)
+	nftMovements := tx.ParseNftLogs(&types.Receipt{Logs: receipt.Logs}, nativeAsset.Chain)
+	result.Sources = append(result.Sources, nftMovements.Sources...)
+	result.Destinations = append(result.Destinations, nftMovements.Destinations...)
+
+	if len(result.Destinations) > 0 {
+		result.To = result.Destinations[0].Address
+		result.Amount = result.Destinations[0].Amount
+		result.ContractAddress = result.Destinations[0].ContractAddress
+	}
+	if result.Error != "" {
+		// drop all changes
+		result.Sources = nil
+		result.Destinations = nil
+	}
+	// normalize
+	for _, movement := range append(result.Sources, result.Destinations...) {
+		movement.Address = xc.Address(strings.ToLower(string(movement.Address)))
+		movement.ContractAddress = xc.ContractAddress(strings.ToLower(string(movement.ContractAddress)))
+	}
+	result.To = xc.Address(strings.ToLower(string(result.To)))
+	result.From = xc.Address(strings.ToLower(string(result.From)))
+	result.FeePayer = xc.Address(strings.ToLower(string(result.FeePayer)))
+	result.ContractAddress = xc.ContractAddress(strings.ToLower(string(result.ContractAddress)))
+
+	return result, nil
+}
@@ -0,0 +1,250 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/builder/buildertest"
+	"github.com/cordialsys/crosschain/chain/evm/builder"
+	"github.com/cordialsys/crosschain/chain/evm/client"
+	"github.com/cordialsys/crosschain/chain/evm/tx"
+	"github.com/cordialsys/crosschain/chain/evm/tx_input"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	xctypes "github.com/cordialsys/crosschain/client/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	smartAccount   = "0x1111111111111111111111111111111111111111"
+	smartOwner     = "0x2222222222222222222222222222222222222222"
+	smartRecipient = "0x3333333333333333333333333333333333333333"
+	smartFactory   = "0x4444444444444444444444444444444444444444"
+	userOpHash     = "0x5555555555555555555555555555555555555555555555555555555555555555"
+	userOpBlock    = `{"baseFeePerGas":"0xba43b7400","difficulty":"0x19","extraData":"0x","gasLimit":"0x1c0e7cb","gasUsed":"0x0","hash":"0x32c7587e0c0634a19c40dee211323dd0b2d83494f65d619a9ddefa6d31f99238","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0x0000000000000000000000000000000000000000","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","number":"0x2bbd145","parentHash":"0x6c63c167c9014fb62dad62dde72f774f64634237d18f5877ec3642c44b3af2dd","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","size":"0x269","stateRoot":"0x70d3c1f93205f1b6970b6e0ebc5a20c938dbcc8050c82e07a09fa1d568a9d428","timestamp":"0x64cbc1e1","totalDifficulty":"0x2f15808f","transactions":[],"transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","uncles":[]}`
+)
+
+type jsonRpcRequest struct {
+	Id     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// mockJsonRpcMethods serves JSON-RPC requests by method, recording the params of each call
+func mockJsonRpcMethods(t *testing.T, results map[string]string) (*httptest.Server, map[string][]json.RawMessage) {
+	calls := map[string][]json.RawMessage{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req jsonRpcRequest
+		require.NoError(t, json.Unmarshal(body, &req))
+		result, ok := results[req.Method]
+		if !ok {
+			t.Errorf("unexpected method: %s", req.Method)
+			result = "null"
+		}
+		calls[req.Method] = req.Params
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.Id) + `,"result":` + result + `}`))
+	}))
+	return server, calls
+}
+
+func newUserOperationClient(t *testing.T, nodeUrl string, bundlerUrl string) *client.Client {
+	asset := xc.NewChainConfig(xc.ETH, xc.DriverEVM).WithUrl(nodeUrl)
+	asset.BundlerURL = bundlerUrl
+	evmClient, err := client.NewClient(asset)
+	require.NoError(t, err)
+	return evmClient
+}
+
+func TestFetchUserOperationInput(t *testing.T) {
+	nodeResults := map[string]string{
+		"eth_getTransactionCount":  `"0x0"`,
+		"eth_chainId":              `"0x1"`,
+		"eth_getBlockByNumber":     userOpBlock,
+		"eth_maxPriorityFeePerGas": `"0x3b9aca00"`,
+		"txpool_contentFrom":       `{"pending":{},"queued":{}}`,
+		// getNonce(sender, 0) on the entry-point
+		"eth_call": `"0x0000000000000000000000000000000000000000000000000000000000000000"`,
+	}
+	bundlerResults := map[string]string{
+		"eth_estimateUserOperationGas": `{"preVerificationGas":"0xc350","verificationGasLimit":"0x493e0","callGasLimit":"0x186a0"}`,
+	}
+	factoryData := common.FromHex("0x5fbfb9cf0000000000000000000000002222222222222222222222222222222222222222")
+
+	t.Run("undeployed smart account without a factory", func(t *testing.T) {
+		results := map[string]string{"eth_getCode": `"0x"`}
+		node, _ := mockJsonRpcMethods(t, results)
+		defer node.Close()
+		bundler, _ := mockJsonRpcMethods(t, bundlerResults)
+		defer bundler.Close()
+		evmClient := newUserOperationClient(t, node.URL, bundler.URL)
+
+		args := buildertest.MustNewTransferArgs(evmClient.Asset.GetChain().Base(), smartAccount, smartRecipient, xc.NewAmountBlockchainFromUint64(10),
+			buildertest.OptionSmartAccountOwner(smartOwner),
+		)
+		_, err := evmClient.FetchTransferInput(context.Background(), args)
+		require.ErrorContains(t, err, "is not deployed")
+	})
+
+	t.Run("deployed by a factory", func(t *testing.T) {
+		// no eth_getCode, as the smart account is deployed by the user-operation
+		node, _ := mockJsonRpcMethods(t, nodeResults)
+		defer node.Close()
+		bundler, bundlerCalls := mockJsonRpcMethods(t, bundlerResults)
+		defer bundler.Close()
+		evmClient := newUserOperationClient(t, node.URL, bundler.URL)
+
+		args := buildertest.MustNewTransferArgs(evmClient.Asset.GetChain().Base(), smartAccount, smartRecipient, xc.NewAmountBlockchainFromUint64(10),
+			buildertest.OptionSmartAccountOwner(smartOwner),
+			buildertest.OptionSmartAccountFactory(smartFactory, factoryData),
+		)
+		input, err := evmClient.FetchTransferInput(context.Background(), args)
+		require.NoError(t, err)
+		userOp := input.(*tx_input.TxInput).UserOperation
+		require.NotNil(t, userOp)
+		require.EqualValues(t, smartFactory, userOp.Factory)
+		require.EqualValues(t, factoryData, userOp.FactoryData)
+		require.EqualValues(t, smartOwner, userOp.Owner)
+		require.EqualValues(t, 50_000, userOp.PreVerificationGas)
+		require.EqualValues(t, 300_000, userOp.VerificationGasLimit)
+		require.EqualValues(t, 100_000, userOp.CallGasLimit)
+
+		// the factory is included when estimating gas
+		estimateParams := bundlerCalls["eth_estimateUserOperationGas"]
+		require.Len(t, estimateParams, 2)
+		var estimated tx.RpcUserOperation
+		require.NoError(t, json.Unmarshal(estimateParams[0], &estimated))
+		require.NotNil(t, estimated.Factory)
+		require.Equal(t, common.HexToAddress(smartFactory), *estimated.Factory)
+		require.EqualValues(t, factoryData, estimated.FactoryData)
+		require.JSONEq(t, strings.ToLower(`"`+tx.EntryPointV07Address+`"`), strings.ToLower(string(estimateParams[1])))
+	})
+}
+
+func TestSubmitUserOperation(t *testing.T) {
+	bundler, bundlerCalls := mockJsonRpcMethods(t, map[string]string{
+		"eth_sendUserOperation": `"` + userOpHash + `"`,
+	})
+	defer bundler.Close()
+	evmClient := newUserOperationClient(t, "http://localhost:0", bundler.URL)
+	chain := evmClient.Asset.GetChain()
+
+	input := tx_input.NewTxInput()
+	input.FromAddress = smartAccount
+	input.ChainId = xc.NewAmountBlockchainFromUint64(1)
+	input.GasFeeCap = xc.NewAmountBlockchainFromUint64(30_000_000_000)
+	input.GasTipCap = xc.NewAmountBlockchainFromUint64(1_000_000_000)
+	input.UserOperation = &tx_input.UserOperationInput{
+		EntryPoint:           tx.EntryPointV07Address,
+		Owner:                smartOwner,
+		Nonce:                xc.NewAmountBlockchainFromUint64(0),
+		CallGasLimit:         100_000,
+		VerificationGasLimit: 300_000,
+		PreVerificationGas:   50_000,
+		Factory:              smartFactory,
+		FactoryData:          []byte{0x5f, 0xbf, 0xb9, 0xcf},
+	}
+	txBuilder, err := builder.NewTxBuilder(chain.Base())
+	require.NoError(t, err)
+	args := buildertest.MustNewTransferArgs(chain.Base(), smartAccount, smartRecipient, xc.NewAmountBlockchainFromUint64(10),
+		buildertest.OptionSmartAccountOwner(smartOwner),
+	)
+	userOpTx, err := txBuilder.Transfer(args, input)
+	require.NoError(t, err)
+	signature := make([]byte, 65)
+	require.NoError(t, userOpTx.SetSignatures(&xc.SignatureResponse{Signature: signature}))
+
+	req, err := xctypes.SubmitTxReqFromTx(chain.Chain, userOpTx)
+	require.NoError(t, err)
+	require.NoError(t, evmClient.SubmitTx(context.Background(), req))
+
+	// submitted to the bundler rather than as a raw transaction
+	params := bundlerCalls["eth_sendUserOperation"]
+	require.Len(t, params, 2)
+	var submitted tx.RpcUserOperation
+	require.NoError(t, json.Unmarshal(params[0], &submitted))
+	require.Equal(t, common.HexToAddress(smartAccount), submitted.Sender)
+	require.Equal(t, common.HexToAddress(smartFactory), *submitted.Factory)
+	require.EqualValues(t, []byte{0x5f, 0xbf, 0xb9, 0xcf}, submitted.FactoryData)
+	require.JSONEq(t, `"`+tx.EntryPointV07Address+`"`, string(params[1]))
+}
+
+func TestFetchUserOperationTxInfo(t *testing.T) {
+	callData, err := tx.EncodeSmartAccountCalls([]tx.SmartAccountCall{
+		{To: common.HexToAddress(smartRecipient), Value: big.NewInt(10), Data: []byte{}},
+	})
+	require.NoError(t, err)
+	userOperation, err := json.Marshal(tx.RpcUserOperation{
+		Sender:   common.HexToAddress(smartAccount),
+		CallData: callData,
+	})
+	require.NoError(t, err)
+
+	vectors := []struct {
+		name    string
+		receipt string
+		err     string
+	}{
+		{
+			name:    "success",
+			receipt: `{"userOpHash":"` + userOpHash + `","sender":"` + smartAccount + `","nonce":"0x0","paymaster":"0x0000000000000000000000000000000000000000","actualGasCost":"0x2386f26fc10000","actualGasUsed":"0x30d40","success":true,"logs":[],"receipt":{"transactionHash":"0x8e1f8b5e3c5a4e7f2b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a","blockHash":"0x32c7587e0c0634a19c40dee211323dd0b2d83494f65d619a9ddefa6d31f99238","blockNumber":"0x2bbd140"}}`,
+		},
+		{
+			name:    "reverted",
+			receipt: `{"userOpHash":"` + userOpHash + `","sender":"` + smartAccount + `","nonce":"0x0","paymaster":"0x0000000000000000000000000000000000000000","actualGasCost":"0x2386f26fc10000","actualGasUsed":"0x30d40","success":false,"reason":"AA23 reverted","logs":[],"receipt":{"transactionHash":"0x8e1f8b5e3c5a4e7f2b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a","blockHash":"0x32c7587e0c0634a19c40dee211323dd0b2d83494f65d619a9ddefa6d31f99238","blockNumber":"0x2bbd140"}}`,
+			err:     "user-operation reverted: AA23 reverted",
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			// the user-operation hash is not a transaction on the node
+			node, _ := mockJsonRpcMethods(t, map[string]string{
+				"eth_getTransactionByHash": `null`,
+				"eth_getBlockByNumber":     userOpBlock,
+			})
+			defer node.Close()
+			bundler, _ := mockJsonRpcMethods(t, map[string]string{
+				"eth_getUserOperationReceipt": v.receipt,
+				"eth_getUserOperationByHash":  `{"userOperation":` + string(userOperation) + `,"entryPoint":"` + tx.EntryPointV07Address + `"}`,
+			})
+			defer bundler.Close()
+			evmClient := newUserOperationClient(t, node.URL, bundler.URL)
+
+			info, err := evmClient.FetchTxInfo(context.Background(), txinfo.NewArgs(userOpHash))
+			require.NoError(t, err)
+			require.Equal(t, strings.TrimPrefix(userOpHash, "0x"), info.Hash)
+			require.EqualValues(t, 0x2bbd140, info.Block.Height.Uint64())
+			require.EqualValues(t, 5, info.Confirmations)
+
+			require.Len(t, info.Fees, 1)
+			require.Equal(t, "10000000000000000", info.Fees[0].Balance.String())
+			// the smart account pays the fee, as there is no paymaster
+			fee := info.Movements[len(info.Movements)-1]
+			require.Len(t, fee.From, 1)
+			require.Len(t, fee.To, 0)
+			require.EqualValues(t, smartAccount, fee.From[0].AddressId)
+			if v.err != "" {
+				require.NotNil(t, info.Error)
+				require.Equal(t, v.err, *info.Error)
+				require.Len(t, info.Movements, 1)
+				return
+			}
+			require.Nil(t, info.Error)
+			require.Len(t, info.Movements, 2)
+			movement := info.Movements[0]
+			require.Len(t, movement.From, 1)
+			require.Len(t, movement.To, 1)
+			require.EqualValues(t, smartAccount, movement.From[0].AddressId)
+			require.EqualValues(t, smartRecipient, movement.To[0].AddressId)
+			require.Equal(t, "10", movement.To[0].Balance.String())
+		})
+	}
+}
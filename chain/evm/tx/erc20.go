@@ -1,9 +1,11 @@
 package tx
 
 import (
+	"fmt"
 	"strings"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/evm/abi/erc20"
 	evmaddress "github.com/cordialsys/crosschain/chain/evm/address"
 	txinfo "github.com/cordialsys/crosschain/client/tx_info"
@@ -42,7 +44,8 @@ func ParseTokenLogs(receipt *types.Receipt, nativeAsset xc.NativeAsset) SourcesA
 		// if event != nil {
 		// fmt.Println("PARSE LOG", event.RawName)
 		// }
-		if event != nil && event.RawName == "Transfer" {
+		// ERC-721 transfers share the signature, but index the token id (see ParseNftLogs)
+		if event != nil && event.RawName == "Transfer" && len(log.Topics) == 3 {
 			erc20, _ := erc20.NewErc20(receipt.ContractAddress, nil)
 			tf, err := erc20.ParseTransfer(*log)
 			if err != nil {
@@ -114,6 +117,40 @@ func BuildERC20Payload(to xc.Address, amount xc.AmountBlockchain) ([]byte, error
 	return data, nil
 }
 
+// Payload to change the allowance of a spender.  `increaseAllowance` is not part of ERC-20,
+// but is supported by most tokens (e.g. OpenZeppelin based).
+func BuildERC20ApprovalPayload(action xcbuilder.ApprovalAction, spender xc.Address, amount xc.AmountBlockchain) ([]byte, error) {
+	var fnSignature []byte
+	switch action {
+	case xcbuilder.ApprovalActionApprove:
+		fnSignature = []byte("approve(address,uint256)")
+	case xcbuilder.ApprovalActionIncrease:
+		fnSignature = []byte("increaseAllowance(address,uint256)")
+	case xcbuilder.ApprovalActionRevoke:
+		fnSignature = []byte("approve(address,uint256)")
+		amount = xc.NewAmountBlockchainFromUint64(0)
+	default:
+		return nil, fmt.Errorf("unsupported approval action: %s", action)
+	}
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(fnSignature)
+	methodID := hash.Sum(nil)[:4]
+
+	spenderAddress, err := evmaddress.FromHex(spender)
+	if err != nil {
+		return nil, err
+	}
+	paddedAddress := common.LeftPadBytes(spenderAddress.Bytes(), 32)
+	paddedAmount := common.LeftPadBytes(amount.Int().Bytes(), 32)
+
+	var data []byte
+	data = append(data, methodID...)
+	data = append(data, paddedAddress...)
+	data = append(data, paddedAmount...)
+
+	return data, nil
+}
+
 func BuildSmartAccountPayload(packedCalls []byte, signature xc.TxSignature) ([]byte, error) {
 
 	fnSignature := []byte("handleOps(bytes,uint256,uint256)")
@@ -0,0 +1,169 @@
+package tx
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	evmaddress "github.com/cordialsys/crosschain/chain/evm/address"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+const erc721ABI = `[
+	{"type":"function","name":"safeTransferFrom","stateMutability":"nonpayable","inputs":[
+		{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[]}
+]`
+
+const erc1155ABI = `[
+	{"type":"function","name":"safeTransferFrom","stateMutability":"nonpayable","inputs":[
+		{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"id","type":"uint256"},
+		{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"safeBatchTransferFrom","stateMutability":"nonpayable","inputs":[
+		{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"ids","type":"uint256[]"},
+		{"name":"values","type":"uint256[]"},{"name":"data","type":"bytes"}],"outputs":[]},
+	{"type":"event","name":"TransferSingle","anonymous":false,"inputs":[
+		{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"from","type":"address"},
+		{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"id","type":"uint256"},
+		{"indexed":false,"name":"value","type":"uint256"}]},
+	{"type":"event","name":"TransferBatch","anonymous":false,"inputs":[
+		{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"from","type":"address"},
+		{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"ids","type":"uint256[]"},
+		{"indexed":false,"name":"values","type":"uint256[]"}]}
+]`
+
+var ERC721 abi.ABI
+var ERC1155 abi.ABI
+
+func init() {
+	var err error
+	ERC721, err = abi.JSON(strings.NewReader(erc721ABI))
+	if err != nil {
+		panic(err)
+	}
+	ERC1155, err = abi.JSON(strings.NewReader(erc1155ABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Token ids may be decimal or 0x-prefixed hex
+func ParseTokenId(tokenId string) (*big.Int, error) {
+	id, ok := new(big.Int).SetString(tokenId, 0)
+	if !ok || id.Sign() < 0 {
+		return nil, fmt.Errorf("invalid token id: %s", tokenId)
+	}
+	return id, nil
+}
+
+// Payload to transfer NFTs using `safeTransferFrom`, or `safeBatchTransferFrom` when
+// multiple ERC-1155 token ids are transferred.
+func BuildNftTransferPayload(standard xcbuilder.NftStandard, from xc.Address, to xc.Address, tokens []xcbuilder.NftToken) ([]byte, error) {
+	fromAddress, err := evmaddress.FromHex(from)
+	if err != nil {
+		return nil, err
+	}
+	toAddress, err := evmaddress.FromHex(to)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]*big.Int, len(tokens))
+	amounts := make([]*big.Int, len(tokens))
+	for i, token := range tokens {
+		ids[i], err = ParseTokenId(token.TokenId)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = token.Amount.Int()
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no token ids to transfer")
+	}
+
+	switch standard {
+	case xcbuilder.NftStandardErc721:
+		if len(tokens) != 1 {
+			return nil, fmt.Errorf("erc721 transfers support a single token id")
+		}
+		return ERC721.Pack("safeTransferFrom", fromAddress, toAddress, ids[0])
+	case xcbuilder.NftStandardErc1155:
+		if len(tokens) == 1 {
+			return ERC1155.Pack("safeTransferFrom", fromAddress, toAddress, ids[0], amounts[0], []byte{})
+		}
+		return ERC1155.Pack("safeBatchTransferFrom", fromAddress, toAddress, ids, amounts, []byte{})
+	default:
+		return nil, fmt.Errorf("unsupported nft standard for evm: %s", standard)
+	}
+}
+
+// ParseNftLogs extracts ERC-721 and ERC-1155 transfers from the logs
+func ParseNftLogs(receipt *types.Receipt, nativeAsset xc.NativeAsset) SourcesAndDests {
+	sources := []*txinfo.LegacyTxInfoEndpoint{}
+	destinations := []*txinfo.LegacyTxInfoEndpoint{}
+	add := func(log *types.Log, eventMeta *txinfo.Event, from common.Address, to common.Address, tokenId *big.Int, amount *big.Int) {
+		for _, endpoint := range []struct {
+			address common.Address
+			into    *[]*txinfo.LegacyTxInfoEndpoint
+		}{{from, &sources}, {to, &destinations}} {
+			*endpoint.into = append(*endpoint.into, &txinfo.LegacyTxInfoEndpoint{
+				Address:         xc.Address(endpoint.address.String()),
+				ContractAddress: xc.ContractAddress(log.Address.String()),
+				TokenId:         tokenId.String(),
+				Amount:          xc.AmountBlockchain(*amount),
+				NativeAsset:     nativeAsset,
+				Event:           eventMeta,
+			})
+		}
+	}
+
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		switch {
+		case len(log.Topics) == 4 && log.Topics[0] == ERC20.Events["Transfer"].ID:
+			// ERC-721 has the same signature as ERC-20, but the token id is indexed
+			tokenId := new(big.Int).SetBytes(log.Topics[3].Bytes())
+			from := common.BytesToAddress(log.Topics[1].Bytes())
+			to := common.BytesToAddress(log.Topics[2].Bytes())
+			add(log, txinfo.NewEventFromIndex(uint64(log.Index), txinfo.MovementVariantNft), from, to, tokenId, big.NewInt(1))
+		case len(log.Topics) == 4 && log.Topics[0] == ERC1155.Events["TransferSingle"].ID:
+			values, err := ERC1155.Unpack("TransferSingle", log.Data)
+			if err != nil || len(values) != 2 {
+				logrus.WithError(err).WithField("index", log.Index).Warn("could not parse erc1155 log")
+				continue
+			}
+			from := common.BytesToAddress(log.Topics[2].Bytes())
+			to := common.BytesToAddress(log.Topics[3].Bytes())
+			add(log, txinfo.NewEventFromIndex(uint64(log.Index), txinfo.MovementVariantNft), from, to, values[0].(*big.Int), values[1].(*big.Int))
+		case len(log.Topics) == 4 && log.Topics[0] == ERC1155.Events["TransferBatch"].ID:
+			values, err := ERC1155.Unpack("TransferBatch", log.Data)
+			if err != nil || len(values) != 2 {
+				logrus.WithError(err).WithField("index", log.Index).Warn("could not parse erc1155 log")
+				continue
+			}
+			ids := values[0].([]*big.Int)
+			amounts := values[1].([]*big.Int)
+			if len(ids) != len(amounts) {
+				logrus.WithField("index", log.Index).Warn("mismatched erc1155 batch transfer")
+				continue
+			}
+			from := common.BytesToAddress(log.Topics[2].Bytes())
+			to := common.BytesToAddress(log.Topics[3].Bytes())
+			for i := range ids {
+				// one log contains the whole batch
+				eventMeta := txinfo.NewEvent(fmt.Sprintf("%d.%d", log.Index, i), txinfo.MovementVariantNft)
+				add(log, eventMeta, from, to, ids[i], amounts[i])
+			}
+		}
+	}
+	return SourcesAndDests{
+		Sources:      sources,
+		Destinations: destinations,
+	}
+}
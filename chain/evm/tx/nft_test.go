@@ -0,0 +1,116 @@
+package tx_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/evm/tx"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildNftTransferPayload(t *testing.T) {
+	from := xc.Address("0x273b437645Ba723299d07B1BdFFcf508bE64771f")
+	to := xc.Address("0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1")
+
+	data, err := tx.BuildNftTransferPayload(xcbuilder.NftStandardErc721, from, to, []xcbuilder.NftToken{xcbuilder.NewNftToken("0x10")})
+	require.NoError(t, err)
+	// safeTransferFrom(address,address,uint256)
+	require.Equal(t, "42842e0e", hex.EncodeToString(data[:4]))
+	require.Len(t, data, 4+3*32)
+	require.EqualValues(t, 16, new(big.Int).SetBytes(data[4+2*32:]).Uint64())
+
+	data, err = tx.BuildNftTransferPayload(xcbuilder.NftStandardErc1155, from, to, []xcbuilder.NftToken{
+		{TokenId: "7", Amount: xc.NewAmountBlockchainFromUint64(5)},
+	})
+	require.NoError(t, err)
+	// safeTransferFrom(address,address,uint256,uint256,bytes)
+	require.Equal(t, "f242432a", hex.EncodeToString(data[:4]))
+
+	data, err = tx.BuildNftTransferPayload(xcbuilder.NftStandardErc1155, from, to, []xcbuilder.NftToken{
+		{TokenId: "7", Amount: xc.NewAmountBlockchainFromUint64(5)},
+		{TokenId: "8", Amount: xc.NewAmountBlockchainFromUint64(1)},
+	})
+	require.NoError(t, err)
+	// safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)
+	require.Equal(t, "2eb2c2d6", hex.EncodeToString(data[:4]))
+
+	_, err = tx.BuildNftTransferPayload(xcbuilder.NftStandardErc721, from, to, []xcbuilder.NftToken{xcbuilder.NewNftToken("abc")})
+	require.ErrorContains(t, err, "invalid token id")
+	_, err = tx.BuildNftTransferPayload(xcbuilder.NftStandardMetaplex, from, to, []xcbuilder.NftToken{xcbuilder.NewNftToken("1")})
+	require.ErrorContains(t, err, "unsupported nft standard")
+}
+
+func TestParseNftLogs(t *testing.T) {
+	contract := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	from := common.HexToAddress("0x273b437645Ba723299d07B1BdFFcf508bE64771f")
+	to := common.HexToAddress("0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1")
+	single, err := tx.ERC1155.Events["TransferSingle"].Inputs.NonIndexed().Pack(big.NewInt(7), big.NewInt(5))
+	require.NoError(t, err)
+	batch, err := tx.ERC1155.Events["TransferBatch"].Inputs.NonIndexed().Pack(
+		[]*big.Int{big.NewInt(8), big.NewInt(9)}, []*big.Int{big.NewInt(1), big.NewInt(2)},
+	)
+	require.NoError(t, err)
+
+	receipt := &types.Receipt{Logs: []*types.Log{
+		{
+			// erc721
+			Address: contract,
+			Topics:  []common.Hash{tx.ERC20.Events["Transfer"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes()), common.BigToHash(big.NewInt(42))},
+			Index:   0,
+		},
+		{
+			// erc20 is ignored
+			Address: contract,
+			Topics:  []common.Hash{tx.ERC20.Events["Transfer"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:    common.BigToHash(big.NewInt(1000)).Bytes(),
+			Index:   1,
+		},
+		{
+			Address: contract,
+			Topics:  []common.Hash{tx.ERC1155.Events["TransferSingle"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:    single,
+			Index:   2,
+		},
+		{
+			Address: contract,
+			Topics:  []common.Hash{tx.ERC1155.Events["TransferBatch"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:    batch,
+			Index:   3,
+		},
+	}}
+	movements := tx.ParseNftLogs(receipt, xc.ETH)
+	require.Len(t, movements.Sources, 4)
+	require.Len(t, movements.Destinations, 4)
+
+	type expected struct {
+		tokenId string
+		amount  string
+		eventId string
+	}
+	for i, exp := range []expected{
+		{"42", "1", "0"},
+		{"7", "5", "2"},
+		{"8", "1", "3.0"},
+		{"9", "2", "3.1"},
+	} {
+		require.Equal(t, exp.tokenId, movements.Sources[i].TokenId)
+		require.Equal(t, exp.tokenId, movements.Destinations[i].TokenId)
+		require.Equal(t, exp.amount, movements.Destinations[i].Amount.String())
+		require.Equal(t, exp.eventId, movements.Destinations[i].Event.Id)
+		require.Equal(t, txinfo.MovementVariantNft, movements.Destinations[i].Event.Variant)
+		require.EqualValues(t, from.String(), movements.Sources[i].Address)
+		require.EqualValues(t, to.String(), movements.Destinations[i].Address)
+		require.EqualValues(t, contract.String(), movements.Destinations[i].ContractAddress)
+	}
+
+	// the erc721 transfer should not be parsed as a token transfer
+	tokenMovements := tx.ParseTokenLogs(receipt, xc.ETH)
+	require.Len(t, tokenMovements.Destinations, 1)
+	require.Equal(t, "1000", tokenMovements.Destinations[0].Amount.String())
+}
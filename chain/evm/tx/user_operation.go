@@ -0,0 +1,425 @@
+package tx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/evm/address"
+	"github.com/cordialsys/crosschain/chain/evm/tx_input"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Canonical deployment of the ERC-4337 v0.7 EntryPoint
+const EntryPointV07Address = "0x0000000071727De22E5E9d8BAf0edAc6f37da032"
+
+// Minimal ABI of the smart account (e.g. SimpleAccount v0.7) that is used to execute calls
+const smartAccountExecuteABI = `[
+	{"inputs":[{"name":"dest","type":"address"},{"name":"value","type":"uint256"},{"name":"func","type":"bytes"}],"name":"execute","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"dest","type":"address[]"},{"name":"value","type":"uint256[]"},{"name":"func","type":"bytes[]"}],"name":"executeBatch","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+var SmartAccountExecute abi.ABI
+
+// abi.encode(bytes32,address,uint256)
+var userOpHashArguments abi.Arguments
+
+// abi.encode(address,uint256,bytes32,bytes32,bytes32,uint256,bytes32,bytes32)
+var packedUserOpArguments abi.Arguments
+
+func init() {
+	var err error
+	SmartAccountExecute, err = abi.JSON(strings.NewReader(smartAccountExecuteABI))
+	if err != nil {
+		panic(err)
+	}
+	bytes32, _ := abi.NewType("bytes32", "", nil)
+	addressT, _ := abi.NewType("address", "", nil)
+	uint256T, _ := abi.NewType("uint256", "", nil)
+	userOpHashArguments = abi.Arguments{{Type: bytes32}, {Type: addressT}, {Type: uint256T}}
+	packedUserOpArguments = abi.Arguments{
+		{Type: addressT}, {Type: uint256T}, {Type: bytes32}, {Type: bytes32},
+		{Type: bytes32}, {Type: uint256T}, {Type: bytes32}, {Type: bytes32},
+	}
+}
+
+// UserOperation per ERC-4337 EntryPoint v0.7 (unpacked form)
+type UserOperation struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	Factory              *common.Address
+	FactoryData          []byte
+	CallData             []byte
+	CallGasLimit         uint64
+	VerificationGasLimit uint64
+	PreVerificationGas   uint64
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+
+	Paymaster                     *common.Address
+	PaymasterVerificationGasLimit uint64
+	PaymasterPostOpGasLimit       uint64
+	PaymasterData                 []byte
+
+	Signature []byte
+}
+
+// JSON-RPC representation of a v0.7 UserOperation, as used by the bundler RPC methods.
+type RpcUserOperation struct {
+	Sender               common.Address  `json:"sender"`
+	Nonce                *hexutil.Big    `json:"nonce"`
+	Factory              *common.Address `json:"factory,omitempty"`
+	FactoryData          hexutil.Bytes   `json:"factoryData,omitempty"`
+	CallData             hexutil.Bytes   `json:"callData"`
+	CallGasLimit         hexutil.Uint64  `json:"callGasLimit"`
+	VerificationGasLimit hexutil.Uint64  `json:"verificationGasLimit"`
+	PreVerificationGas   hexutil.Uint64  `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+
+	Paymaster                     *common.Address `json:"paymaster,omitempty"`
+	PaymasterVerificationGasLimit *hexutil.Uint64 `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       *hexutil.Uint64 `json:"paymasterPostOpGasLimit,omitempty"`
+	PaymasterData                 hexutil.Bytes   `json:"paymasterData,omitempty"`
+
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+func pack128(high uint64, low uint64) []byte {
+	bz := make([]byte, 32)
+	new(big.Int).SetUint64(high).FillBytes(bz[:16])
+	new(big.Int).SetUint64(low).FillBytes(bz[16:])
+	return bz
+}
+
+func pack128Big(high *big.Int, low *big.Int) []byte {
+	bz := make([]byte, 32)
+	high.FillBytes(bz[:16])
+	low.FillBytes(bz[16:])
+	return bz
+}
+
+// The init-code of the packed form is the factory address followed by the factory data
+func (op *UserOperation) InitCode() []byte {
+	if op.Factory == nil {
+		return []byte{}
+	}
+	return append(op.Factory.Bytes(), op.FactoryData...)
+}
+
+func (op *UserOperation) PaymasterAndData() []byte {
+	if op.Paymaster == nil {
+		return []byte{}
+	}
+	bz := []byte{}
+	bz = append(bz, op.Paymaster.Bytes()...)
+	bz = append(bz, pack128(0, op.PaymasterVerificationGasLimit)[16:]...)
+	bz = append(bz, pack128(0, op.PaymasterPostOpGasLimit)[16:]...)
+	bz = append(bz, op.PaymasterData...)
+	return bz
+}
+
+// The hash of the UserOperation, as computed by `EntryPoint.getUserOpHash` (v0.7).
+func (op *UserOperation) Hash(entryPoint common.Address, chainId *big.Int) (common.Hash, error) {
+	var accountGasLimits, gasFees [32]byte
+	copy(accountGasLimits[:], pack128(op.VerificationGasLimit, op.CallGasLimit))
+	copy(gasFees[:], pack128Big(op.MaxPriorityFeePerGas, op.MaxFeePerGas))
+
+	initCodeHash := crypto.Keccak256Hash(op.InitCode())
+	callDataHash := crypto.Keccak256Hash(op.CallData)
+	paymasterAndDataHash := crypto.Keccak256Hash(op.PaymasterAndData())
+
+	packed, err := packedUserOpArguments.Pack(
+		op.Sender,
+		op.Nonce,
+		initCodeHash,
+		callDataHash,
+		accountGasLimits,
+		new(big.Int).SetUint64(op.PreVerificationGas),
+		gasFees,
+		paymasterAndDataHash,
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	encoded, err := userOpHashArguments.Pack(crypto.Keccak256Hash(packed), entryPoint, chainId)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+func (op *UserOperation) ToRpc() *RpcUserOperation {
+	rpcOp := &RpcUserOperation{
+		Sender:               op.Sender,
+		Nonce:                (*hexutil.Big)(op.Nonce),
+		CallData:             op.CallData,
+		CallGasLimit:         hexutil.Uint64(op.CallGasLimit),
+		VerificationGasLimit: hexutil.Uint64(op.VerificationGasLimit),
+		PreVerificationGas:   hexutil.Uint64(op.PreVerificationGas),
+		MaxFeePerGas:         (*hexutil.Big)(op.MaxFeePerGas),
+		MaxPriorityFeePerGas: (*hexutil.Big)(op.MaxPriorityFeePerGas),
+		Signature:            op.Signature,
+	}
+	if op.Factory != nil {
+		rpcOp.Factory = op.Factory
+		rpcOp.FactoryData = op.FactoryData
+	}
+	if op.Paymaster != nil {
+		verificationGasLimit := hexutil.Uint64(op.PaymasterVerificationGasLimit)
+		postOpGasLimit := hexutil.Uint64(op.PaymasterPostOpGasLimit)
+		rpcOp.Paymaster = op.Paymaster
+		rpcOp.PaymasterVerificationGasLimit = &verificationGasLimit
+		rpcOp.PaymasterPostOpGasLimit = &postOpGasLimit
+		rpcOp.PaymasterData = op.PaymasterData
+	}
+	return rpcOp
+}
+
+// Encode the calls for the smart account to execute, using `execute` for a single call
+// and `executeBatch` for multiple calls.
+func EncodeSmartAccountCalls(calls []SmartAccountCall) ([]byte, error) {
+	if len(calls) == 0 {
+		return nil, errors.New("no calls to execute")
+	}
+	if len(calls) == 1 {
+		return SmartAccountExecute.Pack("execute", calls[0].To, calls[0].Value, calls[0].Data)
+	}
+	destinations := make([]common.Address, len(calls))
+	values := make([]*big.Int, len(calls))
+	datas := make([][]byte, len(calls))
+	for i, call := range calls {
+		destinations[i] = call.To
+		values[i] = call.Value
+		datas[i] = call.Data
+		if datas[i] == nil {
+			datas[i] = []byte{}
+		}
+	}
+	return SmartAccountExecute.Pack("executeBatch", destinations, values, datas)
+}
+
+// Decode the calls executed by a smart account, from `execute` or `executeBatch` call-data.
+func DecodeSmartAccountCalls(callData []byte) ([]SmartAccountCall, error) {
+	if len(callData) < 4 {
+		return nil, errors.New("call-data too short")
+	}
+	method, err := SmartAccountExecute.MethodById(callData[:4])
+	if err != nil {
+		return nil, err
+	}
+	values, err := method.Inputs.Unpack(callData[4:])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "execute":
+		return []SmartAccountCall{{
+			To:    values[0].(common.Address),
+			Value: values[1].(*big.Int),
+			Data:  values[2].([]byte),
+		}}, nil
+	default:
+		destinations := values[0].([]common.Address)
+		amounts := values[1].([]*big.Int)
+		datas := values[2].([][]byte)
+		calls := make([]SmartAccountCall, len(destinations))
+		for i := range destinations {
+			calls[i] = SmartAccountCall{To: destinations[i], Value: big.NewInt(0), Data: datas[i]}
+			// value array may be empty when all values are zero
+			if i < len(amounts) {
+				calls[i].Value = amounts[i]
+			}
+		}
+		return calls, nil
+	}
+}
+
+// Metadata needed by the client to submit the UserOperation to the bundler
+type UserOperationMetadata struct {
+	EntryPoint xc.Address `json:"entry_point"`
+}
+
+// UserOperationTx is an ERC-4337 UserOperation, signed by the owner of the smart account.
+type UserOperationTx struct {
+	chain     *xc.ChainBaseConfig
+	input     *tx_input.TxInput
+	calls     []SmartAccountCall
+	signature xc.TxSignature
+}
+
+var _ xc.Tx = &UserOperationTx{}
+var _ xc.TxWithMetadata = &UserOperationTx{}
+
+func NewUserOperationTx(chain *xc.ChainBaseConfig, input *tx_input.TxInput, calls []SmartAccountCall) (*UserOperationTx, error) {
+	if input.UserOperation == nil {
+		return nil, errors.New("tx-input is missing user-operation information")
+	}
+	if len(calls) == 0 {
+		return nil, errors.New("no calls to execute")
+	}
+	return &UserOperationTx{
+		chain: chain,
+		input: input,
+		calls: calls,
+	}, nil
+}
+
+// Build the calls for the smart account to execute, supporting both native and token transfers.
+func SmartAccountCallsFromTransfers(transfers []*xcbuilder.TransferArgs) ([]SmartAccountCall, error) {
+	calls := []SmartAccountCall{}
+	for _, transfer := range transfers {
+		destination, amount, data, err := EvmDestinationAndAmountAndData(transfer.GetTo(), transfer.GetAmount(), transfer)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, SmartAccountCall{
+			To:    destination,
+			Value: amount,
+			Data:  data,
+		})
+	}
+	return calls, nil
+}
+
+func (tx *UserOperationTx) UserOperation() (*UserOperation, error) {
+	opInput := tx.input.UserOperation
+	sender, err := address.FromHex(tx.input.FromAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smart account address: %v", err)
+	}
+	callData, err := EncodeSmartAccountCalls(tx.calls)
+	if err != nil {
+		return nil, err
+	}
+	op := &UserOperation{
+		Sender:               sender,
+		Nonce:                opInput.Nonce.Int(),
+		CallData:             callData,
+		CallGasLimit:         opInput.CallGasLimit,
+		VerificationGasLimit: opInput.VerificationGasLimit,
+		PreVerificationGas:   opInput.PreVerificationGas,
+		MaxFeePerGas:         tx.input.GasFeeCap.Int(),
+		MaxPriorityFeePerGas: tx.input.GasTipCap.Int(),
+		Signature:            tx.signature,
+	}
+	if opInput.Factory != "" {
+		factory, err := address.FromHex(opInput.Factory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid factory address: %v", err)
+		}
+		op.Factory = &factory
+		op.FactoryData = opInput.FactoryData
+	}
+	if opInput.Paymaster != "" {
+		paymaster, err := address.FromHex(opInput.Paymaster)
+		if err != nil {
+			return nil, fmt.Errorf("invalid paymaster address: %v", err)
+		}
+		op.Paymaster = &paymaster
+		op.PaymasterVerificationGasLimit = opInput.PaymasterVerificationGasLimit
+		op.PaymasterPostOpGasLimit = opInput.PaymasterPostOpGasLimit
+		op.PaymasterData = opInput.PaymasterData
+	}
+	if len(op.Signature) == 0 {
+		op.Signature = DummyUserOperationSignature()
+	}
+	return op, nil
+}
+
+// Placeholder ECDSA signature that passes validation of common smart accounts, for gas estimation.
+func DummyUserOperationSignature() []byte {
+	return common.FromHex("0xfffffffffffffffffffffffffffffff0000000000000000000000000000000007aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1c")
+}
+
+func (tx *UserOperationTx) entryPoint() (common.Address, error) {
+	entryPoint := tx.input.UserOperation.EntryPoint
+	if entryPoint == "" {
+		entryPoint = EntryPointV07Address
+	}
+	return address.FromHex(entryPoint)
+}
+
+func (tx *UserOperationTx) userOpHash() (common.Hash, error) {
+	op, err := tx.UserOperation()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	entryPoint, err := tx.entryPoint()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	chainId := GetChainId(tx.chain, tx.input)
+	return op.Hash(entryPoint, chainId.ToBig())
+}
+
+// Hash returns the UserOperation hash, which is what bundlers index the operation by.
+func (tx *UserOperationTx) Hash() xc.TxHash {
+	hash, err := tx.userOpHash()
+	if err != nil {
+		return ""
+	}
+	return xc.TxHash(hash.Hex())
+}
+
+// The owner signs the UserOperation hash as an EIP-191 personal message, which is what
+// ECDSA-based smart accounts (e.g. SimpleAccount) validate.
+func (tx *UserOperationTx) Sighashes() ([]*xc.SignatureRequest, error) {
+	hash, err := tx.userOpHash()
+	if err != nil {
+		return nil, err
+	}
+	return []*xc.SignatureRequest{
+		xc.NewSignatureRequest(accounts.TextHash(hash.Bytes()), tx.input.UserOperation.Owner),
+	}, nil
+}
+
+func (tx *UserOperationTx) SetSignatures(signatures ...*xc.SignatureResponse) error {
+	if len(signatures) != 1 {
+		return fmt.Errorf("expected 1 signature, got %d", len(signatures))
+	}
+	signature := append(xc.TxSignature{}, signatures[0].Signature...)
+	if len(signature) != 65 {
+		return fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	// smart accounts use the legacy recovery id
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+	tx.signature = signature
+	return nil
+}
+
+// Serialize returns the JSON-RPC encoding of the UserOperation
+func (tx *UserOperationTx) Serialize() ([]byte, error) {
+	if len(tx.signature) == 0 {
+		return nil, errors.New("user-operation is not signed")
+	}
+	op, err := tx.UserOperation()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(op.ToRpc())
+}
+
+func (tx *UserOperationTx) GetMetadata() ([]byte, bool, error) {
+	entryPoint, err := tx.entryPoint()
+	if err != nil {
+		return nil, false, err
+	}
+	metadataBz, err := json.Marshal(UserOperationMetadata{
+		EntryPoint: xc.Address(entryPoint.Hex()),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return metadataBz, true, nil
+}
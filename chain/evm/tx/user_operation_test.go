@@ -0,0 +1,171 @@
+package tx_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/evm/tx"
+	"github.com/cordialsys/crosschain/chain/evm/tx_input"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newUserOperationTx(t *testing.T, calls []tx.SmartAccountCall) *tx.UserOperationTx {
+	chain := xc.NewChainConfig(xc.ETH).WithChainID("1").Base()
+	input := tx_input.NewTxInput()
+	input.FromAddress = "0x1111111111111111111111111111111111111111"
+	input.ChainId = xc.NewAmountBlockchainFromUint64(1)
+	input.GasFeeCap = xc.NewAmountBlockchainFromUint64(30_000_000_000)
+	input.GasTipCap = xc.NewAmountBlockchainFromUint64(1_000_000_000)
+	input.UserOperation = &tx_input.UserOperationInput{
+		EntryPoint:           tx.EntryPointV07Address,
+		Owner:                "0x2222222222222222222222222222222222222222",
+		Nonce:                xc.NewAmountBlockchainFromUint64(5),
+		CallGasLimit:         100_000,
+		VerificationGasLimit: 150_000,
+		PreVerificationGas:   50_000,
+	}
+	userOpTx, err := tx.NewUserOperationTx(chain, input, calls)
+	require.NoError(t, err)
+	return userOpTx
+}
+
+func TestSmartAccountCallsRoundTrip(t *testing.T) {
+	single := []tx.SmartAccountCall{
+		{To: common.HexToAddress("0x3333333333333333333333333333333333333333"), Value: big.NewInt(10), Data: []byte{}},
+	}
+	batch := []tx.SmartAccountCall{
+		{To: common.HexToAddress("0x3333333333333333333333333333333333333333"), Value: big.NewInt(10), Data: []byte{}},
+		{To: common.HexToAddress("0x4444444444444444444444444444444444444444"), Value: big.NewInt(0), Data: []byte{0xa9, 0x05, 0x9c, 0xbb}},
+	}
+	for _, calls := range [][]tx.SmartAccountCall{single, batch} {
+		callData, err := tx.EncodeSmartAccountCalls(calls)
+		require.NoError(t, err)
+		decoded, err := tx.DecodeSmartAccountCalls(callData)
+		require.NoError(t, err)
+		require.Len(t, decoded, len(calls))
+		for i := range calls {
+			require.Equal(t, calls[i].To, decoded[i].To)
+			require.Equal(t, calls[i].Value.String(), decoded[i].Value.String())
+			require.Equal(t, calls[i].Data, decoded[i].Data)
+		}
+	}
+	_, err := tx.EncodeSmartAccountCalls(nil)
+	require.Error(t, err)
+}
+
+func TestUserOperationTx(t *testing.T) {
+	calls := []tx.SmartAccountCall{
+		{To: common.HexToAddress("0x3333333333333333333333333333333333333333"), Value: big.NewInt(10), Data: []byte{}},
+	}
+	userOpTx := newUserOperationTx(t, calls)
+
+	hash := userOpTx.Hash()
+	require.Len(t, hash, 66)
+
+	sighashes, err := userOpTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+	require.Equal(t, xc.Address("0x2222222222222222222222222222222222222222"), sighashes[0].Signer)
+	// personal-message hash of the user-op hash
+	require.NotEqual(t, common.FromHex(string(hash)), sighashes[0].Payload)
+
+	_, err = userOpTx.Serialize()
+	require.ErrorContains(t, err, "not signed")
+
+	signature := make([]byte, 65)
+	signature[64] = 1
+	err = userOpTx.SetSignatures(&xc.SignatureResponse{Signature: signature})
+	require.NoError(t, err)
+
+	// the signature is not part of the user-op hash
+	require.Equal(t, hash, userOpTx.Hash())
+
+	bz, err := userOpTx.Serialize()
+	require.NoError(t, err)
+	rpcOp := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(bz, &rpcOp))
+	require.Equal(t, "0x1111111111111111111111111111111111111111", rpcOp["sender"])
+	require.Equal(t, "0x5", rpcOp["nonce"])
+	// recovery id is converted to 27/28
+	require.Equal(t, "0x"+common.Bytes2Hex(signature[:64])+"1c", rpcOp["signature"])
+	require.NotContains(t, rpcOp, "paymaster")
+
+	metadataBz, ok, err := userOpTx.GetMetadata()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.JSONEq(t, `{"entry_point":"0x0000000071727De22E5E9d8BAf0edAc6f37da032"}`, string(metadataBz))
+
+	require.Error(t, userOpTx.SetSignatures(&xc.SignatureResponse{Signature: []byte{1, 2, 3}}))
+}
+
+func TestUserOperationTxHashChanges(t *testing.T) {
+	calls1 := []tx.SmartAccountCall{
+		{To: common.HexToAddress("0x3333333333333333333333333333333333333333"), Value: big.NewInt(10), Data: []byte{}},
+	}
+	calls2 := []tx.SmartAccountCall{
+		{To: common.HexToAddress("0x3333333333333333333333333333333333333333"), Value: big.NewInt(11), Data: []byte{}},
+	}
+	require.Equal(t, newUserOperationTx(t, calls1).Hash(), newUserOperationTx(t, calls1).Hash())
+	require.NotEqual(t, newUserOperationTx(t, calls1).Hash(), newUserOperationTx(t, calls2).Hash())
+}
+
+// Reference vector of EntryPoint v0.7 `getUserOpHash`, for a UserOperation that deploys
+// a SimpleAccount through its factory and is sponsored by a paymaster.
+func TestUserOperationHashVector(t *testing.T) {
+	chain := xc.NewChainConfig(xc.ETH).WithChainID("1").Base()
+	input := tx_input.NewTxInput()
+	input.FromAddress = "0x1111111111111111111111111111111111111111"
+	input.ChainId = xc.NewAmountBlockchainFromUint64(1)
+	input.GasFeeCap = xc.NewAmountBlockchainFromUint64(30_000_000_000)
+	input.GasTipCap = xc.NewAmountBlockchainFromUint64(1_000_000_000)
+	input.UserOperation = &tx_input.UserOperationInput{
+		EntryPoint:           tx.EntryPointV07Address,
+		Owner:                "0x2222222222222222222222222222222222222222",
+		Nonce:                xc.NewAmountBlockchainFromUint64(5),
+		CallGasLimit:         100_000,
+		VerificationGasLimit: 150_000,
+		PreVerificationGas:   50_000,
+		// SimpleAccountFactory.createAccount(owner, 0)
+		Factory:                       "0x91E60e0613810449d098b0b5Ec8b51A0FE8c8985",
+		FactoryData:                   common.FromHex("0x5fbfb9cf00000000000000000000000022222222222222222222222222222222222222220000000000000000000000000000000000000000000000000000000000000000"),
+		Paymaster:                     "0x5555555555555555555555555555555555555555",
+		PaymasterVerificationGasLimit: 60_000,
+		PaymasterPostOpGasLimit:       20_000,
+		PaymasterData:                 common.FromHex("0xdeadbeef"),
+	}
+	calls := []tx.SmartAccountCall{
+		{To: common.HexToAddress("0x3333333333333333333333333333333333333333"), Value: big.NewInt(10), Data: []byte{}},
+	}
+	userOpTx, err := tx.NewUserOperationTx(chain, input, calls)
+	require.NoError(t, err)
+
+	op, err := userOpTx.UserOperation()
+	require.NoError(t, err)
+	require.Equal(t,
+		"0xb61d27f60000000000000000000000003333333333333333333333333333333333333333000000000000000000000000000000000000000000000000000000000000000a00000000000000000000000000000000000000000000000000000000000000600000000000000000000000000000000000000000000000000000000000000000",
+		hexutil.Encode(op.CallData),
+	)
+	require.Equal(t,
+		"0x91e60e0613810449d098b0b5ec8b51a0fe8c89855fbfb9cf00000000000000000000000022222222222222222222222222222222222222220000000000000000000000000000000000000000000000000000000000000000",
+		hexutil.Encode(op.InitCode()),
+	)
+	require.Equal(t,
+		"0x55555555555555555555555555555555555555550000000000000000000000000000ea6000000000000000000000000000004e20deadbeef",
+		hexutil.Encode(op.PaymasterAndData()),
+	)
+	require.EqualValues(t, "0xdc8de84d1c4b468be2135323a90541cb0e10e439f71cff7a27e7c49bdcc94ffd", userOpTx.Hash())
+
+	signature := make([]byte, 65)
+	require.NoError(t, userOpTx.SetSignatures(&xc.SignatureResponse{Signature: signature}))
+	bz, err := userOpTx.Serialize()
+	require.NoError(t, err)
+	rpcOp := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(bz, &rpcOp))
+	require.Equal(t, "0x91e60e0613810449d098b0b5ec8b51a0fe8c8985", rpcOp["factory"])
+	require.Equal(t, hexutil.Encode(input.UserOperation.FactoryData), rpcOp["factoryData"])
+	require.Equal(t, "0x5555555555555555555555555555555555555555", rpcOp["paymaster"])
+}
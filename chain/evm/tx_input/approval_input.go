@@ -0,0 +1,35 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/drivers/registry"
+)
+
+type ApprovalInput struct {
+	TxInput
+}
+
+func init() {
+	registry.RegisterTxVariantInput(&ApprovalInput{})
+}
+
+var _ xc.TxVariantInput = &ApprovalInput{}
+var _ xc.ApprovalTxInput = &ApprovalInput{}
+
+func NewApprovalInput() *ApprovalInput {
+	return &ApprovalInput{}
+}
+
+func (input *ApprovalInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewApprovalInputType(xc.DriverEVM, "erc20")
+}
+
+func (input *ApprovalInput) Approving() {}
+
+func (input *ApprovalInput) GetNonce() uint64 {
+	return input.Nonce
+}
+
+func (input *ApprovalInput) GetFromAddress() string {
+	return string(input.FromAddress)
+}
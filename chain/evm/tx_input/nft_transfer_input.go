@@ -0,0 +1,35 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/drivers/registry"
+)
+
+type NftTransferInput struct {
+	TxInput
+}
+
+func init() {
+	registry.RegisterTxVariantInput(&NftTransferInput{})
+}
+
+var _ xc.TxVariantInput = &NftTransferInput{}
+var _ xc.NftTransferTxInput = &NftTransferInput{}
+
+func NewNftTransferInput() *NftTransferInput {
+	return &NftTransferInput{}
+}
+
+func (input *NftTransferInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewNftTransferInputType(xc.DriverEVM, "nft")
+}
+
+func (input *NftTransferInput) NftTransferring() {}
+
+func (input *NftTransferInput) GetNonce() uint64 {
+	return input.Nonce
+}
+
+func (input *NftTransferInput) GetFromAddress() string {
+	return string(input.FromAddress)
+}
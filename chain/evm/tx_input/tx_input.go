@@ -32,6 +32,9 @@ type TxInput struct {
 	FeePayerAddress        xc.Address `json:"fee_payer_address,omitempty"`
 	FeePayerNonce          uint64     `json:"fee_payer_nonce,omitempty"`
 
+	// For ERC-4337 transactions, sent through a bundler
+	UserOperation *UserOperationInput `json:"user_operation,omitempty"`
+
 	// legacy only
 	Prices []*Price `json:"prices,omitempty"`
 }
@@ -95,6 +98,9 @@ func (input *TxInput) SetGasFeePriority(other xc.GasFeePriority) error {
 
 func (input *TxInput) GetFeeLimit() (xc.AmountBlockchain, xc.ContractAddress) {
 	gasLimit := xc.NewAmountBlockchainFromUint64(input.GasLimit)
+	if input.UserOperation != nil {
+		gasLimit = xc.NewAmountBlockchainFromUint64(input.UserOperation.TotalGas())
+	}
 
 	legacyMaxFeeSpend := input.GasPrice.Mul(&gasLimit)
 	dynamicMaxFeeSpend := input.GasFeeCap.Mul(&gasLimit)
@@ -0,0 +1,40 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/pkg/hex"
+)
+
+// Input for sending an ERC-4337 UserOperation through a bundler, rather than a regular transaction.
+// The smart account is the `FromAddress` of the TxInput, and the fees (max fee, max priority fee) are
+// taken from the TxInput's dynamic fee fields.
+type UserOperationInput struct {
+	// The EntryPoint contract the UserOperation is submitted to (v0.7)
+	EntryPoint xc.Address `json:"entry_point"`
+	// The EOA that owns the smart account and signs the UserOperation
+	Owner xc.Address `json:"owner"`
+	// EntryPoint nonce of the smart account (192-bit key + 64-bit sequence)
+	Nonce xc.AmountBlockchain `json:"nonce"`
+
+	CallGasLimit         uint64 `json:"call_gas_limit"`
+	VerificationGasLimit uint64 `json:"verification_gas_limit"`
+	PreVerificationGas   uint64 `json:"pre_verification_gas"`
+
+	// Optional factory that deploys the smart account with its first UserOperation
+	Factory     xc.Address `json:"factory,omitempty"`
+	FactoryData hex.Hex    `json:"factory_data,omitempty"`
+
+	// Optional paymaster that sponsors the fees of the UserOperation
+	Paymaster                     xc.Address `json:"paymaster,omitempty"`
+	PaymasterVerificationGasLimit uint64     `json:"paymaster_verification_gas_limit,omitempty"`
+	PaymasterPostOpGasLimit       uint64     `json:"paymaster_post_op_gas_limit,omitempty"`
+	PaymasterData                 hex.Hex    `json:"paymaster_data,omitempty"`
+}
+
+func (input *UserOperationInput) TotalGas() uint64 {
+	return input.CallGasLimit +
+		input.VerificationGasLimit +
+		input.PreVerificationGas +
+		input.PaymasterVerificationGasLimit +
+		input.PaymasterPostOpGasLimit
+}
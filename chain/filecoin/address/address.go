@@ -35,6 +35,11 @@ const (
 	// ChecksumHashLength defines the hash length used for calculating address checksums.
 	ChecksumHashLength = 4
 
+	// Address formats: f1 (secp256k1) addresses are the default, f4 addresses are
+	// f410 addresses of the same key, which can interact with the FEVM.
+	FormatSecp256k1 xc.AddressFormat = "f1"
+	FormatDelegated xc.AddressFormat = "f4"
+
 	payloadHashSize        = 20
 	checksumHashSize       = 4
 	protocolIdRawMaxLength = 20
@@ -46,9 +51,11 @@ var Encoding = base32.NewEncoding(EncodeStd)
 type AddressBuilder struct {
 	network   string
 	alghoritm xc.SignatureType
+	format    xc.AddressFormat
 }
 
 var _ xc.AddressBuilder = AddressBuilder{}
+var _ xc.AddressBuilderWithFormats = AddressBuilder{}
 
 // NewAddressBuilder creates a new address builder and validate provided algorithm.
 // Default algorithm is specified in `Driver.SignatureAlgorithm()`
@@ -66,12 +73,26 @@ func NewAddressBuilder(asset *xc.ChainBaseConfig, options ...xcaddress.AddressOp
 		return AddressBuilder{}, fmt.Errorf("unsupported address type: %s", algorithm)
 	}
 
+	format, _ := opts.GetFormat()
+	if format != "" && format != FormatSecp256k1 && format != FormatDelegated {
+		return AddressBuilder{}, fmt.Errorf(
+			"unsupported format: %s, expected: ['%s', '%s'] - default: '%s'",
+			format, FormatSecp256k1, FormatDelegated, FormatSecp256k1,
+		)
+	}
+
 	return AddressBuilder{
 		network:   asset.Network,
 		alghoritm: algorithm,
+		format:    format,
 	}, nil
 }
 
+// Both formats use secp256k1 keys, f4 addresses sign the keccak digest of an Ethereum transaction
+func (ab AddressBuilder) GetSignatureAlgorithm() xc.SignatureType {
+	return ab.alghoritm
+}
+
 func (ab AddressBuilder) GetSecp256k1Address(publicKeyBytes []byte) (xc.Address, error) {
 	pk, err := btcec.ParsePubKey(publicKeyBytes)
 	if err != nil {
@@ -132,6 +153,9 @@ func (ab AddressBuilder) GetAddressFromPublicKey(publicKeyBytes []byte) (xc.Addr
 		// TODO: Add support for BLS key generation
 		// return ab.GetBlsAddress(publicKeyBytes)
 	case "k256-sha256":
+		if ab.format == FormatDelegated {
+			return ab.GetDelegatedAddress(publicKeyBytes)
+		}
 		return ab.GetSecp256k1Address(publicKeyBytes)
 	}
 
@@ -153,14 +177,7 @@ func hash(ingest []byte, hashSize int) ([]byte, error) {
 
 // Filecoin address prefix is set to "f" for mainnet and "t" for testnet
 func (ab AddressBuilder) getPrefix() (string, error) {
-	switch ab.network {
-	case "mainnet":
-		return "f", nil
-	case "testnet":
-		return "t", nil
-	default:
-		return "", fmt.Errorf("invalid network: %s", ab.network)
-	}
+	return NetworkPrefix(ab.network)
 }
 
 func ProtocolIdToBytes(rawAddress string) ([]byte, error) {
@@ -190,9 +207,9 @@ func AddressToBytes(addr string) ([]byte, error) {
 		protocol = ProtocolActor
 	case '3':
 		protocol = ProtocolBls
-	// Delegate transactions require special transaction handling.
-	// For example 410 is an EVM address space, which
-	// is not supported by this implementation.
+	// Delegated addresses, e.g. f410 for the EVM address space
+	case '4':
+		protocol = ProtocolDelegated
 	default:
 		return nil, fmt.Errorf("unsupported protocol: %v", addr[1])
 
@@ -203,6 +220,9 @@ func AddressToBytes(addr string) ([]byte, error) {
 	if protocol == ProtocolId {
 		return ProtocolIdToBytes(rawAddress)
 	}
+	if protocol == ProtocolDelegated {
+		return delegatedToBytes(rawAddress)
+	}
 
 	payloadcksm, err := Encoding.WithPadding(-1).DecodeString(rawAddress)
 	if err != nil {
@@ -1,9 +1,11 @@
 package address_test
 
 import (
+	"encoding/hex"
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
+	xcaddress "github.com/cordialsys/crosschain/address"
 	"github.com/cordialsys/crosschain/chain/filecoin/address"
 	"github.com/stretchr/testify/require"
 )
@@ -77,9 +79,19 @@ func TestAddressToBytes(t *testing.T) {
 			expected: []byte{3, 173, 88, 223, 105, 110, 45, 78, 145, 234, 134, 200, 129, 233, 56, 186, 78, 168, 27, 57, 94, 18, 121, 123, 132, 185, 207, 49, 75, 149, 70, 112, 94, 131, 156, 122, 153, 214, 6, 178, 71, 221, 180, 249, 172, 122, 52, 20, 221},
 		},
 		{
-			name:    "DelegatedAddress",
-			address: "t410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq",
-			err:     "unsupported protocol",
+			name:     "DelegatedAddress",
+			address:  "t410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq",
+			expected: []byte{4, 10, 185, 157, 255, 115, 163, 94, 19, 144, 106, 93, 44, 253, 247, 160, 105, 61, 197, 216, 100, 144},
+		},
+		{
+			name:    "DelegatedAddressInvalidChecksum",
+			address: "t410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzja",
+			err:     "invalid checksum",
+		},
+		{
+			name:    "DelegatedAddressMissingNamespace",
+			address: "t4xgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq",
+			err:     "invalid delegated address",
 		},
 	}
 
@@ -96,3 +108,25 @@ func TestAddressToBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDelegatedAddressFromPublicKey(t *testing.T) {
+	builder, err := address.NewAddressBuilder(
+		xc.NewChainConfig("", xc.DriverFilecoin).WithNet("mainnet").Base(),
+		xcaddress.OptionFormat(address.FormatDelegated),
+	)
+	require.NoError(t, err)
+
+	// uncompressed public key of the private key 0x46...46
+	publicKey, _ := hex.DecodeString("044bc2a31265153f07e70e0bab08724e6b85e217f8cd628ceb62974247bb493382ce28cab79ad7119ee1ad3ebcdb98a16805211530ecc6cfefa1b88e6dff99232a")
+	addr, err := builder.GetAddressFromPublicKey(publicKey)
+	require.NoError(t, err)
+	ethAddress, err := address.ToEthAddress(string(addr))
+	require.NoError(t, err)
+	require.Equal(t, "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F", ethAddress.Hex())
+
+	_, err = address.NewAddressBuilder(
+		xc.NewChainConfig("", xc.DriverFilecoin).WithNet("mainnet").Base(),
+		xcaddress.OptionFormat("f9"),
+	)
+	require.ErrorContains(t, err, "unsupported format")
+}
@@ -0,0 +1,169 @@
+package address
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// The Ethereum Address Manager namespace, delegated addresses in it are f410 addresses
+	EthAddressManagerNamespace = 10
+
+	ethAddressLength = 20
+)
+
+// Ethereum addresses of ID addresses (f0) are "masked": 0xff, 11 zero bytes and the big-endian id
+var maskedIdPrefix = append([]byte{0xff}, make([]byte, 11)...)
+
+// NetworkPrefix returns the address prefix for a network: "f" for mainnet and "t" for testnet
+func NetworkPrefix(network string) (string, error) {
+	switch network {
+	case "mainnet":
+		return "f", nil
+	case "testnet":
+		return "t", nil
+	default:
+		return "", fmt.Errorf("invalid network: %s", network)
+	}
+}
+
+// PrefixFor returns the prefix that 0x addresses should be converted with: the prefix of the
+// first Filecoin address given, otherwise the prefix of the network.
+func PrefixFor(network string, addresses ...string) (string, error) {
+	for _, addr := range addresses {
+		if !IsEthAddress(addr) && (strings.HasPrefix(addr, "f") || strings.HasPrefix(addr, "t")) {
+			return addr[:1], nil
+		}
+	}
+	return NetworkPrefix(network)
+}
+
+// IsEthAddress returns true for 0x prefixed addresses
+func IsEthAddress(addr string) bool {
+	return strings.HasPrefix(addr, "0x") || strings.HasPrefix(addr, "0X")
+}
+
+// IsDelegated returns true for f4 (delegated) and 0x addresses, which send messages signed as
+// Ethereum transactions
+func IsDelegated(addr string) bool {
+	return IsEthAddress(addr) || (len(addr) > 2 && addr[1] == '4')
+}
+
+// NewDelegatedAddress returns the f410 address of an Ethereum address
+func NewDelegatedAddress(prefix string, ethAddress common.Address) string {
+	payload := append(toUvarint(EthAddressManagerNamespace), ethAddress.Bytes()...)
+	checksum, _ := hash(append([]byte{ProtocolDelegated}, payload...), checksumHashSize)
+	return fmt.Sprintf(
+		"%s%d%df%s",
+		prefix,
+		ProtocolDelegated,
+		EthAddressManagerNamespace,
+		Encoding.WithPadding(-1).EncodeToString(append(ethAddress.Bytes(), checksum...)),
+	)
+}
+
+// FromEthAddress converts an Ethereum address to the Filecoin address it refers to: an ID address
+// for masked ids, otherwise a f410 address.
+func FromEthAddress(prefix string, ethAddress common.Address) string {
+	ethBytes := ethAddress.Bytes()
+	if string(ethBytes[:len(maskedIdPrefix)]) == string(maskedIdPrefix) {
+		id := binary.BigEndian.Uint64(ethBytes[len(maskedIdPrefix):])
+		return fmt.Sprintf("%s%d%d", prefix, ProtocolId, id)
+	}
+	return NewDelegatedAddress(prefix, ethAddress)
+}
+
+// Normalize converts 0x addresses to f410 addresses, other addresses are returned as-is
+func Normalize(prefix string, addr string) (string, error) {
+	if !IsEthAddress(addr) {
+		return addr, nil
+	}
+	if !common.IsHexAddress(addr) {
+		return "", fmt.Errorf("invalid ethereum address: %s", addr)
+	}
+	return FromEthAddress(prefix, common.HexToAddress(addr)), nil
+}
+
+// ToEthAddress converts an address to the Ethereum address the FEVM uses for it. Only 0x, f410 and
+// ID addresses have an Ethereum address, other addresses must first be resolved to their ID.
+func ToEthAddress(addr string) (common.Address, error) {
+	if IsEthAddress(addr) {
+		if !common.IsHexAddress(addr) {
+			return common.Address{}, fmt.Errorf("invalid ethereum address: %s", addr)
+		}
+		return common.HexToAddress(addr), nil
+	}
+	bytes, err := AddressToBytes(addr)
+	if err != nil {
+		return common.Address{}, err
+	}
+	switch bytes[0] {
+	case ProtocolId:
+		id, _, err := fromUvarint(bytes[1:])
+		if err != nil {
+			return common.Address{}, err
+		}
+		ethBytes := binary.BigEndian.AppendUint64(append([]byte{}, maskedIdPrefix...), id)
+		return common.BytesToAddress(ethBytes), nil
+	case ProtocolDelegated:
+		namespace, n, err := fromUvarint(bytes[1:])
+		if err != nil {
+			return common.Address{}, err
+		}
+		subaddress := bytes[1+n:]
+		if namespace != EthAddressManagerNamespace || len(subaddress) != ethAddressLength {
+			return common.Address{}, fmt.Errorf("delegated address %s is not an ethereum address", addr)
+		}
+		return common.BytesToAddress(subaddress), nil
+	default:
+		return common.Address{}, fmt.Errorf("address %s has no ethereum address, resolve it to an ID address first", addr)
+	}
+}
+
+// GetDelegatedAddress returns the f410 address of a secp256k1 public key
+func (ab AddressBuilder) GetDelegatedAddress(publicKeyBytes []byte) (xc.Address, error) {
+	pk, err := btcec.ParsePubKey(publicKeyBytes)
+	if err != nil {
+		return xc.Address(""), fmt.Errorf("failed to parse public key: %w", err)
+	}
+	prefix, err := ab.getPrefix()
+	if err != nil {
+		return xc.Address(""), err
+	}
+	return xc.Address(NewDelegatedAddress(prefix, crypto.PubkeyToAddress(*pk.ToECDSA()))), nil
+}
+
+// delegatedToBytes parses the "<namespace>f<base32 subaddress and checksum>" part of a f4 address
+func delegatedToBytes(rawAddress string) ([]byte, error) {
+	namespaceStr, encoded, ok := strings.Cut(rawAddress, "f")
+	if !ok || namespaceStr == "" {
+		return nil, errors.New("invalid delegated address: missing namespace")
+	}
+	namespace, err := strconv.ParseUint(namespaceStr, 10, 63)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delegated address namespace: %w", err)
+	}
+	payloadcksm, err := Encoding.WithPadding(-1).DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode address: %w", err)
+	}
+	if len(payloadcksm) < ChecksumHashLength {
+		return nil, fmt.Errorf("invalid checksum length: %d. Expected: %d", len(payloadcksm), ChecksumHashLength)
+	}
+	subaddress := payloadcksm[:len(payloadcksm)-ChecksumHashLength]
+	checksum := payloadcksm[len(payloadcksm)-ChecksumHashLength:]
+
+	payload := append(toUvarint(namespace), subaddress...)
+	if !validateChecksum(append([]byte{ProtocolDelegated}, payload...), checksum) {
+		return nil, errors.New("invalid checksum")
+	}
+	return toBytes(ProtocolDelegated, payload)
+}
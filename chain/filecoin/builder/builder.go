@@ -32,8 +32,13 @@ func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInpu
 		return nil, fmt.Errorf("invalid input type")
 	}
 
+	message, err := tx.NewTransferMessage(txBuilder.Asset.Network, args, *txInput)
+	if err != nil {
+		return nil, err
+	}
 	return &tx.Tx{
-		Message: tx.NewMessage(args, *txInput),
+		Message: message,
+		ChainId: txInput.ChainId,
 	}, nil
 }
 
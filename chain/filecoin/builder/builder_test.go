@@ -1,9 +1,11 @@
 package builder_test
 
 import (
+	"encoding/hex"
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/builder/buildertest"
 	"github.com/cordialsys/crosschain/chain/filecoin/builder"
 	filecointx "github.com/cordialsys/crosschain/chain/filecoin/tx"
@@ -60,3 +62,120 @@ func TestNewTransferPreservesLargeAmount(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, amount.String(), filecoinTx.Message.Value.String())
 }
+
+func TestNewTokenTransfer(t *testing.T) {
+	builder1, err := builder.NewTxBuilder(xc.NewChainConfig(xc.FIL).WithNet("mainnet").Base())
+	require.NoError(t, err)
+	input := &TxInput{
+		Nonce:      7,
+		GasLimit:   100000,
+		GasFeeCap:  xc.NewAmountBlockchainFromStr("150000"),
+		GasPremium: xc.NewAmountBlockchainFromStr("250000"),
+		ChainId:    314,
+	}
+	chainCfg := xc.NewChainConfig(xc.FIL).Base()
+	from := xc.Address("0xb99DfF73a35e13906A5d2CFdF7A0693dC5d86490")
+	to := xc.Address("f01234")
+	contract := xc.ContractAddress("0x60E1773636CF5E4A227d9AC24F20fEca034ee25A")
+	amount := xc.NewAmountBlockchainFromUint64(1000)
+
+	args, err := xcbuilder.NewTransferArgs(chainCfg, from, to, amount, xcbuilder.OptionContractAddress(contract))
+	require.NoError(t, err)
+	tf, err := builder1.Transfer(args, input)
+	require.NoError(t, err)
+
+	filTx := tf.(*filecointx.Tx)
+	require.Equal(t, "f410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq", filTx.Message.From)
+	require.Equal(t, "f410fmdqxonrwz5peuit5tlbe6ih6zibu5ys223xctfi", filTx.Message.To)
+	require.EqualValues(t, filecointx.MethodInvokeEVM, filTx.Message.Method)
+	require.True(t, filTx.Message.Value.IsZero())
+	require.EqualValues(t, 314, filTx.ChainId)
+
+	calldata, err := filecointx.ParseInvokeEVMParams(filTx.Message.Params)
+	require.NoError(t, err)
+	// transfer(0xff000000000000000000000000000000000004d2, 1000)
+	require.Equal(t,
+		"a9059cbb"+
+			"000000000000000000000000ff000000000000000000000000000000000004d2"+
+			"00000000000000000000000000000000000000000000000000000000000003e8",
+		hex.EncodeToString(calldata),
+	)
+
+	// f1 addresses cannot call the FEVM
+	args, err = xcbuilder.NewTransferArgs(chainCfg, "f1urvqy4hx5idlki6b6f7ab6hzihjdfy47b5cc6dy", to, amount, xcbuilder.OptionContractAddress(contract))
+	require.NoError(t, err)
+	_, err = builder1.Transfer(args, input)
+	require.ErrorContains(t, err, "must be sent from a f4 or 0x address")
+
+	// f1 recipients have no ethereum address without an ID lookup
+	args, err = xcbuilder.NewTransferArgs(chainCfg, from, "f1urvqy4hx5idlki6b6f7ab6hzihjdfy47b5cc6dy", amount, xcbuilder.OptionContractAddress(contract))
+	require.NoError(t, err)
+	_, err = builder1.Transfer(args, input)
+	require.ErrorContains(t, err, "can only be sent to f0, f4 or 0x addresses")
+}
+
+func TestNewDelegatedTransfer(t *testing.T) {
+	builder1, err := builder.NewTxBuilder(xc.NewChainConfig(xc.FIL).WithNet("mainnet").Base())
+	require.NoError(t, err)
+	input := &TxInput{GasLimit: 100000, ChainId: 314}
+	chainCfg := xc.NewChainConfig(xc.FIL).Base()
+
+	tf, err := builder1.Transfer(buildertest.MustNewTransferArgs(
+		chainCfg,
+		"f410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq",
+		"0x60E1773636CF5E4A227d9AC24F20fEca034ee25A",
+		xc.NewAmountBlockchainFromUint64(5),
+	), input)
+	require.NoError(t, err)
+	filTx := tf.(*filecointx.Tx)
+	require.Equal(t, "f410fmdqxonrwz5peuit5tlbe6ih6zibu5ys223xctfi", filTx.Message.To)
+	require.EqualValues(t, filecointx.MethodInvokeEVM, filTx.Message.Method)
+	require.Empty(t, filTx.Message.Params)
+	require.Equal(t, "5", filTx.Message.Value.String())
+}
+
+func TestMultisig(t *testing.T) {
+	builder1, err := builder.NewTxBuilder(xc.NewChainConfig(xc.FIL).WithNet("mainnet").Base())
+	require.NoError(t, err)
+	input := &TxInput{
+		Nonce:      2,
+		GasLimit:   100000,
+		GasFeeCap:  xc.NewAmountBlockchainFromStr("150000"),
+		GasPremium: xc.NewAmountBlockchainFromStr("250000"),
+	}
+	signer := xc.Address("f1urvqy4hx5idlki6b6f7ab6hzihjdfy47b5cc6dy")
+	multisig := xc.Address("f2kbv57glniayy75fausbk7cc3xvrsb2bgvcqscwy")
+
+	tf, err := builder1.MultisigPropose(builder.MultisigProposeArgs{
+		From:     signer,
+		Multisig: multisig,
+		To:       "f01234",
+		Amount:   xc.NewAmountBlockchainFromUint64(1),
+	}, input)
+	require.NoError(t, err)
+	filTx := tf.(*filecointx.Tx)
+	require.Equal(t, string(signer), filTx.Message.From)
+	require.Equal(t, string(multisig), filTx.Message.To)
+	require.EqualValues(t, filecointx.MethodMultisigPropose, filTx.Message.Method)
+	require.True(t, filTx.Message.Value.IsZero())
+	require.Equal(t, "844300d2094200010040", hex.EncodeToString(filTx.Message.Params))
+	require.EqualValues(t, 2, filTx.Message.Nonce)
+
+	txn := builder.MultisigTxnArgs{From: signer, Multisig: multisig, TxnId: 5}
+	tf, err = builder1.MultisigApprove(builder.MultisigApproveArgs{MultisigTxnArgs: txn}, input)
+	require.NoError(t, err)
+	filTx = tf.(*filecointx.Tx)
+	require.EqualValues(t, filecointx.MethodMultisigApprove, filTx.Message.Method)
+	require.Equal(t, "820540", hex.EncodeToString(filTx.Message.Params))
+
+	tf, err = builder1.MultisigCancel(builder.MultisigCancelArgs{MultisigTxnArgs: txn}, input)
+	require.NoError(t, err)
+	filTx = tf.(*filecointx.Tx)
+	require.EqualValues(t, filecointx.MethodMultisigCancel, filTx.Message.Method)
+	require.Equal(t, "820540", hex.EncodeToString(filTx.Message.Params))
+
+	// f4 addresses can only invoke the EVM
+	txn.From = "f410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq"
+	_, err = builder1.MultisigApprove(builder.MultisigApproveArgs{MultisigTxnArgs: txn}, input)
+	require.ErrorContains(t, err, "must be sent from a f1 or f3 address")
+}
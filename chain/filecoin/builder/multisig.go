@@ -0,0 +1,136 @@
+package builder
+
+import (
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/filecoin/address"
+	"github.com/cordialsys/crosschain/chain/filecoin/tx"
+)
+
+// MultisigArgs are the arguments of a message to the built-in multisig actor, sent by one of its signers
+type MultisigArgs interface {
+	GetFrom() xc.Address
+	Message(input TxInput) (tx.Message, error)
+}
+
+// MultisigProposeArgs proposes a message from the multisig, which is sent once enough signers approve it.
+// The proposer's approval is implied.
+type MultisigProposeArgs struct {
+	From     xc.Address
+	Multisig xc.Address
+	To       xc.Address
+	Amount   xc.AmountBlockchain
+	// Optional method and parameters of the proposed message, by default it is a plain send
+	Method uint64
+	Params []byte
+}
+
+// MultisigTxnArgs identify a pending multisig transaction
+type MultisigTxnArgs struct {
+	From     xc.Address
+	Multisig xc.Address
+	TxnId    int64
+	// Optional, when set the actor checks that the pending transaction matches, see tx.ProposalHash
+	ProposalHash []byte
+}
+
+// MultisigApproveArgs approves a pending multisig transaction, which is sent once enough signers approve it
+type MultisigApproveArgs struct {
+	MultisigTxnArgs
+}
+
+// MultisigCancelArgs cancels a pending multisig transaction, only its proposer may cancel it
+type MultisigCancelArgs struct {
+	MultisigTxnArgs
+}
+
+var _ MultisigArgs = MultisigProposeArgs{}
+var _ MultisigArgs = MultisigApproveArgs{}
+var _ MultisigArgs = MultisigCancelArgs{}
+
+func (args MultisigProposeArgs) GetFrom() xc.Address {
+	return args.From
+}
+
+func (args MultisigProposeArgs) Message(input TxInput) (tx.Message, error) {
+	prefix, err := address.PrefixFor("", string(args.Multisig), string(args.From))
+	if err != nil {
+		return tx.Message{}, err
+	}
+	to, err := address.Normalize(prefix, string(args.To))
+	if err != nil {
+		return tx.Message{}, err
+	}
+	params, err := tx.NewProposeParams(tx.Proposal{
+		To:     to,
+		Value:  args.Amount,
+		Method: args.Method,
+		Params: args.Params,
+	})
+	if err != nil {
+		return tx.Message{}, err
+	}
+	return newMultisigMessage(args.From, args.Multisig, tx.MethodMultisigPropose, params, input)
+}
+
+func (args MultisigTxnArgs) GetFrom() xc.Address {
+	return args.From
+}
+
+func (args MultisigTxnArgs) message(method uint64, input TxInput) (tx.Message, error) {
+	params, err := tx.NewTxnIdParams(args.TxnId, args.ProposalHash)
+	if err != nil {
+		return tx.Message{}, err
+	}
+	return newMultisigMessage(args.From, args.Multisig, method, params, input)
+}
+
+func (args MultisigApproveArgs) Message(input TxInput) (tx.Message, error) {
+	return args.message(tx.MethodMultisigApprove, input)
+}
+
+func (args MultisigCancelArgs) Message(input TxInput) (tx.Message, error) {
+	return args.message(tx.MethodMultisigCancel, input)
+}
+
+// MultisigPropose builds a multisig `Propose` message
+func (txBuilder TxBuilder) MultisigPropose(args MultisigProposeArgs, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.multisigTx(args, input)
+}
+
+// MultisigApprove builds a multisig `Approve` message
+func (txBuilder TxBuilder) MultisigApprove(args MultisigApproveArgs, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.multisigTx(args, input)
+}
+
+// MultisigCancel builds a multisig `Cancel` message
+func (txBuilder TxBuilder) MultisigCancel(args MultisigCancelArgs, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.multisigTx(args, input)
+}
+
+func (txBuilder TxBuilder) multisigTx(args MultisigArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*TxInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type")
+	}
+	message, err := args.Message(*txInput)
+	if err != nil {
+		return nil, err
+	}
+	return &tx.Tx{
+		Message: message,
+		ChainId: txInput.ChainId,
+	}, nil
+}
+
+func newMultisigMessage(from xc.Address, multisig xc.Address, method uint64, params []byte, input TxInput) (tx.Message, error) {
+	// f4 addresses sign Ethereum transactions, which can only invoke the EVM
+	if address.IsDelegated(string(from)) {
+		return tx.Message{}, fmt.Errorf("multisig messages must be sent from a f1 or f3 address, not %s", from)
+	}
+	if _, err := address.AddressToBytes(string(multisig)); err != nil {
+		return tx.Message{}, fmt.Errorf("invalid multisig address: %w", err)
+	}
+	return tx.NewMessage(string(from), string(multisig), xc.NewAmountBlockchainFromUint64(0), method, params, input), nil
+}
@@ -12,7 +12,9 @@ import (
 
 	xc "github.com/cordialsys/crosschain"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/filecoin/address"
 	"github.com/cordialsys/crosschain/chain/filecoin/client/types"
+	"github.com/cordialsys/crosschain/chain/filecoin/tx"
 	"github.com/cordialsys/crosschain/chain/filecoin/tx_input"
 	xclient "github.com/cordialsys/crosschain/client"
 	txinfo "github.com/cordialsys/crosschain/client/tx_info"
@@ -70,6 +72,19 @@ func NewClient(cfgI *xc.ChainConfig) (*Client, error) {
 // - MinerFee: GasLimit * GasPremium, where GasPremium is capped by MaxGasFeeCap
 // Because of this, we can limit max fee spent by setting cap on `GasLimit` and `MaxGasFeeCap`
 func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+	message, err := tx.NewTransferMessage(client.Asset.GetChain().Network, args, tx_input.TxInput{})
+	if err != nil {
+		return nil, err
+	}
+	// `to` is required for `Filecoin.EstimateGasFees` method
+	if len(message.To) == 0 {
+		message.To = message.From
+	}
+	return client.fetchMessageInput(message)
+}
+
+// fetchMessageInput estimates the gas of a message, see FetchTransferInput
+func (client *Client) fetchMessageInput(message tx.Message) (*tx_input.TxInput, error) {
 	chainHeadParams := types.NewEmptyParams(types.MethodChainHead)
 	chainHeadResponse := types.NewChainHeadResponse()
 	err := Post(client, chainHeadParams, chainHeadResponse)
@@ -78,14 +93,8 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	}
 	tipset := chainHeadResponse.Result.TipsetKey
 
-	from := string(args.GetFrom())
-	// `to` is required for `Filecoin.EstimateGasFees` method
-	to := string(args.GetTo())
-	if len(to) == 0 {
-		to = from
-	}
 	mpoolGetNonceParams := types.NewParams(types.MethodMpoolGetNonce, types.MpoolGetNonce{
-		Address: from,
+		Address: message.From,
 	})
 	mpoolGetNonceResponse := types.NewMpoolGetNonceResponse()
 	err = Post(client, mpoolGetNonceParams, mpoolGetNonceResponse)
@@ -94,14 +103,16 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	}
 	nonce := uint64(*mpoolGetNonceResponse.Result)
 
-	message := types.Message{
+	estimateMessage := types.Message{
 		Version:    42,
-		To:         to,
-		From:       from,
-		Value:      args.GetAmount().String(),
+		To:         message.To,
+		From:       message.From,
+		Value:      message.Value.String(),
 		Nonce:      nonce,
 		GasFeeCap:  "0",
 		GasPremium: "0",
+		Method:     int(message.Method),
+		Params:     message.Params,
 	}
 
 	// Create a new gas estimation request for a message using the Filecoin API.
@@ -113,7 +124,7 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	maxFee := client.Asset.GetChain().ChainMaxGasPrice
 	hrMaxFee := xc.NewAmountHumanReadableFromFloat(maxFee)
 	gasEstimateMessageGas := types.NewParams(types.MethodGasEstimateMessageGas, types.GasEstimateMessageGas{
-		Message:   message,
+		Message:   estimateMessage,
 		MaxFee:    types.MaxFee{MaxFee: hrMaxFee.String()},
 		TipsetKey: tipset,
 	})
@@ -127,7 +138,7 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	gasFeeCap := xc.NewAmountBlockchainFromStr(msgWithFees.GasFeeCap)
 	gasPremium := xc.NewAmountBlockchainFromStr(msgWithFees.GasPremium)
 
-	return &tx_input.TxInput{
+	input := &tx_input.TxInput{
 		Nonce:      nonce,
 		GasLimit:   msgWithFees.GasLimit,
 		GasFeeCap:  gasFeeCap,
@@ -137,7 +148,16 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		XGasLimit:   msgWithFees.GasLimit,
 		XGasFeeCap:  gasFeeCap,
 		XGasPremium: gasPremium,
-	}, nil
+	}
+
+	// Messages from f4 addresses are signed as Ethereum transactions, which commit to the chain id
+	if address.IsDelegated(message.From) {
+		input.ChainId, err = client.fetchEthChainId()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return input, nil
 }
 
 // Deprecated method - use FetchTransferInput
@@ -272,11 +292,24 @@ func (client *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinf
 	txInfo := txinfo.NewTxInfo(block, chainCfg, sHash, confirmations, errorMsg)
 
 	sourceAddress := xc.Address(msg.From)
-	movement := txinfo.NewMovement(chain, "")
 	amount := xc.NewAmountBlockchainFromStr(msg.Value)
-	movement.AddSource(xc.Address(msg.From), amount, nil)
-	movement.AddDestination(sourceAddress, amount, nil)
-	txInfo.AddMovement(movement)
+	// Messages that invoke actors, e.g. FEVM token transfers, typically do not move FIL
+	if msg.Method == tx.MethodSend || !amount.IsZero() {
+		movement := txinfo.NewMovement(chain, "")
+		movement.AddSource(sourceAddress, amount, nil)
+		movement.AddDestination(xc.Address(msg.To), amount, nil)
+		txInfo.AddMovement(movement)
+	}
+
+	if msg.Method == tx.MethodInvokeEVM && msgState.Receipt.ExitCode == 0 {
+		movements, err := client.fetchTokenMovements(messageCid)
+		if err != nil {
+			return txinfo.TxInfo{}, err
+		}
+		for _, movement := range movements {
+			txInfo.AddMovement(movement)
+		}
+	}
 
 	gasLimit, ok := xc.NewAmountBlockchainFromInt64(int64(msg.GasLimit))
 	if !ok {
@@ -298,12 +331,20 @@ func (client *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinf
 }
 
 func (client *Client) FetchBalance(ctx context.Context, args *xclient.BalanceArgs) (xc.AmountBlockchain, error) {
+	if contract, ok := args.Contract(); ok && contract != "" && contract != xc.ContractAddress(client.Asset.GetChain().Chain) {
+		return client.fetchTokenBalance(args.Address(), contract)
+	}
+
+	addr, err := client.normalize(args.Address())
+	if err != nil {
+		return xc.AmountBlockchain{}, err
+	}
 	params := types.NewParams(types.MethodWalletBallance, types.WalletBalance{
-		Address: string(args.Address()),
+		Address: addr,
 	})
 
 	response := types.NewResponse[string]()
-	err := Post(client, params, response)
+	err = Post(client, params, response)
 	if err != nil {
 		return xc.AmountBlockchain{}, fmt.Errorf("failed to fetch balance: %w", err)
 	}
@@ -313,7 +354,10 @@ func (client *Client) FetchBalance(ctx context.Context, args *xclient.BalanceArg
 }
 
 func (client *Client) FetchDecimals(ctx context.Context, contract xc.ContractAddress) (int, error) {
-	return int(client.Asset.GetChain().GetDecimals()), nil
+	if contract == "" || contract == xc.ContractAddress(client.Asset.GetChain().Chain) {
+		return int(client.Asset.GetChain().GetDecimals()), nil
+	}
+	return client.fetchTokenDecimals(contract)
 }
 
 // Filecoin relies on the `TipSet` as a chain head. Once tipset can include multiple blocks.
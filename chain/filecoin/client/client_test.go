@@ -10,11 +10,13 @@ import (
 	"time"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/filecoin/address"
 	client "github.com/cordialsys/crosschain/chain/filecoin/client"
 	"github.com/cordialsys/crosschain/chain/filecoin/client/types"
 	"github.com/cordialsys/crosschain/chain/filecoin/tx"
 	"github.com/cordialsys/crosschain/chain/filecoin/tx_input"
+	xclient "github.com/cordialsys/crosschain/client"
 	txinfo "github.com/cordialsys/crosschain/client/tx_info"
 	xctypes "github.com/cordialsys/crosschain/client/types"
 	"github.com/stretchr/testify/require"
@@ -383,8 +385,8 @@ func TestFetchTxInfo(t *testing.T) {
 						},
 						[]*txinfo.BalanceChange{{
 							Balance:   xc.NewAmountBlockchainFromUint64(100000000000000000),
-							XAddress:  "chains/FIL/addresses/f13uhmulxtag3qfohj7h2nmtco7e7u3t3nxjdzi7q",
-							AddressId: "f13uhmulxtag3qfohj7h2nmtco7e7u3t3nxjdzi7q",
+							XAddress:  "chains/FIL/addresses/f1urvqy4hx5idlki6b6f7ab6hzihjdfy47b5cc6dy",
+							AddressId: "f1urvqy4hx5idlki6b6f7ab6hzihjdfy47b5cc6dy",
 						}},
 						nil,
 					),
@@ -481,8 +483,8 @@ func TestFetchTxInfo(t *testing.T) {
 						},
 						[]*txinfo.BalanceChange{{
 							Balance:   xc.NewAmountBlockchainFromUint64(100000000000000000),
-							XAddress:  "chains/FIL/addresses/f13uhmulxtag3qfohj7h2nmtco7e7u3t3nxjdzi7q",
-							AddressId: "f13uhmulxtag3qfohj7h2nmtco7e7u3t3nxjdzi7q",
+							XAddress:  "chains/FIL/addresses/f1urvqy4hx5idlki6b6f7ab6hzihjdfy47b5cc6dy",
+							AddressId: "f1urvqy4hx5idlki6b6f7ab6hzihjdfy47b5cc6dy",
 						}},
 						nil,
 					),
@@ -575,3 +577,159 @@ func NewMovement(
 	movement.Event = event
 	return movement
 }
+
+// newRpcServer responds to each json-rpc method with the given result
+func newRpcServer(t *testing.T, results map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqBuff, _ := io.ReadAll(r.Body)
+		rawReq := string(reqBuff)
+		for method, result := range results {
+			if strings.Contains(rawReq, `"`+method+`"`) {
+				_, err := w.Write([]byte(result))
+				require.NoError(t, err)
+				return
+			}
+		}
+		require.Fail(t, "unexpected request", rawReq)
+	}))
+}
+
+func TestFetchTokenBalance(t *testing.T) {
+	server := newRpcServer(t, map[string]string{
+		types.MethodStateLookupID: `{"result":"f01234"}`,
+		types.MethodEthCall:       `{"result":"0x00000000000000000000000000000000000000000000000000000000000003e8"}`,
+	})
+	defer server.Close()
+	client, _ := client.NewClient(xc.NewChainConfig(xc.FIL, xc.DriverFilecoin).WithUrl(server.URL).WithNet("mainnet"))
+
+	for _, owner := range []xc.Address{
+		"f1urvqy4hx5idlki6b6f7ab6hzihjdfy47b5cc6dy",
+		"f410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq",
+		"0xb99DfF73a35e13906A5d2CFdF7A0693dC5d86490",
+	} {
+		balance, err := client.FetchBalance(context.Background(), xclient.NewBalanceArgs(
+			owner, xclient.BalanceOptionContract("0x60E1773636CF5E4A227d9AC24F20fEca034ee25A"),
+		))
+		require.NoError(t, err)
+		require.Equal(t, "1000", balance.String())
+	}
+}
+
+func TestFetchTokenDecimals(t *testing.T) {
+	server := newRpcServer(t, map[string]string{
+		types.MethodEthCall: `{"result":"0x0000000000000000000000000000000000000000000000000000000000000006"}`,
+	})
+	defer server.Close()
+	client, _ := client.NewClient(xc.NewChainConfig(xc.FIL, xc.DriverFilecoin).WithUrl(server.URL).WithNet("mainnet").WithDecimals(18))
+
+	decimals, err := client.FetchDecimals(context.Background(), "f410fmdqxonrwz5peuit5tlbe6ih6zibu5ys223xctfi")
+	require.NoError(t, err)
+	require.Equal(t, 6, decimals)
+
+	decimals, err = client.FetchDecimals(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, 18, decimals)
+}
+
+func TestFetchDelegatedTransferInput(t *testing.T) {
+	server := newRpcServer(t, map[string]string{
+		types.MethodChainHead:             `{"result":{"Cids":[{"/":"bafy2bzacea7kciha7midmmnermmx5dtaj3gjo2lghjkozd5t6diclbwngvnka"}],"Height":2441564}}`,
+		types.MethodMpoolGetNonce:         `{"result": 4}`,
+		types.MethodGasEstimateMessageGas: `{"result":{"GasFeeCap":"100542","GasLimit":2518203,"GasPremium":"99488"}}`,
+		types.MethodEthChainId:            `{"result":"0x13a"}`,
+	})
+	defer server.Close()
+	client, _ := client.NewClient(xc.NewChainConfig(xc.FIL, xc.DriverFilecoin).WithUrl(server.URL).WithNet("mainnet"))
+
+	args, err := xcbuilder.NewTransferArgs(
+		xc.NewChainConfig(xc.FIL).Base(),
+		"0xb99DfF73a35e13906A5d2CFdF7A0693dC5d86490",
+		"f01234",
+		xc.NewAmountBlockchainFromUint64(1000),
+		xcbuilder.OptionContractAddress("0x60E1773636CF5E4A227d9AC24F20fEca034ee25A"),
+	)
+	require.NoError(t, err)
+	input, err := client.FetchTransferInput(context.Background(), args)
+	require.NoError(t, err)
+	filInput := input.(*tx_input.TxInput)
+	require.EqualValues(t, 4, filInput.Nonce)
+	require.EqualValues(t, 2518203, filInput.GasLimit)
+	require.EqualValues(t, 314, filInput.ChainId)
+}
+
+func TestFetchPendingProposals(t *testing.T) {
+	server := newRpcServer(t, map[string]string{
+		types.MethodMsigGetPending: `{"result":[{
+			"ID":5,
+			"To":"f01234",
+			"Value":"1000000000000000000",
+			"Method":0,
+			"Params":null,
+			"Approved":["f0100"]
+		}]}`,
+	})
+	defer server.Close()
+	client, _ := client.NewClient(xc.NewChainConfig(xc.FIL, xc.DriverFilecoin).WithUrl(server.URL).WithNet("mainnet"))
+
+	pending, err := client.FetchPendingProposals(context.Background(), "f2kbv57glniayy75fausbk7cc3xvrsb2bgvcqscwy")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.EqualValues(t, 5, pending[0].ID)
+	require.Equal(t, "f01234", pending[0].To)
+	require.Equal(t, []string{"f0100"}, pending[0].Approved)
+}
+
+func TestFetchTokenTxInfo(t *testing.T) {
+	server := newRpcServer(t, map[string]string{
+		types.MethodChainGetMessage: `{"result":{
+		  "From":"f410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq",
+		  "GasFeeCap":"101183",
+		  "GasLimit":1000,
+		  "GasPremium":"100",
+		  "Method":3844450837,
+		  "Nonce":16,
+		  "Params":"RKkFnLs=",
+		  "To":"f410fmdqxonrwz5peuit5tlbe6ih6zibu5ys223xctfi",
+		  "Value":"0",
+		  "Version":0
+		}}`,
+		types.MethodStateSearchMsg: `{"result":{
+		  "Height":2440037,
+		  "Message":{"/":"bafy2bzacedza344ak7eol4uydlwddlj6igiseftbaomafc3iscsmzoslo65vc"},
+		  "Receipt":{"ExitCode":0,"GasUsed":500,"Return":null},
+		  "TipSet":[{"/":"bafy2bzacectazjjqvph7rf552wthajzstqy7ylugrstmrloq2m7a6gpri3py6"}]
+		}}`,
+		types.MethodChainGetBlock:              `{"result":{"ParentBaseFee":"100","Timestamp":1740527490,"Height":2440037}}`,
+		types.MethodChainHead:                  `{"result":{"Cids":[],"Height":2440047}}`,
+		types.MethodEthGetTransactionHashByCid: `{"result":"0x7fba5ad368aab2490731a31d490e22d905c9b47ac2ca03e41b2021bfb76b423b"}`,
+		types.MethodEthGetTransactionReceipt: `{"result":{
+		  "transactionHash":"0x7fba5ad368aab2490731a31d490e22d905c9b47ac2ca03e41b2021bfb76b423b",
+		  "status":"0x1",
+		  "logs":[{
+		    "address":"0x60e1773636cf5e4a227d9ac24f20feca034ee25a",
+		    "data":"0x00000000000000000000000000000000000000000000000000000000000003e8",
+		    "topics":[
+		      "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+		      "0x000000000000000000000000b99dff73a35e13906a5d2cfdf7a0693dc5d86490",
+		      "0x000000000000000000000000ff000000000000000000000000000000000004d2"
+		    ],
+		    "logIndex":"0x2"
+		  }]
+		}}`,
+	})
+	defer server.Close()
+	client, _ := client.NewClient(xc.NewChainConfig(xc.FIL, xc.DriverFilecoin).WithUrl(server.URL).WithNet("mainnet").WithDecimals(18))
+
+	txInfo, err := client.FetchTxInfo(context.Background(), txinfo.NewArgs("bafy2bzacedza344ak7eol4uydlwddlj6igiseftbaomafc3iscsmzoslo65vc"))
+	require.NoError(t, err)
+	require.Equal(t, txinfo.Succeeded, txInfo.State)
+
+	// the zero-value native movement is skipped, leaving the token transfer and the fee
+	require.Len(t, txInfo.Movements, 2)
+	token := txInfo.Movements[0]
+	require.Equal(t, xc.ContractAddress("f410fmdqxonrwz5peuit5tlbe6ih6zibu5ys223xctfi"), token.XContract)
+	require.Equal(t, xc.Address("f410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq"), token.From[0].AddressId)
+	require.Equal(t, xc.Address("f01234"), token.To[0].AddressId)
+	require.Equal(t, "1000", token.To[0].Balance.String())
+	require.Equal(t, txinfo.NewEventFromIndex(2, txinfo.MovementVariantToken), token.Event)
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	evmtx "github.com/cordialsys/crosschain/chain/evm/tx"
+	"github.com/cordialsys/crosschain/chain/filecoin/address"
+	"github.com/cordialsys/crosschain/chain/filecoin/client/types"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// ERC-20 selectors of `balanceOf(address)` and `decimals()`
+var (
+	balanceOfSelector = common.FromHex("0x70a08231")
+	decimalsSelector  = common.FromHex("0x313ce567")
+)
+
+// prefix returns the address prefix of the client's network
+func (client *Client) prefix() (string, error) {
+	return address.NetworkPrefix(client.Asset.GetChain().Network)
+}
+
+// normalize converts 0x addresses to the Filecoin address they refer to
+func (client *Client) normalize(addr xc.Address) (string, error) {
+	if !address.IsEthAddress(string(addr)) {
+		return string(addr), nil
+	}
+	prefix, err := client.prefix()
+	if err != nil {
+		return "", err
+	}
+	return address.Normalize(prefix, string(addr))
+}
+
+// ethAddress returns the Ethereum address the FEVM uses for an address. f1, f2 and f3 addresses
+// are resolved to their ID address first.
+func (client *Client) ethAddress(addr xc.Address) (common.Address, error) {
+	ethAddress, err := address.ToEthAddress(string(addr))
+	if err == nil {
+		return ethAddress, nil
+	}
+	params := types.NewParams(types.MethodStateLookupID, types.StateLookupID{
+		Address: string(addr),
+	})
+	response := types.NewStateLookupIDResponse()
+	if err := Post(client, params, response); err != nil {
+		return common.Address{}, fmt.Errorf("failed to lookup id of %s: %w", addr, err)
+	}
+	return address.ToEthAddress(string(*response.Result))
+}
+
+func (client *Client) ethCall(contract xc.ContractAddress, calldata []byte) ([]byte, error) {
+	to, err := address.ToEthAddress(string(contract))
+	if err != nil {
+		return nil, fmt.Errorf("invalid token contract: %w", err)
+	}
+	params := types.NewParams(types.MethodEthCall, types.EthCall{
+		To:   strings.ToLower(to.Hex()),
+		Data: "0x" + hex.EncodeToString(calldata),
+	})
+	response := types.NewEthCallResponse()
+	if err := Post(client, params, response); err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", contract, err)
+	}
+	return common.FromHex(string(*response.Result)), nil
+}
+
+func (client *Client) fetchTokenBalance(owner xc.Address, contract xc.ContractAddress) (xc.AmountBlockchain, error) {
+	ownerEth, err := client.ethAddress(owner)
+	if err != nil {
+		return xc.AmountBlockchain{}, err
+	}
+	result, err := client.ethCall(contract, append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(ownerEth.Bytes(), 32)...))
+	if err != nil {
+		return xc.AmountBlockchain{}, fmt.Errorf("failed to fetch token balance: %w", err)
+	}
+	return xc.AmountBlockchain(*new(big.Int).SetBytes(result)), nil
+}
+
+func (client *Client) fetchTokenDecimals(contract xc.ContractAddress) (int, error) {
+	result, err := client.ethCall(contract, decimalsSelector)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch token decimals: %w", err)
+	}
+	decimals := new(big.Int).SetBytes(result)
+	if len(result) == 0 || !decimals.IsInt64() || decimals.Int64() > 255 {
+		return 0, fmt.Errorf("invalid decimals returned by %s: 0x%x", contract, result)
+	}
+	return int(decimals.Int64()), nil
+}
+
+func (client *Client) fetchEthChainId() (uint64, error) {
+	params := types.NewEmptyParams(types.MethodEthChainId)
+	response := types.NewEthChainIdResponse()
+	if err := Post(client, params, response); err != nil {
+		return 0, fmt.Errorf("failed to fetch chain id: %w", err)
+	}
+	chainId, err := strconv.ParseUint(strings.TrimPrefix(string(*response.Result), "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid chain id %s: %w", *response.Result, err)
+	}
+	return chainId, nil
+}
+
+// fetchTokenMovements decodes the ERC-20 transfers logged by an InvokeEVM message
+func (client *Client) fetchTokenMovements(messageCid types.Cid) ([]*txinfo.Movement, error) {
+	hashParams := types.NewParams(types.MethodEthGetTransactionHashByCid, types.EthGetTransactionHashByCid{
+		Cid: messageCid,
+	})
+	hashResponse := types.NewEthGetTransactionHashByCidResponse()
+	if err := Post(client, hashParams, hashResponse); err != nil {
+		return nil, fmt.Errorf("failed to get ethereum hash: %w", err)
+	}
+	if hashResponse.Result == nil {
+		return nil, nil
+	}
+
+	receiptParams := types.NewParams(types.MethodEthGetTransactionReceipt, types.EthGetTransactionReceipt(*hashResponse.Result))
+	receiptResponse := types.NewEthGetTransactionReceiptResponse()
+	if err := Post(client, receiptParams, receiptResponse); err != nil {
+		return nil, fmt.Errorf("failed to get ethereum receipt: %w", err)
+	}
+	if receiptResponse.Result == nil {
+		return nil, errors.New("ethereum receipt not found")
+	}
+
+	receipt, err := toEthReceipt(receiptResponse.Result)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := client.prefix()
+	if err != nil {
+		return nil, err
+	}
+
+	chain := client.Asset.GetChain().Chain
+	logs := evmtx.ParseTokenLogs(receipt, chain)
+	movements := []*txinfo.Movement{}
+	for i, source := range logs.Sources {
+		destination := logs.Destinations[i]
+		contract := address.FromEthAddress(prefix, common.HexToAddress(string(source.ContractAddress)))
+		movement := txinfo.NewMovement(chain, xc.ContractAddress(contract))
+		from := address.FromEthAddress(prefix, common.HexToAddress(string(source.Address)))
+		to := address.FromEthAddress(prefix, common.HexToAddress(string(destination.Address)))
+		movement.AddSource(xc.Address(from), source.Amount, nil)
+		movement.AddDestination(xc.Address(to), destination.Amount, nil)
+		movement.AddEventMeta(source.Event)
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
+
+func toEthReceipt(response *types.EthGetTransactionReceiptResponse) (*ethtypes.Receipt, error) {
+	receipt := &ethtypes.Receipt{}
+	for _, log := range response.Logs {
+		index, err := strconv.ParseUint(strings.TrimPrefix(log.LogIndex, "0x"), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log index %s: %w", log.LogIndex, err)
+		}
+		topics := make([]common.Hash, len(log.Topics))
+		for i, topic := range log.Topics {
+			topics[i] = common.HexToHash(topic)
+		}
+		receipt.Logs = append(receipt.Logs, &ethtypes.Log{
+			Address: common.HexToAddress(log.Address),
+			Topics:  topics,
+			Data:    common.FromHex(log.Data),
+			Index:   uint(index),
+		})
+	}
+	return receipt, nil
+}
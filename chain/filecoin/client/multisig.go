@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/filecoin/builder"
+	"github.com/cordialsys/crosschain/chain/filecoin/client/types"
+	"github.com/cordialsys/crosschain/chain/filecoin/tx_input"
+)
+
+// FetchMultisigInput returns the input for a message to a multisig actor, see builder.MultisigArgs
+func (client *Client) FetchMultisigInput(ctx context.Context, args builder.MultisigArgs) (xc.TxInput, error) {
+	message, err := args.Message(tx_input.TxInput{})
+	if err != nil {
+		return nil, err
+	}
+	return client.fetchMessageInput(message)
+}
+
+// FetchPendingProposals lists the transactions proposed to a multisig that are waiting for approvals
+func (client *Client) FetchPendingProposals(ctx context.Context, multisig xc.Address) ([]types.MsigTransaction, error) {
+	addr, err := client.normalize(multisig)
+	if err != nil {
+		return nil, err
+	}
+	params := types.NewParams(types.MethodMsigGetPending, types.MsigGetPending{
+		Address: addr,
+	})
+	response := types.NewMsigGetPendingResponse()
+	if err := Post(client, params, response); err != nil {
+		return nil, fmt.Errorf("failed to fetch pending multisig transactions: %w", err)
+	}
+	if response.Result == nil {
+		return []types.MsigTransaction{}, nil
+	}
+	return *response.Result, nil
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"encoding/json"
+)
+
+const (
+	MethodEthCall                    = "Filecoin.EthCall"
+	MethodEthChainId                 = "Filecoin.EthChainId"
+	MethodEthGetTransactionHashByCid = "Filecoin.EthGetTransactionHashByCid"
+	MethodEthGetTransactionReceipt   = "Filecoin.EthGetTransactionReceipt"
+	MethodStateLookupID              = "Filecoin.StateLookupID"
+)
+
+// EthCall executes a read-only FEVM call at the latest block
+type EthCall struct {
+	To   string `json:"to"`
+	Data string `json:"data"`
+}
+
+func (call *EthCall) MarshalJSON() ([]byte, error) {
+	type ethCall EthCall
+	return json.Marshal([]any{ethCall(*call), "latest"})
+}
+
+// Hex encoded return data of the call
+type EthCallResponse string
+
+func NewEthCallResponse() *Response[EthCallResponse] {
+	return NewResponse[EthCallResponse]()
+}
+
+// Hex encoded chain id
+type EthChainIdResponse string
+
+func NewEthChainIdResponse() *Response[EthChainIdResponse] {
+	return NewResponse[EthChainIdResponse]()
+}
+
+type EthGetTransactionHashByCid struct {
+	Cid Cid
+}
+
+func (params *EthGetTransactionHashByCid) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]Cid{params.Cid})
+}
+
+// The Ethereum hash of a message, or null if the message has none
+type EthGetTransactionHashByCidResponse string
+
+func NewEthGetTransactionHashByCidResponse() *Response[EthGetTransactionHashByCidResponse] {
+	return NewResponse[EthGetTransactionHashByCidResponse]()
+}
+
+type EthGetTransactionReceipt string
+
+func (hash *EthGetTransactionReceipt) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string{string(*hash)})
+}
+
+type EthLog struct {
+	Address  string   `json:"address"`
+	Data     string   `json:"data"`
+	Topics   []string `json:"topics"`
+	LogIndex string   `json:"logIndex"`
+}
+
+type EthGetTransactionReceiptResponse struct {
+	TransactionHash string   `json:"transactionHash"`
+	Status          string   `json:"status"`
+	Logs            []EthLog `json:"logs"`
+}
+
+func NewEthGetTransactionReceiptResponse() *Response[EthGetTransactionReceiptResponse] {
+	return NewResponse[EthGetTransactionReceiptResponse]()
+}
+
+type StateLookupID struct {
+	Address string
+}
+
+func (params *StateLookupID) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{params.Address, TipsetKey{}})
+}
+
+// The ID address of the actor
+type StateLookupIDResponse string
+
+func NewStateLookupIDResponse() *Response[StateLookupIDResponse] {
+	return NewResponse[StateLookupIDResponse]()
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"encoding/json"
+)
+
+const (
+	MethodMsigGetPending = "Filecoin.MsigGetPending"
+)
+
+type MsigGetPending struct {
+	Address string
+}
+
+func (params *MsigGetPending) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{params.Address, TipsetKey{}})
+}
+
+// MsigTransaction is a pending multisig proposal
+type MsigTransaction struct {
+	ID     int64  `json:"ID"`
+	To     string `json:"To"`
+	Value  string `json:"Value"`
+	Method uint64 `json:"Method"`
+	Params []byte `json:"Params"`
+	// ID addresses of the signers that approved the proposal, the first one is the proposer
+	Approved []string `json:"Approved"`
+}
+
+type MsigGetPendingResponse []MsigTransaction
+
+func NewMsigGetPendingResponse() *Response[MsigGetPendingResponse] {
+	return NewResponse[MsigGetPendingResponse]()
+}
@@ -212,6 +212,13 @@ func NewMpoolGetNonceResponse() *Response[MpoolGetNonceResponse] {
 	return NewResponse[MpoolGetNonceResponse]()
 }
 
+// Signature types, messages from f4 addresses are signed as Ethereum transactions
+const (
+	SignatureTypeSecp256k1 = 1
+	SignatureTypeBls       = 2
+	SignatureTypeDelegated = 3
+)
+
 type Signature struct {
 	Type byte   `json:"Type"`
 	Data []byte `json:"Data"`
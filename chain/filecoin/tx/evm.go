@@ -0,0 +1,39 @@
+package tx
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/fxamacker/cbor"
+)
+
+const (
+	// Plain transfer of FIL
+	MethodSend = 0
+	// FRC-42 method number of the EVM actor's InvokeContract, also accepted by accounts
+	MethodInvokeEVM = 3844450837
+)
+
+// NewInvokeEVMParams encodes EVM calldata as the parameters of an InvokeEVM message: a CBOR byte string
+func NewInvokeEVMParams(calldata []byte) ([]byte, error) {
+	if len(calldata) == 0 {
+		return []byte{}, nil
+	}
+	params, err := cbor.Marshal(calldata, cbor.CanonicalEncOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode calldata: %w", err)
+	}
+	return params, nil
+}
+
+// ParseInvokeEVMParams decodes the EVM calldata from the parameters of an InvokeEVM message
+func ParseInvokeEVMParams(params []byte) ([]byte, error) {
+	if len(params) == 0 {
+		return []byte{}, nil
+	}
+	var calldata []byte
+	if err := cbor.NewDecoder(bytes.NewReader(params)).Decode(&calldata); err != nil {
+		return nil, fmt.Errorf("failed to decode calldata: %w", err)
+	}
+	return calldata, nil
+}
@@ -0,0 +1,84 @@
+package tx
+
+import (
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/filecoin/address"
+	"github.com/fxamacker/cbor"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Methods of the built-in multisig actor
+const (
+	MethodMultisigPropose = 2
+	MethodMultisigApprove = 3
+	MethodMultisigCancel  = 4
+)
+
+// Proposal is a message that a multisig sends once enough of its signers approve it
+type Proposal struct {
+	To     string
+	Value  xc.AmountBlockchain
+	Method uint64
+	Params []byte
+}
+
+// NewProposeParams encodes the parameters of a multisig `Propose`
+func NewProposeParams(proposal Proposal) ([]byte, error) {
+	to, err := address.AddressToBytes(proposal.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal `to` address: %w", err)
+	}
+	return marshalParams([]any{
+		to,
+		BigIntBytes(proposal.Value),
+		proposal.Method,
+		append([]byte{}, proposal.Params...),
+	})
+}
+
+// NewTxnIdParams encodes the parameters of a multisig `Approve` or `Cancel` of a pending transaction.
+// The proposal hash is optional, when set the actor checks that the pending transaction matches it.
+func NewTxnIdParams(txnId int64, proposalHash []byte) ([]byte, error) {
+	if txnId < 0 {
+		return nil, fmt.Errorf("invalid multisig transaction id: %d", txnId)
+	}
+	return marshalParams([]any{
+		txnId,
+		append([]byte{}, proposalHash...),
+	})
+}
+
+// ProposalHash returns the hash the multisig actor computes for a pending transaction.
+// The requester is the ID address of the signer that proposed it.
+func ProposalHash(requester string, proposal Proposal) ([]byte, error) {
+	requesterBytes, err := address.AddressToBytes(requester)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requester address: %w", err)
+	}
+	to, err := address.AddressToBytes(proposal.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal `to` address: %w", err)
+	}
+	data, err := marshalParams([]any{
+		requesterBytes,
+		to,
+		BigIntBytes(proposal.Value),
+		proposal.Method,
+		append([]byte{}, proposal.Params...),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sum := blake2b.Sum256(data)
+	return sum[:], nil
+}
+
+func marshalParams(params []any) ([]byte, error) {
+	bytes, err := cbor.Marshal(params, cbor.CanonicalEncOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode params: %w", err)
+	}
+	return bytes, nil
+}
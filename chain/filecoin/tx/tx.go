@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 
 	xc "github.com/cordialsys/crosschain"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
+	evmtx "github.com/cordialsys/crosschain/chain/evm/tx"
 	"github.com/cordialsys/crosschain/chain/filecoin/address"
 	"github.com/cordialsys/crosschain/chain/filecoin/client/types"
 	"github.com/cordialsys/crosschain/chain/filecoin/tx_input"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/fxamacker/cbor"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/blake2b"
@@ -33,29 +36,82 @@ type Message struct {
 	Params     []byte
 }
 
-func NewMessage(args xcbuilder.TransferArgs, txInput tx_input.TxInput) Message {
-	to := string(args.GetTo())
-	from := string(args.GetFrom())
-	amount := args.GetAmount()
+// NewMessage creates a message to an actor, e.g. a plain `Send` of FIL when method is MethodSend
+func NewMessage(from string, to string, value xc.AmountBlockchain, method uint64, params []byte, txInput tx_input.TxInput) Message {
+	if params == nil {
+		params = []byte{}
+	}
 	return Message{
 		Version:    0,
 		To:         to,
 		From:       from,
 		Nonce:      txInput.Nonce,
-		Value:      amount,
+		Value:      value,
 		GasLimit:   txInput.GasLimit,
 		GasFeeCap:  txInput.GasFeeCap,
 		GasPremium: txInput.GasPremium,
-		Method:     0,
-		Params:     []byte{},
+		Method:     method,
+		Params:     params,
 	}
 }
 
+// NewTransferMessage creates the message for a transfer. Native transfers from f1/f3 addresses are
+// plain `Send` messages, while transfers from f4/0x addresses and FEVM token transfers invoke the EVM.
+func NewTransferMessage(network string, args xcbuilder.TransferArgs, txInput tx_input.TxInput) (Message, error) {
+	contract, _ := args.GetContract()
+	prefix, err := address.PrefixFor(network, string(args.GetFrom()), string(args.GetTo()), string(contract))
+	if err != nil {
+		return Message{}, err
+	}
+	from, err := address.Normalize(prefix, string(args.GetFrom()))
+	if err != nil {
+		return Message{}, err
+	}
+	to, err := address.Normalize(prefix, string(args.GetTo()))
+	if err != nil {
+		return Message{}, err
+	}
+	amount := args.GetAmount()
+
+	if contract == "" {
+		if !address.IsDelegated(from) {
+			return NewMessage(from, to, amount, MethodSend, nil, txInput), nil
+		}
+		if _, err := address.ToEthAddress(to); err != nil {
+			return Message{}, fmt.Errorf("f4 addresses can only send to f0, f4 or 0x addresses: %w", err)
+		}
+		return NewMessage(from, to, amount, MethodInvokeEVM, nil, txInput), nil
+	}
+
+	if !address.IsDelegated(from) {
+		return Message{}, fmt.Errorf("FEVM tokens must be sent from a f4 or 0x address, not %s", from)
+	}
+	tokenContract, err := address.Normalize(prefix, string(contract))
+	if err != nil {
+		return Message{}, err
+	}
+	recipient, err := address.ToEthAddress(to)
+	if err != nil {
+		return Message{}, fmt.Errorf("FEVM tokens can only be sent to f0, f4 or 0x addresses: %w", err)
+	}
+	calldata, err := evmtx.BuildERC20Payload(xc.Address(recipient.Hex()), amount)
+	if err != nil {
+		return Message{}, err
+	}
+	params, err := NewInvokeEVMParams(calldata)
+	if err != nil {
+		return Message{}, err
+	}
+	return NewMessage(from, tokenContract, xc.NewAmountBlockchainFromUint64(0), MethodInvokeEVM, params, txInput), nil
+}
+
 // Filecoint transaction
 type Tx struct {
 	Message      Message
 	XcSignatures []xc.TxSignature
 	Signature    types.Signature
+	// Messages from f4 addresses are signed as Ethereum transactions on this chain id
+	ChainId uint64
 }
 
 type SignedTx struct {
@@ -89,27 +145,17 @@ func (tx Tx) Hash() xc.TxHash {
 
 // Sighashes returns the tx payload to sign, aka sighash
 func (tx Tx) Sighashes() ([]*xc.SignatureRequest, error) {
-	to, err := address.AddressToBytes(tx.Message.To)
-	if err != nil {
-		return nil, fmt.Errorf("invalid `to` address: %w", err)
+	if address.IsDelegated(tx.Message.From) {
+		sighash, err := tx.ethSighash()
+		if err != nil {
+			return nil, err
+		}
+		return []*xc.SignatureRequest{xc.NewSignatureRequest(sighash)}, nil
 	}
 
-	from, err := address.AddressToBytes(tx.Message.From)
+	i, err := tx.Message.cborFields()
 	if err != nil {
-		return nil, fmt.Errorf("invalid `from` address: %w", err)
-	}
-
-	i := []any{
-		tx.Message.Version,
-		to,
-		from,
-		tx.Message.Nonce,
-		append([]byte{0}, tx.Message.Value.Bytes()...),
-		tx.Message.GasLimit,
-		append([]byte{0}, tx.Message.GasFeeCap.Bytes()...),
-		append([]byte{0}, tx.Message.GasPremium.Bytes()...),
-		tx.Message.Method,
-		append([]byte{}, tx.Message.Params...),
+		return nil, err
 	}
 
 	bytes, err := cbor.Marshal(i, cbor.CanonicalEncOptions())
@@ -132,6 +178,36 @@ func (tx Tx) Sighashes() ([]*xc.SignatureRequest, error) {
 	return []*xc.SignatureRequest{xc.NewSignatureRequest(sum)}, err
 }
 
+// Messages from f4 addresses are signed as the equivalent EIP-1559 Ethereum transaction
+func (tx Tx) ethSighash() ([]byte, error) {
+	to, err := address.ToEthAddress(tx.Message.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid `to` address: %w", err)
+	}
+	if tx.Message.Method != MethodInvokeEVM {
+		return nil, fmt.Errorf("f4 addresses can only send InvokeEVM messages, not method %d", tx.Message.Method)
+	}
+	calldata, err := ParseInvokeEVMParams(tx.Message.Params)
+	if err != nil {
+		return nil, err
+	}
+	if tx.ChainId == 0 {
+		return nil, errors.New("chain id is required to sign messages from f4 addresses")
+	}
+	chainId := new(big.Int).SetUint64(tx.ChainId)
+	ethTx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+		ChainID:   chainId,
+		Nonce:     tx.Message.Nonce,
+		GasTipCap: tx.Message.GasPremium.Int(),
+		GasFeeCap: tx.Message.GasFeeCap.Int(),
+		Gas:       tx.Message.GasLimit,
+		To:        &to,
+		Value:     tx.Message.Value.Int(),
+		Data:      calldata,
+	})
+	return ethtypes.NewLondonSigner(chainId).Hash(ethTx).Bytes(), nil
+}
+
 // SetSignatures adds a signature to Tx
 func (tx *Tx) SetSignatures(signatures ...*xc.SignatureResponse) error {
 	if len(signatures) != 1 {
@@ -144,8 +220,12 @@ func (tx *Tx) SetSignatures(signatures ...*xc.SignatureResponse) error {
 	tx.XcSignatures = []xc.TxSignature{signatures[0].Signature}
 
 	signature := signatures[0]
+	signatureType := byte(types.SignatureTypeSecp256k1)
+	if address.IsDelegated(tx.Message.From) {
+		signatureType = types.SignatureTypeDelegated
+	}
 	tx.Signature = types.Signature{
-		Type: address.ProtocolSecp256k1,
+		Type: signatureType,
 		Data: signature.Signature,
 	}
 
@@ -181,31 +261,50 @@ func (tx Tx) SerializeSigned() ([]byte, error) {
 		return nil, errors.New("signature is missing")
 	}
 
-	to, err := address.AddressToBytes(tx.Message.To)
+	message, err := tx.Message.cborFields()
+	if err != nil {
+		return nil, err
+	}
+
+	i := []any{
+		message,
+		append([]byte{tx.Signature.Type}, tx.Signature.Data...),
+	}
+
+	return cbor.Marshal(i, cbor.CanonicalEncOptions())
+}
+
+// cborFields returns the fields of the message, in the order of its CBOR tuple encoding
+func (msg Message) cborFields() ([]any, error) {
+	to, err := address.AddressToBytes(msg.To)
 	if err != nil {
 		return nil, fmt.Errorf("invalid `to` address: %w", err)
 	}
 
-	from, err := address.AddressToBytes(tx.Message.From)
+	from, err := address.AddressToBytes(msg.From)
 	if err != nil {
 		return nil, fmt.Errorf("invalid `from` address: %w", err)
 	}
 
-	i := []any{
-		[]any{
-			tx.Message.Version,
-			to,
-			from,
-			tx.Message.Nonce,
-			append([]byte{0}, tx.Message.Value.Bytes()...),
-			tx.Message.GasLimit,
-			append([]byte{0}, tx.Message.GasFeeCap.Bytes()...),
-			append([]byte{0}, tx.Message.GasPremium.Bytes()...),
-			tx.Message.Method,
-			append([]byte{}, tx.Message.Params...),
-		},
-		append([]byte{address.ProtocolSecp256k1}, tx.Signature.Data...),
-	}
+	return []any{
+		msg.Version,
+		to,
+		from,
+		msg.Nonce,
+		BigIntBytes(msg.Value),
+		msg.GasLimit,
+		BigIntBytes(msg.GasFeeCap),
+		BigIntBytes(msg.GasPremium),
+		msg.Method,
+		append([]byte{}, msg.Params...),
+	}, nil
+}
 
-	return cbor.Marshal(i, cbor.CanonicalEncOptions())
+// BigIntBytes encodes a non-negative amount as a Filecoin big integer: a sign byte followed by the
+// big-endian magnitude, or no bytes for zero.
+func BigIntBytes(amount xc.AmountBlockchain) []byte {
+	if amount.IsZero() {
+		return []byte{}
+	}
+	return append([]byte{0}, amount.Bytes()...)
 }
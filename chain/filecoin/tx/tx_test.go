@@ -1,13 +1,15 @@
 package tx_test
 
 import (
+	"encoding/hex"
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/chain/filecoin/address"
 	"github.com/cordialsys/crosschain/chain/filecoin/client/types"
 	"github.com/cordialsys/crosschain/chain/filecoin/tx"
-	"github.com/test-go/testify/require"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTxHash(t *testing.T) {
@@ -194,3 +196,92 @@ func TestTxAddSignature(t *testing.T) {
 	err = signedTx.SetSignatures(&xc.SignatureResponse{Signature: []byte("asdf")})
 	require.EqualError(t, err, "transaction already signed")
 }
+
+func TestMultisigParams(t *testing.T) {
+	params, err := tx.NewProposeParams(tx.Proposal{
+		To:    "f01234",
+		Value: xc.NewAmountBlockchainFromUint64(1),
+	})
+	require.NoError(t, err)
+	// [to, value, method, params]
+	require.Equal(t, "844300d2094200010040", hex.EncodeToString(params))
+
+	params, err = tx.NewTxnIdParams(5, nil)
+	require.NoError(t, err)
+	require.Equal(t, "820540", hex.EncodeToString(params))
+
+	_, err = tx.NewTxnIdParams(-1, nil)
+	require.ErrorContains(t, err, "invalid multisig transaction id")
+
+	proposal := tx.Proposal{
+		To:    "f1urvqy4hx5idlki6b6f7ab6hzihjdfy47b5cc6dy",
+		Value: xc.NewAmountBlockchainFromStr("1000000000000000000"),
+	}
+	hash1, err := tx.ProposalHash("f0100", proposal)
+	require.NoError(t, err)
+	require.Len(t, hash1, 32)
+	hash2, err := tx.ProposalHash("f0101", proposal)
+	require.NoError(t, err)
+	require.NotEqual(t, hash1, hash2)
+}
+
+func TestInvokeEVMParams(t *testing.T) {
+	params, err := tx.NewInvokeEVMParams([]byte{0xa9, 0x05, 0x9c, 0xbb})
+	require.NoError(t, err)
+	require.Equal(t, "44a9059cbb", hex.EncodeToString(params))
+
+	calldata, err := tx.ParseInvokeEVMParams(params)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xa9, 0x05, 0x9c, 0xbb}, calldata)
+
+	params, err = tx.NewInvokeEVMParams(nil)
+	require.NoError(t, err)
+	require.Empty(t, params)
+}
+
+func TestDelegatedSignature(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA("4646464646464646464646464646464646464646464646464646464646464646")
+	require.NoError(t, err)
+	from := address.NewDelegatedAddress("f", crypto.PubkeyToAddress(privateKey.PublicKey))
+
+	params, err := tx.NewInvokeEVMParams([]byte{0xa9, 0x05, 0x9c, 0xbb})
+	require.NoError(t, err)
+	filTx := tx.Tx{
+		Message: tx.Message{
+			To:         "f410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq",
+			From:       from,
+			Nonce:      3,
+			Value:      xc.NewAmountBlockchainFromUint64(0),
+			GasLimit:   100000,
+			GasFeeCap:  xc.NewAmountBlockchainFromStr("150000"),
+			GasPremium: xc.NewAmountBlockchainFromStr("100000"),
+			Method:     tx.MethodInvokeEVM,
+			Params:     params,
+		},
+		ChainId: 314,
+	}
+
+	sighashes, err := filTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+
+	signature, err := crypto.Sign(sighashes[0].Payload, privateKey)
+	require.NoError(t, err)
+	require.NoError(t, filTx.SetSignatures(&xc.SignatureResponse{Signature: signature}))
+	require.EqualValues(t, types.SignatureTypeDelegated, filTx.Signature.Type)
+
+	// the signer recovered from the ethereum transaction is the sender
+	publicKey, err := crypto.SigToPub(sighashes[0].Payload, signature)
+	require.NoError(t, err)
+	require.Equal(t, from, address.NewDelegatedAddress("f", crypto.PubkeyToAddress(*publicKey)))
+	require.NotEmpty(t, filTx.Hash())
+
+	filTx.ChainId = 0
+	_, err = filTx.Sighashes()
+	require.ErrorContains(t, err, "chain id is required")
+
+	filTx.ChainId = 314
+	filTx.Message.Method = tx.MethodSend
+	_, err = filTx.Sighashes()
+	require.ErrorContains(t, err, "can only send InvokeEVM messages")
+}
@@ -17,6 +17,8 @@ type TxInput struct {
 	// GasPremium is the amount of gas fee that user is willing to pay
 	// per unit of gas
 	GasPremium xc.AmountBlockchain `json:"gas_premium,omitempty"`
+	// ChainId is the Ethereum chain id, required to sign messages from f4 addresses
+	ChainId uint64 `json:"chain_id,omitempty"`
 
 	// renamed to snake_case 03/10/2025, should delete later
 	XNonce      uint64              `json:"Nonce,omitempty"`
@@ -11,6 +11,14 @@ import (
 func ValidateAddress(cfg *xc.ChainBaseConfig, addr xc.Address) error {
 	addrStr := string(addr)
 
+	// 0x addresses are the FEVM form of f410 and ID addresses
+	if address.IsEthAddress(addrStr) {
+		if _, err := address.ToEthAddress(addrStr); err != nil {
+			return fmt.Errorf("invalid filecoin address %s: %w", addr, err)
+		}
+		return nil
+	}
+
 	// Filecoin addresses must start with "f" (mainnet) or "t" (testnet)
 	if !strings.HasPrefix(addrStr, "f") && !strings.HasPrefix(addrStr, "t") {
 		return fmt.Errorf("invalid filecoin address %s: must start with f or t prefix", addr)
@@ -52,6 +52,17 @@ func TestValidateAddress(t *testing.T) {
 			address:   "t0143103",
 			wantError: false,
 		},
+		{
+			name:      "Filecoin - valid testnet delegated address",
+			address:   "t410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq",
+			wantError: false,
+		},
+		// Valid FEVM addresses
+		{
+			name:      "Filecoin - valid ethereum address",
+			address:   "0xb99DfF73a35e13906A5d2CFdF7A0693dC5d86490",
+			wantError: false,
+		},
 		{
 			name:      "Filecoin - valid testnet ID address (single digit)",
 			address:   "t01",
@@ -89,10 +100,10 @@ func TestValidateAddress(t *testing.T) {
 			errorMsg:  "unsupported protocol",
 		},
 		{
-			name:      "Filecoin - delegated address (not supported)",
-			address:   "t410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzjq",
+			name:      "Filecoin - delegated address with invalid checksum",
+			address:   "t410fxgo7645dlyjza2s5ft67pidjhxc5qzeqsspyzja",
 			wantError: true,
-			errorMsg:  "unsupported protocol",
+			errorMsg:  "invalid checksum",
 		},
 		{
 			name:      "Filecoin - invalid base32 encoding",
@@ -113,10 +124,10 @@ func TestValidateAddress(t *testing.T) {
 			errorMsg:  "must start with f or t prefix",
 		},
 		{
-			name:      "Filecoin - ethereum address",
+			name:      "Filecoin - short ethereum address",
 			address:   "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb",
 			wantError: true,
-			errorMsg:  "must start with f or t prefix",
+			errorMsg:  "invalid ethereum address",
 		},
 		{
 			name:      "Filecoin - EOS address",
@@ -1,11 +1,57 @@
 package address
 
 import (
+	"context"
+
 	xc "github.com/cordialsys/crosschain"
 	evmaddress "github.com/cordialsys/crosschain/chain/evm/address"
+	"github.com/sirupsen/logrus"
 )
 
+// TokenAssociationChecker checks whether an account is associated with an HTS token,
+// e.g. the hedera client using the mirror node.
+type TokenAssociationChecker interface {
+	FetchTokenAssociation(ctx context.Context, address xc.Address, token xc.ContractAddress) (bool, error)
+}
+
+// AddressBuilder for Hedera
+type AddressBuilder struct {
+	xc.AddressBuilder
+	associations TokenAssociationChecker
+	token        xc.ContractAddress
+}
+
+var _ xc.AddressBuilder = AddressBuilder{}
+var _ xc.AddressBuilderWithRegistration = AddressBuilder{}
+
 // NewAddressBuilder creates a new Hedera AddressBuilder
 func NewAddressBuilder(cfgI *xc.ChainBaseConfig) (xc.AddressBuilder, error) {
-	return evmaddress.NewAddressBuilder(cfgI)
+	builder, err := evmaddress.NewAddressBuilder(cfgI)
+	if err != nil {
+		return nil, err
+	}
+	return AddressBuilder{AddressBuilder: builder}, nil
+}
+
+// WithTokenAssociation has AddressRegistrationRequired check the association of addresses with the token.
+func (ab AddressBuilder) WithTokenAssociation(associations TokenAssociationChecker, token xc.ContractAddress) AddressBuilder {
+	ab.associations = associations
+	ab.token = token
+	return ab
+}
+
+// AddressRegistrationRequired reports whether the address must be associated with the token
+// (see WithTokenAssociation) before it can receive it.  Accounts themselves do not need
+// registration, so without a token this is always false.
+func (ab AddressBuilder) AddressRegistrationRequired(address xc.Address) bool {
+	if ab.associations == nil || ab.token == "" {
+		return false
+	}
+	associated, err := ab.associations.FetchTokenAssociation(context.Background(), address, ab.token)
+	if err != nil {
+		// assume the association is missing, the create-account flow will check it again
+		logrus.WithError(err).WithField("address", address).WithField("token", ab.token).Warn("could not check token association")
+		return true
+	}
+	return !associated
 }
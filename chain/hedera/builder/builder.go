@@ -1,9 +1,13 @@
 package builder
 
 import (
+	"errors"
+	"fmt"
+
 	xc "github.com/cordialsys/crosschain"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/hedera/tx"
+	"github.com/cordialsys/crosschain/chain/hedera/tx_input"
 )
 
 // TxBuilder for Hedera
@@ -12,6 +16,7 @@ type TxBuilder struct {
 }
 
 var _ xcbuilder.FullTransferBuilder = TxBuilder{}
+var _ xcbuilder.AccountCreation = TxBuilder{}
 
 // NewTxBuilder creates a new Hedera TxBuilder
 func NewTxBuilder(cfgI *xc.ChainBaseConfig) (TxBuilder, error) {
@@ -22,9 +27,33 @@ func NewTxBuilder(cfgI *xc.ChainBaseConfig) (TxBuilder, error) {
 
 // NewTransfer creates a new transfer for an Asset, either native or token
 func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
+	if contract, ok := args.GetContract(); ok {
+		if txInput, ok := input.(*tx_input.TxInput); ok && txInput.NeedsTokenAssociation {
+			return nil, fmt.Errorf("%s is not associated with token %s, it must be associated first (see TokenAssociate or create-account)", args.GetFrom(), contract)
+		}
+	}
 	return tx.NewTransfer(args, input)
 }
 
+// TokenAssociate associates the account of the input with HTS tokens
+func (txBuilder TxBuilder) TokenAssociate(tokens []xc.ContractAddress, input xc.TxInput) (xc.Tx, error) {
+	return tx.NewTokenAssociate(tokens, input)
+}
+
+// TokenDissociate dissociates the account of the input from HTS tokens
+func (txBuilder TxBuilder) TokenDissociate(tokens []xc.ContractAddress, input xc.TxInput) (xc.Tx, error) {
+	return tx.NewTokenDissociate(tokens, input)
+}
+
+// CreateAccount associates the account with a token, so that it may receive it
+func (txBuilder TxBuilder) CreateAccount(args xcbuilder.CreateAccountArgs, input xc.CreateAccountTxInput) (xc.Tx, error) {
+	associateInput, ok := input.(*tx_input.TokenAssociateInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	return txBuilder.TokenAssociate(associateInput.Tokens, &associateInput.TxInput)
+}
+
 func (txBuilder TxBuilder) SupportsMemo() xc.MemoSupport {
 	// Hedera supports memo
 	return xc.MemoSupportString
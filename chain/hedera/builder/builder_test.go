@@ -6,6 +6,7 @@ import (
 	xc "github.com/cordialsys/crosschain"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/hedera/builder"
+	hederatx "github.com/cordialsys/crosschain/chain/hedera/tx"
 	"github.com/cordialsys/crosschain/chain/hedera/tx_input"
 	"github.com/stretchr/testify/require"
 )
@@ -181,3 +182,70 @@ func TestNewTransfer(t *testing.T) {
 		})
 	}
 }
+
+func newAssociationInput() *tx_input.TxInput {
+	return &tx_input.TxInput{
+		AccountId:           "0.0.7182039",
+		NodeAccountID:       "0.0.3",
+		ValidStartTimestamp: 1763121763935298000,
+		MaxTransactionFee:   640000,
+		ValidTime:           180,
+	}
+}
+
+func TestTokenAssociation(t *testing.T) {
+	txBuilder := newBuilder()
+	tokens := []xc.ContractAddress{"0.0.2025", "0.0.2026"}
+
+	associate, err := txBuilder.TokenAssociate(tokens, newAssociationInput())
+	require.NoError(t, err)
+	bz, err := associate.Serialize()
+	require.NoError(t, err)
+	body, err := hederatx.ParseBody(bz)
+	require.NoError(t, err)
+	require.Len(t, body.GetTokenAssociate().Tokens, 2)
+	require.EqualValues(t, 2025, body.GetTokenAssociate().Tokens[0].TokenNum)
+	require.EqualValues(t, 7182039, body.GetTokenAssociate().Account.GetAccountNum())
+
+	dissociate, err := txBuilder.TokenDissociate(tokens[:1], newAssociationInput())
+	require.NoError(t, err)
+	bz, err = dissociate.Serialize()
+	require.NoError(t, err)
+	body, err = hederatx.ParseBody(bz)
+	require.NoError(t, err)
+	require.Len(t, body.GetTokenDissociate().Tokens, 1)
+
+	_, err = txBuilder.TokenAssociate([]xc.ContractAddress{"invalid"}, newAssociationInput())
+	require.Error(t, err)
+}
+
+func TestTransferNeedsTokenAssociation(t *testing.T) {
+	txBuilder := newBuilder()
+	input := newAssociationInput()
+	input.NeedsTokenAssociation = true
+
+	// the association is not built implicitly, it's a separate TokenAssociate transaction
+	for _, amount := range []uint64{0, 1} {
+		args, err := xcbuilder.NewTransferArgs(xc.NewChainConfig(xc.HBAR).Base(), "0.0.7182039", "0.0.7182040", xc.NewAmountBlockchainFromUint64(amount), xcbuilder.OptionContractAddress("0.0.2025"))
+		require.NoError(t, err)
+		_, err = txBuilder.Transfer(args, input)
+		require.ErrorContains(t, err, "0.0.7182039 is not associated with token 0.0.2025")
+	}
+}
+
+func TestCreateAccount(t *testing.T) {
+	txBuilder := newBuilder()
+	args, err := xcbuilder.NewCreateAccountArgs(xc.HBAR, "0.0.7182039", nil, xcbuilder.OptionContractAddress("0.0.2025"))
+	require.NoError(t, err)
+	input := &tx_input.TokenAssociateInput{
+		TxInput: *newAssociationInput(),
+		Tokens:  []xc.ContractAddress{"0.0.2025"},
+	}
+	createTx, err := txBuilder.CreateAccount(args, input)
+	require.NoError(t, err)
+	bz, err := createTx.Serialize()
+	require.NoError(t, err)
+	body, err := hederatx.ParseBody(bz)
+	require.NoError(t, err)
+	require.Len(t, body.GetTokenAssociate().Tokens, 1)
+}
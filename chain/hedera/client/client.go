@@ -16,6 +16,7 @@ import (
 	xcbuilder "github.com/cordialsys/crosschain/builder"
 	resttypes "github.com/cordialsys/crosschain/chain/hedera/client/rest_types"
 	commontypes "github.com/cordialsys/crosschain/chain/hedera/common_types"
+	hederatx "github.com/cordialsys/crosschain/chain/hedera/tx"
 	"github.com/cordialsys/crosschain/chain/hedera/tx_input"
 	xclient "github.com/cordialsys/crosschain/client"
 	clienterrors "github.com/cordialsys/crosschain/client/errors"
@@ -49,6 +50,9 @@ const (
 // Use mirror `api/v1/network/exchangerate` to convert to HBAR
 var CRYPTO_TRANSFER_FEE = xc.NewAmountHumanReadableFromFloat(0.0001)
 
+// Cost of TOKEN_ASSOCIATE_TO_ACCOUNT and TOKEN_DISSOCIATE_FROM_ACCOUNT operations in USD's
+var TOKEN_ASSOCIATE_FEE = xc.NewAmountHumanReadableFromFloat(0.05)
+
 // Fees go to fee accounts + node operator
 // Fee accounts are the same for testnet and mainnet
 var FeeAccounts = []string{
@@ -61,6 +65,7 @@ var FeeAccounts = []string{
 type Client struct {
 	Asset        *xc.ChainConfig
 	CryptoClient services.CryptoServiceClient
+	TokenClient  services.TokenServiceClient
 	HttpClient   *http.Client
 	IndexerUrl   *url.URL
 	Logger       *logrus.Entry
@@ -83,6 +88,7 @@ func NewClient(cfgI *xc.ChainConfig) (*Client, error) {
 		return nil, fmt.Errorf("failed to create grpc client: %w", err)
 	}
 	cryptoClient := services.NewCryptoServiceClient(grpcClient)
+	tokenClient := services.NewTokenServiceClient(grpcClient)
 
 	if cfg.ChainID == "" {
 		return nil, fmt.Errorf("required a proper chain_id (node id) for hedera clients")
@@ -91,6 +97,7 @@ func NewClient(cfgI *xc.ChainConfig) (*Client, error) {
 	return &Client{
 		Asset:        cfg,
 		CryptoClient: cryptoClient,
+		TokenClient:  tokenClient,
 		HttpClient:   http.DefaultClient,
 		IndexerUrl:   url.JoinPath(API_VERSION),
 		Logger: logrus.WithFields(logrus.Fields{
@@ -120,8 +127,32 @@ func (c *Client) FetchExchangeRate(ctx context.Context) (*resttypes.ExchangeRate
 
 // FetchTransferInput returns tx input for a Hedera tx
 func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
-	evmAddress := args.GetFrom()
-	accInfo, err := c.FetchAccountInfo(ctx, evmAddress)
+	memo, _ := args.GetMemo()
+	needsAssociation := false
+	if contract, ok := args.GetContract(); ok {
+		associated, err := c.FetchTokenAssociation(ctx, args.GetFrom(), contract)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check token association: %w", err)
+		}
+		if !associated {
+			// the sender must first associate with the token, similar to an xrp trustline.
+			// The builder refuses the transfer, as the association is a separate transaction.
+			needsAssociation = true
+		} else if err := c.checkRecipientAssociation(ctx, args.GetTo(), contract); err != nil {
+			return nil, err
+		}
+	}
+
+	input, err := c.fetchBaseInput(ctx, args.GetFrom(), memo, CRYPTO_TRANSFER_FEE)
+	if err != nil {
+		return nil, err
+	}
+	input.NeedsTokenAssociation = needsAssociation
+	return input, nil
+}
+
+func (c *Client) fetchBaseInput(ctx context.Context, from xc.Address, memo string, usdFee xc.AmountHumanReadable) (*tx_input.TxInput, error) {
+	accInfo, err := c.FetchAccountInfo(ctx, from)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch accountInfo: %w", err)
 	}
@@ -136,7 +167,6 @@ func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Transfer
 		return nil, fmt.Errorf("failed to read consensus timestamp: %w", err)
 	}
 	ts := t.UnixNano()
-	memo, _ := args.GetMemo()
 	if len(memo) > commontypes.MAX_MEMO_LENGTH {
 		return nil, fmt.Errorf("memo is too long(%d), max length: %d", len(memo), commontypes.MAX_MEMO_LENGTH)
 	}
@@ -156,7 +186,7 @@ func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Transfer
 		return nil, fmt.Errorf("failed to fetch decimals: %w", err)
 	}
 
-	fee := rate.GetMaxEquivalent(CRYPTO_TRANSFER_FEE)
+	fee := rate.GetMaxEquivalent(usdFee)
 	feeMultiplier := c.Asset.ChainGasMultiplier
 	hrFeeMultiplier := xc.NewAmountHumanReadableFromFloat(feeMultiplier)
 	fee = fee.Mul(hrFeeMultiplier)
@@ -191,8 +221,23 @@ func (c *Client) SubmitTx(ctx context.Context, tx xclienttypes.SubmitTxReq) erro
 		SignedTransactionBytes: txBytes,
 	}
 
-	logger.WithField("transaction", fmt.Sprintf("%+v", reqTx)).Debug("calling crypto transfer")
-	r, err := c.CryptoClient.CryptoTransfer(ctx, reqTx)
+	body, err := hederatx.ParseBody(txBytes)
+	if err != nil {
+		return err
+	}
+
+	var r *services.TransactionResponse
+	switch body.Data.(type) {
+	case *services.TransactionBody_TokenAssociate:
+		logger.WithField("transaction", fmt.Sprintf("%+v", reqTx)).Debug("calling associate tokens")
+		r, err = c.TokenClient.AssociateTokens(ctx, reqTx)
+	case *services.TransactionBody_TokenDissociate:
+		logger.WithField("transaction", fmt.Sprintf("%+v", reqTx)).Debug("calling dissociate tokens")
+		r, err = c.TokenClient.DissociateTokens(ctx, reqTx)
+	default:
+		logger.WithField("transaction", fmt.Sprintf("%+v", reqTx)).Debug("calling crypto transfer")
+		r, err = c.CryptoClient.CryptoTransfer(ctx, reqTx)
+	}
 	if err != nil {
 		return fmt.Errorf("failed transaction submission: %w", err)
 	}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	hederaaddress "github.com/cordialsys/crosschain/chain/hedera/address"
+	resttypes "github.com/cordialsys/crosschain/chain/hedera/client/rest_types"
+	"github.com/cordialsys/crosschain/chain/hedera/tx_input"
+	xclient "github.com/cordialsys/crosschain/client"
+	xcerrors "github.com/cordialsys/crosschain/client/errors"
+)
+
+const KEY_TOKEN_ID = "token.id"
+
+var _ xclient.CreateAccountClient = &Client{}
+var _ hederaaddress.TokenAssociationChecker = &Client{}
+
+// FetchTokenAssociation checks with the mirror node whether the account is associated with the token
+func (c *Client) FetchTokenAssociation(ctx context.Context, address xc.Address, token xc.ContractAddress) (bool, error) {
+	params := url.Values{}
+	params.Add(KEY_TOKEN_ID, string(token))
+	u := c.IndexerUrl.JoinPath(ENDPOINT_ACCOUNTS + "/" + string(address) + "/" + ENDPOINT_TOKENS)
+	u.RawQuery = params.Encode()
+	relationships, err := Get[resttypes.TokenRelationships](ctx, c, u)
+	if err != nil {
+		return false, err
+	}
+	for _, relationship := range relationships.Tokens {
+		if relationship.TokenId == string(token) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Recipients must be associated with the token, unless they have automatic association enabled.
+func (c *Client) checkRecipientAssociation(ctx context.Context, to xc.Address, token xc.ContractAddress) error {
+	accInfo, err := c.FetchAccountInfo(ctx, to)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			// a new account will be created for an alias, with unlimited automatic associations
+			return nil
+		}
+		return fmt.Errorf("failed to fetch recipient account info: %w", err)
+	}
+	if accInfo.MaxAutomaticTokenAssociations != 0 {
+		return nil
+	}
+	associated, err := c.FetchTokenAssociation(ctx, to, token)
+	if err != nil {
+		return fmt.Errorf("failed to check recipient token association: %w", err)
+	}
+	if !associated {
+		return fmt.Errorf("recipient %s is not associated with token %s and cannot receive it", to, token)
+	}
+	return nil
+}
+
+// FetchTokenAssociationInput fetches the input for associating or dissociating the address with tokens
+func (c *Client) FetchTokenAssociationInput(ctx context.Context, address xc.Address) (*tx_input.TxInput, error) {
+	return c.fetchBaseInput(ctx, address, "", TOKEN_ASSOCIATE_FEE)
+}
+
+// GetAccountState reports whether the account is associated with the token
+func (c *Client) GetAccountState(ctx context.Context, args *xclient.CreateAccountArgs) (xclient.AccountState, error) {
+	token, ok := args.GetContract()
+	if !ok {
+		return "", fmt.Errorf("a token contract is required to check hedera token association")
+	}
+	associated, err := c.FetchTokenAssociation(ctx, args.GetAddress(), token)
+	if err != nil {
+		return "", fmt.Errorf("failed to check token association: %w", err)
+	}
+	if associated {
+		return xclient.AccountActive, nil
+	}
+	return xclient.AccountInactive, nil
+}
+
+// FetchCreateAccountInput fetches the input to associate the account with the token.
+// It will return an AlreadyActive error if the account is already associated.
+func (c *Client) FetchCreateAccountInput(ctx context.Context, args *xclient.CreateAccountArgs) (xc.CreateAccountTxInput, error) {
+	state, err := c.GetAccountState(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	token, _ := args.GetContract()
+	if state == xclient.AccountActive {
+		return nil, xcerrors.AddressAlreadyActivef("address is already associated with token %s", token)
+	}
+	input, err := c.FetchTokenAssociationInput(ctx, args.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	return &tx_input.TokenAssociateInput{
+		TxInput: *input,
+		Tokens:  []xc.ContractAddress{token},
+	}, nil
+}
@@ -0,0 +1,94 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	hederaaddress "github.com/cordialsys/crosschain/chain/hedera/address"
+	"github.com/cordialsys/crosschain/chain/hedera/client"
+	"github.com/cordialsys/crosschain/chain/hedera/tx_input"
+	xclient "github.com/cordialsys/crosschain/client"
+	xcerrors "github.com/cordialsys/crosschain/client/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchTokenAssociation(t *testing.T) {
+	v := []struct {
+		name       string
+		response   string
+		associated bool
+		err        bool
+	}{
+		{
+			name:       "Associated",
+			response:   `{"tokens":[{"automatic_association":false,"balance":4899,"created_timestamp":"1762254858.110234731","decimals":2,"freeze_status":"NOT_APPLICABLE","kyc_status":"NOT_APPLICABLE","token_id":"0.0.7190398"}],"links":{"next":null}}`,
+			associated: true,
+		},
+		{
+			name:       "NotAssociated",
+			response:   `{"tokens":[],"links":{"next":null}}`,
+			associated: false,
+		},
+		{
+			name:     "MissingAccount",
+			response: `{"_status":{"messages":[{"message":"Not found"}]}}`,
+			err:      true,
+		},
+	}
+
+	for _, v := range v {
+		t.Run(v.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/api/v1/accounts/0.0.7182039/tokens", r.URL.Path)
+				require.Equal(t, "0.0.7190398", r.URL.Query().Get("token.id"))
+				if v.err {
+					w.WriteHeader(404)
+				}
+				_, err := w.Write([]byte(v.response))
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+			c := newTestClient(server.URL, "grpc.url").(*client.Client)
+
+			// registration is only required for accounts that are not associated with the token
+			addressBuilder, err := hederaaddress.NewAddressBuilder(xc.NewChainConfig(xc.HBAR).Base())
+			require.NoError(t, err)
+			registration := addressBuilder.(hederaaddress.AddressBuilder).WithTokenAssociation(c, "0.0.7190398")
+			require.Equal(t, !v.associated, registration.AddressRegistrationRequired("0.0.7182039"))
+			require.False(t, addressBuilder.(hederaaddress.AddressBuilder).AddressRegistrationRequired("0.0.7182039"))
+
+			associated, err := c.FetchTokenAssociation(context.TODO(), "0.0.7182039", "0.0.7190398")
+			if v.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, v.associated, associated)
+
+			args := xclient.NewCreateAccountArgs("0.0.7182039", nil).WithContract("0.0.7190398")
+			state, err := c.GetAccountState(context.TODO(), args)
+			require.NoError(t, err)
+			if v.associated {
+				require.Equal(t, xclient.AccountActive, state)
+				_, err = c.FetchCreateAccountInput(context.TODO(), args)
+				require.True(t, xcerrors.Is(err, xcerrors.AddressAlreadyActive))
+			} else {
+				require.Equal(t, xclient.AccountInactive, state)
+			}
+		})
+	}
+}
+
+func TestGetAccountStateRequiresContract(t *testing.T) {
+	c := newTestClient("http://localhost:1", "grpc.url").(*client.Client)
+	_, err := c.GetAccountState(context.TODO(), xclient.NewCreateAccountArgs("0.0.7182039", nil))
+	require.ErrorContains(t, err, "contract is required")
+}
+
+func TestTokenAssociateInputVariant(t *testing.T) {
+	input := &tx_input.TokenAssociateInput{Tokens: []xc.ContractAddress{"0.0.7190398"}}
+	require.Equal(t, xc.NewCreateAccountInputType(xc.DriverHedera, "token-associate"), input.GetVariant())
+}
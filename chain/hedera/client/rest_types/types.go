@@ -28,6 +28,21 @@ type AccountInfo struct {
 	EvmAddress         string    `json:"evm_address"`
 	Balance            Balance   `json:"balance"`
 	ConsensusTimestamp Timestamp `json:"consensus_timestamp"`
+	// Number of tokens the account automatically associates with on receipt, -1 for unlimited
+	MaxAutomaticTokenAssociations int `json:"max_automatic_token_associations"`
+}
+
+type TokenRelationship struct {
+	TokenId              string `json:"token_id"`
+	AutomaticAssociation bool   `json:"automatic_association"`
+	Balance              uint64 `json:"balance"`
+	FreezeStatus         string `json:"freeze_status"`
+	KycStatus            string `json:"kyc_status"`
+}
+
+type TokenRelationships struct {
+	Tokens []TokenRelationship `json:"tokens"`
+	Links  Links               `json:"links"`
 }
 
 type TokenInfo struct {
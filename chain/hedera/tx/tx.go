@@ -129,8 +129,70 @@ func NewTransfer(args builder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
 			TokenTransfers: tokenTransfers,
 		},
 	}
+	body := newBody(txi)
+	body.Data = cryptoTransferBody
+	return newTx(body)
+}
+
+func tokenIds(tokens []xc.ContractAddress) ([]*common.TokenID, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("at least one token is required")
+	}
+	ids := make([]*common.TokenID, len(tokens))
+	for i, token := range tokens {
+		tokenId, err := commontypes.NewTokenId(string(token))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s to token id: %w", token, err)
+		}
+		ids[i] = tokenId
+	}
+	return ids, nil
+}
+
+// NewTokenAssociate associates the sender account with HTS tokens, which is required
+// before the account can receive them.
+func NewTokenAssociate(tokens []xc.ContractAddress, input xc.TxInput) (xc.Tx, error) {
+	txi, err := validateInput(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+	ids, err := tokenIds(tokens)
+	if err != nil {
+		return nil, err
+	}
+	body := newBody(txi)
+	body.Data = &services.TransactionBody_TokenAssociate{
+		TokenAssociate: &services.TokenAssociateTransactionBody{
+			Account: txi.AccountId,
+			Tokens:  ids,
+		},
+	}
+	return newTx(body)
+}
+
+// NewTokenDissociate dissociates the sender account from HTS tokens.  The account must not hold
+// any balance of the tokens.
+func NewTokenDissociate(tokens []xc.ContractAddress, input xc.TxInput) (xc.Tx, error) {
+	txi, err := validateInput(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+	ids, err := tokenIds(tokens)
+	if err != nil {
+		return nil, err
+	}
+	body := newBody(txi)
+	body.Data = &services.TransactionBody_TokenDissociate{
+		TokenDissociate: &services.TokenDissociateTransactionBody{
+			Account: txi.AccountId,
+			Tokens:  ids,
+		},
+	}
+	return newTx(body)
+}
 
-	body := &services.TransactionBody{
+func newBody(txi *ProcessedInput) *services.TransactionBody {
+	return &services.TransactionBody{
 		TransactionID:  txi.TransactionId,
 		NodeAccountID:  txi.NodeId,
 		TransactionFee: txi.MaxFee,
@@ -138,9 +200,10 @@ func NewTransfer(args builder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
 			Seconds: txi.ValidTime,
 		},
 		Memo: txi.Memo,
-		Data: cryptoTransferBody,
 	}
+}
 
+func newTx(body *services.TransactionBody) (xc.Tx, error) {
 	bodyBytes, err := proto.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to serialize tx body: %w", err)
@@ -214,3 +277,16 @@ func (tx Tx) Serialize() ([]byte, error) {
 
 	return bytes, nil
 }
+
+// ParseBody decodes the transaction body from a serialized signed transaction
+func ParseBody(signedTxBytes []byte) (*services.TransactionBody, error) {
+	signedTx := &services.SignedTransaction{}
+	if err := proto.Unmarshal(signedTxBytes, signedTx); err != nil {
+		return nil, fmt.Errorf("failed to deserialize signed transaction: %w", err)
+	}
+	body := &services.TransactionBody{}
+	if err := proto.Unmarshal(signedTx.BodyBytes, body); err != nil {
+		return nil, fmt.Errorf("failed to deserialize transaction body: %w", err)
+	}
+	return body, nil
+}
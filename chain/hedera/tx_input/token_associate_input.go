@@ -0,0 +1,27 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/drivers/registry"
+)
+
+// TokenAssociateInput is the create-account input for associating an account with an HTS token,
+// similar to creating a trustline on other chains.
+type TokenAssociateInput struct {
+	TxInput
+	// Tokens to associate with, in `0.0.12345` format
+	Tokens []xc.ContractAddress `json:"tokens"`
+}
+
+var _ xc.TxVariantInput = &TokenAssociateInput{}
+var _ xc.CreateAccountTxInput = &TokenAssociateInput{}
+
+func init() {
+	registry.RegisterTxVariantInput(&TokenAssociateInput{})
+}
+
+func (*TokenAssociateInput) CreatingAccount() {}
+
+func (*TokenAssociateInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewCreateAccountInputType(xc.DriverHedera, "token-associate")
+}
@@ -26,6 +26,9 @@ type TxInput struct {
 	ValidTime int64 `json:"valid_time"`
 	// Transaction memo, max 100 characters
 	Memo string `json:"memo"`
+	// NeedsTokenAssociation indicates that the sender must associate with the token before it can
+	// hold it.  Transfers are refused until the association, a separate transaction, is done.
+	NeedsTokenAssociation bool `json:"needs_token_association,omitempty"`
 }
 
 var _ xc.TxInput = &TxInput{}
@@ -0,0 +1,192 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/client/types"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/tx"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/tx_input"
+)
+
+// Dexes that assets may be sent between
+const (
+	DexPerps = ""
+	DexSpot  = "spot"
+)
+
+// WithdrawArgs bridges USDC from the perps account to an address on Arbitrum
+type WithdrawArgs struct {
+	From xc.Address
+	// Arbitrum address
+	To     xc.Address
+	Amount xc.AmountBlockchain
+}
+
+// UsdClassTransferArgs moves USDC between the spot and perps accounts of From
+type UsdClassTransferArgs struct {
+	From   xc.Address
+	Amount xc.AmountBlockchain
+	// Transfer from spot to perps, otherwise from perps to spot
+	ToPerp bool
+}
+
+// VaultTransferArgs deposits USDC to a vault, or withdraws it back to From
+type VaultTransferArgs struct {
+	From    xc.Address
+	Vault   xc.Address
+	Amount  xc.AmountBlockchain
+	Deposit bool
+}
+
+// SendAssetArgs sends a token between dexes, users and sub-accounts
+type SendAssetArgs struct {
+	From xc.Address
+	To   xc.Address
+	// Token to send, or USDC if empty
+	Contract xc.ContractAddress
+	// Required if Contract is set
+	Decimals       int
+	Amount         xc.AmountBlockchain
+	SourceDex      string
+	DestinationDex string
+	// Send from this sub-account of From rather than From itself
+	FromSubAccount xc.Address
+}
+
+// ApproveAgentArgs authorizes an agent (API wallet) to sign actions on behalf of From
+type ApproveAgentArgs struct {
+	From      xc.Address
+	Agent     xc.Address
+	AgentName string
+}
+
+func getInput(input xc.TxInput) (*tx_input.TxInput, error) {
+	txInput, ok := input.(*tx_input.TxInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	return txInput, nil
+}
+
+// Withdraw builds a "withdraw3" action
+func (txBuilder TxBuilder) Withdraw(args WithdrawArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, err := getInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if args.Amount.IsZero() {
+		return nil, errors.New("withdraw amount must be greater than 0")
+	}
+	transaction := tx.NewActionTx(types.Withdraw{
+		Type:             types.ActionWithdraw,
+		SignatureChainId: tx.SignatureChainId,
+		HyperliquidChain: txInput.HyperliquidChain,
+		Destination:      string(args.To),
+		Amount:           args.Amount.ToHuman(tx_input.UsdcDecimals).String(),
+		Time:             uint64(txInput.TransactionTime.UnixMilli()),
+	})
+	return &transaction, nil
+}
+
+// UsdClassTransfer builds a "usdClassTransfer" action
+func (txBuilder TxBuilder) UsdClassTransfer(args UsdClassTransferArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, err := getInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if args.Amount.IsZero() {
+		return nil, errors.New("transfer amount must be greater than 0")
+	}
+	transaction := tx.NewActionTx(types.UsdClassTransfer{
+		Type:             types.ActionUsdClassTransfer,
+		SignatureChainId: tx.SignatureChainId,
+		HyperliquidChain: txInput.HyperliquidChain,
+		Amount:           args.Amount.ToHuman(tx_input.UsdcDecimals).String(),
+		ToPerp:           args.ToPerp,
+		Nonce:            uint64(txInput.TransactionTime.UnixMilli()),
+	})
+	return &transaction, nil
+}
+
+// VaultTransfer builds a "vaultTransfer" action
+func (txBuilder TxBuilder) VaultTransfer(args VaultTransferArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, err := getInput(input)
+	if err != nil {
+		return nil, err
+	}
+	usd := args.Amount.ToHuman(tx_input.UsdcDecimals).ToBlockchain(types.VaultUsdDecimals)
+	if usd.IsZero() {
+		return nil, fmt.Errorf("vault transfer amount must be at least 1 micro-USD")
+	}
+	if !usd.Int().IsUint64() {
+		return nil, fmt.Errorf("vault transfer amount is too large")
+	}
+	transaction := tx.NewActionTx(types.VaultTransfer{
+		Type:             types.ActionVaultTransfer,
+		VaultAddress:     strings.ToLower(string(args.Vault)),
+		IsDeposit:        args.Deposit,
+		Usd:              usd.Uint64(),
+		Nonce:            uint64(txInput.TransactionTime.UnixMilli()),
+		HyperliquidChain: txInput.HyperliquidChain,
+	})
+	return &transaction, nil
+}
+
+// SendAsset builds a "sendAsset" action
+func (txBuilder TxBuilder) SendAsset(args SendAssetArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, err := getInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if args.Amount.IsZero() {
+		return nil, errors.New("send amount must be greater than 0")
+	}
+	token := tx_input.TokenLabel(tx_input.UsdcTokenId)
+	decimals := tx_input.UsdcDecimals
+	if args.Contract != "" {
+		if args.Decimals == 0 {
+			return nil, errors.New("decimals are required when contract is provided")
+		}
+		token = tx_input.NewTokenLabel(txInput.Symbol, args.Contract)
+		decimals = args.Decimals
+	}
+	if symbol, _ := token.GetSymbol(); symbol == "" {
+		return nil, fmt.Errorf("missing token symbol for contract: %s", args.Contract)
+	}
+	transaction := tx.NewActionTx(types.SendAsset{
+		Type:             types.ActionSendAsset,
+		SignatureChainId: tx.SignatureChainId,
+		HyperliquidChain: txInput.HyperliquidChain,
+		Destination:      string(args.To),
+		SourceDex:        args.SourceDex,
+		DestinationDex:   args.DestinationDex,
+		Token:            string(token),
+		Amount:           args.Amount.ToHuman(int32(decimals)).String(),
+		FromSubAccount:   string(args.FromSubAccount),
+		Nonce:            uint64(txInput.TransactionTime.UnixMilli()),
+	})
+	return &transaction, nil
+}
+
+// ApproveAgent builds an "approveAgent" action
+func (txBuilder TxBuilder) ApproveAgent(args ApproveAgentArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, err := getInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if args.Agent == "" {
+		return nil, errors.New("agent address is required")
+	}
+	transaction := tx.NewActionTx(types.ApproveAgent{
+		Type:             types.ActionApproveAgent,
+		SignatureChainId: tx.SignatureChainId,
+		HyperliquidChain: txInput.HyperliquidChain,
+		AgentAddress:     string(args.Agent),
+		AgentName:        args.AgentName,
+		Nonce:            uint64(txInput.TransactionTime.UnixMilli()),
+	})
+	return &transaction, nil
+}
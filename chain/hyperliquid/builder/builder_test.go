@@ -7,6 +7,8 @@ import (
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/builder/buildertest"
 	"github.com/cordialsys/crosschain/chain/hyperliquid/builder"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/client/types"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/tx"
 	"github.com/cordialsys/crosschain/chain/hyperliquid/tx_input"
 	"github.com/stretchr/testify/require"
 )
@@ -45,3 +47,144 @@ func TestNewTransfer(t *testing.T) {
 	_, err = builder.Transfer(args, input)
 	require.NoError(t, err)
 }
+
+func TestActions(t *testing.T) {
+	chainCfg := xc.NewChainConfig("HYPE").Base()
+	txBuilder, err := builder.NewTxBuilder(chainCfg)
+	require.NoError(t, err)
+
+	from := xc.Address("0xdb32f3f4c2ec447c7e15dd5df45055c08652f4db")
+	to := xc.Address("0x21db009054831a7fd8914f544f749180630ce217")
+	input := tx_input.NewTxInput()
+	input.TransactionTime = time.UnixMilli(1758098790757)
+	input.HyperliquidChain = "Mainnet"
+	input.Symbol = "HYPE"
+
+	getAction := func(t *testing.T, xcTx xc.Tx, err error) types.Action {
+		require.NoError(t, err)
+		require.EqualValues(t, 1758098790757, xcTx.(*tx.Tx).Nonce)
+		return xcTx.(*tx.Tx).GetAction()
+	}
+
+	t.Run("withdraw", func(t *testing.T) {
+		xcTx, err := txBuilder.Withdraw(builder.WithdrawArgs{
+			From:   from,
+			To:     to,
+			Amount: xc.NewAmountBlockchainFromUint64(1_050_000_000),
+		}, input)
+		require.Equal(t, types.Withdraw{
+			Type:             "withdraw3",
+			SignatureChainId: tx.SignatureChainId,
+			HyperliquidChain: "Mainnet",
+			Destination:      string(to),
+			Amount:           "10.5",
+			Time:             1758098790757,
+		}, getAction(t, xcTx, err))
+
+		_, err = txBuilder.Withdraw(builder.WithdrawArgs{From: from, To: to}, input)
+		require.ErrorContains(t, err, "must be greater than 0")
+	})
+
+	t.Run("usd_class_transfer", func(t *testing.T) {
+		xcTx, err := txBuilder.UsdClassTransfer(builder.UsdClassTransferArgs{
+			From:   from,
+			Amount: xc.NewAmountBlockchainFromUint64(100_000_000),
+			ToPerp: true,
+		}, input)
+		require.Equal(t, types.UsdClassTransfer{
+			Type:             "usdClassTransfer",
+			SignatureChainId: tx.SignatureChainId,
+			HyperliquidChain: "Mainnet",
+			Amount:           "1",
+			ToPerp:           true,
+			Nonce:            1758098790757,
+		}, getAction(t, xcTx, err))
+	})
+
+	t.Run("vault_transfer", func(t *testing.T) {
+		xcTx, err := txBuilder.VaultTransfer(builder.VaultTransferArgs{
+			From:    from,
+			Vault:   "0xDFC24B077BC1425AD1DEA75BCB6F8158E10DF303",
+			Amount:  xc.NewAmountBlockchainFromUint64(500_000_000),
+			Deposit: true,
+		}, input)
+		require.Equal(t, types.VaultTransfer{
+			Type:             "vaultTransfer",
+			VaultAddress:     "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303",
+			IsDeposit:        true,
+			Usd:              5_000_000,
+			Nonce:            1758098790757,
+			HyperliquidChain: "Mainnet",
+		}, getAction(t, xcTx, err))
+
+		// less than a micro-USD
+		_, err = txBuilder.VaultTransfer(builder.VaultTransferArgs{
+			From:   from,
+			Vault:  to,
+			Amount: xc.NewAmountBlockchainFromUint64(99),
+		}, input)
+		require.ErrorContains(t, err, "at least 1 micro-USD")
+	})
+
+	t.Run("send_asset", func(t *testing.T) {
+		xcTx, err := txBuilder.SendAsset(builder.SendAssetArgs{
+			From:           from,
+			To:             to,
+			Contract:       "0x0d01dc56dcaaca66ad901c959b4011ec",
+			Decimals:       8,
+			Amount:         xc.NewAmountBlockchainFromUint64(10_000_000),
+			SourceDex:      builder.DexSpot,
+			DestinationDex: builder.DexPerps,
+			FromSubAccount: "0x1111111111111111111111111111111111111111",
+		}, input)
+		require.Equal(t, types.SendAsset{
+			Type:             "sendAsset",
+			SignatureChainId: tx.SignatureChainId,
+			HyperliquidChain: "Mainnet",
+			Destination:      string(to),
+			SourceDex:        "spot",
+			DestinationDex:   "",
+			Token:            "HYPE:0x0d01dc56dcaaca66ad901c959b4011ec",
+			Amount:           "0.1",
+			FromSubAccount:   "0x1111111111111111111111111111111111111111",
+			Nonce:            1758098790757,
+		}, getAction(t, xcTx, err))
+
+		// USDC by default
+		xcTx, err = txBuilder.SendAsset(builder.SendAssetArgs{
+			From:   from,
+			To:     to,
+			Amount: xc.NewAmountBlockchainFromUint64(100_000_000),
+		}, input)
+		action := getAction(t, xcTx, err).(types.SendAsset)
+		require.Equal(t, tx_input.UsdcTokenId, action.Token)
+		require.Equal(t, "1", action.Amount)
+
+		_, err = txBuilder.SendAsset(builder.SendAssetArgs{
+			From:     from,
+			To:       to,
+			Contract: "0x0d01dc56dcaaca66ad901c959b4011ec",
+			Amount:   xc.NewAmountBlockchainFromUint64(1),
+		}, input)
+		require.ErrorContains(t, err, "decimals are required")
+	})
+
+	t.Run("approve_agent", func(t *testing.T) {
+		xcTx, err := txBuilder.ApproveAgent(builder.ApproveAgentArgs{
+			From:      from,
+			Agent:     to,
+			AgentName: "rebalancer",
+		}, input)
+		require.Equal(t, types.ApproveAgent{
+			Type:             "approveAgent",
+			SignatureChainId: tx.SignatureChainId,
+			HyperliquidChain: "Mainnet",
+			AgentAddress:     string(to),
+			AgentName:        "rebalancer",
+			Nonce:            1758098790757,
+		}, getAction(t, xcTx, err))
+
+		_, err = txBuilder.ApproveAgent(builder.ApproveAgentArgs{From: from}, input)
+		require.ErrorContains(t, err, "agent address is required")
+	})
+}
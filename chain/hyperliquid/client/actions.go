@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/client/types"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/tx_input"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+)
+
+// FetchActionInput returns the tx input for withdrawals, class/vault transfers, asset sends and agent approvals.
+// The token symbol is looked up if a contract is given.
+func (client *Client) FetchActionInput(ctx context.Context, contract xc.ContractAddress) (*tx_input.TxInput, error) {
+	txInput := tx_input.NewTxInput()
+	txInput.TransactionTime = time.Now()
+	txInput.HyperliquidChain = client.HyperliquidChain
+
+	if contract != "" {
+		tokensMetadata, err := client.fetchTokensMetadata(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch tokens metadata: %w", err)
+		}
+		tokenMeta, ok := tokensMetadata.GetTokenMetaByNameOrSuffix(string(contract))
+		if !ok {
+			return nil, fmt.Errorf("missing token metadata for contract: %s", contract)
+		}
+		txInput.Symbol = tokenMeta.Name
+		txInput.DecimalsOld = int32(tokenMeta.WeiDecimals)
+	}
+
+	return txInput, nil
+}
+
+// USDC in the perps account is reported as a separate asset from spot USDC
+func setPerpsAsset(movement *txinfo.Movement) {
+	movement.ContractId = ""
+	movement.XContract = ""
+	movement.XAsset = UsdcAsset
+	movement.AssetId = UsdcPerps
+}
+
+// newUsdcMovement moves USDC from and/or to an address, which may be empty when moving between its spot and perps accounts
+func (client *Client) newUsdcMovement(from xc.Address, to xc.Address, amount xc.AmountBlockchain, perps bool) *txinfo.Movement {
+	chain := client.Asset.GetChain().Chain
+	var movement *txinfo.Movement
+	if perps {
+		movement = txinfo.NewMovement(chain, "")
+		setPerpsAsset(movement)
+	} else {
+		contract, _ := tx_input.TokenLabel(tx_input.UsdcTokenId).GetContract()
+		movement = txinfo.NewMovement(chain, contract)
+	}
+	if from != "" {
+		movement.AddSource(from, amount, nil)
+	}
+	if to != "" {
+		movement.AddDestination(to, amount, nil)
+	}
+	return movement
+}
+
+// getActionMovements returns the balance movements for actions other than spot and usd sends
+func (client *Client) getActionMovements(ctx context.Context, user xc.Address, action types.Action) ([]*txinfo.Movement, error) {
+	hrAmount, err := action.GetAmount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount to HumanReadable: %w", err)
+	}
+	usdc := hrAmount.ToBlockchain(UsdcDecimals)
+
+	switch action := action.(type) {
+	case types.Withdraw:
+		// bridged out of the perps account
+		return []*txinfo.Movement{
+			client.newUsdcMovement(user, action.GetDestination(), usdc, true),
+		}, nil
+	case types.UsdClassTransfer:
+		if subAccount, ok := action.GetSubAccount(); ok {
+			user = subAccount
+		}
+		if action.ToPerp {
+			return []*txinfo.Movement{
+				client.newUsdcMovement(user, "", usdc, false),
+				client.newUsdcMovement("", user, usdc, true),
+			}, nil
+		}
+		return []*txinfo.Movement{
+			client.newUsdcMovement(user, "", usdc, true),
+			client.newUsdcMovement("", user, usdc, false),
+		}, nil
+	case types.VaultTransfer:
+		vault := action.GetDestination()
+		if action.IsDeposit {
+			return []*txinfo.Movement{client.newUsdcMovement(user, vault, usdc, true)}, nil
+		}
+		return []*txinfo.Movement{client.newUsdcMovement(vault, user, usdc, true)}, nil
+	case types.SendAsset:
+		from := user
+		if action.FromSubAccount != "" {
+			from = xc.Address(action.FromSubAccount)
+		}
+		contract, _ := tx_input.TokenLabel(action.Token).GetContract()
+		decimals, err := client.FetchDecimals(ctx, contract)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch token decimals: %w", err)
+		}
+		amount := hrAmount.ToBlockchain(int32(decimals))
+		movement := txinfo.NewMovement(client.Asset.GetChain().Chain, contract)
+		movement.AddSource(from, amount, nil)
+		movement.AddDestination(action.GetDestination(), amount, nil)
+		return []*txinfo.Movement{movement}, nil
+	case types.ApproveAgent:
+		// no funds are moved
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w: %T", types.ErrUnsupportedAction, action)
+}
@@ -40,7 +40,7 @@ const (
 	MethodUserDetails                 = "userDetails"
 	MethodUserNonFundingLedgerUpdates = "userNonFundingLedgerUpdates"
 	ResponseTypeError                 = "error"
-	UsdcDecimals                      = 8
+	UsdcDecimals                      = tx_input.UsdcDecimals
 	UsdcPerps                         = "USDCPerps"
 	UsdcAsset                         = "chains/HYPE/assets/" + UsdcPerps
 )
@@ -244,27 +244,35 @@ func (client *Client) fetchTxInfoByHash(ctx context.Context, txHash string) (txi
 
 	txInfo := txinfo.NewTxInfo(block, client.Asset.GetChain(), txDetails.Hash, confirmations, &txDetails.Error)
 	sourceAddress := xc.Address(txDetails.User)
-	destinationAddress := action.GetDestination()
-	hrAmount, err := action.GetAmount()
-	if err != nil {
-		return txinfo.TxInfo{}, fmt.Errorf("failed to convert amount to HumanReadable: %w", err)
-	}
-	decimals, err := client.FetchDecimals(ctx, contract)
-	if err != nil {
-		return txinfo.TxInfo{}, fmt.Errorf("failed to fetch token decimals: %w", err)
-	}
-	amount := hrAmount.ToBlockchain(int32(decimals))
-	movement := txinfo.NewMovement(chain, contract)
-	movement.AddSource(sourceAddress, amount, nil)
-	movement.AddDestination(destinationAddress, amount, nil)
-	// Explicitely remove contract for perps transactions
-	if contract == "" {
-		movement.ContractId = ""
-		movement.XContract = ""
-		movement.XAsset = UsdcAsset
-		movement.AssetId = UsdcPerps
+	switch action.(type) {
+	case types.SpotSend, types.UsdSend:
+		destinationAddress := action.GetDestination()
+		hrAmount, err := action.GetAmount()
+		if err != nil {
+			return txinfo.TxInfo{}, fmt.Errorf("failed to convert amount to HumanReadable: %w", err)
+		}
+		decimals, err := client.FetchDecimals(ctx, contract)
+		if err != nil {
+			return txinfo.TxInfo{}, fmt.Errorf("failed to fetch token decimals: %w", err)
+		}
+		amount := hrAmount.ToBlockchain(int32(decimals))
+		movement := txinfo.NewMovement(chain, contract)
+		movement.AddSource(sourceAddress, amount, nil)
+		movement.AddDestination(destinationAddress, amount, nil)
+		// Explicitely remove contract for perps transactions
+		if contract == "" {
+			setPerpsAsset(movement)
+		}
+		txInfo.AddMovement(movement)
+	default:
+		movements, err := client.getActionMovements(ctx, sourceAddress, action)
+		if err != nil {
+			return txinfo.TxInfo{}, err
+		}
+		for _, movement := range movements {
+			txInfo.AddMovement(movement)
+		}
 	}
-	txInfo.AddMovement(movement)
 
 	fee, feeToken, err := client.fetchTransactionFee(ctx, sourceAddress, txHash)
 	if err != nil {
@@ -315,6 +323,10 @@ func (client *Client) fetchTxInfoByActionHash(ctx context.Context, args *txinfo.
 
 	for _, tx := range userDetails.Txs {
 		spotSend, err := tx.GetAction()
+		if errors.Is(err, types.ErrUnsupportedAction) {
+			// e.g. orders, which are not created by this client
+			continue
+		}
 		if err != nil {
 			return txinfo.TxInfo{}, fmt.Errorf("failed to get tx action: %w", err)
 		}
@@ -0,0 +1,137 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/builder"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/client"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/tx_input"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUser      = "0xdb32f3f4c2ec447c7e15dd5df45055c08652f4db"
+	testVault     = "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303"
+	vaultTxHash   = "0x2b4c0b8a1f6a3ff6c1dd04225e7a2a0201b600f3f5f2e1b8c5d3e7f4a1b2c3d4"
+	latestTxHash  = "0x9d1e2f3a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6"
+	vaultTxTime   = 1758098790757
+	vaultTxBlock  = 990
+	latestTxBlock = 1000
+)
+
+// newTestServer serves the info and explorer apis, and the websocket used to find the latest block
+func newTestServer(t *testing.T, userTxs []map[string]any) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	txs := map[string]map[string]any{
+		latestTxHash: {"time": vaultTxTime + 1000, "user": testUser, "block": latestTxBlock, "hash": latestTxHash, "action": map[string]any{"type": "order"}},
+	}
+	for _, tx := range userTxs {
+		txs[tx["hash"].(string)] = tx
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ws" {
+			conn, err := upgrader.Upgrade(w, r, nil)
+			require.NoError(t, err)
+			defer conn.Close()
+			_, _, err = conn.ReadMessage()
+			require.NoError(t, err)
+			require.NoError(t, conn.WriteJSON(map[string]any{
+				"channel": "subscriptionResponse",
+				"data":    map[string]any{"method": "subscribe", "subscription": map[string]any{"type": "trades", "coin": "HYPE"}},
+			}))
+			require.NoError(t, conn.WriteJSON(map[string]any{
+				"channel": "trades",
+				"data":    []map[string]any{{"coin": "HYPE", "hash": latestTxHash, "time": vaultTxTime + 1000}},
+			}))
+			return
+		}
+
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var resp any
+		switch req["type"] {
+		case client.MethodUserDetails:
+			resp = map[string]any{"type": "userDetails", "txs": userTxs}
+		case client.MethodTxDetails:
+			tx, ok := txs[req["hash"].(string)]
+			require.True(t, ok, "unexpected tx %s", req["hash"])
+			resp = map[string]any{"type": "txDetails", "tx": tx}
+		case client.MethodUserNonFundingLedgerUpdates:
+			resp = []any{}
+		case client.MethodSpotMeta:
+			resp = map[string]any{"universe": []any{}, "tokens": []any{}}
+		default:
+			t.Errorf("unexpected request type: %v", req["type"])
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+}
+
+func newTestClient(t *testing.T, server *httptest.Server) *client.Client {
+	cfg := xc.NewChainConfig(xc.HYPE).WithUrl(server.URL)
+	cfg.IndexerUrl = server.URL
+	hlClient, err := client.NewClient(cfg)
+	require.NoError(t, err)
+	hlClient.WebsocketUrl.Scheme = "ws"
+	return hlClient
+}
+
+func TestFetchTxInfoVaultTransfer(t *testing.T) {
+	// the action hash of the vault transfer, as built by this driver
+	txBuilder, err := builder.NewTxBuilder(xc.NewChainConfig(xc.HYPE).Base())
+	require.NoError(t, err)
+	input := tx_input.NewTxInput()
+	input.TransactionTime = time.UnixMilli(vaultTxTime)
+	input.HyperliquidChain = "Mainnet"
+	vaultTx, err := txBuilder.VaultTransfer(builder.VaultTransferArgs{
+		From:    testUser,
+		Vault:   testVault,
+		Amount:  xc.NewAmountBlockchainFromUint64(500_000_000),
+		Deposit: true,
+	}, input)
+	require.NoError(t, err)
+	actionHash := vaultTx.Hash()
+
+	// the explorer does not report the nonce of L1 actions, so it's taken from the time
+	server := newTestServer(t, []map[string]any{
+		{
+			"time":   vaultTxTime,
+			"user":   testUser,
+			"block":  vaultTxBlock,
+			"hash":   vaultTxHash,
+			"action": map[string]any{"type": "vaultTransfer", "vaultAddress": testVault, "isDeposit": true, "usd": 5_000_000},
+		},
+	})
+	defer server.Close()
+	hlClient := newTestClient(t, server)
+
+	args := txinfo.NewArgs(actionHash, txinfo.OptionSender(testUser))
+	info, err := hlClient.FetchTxInfo(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, vaultTxHash, info.Hash)
+	require.EqualValues(t, vaultTxBlock, info.Block.Height.Uint64())
+	require.EqualValues(t, latestTxBlock-vaultTxBlock, info.Confirmations)
+
+	require.Len(t, info.Movements, 1)
+	movement := info.Movements[0]
+	require.Len(t, movement.From, 1)
+	require.Len(t, movement.To, 1)
+	require.EqualValues(t, testUser, strings.ToLower(string(movement.From[0].AddressId)))
+	require.EqualValues(t, testVault, strings.ToLower(string(movement.To[0].AddressId)))
+	require.Equal(t, "500000000", movement.To[0].Balance.String())
+
+	// an action hash that no transaction of the sender has
+	args = txinfo.NewArgs(xc.TxHash("0x"+strings.Repeat("00", 32)), txinfo.OptionSender(testUser))
+	_, err = hlClient.FetchTxInfo(context.Background(), args)
+	require.Error(t, err)
+}
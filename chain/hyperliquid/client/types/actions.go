@@ -0,0 +1,297 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	ActionWithdraw         = "withdraw3"
+	ActionUsdClassTransfer = "usdClassTransfer"
+	ActionVaultTransfer    = "vaultTransfer"
+	ActionSendAsset        = "sendAsset"
+	ActionApproveAgent     = "approveAgent"
+)
+
+// Vault transfers are denominated in micro-USD
+const VaultUsdDecimals = 6
+
+var eip712DomainType = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+}
+
+// userSignedTypedData returns the typed data for actions signed directly by the user, e.g. "HyperliquidTransaction:Withdraw"
+func userSignedTypedData(signatureChainId string, primaryType string, fields []apitypes.Type, message apitypes.TypedDataMessage) (apitypes.TypedData, error) {
+	chainId, err := strconv.ParseInt(signatureChainId, 0, 64)
+	if err != nil {
+		return apitypes.TypedData{}, fmt.Errorf("invalid signature chain id %q: %w", signatureChainId, err)
+	}
+	hexChainId := math.HexOrDecimal256(*big.NewInt(chainId))
+	return apitypes.TypedData{
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           &hexChainId,
+			Name:              "HyperliquidSignTransaction",
+			Version:           "1",
+			VerifyingContract: "0x0000000000000000000000000000000000000000",
+		},
+		Types: apitypes.Types{
+			primaryType:    fields,
+			"EIP712Domain": eip712DomainType,
+		},
+		PrimaryType: primaryType,
+		Message:     message,
+	}, nil
+}
+
+// l1TypedData returns the typed data for L1 actions, which sign a "phantom agent" committing to the action hash
+func l1TypedData(action Action, hyperliquidChain string) (apitypes.TypedData, error) {
+	actionHash, err := GetActionHash(action)
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+	source := "b"
+	if hyperliquidChain == "Mainnet" {
+		source = "a"
+	}
+	hexChainId := math.HexOrDecimal256(*big.NewInt(1337))
+	return apitypes.TypedData{
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           &hexChainId,
+			Name:              "Exchange",
+			Version:           "1",
+			VerifyingContract: "0x0000000000000000000000000000000000000000",
+		},
+		Types: apitypes.Types{
+			"Agent": []apitypes.Type{
+				{Name: "source", Type: "string"},
+				{Name: "connectionId", Type: "bytes32"},
+			},
+			"EIP712Domain": eip712DomainType,
+		},
+		PrimaryType: "Agent",
+		Message: apitypes.TypedDataMessage{
+			"source":       source,
+			"connectionId": actionHash,
+		},
+	}, nil
+}
+
+// Withdraw bridges USDC from the perps account to Arbitrum
+type Withdraw struct {
+	Type             string `json:"type"             msgpack:"type"`
+	SignatureChainId string `json:"signatureChainId" msgpack:"signatureChainId"`
+	HyperliquidChain string `json:"hyperliquidChain" msgpack:"hyperliquidChain"`
+	Destination      string `json:"destination"      msgpack:"destination"`
+	Amount           string `json:"amount"           msgpack:"amount"`
+	Time             uint64 `json:"time"             msgpack:"time"`
+}
+
+func (s Withdraw) GetTime() uint64 {
+	return s.Time
+}
+
+func (s Withdraw) GetDestination() xc.Address {
+	return xc.Address(s.Destination)
+}
+
+func (s Withdraw) GetAmount() (xc.AmountHumanReadable, error) {
+	return xc.NewAmountHumanReadableFromStr(s.Amount)
+}
+
+func (s Withdraw) GetTypedData() (apitypes.TypedData, error) {
+	return userSignedTypedData(s.SignatureChainId, "HyperliquidTransaction:Withdraw", []apitypes.Type{
+		{Name: "hyperliquidChain", Type: "string"},
+		{Name: "destination", Type: "string"},
+		{Name: "amount", Type: "string"},
+		{Name: "time", Type: "uint64"},
+	}, apitypes.TypedDataMessage{
+		"hyperliquidChain": s.HyperliquidChain,
+		"destination":      s.Destination,
+		"amount":           s.Amount,
+		"time":             new(big.Int).SetUint64(s.Time),
+	})
+}
+
+// UsdClassTransfer moves USDC between the spot and perps accounts of a user
+type UsdClassTransfer struct {
+	Type             string `json:"type"             msgpack:"type"`
+	SignatureChainId string `json:"signatureChainId" msgpack:"signatureChainId"`
+	HyperliquidChain string `json:"hyperliquidChain" msgpack:"hyperliquidChain"`
+	// May be suffixed with " subaccount:<address>" to transfer within a sub-account
+	Amount string `json:"amount" msgpack:"amount"`
+	ToPerp bool   `json:"toPerp" msgpack:"toPerp"`
+	Nonce  uint64 `json:"nonce"  msgpack:"nonce"`
+}
+
+func (s UsdClassTransfer) GetTime() uint64 {
+	return s.Nonce
+}
+
+// GetSubAccount returns the sub-account the transfer is made within, if any
+func (s UsdClassTransfer) GetSubAccount() (xc.Address, bool) {
+	_, subAccount, ok := strings.Cut(s.Amount, " subaccount:")
+	return xc.Address(subAccount), ok
+}
+
+// The transfer stays within the user's account
+func (s UsdClassTransfer) GetDestination() xc.Address {
+	return ""
+}
+
+func (s UsdClassTransfer) GetAmount() (xc.AmountHumanReadable, error) {
+	amount, _, _ := strings.Cut(s.Amount, " ")
+	return xc.NewAmountHumanReadableFromStr(amount)
+}
+
+func (s UsdClassTransfer) GetTypedData() (apitypes.TypedData, error) {
+	return userSignedTypedData(s.SignatureChainId, "HyperliquidTransaction:UsdClassTransfer", []apitypes.Type{
+		{Name: "hyperliquidChain", Type: "string"},
+		{Name: "amount", Type: "string"},
+		{Name: "toPerp", Type: "bool"},
+		{Name: "nonce", Type: "uint64"},
+	}, apitypes.TypedDataMessage{
+		"hyperliquidChain": s.HyperliquidChain,
+		"amount":           s.Amount,
+		"toPerp":           s.ToPerp,
+		"nonce":            new(big.Int).SetUint64(s.Nonce),
+	})
+}
+
+// VaultTransfer deposits to or withdraws from a vault.  This is an L1 action, so the nonce
+// is not part of the action and is not reported by the explorer.
+type VaultTransfer struct {
+	Type         string `json:"type"         msgpack:"type"`
+	VaultAddress string `json:"vaultAddress" msgpack:"vaultAddress"`
+	IsDeposit    bool   `json:"isDeposit"    msgpack:"isDeposit"`
+	// micro-USD
+	Usd uint64 `json:"usd" msgpack:"usd"`
+
+	Nonce            uint64 `json:"-" msgpack:"-"`
+	HyperliquidChain string `json:"-" msgpack:"-"`
+}
+
+func (s VaultTransfer) GetTime() uint64 {
+	return s.Nonce
+}
+
+func (s VaultTransfer) GetDestination() xc.Address {
+	return xc.Address(s.VaultAddress)
+}
+
+func (s VaultTransfer) GetAmount() (xc.AmountHumanReadable, error) {
+	usd := xc.NewAmountBlockchainFromUint64(s.Usd)
+	return usd.ToHuman(VaultUsdDecimals), nil
+}
+
+func (s VaultTransfer) GetTypedData() (apitypes.TypedData, error) {
+	return l1TypedData(s, s.HyperliquidChain)
+}
+
+// SendAsset sends a token between dexes (the perps dex is "", the spot dex is "spot"), users and sub-accounts
+type SendAsset struct {
+	Type             string `json:"type"             msgpack:"type"`
+	SignatureChainId string `json:"signatureChainId" msgpack:"signatureChainId"`
+	HyperliquidChain string `json:"hyperliquidChain" msgpack:"hyperliquidChain"`
+	Destination      string `json:"destination"      msgpack:"destination"`
+	SourceDex        string `json:"sourceDex"        msgpack:"sourceDex"`
+	DestinationDex   string `json:"destinationDex"   msgpack:"destinationDex"`
+	Token            string `json:"token"            msgpack:"token"`
+	Amount           string `json:"amount"           msgpack:"amount"`
+	FromSubAccount   string `json:"fromSubAccount"   msgpack:"fromSubAccount"`
+	Nonce            uint64 `json:"nonce"            msgpack:"nonce"`
+}
+
+func (s SendAsset) GetTime() uint64 {
+	return s.Nonce
+}
+
+func (s SendAsset) GetDestination() xc.Address {
+	return xc.Address(s.Destination)
+}
+
+func (s SendAsset) GetAmount() (xc.AmountHumanReadable, error) {
+	return xc.NewAmountHumanReadableFromStr(s.Amount)
+}
+
+func (s SendAsset) GetTypedData() (apitypes.TypedData, error) {
+	return userSignedTypedData(s.SignatureChainId, "HyperliquidTransaction:SendAsset", []apitypes.Type{
+		{Name: "hyperliquidChain", Type: "string"},
+		{Name: "destination", Type: "string"},
+		{Name: "sourceDex", Type: "string"},
+		{Name: "destinationDex", Type: "string"},
+		{Name: "token", Type: "string"},
+		{Name: "amount", Type: "string"},
+		{Name: "fromSubAccount", Type: "string"},
+		{Name: "nonce", Type: "uint64"},
+	}, apitypes.TypedDataMessage{
+		"hyperliquidChain": s.HyperliquidChain,
+		"destination":      s.Destination,
+		"sourceDex":        s.SourceDex,
+		"destinationDex":   s.DestinationDex,
+		"token":            s.Token,
+		"amount":           s.Amount,
+		"fromSubAccount":   s.FromSubAccount,
+		"nonce":            new(big.Int).SetUint64(s.Nonce),
+	})
+}
+
+// ApproveAgent authorizes an agent (API wallet) to sign L1 actions on behalf of the user
+type ApproveAgent struct {
+	Type             string `json:"type"             msgpack:"type"`
+	SignatureChainId string `json:"signatureChainId" msgpack:"signatureChainId"`
+	HyperliquidChain string `json:"hyperliquidChain" msgpack:"hyperliquidChain"`
+	AgentAddress     string `json:"agentAddress"     msgpack:"agentAddress"`
+	AgentName        string `json:"agentName"        msgpack:"agentName"`
+	Nonce            uint64 `json:"nonce"            msgpack:"nonce"`
+}
+
+func (s ApproveAgent) GetTime() uint64 {
+	return s.Nonce
+}
+
+func (s ApproveAgent) GetDestination() xc.Address {
+	return xc.Address(s.AgentAddress)
+}
+
+// Approving an agent does not move any funds
+func (s ApproveAgent) GetAmount() (xc.AmountHumanReadable, error) {
+	return xc.NewAmountHumanReadableFromStr("0")
+}
+
+func (s ApproveAgent) GetTypedData() (apitypes.TypedData, error) {
+	return userSignedTypedData(s.SignatureChainId, "HyperliquidTransaction:ApproveAgent", []apitypes.Type{
+		{Name: "hyperliquidChain", Type: "string"},
+		{Name: "agentAddress", Type: "address"},
+		{Name: "agentName", Type: "string"},
+		{Name: "nonce", Type: "uint64"},
+	}, apitypes.TypedDataMessage{
+		"hyperliquidChain": s.HyperliquidChain,
+		"agentAddress":     s.AgentAddress,
+		"agentName":        s.AgentName,
+		"nonce":            new(big.Int).SetUint64(s.Nonce),
+	})
+}
+
+// decodeAction decodes the explorer's action into the given action type
+func decodeAction[T Action](t Transaction) (T, error) {
+	var action T
+	bz, err := json.Marshal(t.Action)
+	if err != nil {
+		return action, err
+	}
+	if err := json.Unmarshal(bz, &action); err != nil {
+		actionType, _ := t.GetType()
+		return action, fmt.Errorf("failed to decode %s action: %w", actionType, err)
+	}
+	return action, nil
+}
@@ -21,6 +21,8 @@ const (
 	ActionUsdSend  = "usdSend"
 )
 
+var ErrUnsupportedAction = errors.New("unsupported action")
+
 type SpotClearinghouseState struct {
 	Balances []SpotBalance `json:"balances"`
 }
@@ -279,6 +281,17 @@ type Transaction struct {
 	Block  uint64         `json:"block"`
 	Hash   string         `json:"hash"`
 	Error  string         `json:"error"`
+	// Nonce of the signed request, if reported
+	Nonce uint64 `json:"nonce,omitempty"`
+}
+
+// GetNonce returns the nonce the action was signed with.  Clients use the time in milliseconds
+// as the nonce, so the time is used if the nonce is not reported.
+func (t Transaction) GetNonce() uint64 {
+	if t.Nonce != 0 {
+		return t.Nonce
+	}
+	return uint64(t.Time)
 }
 
 func GetValue[T any](m map[string]any, key string) (T, bool) {
@@ -427,7 +440,23 @@ func (t Transaction) GetAction() (Action, error) {
 		return usdSend, err
 	}
 
-	return nil, errors.New("only spotSend and usdSend actions are suppoted for now")
+	switch actionType {
+	case ActionWithdraw:
+		return decodeAction[Withdraw](t)
+	case ActionUsdClassTransfer:
+		return decodeAction[UsdClassTransfer](t)
+	case ActionVaultTransfer:
+		action, err := decodeAction[VaultTransfer](t)
+		// L1 actions do not include their nonce, which is needed for the action hash
+		action.Nonce = t.GetNonce()
+		return action, err
+	case ActionSendAsset:
+		return decodeAction[SendAsset](t)
+	case ActionApproveAgent:
+		return decodeAction[ApproveAgent](t)
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedAction, actionType)
 }
 
 func GetActionHash(action Action) (string, error) {
@@ -0,0 +1,114 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/client/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionGetAction(t *testing.T) {
+	vectors := []struct {
+		name     string
+		action   string
+		expected types.Action
+		err      string
+	}{
+		{
+			name:   "withdraw3",
+			action: `{"type":"withdraw3","signatureChainId":"0xa4b1","hyperliquidChain":"Mainnet","destination":"0x5e9ee1089755c3435139848e47e6635505d5a13a","amount":"10.5","time":1758098790757}`,
+			expected: types.Withdraw{
+				Type:             "withdraw3",
+				SignatureChainId: "0xa4b1",
+				HyperliquidChain: "Mainnet",
+				Destination:      "0x5e9ee1089755c3435139848e47e6635505d5a13a",
+				Amount:           "10.5",
+				Time:             1758098790757,
+			},
+		},
+		{
+			name:   "usdClassTransfer",
+			action: `{"type":"usdClassTransfer","signatureChainId":"0xa4b1","hyperliquidChain":"Mainnet","amount":"1 subaccount:0x1111111111111111111111111111111111111111","toPerp":false,"nonce":1758098790757}`,
+			expected: types.UsdClassTransfer{
+				Type:             "usdClassTransfer",
+				SignatureChainId: "0xa4b1",
+				HyperliquidChain: "Mainnet",
+				Amount:           "1 subaccount:0x1111111111111111111111111111111111111111",
+				Nonce:            1758098790757,
+			},
+		},
+		{
+			name:   "vaultTransfer",
+			action: `{"type":"vaultTransfer","vaultAddress":"0xdfc24b077bc1425ad1dea75bcb6f8158e10df303","isDeposit":true,"usd":5000000}`,
+			expected: types.VaultTransfer{
+				Type:         "vaultTransfer",
+				VaultAddress: "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303",
+				IsDeposit:    true,
+				Usd:          5000000,
+			},
+		},
+		{
+			name:   "sendAsset",
+			action: `{"type":"sendAsset","signatureChainId":"0xa4b1","hyperliquidChain":"Mainnet","destination":"0x21db009054831a7fd8914f544f749180630ce217","sourceDex":"spot","destinationDex":"","token":"HYPE:0x0d01dc56dcaaca66ad901c959b4011ec","amount":"0.1","fromSubAccount":"","nonce":1758098790757}`,
+			expected: types.SendAsset{
+				Type:             "sendAsset",
+				SignatureChainId: "0xa4b1",
+				HyperliquidChain: "Mainnet",
+				Destination:      "0x21db009054831a7fd8914f544f749180630ce217",
+				SourceDex:        "spot",
+				Token:            "HYPE:0x0d01dc56dcaaca66ad901c959b4011ec",
+				Amount:           "0.1",
+				Nonce:            1758098790757,
+			},
+		},
+		{
+			name:   "approveAgent",
+			action: `{"type":"approveAgent","signatureChainId":"0xa4b1","hyperliquidChain":"Mainnet","agentAddress":"0x21db009054831a7fd8914f544f749180630ce217","agentName":"rebalancer","nonce":1758098790757}`,
+			expected: types.ApproveAgent{
+				Type:             "approveAgent",
+				SignatureChainId: "0xa4b1",
+				HyperliquidChain: "Mainnet",
+				AgentAddress:     "0x21db009054831a7fd8914f544f749180630ce217",
+				AgentName:        "rebalancer",
+				Nonce:            1758098790757,
+			},
+		},
+		{
+			name:   "order",
+			action: `{"type":"order","orders":[],"grouping":"na"}`,
+			err:    "unsupported action: order",
+		},
+	}
+
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			tx := types.Transaction{}
+			require.NoError(t, json.Unmarshal([]byte(v.action), &tx.Action))
+
+			action, err := tx.GetAction()
+			if v.err != "" {
+				require.ErrorIs(t, err, types.ErrUnsupportedAction)
+				require.ErrorContains(t, err, v.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, v.expected, action)
+		})
+	}
+}
+
+func TestUsdClassTransferSubAccount(t *testing.T) {
+	transfer := types.UsdClassTransfer{Amount: "1.5 subaccount:0x1111111111111111111111111111111111111111"}
+	subAccount, ok := transfer.GetSubAccount()
+	require.True(t, ok)
+	require.Equal(t, xc.Address("0x1111111111111111111111111111111111111111"), subAccount)
+
+	amount, err := transfer.GetAmount()
+	require.NoError(t, err)
+	require.Equal(t, "1.5", amount.String())
+
+	_, ok = types.UsdClassTransfer{Amount: "1.5"}.GetSubAccount()
+	require.False(t, ok)
+}
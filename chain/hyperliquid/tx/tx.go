@@ -36,6 +36,8 @@ type Tx struct {
 	Nonce            uint64
 	HyperliquidChain string
 	Signature        SignatureResult
+	// Set for actions other than spot and usd sends
+	Action types.Action
 }
 
 var _ xc.Tx = &Tx{}
@@ -58,7 +60,18 @@ func NewTx(args xcbuilder.TransferArgs, input tx_input.TxInput, decimals int) Tx
 	}
 }
 
+// NewActionTx creates a tx for an action, using its time or nonce as the tx nonce
+func NewActionTx(action types.Action) Tx {
+	return Tx{
+		Action: action,
+		Nonce:  action.GetTime(),
+	}
+}
+
 func (tx Tx) GetAction() types.Action {
+	if tx.Action != nil {
+		return tx.Action
+	}
 	amount := tx.Amount.ToHuman(tx.Decimals)
 
 	if len(tx.Token) == 0 {
@@ -2,10 +2,15 @@ package tx_test
 
 import (
 	"encoding/hex"
+	"math/big"
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/hyperliquid/client/types"
 	"github.com/cordialsys/crosschain/chain/hyperliquid/tx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -71,3 +76,178 @@ func TestTxAddSignature(t *testing.T) {
 	}
 	require.Equal(t, expectedSignature, tx1.Signature)
 }
+
+func word(value uint64) []byte {
+	return common.LeftPadBytes(new(big.Int).SetUint64(value).Bytes(), 32)
+}
+
+func keccakString(value string) []byte {
+	return crypto.Keccak256([]byte(value))
+}
+
+// eip712Hash independently encodes the typed data hash signed for an action
+func eip712Hash(domainName string, chainId uint64, typeString string, fields ...[]byte) []byte {
+	domainType := crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	domain := crypto.Keccak256(domainType, keccakString(domainName), keccakString("1"), word(chainId), word(0))
+	structHash := crypto.Keccak256(append([][]byte{crypto.Keccak256([]byte(typeString))}, fields...)...)
+	return crypto.Keccak256([]byte{0x19, 0x01}, domain, structHash)
+}
+
+func TestActionSighashes(t *testing.T) {
+	agent := common.HexToAddress("0x5e9ee1089755c3435139848e47e6635505d5a13a")
+
+	vectors := []struct {
+		name     string
+		action   types.Action
+		expected []byte
+	}{
+		{
+			name: "withdraw3",
+			action: types.Withdraw{
+				Type:             types.ActionWithdraw,
+				SignatureChainId: tx.SignatureChainId,
+				HyperliquidChain: "Mainnet",
+				Destination:      "0x5e9ee1089755c3435139848e47e6635505d5a13a",
+				Amount:           "10.5",
+				Time:             1758098790757,
+			},
+			expected: eip712Hash("HyperliquidSignTransaction", 42161,
+				"HyperliquidTransaction:Withdraw(string hyperliquidChain,string destination,string amount,uint64 time)",
+				keccakString("Mainnet"), keccakString("0x5e9ee1089755c3435139848e47e6635505d5a13a"), keccakString("10.5"), word(1758098790757),
+			),
+		},
+		{
+			name: "usdClassTransfer",
+			action: types.UsdClassTransfer{
+				Type:             types.ActionUsdClassTransfer,
+				SignatureChainId: tx.SignatureChainId,
+				HyperliquidChain: "Testnet",
+				Amount:           "1",
+				ToPerp:           true,
+				Nonce:            1758098790757,
+			},
+			expected: eip712Hash("HyperliquidSignTransaction", 42161,
+				"HyperliquidTransaction:UsdClassTransfer(string hyperliquidChain,string amount,bool toPerp,uint64 nonce)",
+				keccakString("Testnet"), keccakString("1"), word(1), word(1758098790757),
+			),
+		},
+		{
+			name: "sendAsset",
+			action: types.SendAsset{
+				Type:             types.ActionSendAsset,
+				SignatureChainId: tx.SignatureChainId,
+				HyperliquidChain: "Mainnet",
+				Destination:      "0x21db009054831a7fd8914f544f749180630ce217",
+				SourceDex:        "",
+				DestinationDex:   "spot",
+				Token:            "USDC:0x6d1e7cde53ba9467b783cb7c530ce054",
+				Amount:           "2",
+				FromSubAccount:   "",
+				Nonce:            1758098790757,
+			},
+			expected: eip712Hash("HyperliquidSignTransaction", 42161,
+				"HyperliquidTransaction:SendAsset(string hyperliquidChain,string destination,string sourceDex,string destinationDex,string token,string amount,string fromSubAccount,uint64 nonce)",
+				keccakString("Mainnet"), keccakString("0x21db009054831a7fd8914f544f749180630ce217"), keccakString(""), keccakString("spot"),
+				keccakString("USDC:0x6d1e7cde53ba9467b783cb7c530ce054"), keccakString("2"), keccakString(""), word(1758098790757),
+			),
+		},
+		{
+			name: "approveAgent",
+			action: types.ApproveAgent{
+				Type:             types.ActionApproveAgent,
+				SignatureChainId: tx.SignatureChainId,
+				HyperliquidChain: "Mainnet",
+				AgentAddress:     agent.Hex(),
+				AgentName:        "rebalancer",
+				Nonce:            1758098790757,
+			},
+			expected: eip712Hash("HyperliquidSignTransaction", 42161,
+				"HyperliquidTransaction:ApproveAgent(string hyperliquidChain,address agentAddress,string agentName,uint64 nonce)",
+				keccakString("Mainnet"), common.LeftPadBytes(agent.Bytes(), 32), keccakString("rebalancer"), word(1758098790757),
+			),
+		},
+	}
+
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			actionTx := tx.NewActionTx(v.action)
+			require.EqualValues(t, 1758098790757, actionTx.Nonce)
+
+			sighashes, err := actionTx.Sighashes()
+			require.NoError(t, err)
+			require.Len(t, sighashes, 1)
+			require.Equal(t, v.expected, sighashes[0].Payload)
+		})
+	}
+}
+
+func TestVaultTransferSighash(t *testing.T) {
+	action := types.VaultTransfer{
+		Type:             types.ActionVaultTransfer,
+		VaultAddress:     "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303",
+		IsDeposit:        true,
+		Usd:              5_000_000,
+		Nonce:            1758098790757,
+		HyperliquidChain: "Mainnet",
+	}
+	actionTx := tx.NewActionTx(action)
+
+	// msgpack encoding of the action, followed by the nonce and the (absent) vault address
+	packed := []byte{0x84}
+	packed = append(packed, 0xa4)
+	packed = append(packed, "type"...)
+	packed = append(packed, 0xad)
+	packed = append(packed, "vaultTransfer"...)
+	packed = append(packed, 0xac)
+	packed = append(packed, "vaultAddress"...)
+	packed = append(packed, 0xd9, 42)
+	packed = append(packed, "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303"...)
+	packed = append(packed, 0xa9)
+	packed = append(packed, "isDeposit"...)
+	packed = append(packed, 0xc3)
+	packed = append(packed, 0xa3)
+	packed = append(packed, "usd"...)
+	packed = append(packed, 0xce, 0x00, 0x4c, 0x4b, 0x40)
+	packed = append(packed, word(1758098790757)[24:]...)
+	packed = append(packed, 0x00)
+	connectionId := crypto.Keccak256(packed)
+	require.Equal(t, xc.TxHash(hexutil.Encode(connectionId)), actionTx.Hash())
+
+	// L1 actions are signed by a "phantom agent" committing to the action
+	expected := eip712Hash("Exchange", 1337, "Agent(string source,bytes32 connectionId)", keccakString("a"), connectionId)
+	sighashes, err := actionTx.Sighashes()
+	require.NoError(t, err)
+	require.Equal(t, expected, sighashes[0].Payload)
+
+	// testnet uses a different source
+	action.HyperliquidChain = "Testnet"
+	sighashes, err = tx.NewActionTx(action).Sighashes()
+	require.NoError(t, err)
+	require.Equal(t, eip712Hash("Exchange", 1337, "Agent(string source,bytes32 connectionId)", keccakString("b"), connectionId), sighashes[0].Payload)
+}
+
+func TestActionSerialize(t *testing.T) {
+	actionTx := tx.NewActionTx(types.VaultTransfer{
+		Type:             types.ActionVaultTransfer,
+		VaultAddress:     "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303",
+		IsDeposit:        false,
+		Usd:              1_500_000,
+		Nonce:            1758098790757,
+		HyperliquidChain: "Mainnet",
+	})
+	signature, err := hex.DecodeString("72de6a68969db4d59796116554584b4c5f6b80ac39f49008a83819c726971356050ac27403b9c85ee640c91c838fe6d779f806053a8ebcd663d08bffe8ea2c5001")
+	require.NoError(t, err)
+	require.NoError(t, actionTx.SetSignatures(&xc.SignatureResponse{Signature: signature}))
+
+	bz, err := actionTx.Serialize()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"action": {"type": "vaultTransfer", "vaultAddress": "0xdfc24b077bc1425ad1dea75bcb6f8158e10df303", "isDeposit": false, "usd": 1500000},
+		"nonce": 1758098790757,
+		"signature": {
+			"r": "0x72de6a68969db4d59796116554584b4c5f6b80ac39f49008a83819c726971356",
+			"s": "0x50ac27403b9c85ee640c91c838fe6d779f806053a8ebcd663d08bffe8ea2c50",
+			"v": 28
+		}
+	}`, string(bz))
+}
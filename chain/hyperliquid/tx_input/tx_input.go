@@ -8,6 +8,7 @@ import (
 )
 
 const UsdcTokenId = "USDC:0x6d1e7cde53ba9467b783cb7c530ce054"
+const UsdcDecimals = 8
 const SafetyTimeoutMargin = (48 * time.Hour)
 
 // TxInput for Hyperliquid
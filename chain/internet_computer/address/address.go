@@ -108,10 +108,15 @@ func DerEncodePublicKey(publicKey []byte) ([]byte, error) {
 }
 
 func NewAccountId(principal []byte) AccountId {
+	return NewAccountIdWithSubaccount(principal, DefaultPrincipalSubaccount)
+}
+
+// NewAccountIdWithSubaccount returns the ICP ledger account of a principal's subaccount
+func NewAccountIdWithSubaccount(principal []byte, subaccount [32]byte) AccountId {
 	h := sha256.New224()
 	h.Write([]byte("\x0Aaccount-id"))
 	h.Write(principal)
-	h.Write(DefaultPrincipalSubaccount[:])
+	h.Write(subaccount[:])
 	bs := h.Sum(nil)
 
 	var accountId [28]byte
@@ -0,0 +1,130 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/internet_computer/address"
+	"github.com/cordialsys/crosschain/chain/internet_computer/candid/idl"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icp"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icrc"
+	"github.com/cordialsys/crosschain/chain/internet_computer/tx"
+	"github.com/cordialsys/crosschain/chain/internet_computer/tx_input"
+)
+
+var _ xcbuilder.Approval = TxBuilder{}
+
+// TransferFromArgs transfers tokens that Owner has approved the spender to transfer
+type TransferFromArgs struct {
+	// The spender, which signs the transfer
+	From      xc.Address
+	PublicKey []byte
+	Owner     xc.Address
+	To        xc.Address
+	// ICRC-2 ledger, or the ICP ledger if empty
+	Contract xc.ContractAddress
+	Amount   xc.AmountBlockchain
+}
+
+// LedgerCanister returns the ledger canister of a contract, defaulting to the ICP ledger
+func LedgerCanister(contract xc.ContractAddress) (address.Principal, error) {
+	if contract == "" {
+		return icp.LedgerPrincipal, nil
+	}
+	canister, err := address.Decode(string(contract))
+	if err != nil {
+		return address.Principal{}, fmt.Errorf("failed to decode canister: %w", err)
+	}
+	return canister, nil
+}
+
+// Approve creates an ICRC-2 approval.  The expected allowance is always set, so the approval
+// fails if the allowance has changed since the input was fetched.
+func (txBuilder TxBuilder) Approve(args xcbuilder.ApprovalArgs, input xc.ApprovalTxInput) (xc.Tx, error) {
+	approvalInput, ok := input.(*tx_input.ApprovalInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	pubkey, ok := args.GetPublicKey()
+	if !ok {
+		return nil, errors.New("missing public key")
+	}
+	canister, err := LedgerCanister(args.GetContract())
+	if err != nil {
+		return nil, err
+	}
+	spender, err := icrc.DecodeAccount(string(args.GetSpender()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode spender icrc1 address: %w", err)
+	}
+
+	currentAllowance := approvalInput.CurrentAllowance
+	allowance := args.GetAmount()
+	switch args.GetAction() {
+	case xcbuilder.ApprovalActionIncrease:
+		allowance = allowance.Add(&currentAllowance)
+	case xcbuilder.ApprovalActionRevoke:
+		allowance = xc.NewAmountBlockchainFromUint64(0)
+	}
+
+	expectedAllowance := idl.NewBigNat(currentAllowance.Int())
+	fee := idl.NewNat(approvalInput.Fee)
+	createdAtTime := uint64(approvalInput.GetCreateTimeNanos())
+	approve := icrc.ApproveArgs{
+		FromSubaccount:    nil,
+		Spender:           spender,
+		Amount:            idl.NewBigNat(allowance.Int()),
+		ExpectedAllowance: &expectedAllowance,
+		ExpiresAt:         nil,
+		Fee:               &fee,
+		Memo:              approvalInput.ICRC1Memo,
+		CreatedAtTime:     &createdAtTime,
+	}
+	transaction, err := tx.NewApproveTx(pubkey, canister, approve, approvalInput.TxInput)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// TransferFrom creates an ICRC-2 transfer of the owner's tokens, signed by the spender
+func (txBuilder TxBuilder) TransferFrom(args TransferFromArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*tx_input.TxInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	if len(args.PublicKey) == 0 {
+		return nil, errors.New("missing public key")
+	}
+	canister, err := LedgerCanister(args.Contract)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := icrc.DecodeAccount(string(args.Owner))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode owner icrc1 address: %w", err)
+	}
+	to, err := icrc.DecodeAccount(string(args.To))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode destination icrc1 address: %w", err)
+	}
+
+	fee := idl.NewNat(txInput.Fee)
+	createdAtTime := uint64(txInput.GetCreateTimeNanos())
+	transfer := icrc.TransferFromArgs{
+		SpenderSubaccount: nil,
+		From:              owner,
+		To:                to,
+		Amount:            idl.NewBigNat(args.Amount.Int()),
+		Fee:               &fee,
+		Memo:              txInput.ICRC1Memo,
+		CreatedAtTime:     &createdAtTime,
+	}
+	transaction, err := tx.NewTransferFromTx(args.PublicKey, canister, transfer, *txInput)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
@@ -2,11 +2,20 @@ package builder_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"testing"
+	"time"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/builder/buildertest"
+	"github.com/cordialsys/crosschain/chain/internet_computer/address"
 	"github.com/cordialsys/crosschain/chain/internet_computer/builder"
+	"github.com/cordialsys/crosschain/chain/internet_computer/candid"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/governance"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icp"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icrc"
+	"github.com/cordialsys/crosschain/chain/internet_computer/tx"
 	"github.com/cordialsys/crosschain/chain/internet_computer/tx_input"
 	"github.com/stretchr/testify/require"
 )
@@ -106,3 +115,243 @@ func TestNewTokenTransfer(t *testing.T) {
 		"a367636f6e74656e74a763617267586b4449444c056d7b6e006c02b3b0dac30368ad86ca8305016e786c06fbca0102c6fcb6027dba89e5c20401a2de94eb060182f3f3910c03d8a38ca80d7d0104011db9264e4ed0eb963400b9b36bca08a5c52dc7d9903a409af7c84c6a32020000000001000000000000000000656e6f6e63654a000000000000000000006673656e646572581dc0e2fee0ef1f2663f31387eab530ba3ecfbcea1913c6ac0cab9cc1dd026b63616e69737465725f69644a000000000230000801016b6d6574686f645f6e616d656e69637263315f7472616e736665726c726571756573745f747970656463616c6c6e696e67726573735f6578706972791b00000045d964b8006d73656e6465725f7075626b657950300e300506032b6570030500010203046a73656e6465725f736967584000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
 		hex.EncodeToString(bz))
 }
+
+var testPublicKey = []byte{
+	0x6c, 0x50, 0x66, 0x26, 0x15, 0x53, 0x06, 0x4a, 0x8d, 0x4f, 0xa8, 0xf3, 0x0f, 0xa9, 0xd5, 0x87,
+	0xd9, 0x88, 0x7b, 0xce, 0x69, 0x60, 0x1c, 0xdb, 0x5b, 0x6c, 0xac, 0x87, 0x80, 0xfc, 0x88, 0x99,
+}
+
+func testPrincipal(t *testing.T, seed byte) address.Principal {
+	pubkey := make([]byte, 32)
+	pubkey[0] = seed
+	principal, err := address.NewEd25519Identity(pubkey).Principal()
+	require.NoError(t, err)
+	return principal
+}
+
+func decodeArgs(t *testing.T, transaction xc.Tx, out ...any) {
+	icpTx := transaction.(*tx.Tx)
+	err := candid.Unmarshal(icpTx.Request.Arguments, out)
+	require.NoError(t, err)
+}
+
+func TestApprove(t *testing.T) {
+	builder1, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.ICP).Base())
+	owner := testPrincipal(t, 1)
+	spender := testPrincipal(t, 2)
+	ckBTC := xc.ContractAddress("mxzaz-hqaaa-aaaar-qaada-cai")
+
+	for _, tc := range []struct {
+		action            xcbuilder.ApprovalAction
+		amount            uint64
+		expectedAllowance uint64
+	}{
+		{action: xcbuilder.ApprovalActionApprove, amount: 500, expectedAllowance: 500},
+		{action: xcbuilder.ApprovalActionIncrease, amount: 500, expectedAllowance: 600},
+		{action: xcbuilder.ApprovalActionRevoke, amount: 500, expectedAllowance: 0},
+	} {
+		t.Run(string(tc.action), func(t *testing.T) {
+			input := &tx_input.ApprovalInput{
+				TxInput:          TxInput{Fee: 10, CreateTime: 1700000000, Nonce: "0102"},
+				CurrentAllowance: xc.NewAmountBlockchainFromUint64(100),
+			}
+			args, err := xcbuilder.NewApprovalArgs(xc.ICP, tc.action, xc.Address(owner.String()), xc.Address(spender.String()), ckBTC,
+				xc.NewAmountBlockchainFromUint64(tc.amount), xcbuilder.OptionPublicKey(testPublicKey))
+			require.NoError(t, err)
+
+			transaction, err := builder1.Approve(args, input)
+			require.NoError(t, err)
+			icpTx := transaction.(*tx.Tx)
+			require.Equal(t, icrc.MethodApprove, icpTx.Request.MethodName)
+			require.Equal(t, string(ckBTC), icpTx.Request.CanisterID.String())
+			require.True(t, icpTx.IsIcrcTx)
+
+			var approve icrc.ApproveArgs
+			decodeArgs(t, transaction, &approve)
+			require.Equal(t, spender.String(), approve.Spender.Owner.String())
+			require.EqualValues(t, tc.expectedAllowance, approve.Amount.BigInt().Uint64())
+			require.EqualValues(t, 100, approve.ExpectedAllowance.BigInt().Uint64())
+			require.EqualValues(t, 10, approve.Fee.BigInt().Uint64())
+			require.EqualValues(t, 1700000000*uint64(time.Second), *approve.CreatedAtTime)
+			require.NotEmpty(t, transaction.Hash())
+		})
+	}
+
+	// The ICP ledger also supports approvals
+	input := &tx_input.ApprovalInput{TxInput: TxInput{Fee: 10000, CreateTime: 1700000000}}
+	args, err := xcbuilder.NewApprovalArgs(xc.ICP, xcbuilder.ApprovalActionApprove, xc.Address(owner.String()), xc.Address(spender.String()), xc.ContractAddress(icp.LedgerPrincipal.String()),
+		xc.NewAmountBlockchainFromUint64(1), xcbuilder.OptionPublicKey(testPublicKey))
+	require.NoError(t, err)
+	transaction, err := builder1.Approve(args, input)
+	require.NoError(t, err)
+	require.Equal(t, icp.LedgerPrincipal.String(), transaction.(*tx.Tx).Request.CanisterID.String())
+	require.False(t, transaction.(*tx.Tx).IsIcrcTx)
+	require.NotEmpty(t, transaction.Hash())
+}
+
+func TestTransferFrom(t *testing.T) {
+	builder1, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.ICP).Base())
+	owner := testPrincipal(t, 1)
+	to := testPrincipal(t, 3)
+	input := &TxInput{Fee: 10, CreateTime: 1700000000}
+
+	transaction, err := builder1.TransferFrom(builder.TransferFromArgs{
+		From:      "spender",
+		PublicKey: testPublicKey,
+		Owner:     xc.Address(owner.String()),
+		To:        xc.Address(to.String()),
+		Contract:  "mxzaz-hqaaa-aaaar-qaada-cai",
+		Amount:    xc.NewAmountBlockchainFromUint64(250),
+	}, input)
+	require.NoError(t, err)
+	require.Equal(t, icrc.MethodTransferFrom, transaction.(*tx.Tx).Request.MethodName)
+
+	var transfer icrc.TransferFromArgs
+	decodeArgs(t, transaction, &transfer)
+	require.Equal(t, owner.String(), transfer.From.Owner.String())
+	require.Equal(t, to.String(), transfer.To.Owner.String())
+	require.EqualValues(t, 250, transfer.Amount.BigInt().Uint64())
+	require.NotEmpty(t, transaction.Hash())
+
+	_, err = builder1.TransferFrom(builder.TransferFromArgs{
+		PublicKey: testPublicKey,
+		Owner:     "6c5066261553064a8d4fa8f30fa9d587d9887bce69601cdb5b6cac8780fc8899",
+		To:        xc.Address(to.String()),
+		Amount:    xc.NewAmountBlockchainFromUint64(250),
+	}, input)
+	require.ErrorContains(t, err, "failed to decode owner")
+}
+
+func TestStake(t *testing.T) {
+	builder1, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.ICP).Base())
+	controller, err := address.NewEd25519Identity(testPublicKey).Principal()
+	require.NoError(t, err)
+	input := &tx_input.StakingInput{
+		TxInput:    TxInput{Fee: 10000, CreateTime: 1700000000},
+		NeuronMemo: 3,
+	}
+
+	args, err := xcbuilder.NewStakeArgs(xc.ICP, "from",
+		xcbuilder.OptionStakeAmount(xc.NewAmountBlockchainFromUint64(200_000_000)),
+		xcbuilder.OptionPublicKey(testPublicKey),
+	)
+	require.NoError(t, err)
+	transaction, err := builder1.Stake(args, input)
+	require.NoError(t, err)
+	icpTx := transaction.(*tx.Tx)
+
+	// transfer to the neuron subaccount, then claim it
+	require.Equal(t, icp.MethodTransfer, icpTx.Request.MethodName)
+	require.EqualValues(t, governance.NeuronAccountId(controller, 3), icpTx.IcpTransfer.To)
+	require.EqualValues(t, 3, icpTx.IcpTransfer.Memo)
+	require.EqualValues(t, 200_000_000, icpTx.IcpTransfer.Amount.E8s)
+	require.Len(t, icpTx.FollowUps, 1)
+	claimRequest := icpTx.FollowUps[0].Request
+	require.Equal(t, governance.MethodManageNeuron, claimRequest.MethodName)
+	require.Equal(t, governance.GovernancePrincipal.String(), claimRequest.CanisterID.String())
+
+	var claim governance.ManageNeuron
+	require.NoError(t, candid.Unmarshal(claimRequest.Arguments, []any{&claim}))
+	require.NotNil(t, claim.Command.ClaimOrRefresh)
+	require.EqualValues(t, 3, claim.Command.ClaimOrRefresh.By.MemoAndController.Memo)
+	require.Equal(t, controller.String(), claim.Command.ClaimOrRefresh.By.MemoAndController.Controller.String())
+
+	sighashes, err := transaction.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 2)
+	require.ErrorContains(t, transaction.SetSignatures(&xc.SignatureResponse{Signature: make([]byte, 64)}), "expected 2 signatures")
+	err = transaction.SetSignatures(&xc.SignatureResponse{Signature: make([]byte, 64)}, &xc.SignatureResponse{Signature: make([]byte, 64)})
+	require.NoError(t, err)
+	require.NotEmpty(t, icpTx.FollowUps[0].SignedRequest)
+
+	metadataBz, ok, err := transaction.(xc.TxWithMetadata).GetMetadata()
+	require.NoError(t, err)
+	require.True(t, ok)
+	var metadata tx.BroadcastMetadata
+	require.NoError(t, json.Unmarshal(metadataBz, &metadata))
+	require.Equal(t, icp.MethodTransfer, metadata.Method)
+	require.Len(t, metadata.FollowUps, 1)
+	require.Equal(t, governance.MethodManageNeuron, metadata.FollowUps[0].Method)
+
+	// a new neuron needs at least 1 ICP
+	args, err = xcbuilder.NewStakeArgs(xc.ICP, "from",
+		xcbuilder.OptionStakeAmount(xc.NewAmountBlockchainFromUint64(10_000_000)),
+		xcbuilder.OptionPublicKey(testPublicKey),
+	)
+	require.NoError(t, err)
+	_, err = builder1.Stake(args, input)
+	require.ErrorContains(t, err, "at least 1")
+
+	// top up an existing neuron by its subaccount
+	subaccount := governance.NeuronSubaccount(controller, 0)
+	args, err = xcbuilder.NewStakeArgs(xc.ICP, "from",
+		xcbuilder.OptionStakeAmount(xc.NewAmountBlockchainFromUint64(10_000_000)),
+		xcbuilder.OptionPublicKey(testPublicKey),
+		xcbuilder.OptionStakeAccount(hex.EncodeToString(subaccount[:])),
+	)
+	require.NoError(t, err)
+	transaction, err = builder1.Stake(args, input)
+	require.NoError(t, err)
+	icpTx = transaction.(*tx.Tx)
+	require.EqualValues(t, governance.NeuronAccountId(controller, 0), icpTx.IcpTransfer.To)
+	var refresh governance.ManageNeuron
+	require.NoError(t, candid.Unmarshal(icpTx.FollowUps[0].Request.Arguments, []any{&refresh}))
+	require.NotNil(t, refresh.Command.ClaimOrRefresh.By.NeuronIdOrSubaccount)
+	require.Equal(t, subaccount[:], *refresh.NeuronIdOrSubaccount.Subaccount)
+}
+
+func TestManageNeuron(t *testing.T) {
+	builder1, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.ICP).Base())
+	input := TxInput{Fee: 10000, CreateTime: 1700000000}
+	args, err := xcbuilder.NewStakeArgs(xc.ICP, "from",
+		xcbuilder.OptionPublicKey(testPublicKey),
+		xcbuilder.OptionStakeAccount("1234"),
+	)
+	require.NoError(t, err)
+
+	manageNeuron := func(transaction xc.Tx) governance.ManageNeuron {
+		icpTx := transaction.(*tx.Tx)
+		require.Equal(t, governance.MethodManageNeuron, icpTx.Request.MethodName)
+		require.Empty(t, icpTx.FollowUps)
+		var manage governance.ManageNeuron
+		decodeArgs(t, transaction, &manage)
+		require.EqualValues(t, 1234, manage.NeuronIdOrSubaccount.NeuronId.Id)
+		return manage
+	}
+
+	transaction, err := builder1.Unstake(args, &tx_input.UnstakingInput{TxInput: input})
+	require.NoError(t, err)
+	require.NotNil(t, manageNeuron(transaction).Command.Configure.Operation.StartDissolving)
+
+	transaction, err = builder1.Withdraw(args, &tx_input.WithdrawInput{TxInput: input})
+	require.NoError(t, err)
+	controller, err := address.NewEd25519Identity(testPublicKey).Principal()
+	require.NoError(t, err)
+	disburse := manageNeuron(transaction).Command.Disburse
+	require.EqualValues(t, address.NewAccountId(controller.Raw), disburse.ToAccount.Hash)
+	require.Nil(t, disburse.Amount)
+
+	neuronArgs := builder.NeuronArgs{From: "from", PublicKey: testPublicKey, Neuron: "1234"}
+	transaction, err = builder1.StopDissolving(neuronArgs, &input)
+	require.NoError(t, err)
+	require.NotNil(t, manageNeuron(transaction).Command.Configure.Operation.StopDissolving)
+
+	transaction, err = builder1.SetDissolveDelay(builder.SetDissolveDelayArgs{
+		NeuronArgs:    neuronArgs,
+		DissolveDelay: 365 * 24 * time.Hour,
+	}, &tx_input.NeuronInput{TxInput: input, DissolveDelaySeconds: 7 * 24 * 3600})
+	require.NoError(t, err)
+	increase := manageNeuron(transaction).Command.Configure.Operation.IncreaseDissolveDelay
+	require.EqualValues(t, 358*24*3600, increase.AdditionalDissolveDelaySeconds)
+
+	_, err = builder1.SetDissolveDelay(builder.SetDissolveDelayArgs{
+		NeuronArgs:    neuronArgs,
+		DissolveDelay: 24 * time.Hour,
+	}, &tx_input.NeuronInput{TxInput: input, DissolveDelaySeconds: 7 * 24 * 3600})
+	require.ErrorContains(t, err, "can only be increased")
+
+	args, err = xcbuilder.NewStakeArgs(xc.ICP, "from", xcbuilder.OptionPublicKey(testPublicKey))
+	require.NoError(t, err)
+	_, err = builder1.Unstake(args, &tx_input.UnstakingInput{TxInput: input})
+	require.ErrorContains(t, err, "stake account")
+}
@@ -0,0 +1,194 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	buildererrors "github.com/cordialsys/crosschain/builder/errors"
+	"github.com/cordialsys/crosschain/chain/internet_computer/address"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/governance"
+	"github.com/cordialsys/crosschain/chain/internet_computer/tx"
+	"github.com/cordialsys/crosschain/chain/internet_computer/tx_input"
+)
+
+var _ xcbuilder.Staking = TxBuilder{}
+
+// NeuronArgs identifies a neuron controlled by From
+type NeuronArgs struct {
+	From      xc.Address
+	PublicKey []byte
+	// Hex encoded governance subaccount, or decimal id, of the neuron
+	Neuron string
+}
+
+// SetDissolveDelayArgs increases the dissolve delay of a neuron
+type SetDissolveDelayArgs struct {
+	NeuronArgs
+	DissolveDelay time.Duration
+}
+
+// Stake creates a new neuron, or tops up the neuron given as the stake account.  The ICP is
+// transferred to the neuron's governance subaccount, and then the neuron is claimed or refreshed.
+func (txBuilder TxBuilder) Stake(args xcbuilder.StakeArgs, input xc.StakeTxInput) (xc.Tx, error) {
+	stakingInput, ok := input.(*tx_input.StakingInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	pubkey, ok := args.GetPublicKey()
+	if !ok {
+		return nil, errors.New("missing public key")
+	}
+	amount, ok := args.GetAmount()
+	if !ok {
+		return nil, buildererrors.ErrStakingAmountRequired
+	}
+
+	if account, ok := args.GetStakeAccount(); ok {
+		neuron, err := governance.ParseNeuron(account)
+		if err != nil {
+			return nil, err
+		}
+		subaccount, ok := neuron.GetSubaccount()
+		if !ok {
+			return nil, errors.New("the neuron must be given by its subaccount to top it up")
+		}
+		refresh := governance.NewRefresh()
+		transaction, err := tx.NewStakeTx(pubkey, subaccount, amount, 0, governance.ManageNeuron{
+			Command:              &refresh,
+			NeuronIdOrSubaccount: &neuron,
+		}, stakingInput.TxInput)
+		if err != nil {
+			return nil, err
+		}
+		return &transaction, nil
+	}
+
+	minimum := xc.NewAmountBlockchainFromUint64(governance.MinimumStakeE8s)
+	if amount.Cmp(&minimum) < 0 {
+		return nil, fmt.Errorf("a new neuron requires a stake of at least %s", minimum.ToHuman(8).String())
+	}
+	controller, err := address.NewEd25519Identity(pubkey).Principal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get controller principal: %w", err)
+	}
+	memo := stakingInput.NeuronMemo
+	claim := governance.NewClaimOrRefresh(controller, memo)
+	transaction, err := tx.NewStakeTx(pubkey, governance.NeuronSubaccount(controller, memo), amount, memo, governance.ManageNeuron{
+		Command: &claim,
+	}, stakingInput.TxInput)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// Unstake starts dissolving the neuron given as the stake account.  The whole neuron dissolves, so the amount is not used.
+func (txBuilder TxBuilder) Unstake(args xcbuilder.StakeArgs, input xc.UnstakeTxInput) (xc.Tx, error) {
+	unstakingInput, ok := input.(*tx_input.UnstakingInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	neuronArgs, err := getNeuronArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return manageNeuron(neuronArgs, governance.NewStartDissolving(), unstakingInput.TxInput)
+}
+
+// Withdraw disburses the full stake of the dissolved neuron given as the stake account to the controller
+func (txBuilder TxBuilder) Withdraw(args xcbuilder.StakeArgs, input xc.WithdrawTxInput) (xc.Tx, error) {
+	withdrawInput, ok := input.(*tx_input.WithdrawInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	neuronArgs, err := getNeuronArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	controller, err := address.NewEd25519Identity(neuronArgs.PublicKey).Principal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get controller principal: %w", err)
+	}
+	return manageNeuron(neuronArgs, governance.NewDisburse(address.NewAccountId(controller.Raw)), withdrawInput.TxInput)
+}
+
+func (txBuilder TxBuilder) MethodsUsed() []xc.StakingMethod {
+	return []xc.StakingMethod{
+		xc.StakingMethodStake,
+		xc.StakingMethodUnstake,
+		xc.StakingMethodWithdraw,
+	}
+}
+
+// StartDissolving starts dissolving a neuron, the same as unstaking it
+func (txBuilder TxBuilder) StartDissolving(args NeuronArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*tx_input.TxInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	return manageNeuron(args, governance.NewStartDissolving(), *txInput)
+}
+
+// StopDissolving locks a dissolving neuron again, keeping its remaining dissolve delay
+func (txBuilder TxBuilder) StopDissolving(args NeuronArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*tx_input.TxInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	return manageNeuron(args, governance.NewStopDissolving(), *txInput)
+}
+
+// SetDissolveDelay increases the dissolve delay of a neuron to the given delay.  Governance only
+// allows increasing the dissolve delay, up to the maximum of 8 years.
+func (txBuilder TxBuilder) SetDissolveDelay(args SetDissolveDelayArgs, input xc.TxInput) (xc.Tx, error) {
+	neuronInput, ok := input.(*tx_input.NeuronInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	delay := uint64(args.DissolveDelay.Seconds())
+	if delay > governance.MaximumDissolveDelaySeconds {
+		return nil, fmt.Errorf("dissolve delay may be at most %d seconds", governance.MaximumDissolveDelaySeconds)
+	}
+	if delay <= neuronInput.DissolveDelaySeconds {
+		return nil, fmt.Errorf("dissolve delay can only be increased, the neuron's current delay is %d seconds", neuronInput.DissolveDelaySeconds)
+	}
+	additional := delay - neuronInput.DissolveDelaySeconds
+	return manageNeuron(args.NeuronArgs, governance.NewIncreaseDissolveDelay(uint32(additional)), neuronInput.TxInput)
+}
+
+func getNeuronArgs(args xcbuilder.StakeArgs) (NeuronArgs, error) {
+	pubkey, ok := args.GetPublicKey()
+	if !ok {
+		return NeuronArgs{}, errors.New("missing public key")
+	}
+	neuron, ok := args.GetStakeAccount()
+	if !ok {
+		return NeuronArgs{}, errors.New("the neuron must be given as the stake account")
+	}
+	return NeuronArgs{
+		From:      args.GetFrom(),
+		PublicKey: pubkey,
+		Neuron:    neuron,
+	}, nil
+}
+
+func manageNeuron(args NeuronArgs, command governance.Command, input tx_input.TxInput) (xc.Tx, error) {
+	if len(args.PublicKey) == 0 {
+		return nil, errors.New("missing public key")
+	}
+	neuron, err := governance.ParseNeuron(args.Neuron)
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := tx.NewManageNeuronTx(args.PublicKey, governance.ManageNeuron{
+		Command:              &command,
+		NeuronIdOrSubaccount: &neuron,
+	}, input)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
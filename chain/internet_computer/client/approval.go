@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/internet_computer/builder"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icp"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icrc"
+	"github.com/cordialsys/crosschain/chain/internet_computer/tx_input"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+var _ xclient.ApprovalClient = &Client{}
+
+// FetchAllowance returns the ICRC-2 allowance of the spender over the owner's tokens.  Both must be given as icrc1 addresses.
+func (client *Client) FetchAllowance(ctx context.Context, owner xc.Address, spender xc.Address, contract xc.ContractAddress) (xc.AmountBlockchain, error) {
+	canister, err := builder.LedgerCanister(contract)
+	if err != nil {
+		return xc.AmountBlockchain{}, err
+	}
+	ownerAccount, err := icrc.DecodeAccount(string(owner))
+	if err != nil {
+		return xc.AmountBlockchain{}, fmt.Errorf("failed to decode owner icrc1 address: %w", err)
+	}
+	spenderAccount, err := icrc.DecodeAccount(string(spender))
+	if err != nil {
+		return xc.AmountBlockchain{}, fmt.Errorf("failed to decode spender icrc1 address: %w", err)
+	}
+
+	var allowance icrc.Allowance
+	err = client.Agent.Query(ctx, canister, icrc.MethodAllowance, []any{icrc.AllowanceArgs{
+		Account: ownerAccount,
+		Spender: spenderAccount,
+	}}, []any{&allowance})
+	if err != nil {
+		return xc.AmountBlockchain{}, fmt.Errorf("failed to query allowance: %w", err)
+	}
+	if allowance.Allowance.BigInt() == nil {
+		return xc.NewAmountBlockchainFromUint64(0), nil
+	}
+	return xc.AmountBlockchain(*allowance.Allowance.BigInt()), nil
+}
+
+func (client *Client) FetchApprovalInput(ctx context.Context, args xcbuilder.ApprovalArgs) (xc.ApprovalTxInput, error) {
+	contract := args.GetContract()
+	if contract == "" {
+		contract = xc.ContractAddress(icp.LedgerPrincipal.String())
+	}
+	txInput, err := client.newTxInput(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := client.FetchAllowance(ctx, args.GetFrom(), args.GetSpender(), contract)
+	if err != nil {
+		return nil, err
+	}
+	return &tx_input.ApprovalInput{
+		TxInput:          *txInput,
+		CurrentAllowance: allowance,
+	}, nil
+}
+
+// FetchTransferFromInput returns the input for an ICRC-2 transfer from an owner that approved the spender
+func (client *Client) FetchTransferFromInput(ctx context.Context, contract xc.ContractAddress) (*tx_input.TxInput, error) {
+	if contract == "" {
+		contract = xc.ContractAddress(icp.LedgerPrincipal.String())
+	}
+	return client.newTxInput(ctx, contract)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/cordialsys/crosschain/chain/internet_computer/agent"
 	"github.com/cordialsys/crosschain/chain/internet_computer/candid/idl"
 	"github.com/cordialsys/crosschain/chain/internet_computer/client/types"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/governance"
 	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icp"
 	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icrc"
 	"github.com/cordialsys/crosschain/chain/internet_computer/tx"
@@ -104,14 +105,8 @@ func (client *Client) fetchFee(ctx context.Context, contract xc.ContractAddress)
 	return xc.NewAmountBlockchainFromUint64(fee.BigInt().Uint64()), nil
 }
 
-// FetchTransferInput returns tx input for a InternetComputerProtocol tx
-func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
-	contract, isIcrc := args.GetContract()
-	if !isIcrc {
-		contract = xc.ContractAddress(icp.LedgerPrincipal.String())
-	}
-	memo, hasMemo := args.GetMemo()
-
+// newTxInput returns a tx input with the fee of the ledger canister
+func (client *Client) newTxInput(ctx context.Context, contract xc.ContractAddress) (*tx_input.TxInput, error) {
 	fee, err := client.fetchFee(ctx, contract)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch fee: %w", err)
@@ -127,6 +122,21 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		return txInput, err
 	}
 	txInput.Nonce = hex.EncodeToString(randomNonce)
+	return txInput, nil
+}
+
+// FetchTransferInput returns tx input for a InternetComputerProtocol tx
+func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+	contract, isIcrc := args.GetContract()
+	if !isIcrc {
+		contract = xc.ContractAddress(icp.LedgerPrincipal.String())
+	}
+	memo, hasMemo := args.GetMemo()
+
+	txInput, err := client.newTxInput(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
 
 	if hasMemo {
 		if isIcrc {
@@ -186,10 +196,73 @@ func (client *Client) SubmitTx(ctx context.Context, txI xctypes.SubmitTxReq) err
 		return fmt.Errorf("failed to decode canister principal: %w", err)
 	}
 
-	if metadata.IsIcrcTx {
-		return client.CallIcrcTransaction(agent, types.RequestID(requestId), canister, serializedSignedTx)
-	} else {
-		return client.CallIcpTransaction(agent, types.RequestID(requestId), canister, serializedSignedTx)
+	method := metadata.Method
+	if method == "" {
+		method = icp.MethodTransfer
+		if metadata.IsIcrcTx {
+			method = icrc.MethodTransfer
+		}
+	}
+	err = client.call(agent, method, types.RequestID(requestId), canister, serializedSignedTx)
+	if err != nil {
+		return err
+	}
+
+	// Follow-up calls depend on the previous calls, so they are submitted in order
+	for _, call := range metadata.FollowUps {
+		callRequestId, err := hex.DecodeString(call.RequestID)
+		if err != nil {
+			return fmt.Errorf("failed to decode %s request id: %w", call.Method, err)
+		}
+		callCanister, err := icpaddress.Decode(call.CanisterID)
+		if err != nil {
+			return fmt.Errorf("failed to decode %s canister principal: %w", call.Method, err)
+		}
+		err = client.call(agent, call.Method, types.RequestID(callRequestId), callCanister, call.SignedRequest)
+		if err != nil {
+			return fmt.Errorf("failed to submit %s call: %w", call.Method, err)
+		}
+	}
+	return nil
+}
+
+// call submits a signed call and checks its reply according to the method
+func (client *Client) call(a *agent.Agent, method string, id types.RequestID, canister icpaddress.Principal, tx []byte) error {
+	switch method {
+	case icp.MethodTransfer:
+		return client.CallIcpTransaction(a, id, canister, tx)
+	case icrc.MethodTransfer:
+		return client.CallIcrcTransaction(a, id, canister, tx)
+	case icrc.MethodApprove:
+		var result icrc.ApproveResult
+		if err := a.Call(canister, id, tx, []any{&result}); err != nil {
+			return fmt.Errorf("failed to submit tx: %w", err)
+		}
+		if result.Err != nil {
+			return fmt.Errorf("tx rejected: %v", result.Err)
+		}
+		return nil
+	case icrc.MethodTransferFrom:
+		var result icrc.TransferFromResult
+		if err := a.Call(canister, id, tx, []any{&result}); err != nil {
+			return fmt.Errorf("failed to submit tx: %w", err)
+		}
+		if result.Err != nil {
+			return fmt.Errorf("tx rejected: %v", result.Err)
+		}
+		return nil
+	case governance.MethodManageNeuron:
+		var result governance.ManageNeuronResponse
+		if err := a.Call(canister, id, tx, []any{&result}); err != nil {
+			return fmt.Errorf("failed to submit tx: %w", err)
+		}
+		// An empty response means the call was accepted but has not been executed yet
+		if result.Command != nil && result.Command.Error != nil {
+			return fmt.Errorf("tx rejected: %v", result.Command.Error)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported method: %s", method)
 	}
 }
 
@@ -358,12 +431,15 @@ func (client *Client) fetchTxInfoByBlockIndex(ctx context.Context, canister icpa
 	if canister.Encode() != icp.LedgerPrincipal.Encode() {
 		contract = xc.ContractAddress(canister.Encode())
 	}
-	movement := txinfo.NewMovement(client.Asset.GetChain().Chain, contract)
-	movement.AddSource(sourceAddress, xcAmount, nil)
-	movement.AddDestination(destinationAddress, xcAmount, nil)
-	movement.SetMemo(transaction.Memo())
+	// Approvals only pay the fee
+	if !transaction.IsApproval() {
+		movement := txinfo.NewMovement(client.Asset.GetChain().Chain, contract)
+		movement.AddSource(sourceAddress, xcAmount, nil)
+		movement.AddDestination(destinationAddress, xcAmount, nil)
+		movement.SetMemo(transaction.Memo())
 
-	txInfo.AddMovement(movement)
+		txInfo.AddMovement(movement)
+	}
 
 	fee := transaction.Fee()
 	if fee == 0 {
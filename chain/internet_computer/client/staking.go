@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	icpaddress "github.com/cordialsys/crosschain/chain/internet_computer/address"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/governance"
+	"github.com/cordialsys/crosschain/chain/internet_computer/tx_input"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+// Neuron memos are allocated sequentially from 0, so that the neurons of a controller can be found
+// without authenticating as it.  This limits how many are looked up.
+const MaxNeuronMemo = 100
+
+var _ xclient.StakingClient = &Client{}
+
+// controllerPrincipal returns the principal controlling the neurons, from the public key if
+// available or else the address, which must then be in the principal (icrc1) format.
+func controllerPrincipal(from xc.Address, publicKey []byte) (icpaddress.Principal, error) {
+	if len(publicKey) > 0 {
+		return icpaddress.NewEd25519Identity(publicKey).Principal()
+	}
+	principal, err := icpaddress.Decode(string(from))
+	if err != nil {
+		return icpaddress.Principal{}, fmt.Errorf("neurons are controlled by a principal, %s is not one: %w", from, err)
+	}
+	return principal, nil
+}
+
+// fetchNeuronInfo returns the public neuron info, or nil if the neuron does not exist
+func (client *Client) fetchNeuronInfo(ctx context.Context, neuron governance.NeuronIdOrSubaccount) (*governance.NeuronInfo, error) {
+	var result governance.NeuronInfoResult
+	err := client.Agent.Query(
+		ctx,
+		governance.GovernancePrincipal,
+		governance.MethodGetNeuronInfoByIdOrSubaccount,
+		[]any{neuron},
+		[]any{&result},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query neuron info: %w", err)
+	}
+	if result.Err != nil {
+		if result.Err.ErrorType == governance.ErrorTypeNotFound {
+			return nil, nil
+		}
+		return nil, result.Err
+	}
+	return result.Ok, nil
+}
+
+func newNeuronBalance(info *governance.NeuronInfo, account string) *xclient.StakedBalance {
+	stake := xc.NewAmountBlockchainFromUint64(info.StakeE8s)
+	state := xclient.Active
+	switch info.State {
+	case governance.NeuronStateDissolving:
+		state = xclient.Deactivating
+	case governance.NeuronStateDissolved:
+		state = xclient.Inactive
+	case governance.NeuronStateSpawning:
+		state = xclient.Activating
+	}
+	return xclient.NewStakedBalance(stake, state, "", account)
+}
+
+// FetchStakeBalance reports the neurons of the controller, or only the neuron given as the account.
+// Neurons are reported by their hex encoded governance subaccount.
+func (client *Client) FetchStakeBalance(ctx context.Context, args xclient.StakedBalanceArgs) ([]*xclient.StakedBalance, error) {
+	if account, ok := args.GetAccount(); ok {
+		neuron, err := governance.ParseNeuron(account)
+		if err != nil {
+			return nil, err
+		}
+		info, err := client.fetchNeuronInfo(ctx, neuron)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			return nil, fmt.Errorf("neuron %s not found", account)
+		}
+		return []*xclient.StakedBalance{newNeuronBalance(info, account)}, nil
+	}
+
+	controller, err := controllerPrincipal(args.GetFrom(), nil)
+	if err != nil {
+		return nil, err
+	}
+	balances := []*xclient.StakedBalance{}
+	for memo := uint64(0); memo < MaxNeuronMemo; memo++ {
+		subaccount := governance.NeuronSubaccount(controller, memo)
+		info, err := client.fetchNeuronInfo(ctx, governance.NewNeuronSubaccount(subaccount))
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			break
+		}
+		// Disbursed neurons remain with no stake
+		if info.StakeE8s == 0 {
+			continue
+		}
+		balances = append(balances, newNeuronBalance(info, hex.EncodeToString(subaccount[:])))
+	}
+	return balances, nil
+}
+
+// nextNeuronMemo returns the first memo that the controller does not have a neuron for
+func (client *Client) nextNeuronMemo(ctx context.Context, controller icpaddress.Principal) (uint64, error) {
+	for memo := uint64(0); memo < MaxNeuronMemo; memo++ {
+		info, err := client.fetchNeuronInfo(ctx, governance.NewNeuronSubaccount(governance.NeuronSubaccount(controller, memo)))
+		if err != nil {
+			return 0, err
+		}
+		if info == nil {
+			return memo, nil
+		}
+	}
+	return 0, fmt.Errorf("controller %s already has the maximum of %d neurons", controller, MaxNeuronMemo)
+}
+
+func (client *Client) FetchStakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.StakeTxInput, error) {
+	txInput, err := client.newTxInput(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	stakingInput := &tx_input.StakingInput{
+		TxInput: *txInput,
+	}
+	if _, ok := args.GetStakeAccount(); !ok {
+		publicKey, _ := args.GetPublicKey()
+		controller, err := controllerPrincipal(args.GetFrom(), publicKey)
+		if err != nil {
+			return nil, err
+		}
+		stakingInput.NeuronMemo, err = client.nextNeuronMemo(ctx, controller)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stakingInput, nil
+}
+
+func (client *Client) FetchUnstakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.UnstakeTxInput, error) {
+	txInput, err := client.newTxInput(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return &tx_input.UnstakingInput{
+		TxInput: *txInput,
+	}, nil
+}
+
+func (client *Client) FetchWithdrawInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.WithdrawTxInput, error) {
+	txInput, err := client.newTxInput(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return &tx_input.WithdrawInput{
+		TxInput: *txInput,
+	}, nil
+}
+
+// FetchNeuronInput returns the input for configuring a neuron, including its current dissolve delay
+func (client *Client) FetchNeuronInput(ctx context.Context, neuron string) (*tx_input.NeuronInput, error) {
+	neuronId, err := governance.ParseNeuron(neuron)
+	if err != nil {
+		return nil, err
+	}
+	info, err := client.fetchNeuronInfo(ctx, neuronId)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("neuron %s not found", neuron)
+	}
+	txInput, err := client.newTxInput(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return &tx_input.NeuronInput{
+		TxInput:              *txInput,
+		DissolveDelaySeconds: info.DissolveDelaySeconds,
+	}, nil
+}
@@ -247,6 +247,8 @@ type Transaction interface {
 	Memo() string
 	Amount() (uint64, error)
 	Fee() uint64
+	// Approvals change an allowance and do not move funds
+	IsApproval() bool
 }
 
 type Block interface {
@@ -42,3 +42,24 @@ func BadBurn(minAmount uint64) string {
 func Generic(code uint64, message string) string {
 	return fmt.Sprintf("generic error, code: %d, message: %s", code, message)
 }
+
+const AllowanceChangedError = "allowance changed"
+const ExpiredError = "approval expired"
+const InsufficientAllowanceError = "insufficient allowance"
+const TemporarilyUnavailableError = "temporarily unavailable"
+
+func AllowanceChanged(current uint64) string {
+	return fmt.Sprintf("%s, current allowance: %d", AllowanceChangedError, current)
+}
+
+func Expired(ledgerTime uint64) string {
+	return fmt.Sprintf("%s, ledger time: %d", ExpiredError, ledgerTime)
+}
+
+func InsufficientAllowance(allowance uint64) string {
+	return fmt.Sprintf("%s, allowance: %d", InsufficientAllowanceError, allowance)
+}
+
+func TemporarilyUnavailable() string {
+	return TemporarilyUnavailableError
+}
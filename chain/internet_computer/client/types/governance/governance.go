@@ -0,0 +1,252 @@
+package governance
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/cordialsys/crosschain/chain/internet_computer/address"
+)
+
+// NNS governance canister, which manages neurons staked on the ICP ledger
+const (
+	MethodManageNeuron                  = "manage_neuron"
+	MethodGetNeuronInfoByIdOrSubaccount = "get_neuron_info_by_id_or_subaccount"
+
+	// Minimum stake required to claim a neuron, 1 ICP
+	MinimumStakeE8s = 100_000_000
+	// Neurons need at least 6 months of dissolve delay to vote and earn rewards,
+	// and may have at most 8 years.
+	MinimumDissolveDelaySeconds = 15_778_800
+	MaximumDissolveDelaySeconds = 252_460_800
+
+	ErrorTypeNotFound int32 = 4
+)
+
+var GovernancePrincipal = address.MustDecode("rrkah-fqaaa-aaaaa-aaaaq-cai")
+
+// Neuron states reported in NeuronInfo
+const (
+	NeuronStateUnspecified   int32 = 0
+	NeuronStateNotDissolving int32 = 1
+	NeuronStateDissolving    int32 = 2
+	NeuronStateDissolved     int32 = 3
+	NeuronStateSpawning      int32 = 4
+)
+
+// NeuronSubaccount returns the governance subaccount that stakes a controller's neuron.  The memo
+// distinguishes between the neurons of the same controller.
+func NeuronSubaccount(controller address.Principal, memo uint64) [32]byte {
+	h := sha256.New()
+	h.Write([]byte{0x0c})
+	h.Write([]byte("neuron-stake"))
+	h.Write(controller.Raw)
+	memoBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(memoBz, memo)
+	h.Write(memoBz)
+
+	var subaccount [32]byte
+	copy(subaccount[:], h.Sum(nil))
+	return subaccount
+}
+
+// NeuronAccountId returns the ICP ledger account that a neuron is staked from
+func NeuronAccountId(controller address.Principal, memo uint64) address.AccountId {
+	return address.NewAccountIdWithSubaccount(GovernancePrincipal.Raw, NeuronSubaccount(controller, memo))
+}
+
+type NeuronId struct {
+	Id uint64 `ic:"id" json:"id"`
+}
+
+type NeuronIdOrSubaccount struct {
+	Subaccount *[]byte   `ic:"Subaccount,variant" json:"Subaccount,omitempty"`
+	NeuronId   *NeuronId `ic:"NeuronId,variant" json:"NeuronId,omitempty"`
+}
+
+func NewNeuronSubaccount(subaccount [32]byte) NeuronIdOrSubaccount {
+	bz := subaccount[:]
+	return NeuronIdOrSubaccount{Subaccount: &bz}
+}
+
+func NewNeuronId(id uint64) NeuronIdOrSubaccount {
+	return NeuronIdOrSubaccount{NeuronId: &NeuronId{Id: id}}
+}
+
+// ParseNeuron parses a neuron given either as its hex encoded governance subaccount or its decimal id
+func ParseNeuron(neuron string) (NeuronIdOrSubaccount, error) {
+	if len(neuron) == 2*len([32]byte{}) {
+		subaccountBz, err := hex.DecodeString(neuron)
+		if err == nil {
+			var subaccount [32]byte
+			copy(subaccount[:], subaccountBz)
+			return NewNeuronSubaccount(subaccount), nil
+		}
+	}
+	id, err := strconv.ParseUint(neuron, 10, 64)
+	if err != nil {
+		return NeuronIdOrSubaccount{}, fmt.Errorf("invalid neuron %q, expected a hex subaccount or a neuron id", neuron)
+	}
+	return NewNeuronId(id), nil
+}
+
+// GetSubaccount returns the governance subaccount of the neuron, if it was given by subaccount
+func (n NeuronIdOrSubaccount) GetSubaccount() ([32]byte, bool) {
+	var subaccount [32]byte
+	if n.Subaccount == nil {
+		return subaccount, false
+	}
+	copy(subaccount[:], *n.Subaccount)
+	return subaccount, true
+}
+
+type ManageNeuron struct {
+	Id                   *NeuronId             `ic:"id,omitempty" json:"id,omitempty"`
+	Command              *Command              `ic:"command,omitempty" json:"command,omitempty"`
+	NeuronIdOrSubaccount *NeuronIdOrSubaccount `ic:"neuron_id_or_subaccount,omitempty" json:"neuron_id_or_subaccount,omitempty"`
+}
+
+// Command is the subset of the neuron commands that are supported
+type Command struct {
+	Configure      *Configure      `ic:"Configure,variant" json:"Configure,omitempty"`
+	ClaimOrRefresh *ClaimOrRefresh `ic:"ClaimOrRefresh,variant" json:"ClaimOrRefresh,omitempty"`
+	Disburse       *Disburse       `ic:"Disburse,variant" json:"Disburse,omitempty"`
+}
+
+type Configure struct {
+	Operation *Operation `ic:"operation,omitempty" json:"operation,omitempty"`
+}
+
+type Operation struct {
+	StopDissolving        *struct{}              `ic:"StopDissolving,variant" json:"StopDissolving,omitempty"`
+	StartDissolving       *struct{}              `ic:"StartDissolving,variant" json:"StartDissolving,omitempty"`
+	IncreaseDissolveDelay *IncreaseDissolveDelay `ic:"IncreaseDissolveDelay,variant" json:"IncreaseDissolveDelay,omitempty"`
+}
+
+type IncreaseDissolveDelay struct {
+	AdditionalDissolveDelaySeconds uint32 `ic:"additional_dissolve_delay_seconds" json:"additional_dissolve_delay_seconds"`
+}
+
+type ClaimOrRefresh struct {
+	By *By `ic:"by,omitempty" json:"by,omitempty"`
+}
+
+type By struct {
+	// Refresh the neuron given by ManageNeuron.NeuronIdOrSubaccount
+	NeuronIdOrSubaccount *struct{}                        `ic:"NeuronIdOrSubaccount,variant" json:"NeuronIdOrSubaccount,omitempty"`
+	MemoAndController    *ClaimOrRefreshNeuronFromAccount `ic:"MemoAndController,variant" json:"MemoAndController,omitempty"`
+}
+
+type ClaimOrRefreshNeuronFromAccount struct {
+	Controller *address.Principal `ic:"controller,omitempty" json:"controller,omitempty"`
+	Memo       uint64             `ic:"memo" json:"memo"`
+}
+
+type Disburse struct {
+	ToAccount *AccountIdentifier `ic:"to_account,omitempty" json:"to_account,omitempty"`
+	Amount    *Amount            `ic:"amount,omitempty" json:"amount,omitempty"`
+}
+
+type AccountIdentifier struct {
+	Hash []byte `ic:"hash" json:"hash"`
+}
+
+type Amount struct {
+	E8s uint64 `ic:"e8s" json:"e8s"`
+}
+
+func NewStartDissolving() Command {
+	return Command{Configure: &Configure{Operation: &Operation{StartDissolving: &struct{}{}}}}
+}
+
+func NewStopDissolving() Command {
+	return Command{Configure: &Configure{Operation: &Operation{StopDissolving: &struct{}{}}}}
+}
+
+func NewIncreaseDissolveDelay(additionalSeconds uint32) Command {
+	return Command{Configure: &Configure{Operation: &Operation{
+		IncreaseDissolveDelay: &IncreaseDissolveDelay{AdditionalDissolveDelaySeconds: additionalSeconds},
+	}}}
+}
+
+func NewClaimOrRefresh(controller address.Principal, memo uint64) Command {
+	return Command{ClaimOrRefresh: &ClaimOrRefresh{By: &By{
+		MemoAndController: &ClaimOrRefreshNeuronFromAccount{Controller: &controller, Memo: memo},
+	}}}
+}
+
+func NewRefresh() Command {
+	return Command{ClaimOrRefresh: &ClaimOrRefresh{By: &By{NeuronIdOrSubaccount: &struct{}{}}}}
+}
+
+// NewDisburse disburses the full stake of a dissolved neuron to an ICP account id
+func NewDisburse(toAccount address.AccountId) Command {
+	return Command{Disburse: &Disburse{ToAccount: &AccountIdentifier{Hash: toAccount}}}
+}
+
+type ManageNeuronResponse struct {
+	Command *CommandResponse `ic:"command,omitempty" json:"command,omitempty"`
+}
+
+type CommandResponse struct {
+	Error          *GovernanceError        `ic:"Error,variant" json:"Error,omitempty"`
+	Configure      *struct{}               `ic:"Configure,variant" json:"Configure,omitempty"`
+	ClaimOrRefresh *ClaimOrRefreshResponse `ic:"ClaimOrRefresh,variant" json:"ClaimOrRefresh,omitempty"`
+	Disburse       *DisburseResponse       `ic:"Disburse,variant" json:"Disburse,omitempty"`
+}
+
+type ClaimOrRefreshResponse struct {
+	RefreshedNeuronId *NeuronId `ic:"refreshed_neuron_id,omitempty" json:"refreshed_neuron_id,omitempty"`
+}
+
+type DisburseResponse struct {
+	TransferBlockHeight uint64 `ic:"transfer_block_height" json:"transfer_block_height"`
+}
+
+type GovernanceError struct {
+	ErrorMessage string `ic:"error_message" json:"error_message"`
+	ErrorType    int32  `ic:"error_type" json:"error_type"`
+}
+
+func (e *GovernanceError) Error() string {
+	return fmt.Sprintf("governance error, type: %d, message: %s", e.ErrorType, e.ErrorMessage)
+}
+
+// Err returns the error of a manage_neuron call, if it failed
+func (r ManageNeuronResponse) Err() error {
+	if r.Command == nil {
+		return fmt.Errorf("empty manage_neuron response")
+	}
+	if r.Command.Error != nil {
+		return r.Command.Error
+	}
+	return nil
+}
+
+type NeuronInfoResult struct {
+	Ok  *NeuronInfo      `ic:"Ok,variant" json:"Ok,omitempty"`
+	Err *GovernanceError `ic:"Err,variant" json:"Err,omitempty"`
+}
+
+// NeuronInfo is the public information of a neuron.  Optional fields that are not needed are omitted.
+type NeuronInfo struct {
+	DissolveDelaySeconds        uint64       `ic:"dissolve_delay_seconds" json:"dissolve_delay_seconds"`
+	RecentBallots               []BallotInfo `ic:"recent_ballots" json:"recent_ballots"`
+	CreatedTimestampSeconds     uint64       `ic:"created_timestamp_seconds" json:"created_timestamp_seconds"`
+	State                       int32        `ic:"state" json:"state"`
+	StakeE8s                    uint64       `ic:"stake_e8s" json:"stake_e8s"`
+	RetrievedAtTimestampSeconds uint64       `ic:"retrieved_at_timestamp_seconds" json:"retrieved_at_timestamp_seconds"`
+	VotingPower                 uint64       `ic:"voting_power" json:"voting_power"`
+	AgeSeconds                  uint64       `ic:"age_seconds" json:"age_seconds"`
+}
+
+type BallotInfo struct {
+	Vote       int32       `ic:"vote" json:"vote"`
+	ProposalId *ProposalId `ic:"proposal_id,omitempty" json:"proposal_id,omitempty"`
+}
+
+type ProposalId struct {
+	Id uint64 `ic:"id" json:"id"`
+}
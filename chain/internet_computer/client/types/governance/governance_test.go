@@ -0,0 +1,117 @@
+package governance_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cordialsys/crosschain/chain/internet_computer/address"
+	"github.com/cordialsys/crosschain/chain/internet_computer/candid"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/governance"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeuronSubaccount(t *testing.T) {
+	controller := address.MustDecode("mglk4-25zez-he5uh-lsy2a-bontn-pfarj-offxd-5teb2-icnpp-scmni-zae")
+	// sha256(0x0c || "neuron-stake" || principal || big-endian memo)
+	preimage := append([]byte("\x0cneuron-stake"), controller.Raw...)
+	preimage = append(preimage, 0, 0, 0, 0, 0, 0, 0x01, 0x02)
+	expected := sha256.Sum256(preimage)
+
+	subaccount := governance.NeuronSubaccount(controller, 0x0102)
+	require.Equal(t, expected, subaccount)
+	require.NotEqual(t, subaccount, governance.NeuronSubaccount(controller, 0x0103))
+	require.Equal(t,
+		address.NewAccountIdWithSubaccount(governance.GovernancePrincipal.Raw, subaccount),
+		governance.NeuronAccountId(controller, 0x0102),
+	)
+}
+
+func TestParseNeuron(t *testing.T) {
+	neuron, err := governance.ParseNeuron("8271633478451981467")
+	require.NoError(t, err)
+	require.EqualValues(t, 8271633478451981467, neuron.NeuronId.Id)
+	_, ok := neuron.GetSubaccount()
+	require.False(t, ok)
+
+	subaccountHex := "f0e1d2c3b4a5968778695a4b3c2d1e0ff0e1d2c3b4a5968778695a4b3c2d1e0f"
+	neuron, err = governance.ParseNeuron(subaccountHex)
+	require.NoError(t, err)
+	require.Nil(t, neuron.NeuronId)
+	subaccount, ok := neuron.GetSubaccount()
+	require.True(t, ok)
+	require.Equal(t, subaccountHex, hex.EncodeToString(subaccount[:]))
+
+	_, err = governance.ParseNeuron("not-a-neuron")
+	require.ErrorContains(t, err, "invalid neuron")
+}
+
+// The governance canister's NeuronInfo, including optional fields that are not decoded
+type wireNeuronInfo struct {
+	DissolveDelaySeconds        uint64                  `ic:"dissolve_delay_seconds"`
+	RecentBallots               []governance.BallotInfo `ic:"recent_ballots"`
+	NeuronType                  *int32                  `ic:"neuron_type"`
+	CreatedTimestampSeconds     uint64                  `ic:"created_timestamp_seconds"`
+	State                       int32                   `ic:"state"`
+	StakeE8s                    uint64                  `ic:"stake_e8s"`
+	RetrievedAtTimestampSeconds uint64                  `ic:"retrieved_at_timestamp_seconds"`
+	Visibility                  *int32                  `ic:"visibility"`
+	VotingPower                 uint64                  `ic:"voting_power"`
+	AgeSeconds                  uint64                  `ic:"age_seconds"`
+}
+
+type wireNeuronInfoResult struct {
+	Ok  *wireNeuronInfo             `ic:"Ok,variant"`
+	Err *governance.GovernanceError `ic:"Err,variant"`
+}
+
+func TestDecodeNeuronInfo(t *testing.T) {
+	visibility := int32(1)
+	reply, err := candid.Marshal([]any{wireNeuronInfoResult{Ok: &wireNeuronInfo{
+		DissolveDelaySeconds:        15778800,
+		RecentBallots:               []governance.BallotInfo{{Vote: 1, ProposalId: &governance.ProposalId{Id: 5}}},
+		CreatedTimestampSeconds:     1700000000,
+		State:                       governance.NeuronStateDissolving,
+		StakeE8s:                    200000000,
+		RetrievedAtTimestampSeconds: 1700000100,
+		Visibility:                  &visibility,
+		VotingPower:                 300000000,
+	}}})
+	require.NoError(t, err)
+
+	var result governance.NeuronInfoResult
+	require.NoError(t, candid.Unmarshal(reply, []any{&result}))
+	require.Nil(t, result.Err)
+	require.EqualValues(t, 15778800, result.Ok.DissolveDelaySeconds)
+	require.EqualValues(t, governance.NeuronStateDissolving, result.Ok.State)
+	require.EqualValues(t, 200000000, result.Ok.StakeE8s)
+	require.EqualValues(t, 5, result.Ok.RecentBallots[0].ProposalId.Id)
+
+	reply, err = candid.Marshal([]any{wireNeuronInfoResult{Err: &governance.GovernanceError{
+		ErrorMessage: "Neuron not found",
+		ErrorType:    governance.ErrorTypeNotFound,
+	}}})
+	require.NoError(t, err)
+	result = governance.NeuronInfoResult{}
+	require.NoError(t, candid.Unmarshal(reply, []any{&result}))
+	require.EqualValues(t, governance.ErrorTypeNotFound, result.Err.ErrorType)
+}
+
+func TestDecodeManageNeuronResponse(t *testing.T) {
+	reply, err := candid.Marshal([]any{governance.ManageNeuronResponse{Command: &governance.CommandResponse{
+		Disburse: &governance.DisburseResponse{TransferBlockHeight: 123},
+	}}})
+	require.NoError(t, err)
+	var response governance.ManageNeuronResponse
+	require.NoError(t, candid.Unmarshal(reply, []any{&response}))
+	require.NoError(t, response.Err())
+	require.EqualValues(t, 123, response.Command.Disburse.TransferBlockHeight)
+
+	reply, err = candid.Marshal([]any{governance.ManageNeuronResponse{Command: &governance.CommandResponse{
+		Error: &governance.GovernanceError{ErrorMessage: "Neuron is not dissolved", ErrorType: 9},
+	}}})
+	require.NoError(t, err)
+	response = governance.ManageNeuronResponse{}
+	require.NoError(t, candid.Unmarshal(reply, []any{&response}))
+	require.ErrorContains(t, response.Err(), "Neuron is not dissolved")
+}
@@ -464,6 +464,10 @@ func (tx Transaction[T]) Fee() uint64 {
 	return tx.Operation.Fee()
 }
 
+func (tx Transaction[T]) IsApproval() bool {
+	return tx.Operation.Approve != nil
+}
+
 func (tx Transaction[T]) SourceAddress() string {
 	return tx.Operation.From()
 }
@@ -141,6 +141,15 @@ func DecodeAccount(addrs string) (Account, error) {
 	return Account{Owner: owner, Subaccount: &subaccount}, nil
 }
 
+// AccountId returns the ICP ledger account id of the account
+func (a Account) AccountId() address.AccountId {
+	var subaccount [ICRCSubbaccountLen]byte
+	if a.Subaccount != nil {
+		copy(subaccount[:], *a.Subaccount)
+	}
+	return address.NewAccountIdWithSubaccount(a.Owner.Raw, subaccount)
+}
+
 func (a Account) Encode() string {
 	principal := a.Owner.Encode()
 	if a.Subaccount != nil {
@@ -686,6 +695,10 @@ func (t Transaction) Op() string {
 	return ""
 }
 
+func (t Transaction) IsApproval() bool {
+	return t.Approve != nil
+}
+
 func (t Transaction) From() *Account {
 	if t.Burn != nil {
 		return &t.Burn.From
@@ -783,7 +796,7 @@ func (t Transaction) Fee() uint64 {
 }
 
 func (t Transaction) ExpectedAllowance() *uint64 {
-	if t.Approve != nil {
+	if t.Approve != nil && t.Approve.ExpectedAllowance != nil {
 		ea := new(uint64)
 		*ea = t.Approve.ExpectedAllowance.BigInt().Uint64()
 		return ea
@@ -793,7 +806,7 @@ func (t Transaction) ExpectedAllowance() *uint64 {
 }
 
 func (t Transaction) ExpiresAt() *uint64 {
-	if t.Approve != nil {
+	if t.Approve != nil && t.Approve.ExpiresAt != nil {
 		ea := new(uint64)
 		*ea = t.Approve.ExpiresAt.BigInt().Uint64()
 		return ea
@@ -962,8 +975,8 @@ type Approve struct {
 	CreatedAtTime     *idl.Nat `ic:"created_at_time,omitempty"`
 	Amount            idl.Nat  `ic:"amount"`
 	ExpectedAllowance *idl.Nat `ic:"expected_allowance,omitempty"`
-	ExpiresAt         *idl.Nat
-	Spender           Account `ic:"spender,omitempty"`
+	ExpiresAt         *idl.Nat `ic:"expires_at,omitempty"`
+	Spender           Account  `ic:"spender,omitempty"`
 }
 
 type Mint struct {
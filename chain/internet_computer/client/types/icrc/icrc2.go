@@ -0,0 +1,138 @@
+package icrc
+
+import (
+	"github.com/cordialsys/crosschain/chain/internet_computer/candid/idl"
+	icperrors "github.com/cordialsys/crosschain/chain/internet_computer/client/types/errors"
+)
+
+// ICRC-2 approve and transfer-from extension
+const (
+	MethodApprove      = "icrc2_approve"
+	MethodTransferFrom = "icrc2_transfer_from"
+	MethodAllowance    = "icrc2_allowance"
+)
+
+type ApproveArgs struct {
+	FromSubaccount    *[]byte  `ic:"from_subaccount,omitempty"`
+	Spender           Account  `ic:"spender"`
+	Amount            idl.Nat  `ic:"amount"`
+	ExpectedAllowance *idl.Nat `ic:"expected_allowance,omitempty"`
+	ExpiresAt         *uint64  `ic:"expires_at,omitempty"`
+	Fee               *idl.Nat `ic:"fee,omitempty"`
+	Memo              *[]byte  `ic:"memo,omitempty"`
+	CreatedAtTime     *uint64  `ic:"created_at_time,omitempty"`
+}
+
+type ApproveResult struct {
+	Ok  *idl.Nat      `ic:"Ok,variant" json:"Ok,omitempty"`
+	Err *ApproveError `ic:"Err,variant" json:"Err,omitempty"`
+}
+
+type ApproveError struct {
+	BadFee                 *BadFeeError            `ic:"BadFee,variant"`
+	InsufficientFunds      *InsufficientFundsError `ic:"InsufficientFunds,variant"`
+	AllowanceChanged       *AllowanceChangedError  `ic:"AllowanceChanged,variant"`
+	Expired                *ExpiredError           `ic:"Expired,variant"`
+	TooOld                 *struct{}               `ic:"TooOld,variant"`
+	CreatedInFuture        *CreatedInFutureError   `ic:"CreatedInFuture,variant"`
+	Duplicate              *DuplicateError         `ic:"Duplicate,variant"`
+	TemporarilyUnavailable *struct{}               `ic:"TemporarilyUnavailable,variant"`
+	GenericError           *GenericError           `ic:"GenericError,variant"`
+}
+
+type AllowanceChangedError struct {
+	CurrentAllowance idl.Nat `ic:"current_allowance"`
+}
+
+type ExpiredError struct {
+	LedgerTime uint64 `ic:"ledger_time"`
+}
+
+type InsufficientAllowanceError struct {
+	Allowance idl.Nat `ic:"allowance"`
+}
+
+func (e *ApproveError) Error() string {
+	if e.BadFee != nil {
+		return icperrors.BadFee(e.BadFee.ExpectedFee.BigInt().Uint64())
+	} else if e.InsufficientFunds != nil {
+		return icperrors.InsufficientFunds(e.InsufficientFunds.Balance.BigInt().Uint64())
+	} else if e.AllowanceChanged != nil {
+		return icperrors.AllowanceChanged(e.AllowanceChanged.CurrentAllowance.BigInt().Uint64())
+	} else if e.Expired != nil {
+		return icperrors.Expired(e.Expired.LedgerTime)
+	} else if e.TooOld != nil {
+		return icperrors.TransactionTooOld()
+	} else if e.CreatedInFuture != nil {
+		return icperrors.CreatedInFuture()
+	} else if e.Duplicate != nil {
+		return icperrors.TransactionDuplicate(e.Duplicate.DuplicateOf.BigInt().Uint64())
+	} else if e.TemporarilyUnavailable != nil {
+		return icperrors.TemporarilyUnavailable()
+	} else if e.GenericError != nil {
+		return icperrors.Generic(e.GenericError.ErrorCode.BigInt().Uint64(), e.GenericError.Message)
+	} else {
+		return icperrors.Unknown()
+	}
+}
+
+type TransferFromArgs struct {
+	SpenderSubaccount *[]byte  `ic:"spender_subaccount,omitempty"`
+	From              Account  `ic:"from"`
+	To                Account  `ic:"to"`
+	Amount            idl.Nat  `ic:"amount"`
+	Fee               *idl.Nat `ic:"fee,omitempty"`
+	Memo              *[]byte  `ic:"memo,omitempty"`
+	CreatedAtTime     *uint64  `ic:"created_at_time,omitempty"`
+}
+
+type TransferFromResult struct {
+	Ok  *idl.Nat           `ic:"Ok,variant" json:"Ok,omitempty"`
+	Err *TransferFromError `ic:"Err,variant" json:"Err,omitempty"`
+}
+
+type TransferFromError struct {
+	BadFee                 *BadFeeError                `ic:"BadFee,variant"`
+	BadBurn                *BadBurnError               `ic:"BadBurn,variant"`
+	InsufficientFunds      *InsufficientFundsError     `ic:"InsufficientFunds,variant"`
+	InsufficientAllowance  *InsufficientAllowanceError `ic:"InsufficientAllowance,variant"`
+	TooOld                 *struct{}                   `ic:"TooOld,variant"`
+	CreatedInFuture        *CreatedInFutureError       `ic:"CreatedInFuture,variant"`
+	Duplicate              *DuplicateError             `ic:"Duplicate,variant"`
+	TemporarilyUnavailable *struct{}                   `ic:"TemporarilyUnavailable,variant"`
+	GenericError           *GenericError               `ic:"GenericError,variant"`
+}
+
+func (e *TransferFromError) Error() string {
+	if e.BadFee != nil {
+		return icperrors.BadFee(e.BadFee.ExpectedFee.BigInt().Uint64())
+	} else if e.BadBurn != nil {
+		return icperrors.BadBurn(e.BadBurn.MinBurnAmount.BigInt().Uint64())
+	} else if e.InsufficientFunds != nil {
+		return icperrors.InsufficientFunds(e.InsufficientFunds.Balance.BigInt().Uint64())
+	} else if e.InsufficientAllowance != nil {
+		return icperrors.InsufficientAllowance(e.InsufficientAllowance.Allowance.BigInt().Uint64())
+	} else if e.TooOld != nil {
+		return icperrors.TransactionTooOld()
+	} else if e.CreatedInFuture != nil {
+		return icperrors.CreatedInFuture()
+	} else if e.Duplicate != nil {
+		return icperrors.TransactionDuplicate(e.Duplicate.DuplicateOf.BigInt().Uint64())
+	} else if e.TemporarilyUnavailable != nil {
+		return icperrors.TemporarilyUnavailable()
+	} else if e.GenericError != nil {
+		return icperrors.Generic(e.GenericError.ErrorCode.BigInt().Uint64(), e.GenericError.Message)
+	} else {
+		return icperrors.Unknown()
+	}
+}
+
+type AllowanceArgs struct {
+	Account Account `ic:"account"`
+	Spender Account `ic:"spender"`
+}
+
+type Allowance struct {
+	Allowance idl.Nat `ic:"allowance"`
+	ExpiresAt *uint64 `ic:"expires_at,omitempty"`
+}
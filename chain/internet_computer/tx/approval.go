@@ -0,0 +1,169 @@
+package tx
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/internet_computer/address"
+	"github.com/cordialsys/crosschain/chain/internet_computer/candid/idl"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icp"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icrc"
+	"github.com/cordialsys/crosschain/chain/internet_computer/tx_input"
+)
+
+// NewApproveTx creates an ICRC-2 "icrc2_approve" call on a ledger canister
+func NewApproveTx(pubkey []byte, canister address.Principal, approve icrc.ApproveArgs, input tx_input.TxInput) (Tx, error) {
+	request, err := newRequest(pubkey, canister, icrc.MethodApprove, input, approve)
+	if err != nil {
+		return Tx{}, err
+	}
+	return Tx{
+		Request:     request,
+		Signature:   []byte{},
+		IcrcApprove: &approve,
+		Pubkey:      pubkey,
+		IsIcrcTx:    canister.String() != icp.LedgerPrincipal.String(),
+	}, nil
+}
+
+// NewTransferFromTx creates an ICRC-2 "icrc2_transfer_from" call on a ledger canister
+func NewTransferFromTx(pubkey []byte, canister address.Principal, transfer icrc.TransferFromArgs, input tx_input.TxInput) (Tx, error) {
+	request, err := newRequest(pubkey, canister, icrc.MethodTransferFrom, input, transfer)
+	if err != nil {
+		return Tx{}, err
+	}
+	return Tx{
+		Request:          request,
+		Signature:        []byte{},
+		IcrcTransferFrom: &transfer,
+		Pubkey:           pubkey,
+		IsIcrcTx:         canister.String() != icp.LedgerPrincipal.String(),
+	}, nil
+}
+
+func optionalNat(n *uint64) *idl.Nat {
+	if n == nil {
+		return nil
+	}
+	nat := idl.NewNat(*n)
+	return &nat
+}
+
+func optionalTokens(n *idl.Nat) *icp.Tokens {
+	if n == nil {
+		return nil
+	}
+	tokens := icp.NewTokens(n.BigInt().Uint64())
+	return &tokens
+}
+
+func optionalTimestamp(ts *uint64) *icp.Timestamp {
+	if ts == nil {
+		return nil
+	}
+	timestamp := icp.NewTimestamp(*ts)
+	return &timestamp
+}
+
+func natOrZero(n *idl.Nat) uint64 {
+	if n == nil {
+		return 0
+	}
+	return n.BigInt().Uint64()
+}
+
+func (tx Tx) signerAccount() (icrc.Account, error) {
+	principal, err := address.NewEd25519Identity(tx.Pubkey).Principal()
+	if err != nil {
+		return icrc.Account{}, err
+	}
+	return icrc.Account{Owner: principal}, nil
+}
+
+// The ICP ledger records ICRC-2 operations as ICP ledger transactions, other ledgers as ICRC-3 blocks
+func (tx Tx) approveHash() xc.TxHash {
+	approve := tx.IcrcApprove
+	from, err := tx.signerAccount()
+	if err != nil {
+		return ""
+	}
+	var hash string
+	if !tx.IsIcrcTx {
+		transaction := icp.Transaction[[]byte]{
+			Operation: icp.Operation[[]byte]{
+				Approve: &icp.Approve[[]byte]{
+					From:              from.AccountId(),
+					Spender:           approve.Spender.AccountId(),
+					Allowance:         icp.NewTokens(approve.Amount.BigInt().Uint64()),
+					ExpectedAllowance: optionalTokens(approve.ExpectedAllowance),
+					ExpiresAt:         optionalTimestamp(approve.ExpiresAt),
+					Fee:               icp.NewTokens(natOrZero(approve.Fee)),
+				},
+			},
+			CreatedAtTime: optionalTimestamp(approve.CreatedAtTime),
+			Icrc1Memo:     approve.Memo,
+		}
+		hash, err = transaction.Hash()
+	} else {
+		transaction := icrc.Transaction{
+			Kind: "approve",
+			Approve: &icrc.Approve{
+				Fee:               approve.Fee,
+				From:              from,
+				Memo:              approve.Memo,
+				CreatedAtTime:     optionalNat(approve.CreatedAtTime),
+				Amount:            approve.Amount,
+				ExpectedAllowance: approve.ExpectedAllowance,
+				ExpiresAt:         optionalNat(approve.ExpiresAt),
+				Spender:           approve.Spender,
+			},
+		}
+		hash, err = transaction.ToFlattened().Hash()
+	}
+	if err != nil {
+		return ""
+	}
+	return xc.TxHash(hash)
+}
+
+func (tx Tx) transferFromHash() xc.TxHash {
+	transfer := tx.IcrcTransferFrom
+	spender, err := tx.signerAccount()
+	if err != nil {
+		return ""
+	}
+	var hash string
+	if !tx.IsIcrcTx {
+		spenderId := []byte(spender.AccountId())
+		transaction := icp.Transaction[[]byte]{
+			Operation: icp.Operation[[]byte]{
+				Transfer: &icp.Transfer[[]byte]{
+					From:    transfer.From.AccountId(),
+					To:      transfer.To.AccountId(),
+					Amount:  icp.NewTokens(transfer.Amount.BigInt().Uint64()),
+					Fee:     icp.NewTokens(natOrZero(transfer.Fee)),
+					Spender: &spenderId,
+				},
+			},
+			CreatedAtTime: optionalTimestamp(transfer.CreatedAtTime),
+			Icrc1Memo:     transfer.Memo,
+		}
+		hash, err = transaction.Hash()
+	} else {
+		transaction := icrc.Transaction{
+			Kind: "transfer",
+			Transfer: &icrc.Transfer{
+				To:            transfer.To,
+				From:          transfer.From,
+				Fee:           transfer.Fee,
+				Memo:          transfer.Memo,
+				CreatedAtTime: optionalNat(transfer.CreatedAtTime),
+				Amount:        transfer.Amount,
+				Spender:       &spender,
+			},
+		}
+		hash, err = transaction.ToFlattened().Hash()
+	}
+	if err != nil {
+		return ""
+	}
+	return xc.TxHash(hash)
+}
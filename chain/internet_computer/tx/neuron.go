@@ -0,0 +1,55 @@
+package tx
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/internet_computer/address"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/governance"
+	"github.com/cordialsys/crosschain/chain/internet_computer/client/types/icp"
+	"github.com/cordialsys/crosschain/chain/internet_computer/tx_input"
+)
+
+// NewStakeTx transfers ICP to a neuron's governance subaccount and then claims or refreshes the
+// neuron, so that governance picks up the new stake.
+func NewStakeTx(pubkey []byte, neuronSubaccount [32]byte, amount xc.AmountBlockchain, memo uint64, claim governance.ManageNeuron, input tx_input.TxInput) (Tx, error) {
+	createTimeNanos := uint64(input.GetCreateTimeNanos())
+	timestamp := icp.NewTimestamp(createTimeNanos)
+	transfer := icp.TransferArgs{
+		To:             address.NewAccountIdWithSubaccount(governance.GovernancePrincipal.Raw, neuronSubaccount),
+		Fee:            icp.NewTokens(input.Fee),
+		Memo:           memo,
+		FromSubaccount: nil,
+		CreatedAtTime:  &timestamp,
+		Amount:         icp.NewTokens(amount.Uint64()),
+	}
+	request, err := newRequest(pubkey, icp.LedgerPrincipal, icp.MethodTransfer, input, transfer)
+	if err != nil {
+		return Tx{}, err
+	}
+	claimRequest, err := newRequest(pubkey, governance.GovernancePrincipal, governance.MethodManageNeuron, input, claim)
+	if err != nil {
+		return Tx{}, err
+	}
+
+	return Tx{
+		Request:     request,
+		Signature:   []byte{},
+		IcpTransfer: &transfer,
+		Pubkey:      pubkey,
+		FollowUps: []Call{
+			{Request: claimRequest},
+		},
+	}, nil
+}
+
+// NewManageNeuronTx creates a governance "manage_neuron" call
+func NewManageNeuronTx(pubkey []byte, manageNeuron governance.ManageNeuron, input tx_input.TxInput) (Tx, error) {
+	request, err := newRequest(pubkey, governance.GovernancePrincipal, governance.MethodManageNeuron, input, manageNeuron)
+	if err != nil {
+		return Tx{}, err
+	}
+	return Tx{
+		Request:   request,
+		Signature: []byte{},
+		Pubkey:    pubkey,
+	}, nil
+}
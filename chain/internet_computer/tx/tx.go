@@ -28,20 +28,40 @@ func NewAgentConfig(pubkey []byte) agent.AgentConfig {
 	return agentConfig
 }
 
-// Tx for InternetComputerProtocol
-type Tx struct {
+// Call is a canister call that is submitted after the main request of a transaction
+type Call struct {
 	Request       types.Request
 	SignedRequest []byte
-	Signature     []byte
-	IcrcTransfer  *icrc.TransferArgs
-	IcpTransfer   *icp.TransferArgs
-	Pubkey        []byte
-	IsIcrcTx      bool
+}
+
+// Tx for InternetComputerProtocol
+type Tx struct {
+	Request          types.Request
+	SignedRequest    []byte
+	Signature        []byte
+	IcrcTransfer     *icrc.TransferArgs
+	IcpTransfer      *icp.TransferArgs
+	IcrcApprove      *icrc.ApproveArgs
+	IcrcTransferFrom *icrc.TransferFromArgs
+	Pubkey           []byte
+	IsIcrcTx         bool
+	// Submitted in order after the main request, e.g. to claim a neuron after staking to it
+	FollowUps []Call
 }
 
 var _ xc.Tx = &Tx{}
 var _ xc.TxWithMetadata = &Tx{}
 
+func newRequest(pubkey []byte, canister address.Principal, method string, input tx_input.TxInput, arg any) (types.Request, error) {
+	agentConfig := NewAgentConfig(pubkey)
+	expiration := time.Unix(input.CreateTime, 0).Add(tx_input.TransactionExpiration)
+	request, err := agentConfig.CreateUnsignedRequest(canister, types.RequestTypeCall, method, input.GetNonce(), expiration, arg)
+	if err != nil {
+		return types.Request{}, fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+	return request, nil
+}
+
 func NewTx(args xcbuilder.TransferArgs, input tx_input.TxInput) (Tx, error) {
 	var transaction Tx
 	_, isIcrc := args.GetContract()
@@ -227,16 +247,33 @@ func (tx Tx) Hash() xc.TxHash {
 		return xc.TxHash(hash)
 
 	}
+	if tx.IcrcApprove != nil {
+		return tx.approveHash()
+	}
+	if tx.IcrcTransferFrom != nil {
+		return tx.transferFromHash()
+	}
 
+	// Governance calls do not create a ledger transaction
+	if tx.Request.MethodName != "" {
+		requestID := tx.Request.RequestID()
+		return xc.TxHash(hex.EncodeToString(requestID[:]))
+	}
 	return ""
 }
 
-// Sighashes returns the tx payload to sign, aka sighash
+// Sighashes returns the tx payload to sign, aka sighash, followed by the payloads of any follow-up calls
 func (tx Tx) Sighashes() ([]*xc.SignatureRequest, error) {
 	signatureRequest := xc.SignatureRequest{
 		Payload: tx.Request.RequestID().PrepareForSign(),
 	}
-	return []*xc.SignatureRequest{&signatureRequest}, nil
+	signatureRequests := []*xc.SignatureRequest{&signatureRequest}
+	for _, call := range tx.FollowUps {
+		signatureRequests = append(signatureRequests, &xc.SignatureRequest{
+			Payload: call.Request.RequestID().PrepareForSign(),
+		})
+	}
+	return signatureRequests, nil
 
 }
 
@@ -245,8 +282,8 @@ func (tx *Tx) SetSignatures(signatures ...*xc.SignatureResponse) error {
 		return errors.New("already signed")
 	}
 
-	if len(signatures) != 1 {
-		return fmt.Errorf("expected only 1 signature, got: %d", len(signatures))
+	if len(signatures) != 1+len(tx.FollowUps) {
+		return fmt.Errorf("expected %d signatures, got: %d", 1+len(tx.FollowUps), len(signatures))
 	}
 
 	signature := signatures[0]
@@ -255,6 +292,14 @@ func (tx *Tx) SetSignatures(signatures ...*xc.SignatureResponse) error {
 		return fmt.Errorf("failed to sign tx: %w", err)
 	}
 
+	for i := range tx.FollowUps {
+		signedCall, err := tx.FollowUps[i].Request.Sign(signatures[i+1].Signature)
+		if err != nil {
+			return fmt.Errorf("failed to sign %s call: %w", tx.FollowUps[i].Request.MethodName, err)
+		}
+		tx.FollowUps[i].SignedRequest = signedCall
+	}
+
 	tx.SignedRequest = signedRequest
 	tx.Signature = []byte(signature.Signature)
 	return nil
@@ -272,6 +317,19 @@ type BroadcastMetadata struct {
 	RequestID       string `json:"request_id"`
 	SenderPublicKey []byte `json:"sender_public_key"`
 	IsIcrcTx        bool   `json:"is_icrc_tx"`
+	// The canister method, which determines how the reply is decoded.  Transfers may omit it.
+	Method    string          `json:"method,omitempty"`
+	FollowUps []BroadcastCall `json:"follow_ups,omitempty"`
+}
+
+// BroadcastCall is a signed follow-up call
+type BroadcastCall struct {
+	// Encoded as a principal string
+	CanisterID string `json:"canister_id"`
+	// encoded as hex
+	RequestID     string `json:"request_id"`
+	Method        string `json:"method"`
+	SignedRequest []byte `json:"signed_request"`
 }
 
 func (tx Tx) GetMetadata() ([]byte, bool, error) {
@@ -281,6 +339,16 @@ func (tx Tx) GetMetadata() ([]byte, bool, error) {
 		RequestID:       hex.EncodeToString(requestID[:]),
 		SenderPublicKey: tx.Request.Sender.PublicKey,
 		IsIcrcTx:        tx.IsIcrcTx,
+		Method:          tx.Request.MethodName,
+	}
+	for _, call := range tx.FollowUps {
+		callRequestID := call.Request.RequestID()
+		metadata.FollowUps = append(metadata.FollowUps, BroadcastCall{
+			CanisterID:    call.Request.CanisterID.String(),
+			RequestID:     hex.EncodeToString(callRequestID[:]),
+			Method:        call.Request.MethodName,
+			SignedRequest: call.SignedRequest,
+		})
 	}
 	metadataBz, err := json.Marshal(metadata)
 	if err != nil {
@@ -0,0 +1,22 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+)
+
+// ApprovalInput is the input for ICRC-2 approvals
+type ApprovalInput struct {
+	TxInput
+	// The spender's allowance at the time the input was fetched.  The approval is rejected
+	// by the ledger if it has changed since.
+	CurrentAllowance xc.AmountBlockchain `json:"current_allowance"`
+}
+
+var _ xc.TxVariantInput = &ApprovalInput{}
+var _ xc.ApprovalTxInput = &ApprovalInput{}
+
+func (*ApprovalInput) Approving() {}
+
+func (*ApprovalInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewApprovalInputType(xc.DriverInternetComputerProtocol, "icrc2")
+}
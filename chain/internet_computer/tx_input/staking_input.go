@@ -0,0 +1,57 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+)
+
+// StakingInput stakes ICP in an NNS neuron
+type StakingInput struct {
+	TxInput
+	// Memo of the new neuron, which determines its governance subaccount.  Ignored when
+	// topping up an existing neuron.
+	NeuronMemo uint64 `json:"neuron_memo"`
+}
+
+var _ xc.TxVariantInput = &StakingInput{}
+var _ xc.StakeTxInput = &StakingInput{}
+
+func (*StakingInput) Staking() {}
+
+func (*StakingInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewStakingInputType(xc.DriverInternetComputerProtocol, string(xc.Native))
+}
+
+// UnstakingInput starts dissolving a neuron
+type UnstakingInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &UnstakingInput{}
+var _ xc.UnstakeTxInput = &UnstakingInput{}
+
+func (*UnstakingInput) Unstaking() {}
+
+func (*UnstakingInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewUnstakingInputType(xc.DriverInternetComputerProtocol, string(xc.Native))
+}
+
+// WithdrawInput disburses a dissolved neuron
+type WithdrawInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &WithdrawInput{}
+var _ xc.WithdrawTxInput = &WithdrawInput{}
+
+func (*WithdrawInput) Withdrawing() {}
+
+func (*WithdrawInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewWithdrawingInputType(xc.DriverInternetComputerProtocol, string(xc.Native))
+}
+
+// NeuronInput is the input for configuring a neuron
+type NeuronInput struct {
+	TxInput
+	// The neuron's dissolve delay at the time the input was fetched
+	DissolveDelaySeconds uint64 `json:"dissolve_delay_seconds"`
+}
@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 	txinfo "github.com/cordialsys/crosschain/client/tx_info"
 	xctypes "github.com/cordialsys/crosschain/client/types"
 	"github.com/cordialsys/crosschain/testutil"
+	"github.com/kaspanet/kaspad/util"
 	"github.com/kaspanet/kaspad/util/txmass"
 	"github.com/sirupsen/logrus"
 )
@@ -27,12 +30,17 @@ type Client struct {
 	chainCfg *xc.ChainConfig
 	client   *rest.Client
 	decimals int
+	// Kasplex indexer for KRC-20 tokens, if configured
+	indexer *rest.Client
 }
 
 const (
 	defaultMassPerTxByte           = 1
 	defaultMassPerScriptPubKeyByte = 10
 	defaultMassPerSigOp            = 1000
+
+	// Amount locked in the P2SH address of a KRC-20 inscription, the reveal transaction returns it minus its fee
+	defaultCommitAmount = 30_000_000
 )
 
 var _ xclient.Client = &Client{}
@@ -50,7 +58,18 @@ func NewClient(cfgI *xc.ChainConfig) (*Client, error) {
 	chain := cfgI.GetChain()
 	clientConfig := chain.ChainClientConfig
 	client := rest.NewClient(clientConfig.URL, chain.Chain, chain.DefaultHttpClient())
-	return &Client{chain.Chain, chain, client, int(chain.Decimals)}, nil
+	var indexer *rest.Client
+	if clientConfig.IndexerUrl != "" {
+		indexer = rest.NewClient(clientConfig.IndexerUrl, chain.Chain, chain.DefaultHttpClient())
+	}
+	return &Client{chain.Chain, chain, client, int(chain.Decimals), indexer}, nil
+}
+
+func (c *Client) getIndexer() (*rest.Client, error) {
+	if c.indexer == nil {
+		return nil, fmt.Errorf("must set .indexer_url to a kasplex indexer for KRC-20 tokens")
+	}
+	return c.indexer, nil
 }
 
 func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
@@ -60,6 +79,9 @@ func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Transfer
 	}
 	txInput := tx_input.NewTxInput()
 	txInput.Address = args.GetFrom()
+	if contract, ok := args.GetContract(); ok && contract != "" {
+		txInput.CommitAmount = xc.NewAmountBlockchainFromUint64(defaultCommitAmount)
+	}
 	for _, utxo := range utxos {
 		if utxo.UtxoEntry.Amount == nil {
 			// skip?
@@ -94,9 +116,23 @@ func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Transfer
 	txMassCalculator := txmass.NewCalculator(defaultMassPerTxByte, defaultMassPerScriptPubKeyByte, defaultMassPerSigOp)
 	transactionMass := txMassCalculator.CalculateTransactionMass(domainTransaction)
 
-	txInput.Mass = transactionMass
-	if c.chainCfg.ChainGasMultiplier > 0.01 {
-		txInput.Mass = uint64(float64(transactionMass) * c.chainCfg.ChainGasMultiplier)
+	txInput.Mass = c.applyGasMultiplier(transactionMass)
+
+	if kaspaTx.IsKrc20() {
+		revealTransaction, err := kaspaTx.BuildUnsignedRevealTransaction(domainTransaction)
+		if err != nil {
+			return nil, err
+		}
+		// include a placeholder signature, as the redeem script makes up most of the reveal mass
+		redeemScript, err := kaspaTx.RedeemScript()
+		if err != nil {
+			return nil, err
+		}
+		revealTransaction.Inputs[0].SignatureScript, err = tx.NewRevealSignatureScript(make([]byte, 65), redeemScript)
+		if err != nil {
+			return nil, err
+		}
+		txInput.RevealMass = c.applyGasMultiplier(txMassCalculator.CalculateTransactionMass(revealTransaction))
 	}
 	txInput.FeePerGram = feeRates.GetMostNormalFeeEstimate()
 	txInput.MinFee = c.chainCfg.GasBudgetMinimum.ToBlockchain(int32(c.chainCfg.Decimals))
@@ -104,6 +140,13 @@ func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Transfer
 	return txInput, nil
 }
 
+func (c *Client) applyGasMultiplier(mass uint64) uint64 {
+	if c.chainCfg.ChainGasMultiplier > 0.01 {
+		return uint64(float64(mass) * c.chainCfg.ChainGasMultiplier)
+	}
+	return mass
+}
+
 func (c *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, to xc.Address) (xc.TxInput, error) {
 	chainCfg := c.chainCfg.Base()
 	args, _ := xcbuilder.NewTransferArgs(chainCfg, from, to, xc.NewAmountBlockchainFromUint64(1))
@@ -115,6 +158,31 @@ func (c *Client) SubmitTx(ctx context.Context, txInput xctypes.SubmitTxReq) erro
 	if err != nil {
 		return err
 	}
+	if err := c.submitTransaction(serializedSigned); err != nil {
+		return err
+	}
+
+	metadataBz, ok, err := txInput.GetMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to get tx metadata: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+	var metadata tx.BroadcastMetadata
+	if err := json.Unmarshal(metadataBz, &metadata); err != nil {
+		return fmt.Errorf("failed to unmarshal tx metadata: %w", err)
+	}
+	if len(metadata.RevealTransaction) > 0 {
+		// the reveal spends the commit output from the mempool
+		if err := c.submitTransaction(metadata.RevealTransaction); err != nil {
+			return fmt.Errorf("failed to submit KRC-20 reveal transaction: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) submitTransaction(serializedSigned []byte) error {
 	response, err := c.client.SubmitTransaction(serializedSigned)
 	if err != nil {
 		if strings.Contains(err.Error(), "larger than max allowed size") {
@@ -123,7 +191,7 @@ func (c *Client) SubmitTx(ctx context.Context, txInput xctypes.SubmitTxReq) erro
 		return err
 	}
 	logrus.WithFields(logrus.Fields{
-		"txid": *response.TransactionId,
+		"txid": derefOrZero(response.TransactionId),
 	}).Debug("submitted kaspa tx")
 	return nil
 }
@@ -205,10 +273,71 @@ func (c *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinfo.TxI
 	// The fee is the natural difference of (inputs - outputs)
 	txInfo.Fees = txInfo.CalculateFees()
 
+	// Only reveal transactions, which spend the P2SH address of an inscription, can transfer KRC-20 tokens
+	if c.indexer != nil && spendsScriptHash(derefOrZero(tx.Inputs)) {
+		tokenMovement, err := c.fetchKrc20Movement(txId)
+		if err != nil {
+			// the native movements are still reported
+			logrus.WithError(err).WithField("tx", txId).Warn("could not fetch KRC-20 operation")
+		} else if tokenMovement != nil {
+			txInfo.AddMovement(tokenMovement)
+		}
+	}
+
 	return *txInfo, nil
 }
 
+// spendsScriptHash reports whether any of the inputs spends a P2SH address
+func spendsScriptHash(inputs []rest.TxInput) bool {
+	for _, input := range inputs {
+		if input.PreviousOutpointAddress == nil {
+			continue
+		}
+		address, err := util.DecodeAddress(*input.PreviousOutpointAddress, util.Bech32PrefixUnknown)
+		if err != nil {
+			continue
+		}
+		if _, ok := address.(*util.AddressScriptHash); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchKrc20Movement returns the token transfer inscribed by a reveal transaction, if it was applied
+func (c *Client) fetchKrc20Movement(txId string) (*txinfo.Movement, error) {
+	operation, err := c.indexer.GetKrc20Operation(txId)
+	if apiErr, ok := err.(*rest.ErrorResponse); ok && apiErr.Code == 404 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch KRC-20 operation: %w", err)
+	}
+	if operation == nil || operation.Op != "transfer" || operation.OpAccept != rest.Krc20OpAccepted {
+		return nil, nil
+	}
+	amount := xc.NewAmountBlockchainFromStr(operation.Amt)
+	movement := txinfo.NewMovement(c.chain, xc.ContractAddress(operation.Tick))
+	movement.AddSource(xc.Address(operation.From), amount, nil)
+	movement.AddDestination(xc.Address(operation.To), amount, nil)
+	return movement, nil
+}
+
 func (c *Client) FetchBalance(ctx context.Context, args *xclient.BalanceArgs) (xc.AmountBlockchain, error) {
+	if contract, ok := args.Contract(); ok && contract != xc.ContractAddress(c.chain) {
+		indexer, err := c.getIndexer()
+		if err != nil {
+			return xc.AmountBlockchain{}, err
+		}
+		balance, err := indexer.GetKrc20Balance(string(args.Address()), string(contract))
+		if err != nil {
+			return xc.AmountBlockchain{}, err
+		}
+		if balance == nil {
+			return xc.NewAmountBlockchainFromUint64(0), nil
+		}
+		return xc.NewAmountBlockchainFromStr(balance.Balance), nil
+	}
 	utxos, err := c.client.GetUtxos([]string{string(args.Address())})
 	if err != nil {
 		return xc.AmountBlockchain{}, err
@@ -224,9 +353,20 @@ func (c *Client) FetchBalance(ctx context.Context, args *xclient.BalanceArgs) (x
 }
 
 func (c *Client) FetchDecimals(ctx context.Context, contract xc.ContractAddress) (int, error) {
-	// no tokens on kaspa currently
 	if contract != "" && contract != xc.ContractAddress(c.chain) {
-		return 0, fmt.Errorf("no tokens on kaspa currently")
+		indexer, err := c.getIndexer()
+		if err != nil {
+			return 0, err
+		}
+		token, err := indexer.GetKrc20Token(string(contract))
+		if err != nil {
+			return 0, err
+		}
+		decimals, err := strconv.Atoi(token.Dec)
+		if err != nil {
+			return 0, fmt.Errorf("invalid decimals of KRC-20 token %s: %w", contract, err)
+		}
+		return decimals, nil
 	}
 	return c.decimals, nil
 }
@@ -0,0 +1,122 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/kaspa/client"
+	xclient "github.com/cordialsys/crosschain/client"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newIndexerServer(t *testing.T, responses map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestKrc20BalanceAndDecimals(t *testing.T) {
+	address := xc.Address("kaspa:qyp2uxq7rl0a95npw0yay62chv22l4f33hd8nrrs5hc4ajq3xhdnszcxytgxr6")
+	server := newIndexerServer(t, map[string]string{
+		"/v1/krc20/address/" + string(address) + "/token/NACHO": `{"message":"successful","result":[{"tick":"NACHO","balance":"123400000000","locked":"0","dec":"8"}]}`,
+		"/v1/krc20/address/" + string(address) + "/token/KASPY": `{"message":"successful","result":[]}`,
+		"/v1/krc20/token/NACHO":                                 `{"message":"successful","result":[{"tick":"NACHO","max":"287000000000000000","dec":"8","state":"finished"}]}`,
+	})
+	cfg := xc.NewChainConfig(xc.KAS).WithUrl(server.URL).WithIndexer("", server.URL).WithDecimals(8)
+	kaspaClient, err := client.NewClient(cfg)
+	require.NoError(t, err)
+
+	balance, err := kaspaClient.FetchBalance(context.Background(), xclient.NewBalanceArgs(address, xclient.BalanceOptionContract("NACHO")))
+	require.NoError(t, err)
+	require.Equal(t, "123400000000", balance.String())
+
+	balance, err = kaspaClient.FetchBalance(context.Background(), xclient.NewBalanceArgs(address, xclient.BalanceOptionContract("KASPY")))
+	require.NoError(t, err)
+	require.Equal(t, "0", balance.String())
+
+	decimals, err := kaspaClient.FetchDecimals(context.Background(), "NACHO")
+	require.NoError(t, err)
+	require.Equal(t, 8, decimals)
+
+	_, err = kaspaClient.FetchDecimals(context.Background(), "UNKNOWN")
+	require.Error(t, err)
+
+	decimals, err = kaspaClient.FetchDecimals(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, 8, decimals)
+}
+
+func TestKrc20RequiresIndexer(t *testing.T) {
+	kaspaClient, err := client.NewClient(xc.NewChainConfig(xc.KAS).WithUrl("http://localhost"))
+	require.NoError(t, err)
+	_, err = kaspaClient.FetchDecimals(context.Background(), "NACHO")
+	require.ErrorContains(t, err, "indexer_url")
+}
+
+func TestFetchTxInfoKrc20(t *testing.T) {
+	sender := "kaspa:qyp2uxq7rl0a95npw0yay62chv22l4f33hd8nrrs5hc4ajq3xhdnszcxytgxr6"
+	receiver := "kaspa:qypr7ayn2xkhl3v6a6vvrfmqpkd6ct2rm6ygq6f2lr6fmgqjsnx8gvqmgtmxqmz"
+	inscription, err := util.NewAddressScriptHash([]byte{0x51}, util.Bech32PrefixKaspa)
+	require.NoError(t, err)
+
+	txModel := func(txId string, from string) string {
+		return `{
+			"transaction_id": "` + txId + `",
+			"block_hash": ["blockhash"],
+			"block_time": 1710000000000,
+			"accepting_block_blue_score": 990,
+			"inputs": [{"previous_outpoint_address": "` + from + `", "previous_outpoint_amount": 30000000, "previous_outpoint_hash": "commit", "previous_outpoint_index": "0", "transaction_id": "` + txId + `"}],
+			"outputs": [{"script_public_key_address": "` + sender + `", "amount": 29990000, "index": 0, "transaction_id": "` + txId + `"}]
+		}`
+	}
+	operation := func(txId string) string {
+		return `{"message":"successful","result":[{"p":"krc-20","op":"transfer","tick":"NACHO","amt":"500000000","from":"` + sender + `","to":"` + receiver + `","opAccept":"1","hashRev":"` + txId + `"}]}`
+	}
+	server := newIndexerServer(t, map[string]string{
+		"/info/virtual-chain-blue-score": `{"blueScore": 1000}`,
+		"/transactions/reveal":           txModel("reveal", inscription.String()),
+		"/transactions/payment":          txModel("payment", sender),
+		"/v1/krc20/op/reveal":            operation("reveal"),
+		// the indexer is not queried for transactions that cannot transfer tokens
+		"/v1/krc20/op/payment": operation("payment"),
+	})
+	cfg := xc.NewChainConfig(xc.KAS).WithUrl(server.URL).WithIndexer("", server.URL).WithDecimals(8)
+	kaspaClient, err := client.NewClient(cfg)
+	require.NoError(t, err)
+
+	info, err := kaspaClient.FetchTxInfo(context.Background(), txinfo.NewArgs("reveal"))
+	require.NoError(t, err)
+	require.Len(t, info.Movements, 2)
+	token := info.Movements[1]
+	require.EqualValues(t, "NACHO", token.AssetId)
+	require.EqualValues(t, sender, token.From[0].AddressId)
+	require.EqualValues(t, receiver, token.To[0].AddressId)
+	require.Equal(t, "500000000", token.To[0].Balance.String())
+
+	info, err = kaspaClient.FetchTxInfo(context.Background(), txinfo.NewArgs("payment"))
+	require.NoError(t, err)
+	require.Len(t, info.Movements, 1)
+	require.EqualValues(t, xc.KAS, info.Movements[0].AssetId)
+
+	// the native movements are reported when the indexer is unavailable
+	cfg = xc.NewChainConfig(xc.KAS).WithUrl(server.URL).WithIndexer("", "http://127.0.0.1:1").WithDecimals(8)
+	kaspaClient, err = client.NewClient(cfg)
+	require.NoError(t, err)
+	info, err = kaspaClient.FetchTxInfo(context.Background(), txinfo.NewArgs("reveal"))
+	require.NoError(t, err)
+	require.Len(t, info.Movements, 1)
+	require.EqualValues(t, 10, info.Confirmations)
+}
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Responses of a Kasplex indexer, which tracks the KRC-20 inscriptions
+type Krc20Response[T any] struct {
+	Message string `json:"message"`
+	Result  []T    `json:"result"`
+}
+
+type Krc20Balance struct {
+	Tick    string `json:"tick"`
+	Balance string `json:"balance"`
+	Locked  string `json:"locked"`
+	Dec     string `json:"dec"`
+}
+
+type Krc20Token struct {
+	Tick  string `json:"tick"`
+	Max   string `json:"max"`
+	Dec   string `json:"dec"`
+	State string `json:"state"`
+}
+
+type Krc20Operation struct {
+	P    string `json:"p"`
+	Op   string `json:"op"`
+	Tick string `json:"tick"`
+	Amt  string `json:"amt"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	// "1" when the operation was applied, "-1" when it failed
+	OpAccept string `json:"opAccept"`
+	OpError  string `json:"opError"`
+	HashRev  string `json:"hashRev"`
+}
+
+// Value of `opAccept` for applied operations
+const Krc20OpAccepted = "1"
+
+func getKrc20[T any](cli *Client, path string) ([]T, error) {
+	var response Krc20Response[T]
+	if err := cli.Do("GET", path, nil, &response); err != nil {
+		return nil, err
+	}
+	if response.Message != "" && response.Message != "successful" {
+		return nil, fmt.Errorf("indexer error: %s", response.Message)
+	}
+	return response.Result, nil
+}
+
+// GetKrc20Balance returns the balance of a KRC-20 token, or nil if the address never held it
+func (cli *Client) GetKrc20Balance(address string, tick string) (*Krc20Balance, error) {
+	balances, err := getKrc20[Krc20Balance](cli, fmt.Sprintf("/v1/krc20/address/%s/token/%s", address, url.PathEscape(tick)))
+	if err != nil {
+		return nil, err
+	}
+	if len(balances) == 0 {
+		return nil, nil
+	}
+	return &balances[0], nil
+}
+
+func (cli *Client) GetKrc20Token(tick string) (*Krc20Token, error) {
+	tokens, err := getKrc20[Krc20Token](cli, fmt.Sprintf("/v1/krc20/token/%s", url.PathEscape(tick)))
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("KRC-20 token %s not found", tick)
+	}
+	return &tokens[0], nil
+}
+
+// GetKrc20Operation returns the KRC-20 operation inscribed by a reveal transaction, or nil if there is none
+func (cli *Client) GetKrc20Operation(txId string) (*Krc20Operation, error) {
+	operations, err := getKrc20[Krc20Operation](cli, fmt.Sprintf("/v1/krc20/op/%s", txId))
+	if err != nil {
+		return nil, err
+	}
+	if len(operations) == 0 {
+		return nil, nil
+	}
+	return &operations[0], nil
+}
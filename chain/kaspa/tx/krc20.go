@@ -0,0 +1,72 @@
+package tx
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cordialsys/crosschain/chain/kaspa/tx/txscript"
+	"github.com/kaspanet/kaspad/util"
+)
+
+// Protocol id of the Kasplex inscriptions, which KRC-20 is defined on
+const KasplexProtocol = "kasplex"
+
+const Krc20 = "krc-20"
+
+// Krc20Operation is the JSON inscribed in the reveal transaction
+type Krc20Operation struct {
+	P    string `json:"p"`
+	Op   string `json:"op"`
+	Tick string `json:"tick"`
+	Amt  string `json:"amt"`
+	To   string `json:"to,omitempty"`
+}
+
+func NewKrc20Transfer(tick string, amount string, to string) Krc20Operation {
+	return Krc20Operation{
+		P:    Krc20,
+		Op:   "transfer",
+		Tick: tick,
+		Amt:  amount,
+		To:   to,
+	}
+}
+
+// NewInscriptionScript returns the redeem script of a Kasplex inscription. It can only be spent by the
+// owner of the public key, and the envelope after the signature check is never executed:
+//
+//	<pubkey> OP_CHECKSIG OP_FALSE OP_IF "kasplex" 0 <operation json> OP_ENDIF
+func NewInscriptionScript(publicKey []byte, operation Krc20Operation) ([]byte, error) {
+	operationBz, err := json.Marshal(operation)
+	if err != nil {
+		return nil, err
+	}
+	return txscript.NewScriptBuilder().
+		AddData(publicKey).
+		AddOp(txscript.OpCheckSig).
+		AddOp(txscript.OpFalse).
+		AddOp(txscript.OpIf).
+		AddData([]byte(KasplexProtocol)).
+		AddInt64(0).
+		AddData(operationBz).
+		AddOp(txscript.OpEndIf).
+		Script()
+}
+
+// NewRevealSignatureScript returns the signature script spending an inscription from its P2SH address
+func NewRevealSignatureScript(signature []byte, redeemScript []byte) ([]byte, error) {
+	signatureData, err := txscript.NewScriptBuilder().AddData(signature).Script()
+	if err != nil {
+		return nil, err
+	}
+	return txscript.PayToScriptHashSignatureScript(redeemScript, signatureData)
+}
+
+// publicKey returns the schnorr public key in a kaspa address
+func publicKey(address util.Address) ([]byte, error) {
+	publicKeyAddress, ok := address.(*util.AddressPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("KRC-20 transfers must be sent from a schnorr address, not %s", address.String())
+	}
+	return publicKeyAddress.ScriptAddress(), nil
+}
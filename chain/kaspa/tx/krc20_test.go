@@ -0,0 +1,169 @@
+package tx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/kaspa/tx"
+	"github.com/cordialsys/crosschain/chain/kaspa/tx/txscript"
+	"github.com/cordialsys/crosschain/chain/kaspa/tx_input"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newKrc20Tx(t *testing.T) *tx.Tx {
+	publicKey := bytes.Repeat([]byte{0x11}, 32)
+	from, err := util.NewAddressPublicKey(publicKey, util.Bech32PrefixKaspa)
+	require.NoError(t, err)
+	to, err := util.NewAddressPublicKey(bytes.Repeat([]byte{0x22}, 32), util.Bech32PrefixKaspa)
+	require.NoError(t, err)
+
+	args, err := xcbuilder.NewTransferArgs(
+		xc.NewChainConfig(xc.KAS).Base(),
+		xc.Address(from.String()),
+		xc.Address(to.String()),
+		xc.NewAmountBlockchainFromUint64(100_000_000),
+		xcbuilder.OptionContractAddress("NACHO"),
+	)
+	require.NoError(t, err)
+
+	input := tx_input.NewTxInput()
+	input.Utxos = []tx_input.Utxo{
+		{
+			TransactionId: hex.EncodeToString(bytes.Repeat([]byte{0x01}, 32)),
+			Index:         1,
+			Amount:        xc.NewAmountBlockchainFromUint64(500_000_000),
+		},
+	}
+	input.FeePerGram = xc.NewAmountBlockchainFromUint64(1)
+	input.Mass = 2000
+	input.RevealMass = 3000
+	input.CommitAmount = xc.NewAmountBlockchainFromUint64(30_000_000)
+	return tx.NewTx(args, input, uint64(util.Bech32PrefixKaspa))
+}
+
+func TestInscriptionScript(t *testing.T) {
+	publicKey := bytes.Repeat([]byte{0x11}, 32)
+	operation := tx.NewKrc20Transfer("NACHO", "100", "kaspa:to")
+	script, err := tx.NewInscriptionScript(publicKey, operation)
+	require.NoError(t, err)
+
+	pushes, err := txscript.PushedData(script)
+	require.NoError(t, err)
+	require.Len(t, pushes, 5)
+	require.Equal(t, publicKey, pushes[0])
+	// OP_FALSE
+	require.Empty(t, pushes[1])
+	require.Equal(t, "kasplex", string(pushes[2]))
+	// 0
+	require.Empty(t, pushes[3])
+	require.JSONEq(t, `{"p":"krc-20","op":"transfer","tick":"NACHO","amt":"100","to":"kaspa:to"}`, string(pushes[4]))
+
+	require.Equal(t, byte(txscript.OpCheckSig), script[33])
+	require.Equal(t, byte(txscript.OpEndIf), script[len(script)-1])
+}
+
+func TestKrc20Transfer(t *testing.T) {
+	krc20Tx := newKrc20Tx(t)
+	require.True(t, krc20Tx.IsKrc20())
+
+	commit, err := krc20Tx.BuildUnsignedDomainTransaction()
+	require.NoError(t, err)
+	redeemScript, err := krc20Tx.RedeemScript()
+	require.NoError(t, err)
+	p2sh, err := txscript.PayToScriptHashScript(redeemScript)
+	require.NoError(t, err)
+
+	// commit pays to the inscription and returns the change
+	require.Len(t, commit.Outputs, 2)
+	require.Equal(t, p2sh, commit.Outputs[0].ScriptPublicKey.Script)
+	require.EqualValues(t, 30_000_000, commit.Outputs[0].Value)
+	require.EqualValues(t, 500_000_000-30_000_000-2000, commit.Outputs[1].Value)
+
+	// reveal spends the inscription back to the sender
+	reveal, err := krc20Tx.BuildUnsignedRevealTransaction(commit)
+	require.NoError(t, err)
+	require.Len(t, reveal.Inputs, 1)
+	require.Equal(t, *consensushashing.TransactionID(commit), reveal.Inputs[0].PreviousOutpoint.TransactionID)
+	require.EqualValues(t, 0, reveal.Inputs[0].PreviousOutpoint.Index)
+	require.Len(t, reveal.Outputs, 1)
+	require.EqualValues(t, 30_000_000-3000, reveal.Outputs[0].Value)
+	require.Equal(t, commit.Inputs[0].UTXOEntry.ScriptPublicKey(), reveal.Outputs[0].ScriptPublicKey)
+}
+
+func TestKrc20Signing(t *testing.T) {
+	krc20Tx := newKrc20Tx(t)
+
+	// first round signs the commit
+	sighashes, err := krc20Tx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+	_, err = krc20Tx.AdditionalSighashes()
+	require.ErrorContains(t, err, "not signed")
+
+	commitSignature := &xc.SignatureResponse{Signature: bytes.Repeat([]byte{0xaa}, 64)}
+	require.NoError(t, krc20Tx.SetSignatures(commitSignature))
+	_, err = krc20Tx.Serialize()
+	require.ErrorContains(t, err, "reveal transaction not signed")
+
+	// second round signs the reveal
+	additional, err := krc20Tx.AdditionalSighashes()
+	require.NoError(t, err)
+	require.Len(t, additional, 1)
+	require.NotEqual(t, sighashes[0].Payload, additional[0].Payload)
+
+	revealSignature := &xc.SignatureResponse{Signature: bytes.Repeat([]byte{0xbb}, 64)}
+	require.NoError(t, krc20Tx.SetSignatures(commitSignature, revealSignature))
+	additional, err = krc20Tx.AdditionalSighashes()
+	require.NoError(t, err)
+	require.Empty(t, additional)
+
+	commitBz, err := krc20Tx.Serialize()
+	require.NoError(t, err)
+	metadataBz, ok, err := krc20Tx.GetMetadata()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	var metadata tx.BroadcastMetadata
+	require.NoError(t, json.Unmarshal(metadataBz, &metadata))
+	var commitMsg, revealMsg tx.TransactionMessage
+	require.NoError(t, json.Unmarshal(commitBz, &commitMsg))
+	require.NoError(t, json.Unmarshal(metadata.RevealTransaction, &revealMsg))
+
+	// the reveal signature script pushes the signature and the inscription
+	redeemScript, err := krc20Tx.RedeemScript()
+	require.NoError(t, err)
+	signatureScript, err := hex.DecodeString(revealMsg.Transaction.Inputs[0].SignatureScript)
+	require.NoError(t, err)
+	pushes, err := txscript.PushedData(signatureScript)
+	require.NoError(t, err)
+	require.Equal(t, append(bytes.Repeat([]byte{0xbb}, 64), byte(consensushashing.SigHashAll)), pushes[0])
+	require.Equal(t, redeemScript, pushes[1])
+
+	// the hash is the reveal id, which does not depend on the signatures
+	commit, err := krc20Tx.BuildUnsignedDomainTransaction()
+	require.NoError(t, err)
+	reveal, err := krc20Tx.BuildUnsignedRevealTransaction(commit)
+	require.NoError(t, err)
+	require.Equal(t, consensushashing.TransactionID(reveal).String(), string(krc20Tx.Hash()))
+	require.Equal(t, consensushashing.TransactionID(commit).String(), revealMsg.Transaction.Inputs[0].PreviousOutpoint.TransactionId)
+	require.Equal(t, commit.Inputs[0].PreviousOutpoint.TransactionID.String(), commitMsg.Transaction.Inputs[0].PreviousOutpoint.TransactionId)
+}
+
+func TestKrc20FeeLimit(t *testing.T) {
+	input := tx_input.NewTxInput()
+	input.FeePerGram = xc.NewAmountBlockchainFromUint64(2)
+	input.MinFee = xc.NewAmountBlockchainFromUint64(1000)
+	input.Mass = 2000
+	fee, _ := input.GetFeeLimit()
+	require.EqualValues(t, 4000, fee.Uint64())
+
+	input.RevealMass = 100
+	fee, _ = input.GetFeeLimit()
+	require.EqualValues(t, 5000, fee.Uint64())
+}
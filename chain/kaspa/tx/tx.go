@@ -2,6 +2,7 @@ package tx
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -26,6 +27,8 @@ type Tx struct {
 	chainPrefix             util.Bech32Prefix
 	signedDomainTransaction *externalapi.DomainTransaction
 	signatures              []xc.TxSignature
+	// KRC-20 transfers have a second transaction, revealing the inscription of the first
+	signedRevealTransaction *externalapi.DomainTransaction
 }
 
 func NewTx(args xcbuilder.TransferArgs, input *tx_input.TxInput, chainPrefix uint64) *Tx {
@@ -35,19 +38,50 @@ func NewTx(args xcbuilder.TransferArgs, input *tx_input.TxInput, chainPrefix uin
 		util.Bech32Prefix(chainPrefix),
 		nil,
 		nil,
+		nil,
 	}
 }
 
 var _ xc.Tx = &Tx{}
+var _ xc.TxAdditionalSighashes = &Tx{}
+var _ xc.TxWithMetadata = &Tx{}
 
 const hashType = consensushashing.SigHashAll
 
-// Hash returns the tx hash or id
+// Hash returns the tx hash or id. For KRC-20 transfers it's the id of the reveal transaction.
 func (tx Tx) Hash() xc.TxHash {
+	if tx.signedRevealTransaction != nil {
+		domainId := consensushashing.TransactionID(tx.signedRevealTransaction)
+		return xc.TxHash(hex.EncodeToString(domainId.ByteSlice()))
+	}
 	domainId := consensushashing.TransactionID(tx.signedDomainTransaction)
 	return xc.TxHash(hex.EncodeToString(domainId.ByteSlice()))
 }
 
+// IsKrc20 returns true for transfers of a KRC-20 token, which is identified by its ticker
+func (tx Tx) IsKrc20() bool {
+	contract, ok := tx.args.GetContract()
+	return ok && contract != ""
+}
+
+// RedeemScript returns the inscription of a KRC-20 transfer
+func (tx Tx) RedeemScript() ([]byte, error) {
+	fromAddress, err := util.DecodeAddress(string(tx.args.GetFrom()), tx.chainPrefix)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := publicKey(fromAddress)
+	if err != nil {
+		return nil, err
+	}
+	contract, _ := tx.args.GetContract()
+	amount := tx.args.GetAmount()
+	operation := NewKrc20Transfer(string(contract), amount.String(), string(tx.args.GetTo()))
+	return NewInscriptionScript(publicKey, operation)
+}
+
+// BuildUnsignedDomainTransaction builds the transaction spending the utxos. For KRC-20 transfers this
+// is the commit transaction, paying to the P2SH address of the inscription.
 func (tx Tx) BuildUnsignedDomainTransaction() (*externalapi.DomainTransaction, error) {
 	txInput := tx.input
 	var totalInput uint64
@@ -88,22 +122,36 @@ func (tx Tx) BuildUnsignedDomainTransaction() (*externalapi.DomainTransaction, e
 	}
 
 	var outputs []*externalapi.DomainTransactionOutput
-	toAddress, err := util.DecodeAddress(string(tx.args.GetTo()), prefix)
-	if err != nil {
-		return nil, err
+	var toAddress util.Address
+	toAmount := tx.args.GetAmount().Uint64()
+	if tx.IsKrc20() {
+		redeemScript, err := tx.RedeemScript()
+		if err != nil {
+			return nil, err
+		}
+		toAddress, err = util.NewAddressScriptHash(redeemScript, prefix)
+		if err != nil {
+			return nil, err
+		}
+		toAmount = txInput.CommitAmount.Uint64()
+	} else {
+		var err error
+		toAddress, err = util.DecodeAddress(string(tx.args.GetTo()), prefix)
+		if err != nil {
+			return nil, err
+		}
 	}
 	scriptPublicKey, err := txscript.PayToAddrScript(toAddress)
 	if err != nil {
 		return nil, err
 	}
-	toAmount := tx.args.GetAmount().Uint64()
 	outputs = append(outputs, &externalapi.DomainTransactionOutput{
 		Value:           toAmount,
 		ScriptPublicKey: scriptPublicKey,
 	})
 
-	// fee limit produces the exact fee estimate
-	feeBigInt, _ := txInput.GetFeeLimit()
+	// commit fee produces the exact fee estimate
+	feeBigInt := txInput.CommitFee()
 	fee := feeBigInt.Uint64()
 	// handle remainder/change
 	if totalInput > toAmount+fee {
@@ -135,6 +183,64 @@ func (tx Tx) BuildUnsignedDomainTransaction() (*externalapi.DomainTransaction, e
 	return domainTransaction, nil
 }
 
+// BuildUnsignedRevealTransaction builds the KRC-20 reveal transaction, which spends the P2SH output
+// of the commit transaction back to the sender
+func (tx Tx) BuildUnsignedRevealTransaction(commitTransaction *externalapi.DomainTransaction) (*externalapi.DomainTransaction, error) {
+	prefix := tx.chainPrefix
+	redeemScript, err := tx.RedeemScript()
+	if err != nil {
+		return nil, err
+	}
+	commitAddress, err := util.NewAddressScriptHash(redeemScript, prefix)
+	if err != nil {
+		return nil, err
+	}
+	commitScriptPublicKey, err := txscript.PayToAddrScript(commitAddress)
+	if err != nil {
+		return nil, err
+	}
+	commitAmount := tx.input.CommitAmount.Uint64()
+	fee := tx.input.RevealFee()
+	if commitAmount <= fee.Uint64() {
+		return nil, fmt.Errorf("commit amount %d does not cover the reveal fee %d", commitAmount, fee.Uint64())
+	}
+
+	fromAddress, err := util.DecodeAddress(string(tx.args.GetFrom()), prefix)
+	if err != nil {
+		return nil, err
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(fromAddress)
+	if err != nil {
+		return nil, err
+	}
+	// the commit transaction id does not include the signature scripts, so it's known before signing
+	commitId := consensushashing.TransactionID(commitTransaction)
+	return &externalapi.DomainTransaction{
+		Version: constants.MaxTransactionVersion,
+		Inputs: []*externalapi.DomainTransactionInput{{
+			PreviousOutpoint: externalapi.DomainOutpoint{
+				TransactionID: *commitId,
+				Index:         0,
+			},
+			SigOpCount: 1,
+			UTXOEntry: utxo.NewUTXOEntry(
+				commitAmount,
+				commitScriptPublicKey,
+				false,
+				0,
+			),
+		}},
+		Outputs: []*externalapi.DomainTransactionOutput{{
+			Value:           commitAmount - fee.Uint64(),
+			ScriptPublicKey: scriptPublicKey,
+		}},
+		LockTime:     0,
+		SubnetworkID: subnetworks.SubnetworkIDNative,
+		Gas:          0,
+		Payload:      nil,
+	}, nil
+}
+
 // Sighashes returns the tx payload to sign, aka sighash
 func (tx Tx) Sighashes() ([]*xc.SignatureRequest, error) {
 	domainTransaction, err := tx.BuildUnsignedDomainTransaction()
@@ -160,12 +266,44 @@ func (tx Tx) Sighashes() ([]*xc.SignatureRequest, error) {
 	return signRequests, nil
 }
 
-// Add signature script for each transaction input to spend it
+// AdditionalSighashes returns the sighash of the KRC-20 reveal transaction, once the commit transaction is signed
+func (tx Tx) AdditionalSighashes() ([]*xc.SignatureRequest, error) {
+	if !tx.IsKrc20() || tx.signedRevealTransaction != nil {
+		return nil, nil
+	}
+	if tx.signedDomainTransaction == nil {
+		return nil, errors.New("kaspa commit transaction not signed")
+	}
+	revealTransaction, err := tx.BuildUnsignedRevealTransaction(tx.signedDomainTransaction)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := consensushashing.CalculateSignatureHashSchnorr(
+		revealTransaction,
+		0,
+		hashType,
+		&consensushashing.SighashReusedValues{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return []*xc.SignatureRequest{
+		{Payload: hash.ByteSlice()},
+	}, nil
+}
+
+// Add signature script for each transaction input to spend it. For KRC-20 transfers, the signature
+// following the ones of the commit transaction is for the reveal transaction.
 func (tx *Tx) SetSignatures(signatures ...*xc.SignatureResponse) error {
 	domainTransaction, err := tx.BuildUnsignedDomainTransaction()
 	if err != nil {
 		return err
 	}
+	var revealSignature *xc.SignatureResponse
+	if tx.IsKrc20() && len(signatures) == len(domainTransaction.Inputs)+1 {
+		revealSignature = signatures[len(signatures)-1]
+		signatures = signatures[:len(signatures)-1]
+	}
 	if len(signatures) != len(domainTransaction.Inputs) {
 		return fmt.Errorf("expected %d signatures for kaspa tx, got %d", len(domainTransaction.Inputs), len(signatures))
 	}
@@ -181,13 +319,59 @@ func (tx *Tx) SetSignatures(signatures ...*xc.SignatureResponse) error {
 		tx.signatures[i] = signatures[i].Signature
 	}
 	tx.signedDomainTransaction = domainTransaction
+	tx.signedRevealTransaction = nil
+
+	if revealSignature != nil {
+		revealTransaction, err := tx.BuildUnsignedRevealTransaction(domainTransaction)
+		if err != nil {
+			return err
+		}
+		redeemScript, err := tx.RedeemScript()
+		if err != nil {
+			return err
+		}
+		signature := append(append([]byte{}, revealSignature.Signature...), byte(hashType))
+		signatureScript, err := NewRevealSignatureScript(signature, redeemScript)
+		if err != nil {
+			return fmt.Errorf("could not add kaspa signature for reveal: %w", err)
+		}
+		revealTransaction.Inputs[0].SignatureScript = signatureScript
+		tx.signatures = append(tx.signatures, revealSignature.Signature)
+		tx.signedRevealTransaction = revealTransaction
+	}
 
 	return nil
 }
 
+// Serialize returns the transaction spending the utxos, the KRC-20 reveal transaction is in the metadata
 func (tx Tx) Serialize() ([]byte, error) {
 	if tx.signedDomainTransaction == nil {
 		return nil, errors.New("kaspa transaction not signed")
 	}
+	if tx.IsKrc20() && tx.signedRevealTransaction == nil {
+		return nil, errors.New("kaspa reveal transaction not signed")
+	}
 	return serialize(tx.signedDomainTransaction)
 }
+
+type BroadcastMetadata struct {
+	// The KRC-20 reveal transaction, submitted after the commit transaction
+	RevealTransaction json.RawMessage `json:"reveal_transaction,omitempty"`
+}
+
+func (tx Tx) GetMetadata() ([]byte, bool, error) {
+	if tx.signedRevealTransaction == nil {
+		return nil, false, nil
+	}
+	revealBz, err := serialize(tx.signedRevealTransaction)
+	if err != nil {
+		return nil, false, err
+	}
+	metadataBz, err := json.Marshal(BroadcastMetadata{
+		RevealTransaction: revealBz,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	return metadataBz, true, nil
+}
@@ -481,14 +481,15 @@ var opcodeArray = [256]opcode{
 	OpWithin:             {OpWithin, "OP_WITHIN", 1, opcodeWithin},
 
 	// Crypto opcodes.
-	// OpCheckMultiSigECDSA: {OpCheckMultiSigECDSA, "OP_CHECKMULTISIGECDSA", 1, opcodeCheckMultiSigECDSA},
-	OpSHA256:  {OpSHA256, "OP_SHA256", 1, opcodeSha256},
-	OpBlake2b: {OpBlake2b, "OP_BLAKE2B", 1, opcodeBlake2b},
-	// OpCheckSigECDSA:      {OpCheckSigECDSA, "OP_CHECKSIGECDSA", 1, opcodeCheckSigECDSA},
-	// OpCheckSig:            {OpCheckSig, "OP_CHECKSIG", 1, opcodeCheckSig},
-	// OpCheckSigVerify:      {OpCheckSigVerify, "OP_CHECKSIGVERIFY", 1, opcodeCheckSigVerify},
-	// OpCheckMultiSig:       {OpCheckMultiSig, "OP_CHECKMULTISIG", 1, opcodeCheckMultiSig},
-	// OpCheckMultiSigVerify: {OpCheckMultiSigVerify, "OP_CHECKMULTISIGVERIFY", 1, opcodeCheckMultiSigVerify},
+	// xc: the signature checks are kept so that scripts using them can be parsed, but they can't be executed
+	OpCheckMultiSigECDSA:  {OpCheckMultiSigECDSA, "OP_CHECKMULTISIGECDSA", 1, opcodeSignatureCheck},
+	OpSHA256:              {OpSHA256, "OP_SHA256", 1, opcodeSha256},
+	OpBlake2b:             {OpBlake2b, "OP_BLAKE2B", 1, opcodeBlake2b},
+	OpCheckSigECDSA:       {OpCheckSigECDSA, "OP_CHECKSIGECDSA", 1, opcodeSignatureCheck},
+	OpCheckSig:            {OpCheckSig, "OP_CHECKSIG", 1, opcodeSignatureCheck},
+	OpCheckSigVerify:      {OpCheckSigVerify, "OP_CHECKSIGVERIFY", 1, opcodeSignatureCheck},
+	OpCheckMultiSig:       {OpCheckMultiSig, "OP_CHECKMULTISIG", 1, opcodeSignatureCheck},
+	OpCheckMultiSigVerify: {OpCheckMultiSigVerify, "OP_CHECKMULTISIGVERIFY", 1, opcodeSignatureCheck},
 
 	// Undefined opcodes.
 	OpUnknown166: {OpUnknown166, "OP_UNKNOWN166", 1, opcodeInvalid},
@@ -861,6 +862,14 @@ func opcodeInvalid(op *parsedOpcode, vm *Engine) error {
 	return scriptError(ErrReservedOpcode, str)
 }
 
+// opcodeSignatureCheck is the handler for the signature check opcodes.
+// xc: the upstream handlers need a C-linked library for the signatures, so they always fail here.
+func opcodeSignatureCheck(op *parsedOpcode, vm *Engine) error {
+	str := fmt.Sprintf("attempt to execute unsupported opcode %s",
+		op.opcode.name)
+	return scriptError(ErrDisabledOpcode, str)
+}
+
 // opcodeFalse pushes an empty array to the data stack to represent false. Note
 // that 0, when encoded as a number according to the numeric encoding consensus
 // rules, is an empty array.
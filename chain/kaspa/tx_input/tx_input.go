@@ -15,6 +15,12 @@ type TxInput struct {
 	Mass       uint64              `json:"mass"`
 	// we also include the network minimum fee, as the estimated fee may be lower than this
 	MinFee xc.AmountBlockchain `json:"min_fee"`
+
+	// KRC-20 transfers are inscribed in two stages: the commit transaction, which spends the utxos above
+	// and locks `CommitAmount` in the P2SH address of the inscription, then the reveal transaction, which
+	// spends it back to the sender.
+	CommitAmount xc.AmountBlockchain `json:"commit_amount"`
+	RevealMass   uint64              `json:"reveal_mass,omitempty"`
 }
 
 type Utxo struct {
@@ -55,18 +61,43 @@ func (input *TxInput) SetGasFeePriority(other xc.GasFeePriority) error {
 	// So we apply the multiplier to the mass.
 	mass := uint64(float64(input.Mass) * multiplier.InexactFloat64())
 	input.Mass = mass
+	input.RevealMass = uint64(float64(input.RevealMass) * multiplier.InexactFloat64())
 
 	return nil
 }
 
-func (input *TxInput) GetFeeLimit() (xc.AmountBlockchain, xc.ContractAddress) {
-	mass := xc.NewAmountBlockchainFromUint64(input.Mass)
-	feeEstimate := mass.Mul(&input.FeePerGram)
+// IsKrc20 returns true if the input is for a KRC-20 transfer, which has a reveal transaction
+func (input *TxInput) IsKrc20() bool {
+	return input.RevealMass > 0
+}
+
+func (input *TxInput) fee(mass uint64) xc.AmountBlockchain {
+	massAmount := xc.NewAmountBlockchainFromUint64(mass)
+	feeEstimate := massAmount.Mul(&input.FeePerGram)
 
 	if feeEstimate.Cmp(&input.MinFee) < 0 {
-		return input.MinFee, ""
+		return input.MinFee
+	}
+	return feeEstimate
+}
+
+// CommitFee is the fee of the transaction spending the utxos
+func (input *TxInput) CommitFee() xc.AmountBlockchain {
+	return input.fee(input.Mass)
+}
+
+// RevealFee is the fee of the KRC-20 reveal transaction, if any
+func (input *TxInput) RevealFee() xc.AmountBlockchain {
+	if !input.IsKrc20() {
+		return xc.NewAmountBlockchainFromUint64(0)
 	}
-	return feeEstimate, ""
+	return input.fee(input.RevealMass)
+}
+
+func (input *TxInput) GetFeeLimit() (xc.AmountBlockchain, xc.ContractAddress) {
+	commitFee := input.CommitFee()
+	revealFee := input.RevealFee()
+	return commitFee.Add(&revealFee), ""
 }
 
 func (input *TxInput) IsFeeLimitAccurate() bool {
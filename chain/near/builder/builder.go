@@ -15,6 +15,7 @@ type TxBuilder struct {
 }
 
 var _ xcbuilder.FullTransferBuilder = TxBuilder{}
+var _ xcbuilder.AccessKeyManagement = TxBuilder{}
 
 // NewTxBuilder creates a new Template TxBuilder
 func NewTxBuilder(cfgI *xc.ChainBaseConfig) (TxBuilder, error) {
@@ -42,7 +43,8 @@ func (txBuilder TxBuilder) NewNativeTransfer(args xcbuilder.TransferArgs, input
 	return tx.NewNativeTx(nearInput, args)
 }
 
-// NewTokenTransfer creates a new transfer for a token asset
+// NewTokenTransfer creates a new transfer for a token asset.  If the input has a required deposit,
+// the recipient is first registered on the token contract via `storage_deposit`.
 func (txBuilder TxBuilder) NewTokenTransfer(args xcbuilder.TransferArgs, contract xc.ContractAddress, input xc.TxInput) (xc.Tx, error) {
 	nearInput, ok := input.(*near_input.TxInput)
 	if !ok {
@@ -52,6 +54,16 @@ func (txBuilder TxBuilder) NewTokenTransfer(args xcbuilder.TransferArgs, contrac
 	return tx.NewTokenTx(nearInput, args)
 }
 
+// ManageAccessKey adds or deletes an access key on the account
+func (txBuilder TxBuilder) ManageAccessKey(args xcbuilder.AccessKeyArgs, input xc.AccessKeyTxInput) (xc.Tx, error) {
+	accessKeyInput, ok := input.(*near_input.AccessKeyInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+
+	return tx.NewAccessKeyTx(&accessKeyInput.TxInput, args)
+}
+
 func (txBuilder TxBuilder) SupportsMemo() xc.MemoSupport {
 	// Near does not support memo
 	return xc.MemoSupportNone
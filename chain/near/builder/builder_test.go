@@ -4,9 +4,11 @@ import (
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/builder/buildertest"
 	"github.com/cordialsys/crosschain/chain/near/builder"
 	nearerrors "github.com/cordialsys/crosschain/chain/near/errors"
+	"github.com/cordialsys/crosschain/chain/near/tx"
 	"github.com/cordialsys/crosschain/chain/near/tx_input"
 	"github.com/stretchr/testify/require"
 )
@@ -124,3 +126,71 @@ func TestNewTokenTransfer(t *testing.T) {
 	_, err = builder1.Transfer(args, input)
 	require.NoError(t, err)
 }
+
+func TestNewTokenTransferWithStorageDeposit(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.NEAR).Base()
+	builder1, _ := builder.NewTxBuilder(chainCfg)
+	input := &TxInput{
+		RequiredDepopsit: xc.NewAmountBlockchainFromStr("1250000000000000000000"),
+		GasCost:          xc.NewAmountBlockchainFromUint64(5_000_000_000_000),
+	}
+	args := buildertest.MustNewTransferArgs(
+		chainCfg,
+		"from", "to", xc.NewAmountBlockchainFromUint64(10),
+		buildertest.OptionContractAddress(xc.ContractAddress("contract")),
+		buildertest.OptionPublicKey(make([]byte, 32)),
+	)
+	transfer, err := builder1.Transfer(args, input)
+	require.NoError(t, err)
+	nearTx := transfer.(*tx.Tx[tx.FunctionCallAction])
+	require.Equal(t, "contract", nearTx.Transaction.ReceiverID)
+	require.Len(t, nearTx.Transaction.Actions, 2)
+	require.Equal(t, tx.MethodNameStorageDeposit, nearTx.Transaction.Actions[0].MethodName)
+	require.JSONEq(t, `{"account_id":"to","registration_only":true}`, string(nearTx.Transaction.Actions[0].Args))
+	require.EqualValues(t, 5_000_000_000_000, nearTx.Transaction.Actions[0].Gas)
+	require.Equal(t, tx.MethodNameFtTransfer, nearTx.Transaction.Actions[1].MethodName)
+
+	// registered recipients only need the transfer
+	input.RequiredDepopsit = xc.AmountBlockchain{}
+	transfer, err = builder1.Transfer(args, input)
+	require.NoError(t, err)
+	require.Len(t, transfer.(*tx.Tx[tx.FunctionCallAction]).Transaction.Actions, 1)
+}
+
+func TestManageAccessKey(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.NEAR).Base()
+	builder1, _ := builder.NewTxBuilder(chainCfg)
+	key := make([]byte, 32)
+	key[0] = 1
+	input := &tx_input.AccessKeyInput{TxInput: TxInput{Nonce: 5}}
+
+	args, err := xcbuilder.NewAccessKeyArgs(xc.NEAR, xcbuilder.AccessKeyActionAdd, "from", key, &xcbuilder.AccessKeyPermission{
+		Receiver:    "contract",
+		MethodNames: []string{"ft_transfer"},
+		Allowance:   xc.NewAmountBlockchainFromUint64(100),
+	}, buildertest.OptionPublicKey(make([]byte, 32)))
+	require.NoError(t, err)
+	addKey, err := builder1.ManageAccessKey(args, input)
+	require.NoError(t, err)
+	nearTx := addKey.(*tx.Tx[tx.KeyAction])
+	require.Equal(t, "from", nearTx.Transaction.ReceiverID)
+	require.EqualValues(t, 5, nearTx.Transaction.Nonce)
+	action := nearTx.Transaction.Actions[0]
+	require.EqualValues(t, tx.ActionAddKey, action.Type)
+	require.Equal(t, "contract", action.Permission.ReceiverID)
+	require.EqualValues(t, 100, action.Permission.Allowance.Lo)
+
+	args, err = xcbuilder.NewAccessKeyArgs(xc.NEAR, xcbuilder.AccessKeyActionDelete, "from", key, nil, buildertest.OptionPublicKey(make([]byte, 32)))
+	require.NoError(t, err)
+	deleteKey, err := builder1.ManageAccessKey(args, input)
+	require.NoError(t, err)
+	require.EqualValues(t, tx.ActionDeleteKey, deleteKey.(*tx.Tx[tx.KeyAction]).Transaction.Actions[0].Type)
+
+	_, err = builder1.ManageAccessKey(args, nil)
+	require.ErrorContains(t, err, "invalid input type")
+
+	args, err = xcbuilder.NewAccessKeyArgs(xc.NEAR, xcbuilder.AccessKeyActionDelete, "from", []byte{1, 2}, nil, buildertest.OptionPublicKey(make([]byte, 32)))
+	require.NoError(t, err)
+	_, err = builder1.ManageAccessKey(args, input)
+	require.ErrorContains(t, err, nearerrors.ErrInvalidPublicKeyLength.Error())
+}
@@ -55,6 +55,7 @@ type Client struct {
 }
 
 var _ xclient.Client = &Client{}
+var _ xclient.AccessKeyClient = &Client{}
 
 // NewClient returns a new Template Client
 func NewClient(cfgI *xc.ChainConfig) (*Client, error) {
@@ -87,6 +88,16 @@ type GasDetails struct {
 }
 
 func (client *Client) fetchTxCost(ctx context.Context, isToken bool) (GasDetails, error) {
+	return client.fetchActionCost(ctx, func(cfg types.ActionCreationConfig) uint64 {
+		if isToken {
+			return cfg.FunctionCallCost.Execution
+		}
+		return cfg.TransferCost.Execution
+	})
+}
+
+// fetchActionCost estimates the gas and fee of a single action transaction
+func (client *Client) fetchActionCost(ctx context.Context, actionCost func(cfg types.ActionCreationConfig) uint64) (GasDetails, error) {
 	protocolConfigParams := types.ProtocolConfigParams{}
 	protocolConfig, err := GetRpc[types.ProtocolConfig](ctx, client, MethodProtocolConfig, protocolConfigParams)
 	if err != nil {
@@ -100,12 +111,7 @@ func (client *Client) fetchTxCost(ctx context.Context, isToken bool) (GasDetails
 	}
 	xcGasPrice := xc.NewAmountBlockchainFromStr(gasPrice.GasPrice)
 
-	var gasCost uint64
-	if isToken {
-		gasCost = protocolConfig.RuntimeConfig.TransactionCosts.ActionCreationConfig.FunctionCallCost.Execution
-	} else {
-		gasCost = protocolConfig.RuntimeConfig.TransactionCosts.ActionCreationConfig.TransferCost.Execution
-	}
+	gasCost := actionCost(protocolConfig.RuntimeConfig.TransactionCosts.ActionCreationConfig)
 	gasCost += protocolConfig.RuntimeConfig.TransactionCosts.ActionReceiptCreationConfig.Execution
 
 	multiplier := client.Asset.GetChain().ChainGasMultiplier
@@ -122,19 +128,14 @@ func (client *Client) fetchTxCost(ctx context.Context, isToken bool) (GasDetails
 	}, nil
 }
 
-// FetchTransferInput returns tx input for a Template tx
-func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
-	accessKeysParams := types.NewViewAccessKeyListParams(string(args.GetFrom()))
+// fetchBaseInput fetches the nonce of the signing access key and a recent block hash
+func (client *Client) fetchBaseInput(ctx context.Context, from xc.Address, publicKey []byte) (*tx_input.TxInput, error) {
+	accessKeysParams := types.NewViewAccessKeyListParams(string(from))
 	accessKeys, err := GetRpc[types.AccessKeyList](ctx, client, MethodQuery, accessKeysParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch access keys list: %w", err)
 	}
 
-	publicKey, ok := args.GetPublicKey()
-	if !ok {
-		return nil, fmt.Errorf("near tx-input requires a valid public key")
-	}
-
 	b58Pk := base58.Encode(publicKey)
 	expectedAccessKey := fmt.Sprintf("%s:%s", KeyTypeEd25519, b58Pk)
 	var k *types.AccessKey
@@ -151,12 +152,26 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	txInput := tx_input.NewTxInput()
 	txInput.Nonce = k.Nonce + 1
 	txInput.BlockHash = accessKeys.BlockHash
+	return txInput, nil
+}
+
+// FetchTransferInput returns tx input for a Template tx
+func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+	publicKey, ok := args.GetPublicKey()
+	if !ok {
+		return nil, fmt.Errorf("near tx-input requires a valid public key")
+	}
+	txInput, err := client.fetchBaseInput(ctx, args.GetFrom(), publicKey)
+	if err != nil {
+		return nil, err
+	}
 
 	contract, isToken := args.GetContract()
 	if isToken {
+		// The recipient must be registered on the token contract to receive it
 		storageBalanceParams, err := types.NewStorageBalanceOfParams(
 			string(contract),
-			string(args.GetFrom()),
+			string(args.GetTo()),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create storage_balance_of params: %w", err)
@@ -173,7 +188,7 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		}
 
 		if storageBalance.IsZero() {
-			storageBoundsParams, err := types.NewStorageBalanceBoundsParams(string(contract), string(args.GetFrom()))
+			storageBoundsParams, err := types.NewStorageBalanceBoundsParams(string(contract), string(args.GetTo()))
 			if err != nil {
 				return nil, fmt.Errorf("failed to create storage balance bounds params: %w", err)
 			}
@@ -196,10 +211,42 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	}
 	txInput.FeeEstimation = gasDetails.FeeEstimation
 	txInput.GasCost = gasDetails.GasCost
+	if !txInput.RequiredDepopsit.IsZero() {
+		// the storage_deposit is an additional function call
+		txInput.FeeEstimation = txInput.FeeEstimation.Add(&gasDetails.FeeEstimation)
+	}
 
 	return txInput, nil
 }
 
+// FetchAccessKeyInput returns tx input for adding or deleting an access key
+func (client *Client) FetchAccessKeyInput(ctx context.Context, args xcbuilder.AccessKeyArgs) (xc.AccessKeyTxInput, error) {
+	publicKey, ok := args.GetPublicKey()
+	if !ok {
+		return nil, fmt.Errorf("near tx-input requires a valid public key")
+	}
+	txInput, err := client.fetchBaseInput(ctx, args.GetFrom(), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	gasDetails, err := client.fetchActionCost(ctx, func(cfg types.ActionCreationConfig) uint64 {
+		if args.GetAction() == xcbuilder.AccessKeyActionDelete {
+			return cfg.DeleteKeyCost.Execution
+		}
+		if _, ok := args.GetPermission(); ok {
+			return cfg.AddKeyCost.FunctionCallCost.Execution
+		}
+		return cfg.AddKeyCost.FullAccessCost.Execution
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tx cost: %w", err)
+	}
+	txInput.FeeEstimation = gasDetails.FeeEstimation
+	txInput.GasCost = gasDetails.GasCost
+
+	return &tx_input.AccessKeyInput{TxInput: *txInput}, nil
+}
+
 // Deprecated method - use FetchTransferInput
 func (client *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, to xc.Address) (xc.TxInput, error) {
 	// No way to pass the amount in the input using legacy interface, so we estimate using min amount.
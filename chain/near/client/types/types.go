@@ -563,11 +563,18 @@ type GasCost struct {
 	SendSir    uint64 `json:"send_sir"`
 }
 
-type ActionCreationConfig struct {
-	TransferCost     GasCost `json:"transfer_cost"`
+type AddKeyCost struct {
+	FullAccessCost   GasCost `json:"full_access_cost"`
 	FunctionCallCost GasCost `json:"function_call_cost"`
 }
 
+type ActionCreationConfig struct {
+	TransferCost     GasCost    `json:"transfer_cost"`
+	FunctionCallCost GasCost    `json:"function_call_cost"`
+	AddKeyCost       AddKeyCost `json:"add_key_cost"`
+	DeleteKeyCost    GasCost    `json:"delete_key_cost"`
+}
+
 type TransactionCosts struct {
 	ActionCreationConfig        ActionCreationConfig `json:"action_creation_config"`
 	ActionReceiptCreationConfig GasCost              `json:"action_receipt_creation_config"` // fixed typo
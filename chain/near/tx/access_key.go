@@ -0,0 +1,174 @@
+package tx
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	nearerrors "github.com/cordialsys/crosschain/chain/near/errors"
+	"github.com/cordialsys/crosschain/chain/near/tx_input"
+	bin "github.com/gagliardetto/binary"
+)
+
+const (
+	AccessKeyPermissionFunctionCall = 0
+	AccessKeyPermissionFullAccess   = 1
+)
+
+// FunctionCallPermission restricts an access key to calling methods on the receiver
+type FunctionCallPermission struct {
+	// Optional, unlimited if nil
+	Allowance   *Uint128
+	ReceiverID  string
+	MethodNames []string
+}
+
+// KeyAction is either an AddKey or DeleteKey action
+type KeyAction struct {
+	Type      uint8
+	PublicKey PublicKey
+	// Only used when adding a key; full access if nil
+	Permission *FunctionCallPermission
+}
+
+var _ bin.BinaryMarshaler = KeyAction{}
+
+func NewAddKeyAction(publicKey [PublicKeyLen]byte, permission *FunctionCallPermission) KeyAction {
+	return KeyAction{
+		Type:       ActionAddKey,
+		PublicKey:  PublicKey{KeyType: 0, Data: publicKey},
+		Permission: permission,
+	}
+}
+
+func NewDeleteKeyAction(publicKey [PublicKeyLen]byte) KeyAction {
+	return KeyAction{
+		Type:      ActionDeleteKey,
+		PublicKey: PublicKey{KeyType: 0, Data: publicKey},
+	}
+}
+
+// MarshalWithEncoder serializes the action, as the permission is a borsh enum which can't be
+// represented by struct tags.
+func (action KeyAction) MarshalWithEncoder(encoder *bin.Encoder) error {
+	if err := encoder.WriteUint8(action.Type); err != nil {
+		return err
+	}
+	if err := encoder.WriteUint8(action.PublicKey.KeyType); err != nil {
+		return err
+	}
+	if err := encoder.WriteBytes(action.PublicKey.Data[:], false); err != nil {
+		return err
+	}
+	if action.Type == ActionDeleteKey {
+		return nil
+	}
+
+	// AccessKey { nonce, permission }
+	if err := encoder.WriteUint64(0, binary.LittleEndian); err != nil {
+		return err
+	}
+	if action.Permission == nil {
+		return encoder.WriteUint8(AccessKeyPermissionFullAccess)
+	}
+	if err := encoder.WriteUint8(AccessKeyPermissionFunctionCall); err != nil {
+		return err
+	}
+	permission := action.Permission
+	if err := encoder.WriteOption(permission.Allowance != nil); err != nil {
+		return err
+	}
+	if permission.Allowance != nil {
+		if err := encoder.WriteUint64(permission.Allowance.Lo, binary.LittleEndian); err != nil {
+			return err
+		}
+		if err := encoder.WriteUint64(permission.Allowance.Hi, binary.LittleEndian); err != nil {
+			return err
+		}
+	}
+	if err := writeString(encoder, permission.ReceiverID); err != nil {
+		return err
+	}
+	if err := encoder.WriteUint32(uint32(len(permission.MethodNames)), binary.LittleEndian); err != nil {
+		return err
+	}
+	for _, method := range permission.MethodNames {
+		if err := writeString(encoder, method); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeString(encoder *bin.Encoder, value string) error {
+	if err := encoder.WriteUint32(uint32(len(value)), binary.LittleEndian); err != nil {
+		return err
+	}
+	return encoder.WriteBytes([]byte(value), false)
+}
+
+// NewAccessKeyTx adds or deletes an access key on the signer's account
+func NewAccessKeyTx(input *tx_input.TxInput, args xcbuilder.AccessKeyArgs) (*Tx[KeyAction], error) {
+	account := string(args.GetFrom())
+	key := args.GetKey()
+	if len(key) != PublicKeyLen {
+		return nil, nearerrors.ErrInvalidPublicKeyLengthf(PublicKeyLen, len(key))
+	}
+	var keybz [PublicKeyLen]byte
+	copy(keybz[:], key)
+
+	var action KeyAction
+	switch args.GetAction() {
+	case xcbuilder.AccessKeyActionAdd:
+		var permission *FunctionCallPermission
+		if restriction, ok := args.GetPermission(); ok {
+			permission = &FunctionCallPermission{
+				ReceiverID:  string(restriction.Receiver),
+				MethodNames: restriction.MethodNames,
+			}
+			if !restriction.Allowance.IsZero() {
+				allowance, err := Uint128FromAmountBlockchain(restriction.Allowance)
+				if err != nil {
+					return nil, fmt.Errorf("failed to convert allowance to uint128: %w", err)
+				}
+				permission.Allowance = &allowance
+			}
+		}
+		action = NewAddKeyAction(keybz, permission)
+	case xcbuilder.AccessKeyActionDelete:
+		action = NewDeleteKeyAction(keybz)
+	default:
+		return nil, fmt.Errorf("unsupported access key action: %s", args.GetAction())
+	}
+
+	publicKey, ok := args.GetPublicKey()
+	if !ok {
+		return nil, nearerrors.ErrMissingPublicKey
+	}
+	if len(publicKey) != PublicKeyLen {
+		return nil, nearerrors.ErrInvalidPublicKeyLengthf(PublicKeyLen, len(publicKey))
+	}
+	var pkbz [PublicKeyLen]byte
+	copy(pkbz[:], publicKey)
+
+	var blockHash [BlockHashLen]byte
+	copy(blockHash[:], base58.Decode(input.BlockHash))
+	return &Tx[KeyAction]{
+		Transaction: Transaction[KeyAction]{
+			SignerID: account,
+			PublicKey: PublicKey{
+				KeyType: 0,
+				Data:    pkbz,
+			},
+			Nonce: input.Nonce,
+			// key actions always target the signer's own account
+			ReceiverID: account,
+			BlockHash:  blockHash,
+			Actions:    []KeyAction{action},
+		},
+	}, nil
+}
+
+var _ xc.Tx = &Tx[KeyAction]{}
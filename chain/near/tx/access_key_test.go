@@ -0,0 +1,50 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/cordialsys/crosschain/chain/near/tx"
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyActionSerialization(t *testing.T) {
+	var key [32]byte
+	key[0] = 7
+	keyBz := append([]byte{0}, key[:]...)
+
+	bz, err := bin.MarshalBorsh(tx.NewDeleteKeyAction(key))
+	require.NoError(t, err)
+	require.Equal(t, append([]byte{tx.ActionDeleteKey}, keyBz...), bz)
+
+	bz, err = bin.MarshalBorsh(tx.NewAddKeyAction(key, nil))
+	require.NoError(t, err)
+	expected := append([]byte{tx.ActionAddKey}, keyBz...)
+	// nonce, then the full access permission
+	expected = append(expected, 0, 0, 0, 0, 0, 0, 0, 0, tx.AccessKeyPermissionFullAccess)
+	require.Equal(t, expected, bz)
+
+	allowance := tx.Uint128{Lo: 2}
+	bz, err = bin.MarshalBorsh(tx.NewAddKeyAction(key, &tx.FunctionCallPermission{
+		Allowance:   &allowance,
+		ReceiverID:  "ab",
+		MethodNames: []string{"c"},
+	}))
+	require.NoError(t, err)
+	expected = append([]byte{tx.ActionAddKey}, keyBz...)
+	expected = append(expected, 0, 0, 0, 0, 0, 0, 0, 0, tx.AccessKeyPermissionFunctionCall)
+	// Some(allowance) as u128
+	expected = append(expected, 1, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	// receiver "ab"
+	expected = append(expected, 2, 0, 0, 0, 'a', 'b')
+	// method names ["c"]
+	expected = append(expected, 1, 0, 0, 0, 1, 0, 0, 0, 'c')
+	require.Equal(t, expected, bz)
+
+	// no allowance
+	bz, err = bin.MarshalBorsh(tx.NewAddKeyAction(key, &tx.FunctionCallPermission{ReceiverID: "ab"}))
+	require.NoError(t, err)
+	expected = append([]byte{tx.ActionAddKey}, keyBz...)
+	expected = append(expected, 0, 0, 0, 0, 0, 0, 0, 0, tx.AccessKeyPermissionFunctionCall, 0, 2, 0, 0, 0, 'a', 'b', 0, 0, 0, 0)
+	require.Equal(t, expected, bz)
+}
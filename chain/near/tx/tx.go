@@ -16,14 +16,19 @@ import (
 )
 
 const (
-	ActionFunctionCall   = 2
-	ActionTypeTransfer   = 3
-	BlockHashLen         = 32
-	KeyAmount            = "amount"
-	KeyReceiverId        = "receiver_id"
-	MethodNameFtTransfer = "ft_transfer"
-	PublicKeyLen         = 32
-	SignatureLen         = 64
+	ActionFunctionCall       = 2
+	ActionTypeTransfer       = 3
+	ActionAddKey             = 5
+	ActionDeleteKey          = 6
+	BlockHashLen             = 32
+	KeyAccountId             = "account_id"
+	KeyAmount                = "amount"
+	KeyReceiverId            = "receiver_id"
+	KeyRegistrationOnly      = "registration_only"
+	MethodNameFtTransfer     = "ft_transfer"
+	MethodNameStorageDeposit = "storage_deposit"
+	PublicKeyLen             = 32
+	SignatureLen             = 64
 )
 
 type Tx[T any] struct {
@@ -83,6 +88,33 @@ func NewFunctionCallAction(contract string, receiver string, amount xc.AmountBlo
 	}, nil
 }
 
+// NewStorageDepositAction registers the account on a NEP-145 contract, so that it may hold tokens
+func NewStorageDepositAction(account string, deposit xc.AmountBlockchain) (FunctionCallAction, error) {
+	args, err := json.Marshal(map[string]any{
+		KeyAccountId:        account,
+		KeyRegistrationOnly: true,
+	})
+	if err != nil {
+		return FunctionCallAction{}, fmt.Errorf("failed to marshal storage deposit args: %w", err)
+	}
+	depositUint128, err := Uint128FromAmountBlockchain(deposit)
+	if err != nil {
+		return FunctionCallAction{}, fmt.Errorf("failed to convert deposit amount to uint128: %w", err)
+	}
+	depositAmountBz, err := bin.MarshalBorsh(depositUint128)
+	if err != nil {
+		return FunctionCallAction{}, fmt.Errorf("failed to marshal deposit amount: %w", err)
+	}
+	var depositAmountBytes [16]byte
+	copy(depositAmountBytes[:], depositAmountBz)
+	return FunctionCallAction{
+		Type:       ActionFunctionCall,
+		MethodName: MethodNameStorageDeposit,
+		Args:       args,
+		Deposit:    depositAmountBytes,
+	}, nil
+}
+
 // PublicKey represents a NEAR public key
 type PublicKey struct {
 	KeyType uint8    // 0 for ED25519
@@ -183,6 +215,18 @@ func NewTokenTx(input *tx_input.TxInput, args xcbuilder.TransferArgs) (*Tx[Funct
 	}
 	tokenTransfer.Gas = input.GasCost.Uint64()
 
+	actions := []FunctionCallAction{}
+	if !input.RequiredDepopsit.IsZero() {
+		// the recipient is not registered with the token contract, so it's registered first
+		storageDeposit, err := NewStorageDepositAction(string(to), input.RequiredDepopsit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create storage deposit action: %w", err)
+		}
+		storageDeposit.Gas = input.GasCost.Uint64()
+		actions = append(actions, storageDeposit)
+	}
+	actions = append(actions, tokenTransfer)
+
 	publicKey, ok := args.GetPublicKey()
 	if !ok {
 		return nil, nearerrors.ErrMissingPublicKey
@@ -206,9 +250,7 @@ func NewTokenTx(input *tx_input.TxInput, args xcbuilder.TransferArgs) (*Tx[Funct
 			Nonce:      input.Nonce,
 			ReceiverID: string(contract),
 			BlockHash:  blockHash,
-			Actions: []FunctionCallAction{
-				tokenTransfer,
-			},
+			Actions:    actions,
 		},
 	}, nil
 }
@@ -0,0 +1,25 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/drivers/registry"
+)
+
+func init() {
+	registry.RegisterTxVariantInput(&AccessKeyInput{})
+}
+
+// AccessKeyInput is the input for adding or deleting an access key
+type AccessKeyInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &AccessKeyInput{}
+var _ xc.AccessKeyTxInput = &AccessKeyInput{}
+
+func (*AccessKeyInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewAccessKeyInputType(xc.DriverNear, "access-key")
+}
+
+// Mark as valid for access key transactions
+func (*AccessKeyInput) ManagingAccessKey() {}
@@ -309,6 +309,17 @@ func (txBuilder TxBuilder) buildSolanaTx(feePayer xc.Address, from xc.Address, i
 	if err != nil {
 		return nil, err
 	}
+	// The nonce account must remain a static key for the runtime to recognize the durable nonce.
+	// A v0 message is only produced if one of the accounts is found in a table.
+	if err = compileAddressTableLookups(&tx1.Message, txInput, nonceAccount); err != nil {
+		return nil, err
+	}
+	if tx1.Message.IsVersioned() {
+		// resolve the lookups so the full account list is available to decode the tx
+		if err = tx1.Message.ResolveLookups(); err != nil {
+			return nil, err
+		}
+	}
 	tx := &tx.Tx{
 		SolTx: tx1,
 	}
@@ -0,0 +1,293 @@
+package builder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/solana/tx_input"
+	"github.com/gagliardetto/solana-go"
+)
+
+// Instruction indices of the address lookup table program
+const (
+	lookupTableCreateInstruction     = 0
+	lookupTableFreezeInstruction     = 1
+	lookupTableExtendInstruction     = 2
+	lookupTableDeactivateInstruction = 3
+	lookupTableCloseInstruction      = 4
+)
+
+// Max number of addresses that can be stored in a lookup table
+const MaxLookupTableAddresses = 256
+
+// Max number of addresses that fit in a single extend instruction, given the tx size limit
+const MaxLookupTableExtendAddresses = 20
+
+// FindLookupTableAddress derives the address of a lookup table created by an authority at a recent slot.
+func FindLookupTableAddress(authority solana.PublicKey, recentSlot uint64) (solana.PublicKey, uint8, error) {
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, recentSlot)
+	return solana.FindProgramAddress([][]byte{authority[:], slot}, solana.AddressLookupTableProgramID)
+}
+
+func lookupTableInstructionData(instruction uint32, size int) []byte {
+	data := make([]byte, 4, 4+size)
+	binary.LittleEndian.PutUint32(data, instruction)
+	return data
+}
+
+// NewCreateLookupTableInstruction creates a new lookup table owned by the authority, returning the table address.
+func NewCreateLookupTableInstruction(authority solana.PublicKey, payer solana.PublicKey, recentSlot uint64) (solana.Instruction, solana.PublicKey, error) {
+	table, bump, err := FindLookupTableAddress(authority, recentSlot)
+	if err != nil {
+		return nil, solana.PublicKey{}, err
+	}
+	data := lookupTableInstructionData(lookupTableCreateInstruction, 9)
+	data = binary.LittleEndian.AppendUint64(data, recentSlot)
+	data = append(data, bump)
+	return solana.NewInstruction(
+		solana.AddressLookupTableProgramID,
+		solana.AccountMetaSlice{
+			solana.Meta(table).WRITE(),
+			solana.Meta(authority).SIGNER(),
+			solana.Meta(payer).WRITE().SIGNER(),
+			solana.Meta(solana.SystemProgramID),
+		},
+		data,
+	), table, nil
+}
+
+// NewExtendLookupTableInstruction appends addresses to a lookup table.
+func NewExtendLookupTableInstruction(table solana.PublicKey, authority solana.PublicKey, payer solana.PublicKey, addresses []solana.PublicKey) solana.Instruction {
+	data := lookupTableInstructionData(lookupTableExtendInstruction, 8+32*len(addresses))
+	data = binary.LittleEndian.AppendUint64(data, uint64(len(addresses)))
+	for _, address := range addresses {
+		data = append(data, address[:]...)
+	}
+	return solana.NewInstruction(
+		solana.AddressLookupTableProgramID,
+		solana.AccountMetaSlice{
+			solana.Meta(table).WRITE(),
+			solana.Meta(authority).SIGNER(),
+			solana.Meta(payer).WRITE().SIGNER(),
+			solana.Meta(solana.SystemProgramID),
+		},
+		data,
+	)
+}
+
+// NewDeactivateLookupTableInstruction deactivates a lookup table so that it may later be closed.
+func NewDeactivateLookupTableInstruction(table solana.PublicKey, authority solana.PublicKey) solana.Instruction {
+	return solana.NewInstruction(
+		solana.AddressLookupTableProgramID,
+		solana.AccountMetaSlice{
+			solana.Meta(table).WRITE(),
+			solana.Meta(authority).SIGNER(),
+		},
+		lookupTableInstructionData(lookupTableDeactivateInstruction, 0),
+	)
+}
+
+// NewCloseLookupTableInstruction closes a deactivated lookup table, returning the rent to the recipient.
+func NewCloseLookupTableInstruction(table solana.PublicKey, authority solana.PublicKey, recipient solana.PublicKey) solana.Instruction {
+	return solana.NewInstruction(
+		solana.AddressLookupTableProgramID,
+		solana.AccountMetaSlice{
+			solana.Meta(table).WRITE(),
+			solana.Meta(authority).SIGNER(),
+			solana.Meta(recipient).WRITE(),
+		},
+		lookupTableInstructionData(lookupTableCloseInstruction, 0),
+	)
+}
+
+func validateLookupTableAddresses(addresses []solana.PublicKey) error {
+	if len(addresses) > MaxLookupTableExtendAddresses {
+		return fmt.Errorf("cannot add more than %d addresses to a lookup table in a single tx", MaxLookupTableExtendAddresses)
+	}
+	for _, address := range addresses {
+		if address.IsZero() {
+			return errors.New("cannot add an empty address to a lookup table")
+		}
+	}
+	return nil
+}
+
+// CreateLookupTable creates a new lookup table with `from` as the authority, optionally
+// extending it with an initial set of addresses.  The table address is returned along with the tx.
+func (txBuilder TxBuilder) CreateLookupTable(from xc.Address, recentSlot uint64, addresses []solana.PublicKey, input *TxInput) (xc.Tx, solana.PublicKey, error) {
+	if err := validateLookupTableAddresses(addresses); err != nil {
+		return nil, solana.PublicKey{}, err
+	}
+	authority, err := solana.PublicKeyFromBase58(string(from))
+	if err != nil {
+		return nil, solana.PublicKey{}, err
+	}
+	create, table, err := NewCreateLookupTableInstruction(authority, authority, recentSlot)
+	if err != nil {
+		return nil, solana.PublicKey{}, err
+	}
+	instructions := []solana.Instruction{create}
+	if len(addresses) > 0 {
+		instructions = append(instructions, NewExtendLookupTableInstruction(table, authority, authority, addresses))
+	}
+	tx, err := txBuilder.buildSolanaTx(from, from, instructions, withoutLookupTables(input), "")
+	if err != nil {
+		return nil, solana.PublicKey{}, err
+	}
+	return tx, table, nil
+}
+
+// ExtendLookupTable adds addresses to an existing lookup table that `from` is the authority of.
+func (txBuilder TxBuilder) ExtendLookupTable(from xc.Address, table solana.PublicKey, addresses []solana.PublicKey, input *TxInput) (xc.Tx, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("no addresses to add to lookup table")
+	}
+	if err := validateLookupTableAddresses(addresses); err != nil {
+		return nil, err
+	}
+	authority, err := solana.PublicKeyFromBase58(string(from))
+	if err != nil {
+		return nil, err
+	}
+	instructions := []solana.Instruction{
+		NewExtendLookupTableInstruction(table, authority, authority, addresses),
+	}
+	return txBuilder.buildSolanaTx(from, from, instructions, withoutLookupTables(input), "")
+}
+
+// DeactivateLookupTable deactivates a lookup table that `from` is the authority of.
+func (txBuilder TxBuilder) DeactivateLookupTable(from xc.Address, table solana.PublicKey, input *TxInput) (xc.Tx, error) {
+	authority, err := solana.PublicKeyFromBase58(string(from))
+	if err != nil {
+		return nil, err
+	}
+	instructions := []solana.Instruction{
+		NewDeactivateLookupTableInstruction(table, authority),
+	}
+	return txBuilder.buildSolanaTx(from, from, instructions, withoutLookupTables(input), "")
+}
+
+// CloseLookupTable closes a deactivated lookup table that `from` is the authority of, reclaiming the rent.
+func (txBuilder TxBuilder) CloseLookupTable(from xc.Address, table solana.PublicKey, input *TxInput) (xc.Tx, error) {
+	authority, err := solana.PublicKeyFromBase58(string(from))
+	if err != nil {
+		return nil, err
+	}
+	instructions := []solana.Instruction{
+		NewCloseLookupTableInstruction(table, authority, authority),
+	}
+	return txBuilder.buildSolanaTx(from, from, instructions, withoutLookupTables(input), "")
+}
+
+// Lookup tables should never be used to compress the transactions that manage lookup tables
+func withoutLookupTables(input *TxInput) *TxInput {
+	withoutTables := *input
+	withoutTables.AddressLookupTables = nil
+	return &withoutTables
+}
+
+type lookupTableEntry struct {
+	table int
+	index uint8
+}
+
+// compileAddressTableLookups converts a legacy message into a v0 message, loading the accounts found in
+// the lookup tables through the tables.  solana-go compiles lookups by iterating over a map, which
+// changes the message bytes between builds when there are multiple tables; here the tables are
+// sorted by address, and an account in several tables is always loaded from the first one.
+// Signers, invoked programs and the `static` accounts are never loaded from a table.  The message
+// is left as-is if none of its accounts are found in a table.
+func compileAddressTableLookups(message *solana.Message, input *TxInput, static ...solana.PublicKey) error {
+	tables := make([]*tx_input.AddressLookupTable, len(input.AddressLookupTables))
+	copy(tables, input.AddressLookupTables)
+	sort.SliceStable(tables, func(i, j int) bool {
+		return bytes.Compare(tables[i].Account[:], tables[j].Account[:]) < 0
+	})
+	entries := map[solana.PublicKey]lookupTableEntry{}
+	for i, table := range tables {
+		if len(table.Addresses) > MaxLookupTableAddresses {
+			return fmt.Errorf("max lookup table index exceeded for %s table", table.Account)
+		}
+		for index, address := range table.Addresses {
+			if _, ok := entries[address]; !ok {
+				entries[address] = lookupTableEntry{table: i, index: uint8(index)}
+			}
+		}
+	}
+	for _, key := range static {
+		delete(entries, key)
+	}
+	invoked := map[uint16]bool{}
+	for _, instruction := range message.Instructions {
+		invoked[instruction.ProgramIDIndex] = true
+	}
+
+	header := message.Header
+	numKeys := len(message.AccountKeys)
+	firstReadonly := numKeys - int(header.NumReadonlyUnsignedAccounts)
+	writable := make([][]uint16, len(tables))
+	readonly := make([][]uint16, len(tables))
+	staticKeys := solana.PublicKeySlice{}
+	newIndex := make([]uint16, numKeys)
+	loaded := 0
+	for i, key := range message.AccountKeys {
+		entry, ok := entries[key]
+		if !ok || i < int(header.NumRequiredSignatures) || invoked[uint16(i)] {
+			newIndex[i] = uint16(len(staticKeys))
+			staticKeys = append(staticKeys, key)
+			continue
+		}
+		loaded++
+		if i < firstReadonly {
+			writable[entry.table] = append(writable[entry.table], uint16(i))
+		} else {
+			readonly[entry.table] = append(readonly[entry.table], uint16(i))
+			header.NumReadonlyUnsignedAccounts--
+		}
+	}
+	if loaded == 0 {
+		return nil
+	}
+
+	// loaded accounts are indexed after the static accounts, writable before read-only
+	lookups := []solana.MessageAddressTableLookup{}
+	next := uint16(len(staticKeys))
+	for _, group := range [][][]uint16{writable, readonly} {
+		for _, indices := range group {
+			for _, i := range indices {
+				newIndex[i] = next
+				next++
+			}
+		}
+	}
+	for t, table := range tables {
+		if len(writable[t]) == 0 && len(readonly[t]) == 0 {
+			continue
+		}
+		lookup := solana.MessageAddressTableLookup{AccountKey: table.Account}
+		for _, i := range writable[t] {
+			lookup.WritableIndexes = append(lookup.WritableIndexes, entries[message.AccountKeys[i]].index)
+		}
+		for _, i := range readonly[t] {
+			lookup.ReadonlyIndexes = append(lookup.ReadonlyIndexes, entries[message.AccountKeys[i]].index)
+		}
+		lookups = append(lookups, lookup)
+	}
+
+	for i := range message.Instructions {
+		instruction := &message.Instructions[i]
+		instruction.ProgramIDIndex = newIndex[instruction.ProgramIDIndex]
+		for j, account := range instruction.Accounts {
+			instruction.Accounts[j] = newIndex[account]
+		}
+	}
+	message.AccountKeys = staticKeys
+	message.Header = header
+	message.SetAddressTableLookups(lookups)
+	return message.SetAddressTables(input.GetAddressTables(static...))
+}
@@ -0,0 +1,117 @@
+package builder_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/solana/builder"
+	"github.com/cordialsys/crosschain/chain/solana/tx"
+	"github.com/cordialsys/crosschain/chain/solana/tx_input"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLookupTable(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.SOL).WithDriver(xc.DriverSolana).Base()
+	txBuilder, _ := builder.NewTxBuilder(chainCfg)
+	from := xc.Address("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb")
+	authority := solana.MustPublicKeyFromBase58(string(from))
+	addresses := []solana.PublicKey{solana.TokenProgramID, solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qFxEkDzmnaxGQUvk34uRgxNt4r")}
+	recentSlot := uint64(301234567)
+
+	expectedTable, bump, err := builder.FindLookupTableAddress(authority, recentSlot)
+	require.NoError(t, err)
+
+	input := &tx_input.TxInput{
+		// lookup tables are never used for managing lookup tables
+		AddressLookupTables: []*tx_input.AddressLookupTable{{Account: solana.NewWallet().PublicKey(), Addresses: addresses}},
+	}
+	trxn, table, err := txBuilder.CreateLookupTable(from, recentSlot, addresses, input)
+	require.NoError(t, err)
+	require.Equal(t, expectedTable, table)
+	solTx := trxn.(*tx.Tx).SolTx
+	require.False(t, solTx.Message.IsVersioned())
+	require.Len(t, solTx.Message.Instructions, 2)
+
+	create := solTx.Message.Instructions[0]
+	programId, err := solTx.Message.Program(create.ProgramIDIndex)
+	require.NoError(t, err)
+	require.Equal(t, solana.AddressLookupTableProgramID, programId)
+	expectedData := []byte{0, 0, 0, 0}
+	expectedData = binary.LittleEndian.AppendUint64(expectedData, recentSlot)
+	expectedData = append(expectedData, bump)
+	require.Equal(t, expectedData, []byte(create.Data))
+	accounts, err := create.ResolveInstructionAccounts(&solTx.Message)
+	require.NoError(t, err)
+	require.Len(t, accounts, 4)
+	require.Equal(t, table, accounts[0].PublicKey)
+	require.True(t, accounts[0].IsWritable)
+	require.Equal(t, authority, accounts[1].PublicKey)
+	require.True(t, accounts[1].IsSigner)
+	require.Equal(t, solana.SystemProgramID, accounts[3].PublicKey)
+
+	extend := solTx.Message.Instructions[1]
+	require.Len(t, extend.Data, 4+8+32*2)
+	require.Equal(t, []byte{2, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}, []byte(extend.Data[:12]))
+	require.Equal(t, addresses[1][:], []byte(extend.Data[44:]))
+
+	tooMany := make([]solana.PublicKey, builder.MaxLookupTableExtendAddresses+1)
+	for i := range tooMany {
+		tooMany[i] = solana.NewWallet().PublicKey()
+	}
+	_, _, err = txBuilder.CreateLookupTable(from, recentSlot, tooMany, input)
+	require.ErrorContains(t, err, "cannot add more than")
+}
+
+func TestManageLookupTable(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.SOL).WithDriver(xc.DriverSolana).Base()
+	txBuilder, _ := builder.NewTxBuilder(chainCfg)
+	from := xc.Address("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb")
+	authority := solana.MustPublicKeyFromBase58(string(from))
+	table := solana.MustPublicKeyFromBase58("2immgwYNHBbyVQKVGCEkgWpi53bLwWNRMB5G2nbgYV17")
+	input := &tx_input.TxInput{}
+
+	_, err := txBuilder.ExtendLookupTable(from, table, nil, input)
+	require.ErrorContains(t, err, "no addresses")
+
+	for _, tc := range []struct {
+		name     string
+		build    func() (xc.Tx, error)
+		data     []byte
+		accounts []solana.PublicKey
+	}{
+		{
+			name: "deactivate",
+			build: func() (xc.Tx, error) {
+				return txBuilder.DeactivateLookupTable(from, table, input)
+			},
+			data:     []byte{3, 0, 0, 0},
+			accounts: []solana.PublicKey{table, authority},
+		},
+		{
+			name: "close",
+			build: func() (xc.Tx, error) {
+				return txBuilder.CloseLookupTable(from, table, input)
+			},
+			data:     []byte{4, 0, 0, 0},
+			accounts: []solana.PublicKey{table, authority, authority},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			trxn, err := tc.build()
+			require.NoError(t, err)
+			solTx := trxn.(*tx.Tx).SolTx
+			require.Len(t, solTx.Message.Instructions, 1)
+			instruction := solTx.Message.Instructions[0]
+			require.Equal(t, tc.data, []byte(instruction.Data))
+			accounts, err := instruction.ResolveInstructionAccounts(&solTx.Message)
+			require.NoError(t, err)
+			keys := []solana.PublicKey{}
+			for _, account := range accounts {
+				keys = append(keys, account.PublicKey)
+			}
+			require.Equal(t, tc.accounts, keys)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/solana/tx_input"
+	"github.com/cordialsys/crosschain/chain/solana/types"
+	"github.com/gagliardetto/solana-go"
+	ata "github.com/gagliardetto/solana-go/programs/associated-token-account"
+	compute_budget "github.com/gagliardetto/solana-go/programs/compute-budget"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/programs/token"
+)
+
+var _ xcbuilder.MultiTransfer = &TxBuilder{}
+
+// MultiTransfer sends native and/or token transfers to many receivers from a single sender.
+// If the input includes address lookup tables, a v0 transaction is built to fit more transfers.
+func (txBuilder TxBuilder) MultiTransfer(args xcbuilder.MultiTransferArgs, input xc.MultiTransferInput) (xc.Tx, error) {
+	multiInput, ok := input.(*tx_input.MultiTransferInput)
+	if !ok {
+		return nil, errors.New("xc.MultiTransferInput is not from a solana chain")
+	}
+	spenders := args.Spenders()
+	if len(spenders) != 1 {
+		return nil, errors.New("only one spender is supported for solana multi-transfers")
+	}
+	receivers := args.Receivers()
+	if len(receivers) == 0 {
+		return nil, errors.New("no receivers for solana multi-transfer")
+	}
+	if len(receivers) != len(multiInput.Receivers) {
+		return nil, fmt.Errorf("expected input for %d receivers, but have %d", len(receivers), len(multiInput.Receivers))
+	}
+	from := spenders[0].GetFrom()
+	feePayer, ok := args.GetFeePayer()
+	if !ok {
+		feePayer = from
+	}
+	memoMaybe, _ := args.GetMemo()
+
+	accountFrom, err := solana.PublicKeyFromBase58(string(from))
+	if err != nil {
+		return nil, err
+	}
+	accountFeePayer, err := solana.PublicKeyFromBase58(string(feePayer))
+	if err != nil {
+		return nil, err
+	}
+
+	instructions := []solana.Instruction{}
+	createdATAs := map[solana.PublicKey]bool{}
+	for i, receiver := range receivers {
+		receiverInput := multiInput.Receivers[i]
+		accountTo, err := solana.PublicKeyFromBase58(string(receiver.GetTo()))
+		if err != nil {
+			return nil, err
+		}
+		contract, isToken := receiver.GetContract()
+		if !isToken {
+			instructions = append(instructions,
+				system.NewTransferInstruction(
+					receiver.GetAmount().Uint64(),
+					accountFrom,
+					accountTo,
+				).Build(),
+			)
+			continue
+		}
+		if receiverInput.Contract != contract {
+			return nil, fmt.Errorf("input for receiver %d is for %s, not %s", i, receiverInput.Contract, contract)
+		}
+		decimals, ok := receiver.GetDecimals()
+		if !ok {
+			return nil, fmt.Errorf("cannot send solana token transfer without knowing the decimals")
+		}
+		accountContract, err := solana.PublicKeyFromBase58(string(contract))
+		if err != nil {
+			return nil, err
+		}
+		tokenProgram := receiverInput.TokenProgram
+		if tokenProgram.IsZero() {
+			tokenProgram = solana.TokenProgramID
+		}
+
+		ataFrom := receiverInput.SourceTokenAccount
+		if ataFrom.IsZero() {
+			ataFromStr, err := types.FindAssociatedTokenAddress(string(from), string(contract), tokenProgram)
+			if err != nil {
+				return nil, err
+			}
+			ataFrom = solana.MustPublicKeyFromBase58(ataFromStr)
+		}
+		ataTo := accountTo
+		if !receiverInput.ToIsATA {
+			ataToStr, err := types.FindAssociatedTokenAddress(string(receiver.GetTo()), string(contract), tokenProgram)
+			if err != nil {
+				return nil, err
+			}
+			ataTo = solana.MustPublicKeyFromBase58(ataToStr)
+		}
+
+		if receiverInput.ShouldCreateATA && !createdATAs[ataTo] {
+			createdATAs[ataTo] = true
+			// fee payer should pay for the ATA creation
+			createAta := ata.NewCreateInstruction(
+				accountFeePayer,
+				accountTo,
+				accountContract,
+			).Build()
+			// Adjust the ata-create-account arguments:
+			// index 1 - associated token account
+			// index 5 - token program
+			createAta.Impl.(ata.Create).AccountMetaSlice[1].PublicKey = ataTo
+			createAta.Impl.(ata.Create).AccountMetaSlice[5].PublicKey = tokenProgram
+			instructions = append(instructions, createAta)
+		}
+
+		transfer := token.NewTransferCheckedInstruction(
+			receiver.GetAmount().Uint64(),
+			uint8(decimals),
+			ataFrom,
+			accountContract,
+			ataTo,
+			accountFrom,
+			[]solana.PublicKey{},
+		).Build()
+		data, err := transfer.Data()
+		if err != nil {
+			return nil, err
+		}
+		// Set the program explicitly as receivers may use different token programs (e.g. token2022)
+		instructions = append(instructions, solana.NewInstruction(tokenProgram, transfer.Accounts(), data))
+	}
+
+	// add priority fee last
+	priorityFee := multiInput.GetPrioritizationFee()
+	if priorityFee > 0 {
+		instructions = append(instructions,
+			compute_budget.NewSetComputeUnitPriceInstruction(priorityFee).Build(),
+		)
+	}
+
+	return txBuilder.buildSolanaTx(feePayer, from, instructions, &multiInput.TxInput, memoMaybe)
+}
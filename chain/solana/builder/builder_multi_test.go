@@ -0,0 +1,253 @@
+package builder_test
+
+import (
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/solana/builder"
+	"github.com/cordialsys/crosschain/chain/solana/tx"
+	"github.com/cordialsys/crosschain/chain/solana/tx_input"
+	"github.com/cordialsys/crosschain/chain/solana/types"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func newMultiTransferArgs(t *testing.T, chainCfg *xc.ChainBaseConfig, from xc.Address, receivers []*xcbuilder.Receiver) xcbuilder.MultiTransferArgs {
+	sender, err := xcbuilder.NewSender(from, []byte{})
+	require.NoError(t, err)
+	args, err := xcbuilder.NewMultiTransferArgs(chainCfg, []*xcbuilder.Sender{sender}, receivers)
+	require.NoError(t, err)
+	return *args
+}
+
+func TestMultiTransfer(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.SOL).WithDriver(xc.DriverSolana).Base()
+	txBuilder, _ := builder.NewTxBuilder(chainCfg)
+	from := xc.Address("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb")
+	to := xc.Address("BWbmXj5ckAaWCAtzMZ97qnJhBAKegoXtgNrv9BUpAB11")
+	usdc := xc.ContractAddress("EPjFWdd5AufqSSqeM2qFxEkDzmnaxGQUvk34uRgxNt4r")
+	token2022 := xc.ContractAddress("2b1kV6DkPAnxd5ixfnxCpjxmKwqjjaYmCZfHsFu24GXo")
+
+	native, _ := xcbuilder.NewReceiver(to, xc.NewAmountBlockchainFromUint64(1000))
+	token1, _ := xcbuilder.NewReceiver(to, xc.NewAmountBlockchainFromUint64(2000), xcbuilder.OptionContractAddress(usdc), xcbuilder.OptionContractDecimals(6))
+	token2, _ := xcbuilder.NewReceiver(to, xc.NewAmountBlockchainFromUint64(3000), xcbuilder.OptionContractAddress(usdc), xcbuilder.OptionContractDecimals(6))
+	token3, _ := xcbuilder.NewReceiver(to, xc.NewAmountBlockchainFromUint64(4000), xcbuilder.OptionContractAddress(token2022), xcbuilder.OptionContractDecimals(6))
+	args := newMultiTransferArgs(t, chainCfg, from, []*xcbuilder.Receiver{native, token1, token2, token3})
+
+	input := tx_input.NewMultiTransferInput()
+	input.PrioritizationFee = xc.NewAmountBlockchainFromUint64(100)
+	input.Receivers = []*tx_input.ReceiverInput{
+		{},
+		{Contract: usdc, TokenProgram: solana.TokenProgramID, ShouldCreateATA: true},
+		{Contract: usdc, TokenProgram: solana.TokenProgramID, ShouldCreateATA: true},
+		{Contract: token2022, TokenProgram: solana.Token2022ProgramID},
+	}
+	trxn, err := txBuilder.MultiTransfer(args, input)
+	require.NoError(t, err)
+	solTx := trxn.(*tx.Tx).SolTx
+	require.False(t, solTx.Message.IsVersioned())
+
+	// native + create ata (only once) + 3 token transfers + priority fee
+	require.Len(t, solTx.Message.Instructions, 6)
+	programs := []solana.PublicKey{}
+	for _, instruction := range solTx.Message.Instructions {
+		programId, err := solTx.Message.Program(instruction.ProgramIDIndex)
+		require.NoError(t, err)
+		programs = append(programs, programId)
+	}
+	require.Equal(t, []solana.PublicKey{
+		solana.SystemProgramID,
+		solana.SPLAssociatedTokenAccountProgramID,
+		solana.TokenProgramID,
+		solana.TokenProgramID,
+		solana.Token2022ProgramID,
+		solana.ComputeBudget,
+	}, programs)
+
+	decoder := tx.NewDecoderFromNativeTx(solTx, &rpc.TransactionMeta{})
+	transfers := decoder.GetTokenTransferCheckeds()
+	require.Len(t, transfers, 3)
+	require.EqualValues(t, 4000, *transfers[2].Instruction.Amount)
+	ata2022, err := types.FindAssociatedTokenAddress(string(to), string(token2022), solana.Token2022ProgramID)
+	require.NoError(t, err)
+	require.Equal(t, ata2022, transfers[2].Instruction.GetDestinationAccount().PublicKey.String())
+
+	// the input must match the receivers
+	input.Receivers = input.Receivers[:3]
+	_, err = txBuilder.MultiTransfer(args, input)
+	require.ErrorContains(t, err, "expected input for 4 receivers")
+}
+
+func TestMultiTransferWithLookupTables(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.SOL).WithDriver(xc.DriverSolana).Base()
+	txBuilder, _ := builder.NewTxBuilder(chainCfg)
+	from := xc.Address("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb")
+	usdc := xc.ContractAddress("EPjFWdd5AufqSSqeM2qFxEkDzmnaxGQUvk34uRgxNt4r")
+	table := solana.MustPublicKeyFromBase58("2immgwYNHBbyVQKVGCEkgWpi53bLwWNRMB5G2nbgYV17")
+
+	receivers := []*xcbuilder.Receiver{}
+	receiverInputs := []*tx_input.ReceiverInput{}
+	tableAddresses := solana.PublicKeySlice{solana.MustPublicKeyFromBase58(string(usdc)), solana.TokenProgramID}
+	for i := 0; i < 24; i++ {
+		to := solana.NewWallet().PublicKey()
+		receiver, err := xcbuilder.NewReceiver(xc.Address(to.String()), xc.NewAmountBlockchainFromUint64(uint64(i+1)), xcbuilder.OptionContractAddress(usdc), xcbuilder.OptionContractDecimals(6))
+		require.NoError(t, err)
+		receivers = append(receivers, receiver)
+		receiverInputs = append(receiverInputs, &tx_input.ReceiverInput{Contract: usdc, TokenProgram: solana.TokenProgramID, ToIsATA: true})
+		tableAddresses = append(tableAddresses, to)
+	}
+	args := newMultiTransferArgs(t, chainCfg, from, receivers)
+
+	input := tx_input.NewMultiTransferInput()
+	input.Receivers = receiverInputs
+	legacyTx, err := txBuilder.MultiTransfer(args, input)
+	require.NoError(t, err)
+	legacyBz, err := legacyTx.Serialize()
+	require.NoError(t, err)
+	// too large for a legacy transaction
+	require.Greater(t, len(legacyBz), 1232)
+
+	input.AddressLookupTables = []*tx_input.AddressLookupTable{
+		{Account: table, Addresses: tableAddresses},
+	}
+	trxn, err := txBuilder.MultiTransfer(args, input)
+	require.NoError(t, err)
+	solTx := trxn.(*tx.Tx).SolTx
+	require.True(t, solTx.Message.IsVersioned())
+	require.Len(t, solTx.Message.AddressTableLookups, 1)
+	require.Equal(t, table, solTx.Message.AddressTableLookups[0].AccountKey)
+	// the destination accounts are writable, the mint is read-only
+	require.Len(t, solTx.Message.AddressTableLookups[0].WritableIndexes, 24)
+	require.Len(t, solTx.Message.AddressTableLookups[0].ReadonlyIndexes, 1)
+
+	// the tx can be decoded after it is built
+	decoder := tx.NewDecoderFromNativeTx(solTx, &rpc.TransactionMeta{})
+	transfers := decoder.GetTokenTransferCheckeds()
+	require.Len(t, transfers, 24)
+	require.Equal(t, tableAddresses[25].String(), transfers[23].Instruction.GetDestinationAccount().PublicKey.String())
+
+	bz, err := trxn.Serialize()
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(bz), 1232)
+	parsed, err := solana.TransactionFromBytes(bz)
+	require.NoError(t, err)
+	require.True(t, parsed.Message.IsVersioned())
+	require.Equal(t, solTx.Message.AddressTableLookups, parsed.Message.AddressTableLookups)
+
+	// lookup tables that are not used result in a legacy transaction
+	input.AddressLookupTables = []*tx_input.AddressLookupTable{
+		{Account: table, Addresses: solana.PublicKeySlice{solana.NewWallet().PublicKey()}},
+	}
+	native, _ := xcbuilder.NewReceiver(xc.Address(solana.NewWallet().PublicKey().String()), xc.NewAmountBlockchainFromUint64(1))
+	input.Receivers = []*tx_input.ReceiverInput{{}}
+	trxn, err = txBuilder.MultiTransfer(newMultiTransferArgs(t, chainCfg, from, []*xcbuilder.Receiver{native}), input)
+	require.NoError(t, err)
+	require.False(t, trxn.(*tx.Tx).SolTx.Message.IsVersioned())
+}
+
+func TestLookupTablesKeepNonceAccountStatic(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.SOL).WithDriver(xc.DriverSolana).Base()
+	txBuilder, _ := builder.NewTxBuilder(chainCfg)
+	from := xc.Address("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb")
+	to := solana.NewWallet().PublicKey()
+	nonceAccount := solana.NewWallet().PublicKey()
+	table := solana.NewWallet().PublicKey()
+
+	input := &tx_input.TxInput{
+		DurableNonceAccount: nonceAccount,
+		DurableNonce:        solana.Hash{1, 2, 3},
+		AddressLookupTables: []*tx_input.AddressLookupTable{
+			{Account: table, Addresses: solana.PublicKeySlice{nonceAccount, to}},
+		},
+	}
+	args, err := xcbuilder.NewTransferArgs(chainCfg, from, xc.Address(to.String()), xc.NewAmountBlockchainFromUint64(1))
+	require.NoError(t, err)
+	trxn, err := txBuilder.Transfer(args, input)
+	require.NoError(t, err)
+	solTx := trxn.(*tx.Tx).SolTx
+	require.True(t, solTx.Message.IsVersioned())
+	lookups := solTx.Message.AddressTableLookups
+	require.Len(t, lookups, 1)
+	// only the destination is looked up, at its original index
+	require.Equal(t, []uint8{1}, []uint8(lookups[0].WritableIndexes))
+	require.Empty(t, lookups[0].ReadonlyIndexes)
+
+	staticKeys := solTx.Message.AccountKeys[:len(solTx.Message.AccountKeys)-1]
+	require.Contains(t, staticKeys, nonceAccount)
+	require.NotContains(t, staticKeys, to)
+	require.Equal(t, solana.Hash{1, 2, 3}, solTx.Message.RecentBlockhash)
+}
+
+func TestMultiTransferWithLookupTablesIsDeterministic(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.SOL).WithDriver(xc.DriverSolana).Base()
+	txBuilder, _ := builder.NewTxBuilder(chainCfg)
+	from := xc.Address("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb")
+	usdc := xc.ContractAddress("EPjFWdd5AufqSSqeM2qFxEkDzmnaxGQUvk34uRgxNt4r")
+	tables := []solana.PublicKey{
+		solana.MustPublicKeyFromBase58("2immgwYNHBbyVQKVGCEkgWpi53bLwWNRMB5G2nbgYV17"),
+		solana.MustPublicKeyFromBase58("BWbmXj5ckAaWCAtzMZ97qnJhBAKegoXtgNrv9BUpAB11"),
+		solana.MustPublicKeyFromBase58("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"),
+	}
+
+	receivers := []*xcbuilder.Receiver{}
+	receiverInputs := []*tx_input.ReceiverInput{}
+	destinations := solana.PublicKeySlice{}
+	for i := 0; i < 12; i++ {
+		to := solana.NewWallet().PublicKey()
+		receiver, err := xcbuilder.NewReceiver(xc.Address(to.String()), xc.NewAmountBlockchainFromUint64(uint64(i+1)), xcbuilder.OptionContractAddress(usdc), xcbuilder.OptionContractDecimals(6))
+		require.NoError(t, err)
+		receivers = append(receivers, receiver)
+		receiverInputs = append(receiverInputs, &tx_input.ReceiverInput{Contract: usdc, TokenProgram: solana.TokenProgramID, ToIsATA: true})
+		destinations = append(destinations, to)
+	}
+	args := newMultiTransferArgs(t, chainCfg, from, receivers)
+
+	input := tx_input.NewMultiTransferInput()
+	input.Receivers = receiverInputs
+	legacyTx, err := txBuilder.MultiTransfer(args, input)
+	require.NoError(t, err)
+	legacyMessage := legacyTx.(*tx.Tx).SolTx.Message
+
+	// the tables overlap, so the same account could be loaded from more than one table
+	input.AddressLookupTables = []*tx_input.AddressLookupTable{
+		{Account: tables[0], Addresses: append(solana.PublicKeySlice{solana.MustPublicKeyFromBase58(string(usdc))}, destinations[:8]...)},
+		{Account: tables[1], Addresses: destinations[4:]},
+		{Account: tables[2], Addresses: append(solana.PublicKeySlice{solana.TokenProgramID}, destinations[6:10]...)},
+	}
+
+	var expected []byte
+	for i := 0; i < 50; i++ {
+		trxn, err := txBuilder.MultiTransfer(args, input)
+		require.NoError(t, err)
+		solTx := trxn.(*tx.Tx).SolTx
+		require.True(t, solTx.Message.IsVersioned())
+		bz, err := solTx.Message.MarshalBinary()
+		require.NoError(t, err)
+		if expected == nil {
+			expected = bz
+			// the lookups are compiled in the order of the table addresses
+			lookups := solTx.Message.AddressTableLookups
+			require.Len(t, lookups, 3)
+			require.Equal(t, tables[0], lookups[0].AccountKey)
+			require.Equal(t, tables[2], lookups[1].AccountKey)
+			require.Equal(t, tables[1], lookups[2].AccountKey)
+			// shared accounts are loaded from the first table that has them
+			require.Equal(t, []uint8{1, 2, 3, 4, 5, 6, 7, 8}, []uint8(lookups[0].WritableIndexes))
+			require.Equal(t, []uint8{3, 4}, []uint8(lookups[1].WritableIndexes))
+			require.Equal(t, []uint8{6, 7}, []uint8(lookups[2].WritableIndexes))
+
+			// every instruction resolves to the same accounts as the legacy message
+			require.Len(t, solTx.Message.Instructions, len(legacyMessage.Instructions))
+			for j := range legacyMessage.Instructions {
+				legacyAccounts, err := legacyMessage.Instructions[j].ResolveInstructionAccounts(&legacyMessage)
+				require.NoError(t, err)
+				accounts, err := solTx.Message.Instructions[j].ResolveInstructionAccounts(&solTx.Message)
+				require.NoError(t, err)
+				require.Equal(t, legacyAccounts, accounts)
+			}
+		}
+		require.Equal(t, expected, bz, "build %d", i)
+	}
+}
@@ -0,0 +1,184 @@
+package builder
+
+import (
+	"encoding/binary"
+	"errors"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/solana/tx_input"
+	"github.com/cordialsys/crosschain/chain/solana/types"
+	"github.com/gagliardetto/solana-go"
+	ata "github.com/gagliardetto/solana-go/programs/associated-token-account"
+	compute_budget "github.com/gagliardetto/solana-go/programs/compute-budget"
+	"github.com/gagliardetto/solana-go/programs/token"
+)
+
+var _ xcbuilder.NftTransfer = &TxBuilder{}
+
+// Instruction index of `Transfer` in the token metadata program
+const tokenMetadataTransferInstruction = 49
+
+func (txBuilder TxBuilder) NftTransfer(args xcbuilder.NftTransferArgs, input xc.NftTransferTxInput) (xc.Tx, error) {
+	nftInput, ok := input.(*tx_input.NftTransferInput)
+	if !ok {
+		return nil, errors.New("xc.NftTransferTxInput is not from a solana chain")
+	}
+	if args.GetStandard() != xcbuilder.NftStandardMetaplex {
+		return nil, errors.New("unsupported nft standard for solana")
+	}
+	from := args.GetFrom()
+	to := args.GetTo()
+	memoMaybe, _ := args.GetMemo()
+	txInput := &nftInput.TxInput
+
+	accountFrom, err := solana.PublicKeyFromBase58(string(from))
+	if err != nil {
+		return nil, err
+	}
+	accountTo, err := solana.PublicKeyFromBase58(string(to))
+	if err != nil {
+		return nil, err
+	}
+	mint, err := solana.PublicKeyFromBase58(args.GetTokenId())
+	if err != nil {
+		return nil, err
+	}
+
+	ataFromStr, err := types.FindAssociatedTokenAddress(string(from), mint.String(), txInput.TokenProgram)
+	if err != nil {
+		return nil, err
+	}
+	ataFrom := solana.MustPublicKeyFromBase58(ataFromStr)
+	if len(txInput.SourceTokenAccounts) > 0 {
+		ataFrom = txInput.SourceTokenAccounts[0].Account
+	}
+	ataTo := accountTo
+	if !txInput.ToIsATA {
+		ataToStr, err := types.FindAssociatedTokenAddress(string(to), mint.String(), txInput.TokenProgram)
+		if err != nil {
+			return nil, err
+		}
+		ataTo = solana.MustPublicKeyFromBase58(ataToStr)
+	}
+
+	instructions := []solana.Instruction{}
+	if nftInput.Programmable {
+		if txInput.ToIsATA {
+			return nil, errors.New("programmable nfts must be sent to an owner address, not a token account")
+		}
+		// the token metadata program creates the destination token account if needed
+		transfer, err := NewProgrammableNftTransferInstruction(
+			accountFrom, ataFrom, accountTo, ataTo, mint, txInput.TokenProgram, nftInput.AuthorizationRules,
+		)
+		if err != nil {
+			return nil, err
+		}
+		instructions = append(instructions, transfer)
+	} else {
+		// Temporarily adjust the backend library to use a different program ID (see NewTokenTransfer).
+		originalTokenId := token.ProgramID
+		defer func() {
+			token.ProgramID = originalTokenId
+		}()
+		if !txInput.TokenProgram.IsZero() && !txInput.TokenProgram.Equals(originalTokenId) {
+			token.ProgramID = txInput.TokenProgram
+		}
+		if txInput.ShouldCreateATA {
+			createAta := ata.NewCreateInstruction(
+				accountFrom,
+				accountTo,
+				mint,
+			).Build()
+			createAta.Impl.(ata.Create).AccountMetaSlice[1].PublicKey = ataTo
+			createAta.Impl.(ata.Create).AccountMetaSlice[5].PublicKey = txInput.TokenProgram
+			instructions = append(instructions, createAta)
+		}
+		instructions = append(instructions,
+			token.NewTransferCheckedInstruction(
+				1,
+				0,
+				ataFrom,
+				mint,
+				ataTo,
+				accountFrom,
+				[]solana.PublicKey{},
+			).Build(),
+		)
+	}
+
+	priorityFee := txInput.GetPrioritizationFee()
+	if priorityFee > 0 {
+		instructions = append(instructions,
+			compute_budget.NewSetComputeUnitPriceInstruction(priorityFee).Build(),
+		)
+	}
+
+	return txBuilder.buildSolanaTx(from, from, instructions, txInput, memoMaybe)
+}
+
+// NewProgrammableNftTransferInstruction creates a `TransferV1` instruction for the token metadata program.
+// The owner is both the authority and the payer for any accounts that get created.
+func NewProgrammableNftTransferInstruction(
+	owner solana.PublicKey,
+	ownerToken solana.PublicKey,
+	destinationOwner solana.PublicKey,
+	destinationToken solana.PublicKey,
+	mint solana.PublicKey,
+	tokenProgram solana.PublicKey,
+	authorizationRules solana.PublicKey,
+) (solana.Instruction, error) {
+	metadata, err := types.FindMetadataAddress(mint)
+	if err != nil {
+		return nil, err
+	}
+	edition, err := types.FindMasterEditionAddress(mint)
+	if err != nil {
+		return nil, err
+	}
+	ownerTokenRecord, err := types.FindTokenRecordAddress(mint, ownerToken)
+	if err != nil {
+		return nil, err
+	}
+	destinationTokenRecord, err := types.FindTokenRecordAddress(mint, destinationToken)
+	if err != nil {
+		return nil, err
+	}
+	if tokenProgram.IsZero() {
+		tokenProgram = solana.TokenProgramID
+	}
+	// optional accounts that are not used are set to the program id
+	authRulesProgram := types.TokenMetadataProgramID
+	authRules := types.TokenMetadataProgramID
+	if !authorizationRules.IsZero() {
+		authRulesProgram = types.TokenAuthRulesProgramID
+		authRules = authorizationRules
+	}
+
+	accounts := solana.AccountMetaSlice{
+		solana.Meta(ownerToken).WRITE(),
+		solana.Meta(owner),
+		solana.Meta(destinationToken).WRITE(),
+		solana.Meta(destinationOwner),
+		solana.Meta(mint),
+		solana.Meta(metadata).WRITE(),
+		solana.Meta(edition),
+		solana.Meta(ownerTokenRecord).WRITE(),
+		solana.Meta(destinationTokenRecord).WRITE(),
+		solana.Meta(owner).SIGNER(),
+		solana.Meta(owner).WRITE().SIGNER(),
+		solana.Meta(solana.SystemProgramID),
+		solana.Meta(solana.SysVarInstructionsPubkey),
+		solana.Meta(tokenProgram),
+		solana.Meta(solana.SPLAssociatedTokenAccountProgramID),
+		solana.Meta(authRulesProgram),
+		solana.Meta(authRules),
+	}
+
+	// TransferArgs::V1 { amount, authorization_data: None }
+	data := []byte{tokenMetadataTransferInstruction, 0}
+	data = binary.LittleEndian.AppendUint64(data, 1)
+	data = append(data, 0)
+
+	return solana.NewInstruction(types.TokenMetadataProgramID, accounts, data), nil
+}
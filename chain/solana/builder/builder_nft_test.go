@@ -0,0 +1,85 @@
+package builder_test
+
+import (
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/solana/builder"
+	"github.com/cordialsys/crosschain/chain/solana/tx"
+	"github.com/cordialsys/crosschain/chain/solana/tx_input"
+	"github.com/cordialsys/crosschain/chain/solana/types"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNftTransfer(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.SOL).WithDriver(xc.DriverSolana).Base()
+	txBuilder, _ := builder.NewTxBuilder(chainCfg)
+	from := xc.Address("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb")
+	to := xc.Address("BWbmXj5ckAaWCAtzMZ97qnJhBAKegoXtgNrv9BUpAB11")
+	mint := "4zMMC9srt5Ri5X14GAgXhaHii3GnPAEERYPJgZJDncDU"
+
+	args, err := xcbuilder.NewNftTransferArgs(chainCfg, "", from, to, "", []xcbuilder.NftToken{xcbuilder.NewNftToken(mint)})
+	require.NoError(t, err)
+	require.Equal(t, xcbuilder.NftStandardMetaplex, args.GetStandard())
+
+	input := &tx_input.NftTransferInput{
+		TxInput: tx_input.TxInput{ShouldCreateATA: true},
+	}
+	trxn, err := txBuilder.NftTransfer(args, input)
+	require.NoError(t, err)
+	solTx := trxn.(*tx.Tx).SolTx
+	decoder := tx.NewDecoderFromNativeTx(solTx, &rpc.TransactionMeta{})
+	transfers := decoder.GetTokenTransferCheckeds()
+	require.Len(t, transfers, 1)
+	require.EqualValues(t, 1, *transfers[0].Instruction.Amount)
+	require.EqualValues(t, 0, *transfers[0].Instruction.Decimals)
+	require.Equal(t, mint, transfers[0].Instruction.GetMintAccount().PublicKey.String())
+	// create ata + transfer
+	require.Len(t, solTx.Message.Instructions, 2)
+}
+
+func TestProgrammableNftTransfer(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.SOL).WithDriver(xc.DriverSolana).Base()
+	txBuilder, _ := builder.NewTxBuilder(chainCfg)
+	from := xc.Address("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb")
+	to := xc.Address("BWbmXj5ckAaWCAtzMZ97qnJhBAKegoXtgNrv9BUpAB11")
+	mint := solana.MustPublicKeyFromBase58("4zMMC9srt5Ri5X14GAgXhaHii3GnPAEERYPJgZJDncDU")
+	ruleSet := solana.MustPublicKeyFromBase58("eBJLFYPxJmMGKuFwpDWkzxZeUrad92kZRC5BJLpzyT9")
+
+	args, err := xcbuilder.NewNftTransferArgs(chainCfg, xcbuilder.NftStandardMetaplex, from, to, "", []xcbuilder.NftToken{xcbuilder.NewNftToken(mint.String())})
+	require.NoError(t, err)
+
+	input := &tx_input.NftTransferInput{
+		TxInput:            tx_input.TxInput{},
+		Programmable:       true,
+		AuthorizationRules: ruleSet,
+	}
+	trxn, err := txBuilder.NftTransfer(args, input)
+	require.NoError(t, err)
+	solTx := trxn.(*tx.Tx).SolTx
+	require.Len(t, solTx.Message.Instructions, 1)
+
+	instruction := solTx.Message.Instructions[0]
+	programId, err := solTx.Message.Program(instruction.ProgramIDIndex)
+	require.NoError(t, err)
+	require.Equal(t, types.TokenMetadataProgramID, programId)
+	require.Equal(t, []byte{49, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0}, []byte(instruction.Data))
+	require.Len(t, instruction.Accounts, 17)
+
+	accounts, err := instruction.ResolveInstructionAccounts(&solTx.Message)
+	require.NoError(t, err)
+	metadata, _ := types.FindMetadataAddress(mint)
+	require.Equal(t, metadata, accounts[5].PublicKey)
+	require.True(t, accounts[5].IsWritable)
+	require.Equal(t, mint, accounts[4].PublicKey)
+	require.Equal(t, types.TokenAuthRulesProgramID, accounts[15].PublicKey)
+	require.Equal(t, ruleSet, accounts[16].PublicKey)
+
+	// pNFTs cannot be sent directly to a token account
+	input.ToIsATA = true
+	_, err = txBuilder.NftTransfer(args, input)
+	require.ErrorContains(t, err, "owner address")
+}
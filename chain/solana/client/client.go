@@ -257,37 +257,14 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		return nil, err
 	}
 	if hasFeePayer {
-		feePayerPub, err := solana.PublicKeyFromBase58(string(feePayer))
-		if err != nil {
-			return nil, fmt.Errorf("invalid fee payer address: %v", err)
-		}
-		fromPub, err := solana.PublicKeyFromBase58(string(args.GetFrom()))
-		if err != nil {
-			return nil, fmt.Errorf("invalid from address: %v", err)
-		}
-		feePayerNonceAccount, err := DeriveNonceAccount(feePayerPub)
-		if err != nil {
-			return nil, fmt.Errorf("could not derive fee-payer nonce account: %v", err)
-		}
-		if nonceAccountMaybe != nil && !nonceAccountMaybe.IsZero() {
-			feePayerNonceAccount = *nonceAccountMaybe
-		}
-		lamports, err := client.SolClient.GetMinimumBalanceForRentExemption(ctx, 165, rpc.CommitmentFinalized)
-		if err != nil {
-			return nil, fmt.Errorf("could not get minimum balance for rent exemption: %v", err)
-		}
-		feePayerBalance, err := client.FetchNativeBalance(ctx, feePayer)
-		if err != nil {
-			return nil, fmt.Errorf("could not fetch fee-payer native balance: %v", err)
-		}
-		if err := client.FetchFeePayerDurableNonceInput(ctx, txInput, feePayerNonceAccount, fromPub, feePayerPub, feePayerBalance, lamports); err != nil {
-			return nil, fmt.Errorf("could not fetch fee-payer durable nonce: %v", err)
-		}
-		if txInput.ShouldCreateFeePayerNonce && !txInput.ShouldCreateDurableNonce {
-			rent := xc.NewAmountBlockchainFromUint64(lamports)
-			txInput.FeePayerBaseFee = txInput.FeePayerBaseFee.Add(&rent)
+		if err := client.fetchFeePayerInput(ctx, txInput, feePayer, args.GetFrom(), nonceAccountMaybe); err != nil {
+			return nil, err
 		}
 	}
+	txInput.AddressLookupTables, err = client.FetchAddressLookupTables(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	if contract == "" {
 		// native transfer
@@ -306,36 +283,11 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	}
 	txInput.TokenProgram = mintInfo.Value.Owner
 
-	// get account info - check if to is an owner or ata
-	accountTo, err := solana.PublicKeyFromBase58(string(args.GetTo()))
+	txInput.ToIsATA, txInput.ShouldCreateATA, err = client.fetchTokenDestination(ctx, args.GetTo(), contract, txInput.TokenProgram)
 	if err != nil {
 		return nil, err
 	}
 
-	// Determine if destination is a token account or not by
-	// trying to lookup a token balance
-	_, err = client.SolClient.GetTokenAccountBalance(ctx, accountTo, rpc.CommitmentFinalized)
-	if err != nil {
-		txInput.ToIsATA = false
-	} else {
-		txInput.ToIsATA = true
-	}
-
-	// for tokens, get ata account info
-	ataTo := accountTo
-	if !txInput.ToIsATA {
-		ataToStr, err := types.FindAssociatedTokenAddress(string(args.GetTo()), string(contract), mintInfo.Value.Owner)
-		if err != nil {
-			return nil, err
-		}
-		ataTo = solana.MustPublicKeyFromBase58(ataToStr)
-	}
-	_, err = client.SolClient.GetAccountInfo(ctx, ataTo)
-	if err != nil {
-		// if the ATA doesn't exist yet, we will create when sending tokens
-		txInput.ShouldCreateATA = true
-	}
-
 	// Fetch all token accounts as if they are utxo
 	if contract != "" {
 		tokenAccounts, err := client.GetTokenAccountsByOwner(ctx, string(args.GetFrom()), string(contract))
@@ -368,11 +320,110 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	}
 
 	// fetch priority fee info
-	accountsToLock := solana.PublicKeySlice{}
-	accountsToLock = append(accountsToLock, mint)
+	txInput.PrioritizationFee, err = client.FetchPrioritizationFee(ctx, solana.PublicKeySlice{mint})
+	if err != nil {
+		return txInput, err
+	}
+
+	return client.WithTransferSimulation(ctx, args, txInput)
+}
+
+// fetchFeePayerInput sets the durable nonce fields for a separate fee payer
+func (client *Client) fetchFeePayerInput(ctx context.Context, txInput *tx_input.TxInput, feePayer xc.Address, from xc.Address, nonceAccountMaybe *solana.PublicKey) error {
+	feePayerPub, err := solana.PublicKeyFromBase58(string(feePayer))
+	if err != nil {
+		return fmt.Errorf("invalid fee payer address: %v", err)
+	}
+	fromPub, err := solana.PublicKeyFromBase58(string(from))
+	if err != nil {
+		return fmt.Errorf("invalid from address: %v", err)
+	}
+	feePayerNonceAccount, err := DeriveNonceAccount(feePayerPub)
+	if err != nil {
+		return fmt.Errorf("could not derive fee-payer nonce account: %v", err)
+	}
+	if nonceAccountMaybe != nil && !nonceAccountMaybe.IsZero() {
+		feePayerNonceAccount = *nonceAccountMaybe
+	}
+	lamports, err := client.SolClient.GetMinimumBalanceForRentExemption(ctx, 165, rpc.CommitmentFinalized)
+	if err != nil {
+		return fmt.Errorf("could not get minimum balance for rent exemption: %v", err)
+	}
+	feePayerBalance, err := client.FetchNativeBalance(ctx, feePayer)
+	if err != nil {
+		return fmt.Errorf("could not fetch fee-payer native balance: %v", err)
+	}
+	if err := client.FetchFeePayerDurableNonceInput(ctx, txInput, feePayerNonceAccount, fromPub, feePayerPub, feePayerBalance, lamports); err != nil {
+		return fmt.Errorf("could not fetch fee-payer durable nonce: %v", err)
+	}
+	if txInput.ShouldCreateFeePayerNonce && !txInput.ShouldCreateDurableNonce {
+		rent := xc.NewAmountBlockchainFromUint64(lamports)
+		txInput.FeePayerBaseFee = txInput.FeePayerBaseFee.Add(&rent)
+	}
+	return nil
+}
+
+// fetchTokenDestination determines if the destination is a token account, and if the
+// destination's associated token account needs to be created.
+func (client *Client) fetchTokenDestination(ctx context.Context, to xc.Address, contract xc.ContractAddress, tokenProgram solana.PublicKey) (toIsATA bool, shouldCreateATA bool, err error) {
+	// get account info - check if to is an owner or ata
+	accountTo, err := solana.PublicKeyFromBase58(string(to))
+	if err != nil {
+		return false, false, err
+	}
+
+	// Determine if destination is a token account or not by
+	// trying to lookup a token balance
+	_, err = client.SolClient.GetTokenAccountBalance(ctx, accountTo, rpc.CommitmentFinalized)
+	toIsATA = err == nil
+
+	// for tokens, get ata account info
+	ataTo := accountTo
+	if !toIsATA {
+		ataToStr, err := types.FindAssociatedTokenAddress(string(to), string(contract), tokenProgram)
+		if err != nil {
+			return false, false, err
+		}
+		ataTo = solana.MustPublicKeyFromBase58(ataToStr)
+	}
+	_, err = client.SolClient.GetAccountInfo(ctx, ataTo)
+	if err != nil {
+		// if the ATA doesn't exist yet, we will create when sending tokens
+		shouldCreateATA = true
+	}
+	return toIsATA, shouldCreateATA, nil
+}
+
+// FetchAddressLookupTables loads the lookup tables configured for the chain.  Tables that
+// have been deactivated are skipped, as they can no longer be used in new transactions.
+func (client *Client) FetchAddressLookupTables(ctx context.Context) ([]*tx_input.AddressLookupTable, error) {
+	tables := []*tx_input.AddressLookupTable{}
+	for _, address := range client.Asset.GetChain().Client().AddressLookupTables {
+		tableAccount, err := solana.PublicKeyFromBase58(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address lookup table: %s: %v", address, err)
+		}
+		state, err := lookup.GetAddressLookupTable(ctx, client.SolClient, tableAccount)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch address lookup table %s: %v", address, err)
+		}
+		if !state.IsActive() {
+			logrus.WithField("address_lookup_table", address).Warn("address lookup table is deactivated, will not use it")
+			continue
+		}
+		tables = append(tables, &tx_input.AddressLookupTable{
+			Account:   tableAccount,
+			Addresses: state.Addresses,
+		})
+	}
+	return tables, nil
+}
+
+// FetchPrioritizationFee averages the recent priority fees paid for the accounts that the tx will lock
+func (client *Client) FetchPrioritizationFee(ctx context.Context, accountsToLock solana.PublicKeySlice) (xc.AmountBlockchain, error) {
 	fees, err := client.SolClient.GetRecentPrioritizationFees(ctx, accountsToLock)
 	if err != nil {
-		return txInput, fmt.Errorf("could not lookup priority fees: %v", err)
+		return xc.AmountBlockchain{}, fmt.Errorf("could not lookup priority fees: %v", err)
 	}
 	priority_fee_count := uint64(0)
 	// start with 100 min priority fee, then average in the recent priority fees paid.
@@ -383,20 +434,15 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 			priority_fee_count += 1
 		}
 	}
+	// default 100
+	priorityFee := xc.NewAmountBlockchainFromUint64(100)
 	if priority_fee_count > 0 {
-		txInput.PrioritizationFee = xc.NewAmountBlockchainFromUint64(
+		priorityFee = xc.NewAmountBlockchainFromUint64(
 			priority_fee_sum / priority_fee_count,
 		)
-	} else {
-		// default 100
-		txInput.PrioritizationFee = xc.NewAmountBlockchainFromUint64(
-			100,
-		)
 	}
 	// apply multiplier
-	txInput.PrioritizationFee = txInput.PrioritizationFee.ApplyGasPriceMultiplier(client.Asset.GetChain().Client())
-
-	return client.WithTransferSimulation(ctx, args, txInput)
+	return priorityFee.ApplyGasPriceMultiplier(client.Asset.GetChain().Client()), nil
 }
 
 func (client *Client) WithTransferSimulation(ctx context.Context, args xcbuilder.TransferArgs, txInput *tx_input.TxInput) (xc.TxInput, error) {
@@ -408,12 +454,22 @@ func (client *Client) WithTransferSimulation(ctx context.Context, args xcbuilder
 	if err != nil {
 		return &tx_input.TxInput{}, fmt.Errorf("could not create tx builder: %v", err)
 	}
+	_, hasFeePayer := args.GetFeePayer()
+	txInput.UnitsConsumed, err = client.simulateUnitsConsumed(ctx, txI, hasFeePayer)
+	if err != nil {
+		return &tx_input.TxInput{}, err
+	}
+	return txInput, nil
+}
+
+// simulateUnitsConsumed simulates an unsigned transaction to estimate the compute units it uses
+func (client *Client) simulateUnitsConsumed(ctx context.Context, txI xc.Tx, hasFeePayer bool) (uint64, error) {
 	tx := txI.(*tx.Tx)
 	tx.SolTx.Signatures = []solana.Signature{
 		// one signature for solana transfers (note: staking txs use multiple)
 		{},
 	}
-	if _, ok := args.GetFeePayer(); ok {
+	if hasFeePayer {
 		// add another for the fee payer
 		tx.SolTx.Signatures = append(tx.SolTx.Signatures, solana.Signature{})
 	}
@@ -424,14 +480,14 @@ func (client *Client) WithTransferSimulation(ctx context.Context, args xcbuilder
 
 	// sim, err := client.SolClient.SimulateTransaction(ctx, tx.SolTx)
 	if err != nil {
-		return &tx_input.TxInput{}, fmt.Errorf("could not simulate tx: %v", err)
+		return 0, fmt.Errorf("could not simulate tx: %v", err)
 	}
 	// simBz, _ := json.MarshalIndent(sim, "", "  ")
 	// fmt.Println(string(simBz))
 	if sim.Value != nil && sim.Value.UnitsConsumed != nil {
-		txInput.UnitsConsumed = *sim.Value.UnitsConsumed
+		return *sim.Value.UnitsConsumed, nil
 	}
-	return txInput, nil
+	return 0, nil
 }
 
 func (client *Client) SubmitTx(ctx context.Context, txInput xctypes.SubmitTxReq) error {
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/solana/tx_input"
+	"github.com/gagliardetto/solana-go"
+	lookup "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// FetchLookupTableInput returns the input needed to create or manage an address lookup table,
+// along with a recent slot that may be used to derive a new table address.
+func (client *Client) FetchLookupTableInput(ctx context.Context, authority xc.Address) (*tx_input.TxInput, uint64, error) {
+	txInput, err := client.FetchBaseInput(ctx, authority, "", xc.NewAmountBlockchainFromUint64(0), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	// the created table address must be derived from a slot in the recent slot-hashes
+	recentSlot, err := client.SolClient.GetSlot(ctx, rpc.CommitmentFinalized)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not get recent slot: %v", err)
+	}
+	txInput.PrioritizationFee, err = client.FetchPrioritizationFee(ctx, solana.PublicKeySlice{})
+	if err != nil {
+		return nil, 0, err
+	}
+	return txInput, recentSlot, nil
+}
+
+// FetchAddressLookupTable returns the current state of an address lookup table
+func (client *Client) FetchAddressLookupTable(ctx context.Context, table solana.PublicKey) (*lookup.AddressLookupTableState, error) {
+	state, err := lookup.GetAddressLookupTable(ctx, client.SolClient, table)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch address lookup table %s: %v", table, err)
+	}
+	return state, nil
+}
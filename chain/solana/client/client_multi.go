@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/solana/builder"
+	"github.com/cordialsys/crosschain/chain/solana/tx_input"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/gagliardetto/solana-go"
+)
+
+var _ xclient.MultiTransferClient = &Client{}
+
+func (client *Client) FetchMultiTransferInput(ctx context.Context, args xcbuilder.MultiTransferArgs) (xc.MultiTransferInput, error) {
+	spenders := args.Spenders()
+	if len(spenders) != 1 {
+		return nil, errors.New("only one spender is supported for solana multi-transfers")
+	}
+	from := spenders[0].GetFrom()
+
+	var nonceAccountMaybe *solana.PublicKey
+	nonceAccountInput, ok := args.GetNonceAccount()
+	if ok {
+		nonceAccountPub, err := solana.PublicKeyFromBase58(nonceAccountInput)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce account: %s: %v", nonceAccountInput, err)
+		}
+		nonceAccountMaybe = &nonceAccountPub
+	}
+	feePayer, hasFeePayer := args.GetFeePayer()
+	baseNonceAccountMaybe := nonceAccountMaybe
+	if hasFeePayer {
+		baseNonceAccountMaybe = nil
+	}
+
+	// total native amount being sent, to check if the durable nonce rent is affordable
+	nativeAmount := xc.NewAmountBlockchainFromUint64(0)
+	for _, receiver := range args.Receivers() {
+		if _, ok := receiver.GetContract(); !ok {
+			amount := receiver.GetAmount()
+			nativeAmount = nativeAmount.Add(&amount)
+		}
+	}
+	baseInput, err := client.FetchBaseInput(ctx, from, "", nativeAmount, baseNonceAccountMaybe)
+	if err != nil {
+		return nil, err
+	}
+	if hasFeePayer {
+		if err := client.fetchFeePayerInput(ctx, baseInput, feePayer, from, nonceAccountMaybe); err != nil {
+			return nil, err
+		}
+	}
+	baseInput.AddressLookupTables, err = client.FetchAddressLookupTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	input := &tx_input.MultiTransferInput{
+		TxInput: *baseInput,
+	}
+
+	mints := solana.PublicKeySlice{}
+	tokenPrograms := map[xc.ContractAddress]solana.PublicKey{}
+	sourceAccounts := map[xc.ContractAddress]solana.PublicKey{}
+	for _, receiver := range args.Receivers() {
+		contract, ok := receiver.GetContract()
+		if !ok {
+			input.Receivers = append(input.Receivers, &tx_input.ReceiverInput{})
+			continue
+		}
+		mint, err := solana.PublicKeyFromBase58(string(contract))
+		if err != nil {
+			return nil, fmt.Errorf("invalid mint address: %s: %v", contract, err)
+		}
+		if _, ok := tokenPrograms[contract]; !ok {
+			// determine token program for the token
+			mintInfo, err := client.SolClient.GetAccountInfo(ctx, mint)
+			if err != nil {
+				return nil, err
+			}
+			tokenPrograms[contract] = mintInfo.Value.Owner
+
+			// spend from the largest token account
+			tokenAccounts, err := client.GetTokenAccountsByOwner(ctx, string(from), string(contract))
+			if err != nil {
+				return nil, err
+			}
+			if len(tokenAccounts) == 0 {
+				return nil, fmt.Errorf("no balance to send solana token %s", contract)
+			}
+			largest := xc.NewAmountBlockchainFromUint64(0)
+			for _, acc := range tokenAccounts {
+				amount := xc.NewAmountBlockchainFromStr(acc.Info.Parsed.Info.TokenAmount.Amount)
+				if sourceAccounts[contract].IsZero() || amount.Cmp(&largest) > 0 {
+					largest = amount
+					sourceAccounts[contract] = acc.Account.Pubkey
+				}
+			}
+			mints = append(mints, mint)
+		}
+		toIsATA, shouldCreateATA, err := client.fetchTokenDestination(ctx, receiver.GetTo(), contract, tokenPrograms[contract])
+		if err != nil {
+			return nil, err
+		}
+		input.Receivers = append(input.Receivers, &tx_input.ReceiverInput{
+			Contract:           contract,
+			TokenProgram:       tokenPrograms[contract],
+			ToIsATA:            toIsATA,
+			ShouldCreateATA:    shouldCreateATA,
+			SourceTokenAccount: sourceAccounts[contract],
+		})
+	}
+
+	// fetch priority fee info
+	input.PrioritizationFee, err = client.FetchPrioritizationFee(ctx, mints)
+	if err != nil {
+		return input, err
+	}
+
+	txBuilder, err := builder.NewTxBuilder(client.Asset.GetChain().Base())
+	if err != nil {
+		return nil, fmt.Errorf("could not create tx builder: %v", err)
+	}
+	tx, err := txBuilder.MultiTransfer(args, input)
+	if err != nil {
+		return nil, fmt.Errorf("could not build multi-transfer: %v", err)
+	}
+	input.UnitsConsumed, err = client.simulateUnitsConsumed(ctx, tx, hasFeePayer)
+	if err != nil {
+		return nil, err
+	}
+	return input, nil
+}
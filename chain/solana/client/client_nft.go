@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/solana/builder"
+	"github.com/cordialsys/crosschain/chain/solana/tx"
+	"github.com/cordialsys/crosschain/chain/solana/tx_input"
+	"github.com/cordialsys/crosschain/chain/solana/types"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+var _ xclient.NftClient = &Client{}
+
+func (client *Client) FetchNftTransferInput(ctx context.Context, args xcbuilder.NftTransferArgs) (xc.NftTransferTxInput, error) {
+	mint, err := solana.PublicKeyFromBase58(args.GetTokenId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid mint address: %s: %v", args.GetTokenId(), err)
+	}
+	var nonceAccountMaybe *solana.PublicKey
+	if nonceAccountInput, ok := args.GetNonceAccount(); ok {
+		nonceAccountPub, err := solana.PublicKeyFromBase58(nonceAccountInput)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce account: %s: %v", nonceAccountInput, err)
+		}
+		nonceAccountMaybe = &nonceAccountPub
+	}
+	txInput, err := client.FetchBaseInput(ctx, args.GetFrom(), xc.ContractAddress(mint.String()), xc.NewAmountBlockchainFromUint64(0), nonceAccountMaybe)
+	if err != nil {
+		return nil, err
+	}
+	nftInput := &tx_input.NftTransferInput{
+		TxInput: *txInput,
+	}
+
+	mintInfo, err := client.SolClient.GetAccountInfo(ctx, mint)
+	if err != nil {
+		return nil, fmt.Errorf("could not get mint %s: %v", mint, err)
+	}
+	nftInput.TokenProgram = mintInfo.Value.Owner
+
+	metadataAddress, err := types.FindMetadataAddress(mint)
+	if err != nil {
+		return nil, err
+	}
+	metadataInfo, err := client.SolClient.GetAccountInfo(ctx, metadataAddress)
+	if err != nil {
+		// NFTs without metadata are transferred like any other token
+		logrus.WithError(err).WithField("mint", mint).Debug("no metadata account for nft")
+	} else if metadataInfo != nil && metadataInfo.Value != nil {
+		metadata, err := types.ParseMetadataAccount(metadataInfo.Value.Data.GetBinary())
+		if err != nil {
+			return nil, fmt.Errorf("could not parse metadata for %s: %v", mint, err)
+		}
+		if metadata.TokenStandard != nil && metadata.TokenStandard.IsProgrammable() {
+			nftInput.Programmable = true
+			if metadata.RuleSet != nil {
+				nftInput.AuthorizationRules = *metadata.RuleSet
+			}
+		}
+	}
+
+	// Determine if destination is a token account or not by
+	// trying to lookup a token balance
+	accountTo, err := solana.PublicKeyFromBase58(string(args.GetTo()))
+	if err != nil {
+		return nil, err
+	}
+	_, err = client.SolClient.GetTokenAccountBalance(ctx, accountTo, rpc.CommitmentFinalized)
+	nftInput.ToIsATA = err == nil
+	if !nftInput.ToIsATA && !nftInput.Programmable {
+		ataToStr, err := types.FindAssociatedTokenAddress(string(args.GetTo()), mint.String(), nftInput.TokenProgram)
+		if err != nil {
+			return nil, err
+		}
+		_, err = client.SolClient.GetAccountInfo(ctx, solana.MustPublicKeyFromBase58(ataToStr))
+		if err != nil {
+			// if the ATA doesn't exist yet, we will create when sending the nft
+			nftInput.ShouldCreateATA = true
+		}
+	}
+
+	tokenAccounts, err := client.GetTokenAccountsByOwner(ctx, string(args.GetFrom()), mint.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, acc := range tokenAccounts {
+		amount := xc.NewAmountBlockchainFromStr(acc.Info.Parsed.Info.TokenAmount.Amount)
+		if !amount.IsZero() {
+			nftInput.SourceTokenAccounts = append(nftInput.SourceTokenAccounts, &tx_input.TokenAccount{
+				Account: acc.Account.Pubkey,
+				Balance: amount,
+			})
+			break
+		}
+	}
+	if len(nftInput.SourceTokenAccounts) == 0 {
+		return nil, fmt.Errorf("%s does not hold nft %s", args.GetFrom(), mint)
+	}
+
+	nftInput.PrioritizationFee, err = client.FetchPrioritizationFee(ctx, solana.PublicKeySlice{mint})
+	if err != nil {
+		return nil, err
+	}
+
+	txBuilder, err := builder.NewTxBuilder(client.Asset.GetChain().Base())
+	if err != nil {
+		return nil, fmt.Errorf("could not create tx builder: %v", err)
+	}
+	txI, err := txBuilder.NftTransfer(args, nftInput)
+	if err != nil {
+		return nil, fmt.Errorf("could not build nft transfer: %v", err)
+	}
+	solTx := txI.(*tx.Tx).SolTx
+	solTx.Signatures = []solana.Signature{{}}
+	sim, err := client.SolClient.SimulateTransactionWithOpts(ctx, solTx, &rpc.SimulateTransactionOpts{
+		SigVerify: false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not simulate tx: %v", err)
+	}
+	if sim.Value != nil && sim.Value.UnitsConsumed != nil {
+		nftInput.UnitsConsumed = *sim.Value.UnitsConsumed
+	}
+
+	return nftInput, nil
+}
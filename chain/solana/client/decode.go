@@ -123,15 +123,27 @@ func BuildTransfersFromDecoder(
 		)
 	}
 	for _, instr := range decoder.GetTokenTransferCheckeds() {
+		mint := instr.Instruction.GetMintAccount().PublicKey.String()
+		// Metaplex NFTs are mints without decimals, where a single token is sent
+		isNft := instr.Instruction.Decimals != nil && *instr.Instruction.Decimals == 0 && *instr.Instruction.Amount == 1
+		variant := txinfo.MovementVariantNative
+		if isNft {
+			variant = txinfo.MovementVariantNft
+		}
 		appendLegacyTransfer(
 			&sources,
 			&dests,
 			xc.Address(ResolveTokenAccountOwner(ctx, accountResolver, instr.Instruction.GetSourceAccount().PublicKey)),
 			xc.Address(ResolveTokenAccountOwner(ctx, accountResolver, instr.Instruction.GetDestinationAccount().PublicKey)),
 			xc.NewAmountBlockchainFromUint64(*instr.Instruction.Amount),
-			xc.ContractAddress(instr.Instruction.GetMintAccount().PublicKey.String()),
-			txinfo.NewEvent(instr.ID, txinfo.MovementVariantNative),
+			xc.ContractAddress(mint),
+			txinfo.NewEvent(instr.ID, variant),
 		)
+		if isNft {
+			// the mint identifies the nft
+			sources[len(sources)-1].TokenId = mint
+			dests[len(dests)-1].TokenId = mint
+		}
 	}
 	for _, instr := range decoder.GetTokenTransferCheckedWithFee() {
 		appendLegacyTransfer(
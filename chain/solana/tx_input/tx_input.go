@@ -44,7 +44,17 @@ type TxInput struct {
 	FeePayerDurableNonceAuthority solana.PublicKey    `json:"fee_payer_durable_nonce_authority,omitempty"`
 	ShouldCreateFeePayerNonce     bool                `json:"should_create_fee_payer_durable_nonce,omitempty"`
 	FeePayerBaseFee               xc.AmountBlockchain `json:"fee_payer_base_fee,omitempty"`
+
+	// If set, a v0 transaction is built that references accounts through these lookup tables.
+	AddressLookupTables []*AddressLookupTable `json:"address_lookup_tables,omitempty"`
+}
+
+// An on-chain address lookup table and the addresses stored in it
+type AddressLookupTable struct {
+	Account   solana.PublicKey      `json:"account"`
+	Addresses solana.PublicKeySlice `json:"addresses"`
 }
+
 type GetTxInfo interface {
 	GetTimestamp() int64
 	GetRecentBlockhash() solana.Hash
@@ -73,6 +83,8 @@ func init() {
 	registry.RegisterTxVariantInput(&StakingInput{})
 	registry.RegisterTxVariantInput(&UnstakingInput{})
 	registry.RegisterTxVariantInput(&WithdrawInput{})
+	registry.RegisterTxVariantInput(&NftTransferInput{})
+	registry.RegisterTxVariantInput(&MultiTransferInput{})
 }
 
 func (input *TxInput) GetTimestamp() int64 {
@@ -83,6 +95,29 @@ func (input *TxInput) GetRecentBlockhash() solana.Hash {
 	return input.RecentBlockHash
 }
 
+// GetAddressTables returns the lookup tables in the form used to compile a v0 message.
+// Accounts listed in `static` will not be resolved through a table; the entries are
+// replaced with the table's own address so that the remaining indices are preserved.
+func (input *TxInput) GetAddressTables(static ...solana.PublicKey) map[solana.PublicKey]solana.PublicKeySlice {
+	if len(input.AddressLookupTables) == 0 {
+		return nil
+	}
+	tables := map[solana.PublicKey]solana.PublicKeySlice{}
+	for _, table := range input.AddressLookupTables {
+		addresses := make(solana.PublicKeySlice, len(table.Addresses))
+		for i, address := range table.Addresses {
+			addresses[i] = address
+			for _, key := range static {
+				if !key.IsZero() && key.Equals(address) {
+					addresses[i] = table.Account
+				}
+			}
+		}
+		tables[table.Account] = addresses
+	}
+	return tables
+}
+
 func (input *TxInput) GetDurableNonceForFromAddress(fromAddress solana.PublicKey) (nonceAuthority solana.PublicKey, nonceAccount solana.PublicKey, nonceValue solana.Hash, needsCreation bool) {
 	authority := input.DurableNonceAuthority
 	if authority.IsZero() {
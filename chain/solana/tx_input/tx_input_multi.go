@@ -0,0 +1,38 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/gagliardetto/solana-go"
+)
+
+// Token account information needed for each receiver of a multi-transfer
+type ReceiverInput struct {
+	// Empty for native transfers
+	Contract        xc.ContractAddress `json:"contract,omitempty"`
+	TokenProgram    solana.PublicKey   `json:"token_program,omitempty"`
+	ToIsATA         bool               `json:"to_is_ata,omitempty"`
+	ShouldCreateATA bool               `json:"should_create_ata,omitempty"`
+	// The sender's token account to spend from
+	SourceTokenAccount solana.PublicKey `json:"source_token_account,omitempty"`
+}
+
+type MultiTransferInput struct {
+	TxInput
+	// One entry per receiver, in the same order as the receivers
+	Receivers []*ReceiverInput `json:"receivers"`
+}
+
+var _ xc.TxVariantInput = &MultiTransferInput{}
+var _ xc.MultiTransferInput = &MultiTransferInput{}
+
+func NewMultiTransferInput() *MultiTransferInput {
+	return &MultiTransferInput{
+		TxInput: *NewTxInput(),
+	}
+}
+
+func (*MultiTransferInput) MultiTransfer() {}
+
+func (*MultiTransferInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewMultiTransferInputType(xc.DriverSolana, "batch")
+}
@@ -0,0 +1,23 @@
+package tx_input
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/gagliardetto/solana-go"
+)
+
+type NftTransferInput struct {
+	TxInput
+	// Programmable NFTs (pNFT) must be transferred using the token metadata program
+	Programmable bool `json:"programmable,omitempty"`
+	// Authorization rules that the pNFT transfer is checked against, if any
+	AuthorizationRules solana.PublicKey `json:"authorization_rules,omitempty"`
+}
+
+var _ xc.TxVariantInput = &NftTransferInput{}
+var _ xc.NftTransferTxInput = &NftTransferInput{}
+
+func (*NftTransferInput) NftTransferring() {}
+
+func (*NftTransferInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewNftTransferInputType(xc.DriverSolana, "metaplex")
+}
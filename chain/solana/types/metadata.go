@@ -0,0 +1,203 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Metaplex Token Metadata program
+var TokenMetadataProgramID = solana.MustPublicKeyFromBase58("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+
+// Metaplex Token Authorization Rules program, used by programmable NFTs
+var TokenAuthRulesProgramID = solana.MustPublicKeyFromBase58("auth9SigNpDKz4sJJ1DfCTuZrZNSAgh9sFD3rboVmgg")
+
+type TokenStandard uint8
+
+const (
+	TokenStandardNonFungible                    TokenStandard = 0
+	TokenStandardFungibleAsset                  TokenStandard = 1
+	TokenStandardFungible                       TokenStandard = 2
+	TokenStandardNonFungibleEdition             TokenStandard = 3
+	TokenStandardProgrammableNonFungible        TokenStandard = 4
+	TokenStandardProgrammableNonFungibleEdition TokenStandard = 5
+)
+
+func (standard TokenStandard) IsProgrammable() bool {
+	return standard == TokenStandardProgrammableNonFungible || standard == TokenStandardProgrammableNonFungibleEdition
+}
+
+// The subset of the Metaplex metadata account needed to transfer an NFT
+type MetadataAccount struct {
+	Mint solana.PublicKey
+	// Not set on older NFTs
+	TokenStandard *TokenStandard
+	// Authorization rules for programmable NFTs, if any
+	RuleSet *solana.PublicKey
+}
+
+func findMetadataAddress(seeds ...[]byte) (solana.PublicKey, error) {
+	seeds = append([][]byte{[]byte("metadata"), TokenMetadataProgramID[:]}, seeds...)
+	addr, _, err := solana.FindProgramAddress(seeds, TokenMetadataProgramID)
+	return addr, err
+}
+
+func FindMetadataAddress(mint solana.PublicKey) (solana.PublicKey, error) {
+	return findMetadataAddress(mint[:])
+}
+
+func FindMasterEditionAddress(mint solana.PublicKey) (solana.PublicKey, error) {
+	return findMetadataAddress(mint[:], []byte("edition"))
+}
+
+// Programmable NFTs track the state of each token account in a token record
+func FindTokenRecordAddress(mint solana.PublicKey, tokenAccount solana.PublicKey) (solana.PublicKey, error) {
+	return findMetadataAddress(mint[:], []byte("token_record"), tokenAccount[:])
+}
+
+type borshReader struct {
+	data []byte
+	pos  int
+}
+
+var errMetadataTooShort = errors.New("metadata account data is too short")
+
+func (r *borshReader) skip(n int) error {
+	if n < 0 || r.pos+n > len(r.data) {
+		return errMetadataTooShort
+	}
+	r.pos += n
+	return nil
+}
+
+func (r *borshReader) read(n int) ([]byte, error) {
+	start := r.pos
+	if err := r.skip(n); err != nil {
+		return nil, err
+	}
+	return r.data[start:r.pos], nil
+}
+
+func (r *borshReader) u8() (uint8, error) {
+	bz, err := r.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return bz[0], nil
+}
+
+func (r *borshReader) u32() (uint32, error) {
+	bz, err := r.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(bz), nil
+}
+
+func (r *borshReader) skipString() error {
+	length, err := r.u32()
+	if err != nil {
+		return err
+	}
+	return r.skip(int(length))
+}
+
+// Skip an Option<T> where T has a fixed size; returns true if the option is set
+func (r *borshReader) skipOption(size int) (bool, error) {
+	tag, err := r.u8()
+	if err != nil || tag == 0 {
+		return false, err
+	}
+	return true, r.skip(size)
+}
+
+// ParseMetadataAccount decodes the borsh encoded Metaplex metadata account.
+// Trailing fields were added over time, so older accounts may end early.
+func ParseMetadataAccount(data []byte) (*MetadataAccount, error) {
+	r := &borshReader{data: data}
+	// key + update authority
+	if err := r.skip(1 + 32); err != nil {
+		return nil, err
+	}
+	mint, err := r.read(32)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &MetadataAccount{Mint: solana.PublicKeyFromBytes(mint)}
+
+	// name, symbol, uri
+	for i := 0; i < 3; i++ {
+		if err := r.skipString(); err != nil {
+			return nil, err
+		}
+	}
+	// seller fee basis points
+	if err := r.skip(2); err != nil {
+		return nil, err
+	}
+	// creators: Option<Vec<Creator>>, each creator is (pubkey, verified, share)
+	if tag, err := r.u8(); err != nil {
+		return nil, err
+	} else if tag == 1 {
+		count, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		if err := r.skip(int(count) * 34); err != nil {
+			return nil, err
+		}
+	}
+	// primary sale happened + is mutable
+	if err := r.skip(2); err != nil {
+		return nil, err
+	}
+	// edition nonce
+	if _, err := r.skipOption(1); err != nil {
+		return metadata, nil
+	}
+
+	// token standard
+	tag, err := r.u8()
+	if err != nil {
+		return metadata, nil
+	}
+	if tag == 1 {
+		standard, err := r.u8()
+		if err != nil {
+			return nil, err
+		}
+		tokenStandard := TokenStandard(standard)
+		metadata.TokenStandard = &tokenStandard
+	}
+	// collection (verified, key)
+	if _, err := r.skipOption(1 + 32); err != nil {
+		return metadata, nil
+	}
+	// uses (use method, remaining, total)
+	if _, err := r.skipOption(1 + 8 + 8); err != nil {
+		return metadata, nil
+	}
+	// collection details (enum of 8 byte variants)
+	if _, err := r.skipOption(1 + 8); err != nil {
+		return metadata, nil
+	}
+	// programmable config: Option<ProgrammableConfig::V1 { rule_set: Option<Pubkey> }>
+	if tag, err := r.u8(); err != nil || tag == 0 {
+		return metadata, nil
+	}
+	if _, err := r.u8(); err != nil {
+		return nil, err
+	}
+	if tag, err := r.u8(); err != nil {
+		return nil, err
+	} else if tag == 1 {
+		ruleSet, err := r.read(32)
+		if err != nil {
+			return nil, err
+		}
+		key := solana.PublicKeyFromBytes(ruleSet)
+		metadata.RuleSet = &key
+	}
+	return metadata, nil
+}
@@ -1,8 +1,6 @@
 package builder
 
 import (
-	"fmt"
-
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
 	xc "github.com/cordialsys/crosschain"
@@ -30,9 +28,6 @@ func NewTxBuilder(cfgI *xc.ChainBaseConfig) (TxBuilder, error) {
 
 // NewTransfer creates a new transfer for an Asset, either native or token
 func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
-	if _, ok := args.GetContract(); ok {
-		return nil, fmt.Errorf("token transfers not supported on substrate")
-	}
 	txInput := input.(*tx_input.TxInput)
 
 	sender, err := address.DecodeMulti(args.GetFrom())
@@ -44,9 +39,22 @@ func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInpu
 		return &tx.Tx{}, err
 	}
 
-	call, err := tx_input.NewCall(&txInput.Meta, "Balances.transfer_keep_alive", receiver, types.NewUCompact(args.GetAmount().Int()))
-	if err != nil {
-		return &tx.Tx{}, err
+	var call types.Call
+	if contract, ok := args.GetContract(); ok {
+		// pallet-assets token, e.g. USDT on Asset Hub
+		assetId, err := tx_input.ParseAssetId(contract)
+		if err != nil {
+			return &tx.Tx{}, err
+		}
+		call, err = tx_input.NewCall(&txInput.Meta, "Assets.transfer_keep_alive", types.NewUCompactFromUInt(uint64(assetId)), receiver, types.NewUCompact(args.GetAmount().Int()))
+		if err != nil {
+			return &tx.Tx{}, err
+		}
+	} else {
+		call, err = tx_input.NewCall(&txInput.Meta, "Balances.transfer_keep_alive", receiver, types.NewUCompact(args.GetAmount().Int()))
+		if err != nil {
+			return &tx.Tx{}, err
+		}
 	}
 
 	return tx.NewTx(extrinsic.NewDynamicExtrinsic(&call), sender, txInput.Tip, txInput)
@@ -6,8 +6,10 @@ import (
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/builder/buildertest"
+	"github.com/cordialsys/crosschain/chain/substrate/address"
 	"github.com/cordialsys/crosschain/chain/substrate/builder"
 	"github.com/cordialsys/crosschain/chain/substrate/tx_input"
 	"github.com/stretchr/testify/require"
@@ -76,3 +78,164 @@ func TestNewTransfer(t *testing.T) {
 	require.EqualValues(14, ext.Signature.Nonce.Int64())
 	require.EqualValues(100, ext.Signature.Tip.Int64())
 }
+
+func newTestInput(calls ...*tx_input.CallMeta) *tx_input.TxInput {
+	input := tx_input.NewTxInput()
+	input.Meta = tx_input.Metadata{
+		Calls:            calls,
+		SignedExtensions: []extensions.SignedExtensionName{"CheckSpecVersion", "CheckTxVersion", "CheckGenesis", "CheckMortality", "CheckNonce", "ChargeTransactionPayment"},
+	}
+	input.Rv = types.RuntimeVersion{SpecVersion: 1014000, TransactionVersion: 26}
+	input.CurrentHeight = 100
+	input.Nonce = 3
+	return input
+}
+
+func decodeCall(t *testing.T, tx xc.Tx) types.Call {
+	require.NoError(t, tx.SetSignatures(&xc.SignatureResponse{Signature: make([]byte, 64)}))
+	bz, err := tx.Serialize()
+	require.NoError(t, err)
+	ext := types.Extrinsic{}
+	require.NoError(t, codec.Decode(bz, &ext))
+	return ext.Method
+}
+
+func TestNewTokenTransfer(t *testing.T) {
+	require := require.New(t)
+	builder, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.DOT).Base())
+	from := xc.Address("5GL7deqCmoKpgmhq3b12DXSAu62VQ3DCqN3Z7Bet6fx9qAyb")
+	to := xc.Address("5FUh5YJztrDvQe58YcDr5rDhkx1kSZcxQFu81wamrPuVyZSW")
+	input := newTestInput(&tx_input.CallMeta{Name: "Assets.transfer_keep_alive", SectionIndex: 50, MethodIndex: 9})
+	chainCfg := xc.NewChainConfig(xc.DOT).Base()
+
+	args := buildertest.MustNewTransferArgs(chainCfg, from, to, xc.NewAmountBlockchainFromUint64(1_000_000), buildertest.OptionContractAddress("1984"))
+	tx, err := builder.Transfer(args, input)
+	require.NoError(err)
+
+	call := decodeCall(t, tx)
+	require.Equal(types.CallIndex{SectionIndex: 50, MethodIndex: 9}, call.CallIndex)
+	receiver, err := address.Decode(to)
+	require.NoError(err)
+	// Compact(1984), MultiAddress::Id, Compact(1000000)
+	expected := append([]byte{0x01, 0x1f, 0x00}, receiver.ToBytes()...)
+	expected = append(expected, 0x02, 0x09, 0x3d, 0x00)
+	require.Equal(expected, []byte(call.Args))
+
+	// asset id must be an integer
+	args = buildertest.MustNewTransferArgs(chainCfg, from, to, xc.NewAmountBlockchainFromUint64(1_000_000), buildertest.OptionContractAddress("USDT"))
+	_, err = builder.Transfer(args, input)
+	require.ErrorContains(err, "invalid substrate asset id")
+
+	// chain without pallet-assets
+	args = buildertest.MustNewTransferArgs(chainCfg, from, to, xc.NewAmountBlockchainFromUint64(1_000_000), buildertest.OptionContractAddress("1984"))
+	_, err = builder.Transfer(args, newTestInput())
+	require.ErrorContains(err, "unsupported substrate method")
+}
+
+func TestXcmTransfer(t *testing.T) {
+	from := xc.Address("5GL7deqCmoKpgmhq3b12DXSAu62VQ3DCqN3Z7Bet6fx9qAyb")
+	to := xc.Address("5FUh5YJztrDvQe58YcDr5rDhkx1kSZcxQFu81wamrPuVyZSW")
+	beneficiary, err := address.Decode(to)
+	require.NoError(t, err)
+	// VersionedLocation::V4 { parents: 0, X1(AccountId32 { network: None, id }) }
+	beneficiaryBz := append([]byte{0x04, 0x00, 0x01, 0x01, 0x00}, beneficiary.ToBytes()...)
+	// Compact(10_000_000_000)
+	amountBz := []byte{0x07, 0x00, 0xe4, 0x0b, 0x54, 0x02}
+	// fee_asset_item 0, WeightLimit::Unlimited
+	suffixBz := []byte{0x00, 0x00, 0x00, 0x00, 0x00}
+
+	relayCalls := []*tx_input.CallMeta{
+		{Name: "XcmPallet.limited_reserve_transfer_assets", SectionIndex: 99, MethodIndex: 8},
+		{Name: "XcmPallet.limited_teleport_assets", SectionIndex: 99, MethodIndex: 9},
+	}
+	parachainCalls := []*tx_input.CallMeta{
+		{Name: "PolkadotXcm.limited_reserve_transfer_assets", SectionIndex: 31, MethodIndex: 8},
+		{Name: "PolkadotXcm.limited_teleport_assets", SectionIndex: 31, MethodIndex: 9},
+	}
+
+	vectors := []struct {
+		name      string
+		calls     []*tx_input.CallMeta
+		parachain uint32
+		teleport  bool
+		callIndex types.CallIndex
+		dest      []byte
+		asset     []byte
+		err       string
+	}{
+		{
+			name:      "teleport from relay to asset hub",
+			calls:     relayCalls,
+			parachain: 1000,
+			teleport:  true,
+			callIndex: types.CallIndex{SectionIndex: 99, MethodIndex: 9},
+			// { parents: 0, X1(Parachain(1000)) }
+			dest: []byte{0x04, 0x00, 0x01, 0x00, 0xa1, 0x0f},
+			// { parents: 0, Here }
+			asset: []byte{0x00, 0x00},
+		},
+		{
+			name:      "reserve transfer from relay to parachain",
+			calls:     relayCalls,
+			parachain: 2004,
+			callIndex: types.CallIndex{SectionIndex: 99, MethodIndex: 8},
+			dest:      []byte{0x04, 0x00, 0x01, 0x00, 0x51, 0x1f},
+			asset:     []byte{0x00, 0x00},
+		},
+		{
+			name:      "teleport from asset hub to relay",
+			calls:     parachainCalls,
+			teleport:  true,
+			callIndex: types.CallIndex{SectionIndex: 31, MethodIndex: 9},
+			// { parents: 1, Here }
+			dest:  []byte{0x04, 0x01, 0x00},
+			asset: []byte{0x01, 0x00},
+		},
+		{
+			name:      "teleport from asset hub to another system parachain",
+			calls:     parachainCalls,
+			parachain: 1002,
+			teleport:  true,
+			callIndex: types.CallIndex{SectionIndex: 31, MethodIndex: 9},
+			dest:      []byte{0x04, 0x01, 0x01, 0x00, 0xa9, 0x0f},
+			asset:     []byte{0x01, 0x00},
+		},
+		{
+			name:     "relay must set a parachain",
+			calls:    relayCalls,
+			teleport: true,
+			err:      "must set a destination parachain",
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			require := require.New(t)
+			txBuilder, _ := builder.NewTxBuilder(xc.NewChainConfig(xc.DOT).Base())
+			tx, err := txBuilder.XcmTransfer(builder.XcmTransferArgs{
+				From:      from,
+				To:        to,
+				Amount:    xc.NewAmountBlockchainFromUint64(10_000_000_000),
+				Parachain: v.parachain,
+				Teleport:  v.teleport,
+			}, newTestInput(v.calls...))
+			if v.err != "" {
+				require.ErrorContains(err, v.err)
+				return
+			}
+			require.NoError(err)
+
+			call := decodeCall(t, tx)
+			require.Equal(v.callIndex, call.CallIndex)
+			expected := append([]byte{}, v.dest...)
+			expected = append(expected, beneficiaryBz...)
+			// VersionedAssets::V4 with 1 asset
+			expected = append(expected, 0x04, 0x04)
+			expected = append(expected, v.asset...)
+			// Fungible
+			expected = append(expected, 0x00)
+			expected = append(expected, amountBz...)
+			expected = append(expected, suffixBz...)
+			require.Equal(expected, []byte(call.Args))
+		})
+	}
+}
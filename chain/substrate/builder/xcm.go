@@ -0,0 +1,179 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/substrate/address"
+	"github.com/cordialsys/crosschain/chain/substrate/tx"
+	"github.com/cordialsys/crosschain/chain/substrate/tx_input"
+)
+
+// XCM version used to encode locations and assets
+const XcmVersion = 4
+
+const (
+	// XCM pallet on relay chains
+	XcmPalletRelay = "XcmPallet"
+	// XCM pallet on parachains
+	XcmPalletParachain = "PolkadotXcm"
+)
+
+// XcmTransferArgs moves the native asset (DOT/KSM) between the relay chain and a parachain.
+type XcmTransferArgs struct {
+	From xc.Address
+	// Beneficiary on the destination chain
+	To     xc.Address
+	Amount xc.AmountBlockchain
+	// Destination parachain id, or 0 for the relay chain
+	Parachain uint32
+	// Teleport the asset rather than transfer it via the reserve.  Moving DOT/KSM between the relay
+	// chain and system parachains (e.g. Asset Hub) is a teleport.
+	Teleport bool
+}
+
+// XcmJunction is either Parachain(id), or AccountId32 { network: None, id } if AccountId is set
+type XcmJunction struct {
+	Parachain uint32
+	AccountId []byte
+}
+
+func (j XcmJunction) Encode(encoder scale.Encoder) error {
+	if len(j.AccountId) > 0 {
+		if len(j.AccountId) != 32 {
+			return fmt.Errorf("invalid xcm account id length: %d", len(j.AccountId))
+		}
+		// variant 1, with no network
+		if err := encoder.Write([]byte{1, 0}); err != nil {
+			return err
+		}
+		return encoder.Write(j.AccountId)
+	}
+	if err := encoder.PushByte(0); err != nil {
+		return err
+	}
+	return encoder.Encode(types.NewUCompactFromUInt(uint64(j.Parachain)))
+}
+
+// XcmLocation is a location relative to the current chain
+type XcmLocation struct {
+	Parents uint8
+	// Empty for `Here`
+	Interior []XcmJunction
+}
+
+func (l XcmLocation) Encode(encoder scale.Encoder) error {
+	if len(l.Interior) > 8 {
+		return fmt.Errorf("xcm location may have at most 8 junctions")
+	}
+	// Junctions enum: Here, X1, X2, ..., X8
+	if err := encoder.Write([]byte{l.Parents, uint8(len(l.Interior))}); err != nil {
+		return err
+	}
+	for _, junction := range l.Interior {
+		if err := encoder.Encode(junction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// XcmAsset is a fungible amount of the asset identified by its location
+type XcmAsset struct {
+	Id     XcmLocation
+	Amount types.UCompact
+}
+
+func (a XcmAsset) Encode(encoder scale.Encoder) error {
+	if err := encoder.Encode(a.Id); err != nil {
+		return err
+	}
+	// Fungibility::Fungible
+	if err := encoder.PushByte(0); err != nil {
+		return err
+	}
+	return encoder.Encode(a.Amount)
+}
+
+// VersionedXcmLocation encodes a location as a VersionedLocation
+type VersionedXcmLocation XcmLocation
+
+func (l VersionedXcmLocation) Encode(encoder scale.Encoder) error {
+	if err := encoder.PushByte(XcmVersion); err != nil {
+		return err
+	}
+	return encoder.Encode(XcmLocation(l))
+}
+
+// VersionedXcmAssets encodes assets as VersionedAssets
+type VersionedXcmAssets []XcmAsset
+
+func (a VersionedXcmAssets) Encode(encoder scale.Encoder) error {
+	if err := encoder.PushByte(XcmVersion); err != nil {
+		return err
+	}
+	return encoder.Encode([]XcmAsset(a))
+}
+
+// WeightLimit::Unlimited
+var XcmWeightUnlimited = types.NewU8(0)
+
+// XcmTransfer builds a limited_teleport_assets or limited_reserve_transfer_assets call, paying
+// the fees on the destination out of the transferred amount.
+func (txBuilder TxBuilder) XcmTransfer(args XcmTransferArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*tx_input.TxInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T", input)
+	}
+	if args.Amount.IsZero() {
+		return nil, fmt.Errorf("xcm transfer amount must be greater than 0")
+	}
+	sender, err := address.DecodeMulti(args.From)
+	if err != nil {
+		return &tx.Tx{}, err
+	}
+	beneficiary, err := address.Decode(args.To)
+	if err != nil {
+		return &tx.Tx{}, err
+	}
+
+	method := "limited_reserve_transfer_assets"
+	if args.Teleport {
+		method = "limited_teleport_assets"
+	}
+	// The native asset is the relay chain's, so it's 1 level up from a parachain
+	var parents uint8
+	pallet := XcmPalletRelay
+	if !txInput.Meta.HasCall(XcmPalletRelay + "." + method) {
+		pallet = XcmPalletParachain
+		parents = 1
+	}
+
+	dest := XcmLocation{Parents: parents}
+	if args.Parachain != 0 {
+		dest.Interior = []XcmJunction{{Parachain: args.Parachain}}
+	} else if parents == 0 {
+		return nil, fmt.Errorf("xcm transfer from the relay chain must set a destination parachain")
+	}
+	assets := VersionedXcmAssets{{
+		Id:     XcmLocation{Parents: parents},
+		Amount: types.NewUCompact(args.Amount.Int()),
+	}}
+	feeAssetItem := types.NewU32(0)
+
+	call, err := tx_input.NewCall(&txInput.Meta, pallet+"."+method,
+		VersionedXcmLocation(dest),
+		VersionedXcmLocation(XcmLocation{Interior: []XcmJunction{{AccountId: beneficiary.ToBytes()}}}),
+		assets,
+		feeAssetItem,
+		XcmWeightUnlimited,
+	)
+	if err != nil {
+		return &tx.Tx{}, err
+	}
+
+	return tx.NewTx(extrinsic.NewDynamicExtrinsic(&call), sender, txInput.Tip, txInput)
+}
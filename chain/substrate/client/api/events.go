@@ -27,6 +27,7 @@ const BindFrom EventBind = "from"
 const BindTo EventBind = "to"
 const BindAmount EventBind = "amount"
 const BindValidator EventBind = "validator"
+const BindContract EventBind = "contract"
 
 type EventValueType string
 
@@ -77,6 +78,37 @@ var SupportedEvents = []EventDescriptor{
 			},
 		},
 	},
+	{
+		// pallet-assets tokens, e.g. USDT on Asset Hub
+		Module: "Assets",
+		Event:  "Transferred",
+		Attributes: []*EventAttributeDescriptor{
+			{
+				Name:  "asset_id",
+				Index: 0,
+				Bind:  BindContract,
+				Type:  EventInteger,
+			},
+			{
+				Name:  "from",
+				Index: 1,
+				Bind:  BindFrom,
+				Type:  EventAddress,
+			},
+			{
+				Name:  "to",
+				Index: 2,
+				Bind:  BindTo,
+				Type:  EventAddress,
+			},
+			{
+				Name:  "amount",
+				Index: 3,
+				Bind:  BindAmount,
+				Type:  EventInteger,
+			},
+		},
+	},
 	{
 		Module: "NominationPools",
 		Event:  "Withdrawn",
@@ -268,7 +300,7 @@ func ParseFailed(events []EventI) (string, bool) {
 		// too difficult to decode further to sus out an error code or message
 		return xcerrors.TransactionFailuref("unable to decode reason").Error(), true
 	}
-	return "", false
+	return ParseXcmFailed(events)
 }
 
 func ParseFee(ab xc.AddressBuilder, events []EventI) (xc.Address, xc.AmountBlockchain, bool, error) {
@@ -307,6 +339,7 @@ func ParseEvents(ab xc.AddressBuilder, chain xc.NativeAsset, events []EventI) (s
 			continue
 		}
 		var from, to xc.Address
+		var contract xc.ContractAddress
 		var amount xc.AmountBlockchain
 		var eventDescriptor *txinfo.Event
 		if desc, ok := ev.GetEventDescriptor(); ok {
@@ -342,26 +375,33 @@ func ParseEvents(ab xc.AddressBuilder, chain xc.NativeAsset, events []EventI) (s
 				switch attr.Bind {
 				case BindAmount:
 					amount = xc.NewAmountBlockchainFromStr(asString)
+				case BindContract:
+					contract = xc.ContractAddress(asString)
 				default:
 					return nil, nil, fmt.Errorf("substrate event %s attribute %s has invalid bind configured: %s", handle, attr.Name, attr.Bind)
 				}
 			}
 		}
+		if contract != "" && eventDescriptor != nil {
+			eventDescriptor = txinfo.NewEvent(eventDescriptor.Id, txinfo.MovementVariantToken)
+		}
 
 		if from != "" {
 			sources = append(sources, &txinfo.LegacyTxInfoEndpoint{
-				Address:     from,
-				NativeAsset: chain,
-				Amount:      amount,
-				Event:       eventDescriptor,
+				Address:         from,
+				ContractAddress: contract,
+				NativeAsset:     chain,
+				Amount:          amount,
+				Event:           eventDescriptor,
 			})
 		}
 		if to != "" {
 			destinations = append(destinations, &txinfo.LegacyTxInfoEndpoint{
-				Address:     to,
-				NativeAsset: chain,
-				Amount:      amount,
-				Event:       eventDescriptor,
+				Address:         to,
+				ContractAddress: contract,
+				NativeAsset:     chain,
+				Amount:          amount,
+				Event:           eventDescriptor,
 			})
 		}
 	}
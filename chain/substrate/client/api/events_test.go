@@ -7,7 +7,9 @@ import (
 	"github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/chain/substrate/address"
 	"github.com/cordialsys/crosschain/chain/substrate/client/api"
+	"github.com/cordialsys/crosschain/chain/substrate/client/api/subscan"
 	"github.com/cordialsys/crosschain/chain/substrate/client/api/taostats"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
 	"github.com/stretchr/testify/require"
 )
 
@@ -134,3 +136,150 @@ func TestTaoEvents(t *testing.T) {
 	require.True(t, ok, "has fee")
 	require.Equal(t, fee.String(), "1234")
 }
+
+func subscanEvents(t *testing.T, raw string) []api.EventI {
+	events := []*subscan.Event{}
+	require.NoError(t, json.Unmarshal([]byte(raw), &events))
+	eventsI := []api.EventI{}
+	for _, ev := range events {
+		_, err := ev.ParseParams()
+		require.NoError(t, err)
+		eventsI = append(eventsI, ev)
+	}
+	return eventsI
+}
+
+func TestAssetEvents(t *testing.T) {
+	eventsI := subscanEvents(t, `[
+		{"event_index":"9000000-2","module_id":"assets","event_id":"Transferred","params":[
+			{"type":"U32","type_name":"AssetId","value":1984,"name":"asset_id"},
+			{"type":"[U8; 32]","type_name":"AccountId","value":"0x5df87265f6ce0c1914eb15c3bdacf6722373e69a1a8d90ac0bc58f5e7fdd246d","name":"from"},
+			{"type":"[U8; 32]","type_name":"AccountId","value":"0x4f3396dd2c6b55498f67ce8883524360347427e30cbc50fb981922de73c4551e","name":"to"},
+			{"type":"U128","type_name":"Balance","value":"2500000","name":"amount"}
+		]},
+		{"event_index":"9000000-2","module_id":"transactionpayment","event_id":"TransactionFeePaid","params":[
+			{"type":"[U8; 32]","type_name":"AccountId","value":"0x5df87265f6ce0c1914eb15c3bdacf6722373e69a1a8d90ac0bc58f5e7fdd246d","name":"who"},
+			{"type":"U128","type_name":"BalanceOf","value":"1000","name":"actual_fee"}
+		]}
+	]`)
+	chain := crosschain.NewChainConfig(crosschain.DOT).WithChainPrefix("0")
+	addressBuilder, err := address.NewAddressBuilder(chain.Base())
+	require.NoError(t, err)
+
+	sources, dests, err := api.ParseEvents(addressBuilder, chain.Chain, eventsI)
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	require.Len(t, dests, 1)
+	require.EqualValues(t, "138DFvwTQfQN9ZttPm1HDBVRcEwGfsPxdWRfKktrquziu8c2", sources[0].Address)
+	require.EqualValues(t, "12nr7GiDrYHzAYT9L8HdeXnMfWcBuYfAXpgfzf3upujeCciz", dests[0].Address)
+	for _, endpoint := range append(sources, dests...) {
+		require.EqualValues(t, "1984", endpoint.ContractAddress)
+		require.Equal(t, "2500000", endpoint.Amount.String())
+		require.Equal(t, txinfo.NewEvent("9000000-2", txinfo.MovementVariantToken), endpoint.Event)
+	}
+
+	_, ok := api.ParseFailed(eventsI)
+	require.False(t, ok)
+	burns, err := api.ParseXcmBurns(addressBuilder, chain.Chain, eventsI)
+	require.NoError(t, err)
+	require.Empty(t, burns)
+}
+
+func TestXcmEvents(t *testing.T) {
+	sender := "0x5df87265f6ce0c1914eb15c3bdacf6722373e69a1a8d90ac0bc58f5e7fdd246d"
+	burnedEvent := func(who string, amount string) string {
+		return `{"event_index":"9000000-3","module_id":"balances","event_id":"Burned","params":[
+		{"type":"[U8; 32]","type_name":"AccountId","value":"` + who + `","name":"who"},
+		{"type":"U128","type_name":"Balance","value":"` + amount + `","name":"amount"}
+	]}`
+	}
+	burned := burnedEvent(sender, "10000000000")
+	// the delivery fee is burned from the sender too
+	deliveryFee := burnedEvent(sender, "3000000")
+	// a burn of another account of the same amount
+	otherBurned := burnedEvent("0x4f3396dd2c6b55498f67ce8883524360347427e30cbc50fb981922de73c4551e", "10000000000")
+	feePaid := `{"event_index":"9000000-3","module_id":"transactionpayment","event_id":"TransactionFeePaid","params":[
+		{"type":"[U8; 32]","type_name":"AccountId","value":"` + sender + `","name":"who"},
+		{"type":"U128","type_name":"BalanceOf","value":"157316518","name":"actual_fee"},
+		{"type":"U128","type_name":"BalanceOf","value":"0","name":"tip"}
+	]}`
+	sent := `{"event_index":"9000000-3","module_id":"xcmpallet","event_id":"Sent","params":[
+		{"type":"staging_xcm:v4:location:Location","name":"origin","value":{"parents":0,"interior":{"X1":[{"AccountId32":{"network":null,"id":"` + sender + `"}}]}}},
+		{"type":"staging_xcm:v4:location:Location","name":"destination","value":{"parents":0,"interior":{"X1":[{"Parachain":1000}]}}},
+		{"type":"Vec<staging_xcm:v4:Instruction>","name":"message","value":[
+			{"ReceiveTeleportedAsset":[{"id":{"parents":1,"interior":"Here"},"fun":{"Fungible":"10000000000"}}]},
+			{"ClearOrigin":null},
+			{"BuyExecution":{"fees":{"id":{"parents":1,"interior":"Here"},"fun":{"Fungible":"10000000000"}},"weight_limit":"Unlimited"}}
+		]},
+		{"type":"[U8; 32]","name":"message_id","value":"0x00"}
+	]}`
+	reserveSent := `{"event_index":"9000000-3","module_id":"xcmpallet","event_id":"Sent","params":[
+		{"type":"staging_xcm:v4:location:Location","name":"origin","value":{"parents":0,"interior":{"X1":[{"AccountId32":{"network":null,"id":"` + sender + `"}}]}}},
+		{"type":"staging_xcm:v4:location:Location","name":"destination","value":{"parents":0,"interior":{"X1":[{"Parachain":2000}]}}},
+		{"type":"Vec<staging_xcm:v4:Instruction>","name":"message","value":[
+			{"ReserveAssetDeposited":[{"id":{"parents":1,"interior":"Here"},"fun":{"Fungible":"10000000000"}}]},
+			{"ClearOrigin":null}
+		]},
+		{"type":"[U8; 32]","name":"message_id","value":"0x00"}
+	]}`
+	attempted := `{"event_index":"9000000-3","module_id":"xcmpallet","event_id":"Attempted","params":[{"type":"staging_xcm:v4:traits:Outcome","name":"outcome","value":{"Complete":{"used":{"proof_size":0,"ref_time":1000}}}}]}`
+	chain := crosschain.NewChainConfig(crosschain.DOT).WithChainPrefix("0")
+	addressBuilder, err := address.NewAddressBuilder(chain.Base())
+	require.NoError(t, err)
+
+	vectors := []struct {
+		name    string
+		events  string
+		burns   int
+		failure string
+	}{
+		{
+			name:   "teleport",
+			events: `[` + burned + `,` + attempted + `,` + sent + `,` + feePaid + `]`,
+			burns:  1,
+		},
+		{
+			name:   "teleport with unrelated burns",
+			events: `[` + otherBurned + `,` + deliveryFee + `,` + burned + `,` + attempted + `,` + sent + `,` + feePaid + `]`,
+			burns:  1,
+		},
+		{
+			name:   "reserve transfer",
+			events: `[` + deliveryFee + `,` + attempted + `,` + reserveSent + `,` + feePaid + `]`,
+		},
+		{
+			name:    "incomplete",
+			events:  `[` + burned + `,{"event_index":"9000000-3","module_id":"polkadotxcm","event_id":"Attempted","params":[{"type":"staging_xcm:v4:traits:Outcome","name":"outcome","value":{"Incomplete":{"used":{"proof_size":0,"ref_time":1000},"error":"TooExpensive"}}}]},` + feePaid + `]`,
+			failure: "xcm incomplete: TooExpensive",
+		},
+		{
+			name:    "error",
+			events:  `[{"event_index":"9000000-3","module_id":"xcmpallet","event_id":"Attempted","params":[{"type":"staging_xcm:v4:traits:Outcome","name":"outcome","value":{"__kind":"Error","error":"FailedToTransactAsset"}}]}]`,
+			failure: "xcm error: FailedToTransactAsset",
+		},
+		{
+			name:   "burn without xcm",
+			events: `[` + burned + `,` + feePaid + `]`,
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			eventsI := subscanEvents(t, v.events)
+			failure, failed := api.ParseFailed(eventsI)
+			if v.failure != "" {
+				require.True(t, failed)
+				require.Contains(t, failure, v.failure)
+			} else {
+				require.False(t, failed)
+			}
+
+			burns, err := api.ParseXcmBurns(addressBuilder, chain.Chain, eventsI)
+			require.NoError(t, err)
+			require.Len(t, burns, v.burns)
+			for _, burn := range burns {
+				require.EqualValues(t, "138DFvwTQfQN9ZttPm1HDBVRcEwGfsPxdWRfKktrquziu8c2", burn.Address)
+				require.Equal(t, "10000000000", burn.Amount.String())
+			}
+		})
+	}
+}
@@ -24,3 +24,18 @@ type AccountInfoMinimal struct {
 		// skip fields after this point as we don't need them
 	}
 }
+
+// AssetAccountMinimal is the leading balance of a pallet-assets `Assets.Account` entry
+type AssetAccountMinimal struct {
+	Balance types.U128
+	// skip fields after this point as we don't need them
+}
+
+// AssetMetadata is a pallet-assets `Assets.Metadata` entry
+type AssetMetadata struct {
+	Deposit  types.U128
+	Name     types.Bytes
+	Symbol   types.Bytes
+	Decimals types.U8
+	IsFrozen types.Bool
+}
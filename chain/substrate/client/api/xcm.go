@@ -0,0 +1,176 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	xcerrors "github.com/cordialsys/crosschain/client/errors"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+)
+
+// The XCM pallet is named "XcmPallet" on relay chains and "PolkadotXcm" on parachains
+var XcmModules = []string{"XcmPallet", "PolkadotXcm"}
+
+func findXcm(events []EventI, event string) (EventI, bool) {
+	for _, module := range XcmModules {
+		if ev, ok := find(events, module, event); ok {
+			return ev, true
+		}
+	}
+	return nil, false
+}
+
+// xcmOutcome returns the kind of an XCM outcome and its contents. Depending on the indexer this is
+// reported as `{"<kind>": {...}}`, `{"__kind": "<kind>", ...}`, or just the kind.
+func xcmOutcome(outcome interface{}) (string, interface{}) {
+	switch outcome := outcome.(type) {
+	case string:
+		return outcome, nil
+	case map[string]interface{}:
+		if kind, ok := outcome["__kind"].(string); ok {
+			return kind, outcome
+		}
+		for kind, value := range outcome {
+			return kind, value
+		}
+	}
+	return "", nil
+}
+
+// ParseXcmFailed checks the outcome of executing an XCM message locally.  A message that did not
+// complete has not transferred the assets.
+func ParseXcmFailed(events []EventI) (string, bool) {
+	ev, ok := findXcm(events, "Attempted")
+	if !ok {
+		return "", false
+	}
+	outcome, ok := ev.GetParam("outcome", 0)
+	if !ok {
+		return "", false
+	}
+	kind, value := xcmOutcome(outcome)
+	if !strings.EqualFold(kind, "Incomplete") && !strings.EqualFold(kind, "Error") {
+		return "", false
+	}
+	reason := value
+	if fields, ok := value.(map[string]interface{}); ok {
+		if xcmErr, ok := fields["error"]; ok {
+			reason = xcmErr
+		}
+	}
+	if reason == nil {
+		return xcerrors.TransactionFailuref("xcm %s", strings.ToLower(kind)).Error(), true
+	}
+	return xcerrors.TransactionFailuref("xcm %s: %v", strings.ToLower(kind), reason).Error(), true
+}
+
+// xcmTeleportedAmount finds the amount in the ReceiveTeleportedAsset instruction of a sent XCM
+// message, which is the asset leg of a teleport.
+func xcmTeleportedAmount(message interface{}) (string, bool) {
+	switch message := message.(type) {
+	case []interface{}:
+		for _, value := range message {
+			if amount, ok := xcmTeleportedAmount(value); ok {
+				return amount, true
+			}
+		}
+	case map[string]interface{}:
+		if kind, ok := message["__kind"].(string); ok && strings.EqualFold(kind, "ReceiveTeleportedAsset") {
+			return xcmFungible(message)
+		}
+		for kind, value := range message {
+			if strings.EqualFold(kind, "ReceiveTeleportedAsset") {
+				return xcmFungible(value)
+			}
+			if amount, ok := xcmTeleportedAmount(value); ok {
+				return amount, true
+			}
+		}
+	}
+	return "", false
+}
+
+// xcmFungible finds the first fungible amount in a list of XCM assets
+func xcmFungible(assets interface{}) (string, bool) {
+	switch assets := assets.(type) {
+	case []interface{}:
+		for _, value := range assets {
+			if amount, ok := xcmFungible(value); ok {
+				return amount, true
+			}
+		}
+	case map[string]interface{}:
+		for kind, value := range assets {
+			if strings.EqualFold(kind, "Fungible") {
+				return fmt.Sprint(value), true
+			}
+			if amount, ok := xcmFungible(value); ok {
+				return amount, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ParseXcmBurns returns the asset burned from the sender when teleporting it to another chain.  Only
+// the Balances.Burned event of the sender for the teleported amount is reported, as delivery fees
+// are burned from the sender as well.  Assets transferred via the reserve are instead moved to the
+// destination's sovereign account, which is reported as a normal transfer.
+func ParseXcmBurns(ab xc.AddressBuilder, chain xc.NativeAsset, events []EventI) ([]*txinfo.LegacyTxInfoEndpoint, error) {
+	sent, ok := findXcm(events, "Sent")
+	if !ok {
+		return nil, nil
+	}
+	message, ok := sent.GetParam("message", 2)
+	if !ok {
+		return nil, nil
+	}
+	teleportedRaw, ok := xcmTeleportedAmount(message)
+	if !ok {
+		return nil, nil
+	}
+	teleported := xc.NewAmountBlockchainFromStr(teleportedRaw)
+	sender, _, ok, err := ParseFee(ab, events)
+	if err != nil || !ok {
+		return nil, err
+	}
+	burns := []*txinfo.LegacyTxInfoEndpoint{}
+	for _, ev := range events {
+		if !strings.EqualFold(ev.GetModule(), "Balances") || !strings.EqualFold(ev.GetId(), "Burned") {
+			continue
+		}
+		who, ok := ev.GetParam("who", 0)
+		if !ok {
+			return nil, fmt.Errorf("substrate event Balances.Burned did not contain expected param who")
+		}
+		whoString, ok := who.(string)
+		if !ok {
+			return nil, fmt.Errorf("substrate event Balances.Burned who unexpected type: %T", who)
+		}
+		addr, err := ParseAddress(ab, whoString)
+		if err != nil {
+			return nil, err
+		}
+		amount, ok := ev.GetParam("amount", 1)
+		if !ok {
+			return nil, fmt.Errorf("substrate event Balances.Burned did not contain expected param amount")
+		}
+		burned := xc.NewAmountBlockchainFromStr(fmt.Sprint(amount))
+		if addr != sender || burned.Cmp(&teleported) != 0 {
+			continue
+		}
+		endpoint := &txinfo.LegacyTxInfoEndpoint{
+			Address:     addr,
+			NativeAsset: chain,
+			Amount:      burned,
+		}
+		if desc, ok := ev.GetEventDescriptor(); ok {
+			endpoint.Event = desc
+		}
+		burns = append(burns, endpoint)
+		// the teleported asset is burned once
+		break
+	}
+	return burns, nil
+}
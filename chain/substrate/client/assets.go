@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/substrate/address"
+	"github.com/cordialsys/crosschain/chain/substrate/client/api"
+	"github.com/cordialsys/crosschain/chain/substrate/tx_input"
+)
+
+// assetStorageKey creates a key for a pallet-assets storage map, which are keyed by the asset id first
+func assetStorageKey(meta *types.Metadata, method string, contract xc.ContractAddress, args ...[]byte) (types.StorageKey, error) {
+	assetId, err := tx_input.ParseAssetId(contract)
+	if err != nil {
+		return nil, err
+	}
+	assetIdBz, err := codec.Encode(types.NewU32(assetId))
+	if err != nil {
+		return nil, err
+	}
+	key, err := types.CreateStorageKey(meta, "Assets", method, append([][]byte{assetIdBz}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("chain does not support pallet-assets: %v", err)
+	}
+	return key, nil
+}
+
+// FetchAssetBalance fetches the balance of a pallet-assets token, e.g. USDT on Asset Hub
+func (client *Client) FetchAssetBalance(ctx context.Context, addr xc.Address, contract xc.ContractAddress) (xc.AmountBlockchain, error) {
+	zero := xc.NewAmountBlockchainFromUint64(0)
+	meta, err := client.DotClient.RPC.State.GetMetadataLatest()
+	if err != nil {
+		return zero, err
+	}
+	addrBz, err := address.Decode(addr)
+	if err != nil {
+		return zero, err
+	}
+	key, err := assetStorageKey(meta, "Account", contract, addrBz.ToBytes())
+	if err != nil {
+		return zero, err
+	}
+
+	var account api.AssetAccountMinimal
+	ok, err := client.DotClient.RPC.State.GetStorageLatest(key, &account)
+	if err != nil {
+		return zero, err
+	}
+	if !ok {
+		// account does not hold the asset
+		return zero, nil
+	}
+	return xc.AmountBlockchain(*account.Balance.Int), nil
+}
+
+// FetchAssetDecimals fetches the decimals of a pallet-assets token from its metadata
+func (client *Client) FetchAssetDecimals(ctx context.Context, contract xc.ContractAddress) (int, error) {
+	meta, err := client.DotClient.RPC.State.GetMetadataLatest()
+	if err != nil {
+		return 0, err
+	}
+	key, err := assetStorageKey(meta, "Metadata", contract)
+	if err != nil {
+		return 0, err
+	}
+
+	var metadata api.AssetMetadata
+	ok, err := client.DotClient.RPC.State.GetStorageLatest(key, &metadata)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("unsupported asset: %v", contract)
+	}
+	return int(metadata.Decimals), nil
+}
@@ -146,11 +146,20 @@ func (client *Client) FetchAccountNonce(meta types.Metadata, from xc.Address) (u
 
 // FetchLegacyTxInput returns tx input for a Substrate tx
 func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+	return client.fetchSenderInput(ctx, args.GetFrom())
+}
+
+// FetchXcmInput returns tx input for an XCM transfer sent by `from`
+func (client *Client) FetchXcmInput(ctx context.Context, from xc.Address) (*tx_input.TxInput, error) {
+	return client.fetchSenderInput(ctx, from)
+}
+
+func (client *Client) fetchSenderInput(ctx context.Context, from xc.Address) (*tx_input.TxInput, error) {
 	meta, txInput, err := client.FetchTxInputChain()
 	if err != nil {
 		return &tx_input.TxInput{}, err
 	}
-	txInput.Nonce, err = client.FetchAccountNonce(*meta, args.GetFrom())
+	txInput.Nonce, err = client.FetchAccountNonce(*meta, from)
 	if err != nil {
 		return &tx_input.TxInput{}, err
 	}
@@ -222,11 +231,16 @@ func (client *Client) SubmitTx(ctx context.Context, txInput xctypes.SubmitTxReq)
 
 // FetchLegacyTxInfo returns tx info for a Substrate tx
 func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (txinfo.LegacyTxInfo, error) {
+	tx, _, err := client.fetchLegacyTxInfo(ctx, txHash)
+	return tx, err
+}
+
+func (client *Client) fetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (txinfo.LegacyTxInfo, []api.EventI, error) {
 	var tx txinfo.LegacyTxInfo
 
 	addressBuilder, err := address.NewAddressBuilder(client.Asset.GetChain().Base())
 	if err != nil {
-		return txinfo.LegacyTxInfo{}, err
+		return txinfo.LegacyTxInfo{}, nil, err
 	}
 	chain := client.Asset.GetChain().Chain
 	var eventsI = []api.EventI{}
@@ -243,16 +257,16 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		var response graphql.SubqueryExtrinsicResponse
 		err := graphql.Post(ctx, client.indexerUrl, []byte(extrinsicQuery), &response, args)
 		if err != nil {
-			return txinfo.LegacyTxInfo{}, err
+			return txinfo.LegacyTxInfo{}, nil, err
 		}
 
 		if len(response.Data.Extrinsics.Nodes) == 0 {
-			return txinfo.LegacyTxInfo{}, fmt.Errorf("no transaction found by hash %s", txHash)
+			return txinfo.LegacyTxInfo{}, nil, fmt.Errorf("no transaction found by hash %s", txHash)
 		}
 		ext := response.Data.Extrinsics.Nodes[0]
 		height, offset, err := ext.ID.Parse()
 		if err != nil {
-			return txinfo.LegacyTxInfo{}, err
+			return txinfo.LegacyTxInfo{}, nil, err
 		}
 		eventsQuery := fmt.Sprintf(
 			`{"query":"query {      events(first: 100, offset: 0, filter: {blockHeight:{equalTo:\"%d\"} extrinsicId:{equalTo: %d}}, orderBy: ID_DESC) { nodes { module event data } } blocks(first: 1, offset: 0, filter: {height:{equalTo:\"%d\"} }, orderBy: ID_DESC) { nodes { timestamp hash } } } "}`,
@@ -261,16 +275,16 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		var eventsResponse graphql.SubqueryEventResponse
 		err = graphql.Post(ctx, client.indexerUrl, []byte(eventsQuery), &eventsResponse, args)
 		if err != nil {
-			return txinfo.LegacyTxInfo{}, err
+			return txinfo.LegacyTxInfo{}, nil, err
 		}
 		if len(eventsResponse.Data.Blocks.Nodes) == 0 {
-			return txinfo.LegacyTxInfo{}, fmt.Errorf("no block found at height %d", height)
+			return txinfo.LegacyTxInfo{}, nil, fmt.Errorf("no block found at height %d", height)
 		}
 		block := eventsResponse.Data.Blocks.Nodes[0]
 		for _, ev := range eventsResponse.Data.Events.Nodes {
 			_, err := ev.ParseParams()
 			if err != nil {
-				return txinfo.LegacyTxInfo{}, err
+				return txinfo.LegacyTxInfo{}, nil, err
 			}
 			eventsI = append(eventsI, ev)
 		}
@@ -283,17 +297,17 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		taostatClient := taostats.NewClient(client.indexerUrl, client.apiKey, client.Asset.GetChain().Limiter)
 		ext, err := taostatClient.GetTransaction(ctx, string(txHash))
 		if err != nil {
-			return txinfo.LegacyTxInfo{}, err
+			return txinfo.LegacyTxInfo{}, nil, err
 		}
 
 		block, err := taostatClient.GetBlock(ctx, ext.BlockNumber)
 		if err != nil {
-			return txinfo.LegacyTxInfo{}, err
+			return txinfo.LegacyTxInfo{}, nil, err
 		}
 
 		events, err := taostatClient.GetEvents(ctx, ext)
 		if err != nil {
-			return txinfo.LegacyTxInfo{}, err
+			return txinfo.LegacyTxInfo{}, nil, err
 		}
 
 		for _, event := range events {
@@ -328,16 +342,16 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		var txInfoResp subscan.SubscanExtrinsicResponse
 		err = subscan.Post(ctx, client.indexerUrl+"/api/scan/extrinsic", []byte(reqBody), &txInfoResp, args)
 		if err != nil {
-			return txinfo.LegacyTxInfo{}, fmt.Errorf("failed to lookup extrinsic: %v", err)
+			return txinfo.LegacyTxInfo{}, nil, fmt.Errorf("failed to lookup extrinsic: %v", err)
 		}
 		if len(txInfoResp.Data.BlockHash) == 0 {
-			return txinfo.LegacyTxInfo{}, fmt.Errorf("not found")
+			return txinfo.LegacyTxInfo{}, nil, fmt.Errorf("not found")
 		}
 
 		for _, ev := range txInfoResp.Data.Event {
 			_, err := ev.ParseParams()
 			if err != nil {
-				return txinfo.LegacyTxInfo{}, fmt.Errorf("could not parse event params: %v", err)
+				return txinfo.LegacyTxInfo{}, nil, fmt.Errorf("could not parse event params: %v", err)
 			}
 			eventsI = append(eventsI, ev)
 		}
@@ -359,7 +373,7 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		rawClient := rpc.NewClient(client.DotClient, maxDepth, client.Asset.GetChain().Limiter)
 		txInfo, err := rawClient.GetTx(ctx, string(txHash))
 		if err != nil {
-			return txinfo.LegacyTxInfo{}, err
+			return txinfo.LegacyTxInfo{}, nil, err
 		}
 		eventsI = txInfo.Events
 		tx.TxID = "0x" + hex.EncodeToString(txInfo.ExtrinsicHash)
@@ -374,7 +388,7 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		// calculate confirmations
 		header, err := client.DotClient.RPC.Chain.GetHeaderLatest()
 		if err != nil {
-			return tx, err
+			return tx, nil, err
 		}
 		tx.Confirmations = int64(header.Number) - tx.BlockIndex
 	}
@@ -388,12 +402,12 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 
 	tx.Sources, tx.Destinations, err = api.ParseEvents(addressBuilder, chain, eventsI)
 	if err != nil {
-		return txinfo.LegacyTxInfo{}, fmt.Errorf("could not parse events: %v", err)
+		return txinfo.LegacyTxInfo{}, nil, fmt.Errorf("could not parse events: %v", err)
 	}
 
 	stakes, unstakes, err := api.ParseStakingEvents(addressBuilder, chain, eventsI)
 	if err != nil {
-		return txinfo.LegacyTxInfo{}, fmt.Errorf("could not staking events: %v", err)
+		return txinfo.LegacyTxInfo{}, nil, fmt.Errorf("could not staking events: %v", err)
 	}
 	for _, ev := range stakes {
 		tx.AddStakeEvent(ev)
@@ -413,7 +427,7 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		// check for fee from events
 		from, fee, ok, err := api.ParseFee(addressBuilder, eventsI)
 		if err != nil {
-			return txinfo.LegacyTxInfo{}, fmt.Errorf("could not parse fee: %v", err)
+			return txinfo.LegacyTxInfo{}, nil, fmt.Errorf("could not parse fee: %v", err)
 		}
 		if ok {
 			if tx.From == "" {
@@ -423,11 +437,11 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		}
 	}
 
-	return tx, nil
+	return tx, eventsI, nil
 }
 
 func (client *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinfo.TxInfo, error) {
-	legacyTx, err := client.FetchLegacyTxInfo(ctx, args.TxHash())
+	legacyTx, events, err := client.fetchLegacyTxInfo(ctx, args.TxHash())
 	if err != nil {
 		// TODO should test each provider instead
 		if strings.Contains(strings.ToLower(err.Error()), "not found") {
@@ -437,7 +451,29 @@ func (client *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinf
 	}
 
 	// remap to new tx
-	return txinfo.TxInfoFromLegacy(client.Asset.GetChain(), legacyTx, txinfo.Account), nil
+	txInfo := txinfo.TxInfoFromLegacy(client.Asset.GetChain(), legacyTx, txinfo.Account)
+
+	// assets teleported over XCM leave the chain without a destination here
+	addressBuilder, err := address.NewAddressBuilder(client.Asset.GetChain().Base())
+	if err != nil {
+		return txinfo.TxInfo{}, err
+	}
+	burns, err := api.ParseXcmBurns(addressBuilder, client.Asset.GetChain().Chain, events)
+	if err != nil {
+		return txinfo.TxInfo{}, fmt.Errorf("could not parse xcm events: %v", err)
+	}
+	for _, burn := range burns {
+		movement := txinfo.NewMovement(burn.NativeAsset, "")
+		movement.AddSource(burn.Address, burn.Amount, nil)
+		if burn.Event != nil {
+			movement.AddEventMeta(burn.Event)
+		}
+		txInfo.AddMovement(movement)
+	}
+	if chainCoin := client.Asset.GetChain().ChainCoin; len(burns) > 0 && chainCoin != "" {
+		txInfo.SetContractIdForNativeAsset(xc.ContractAddress(chainCoin))
+	}
+	return txInfo, nil
 }
 
 // FetchNativeBalance fetches account balance for a Substrate address
@@ -475,10 +511,10 @@ func (client *Client) FetchNativeBalance(ctx context.Context, addr xc.Address) (
 // FetchBalance fetches token balance for a Substrate address
 func (client *Client) FetchBalance(ctx context.Context, args *xclient.BalanceArgs) (xc.AmountBlockchain, error) {
 	contract, _ := args.Contract()
-	if contract == "" {
+	if contract == "" || client.Asset.GetChain().IsChain(contract) {
 		return client.FetchNativeBalance(ctx, args.Address())
 	} else {
-		return xc.AmountBlockchain{}, fmt.Errorf("token balance is not supported for substrate: %v", contract)
+		return client.FetchAssetBalance(ctx, args.Address(), contract)
 	}
 }
 
@@ -514,7 +550,7 @@ func (client *Client) FetchDecimals(ctx context.Context, contract xc.ContractAdd
 		return 9, nil
 	}
 
-	return 0, fmt.Errorf("unsupported asset: %v", contract)
+	return client.FetchAssetDecimals(ctx, contract)
 }
 func (client *Client) FetchBlock(ctx context.Context, args *xclient.BlockArgs) (*txinfo.BlockWithTransactions, error) {
 	var subBlock *types.SignedBlock
@@ -128,6 +128,27 @@ func TestBalance(t *testing.T) {
 	}
 }
 
+func TestAssetBalanceRequiresPalletAssets(t *testing.T) {
+	require := require.New(t)
+	// the test metadata is from the relay chain, which does not have pallet-assets
+	rpc, rpcClose := testtypes.MockJSONRPC(t, []string{RPC_META_RESPONSE, RPC_META_RESPONSE, RPC_META_RESPONSE})
+	defer rpcClose()
+	chain := xc.NewChainConfig(xc.DOT, "substrate").
+		WithUrl(rpc.URL).
+		WithIndexer(client.IndexerRpc, "").
+		WithChainPrefix("0").
+		WithDecimals(10)
+	client, err := client.NewClient(chain)
+	require.NoError(err)
+
+	args := xclient.NewBalanceArgs("1598AR2pgoJCWHn3UA2FTemJ74hBWgp7GLyNB4oSkt6vqMno", xclient.BalanceOptionContract("1984"))
+	_, err = client.FetchBalance(context.Background(), args)
+	require.ErrorContains(err, "does not support pallet-assets")
+
+	_, err = client.FetchDecimals(context.Background(), "USDT")
+	require.ErrorContains(err, "invalid substrate asset id")
+}
+
 func TestFetchTxInfo(t *testing.T) {
 
 	type testcase struct {
@@ -396,6 +417,16 @@ func TestFetchTxInput(t *testing.T) {
 				SectionIndex: 5,
 				MethodIndex:  3,
 			},
+			{
+				Name:         "XcmPallet.limited_reserve_transfer_assets",
+				SectionIndex: 99,
+				MethodIndex:  8,
+			},
+			{
+				Name:         "XcmPallet.limited_teleport_assets",
+				SectionIndex: 99,
+				MethodIndex:  9,
+			},
 			{
 				Name:         "NominationPools.join",
 				SectionIndex: 39,
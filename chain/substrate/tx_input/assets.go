@@ -0,0 +1,17 @@
+package tx_input
+
+import (
+	"fmt"
+	"strconv"
+
+	xc "github.com/cordialsys/crosschain"
+)
+
+// ParseAssetId parses the id of a pallet-assets asset, e.g. "1984" for USDT on Asset Hub.
+func ParseAssetId(contract xc.ContractAddress) (uint32, error) {
+	id, err := strconv.ParseUint(string(contract), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid substrate asset id %q: must be an integer", contract)
+	}
+	return uint32(id), nil
+}
@@ -13,6 +13,13 @@ import (
 var usedSubstrateCalls = []string{
 	"Balances.transfer_keep_alive",
 	"Assets.transfer",
+	"Assets.transfer_keep_alive",
+	// XCM transfers from the relay chain
+	"XcmPallet.limited_reserve_transfer_assets",
+	"XcmPallet.limited_teleport_assets",
+	// XCM transfers from a parachain
+	"PolkadotXcm.limited_reserve_transfer_assets",
+	"PolkadotXcm.limited_teleport_assets",
 	"SubtensorModule.add_stake",
 	"SubtensorModule.remove_stake",
 	"NominationPools.join",
@@ -43,6 +50,11 @@ func (m *Metadata) FindCallIndex(name string) (types.CallIndex, error) {
 	return types.CallIndex{}, fmt.Errorf("unsupported substrate method: %s", name)
 }
 
+func (m *Metadata) HasCall(name string) bool {
+	_, err := m.FindCallIndex(name)
+	return err == nil
+}
+
 // We explicitly define which substrate operations we support so that we can trim down
 // the massive metadata description for all possible substrate transactions that's needed as tx-input.
 func ParseMeta(meta *types.Metadata) (Metadata, error) {
@@ -94,9 +94,11 @@ func (txBuilder TxBuilder) newTransactionBase(feePayer xc.Address, from xc.Addre
 	//  - total sui balance remainder after transfering `amount`.
 
 	normal_budget := local_input.GasBudget
-	gas_coin_balance := local_input.GasCoin.Balance.Uint64()
+	gas_coin_balance := local_input.GasBalance()
 	total_remainder := gas_coin_balance
-	if local_input.IsNativeTransfer() {
+	// a sponsor only pays for gas, so the transfer is funded by the sender's coins alone
+	sponsored := feePayer != from
+	if local_input.IsNativeTransfer() && !sponsored {
 		if local_input.TotalBalance().Uint64() < amount.Uint64() {
 			return transactionBase{}, fmt.Errorf("not enough funds to send after paying for sui gas: budget=%d tf=%d", local_input.GasBudget, amount.Uint64())
 		}
@@ -122,21 +124,29 @@ func (txBuilder TxBuilder) newTransactionBase(feePayer xc.Address, from xc.Addre
 		Field1: bcs.SequenceNumber(gasVersion),
 		Field2: gasDigest,
 	}
+	payment := []struct {
+		Field0 bcs.ObjectID
+		Field1 bcs.SequenceNumber
+		Field2 bcs.ObjectDigest
+	}{
+		gasCoin,
+	}
+	for _, coin := range local_input.ExtraGasCoins {
+		obj, err := CoinToObject(coin)
+		if err != nil {
+			return transactionBase{}, fmt.Errorf("could not decode gas coin: %w", err)
+		}
+		payment = append(payment, ObjectRef(*obj))
+	}
 
 	// Common transaction
 	tx := bcs.TransactionData__V1{
 		Value: bcs.TransactionDataV1{
 			GasData: bcs.GasData{
-				Payment: []struct {
-					Field0 bcs.ObjectID
-					Field1 bcs.SequenceNumber
-					Field2 bcs.ObjectDigest
-				}{
-					gasCoin,
-				},
-				Owner:  feePayerData,
-				Price:  local_input.GasPrice,
-				Budget: local_input.GasBudget,
+				Payment: payment,
+				Owner:   feePayerData,
+				Price:   local_input.GasPrice,
+				Budget:  local_input.GasBudget,
 			},
 			Sender:     fromData,
 			Expiration: &expiration,
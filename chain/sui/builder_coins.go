@@ -0,0 +1,128 @@
+package sui
+
+import (
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/sui/generated/bcs"
+)
+
+// MergeCoins consolidates the coins in the input into a single object.
+// For SUI, the coins are merged into the gas coin.  Otherwise, they're merged into the largest coin.
+func (txBuilder TxBuilder) MergeCoins(from xc.Address, fromPubKey []byte, input *TxInput) (xc.Tx, error) {
+	if len(fromPubKey) == 0 {
+		return &Tx{}, errors.New("must set public key on TxInput for SUI")
+	}
+	txBase, err := txBuilder.newTransactionBase(from, from, xc.NewAmountBlockchainFromUint64(0), input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build merge base: %w", err)
+	}
+
+	coins := input.Coins
+	var primaryCoin bcs.Argument = &bcs.Argument__GasCoin{}
+	cmd_inputs := []bcs.CallArg{}
+	if !input.IsNativeTransfer() {
+		if len(coins) == 0 {
+			return nil, errors.New("no coins to merge")
+		}
+		obj, err := CoinToObject(coins[0])
+		if err != nil {
+			return nil, err
+		}
+		cmd_inputs = append(cmd_inputs, &bcs.CallArg__Object{Value: obj})
+		primaryCoin = ArgumentInput(0)
+		coins = coins[1:]
+	}
+	if len(coins) == 0 {
+		return nil, errors.New("no coins to merge")
+	}
+
+	merge_inputs := []bcs.Argument{}
+	for _, coin := range coins {
+		obj, err := CoinToObject(coin)
+		if err != nil {
+			return nil, err
+		}
+		merge_inputs = append(merge_inputs, ArgumentInput(uint16(len(cmd_inputs))))
+		cmd_inputs = append(cmd_inputs, &bcs.CallArg__Object{Value: obj})
+	}
+	commands := []bcs.Command{
+		&bcs.Command__MergeCoins{
+			Field0: primaryCoin,
+			Field1: merge_inputs,
+		},
+	}
+
+	return &Tx{
+		Tx:         txBase.Build(cmd_inputs, commands),
+		public_key: fromPubKey,
+	}, nil
+}
+
+// SplitCoins splits new coins of the given amounts off of the coins in the input, and sends them back to the owner.
+func (txBuilder TxBuilder) SplitCoins(from xc.Address, fromPubKey []byte, amounts []xc.AmountBlockchain, input *TxInput) (xc.Tx, error) {
+	if len(fromPubKey) == 0 {
+		return &Tx{}, errors.New("must set public key on TxInput for SUI")
+	}
+	if len(amounts) == 0 {
+		return &Tx{}, errors.New("must split at least one coin")
+	}
+	total := xc.NewAmountBlockchainFromUint64(0)
+	for _, amount := range amounts {
+		total = total.Add(&amount)
+	}
+	if !input.IsNativeTransfer() {
+		balance := xc.NewAmountBlockchainFromUint64(0)
+		for _, coin := range input.Coins {
+			coinBalance := xc.NewAmountBlockchainFromUint64(coin.Balance.Uint64())
+			balance = balance.Add(&coinBalance)
+		}
+		if balance.Cmp(&total) < 0 {
+			return &Tx{}, fmt.Errorf("not enough funds to split: balance=%s split=%s", balance.String(), total.String())
+		}
+	}
+
+	txBase, err := txBuilder.newTransactionBase(from, from, total, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build split base: %w", err)
+	}
+	primaryCoinInput, commands, cmd_inputs, err := txBuilder.prepareGasSplitAndMergeCommands(from, from, *input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gas split and merge commands: %w", err)
+	}
+
+	split_inputs := []bcs.Argument{}
+	for _, amount := range amounts {
+		split_inputs = append(split_inputs, ArgumentInput(uint16(len(cmd_inputs))))
+		cmd_inputs = append(cmd_inputs, U64ToPure(amount.Uint64()))
+	}
+	commands = append(commands, &bcs.Command__SplitCoins{
+		Field0: primaryCoinInput,
+		Field1: split_inputs,
+	})
+	splitIndex := uint16(len(commands) - 1)
+
+	// each new coin is a nested result of the split
+	newCoins := []bcs.Argument{}
+	for i := range amounts {
+		newCoins = append(newCoins, &bcs.Argument__NestedResult{
+			Field0: splitIndex,
+			Field1: uint16(i),
+		})
+	}
+	fromPure, err := HexToPure(string(from))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode 'from': %w", err)
+	}
+	commands = append(commands, &bcs.Command__TransferObjects{
+		Field0: newCoins,
+		Field1: ArgumentInput(uint16(len(cmd_inputs))),
+	})
+	cmd_inputs = append(cmd_inputs, fromPure)
+
+	return &Tx{
+		Tx:         txBase.Build(cmd_inputs, commands),
+		public_key: fromPubKey,
+	}, nil
+}
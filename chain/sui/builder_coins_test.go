@@ -0,0 +1,192 @@
+package sui_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/builder/buildertest"
+	. "github.com/cordialsys/crosschain/chain/sui"
+	"github.com/cordialsys/crosschain/chain/sui/generated/bcs"
+	testtypes "github.com/cordialsys/crosschain/testutil"
+	"github.com/cordialsys/go-sui-sdk/v2/types"
+	"github.com/stretchr/testify/require"
+)
+
+const coinsFrom = "0xbb8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de66b9"
+
+func newCoinsInput(gasCoin *types.Coin, coins ...*types.Coin) *TxInput {
+	return &TxInput{
+		TxInputEnvelope: *xc.NewTxInputEnvelope(xc.DriverSui),
+		GasBudget:       1_000_000,
+		GasPrice:        1_000,
+		GasCoin:         *gasCoin,
+		Coins:           coins,
+		CurrentEpoch:    20,
+	}
+}
+
+func programmableTx(tx xc.Tx) bcs.ProgrammableTransaction {
+	return tx.(*Tx).Tx.Value.Kind.(*bcs.TransactionKind__ProgrammableTransaction).Value
+}
+
+func TestMergeCoins(t *testing.T) {
+	require := require.New(t)
+	fromPk, _ := hex.DecodeString("6a03aadd27a3753c3af2d676591528f3d8209f337b9506163479bc5e61f67ebd")
+	txBuilder, err := NewTxBuilder(xc.NewChainConfig(xc.SUI).Base())
+	require.NoError(err)
+
+	gasCoin := suiCoin("0x8192d5c2b5722c60866761927d5a0737cd55d0c2b1150eabf818253795b38998", "HmMNQCsgudhDdXGe9X75WVyPbJnjFApq1EvFhaRzNB1n", 10_000_000_000, 1852477)
+	sui1 := suiCoin("0xc587db1fbe680b769c1a562a09f2c871a087bafa542c7cb73db6064e2b791bdf", "7Y2zjQxn2wj5jhrvS5NBKCFJDzWHZ4UMG7XJNNioNgTS", 1_000, 1852477)
+	sui2 := suiCoin("0x87bae5d7376e857106f7908eab6f7106ea3f7c2a1b3349f99925bb12631b1ff0", "9GeMg1yw4J9ck62XR3KHXi72kfVVeuqfAcK5rL3hRdVK", 2_000, 1852477)
+
+	// SUI is merged into the gas coin
+	tx, err := txBuilder.MergeCoins(coinsFrom, fromPk, newCoinsInput(gasCoin, sui1, sui2))
+	require.NoError(err)
+	ptx := programmableTx(tx)
+	require.Equal([]bcs.Command{
+		&bcs.Command__MergeCoins{
+			Field0: &bcs.Argument__GasCoin{},
+			Field1: []bcs.Argument{ArgumentInput(0), ArgumentInput(1)},
+		},
+	}, ptx.Commands)
+	require.Equal([]bcs.CallArg{
+		&bcs.CallArg__Object{Value: mustCoinToObject(sui1)},
+		&bcs.CallArg__Object{Value: mustCoinToObject(sui2)},
+	}, ptx.Inputs)
+
+	// tokens are merged into the first coin
+	usdc := "0xdba34672e30cb065b1f93e3ab55318768fd6fef66c15942c9f7cb846e2f900e7::usdc::USDC"
+	token1 := coinObject(usdc, "0x5c22194a002befba3d34d26036d4c440f86099ac4cb9b8aaeca22fb379229237", "3t7sWDqfyKvbGxtnS1GwMV2kgdasLgmDTRJ7MHhoyCz3", 5_000, 1852477)
+	token2 := coinObject(usdc, "0xa47d2121ef5ca77d83723d72a6b70c3bce15a2f438294f2d0fbcb530ab5d0b27", "EYMFpVaEcfdv8kv1hxZz8y884z2fhQJt8d3G1zKBYf6m", 1_000, 1852477)
+	input := newCoinsInput(gasCoin, token1, token2)
+	require.True(input.HasCoinsToMerge())
+	tx, err = txBuilder.MergeCoins(coinsFrom, fromPk, input)
+	require.NoError(err)
+	ptx = programmableTx(tx)
+	require.Equal([]bcs.Command{
+		&bcs.Command__MergeCoins{
+			Field0: ArgumentInput(0),
+			Field1: []bcs.Argument{ArgumentInput(1)},
+		},
+	}, ptx.Commands)
+
+	// a single token coin is already merged
+	input = newCoinsInput(gasCoin, token1)
+	require.False(input.HasCoinsToMerge())
+	_, err = txBuilder.MergeCoins(coinsFrom, fromPk, input)
+	require.ErrorContains(err, "no coins to merge")
+}
+
+func TestSplitCoins(t *testing.T) {
+	require := require.New(t)
+	fromPk, _ := hex.DecodeString("6a03aadd27a3753c3af2d676591528f3d8209f337b9506163479bc5e61f67ebd")
+	txBuilder, err := NewTxBuilder(xc.NewChainConfig(xc.SUI).Base())
+	require.NoError(err)
+	gasCoin := suiCoin("0x8192d5c2b5722c60866761927d5a0737cd55d0c2b1150eabf818253795b38998", "HmMNQCsgudhDdXGe9X75WVyPbJnjFApq1EvFhaRzNB1n", 10_000_000_000, 1852477)
+	amounts := []xc.AmountBlockchain{
+		xc.NewAmountBlockchainFromUint64(1_000_000_000),
+		xc.NewAmountBlockchainFromUint64(1_000_000_000),
+	}
+
+	// split directly off of the gas coin, sending the new coins back to the owner
+	tx, err := txBuilder.SplitCoins(coinsFrom, fromPk, amounts, newCoinsInput(gasCoin))
+	require.NoError(err)
+	ptx := programmableTx(tx)
+	require.Equal([]bcs.CallArg{
+		U64ToPure(1_000_000_000),
+		U64ToPure(1_000_000_000),
+		mustHexToPure(coinsFrom),
+	}, ptx.Inputs)
+	require.Equal([]bcs.Command{
+		&bcs.Command__SplitCoins{
+			Field0: &bcs.Argument__GasCoin{},
+			Field1: []bcs.Argument{ArgumentInput(0), ArgumentInput(1)},
+		},
+		&bcs.Command__TransferObjects{
+			Field0: []bcs.Argument{
+				&bcs.Argument__NestedResult{Field0: 0, Field1: 0},
+				&bcs.Argument__NestedResult{Field0: 0, Field1: 1},
+			},
+			Field1: ArgumentInput(2),
+		},
+	}, ptx.Commands)
+
+	// not enough funds
+	usdc := "0xdba34672e30cb065b1f93e3ab55318768fd6fef66c15942c9f7cb846e2f900e7::usdc::USDC"
+	token := coinObject(usdc, "0x5c22194a002befba3d34d26036d4c440f86099ac4cb9b8aaeca22fb379229237", "3t7sWDqfyKvbGxtnS1GwMV2kgdasLgmDTRJ7MHhoyCz3", 5_000, 1852477)
+	_, err = txBuilder.SplitCoins(coinsFrom, fromPk, amounts, newCoinsInput(gasCoin, token))
+	require.ErrorContains(err, "not enough funds to split")
+}
+
+func TestSponsorGasPayment(t *testing.T) {
+	require := require.New(t)
+	fromPk, _ := hex.DecodeString("6a03aadd27a3753c3af2d676591528f3d8209f337b9506163479bc5e61f67ebd")
+	sponsor := xc.Address("0x000a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de6000")
+	to := xc.Address("0xaa8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de6600")
+	chainCfg := xc.NewChainConfig(xc.SUI).Base()
+	txBuilder, err := NewTxBuilder(chainCfg)
+	require.NoError(err)
+
+	sponsorCoins := []*types.Coin{
+		suiCoin("0x000019f7751451412d090632bb1ca2c845a9c8f6cd8798d99d304571cfea1ca6", "ENUoMU2gFeLZEPxxQxdMwrNGtvJLFry2HvgXVCnCB1k9", 600_000, 1852470),
+		suiCoin("0x1111a9b7e3bf4bd3ecdb2d45daae92b2428a3488670e28a620ee7ee870f46b2d", "FUzqDFoq73G1WecQacFv2WmX83ezk16DGZkkzLTwCbvJ", 500_000, 1852470),
+		suiCoin("0x2222a9b7e3bf4bd3ecdb2d45daae92b2428a3488670e28a620ee7ee870f46b2d", "GreYy8apDQHR7zwsZLHZ6bfQAiQ12xE4TNcm4vznpNUM", 400_000, 1852470),
+	}
+	senderCoin := suiCoin("0xc587db1fbe680b769c1a562a09f2c871a087bafa542c7cb73db6064e2b791bdf", "7Y2zjQxn2wj5jhrvS5NBKCFJDzWHZ4UMG7XJNNioNgTS", 1_000_000_000, 1852477)
+
+	input := newCoinsInput(&types.Coin{}, senderCoin)
+	require.ErrorContains(input.SetGasPayment(sponsor, nil), "has no SUI coins")
+	require.NoError(input.SetGasPayment(sponsor, sponsorCoins))
+	// only the coins needed to cover the 1_000_000 budget are used
+	require.Equal(*sponsorCoins[0], input.GasCoin)
+	require.Equal(sponsorCoins[1:2], input.ExtraGasCoins)
+	require.EqualValues(1_100_000, input.GasBalance())
+
+	// the sender can send all of their SUI, as the sponsor pays for gas
+	args := buildertest.MustNewTransferArgs(
+		chainCfg, coinsFrom, to, xc.NewAmountBlockchainFromUint64(1_000_000_000),
+		buildertest.OptionFeePayer(sponsor, []byte{}),
+		buildertest.OptionPublicKey(fromPk),
+	)
+	tx, err := txBuilder.Transfer(args, input)
+	require.NoError(err)
+	gasData := tx.(*Tx).Tx.Value.GasData
+	require.EqualValues(1_000_000, gasData.Budget)
+	require.Len(gasData.Payment, 2)
+	sponsorData, _ := HexToAddress(string(sponsor))
+	require.Equal(sponsorData, gasData.Owner)
+	sighashes, err := tx.Sighashes()
+	require.NoError(err)
+	require.Len(sighashes, 2)
+}
+
+func TestFetchMergeCoinsInput(t *testing.T) {
+	require := require.New(t)
+	fromPk, _ := hex.DecodeString("6a03aadd27a3753c3af2d676591528f3d8209f337b9506163479bc5e61f67ebd")
+	server, close := testtypes.MockJSONRPC(t, []string{
+		// get coins
+		`{"data":[
+			{"coinType":"0x2::sui::SUI","coinObjectId":"0x1cdc19f7751451412d090632bb1ca2c845a9c8f6cd8798d99d304571cfea1ca6","version":"1852477","digest":"u6uSbWNMxkRkCqkjSTbsMeWMYB2VK7pbAo6vFoaMzSo","balance":"2001904720","previousTransaction":"AtPwJTvPfAd47yjBmJCGCJEB7E2XmoJ6aB23XX1o6c4M"},
+			{"coinType":"0x2::sui::SUI","coinObjectId":"0x418ca9b7e3bf4bd3ecdb2d45daae92b2428a3488670e28a620ee7ee870f46b2d","version":"1852477","digest":"SwXnkbcrycgr6unAXdcJQ5jfo9dMNMkztMWc3ZxNjL3","balance":"28969157920","previousTransaction":"AtPwJTvPfAd47yjBmJCGCJEB7E2XmoJ6aB23XX1o6c4M"},
+			{"coinType":"0x2::sui::SUI","coinObjectId":"0x87bae5d7376e857106f7908eab6f7106ea3f7c2a1b3349f99925bb12631b1ff0","version":"1852477","digest":"9GeMg1yw4J9ck62XR3KHXi72kfVVeuqfAcK5rL3hRdVK","balance":"1500000000","previousTransaction":"AtPwJTvPfAd47yjBmJCGCJEB7E2XmoJ6aB23XX1o6c4M"}
+		],"nextCursor":"0x87bae5d7376e857106f7908eab6f7106ea3f7c2a1b3349f99925bb12631b1ff0","hasNextPage":false}`,
+		// get checkpoint
+		`{"data":[{"epoch":"21","sequenceNumber":"2206686","digest":"HtsAAgd1ajMR8qMocnNF6XbAtiBHrxdauGhWtXqKouF3","networkTotalTransactions":"5164703","previousDigest":"H8oYvb73KoG7TWXpw4JPy2qZk7ddvHY3rYQ8kHcNmcua","timestampMs":"1683320609521","transactions":[],"checkpointCommitments":[],"validatorSignature":"i3aT5RVtIOvX0pEc/HU+xFTHbw2zV5SdT7q5n6GfS+e85CtkC8qqseeK2Hx9Nhia"}],"nextCursor":"2206686","hasNextPage":true}`,
+		// reference gas
+		"1000",
+		DryRunResponse(1000000, 2964000, 1956240),
+	})
+	defer close()
+	client, err := NewClient(xc.NewChainConfig(xc.SUI).WithNet("devnet").WithUrl(server.URL))
+	require.NoError(err)
+
+	input, err := client.FetchMergeCoinsInput(context.Background(), coinsFrom, fromPk, "")
+	require.NoError(err)
+	require.True(input.HasCoinsToMerge())
+	// the largest coin pays for gas, and the rest are merged into it
+	require.EqualValues(28969157920, input.GasCoin.Balance.Uint64())
+	require.Len(input.Coins, 2)
+	require.EqualValues(1000000+2964000-1956240, input.GasBudget)
+}
@@ -0,0 +1,78 @@
+package sui
+
+import (
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/sui/generated/bcs"
+)
+
+var _ xcbuilder.NftTransfer = &TxBuilder{}
+
+func ObjectToArg(object Object) (*bcs.ObjectArg__ImmOrOwnedObject, error) {
+	id, err := HexToObjectID(object.ObjectId)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode object id: %v", err)
+	}
+	digest, err := Base58ToObjectDigest(object.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode object digest: %v", err)
+	}
+	return &bcs.ObjectArg__ImmOrOwnedObject{
+		Field0: id,
+		Field1: bcs.SequenceNumber(object.Version),
+		Field2: digest,
+	}, nil
+}
+
+// NftTransfer sends an owned object to the recipient using `TransferObjects`
+func (txBuilder TxBuilder) NftTransfer(args xcbuilder.NftTransferArgs, input xc.NftTransferTxInput) (xc.Tx, error) {
+	nftInput, ok := input.(*NftTransferInput)
+	if !ok {
+		return &Tx{}, errors.New("xc.NftTransferTxInput is not from a sui chain")
+	}
+	fromPubKey, ok := args.GetPublicKey()
+	if !ok {
+		return &Tx{}, errors.New("must set public key on TxInput for SUI")
+	}
+	if NormalizeObjectId(args.GetTokenId()) != NormalizeObjectId(nftInput.Object.ObjectId) {
+		return &Tx{}, fmt.Errorf("input object %s does not match token id %s", nftInput.Object.ObjectId, args.GetTokenId())
+	}
+	from := args.GetFrom()
+
+	txBase, err := txBuilder.newTransactionBase(
+		from,
+		from,
+		xc.NewAmountBlockchainFromUint64(0),
+		&nftInput.TxInput,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transfer base: %w", err)
+	}
+
+	objectArg, err := ObjectToArg(nftInput.Object)
+	if err != nil {
+		return nil, err
+	}
+	toPure, err := HexToPure(string(args.GetTo()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode 'to': %w", err)
+	}
+	cmd_inputs := []bcs.CallArg{
+		&bcs.CallArg__Object{Value: objectArg},
+		toPure,
+	}
+	commands := []bcs.Command{
+		&bcs.Command__TransferObjects{
+			Field0: []bcs.Argument{ArgumentInput(0)},
+			Field1: ArgumentInput(1),
+		},
+	}
+
+	return &Tx{
+		Tx:         txBase.Build(cmd_inputs, commands),
+		public_key: fromPubKey,
+	}, nil
+}
@@ -80,3 +80,49 @@ func (s *CrosschainTestSuite) TestFeePayerSpend() {
 	_, err = txBuilder.Transfer(args, input)
 	require.ErrorContains(err, "no coins to spend")
 }
+
+func (s *CrosschainTestSuite) TestNftTransfer() {
+	require := s.Require()
+
+	from := "0xbb8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de66b9"
+	to := "0xaa8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de6600"
+	objectId := "0x5d3e2e4bb6b7b7a4a1f4c1c3c0e1a8e3f8f3a0c1c3c0e1a8e3f8f3a0c1c3c0e1"
+	from_pk, _ := hex.DecodeString("6a03aadd27a3753c3af2d676591528f3d8209f337b9506163479bc5e61f67ebd")
+	chainCfg := xc.NewChainConfig(xc.SUI).WithDriver(xc.DriverSui).Base()
+	txBuilder, err := sui.NewTxBuilder(chainCfg)
+	require.NoError(err)
+
+	gasCoin := *suiCoin("0x8192d5c2b5722c60866761927d5a0737cd55d0c2b1150eabf818253795b38998", "HmMNQCsgudhDdXGe9X75WVyPbJnjFApq1EvFhaRzNB1n", 10_000_000_000, 1852477)
+	input := &sui.NftTransferInput{
+		TxInput: sui.TxInput{
+			TxInputEnvelope: *xc.NewTxInputEnvelope(xc.DriverSui),
+			GasBudget:       100,
+			GasPrice:        100,
+			GasCoin:         gasCoin,
+			CurrentEpoch:    20,
+		},
+		Object: sui.Object{
+			ObjectId: objectId,
+			Version:  1852480,
+			Digest:   "HmMNQCsgudhDdXGe9X75WVyPbJnjFApq1EvFhaRzNB1n",
+			Type:     "0xabc::nft::Nft",
+		},
+	}
+
+	args, err := builder.NewNftTransferArgs(chainCfg, "", xc.Address(from), xc.Address(to), "", []builder.NftToken{builder.NewNftToken(objectId)}, builder.OptionPublicKey(from_pk))
+	require.NoError(err)
+	require.Equal(builder.NftStandardSuiObject, args.GetStandard())
+
+	tx, err := txBuilder.NftTransfer(args, input)
+	require.NoError(err)
+	require.NotEmpty(tx.Hash())
+	sighashes, err := tx.Sighashes()
+	require.NoError(err)
+	require.Len(sighashes, 1)
+
+	// the input object must match the token being sent
+	args, err = builder.NewNftTransferArgs(chainCfg, "", xc.Address(from), xc.Address(to), "", []builder.NftToken{builder.NewNftToken(to)}, builder.OptionPublicKey(from_pk))
+	require.NoError(err)
+	_, err = txBuilder.NftTransfer(args, input)
+	require.ErrorContains(err, "does not match")
+}
@@ -99,6 +99,10 @@ var (
 	getCheckpoint  SuiMethod = "sui_getCheckpoint"
 	getCheckpoints SuiMethod = "sui_getCheckpoints"
 	MaxCoinObjects int       = 50
+	// Coins merged per transaction when consolidating
+	MaxMergeCoinObjects int = 500
+	// Protocol limit on the number of gas objects
+	MaxGasPaymentObjects int = 256
 )
 
 func (m SuiMethod) String() string {
@@ -267,6 +271,10 @@ func (c *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (txinf
 		}
 	}
 
+	nftSources, nftDestinations := ParseObjectTransfers(resp.ObjectChanges, xc.NativeAsset(c.Asset.GetChain().Chain))
+	sources = append(sources, nftSources...)
+	destinations = append(destinations, nftDestinations...)
+
 	// fee is difference between total sent and received in balance changes
 	fee := totalSuiSent.Sub(&totalSuiReceived)
 	logrus.WithFields(logrus.Fields{
@@ -350,6 +358,10 @@ func (c *Client) GetAllCoinsFor(ctx context.Context, address xc.Address, contrac
 }
 
 func (c *Client) fetchBaseInput(ctx context.Context, contract string, from xc.Address, feePayer xc.Address) (*TxInput, error) {
+	return c.fetchBaseInputWithLimit(ctx, contract, from, feePayer, MaxCoinObjects)
+}
+
+func (c *Client) fetchBaseInputWithLimit(ctx context.Context, contract string, from xc.Address, feePayer xc.Address, maxCoins int) (*TxInput, error) {
 	native := c.Asset.GetChain().ChainCoin
 	if native == "" {
 		native = NativeCoin
@@ -371,19 +383,12 @@ func (c *Client) fetchBaseInput(ctx context.Context, contract string, from xc.Ad
 		input.GasCoinOwner = from
 	}
 
+	var sponsorSuiCoins []*types.Coin
 	if feePayer != "" {
 		input.GasCoin = types.Coin{}
-		sponsorSuiCoins, err := c.GetAllCoinsFor(ctx, feePayer, native)
+		sponsorSuiCoins, err = c.FetchGasPayment(ctx, feePayer)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get fee payer coins: %w", err)
-		}
-		// use gas coin from sponsor if available
-		SortCoins(sponsorSuiCoins)
-		if len(sponsorSuiCoins) > 0 {
-			input.GasCoin = *sponsorSuiCoins[0]
-			input.GasCoinOwner = feePayer
-		} else {
-			return nil, fmt.Errorf("SUI fee payer %s has no SUI coins", feePayer)
+			return nil, err
 		}
 	}
 
@@ -407,9 +412,9 @@ func (c *Client) fetchBaseInput(ctx context.Context, contract string, from xc.Ad
 	// store the object id's for the transfer
 	input.Coins = transferCoins
 	input.SortCoins()
-	// take max 50 to bound the tx_input size.
-	if len(input.Coins) > MaxCoinObjects {
-		input.Coins = input.Coins[:MaxCoinObjects]
+	// bound the tx_input size.
+	if len(input.Coins) > maxCoins {
+		input.Coins = input.Coins[:maxCoins]
 	}
 
 	gasPrice, err := c.EstimateGas(ctx)
@@ -428,10 +433,35 @@ func (c *Client) fetchBaseInput(ctx context.Context, contract string, from xc.Ad
 	// Incrementally increase budget per additional coin being consumed
 	input.GasBudget = input.GasBudget + GAS_BUDGET_PER_COIN*uint64(len(input.Coins))
 
+	if feePayer != "" {
+		// the sponsor provides the gas objects
+		if err := input.SetGasPayment(feePayer, sponsorSuiCoins); err != nil {
+			return nil, err
+		}
+	}
+
 	input.ExcludeGasCoin()
 	return input, nil
 }
 
+// FetchGasPayment returns the SUI coins that an account can pay for gas with, largest first.
+// Sponsors may call this separately from the sender's input and attach the coins with `TxInput.SetGasPayment`.
+func (c *Client) FetchGasPayment(ctx context.Context, owner xc.Address) ([]*types.Coin, error) {
+	native := c.Asset.GetChain().ChainCoin
+	if native == "" {
+		native = NativeCoin
+	}
+	coins, err := c.GetAllCoinsFor(ctx, owner, native)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee payer coins: %w", err)
+	}
+	SortCoins(coins)
+	if len(coins) > MaxGasPaymentObjects {
+		coins = coins[:MaxGasPaymentObjects]
+	}
+	return coins, nil
+}
+
 func (c *Client) simulateTransactionGasFee(ctx context.Context, transaction xc.Tx, isNative bool) (uint64, bool, error) {
 	serialized, err := transaction.Serialize()
 	if err != nil {
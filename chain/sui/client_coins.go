@@ -0,0 +1,66 @@
+package sui
+
+import (
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/sirupsen/logrus"
+)
+
+// FetchMergeCoinsInput returns the input to merge up to `MaxMergeCoinObjects` coins of the contract.
+// Addresses with more coins need to merge in multiple transactions, largest coins first.
+func (c *Client) FetchMergeCoinsInput(ctx context.Context, from xc.Address, fromPubKey []byte, contract xc.ContractAddress) (*TxInput, error) {
+	input, err := c.fetchBaseInputWithLimit(ctx, string(contract), from, "", MaxMergeCoinObjects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch base input: %w", err)
+	}
+	if !input.HasCoinsToMerge() {
+		return input, nil
+	}
+	builder, err := NewTxBuilder(c.Asset.GetChain().Base())
+	if err != nil {
+		return nil, fmt.Errorf("could not create tx builder: %w", err)
+	}
+	err = c.simulateCoinManagement(ctx, input, func() (xc.Tx, error) {
+		return builder.MergeCoins(from, fromPubKey, input)
+	})
+	return input, err
+}
+
+// FetchSplitCoinsInput returns the input to split coins of the given amounts off of the contract's coins
+func (c *Client) FetchSplitCoinsInput(ctx context.Context, from xc.Address, fromPubKey []byte, contract xc.ContractAddress, amounts []xc.AmountBlockchain) (*TxInput, error) {
+	input, err := c.fetchBaseInput(ctx, string(contract), from, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch base input: %w", err)
+	}
+	builder, err := NewTxBuilder(c.Asset.GetChain().Base())
+	if err != nil {
+		return nil, fmt.Errorf("could not create tx builder: %w", err)
+	}
+	err = c.simulateCoinManagement(ctx, input, func() (xc.Tx, error) {
+		return builder.SplitCoins(from, fromPubKey, amounts, input)
+	})
+	return input, err
+}
+
+// Simulate the tx built from the input to set an accurate gas budget
+func (c *Client) simulateCoinManagement(ctx context.Context, input *TxInput, buildTx func() (xc.Tx, error)) error {
+	if len(input.GasCoin.Digest) == 0 {
+		return fmt.Errorf("the address has no SUI to pay for gas")
+	}
+	tx, err := buildTx()
+	if err != nil {
+		return fmt.Errorf("could not build tx: %w", err)
+	}
+	gasFee, ok, err := c.simulateTransactionGasFee(ctx, tx, true)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction gas fee: %w", err)
+	}
+	if ok {
+		input.GasBudget = gasFee
+	} else {
+		logrus.Warn("could not simulate, using the default gas budget")
+	}
+	return nil
+}
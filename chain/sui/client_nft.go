@@ -0,0 +1,165 @@
+package sui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	xclient "github.com/cordialsys/crosschain/client"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	"github.com/cordialsys/go-sui-sdk/v2/lib"
+	"github.com/cordialsys/go-sui-sdk/v2/sui_types"
+	"github.com/cordialsys/go-sui-sdk/v2/types"
+	"github.com/sirupsen/logrus"
+)
+
+var _ xclient.NftClient = &Client{}
+
+func (c *Client) FetchNftTransferInput(ctx context.Context, args xcbuilder.NftTransferArgs) (xc.NftTransferTxInput, error) {
+	txInput, err := c.fetchBaseInput(ctx, NativeCoin, args.GetFrom(), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch base input: %w", err)
+	}
+	// only the gas coin is needed
+	txInput.Coins = nil
+
+	objectId, err := HexToAddress(args.GetTokenId())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode object id: %w", err)
+	}
+	objectDetails, err := c.SuiClient.GetObject(ctx, sui_types.SuiAddress(objectId), &types.SuiObjectDataOptions{
+		ShowType:  true,
+		ShowOwner: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object details: %w", err)
+	}
+	if objectDetails == nil || objectDetails.Data == nil {
+		return nil, fmt.Errorf("object %s does not exist", args.GetTokenId())
+	}
+	if objectDetails.Data.Owner != nil {
+		owner, ok := objectOwnerAddress(objectDetails.Data.Owner)
+		if !ok || NormalizeObjectId(owner) != NormalizeObjectId(string(args.GetFrom())) {
+			return nil, fmt.Errorf("object %s is not owned by %s", args.GetTokenId(), args.GetFrom())
+		}
+	}
+
+	nftInput := &NftTransferInput{
+		TxInput: *txInput,
+		Object: Object{
+			ObjectId: objectDetails.Data.ObjectId.String(),
+			Version:  objectDetails.Data.Version.Uint64(),
+			Digest:   objectDetails.Data.Digest.String(),
+		},
+	}
+	if objectDetails.Data.Type != nil {
+		nftInput.Object.Type = *objectDetails.Data.Type
+	}
+
+	if _, ok := args.GetPublicKey(); !ok {
+		return nil, errors.New("must set public key on TxInput for SUI")
+	}
+	builder, err := NewTxBuilder(c.Asset.GetChain().Base())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tx builder: %w", err)
+	}
+	tx, err := builder.NftTransfer(args, nftInput)
+	if err != nil {
+		return nil, fmt.Errorf("could not build tx: %v", err)
+	}
+
+	if len(nftInput.GasCoin.Digest) == 0 {
+		logrus.Warn("skipping simulation as the address has no SUI balance")
+	} else {
+		gasFee, ok, err := c.simulateTransactionGasFee(ctx, tx, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nft transfer gas fee: %w", err)
+		}
+		if ok {
+			nftInput.GasBudget = gasFee
+		}
+	}
+
+	return nftInput, nil
+}
+
+// Only objects held directly by an address count as transferred; objects owned by
+// other objects (e.g. dynamic fields), shared objects and immutable objects are not.
+func objectOwnerAddress(owner *types.ObjectOwner) (string, bool) {
+	if owner == nil || owner.ObjectOwnerInternal == nil || owner.AddressOwner == nil {
+		return "", false
+	}
+	return owner.AddressOwner.String(), true
+}
+
+// Normalize the package address of a move type, so "0x0...02::coin::Coin<T>" becomes "0x2::coin::Coin<T>"
+func normalizeMoveType(objectType string) string {
+	parts := strings.SplitN(objectType, "::", 2)
+	if len(parts) != 2 {
+		return objectType
+	}
+	return NormalizeObjectId(parts[0]) + "::" + parts[1]
+}
+
+// Coins and dynamic fields are not NFTs
+func isNftObjectType(objectType string) bool {
+	objectType = normalizeMoveType(objectType)
+	if strings.HasPrefix(objectType, "0x2::coin::Coin<") {
+		return false
+	}
+	if strings.HasPrefix(objectType, "0x2::dynamic_field::Field<") {
+		return false
+	}
+	return true
+}
+
+// Objects that move to a new address owner, other than coins, are reported as NFT movements.
+// A mutated object is only considered moved if it now belongs to an address other than the sender.
+func ParseObjectTransfers(changes []lib.TagJson[types.ObjectChange], nativeAsset xc.NativeAsset) (sources []*txinfo.LegacyTxInfoEndpoint, destinations []*txinfo.LegacyTxInfoEndpoint) {
+	for _, change := range changes {
+		var sender string
+		var recipient string
+		var objectType string
+		var objectId string
+		var ok bool
+		if transferred := change.Data.Transferred; transferred != nil {
+			sender = transferred.Sender.String()
+			recipient, ok = objectOwnerAddress(&transferred.Recipient)
+			objectType = transferred.ObjectType
+			objectId = transferred.ObjectId.String()
+		} else if mutated := change.Data.Mutated; mutated != nil {
+			sender = mutated.Sender.String()
+			recipient, ok = objectOwnerAddress(&mutated.Owner)
+			objectType = mutated.ObjectType
+			objectId = mutated.ObjectId.String()
+		}
+		if !ok || NormalizeObjectId(sender) == NormalizeObjectId(recipient) {
+			continue
+		}
+		if !isNftObjectType(objectType) {
+			continue
+		}
+		// the object id is unique within the transaction
+		event := txinfo.NewEvent(objectId, txinfo.MovementVariantNft)
+		sources = append(sources, &txinfo.LegacyTxInfoEndpoint{
+			Address:         xc.Address(sender),
+			ContractAddress: xc.ContractAddress(objectType),
+			TokenId:         objectId,
+			Amount:          xc.NewAmountBlockchainFromUint64(1),
+			NativeAsset:     nativeAsset,
+			Event:           event,
+		})
+		destinations = append(destinations, &txinfo.LegacyTxInfoEndpoint{
+			Address:         xc.Address(recipient),
+			ContractAddress: xc.ContractAddress(objectType),
+			TokenId:         objectId,
+			Amount:          xc.NewAmountBlockchainFromUint64(1),
+			NativeAsset:     nativeAsset,
+			Event:           event,
+		})
+	}
+	return sources, destinations
+}
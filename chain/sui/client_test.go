@@ -3,6 +3,7 @@ package sui_test
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
@@ -1019,3 +1020,69 @@ func TestSubmit(t *testing.T) {
 		})
 	}
 }
+
+func TestParseObjectTransfers(t *testing.T) {
+	sender := "0x7d20dcdb2bca4f508ea9613994683eb4e76e9c4ed371169677c1be02aaf0b58e"
+	sponsor := "0xbb8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de66b9"
+	recipient := "0xfe33ab3ab64a92088402fc22d850f04f0770d899695104447ffd93d7b83cfeb8"
+	nftType := "0x3821e4ae13d37a1c55a03a86eab613450c1302e6b4df461e1c79bdf8381dde47::collection::Nft"
+	nftId := "0xe6dd381983b77040780e98f9b0a9b12ed3dc8223d1f1dda607120fd007d3ce6b"
+
+	vectors := []struct {
+		name         string
+		changes      string
+		sources      []string
+		destinations []string
+	}{
+		{
+			// request_add_stake mutates the shared system state and its dynamic field (owned by 0x5)
+			name: "staking",
+			changes: `[
+				{"type":"mutated","sender":"` + sender + `","owner":{"AddressOwner":"` + sender + `"},"objectType":"0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI>","objectId":"0xbddb28b55556649dd58e27b39ea80c57295b869a770cb0c04e8ab30cb3a358d8","version":"22996","previousVersion":"22995","digest":"9ATwa4EctZHbK2RSEqsrsM6pohyCBR62DDwoFuDUUhVU"},
+				{"type":"mutated","sender":"` + sender + `","owner":{"Shared":{"initial_shared_version":1}},"objectType":"0x3::sui_system::SuiSystemState","objectId":"0x0000000000000000000000000000000000000000000000000000000000000005","version":"22996","previousVersion":"22995","digest":"De3ysFkPDxrzVMW46Jzike9hJ8xgAsy4ZCcctcLuDe9A"},
+				{"type":"mutated","sender":"` + sender + `","owner":{"ObjectOwner":"0x0000000000000000000000000000000000000000000000000000000000000005"},"objectType":"0x0000000000000000000000000000000000000000000000000000000000000002::dynamic_field::Field<u64, 0x3::sui_system_state_inner::SuiSystemStateInnerV2>","objectId":"0x5b890eaf2abcfa2ab90b77b8e6f3d5d8609586c3e583baf3dccd5af17edf48d1","version":"22996","previousVersion":"22995","digest":"537fcd4aKnd4cEDzV9fwvihH91x1F7BxHYNHLDZhhvyJ"},
+				{"type":"created","sender":"` + sender + `","owner":{"AddressOwner":"` + sender + `"},"objectType":"0x3::staking_pool::StakedSui","objectId":"` + nftId + `","version":"22996","digest":"ALf7a4D7bpJCvhL4pW2dtk5ZbyjphQcz49nTY9ZP4tCG"}
+			]`,
+		},
+		{
+			// the sponsor's gas coin is mutated but remains with the sponsor
+			name: "sponsored_nft_transfer",
+			changes: `[
+				{"type":"mutated","sender":"` + sender + `","owner":{"AddressOwner":"` + sponsor + `"},"objectType":"0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI>","objectId":"0x3150377d1db0395abfd3b19cfeca94eaf5987a12b95a0aab431195e77399f092","version":"1852498","previousVersion":"22585","digest":"De3ysFkPDxrzVMW46Jzike9hJ8xgAsy4ZCcctcLuDe9A"},
+				{"type":"mutated","sender":"` + sender + `","owner":{"AddressOwner":"` + recipient + `"},"objectType":"` + nftType + `","objectId":"` + nftId + `","version":"1852498","previousVersion":"23425","digest":"537fcd4aKnd4cEDzV9fwvihH91x1F7BxHYNHLDZhhvyJ"}
+			]`,
+			sources:      []string{sender},
+			destinations: []string{recipient},
+		},
+		{
+			name: "transferred",
+			changes: `[
+				{"type":"transferred","sender":"` + sender + `","recipient":{"AddressOwner":"` + recipient + `"},"objectType":"` + nftType + `","objectId":"` + nftId + `","version":"22996","digest":"ALf7a4D7bpJCvhL4pW2dtk5ZbyjphQcz49nTY9ZP4tCG"}
+			]`,
+			sources:      []string{sender},
+			destinations: []string{recipient},
+		},
+	}
+
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			require := require.New(t)
+			var changes []lib.TagJson[types.ObjectChange]
+			require.NoError(json.Unmarshal([]byte(v.changes), &changes))
+
+			sources, destinations := ParseObjectTransfers(changes, xc.SUI)
+			require.Len(sources, len(v.sources))
+			require.Len(destinations, len(v.destinations))
+			for i, address := range v.sources {
+				require.EqualValues(address, sources[i].Address)
+				require.EqualValues(nftType, sources[i].ContractAddress)
+				require.Equal(nftId, sources[i].TokenId)
+				require.Equal(txinfo.MovementVariantNft, sources[i].Event.Variant)
+			}
+			for i, address := range v.destinations {
+				require.EqualValues(address, destinations[i].Address)
+				require.Equal(nftId, destinations[i].TokenId)
+			}
+		})
+	}
+}
@@ -171,3 +171,25 @@ func (*UnstakingInput) Unstaking() {}
 func (*UnstakingInput) GetVariant() xc.TxVariantInputType {
 	return xc.NewUnstakingInputType(xc.DriverSui, string(xc.Native))
 }
+
+// A Sui object owned by the sender, such as an NFT
+type Object struct {
+	ObjectId string `json:"object_id"`
+	Version  uint64 `json:"version"`
+	Digest   string `json:"digest"`
+	Type     string `json:"type,omitempty"`
+}
+
+type NftTransferInput struct {
+	TxInput
+	// The object being transferred
+	Object Object `json:"object"`
+}
+
+var _ xc.TxVariantInput = &NftTransferInput{}
+var _ xc.NftTransferTxInput = &NftTransferInput{}
+
+func (*NftTransferInput) NftTransferring() {}
+func (*NftTransferInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewNftTransferInputType(xc.DriverSui, "object")
+}
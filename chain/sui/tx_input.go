@@ -2,6 +2,7 @@ package sui
 
 import (
 	"bytes"
+	"fmt"
 	"sort"
 
 	xc "github.com/cordialsys/crosschain"
@@ -18,6 +19,8 @@ type TxInput struct {
 	// Native Sui object that we can use to pay gas with
 	GasCoin      types.Coin `json:"gas_coin,omitempty"`
 	GasCoinOwner xc.Address `json:"gas_coin_owner,omitempty"`
+	// Additional SUI objects of the gas coin owner, for when a single gas coin cannot cover the budget
+	ExtraGasCoins []*types.Coin `json:"extra_gas_coins,omitempty"`
 	// All objects (native or token)
 	Coins []*types.Coin `json:"coins,omitempty"`
 	// current epoch
@@ -27,6 +30,7 @@ type TxInput struct {
 type CoinGetter interface {
 	GetCoins() []*types.Coin
 	GetGasCoin() *types.Coin
+	GetExtraGasCoins() []*types.Coin
 	GetCurrentEpoch() uint64
 }
 
@@ -37,6 +41,7 @@ func init() {
 	registry.RegisterTxBaseInput(&TxInput{})
 	registry.RegisterTxVariantInput(&StakingInput{})
 	registry.RegisterTxVariantInput(&UnstakingInput{})
+	registry.RegisterTxVariantInput(&NftTransferInput{})
 }
 
 func (input *TxInput) GetCoins() []*types.Coin {
@@ -46,6 +51,10 @@ func (input *TxInput) GetGasCoin() *types.Coin {
 	return &input.GasCoin
 }
 
+func (input *TxInput) GetExtraGasCoins() []*types.Coin {
+	return input.ExtraGasCoins
+}
+
 func (input *TxInput) GetCurrentEpoch() uint64 {
 	return input.CurrentEpoch
 }
@@ -96,6 +105,21 @@ func (input *TxInput) coinsDisjoint(other CoinGetter) (disjoint bool) {
 	if CoinEqual(&input.GasCoin, other.GetGasCoin()) {
 		return false
 	}
+	otherGasCoins := append([]*types.Coin{other.GetGasCoin()}, other.GetExtraGasCoins()...)
+	for _, gasCoin := range input.ExtraGasCoins {
+		for _, coinOther := range append(otherGasCoins, other.GetCoins()...) {
+			if CoinEqual(gasCoin, coinOther) {
+				return false
+			}
+		}
+	}
+	for _, gasCoin := range other.GetExtraGasCoins() {
+		for _, coinInput := range input.Coins {
+			if CoinEqual(gasCoin, coinInput) {
+				return false
+			}
+		}
+	}
 
 	for _, coinOther := range other.GetCoins() {
 		for _, coinInput := range input.Coins {
@@ -148,6 +172,33 @@ func (input *TxInput) ExcludeGasCoin() {
 	}
 }
 
+// Set the coins of a sponsor to pay for gas with, using as few coins as needed to cover the budget.
+// The coins should be sorted from highest to lowest.
+func (input *TxInput) SetGasPayment(owner xc.Address, coins []*types.Coin) error {
+	if len(coins) == 0 {
+		return fmt.Errorf("SUI fee payer %s has no SUI coins", owner)
+	}
+	input.GasCoin = *coins[0]
+	input.GasCoinOwner = owner
+	input.ExtraGasCoins = nil
+	for _, coin := range coins[1:] {
+		if input.GasBalance() >= input.GasBudget || len(input.ExtraGasCoins)+1 >= MaxGasPaymentObjects {
+			break
+		}
+		input.ExtraGasCoins = append(input.ExtraGasCoins, coin)
+	}
+	return nil
+}
+
+// Total balance of all objects used to pay for gas
+func (input *TxInput) GasBalance() uint64 {
+	balance := input.GasCoin.Balance.Uint64()
+	for _, coin := range input.ExtraGasCoins {
+		balance += coin.Balance.Uint64()
+	}
+	return balance
+}
+
 func (input *TxInput) TotalBalance() xc.AmountBlockchain {
 	amount := xc.NewAmountBlockchainFromUint64(0)
 	coinType := ""
@@ -169,6 +220,14 @@ func (input *TxInput) SortCoins() {
 	SortCoins(input.Coins)
 }
 
+// Native coins are merged into the gas coin, other coins are merged into the first coin
+func (input *TxInput) HasCoinsToMerge() bool {
+	if input.IsNativeTransfer() {
+		return len(input.Coins) > 0
+	}
+	return len(input.Coins) > 1
+}
+
 func (input *TxInput) IsNativeTransfer() bool {
 	if len(input.Coins) > 0 && input.Coins[0].CoinType != input.GasCoin.CoinType {
 		return false
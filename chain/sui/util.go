@@ -137,3 +137,9 @@ func NormalizeCoinContract(contract string) string {
 	}
 	return contract
 }
+
+// Object id's may be formatted with or without leading zeros
+func NormalizeObjectId(objectId string) string {
+	objectId = strings.TrimPrefix(strings.ToLower(objectId), "0x")
+	return "0x" + strings.TrimLeft(objectId, "0")
+}
@@ -1,11 +1,15 @@
 package address
 
 import (
+	"bytes"
+	"crypto/ed25519"
 	"fmt"
 	"strings"
 
 	xc "github.com/cordialsys/crosschain"
+	xcaddress "github.com/cordialsys/crosschain/address"
 	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
 	"github.com/xssnick/tonutils-go/ton/wallet"
 	"github.com/xssnick/tonutils-go/tvm/cell"
 )
@@ -16,21 +20,58 @@ const DefaultSubwalletId = 698983191
 // Most stable TON wallet version
 const DefaultWalletVersion = wallet.V3
 
+const (
+	AddressFormatV3 = "v3" // standard v3 wallet, the default
+	// Highload v3 wallet, which can send hundreds of messages at once and uses
+	// query id's instead of a sequence for replay protection.
+	AddressFormatHighloadV3 = "highload-v3"
+)
+
+// Subwallet conventionally used for highload v3 wallets
+const HighloadSubwalletId = 0x10ad
+
+// How long a signed highload v3 message is valid for, in seconds
+const HighloadMessageTtl = 60 * 60
+
 // AddressBuilder for Template
 type AddressBuilder struct {
-	Asset *xc.ChainBaseConfig
+	Asset  *xc.ChainBaseConfig
+	Format xc.AddressFormat
 }
 
 var _ xc.AddressBuilder = AddressBuilder{}
+var _ xc.AddressBuilderWithFormats = AddressBuilder{}
+
+func InvalidAddressf(got xc.AddressFormat) error {
+	return fmt.Errorf(
+		"invalid address format, expected one of: [%s, %s], got: %s",
+		AddressFormatV3, AddressFormatHighloadV3, got,
+	)
+}
 
 // NewAddressBuilder creates a new Template AddressBuilder
-func NewAddressBuilder(cfgI *xc.ChainBaseConfig) (xc.AddressBuilder, error) {
-	return AddressBuilder{cfgI}, nil
+func NewAddressBuilder(cfgI *xc.ChainBaseConfig, options ...xcaddress.AddressOption) (xc.AddressBuilder, error) {
+	opts, err := xcaddress.NewAddressOptions(options...)
+	if err != nil {
+		return AddressBuilder{}, err
+	}
+	format, ok := opts.GetFormat()
+	if !ok {
+		format = AddressFormatV3
+	}
+	if format != AddressFormatV3 && format != AddressFormatHighloadV3 {
+		return nil, InvalidAddressf(format)
+	}
+	return AddressBuilder{cfgI, format}, nil
+}
+
+func (ab AddressBuilder) GetSignatureAlgorithm() xc.SignatureType {
+	return xc.Ed255
 }
 
 // GetAddressFromPublicKey returns an Address given a public key
 func (ab AddressBuilder) GetAddressFromPublicKey(publicKeyBytes []byte) (xc.Address, error) {
-	addr, err := wallet.AddressFromPubKey(publicKeyBytes, DefaultWalletVersion, DefaultSubwalletId)
+	addr, err := AddressFromPublicKey(publicKeyBytes, ab.Format)
 	if err != nil {
 		return "", err
 	}
@@ -40,6 +81,50 @@ func (ab AddressBuilder) GetAddressFromPublicKey(publicKeyBytes []byte) (xc.Addr
 	return xc.Address(addr.String()), nil
 }
 
+// WalletVersion returns the wallet version and subwallet for an address format
+func WalletVersion(format xc.AddressFormat) (wallet.VersionConfig, uint32, error) {
+	switch format {
+	case "", AddressFormatV3:
+		return DefaultWalletVersion, DefaultSubwalletId, nil
+	case AddressFormatHighloadV3:
+		return wallet.ConfigHighloadV3{MessageTTL: HighloadMessageTtl}, HighloadSubwalletId, nil
+	default:
+		return nil, 0, InvalidAddressf(format)
+	}
+}
+
+// AddressFromPublicKey calculates the wallet address of the given format
+func AddressFromPublicKey(publicKeyBytes []byte, format xc.AddressFormat) (*address.Address, error) {
+	version, subwallet, err := WalletVersion(format)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.AddressFromPubKey(publicKeyBytes, version, subwallet)
+}
+
+// GetStateInit returns the state needed to deploy a wallet of the given format on first use
+func GetStateInit(publicKeyBytes []byte, format xc.AddressFormat) (*tlb.StateInit, error) {
+	version, subwallet, err := WalletVersion(format)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.GetStateInit(ed25519.PublicKey(publicKeyBytes), version, subwallet)
+}
+
+// IsHighloadV3Code checks if the code (boc) of a deployed contract is the highload v3 wallet
+func IsHighloadV3Code(code []byte) bool {
+	codeCell, err := cell.FromBOC(code)
+	if err != nil {
+		return false
+	}
+	// the code does not depend on the public key
+	stateInit, err := GetStateInit(make([]byte, ed25519.PublicKeySize), AddressFormatHighloadV3)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(codeCell.Hash(), stateInit.Code.Hash())
+}
+
 func ParseAddress(addr xc.Address, net string) (*address.Address, error) {
 	addrS := string(addr)
 	if len(strings.Split(addrS, ":")) == 2 {
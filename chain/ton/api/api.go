@@ -121,6 +121,7 @@ var GetPublicKeyMethod GetMethod = "get_public_key"
 var GetSequenceMethod GetMethod = "seqno"
 var GetWalletAddressMethod GetMethod = "get_wallet_address"
 var GetJettonDataMethod GetMethod = "get_jetton_data"
+var GetProcessedMethod GetMethod = "processed?"
 
 type GetMethodRequest struct {
 	Address string      `json:"address"`
@@ -1,7 +1,6 @@
 package ton
 
 import (
-	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"math/big"
@@ -28,6 +27,7 @@ type TxBuilder struct {
 }
 
 var _ xcbuilder.FullTransferBuilder = &TxBuilder{}
+var _ xcbuilder.MultiTransfer = &TxBuilder{}
 
 // NewTxBuilder creates a new Template TxBuilder
 func NewTxBuilder(cfgI *xc.ChainBaseConfig) (TxBuilder, error) {
@@ -36,6 +36,15 @@ func NewTxBuilder(cfgI *xc.ChainBaseConfig) (TxBuilder, error) {
 	}, nil
 }
 
+// JettonBurnArgs burns jettons held by the sender, e.g. to redeem them with the issuer.
+type JettonBurnArgs struct {
+	From xc.Address
+	// Needed only when the sender's wallet is not yet deployed
+	PublicKey []byte
+	Contract  xc.ContractAddress
+	Amount    xc.AmountBlockchain
+}
+
 // NewTransfer creates a new transfer for an Asset, either native or token
 func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
 	from := args.GetFrom()
@@ -43,19 +52,6 @@ func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInpu
 	amount := args.GetAmount()
 
 	txInput := input.(*TxInput)
-
-	var stateInit *tlb.StateInit
-	var err error
-	if txInput.AccountStatus != api.Active {
-		fromPubKey, ok := args.GetPublicKey()
-		if !ok || len(fromPubKey) == 0 {
-			return nil, fmt.Errorf("must set from-public-key in transfer args for new ton account: %s", from)
-		}
-		stateInit, err = wallet.GetStateInit(ed25519.PublicKey(fromPubKey), tonaddress.DefaultWalletVersion, tonaddress.DefaultSubwalletId)
-		if err != nil {
-			return nil, err
-		}
-	}
 	net := txBuilder.Asset.Network
 
 	toAddr, err := tonaddress.ParseAddress(to, net)
@@ -76,59 +72,154 @@ func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInpu
 	msgs := []*wallet.Message{}
 	// Token transfer
 	if contract, ok := args.GetContract(); ok {
-		tokenAddr, err := tonaddress.ParseAddress(txInput.TokenWallet, net)
+		tokenAddr, err := validateTokenWallet(from, contract, txInput.TokenWallet, txInput.JettonWalletCode, net)
 		if err != nil {
-			return nil, fmt.Errorf("invalid TON token address %s: %v", txInput.TokenWallet, err)
+			return nil, err
 		}
-		// Derive the token wallet address to protect from spending the wrong token
-		walletAddresses, err := tonaddress.CalculatePossibleTokenWalletAddresses(from, contract, txInput.JettonWalletCode)
+		tfMsg, err := BuildJettonTransfer(uint64(txInput.Timestamp), fromAddr, tokenAddr, toAddr, amountTlb, maxJettonFee(txInput), memo)
 		if err != nil {
-			return nil, fmt.Errorf("could not calcuate jetton wallet address for %s: %v", contract, err)
+			return nil, err
 		}
+		msgs = append(msgs, tfMsg)
 
-		matches := false
-		for _, possibleTokenAddr := range walletAddresses {
-			if xc.Address(tokenAddr.String()) == possibleTokenAddr {
-				matches = true
-			}
-		}
-		if !matches {
-			return nil, fmt.Errorf("could not validate token wallet address %s", txInput.TokenWallet)
+	} else {
+		// Native transfer
+		tfMsg, err := BuildTransfer(toAddr, amountTlb, false, memo)
+		if err != nil {
+			return nil, err
 		}
+		msgs = append(msgs, tfMsg)
+	}
 
-		// Spend max 0.2 TON per Jetton transfer.  If we don't have 0.2 TON, we should
-		// lower the max to our balance less max-fees.
-		maxJettonFee := xc.NewAmountBlockchainFromUint64(200000000)
-		remainingTonBal := txInput.TonBalance.Sub(&txInput.EstimatedMaxFee)
-		if maxJettonFee.Cmp(&remainingTonBal) > 0 && remainingTonBal.Cmp(&Zero) > 0 {
-			maxJettonFee = remainingTonBal
-		}
-		// If the estimated max fee is greater, then we should use that.
-		if txInput.EstimatedMaxFee.Cmp(&maxJettonFee) > 0 {
-			maxJettonFee = txInput.EstimatedMaxFee
-		}
+	fromPubKey, _ := args.GetPublicKey()
+	return txBuilder.newTx(fromAddr, fromPubKey, txInput, msgs)
+}
+
+// MultiTransfer sends native and/or jetton transfers to many receivers from a single wallet.
+// A v3 wallet can send up to 4 messages at once, while a highload v3 wallet can send thousands.
+func (txBuilder TxBuilder) MultiTransfer(args xcbuilder.MultiTransferArgs, input xc.MultiTransferInput) (xc.Tx, error) {
+	multiInput, ok := input.(*MultiTransferInput)
+	if !ok {
+		return nil, errors.New("xc.MultiTransferInput is not from a ton chain")
+	}
+	spenders := args.Spenders()
+	if len(spenders) != 1 {
+		return nil, errors.New("only one spender is supported for ton multi-transfers")
+	}
+	receivers := args.Receivers()
+	if len(receivers) == 0 {
+		return nil, errors.New("no receivers for ton multi-transfer")
+	}
+	net := txBuilder.Asset.Network
+	from := spenders[0].GetFrom()
+	fromAddr, err := tonaddress.ParseAddress(from, net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON address %s: %v", from, err)
+	}
+	txInput := &multiInput.TxInput
 
-		tfMsg, err := BuildJettonTransfer(uint64(txInput.Timestamp), fromAddr, tokenAddr, toAddr, amountTlb, tlb.FromNanoTON(maxJettonFee.Int()), memo)
+	msgs := []*wallet.Message{}
+	for _, receiver := range receivers {
+		to := receiver.GetTo()
+		toAddr, err := tonaddress.ParseAddress(to, net)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TON destination %s: %v", to, err)
+		}
+		amount := receiver.GetAmount()
+		amountTlb, err := tlb.FromNano(amount.Int(), int(txBuilder.Asset.Decimals))
 		if err != nil {
 			return nil, err
 		}
-		msgs = append(msgs, tfMsg)
+		memo, ok := receiver.GetMemo()
+		if !ok {
+			memo, _ = args.GetMemo()
+		}
 
-	} else {
-		// Native transfer
-		tfMsg, err := BuildTransfer(toAddr, amountTlb, false, memo)
+		if contract, ok := receiver.GetContract(); ok {
+			tokenWallet, ok := multiInput.GetTokenWallet(contract)
+			if !ok {
+				return nil, fmt.Errorf("missing token wallet input for %s", contract)
+			}
+			tokenAddr, err := validateTokenWallet(from, contract, tokenWallet.TokenWallet, tokenWallet.JettonWalletCode, net)
+			if err != nil {
+				return nil, err
+			}
+			tfMsg, err := BuildJettonTransfer(uint64(txInput.Timestamp), fromAddr, tokenAddr, toAddr, amountTlb, maxJettonFee(txInput), memo)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, tfMsg)
+		} else {
+			tfMsg, err := BuildTransfer(toAddr, amountTlb, false, memo)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, tfMsg)
+		}
+	}
+
+	return txBuilder.newTx(fromAddr, spenders[0].GetPublicKey(), txInput, msgs)
+}
+
+// JettonBurn burns jettons from the sender's token wallet.  Excess TON is returned to the sender.
+func (txBuilder TxBuilder) JettonBurn(args JettonBurnArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*TxInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T", input)
+	}
+	if args.Amount.IsZero() {
+		return nil, fmt.Errorf("jetton burn amount must be greater than 0")
+	}
+	net := txBuilder.Asset.Network
+	fromAddr, err := tonaddress.ParseAddress(args.From, net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON address %s: %v", args.From, err)
+	}
+	tokenAddr, err := validateTokenWallet(args.From, args.Contract, txInput.TokenWallet, txInput.JettonWalletCode, net)
+	if err != nil {
+		return nil, err
+	}
+	amountTlb, err := tlb.FromNano(args.Amount.Int(), int(txBuilder.Asset.Decimals))
+	if err != nil {
+		return nil, err
+	}
+	burnMsg, err := BuildJettonBurn(uint64(txInput.Timestamp), fromAddr, tokenAddr, amountTlb, maxJettonFee(txInput))
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.newTx(fromAddr, args.PublicKey, txInput, []*wallet.Message{burnMsg})
+}
+
+// newTx wraps the messages in an external message to the sender's wallet.  If the wallet is not
+// yet deployed, it will be deployed by the same message.
+func (txBuilder TxBuilder) newTx(fromAddr *address.Address, fromPubKey []byte, txInput *TxInput, msgs []*wallet.Message) (xc.Tx, error) {
+	var stateInit *tlb.StateInit
+	var err error
+	if txInput.AccountStatus != api.Active {
+		if len(fromPubKey) == 0 {
+			return nil, fmt.Errorf("must set from-public-key in transfer args for new ton account: %s", fromAddr)
+		}
+		stateInit, err = tonaddress.GetStateInit(fromPubKey, txInput.WalletFormat)
 		if err != nil {
 			return nil, err
 		}
-		msgs = append(msgs, tfMsg)
 	}
 
 	logrus.WithFields(logrus.Fields{
 		"messages":   len(msgs),
 		"state-init": stateInit != nil,
+		"highload":   txInput.IsHighload(),
 		"chain":      txBuilder.Asset.Chain,
 	}).Debug("building tx")
 
+	if txInput.IsHighload() {
+		cellBuilder, err := BuildHighloadV3UnsignedMessage(txInput, fromAddr, msgs)
+		if err != nil {
+			return nil, err
+		}
+		return tontx.NewHighloadTx(fromAddr, cellBuilder, stateInit), nil
+	}
+
 	cellBuilder, err := BuildV3UnsignedMessage(txInput, msgs)
 	if err != nil {
 		return nil, err
@@ -137,6 +228,41 @@ func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInpu
 	return tontx.NewTx(fromAddr, cellBuilder, stateInit), nil
 }
 
+// Derive the token wallet address to protect from spending the wrong token
+func validateTokenWallet(from xc.Address, contract xc.ContractAddress, tokenWallet xc.Address, jettonWalletCode []byte, net string) (*address.Address, error) {
+	tokenAddr, err := tonaddress.ParseAddress(tokenWallet, net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON token address %s: %v", tokenWallet, err)
+	}
+	walletAddresses, err := tonaddress.CalculatePossibleTokenWalletAddresses(from, contract, jettonWalletCode)
+	if err != nil {
+		return nil, fmt.Errorf("could not calcuate jetton wallet address for %s: %v", contract, err)
+	}
+
+	for _, possibleTokenAddr := range walletAddresses {
+		if xc.Address(tokenAddr.String()) == possibleTokenAddr {
+			return tokenAddr, nil
+		}
+	}
+	return nil, fmt.Errorf("could not validate token wallet address %s", tokenWallet)
+}
+
+// TON to attach to a message to a token wallet, to pay for its fees
+func maxJettonFee(txInput *TxInput) tlb.Coins {
+	// Spend max 0.2 TON per Jetton transfer.  If we don't have 0.2 TON, we should
+	// lower the max to our balance less max-fees.
+	maxJettonFee := xc.NewAmountBlockchainFromUint64(200000000)
+	remainingTonBal := txInput.TonBalance.Sub(&txInput.EstimatedMaxFee)
+	if maxJettonFee.Cmp(&remainingTonBal) > 0 && remainingTonBal.Cmp(&Zero) > 0 {
+		maxJettonFee = remainingTonBal
+	}
+	// If the estimated max fee is greater, then we should use that.
+	if txInput.EstimatedMaxFee.Cmp(&maxJettonFee) > 0 {
+		maxJettonFee = txInput.EstimatedMaxFee
+	}
+	return tlb.FromNanoTON(maxJettonFee.Int())
+}
+
 func BuildTransfer(to *address.Address, amount tlb.Coins, bounce bool, comment string) (_ *wallet.Message, err error) {
 	var body *cell.Cell
 	if comment != "" {
@@ -185,6 +311,19 @@ func BuildJettonTransfer(randomInt uint64, from *address.Address, tokenWallet *a
 	return wallet.SimpleMessage(tokenWallet, maxFee, tokenBody), nil
 }
 
+// BuildJettonBurn burns jettons from the token wallet, sending excess TON back to the owner
+func BuildJettonBurn(randomInt uint64, from *address.Address, tokenWallet *address.Address, amount tlb.Coins, maxFee tlb.Coins) (*wallet.Message, error) {
+	burnBody, err := tlb.ToCell(jetton.BurnPayload{
+		QueryID:             randomInt,
+		Amount:              amount,
+		ResponseDestination: from,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wallet.SimpleMessage(tokenWallet, maxFee, burnBody), nil
+}
+
 func BuildV3UnsignedMessage(txInput *TxInput, messages []*wallet.Message) (*cell.Builder, error) {
 	// TON v3 wallets have a max of 4 messages
 	if len(messages) > 4 {
@@ -208,6 +347,97 @@ func BuildV3UnsignedMessage(txInput *TxInput, messages []*wallet.Message) (*cell
 	return payload, nil
 }
 
+// Highload v3 wallets can send 254 messages per action list, and may nest another list as the last message
+const highloadMessagesPerPack = 253
+const highloadMaxMessages = 254 * 254
+
+// Opcode of the internal message a highload v3 wallet sends to itself to process an action list
+const HighloadInternalTransferOp = 0xae42e5a4
+
+// Opcode of the action_send_msg action
+const ActionSendMsgOp = 0x0ec3c86d
+
+// BuildHighloadV3UnsignedMessage builds the payload to sign for a highload v3 wallet.  Batches of messages
+// are packed into an internal message the wallet sends to itself.
+// See https://github.com/ton-blockchain/highload-wallet-contract-v3
+func BuildHighloadV3UnsignedMessage(txInput *TxInput, walletAddr *address.Address, messages []*wallet.Message) (*cell.Builder, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("should have at least one message")
+	}
+	if len(messages) > highloadMaxMessages {
+		return nil, fmt.Errorf("for this type of wallet max %d messages can be sent in the same time", highloadMaxMessages)
+	}
+	if txInput.QueryId >= 1<<23 {
+		return nil, fmt.Errorf("invalid highload query id: %d", txInput.QueryId)
+	}
+
+	msg := messages[0]
+	// messages with a state init must be packed, as the external message must be validated by the contract
+	if len(messages) > 1 || msg.InternalMessage.StateInit != nil {
+		var err error
+		msg, err = packHighloadActions(uint64(txInput.QueryId), walletAddr, messages)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack messages: %w", err)
+		}
+	}
+	msgCell, err := tlb.ToCell(msg.InternalMessage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert message to cell: %w", err)
+	}
+
+	// The wallet rejects messages created after the latest block, so allow for some clock skew.
+	createdAt := txInput.Timestamp - 30
+	payload := cell.BeginCell().
+		MustStoreUInt(tonaddress.HighloadSubwalletId, 32).
+		MustStoreRef(msgCell).
+		MustStoreUInt(uint64(msg.Mode), 8).
+		MustStoreUInt(uint64(txInput.QueryId), 23).
+		MustStoreUInt(uint64(createdAt), 64).
+		MustStoreUInt(tonaddress.HighloadMessageTtl, 22)
+
+	return payload, nil
+}
+
+func packHighloadActions(queryId uint64, walletAddr *address.Address, messages []*wallet.Message) (*wallet.Message, error) {
+	if len(messages) > highloadMessagesPerPack {
+		rest, err := packHighloadActions(queryId, walletAddr, messages[highloadMessagesPerPack:])
+		if err != nil {
+			return nil, err
+		}
+		messages = append(append([]*wallet.Message{}, messages[:highloadMessagesPerPack]...), rest)
+	}
+
+	amount := big.NewInt(0)
+	list := cell.BeginCell().EndCell()
+	for _, message := range messages {
+		amount = amount.Add(amount, message.InternalMessage.Amount.Nano())
+		outMsg, err := tlb.ToCell(message.InternalMessage)
+		if err != nil {
+			return nil, err
+		}
+		action := cell.BeginCell().
+			MustStoreUInt(ActionSendMsgOp, 32).
+			MustStoreUInt(uint64(message.Mode), 8).
+			MustStoreRef(outMsg)
+		list = cell.BeginCell().MustStoreRef(list).MustStoreBuilder(action).EndCell()
+	}
+
+	return &wallet.Message{
+		Mode: wallet.PayGasSeparately + wallet.IgnoreErrors,
+		InternalMessage: &tlb.InternalMessage{
+			IHRDisabled: true,
+			Bounce:      false,
+			DstAddr:     walletAddr,
+			Amount:      tlb.FromNanoTON(amount),
+			Body: cell.BeginCell().
+				MustStoreUInt(HighloadInternalTransferOp, 32).
+				MustStoreUInt(queryId, 64).
+				MustStoreRef(list).
+				EndCell(),
+		},
+	}, nil
+}
+
 func ParseComment(body *cell.Cell) (string, bool) {
 	if body != nil {
 		l := body.BeginParse()
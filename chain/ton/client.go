@@ -3,13 +3,16 @@ package ton
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -243,16 +246,125 @@ func (client *Client) EstimateMaxFee(ctx context.Context, from xc.Address, to xc
 
 func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
 	var err error
+	publicKey, _ := args.GetPublicKey()
+	input, err := client.fetchWalletInput(ctx, args.GetFrom(), publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if contract, ok := args.GetContract(); ok {
+		tokenWallet, maxFee, err := client.fetchTokenWalletInput(ctx, args.GetFrom(), args.GetTo(), contract)
+		if err != nil {
+			return input, err
+		}
+		input.JettonWalletCode = tokenWallet.JettonWalletCode
+		input.TokenWallet = tokenWallet.TokenWallet
+		input.EstimatedMaxFee = maxFee
+	}
+
+	getAddrResponse := &api.GetMethodResponse{}
+	err = client.post("api/v3/runGetMethod", &api.GetMethodRequest{
+		Address: string(args.GetFrom()),
+		Method:  api.GetPublicKeyMethod,
+		Stack:   []api.StackItem{},
+	}, getAddrResponse)
+	if err != nil {
+		return nil, fmt.Errorf("could not get address public-key: %v", err)
+	}
+	// if getAddrResponse.ExitCode == 0 && len(getAddrResponse.Stack) > 0 {
+	// 	// Set the public key if the account is present on chain.
+	// 	// If not, the public key will need to be set by caller.
+	// 	err = input.SetPublicKeyFromStr(getAddrResponse.Stack[0].Value)
+	// 	if err != nil {
+	// 		logrus.WithError(err).Warn("could not set public key from remote")
+	// 	}
+	// }
+
+	return input, nil
+}
+
+var _ xclient.MultiTransferClient = &Client{}
+
+func (client *Client) FetchMultiTransferInput(ctx context.Context, args xcbuilder.MultiTransferArgs) (xc.MultiTransferInput, error) {
+	spenders := args.Spenders()
+	if len(spenders) != 1 {
+		return nil, fmt.Errorf("only one spender is supported for ton multi-transfers")
+	}
+	from := spenders[0].GetFrom()
+	baseInput, err := client.fetchWalletInput(ctx, from, spenders[0].GetPublicKey())
+	if err != nil {
+		return nil, err
+	}
+	input := &MultiTransferInput{
+		TxInput: *baseInput,
+	}
+
+	for _, receiver := range args.Receivers() {
+		contract, ok := receiver.GetContract()
+		if !ok {
+			continue
+		}
+		if _, ok := input.GetTokenWallet(contract); ok {
+			continue
+		}
+		tokenWallet, maxFee, err := client.fetchTokenWalletInput(ctx, from, receiver.GetTo(), contract)
+		if err != nil {
+			return input, err
+		}
+		input.TokenWallets = append(input.TokenWallets, tokenWallet)
+		// reserve enough for the most expensive jetton
+		if maxFee.Cmp(&input.EstimatedMaxFee) > 0 {
+			input.EstimatedMaxFee = maxFee
+		}
+	}
+	return input, nil
+}
+
+// FetchJettonBurnInput returns the input needed to burn jettons held by the sender
+func (client *Client) FetchJettonBurnInput(ctx context.Context, args JettonBurnArgs) (*TxInput, error) {
+	input, err := client.fetchWalletInput(ctx, args.From, args.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	tokenWallet, maxFee, err := client.fetchTokenWalletInput(ctx, args.From, args.From, args.Contract)
+	if err != nil {
+		return input, err
+	}
+	input.JettonWalletCode = tokenWallet.JettonWalletCode
+	input.TokenWallet = tokenWallet.TokenWallet
+	input.EstimatedMaxFee = maxFee
+	return input, nil
+}
+
+// fetchWalletInput fetches the state of the sender's wallet.  Highload wallets are detected by their
+// code, or by the public key if they are not yet deployed.
+func (client *Client) fetchWalletInput(ctx context.Context, from xc.Address, publicKey []byte) (*TxInput, error) {
 	acc := &api.GetAccountResponse{}
-	err = client.get(fmt.Sprintf("/api/v3/account?address=%s", args.GetFrom()), acc)
+	err := client.get(fmt.Sprintf("/api/v3/account?address=%s", from), acc)
 	if err != nil {
 		return nil, fmt.Errorf("could not get address info: %v", err)
 	}
 
+	input := &TxInput{
+		TxInputEnvelope: NewTxInput().TxInputEnvelope,
+		AccountStatus:   acc.Status,
+		Timestamp:       time.Now().Unix(),
+		TonBalance:      xc.NewAmountBlockchainFromStr(acc.Balance),
+	}
+
+	if client.isHighloadWallet(from, acc, publicKey) {
+		input.WalletFormat = tonaddress.AddressFormatHighloadV3
+		input.QueryId, err = client.FetchUnprocessedQueryId(ctx, from, acc.Status == api.Active)
+		if err != nil {
+			return nil, err
+		}
+		return input, nil
+	}
+
 	getSeqResponse := &api.GetMethodResponse{}
 
 	err = client.post("api/v3/runGetMethod", &api.GetMethodRequest{
-		Address: string(args.GetFrom()),
+		Address: string(from),
 		Method:  api.GetSequenceMethod,
 		Stack:   []api.StackItem{},
 	}, getSeqResponse)
@@ -267,52 +379,91 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	} else {
 		// starts at 0 when address isn't initialized yet
 	}
+	input.Sequence = sequence
+	return input, nil
+}
 
-	input := &TxInput{
-		TxInputEnvelope: NewTxInput().TxInputEnvelope,
-		AccountStatus:   acc.Status,
-		Timestamp:       time.Now().Unix(),
-		Sequence:        sequence,
-		TonBalance:      xc.NewAmountBlockchainFromStr(acc.Balance),
+func (client *Client) isHighloadWallet(from xc.Address, acc *api.GetAccountResponse, publicKey []byte) bool {
+	if acc.Status == api.Active {
+		code, err := base64.StdEncoding.DecodeString(acc.Code)
+		return err == nil && tonaddress.IsHighloadV3Code(code)
 	}
+	// not deployed yet, so check if the address was derived as a highload wallet
+	if len(publicKey) == 0 {
+		return false
+	}
+	highloadAddr, err := tonaddress.AddressFromPublicKey(publicKey, tonaddress.AddressFormatHighloadV3)
+	if err != nil {
+		return false
+	}
+	fromAddr, err := tonaddress.ParseAddress(from, client.Asset.GetChain().Network)
+	if err != nil {
+		return false
+	}
+	return fromAddr.Workchain() == highloadAddr.Workchain() && bytes.Equal(fromAddr.Data(), highloadAddr.Data())
+}
 
-	if contract, ok := args.GetContract(); ok {
-		walletCode, err := client.GetJettonWalletCode(ctx, contract)
-		if err != nil {
-			return input, err
+// Highload wallets track the query id's processed within the message ttl, rather than a sequence.
+// Pick a random query id that has not been processed.
+func (client *Client) FetchUnprocessedQueryId(ctx context.Context, from xc.Address, deployed bool) (uint32, error) {
+	const maxAttempts = 5
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		var bz [4]byte
+		if _, err := rand.Read(bz[:]); err != nil {
+			return 0, err
+		}
+		// query id's are 23 bits
+		queryId := binary.BigEndian.Uint32(bz[:]) % (1 << 23)
+		if !deployed {
+			return queryId, nil
 		}
-		input.JettonWalletCode = walletCode
 
-		input.TokenWallet, err = client.GetTokenWallet(ctx, args.GetFrom(), contract)
+		processedResponse := &api.GetMethodResponse{}
+		err := client.post("api/v3/runGetMethod", &api.GetMethodRequest{
+			Address: string(from),
+			Method:  api.GetProcessedMethod,
+			Stack: []api.StackItem{
+				{Type: "num", Value: fmt.Sprintf("0x%x", queryId)},
+				// need_clean
+				{Type: "num", Value: "0x0"},
+			},
+		}, processedResponse)
 		if err != nil {
-			return input, err
+			return 0, fmt.Errorf("could not check if query id is processed: %v", err)
 		}
-		maxFee, err := client.EstimateMaxFee(ctx, input.TokenWallet, args.GetTo(), string(contract))
-		if err != nil {
-			return input, err
+		if processedResponse.ExitCode != 0 || len(processedResponse.Stack) == 0 {
+			return 0, fmt.Errorf("could not check if query id is processed for %s (%d)", from, processedResponse.ExitCode)
+		}
+		processed, ok := new(big.Int).SetString(processedResponse.Stack[0].Value, 0)
+		if !ok {
+			return 0, fmt.Errorf("invalid processed? result: %s", processedResponse.Stack[0].Value)
+		}
+		if processed.Sign() == 0 {
+			return queryId, nil
 		}
-		input.EstimatedMaxFee = xc.NewAmountBlockchainFromUint64(maxFee)
 	}
+	return 0, fmt.Errorf("could not find an unprocessed query id for %s", from)
+}
 
-	getAddrResponse := &api.GetMethodResponse{}
-	err = client.post("api/v3/runGetMethod", &api.GetMethodRequest{
-		Address: string(args.GetFrom()),
-		Method:  api.GetPublicKeyMethod,
-		Stack:   []api.StackItem{},
-	}, getAddrResponse)
+// fetchTokenWalletInput looks up the sender's token wallet for a jetton, and the max fee to reserve for sending from it
+func (client *Client) fetchTokenWalletInput(ctx context.Context, from xc.Address, to xc.Address, contract xc.ContractAddress) (*TokenWalletInput, xc.AmountBlockchain, error) {
+	walletCode, err := client.GetJettonWalletCode(ctx, contract)
 	if err != nil {
-		return nil, fmt.Errorf("could not get address public-key: %v", err)
+		return nil, xc.AmountBlockchain{}, err
 	}
-	// if getAddrResponse.ExitCode == 0 && len(getAddrResponse.Stack) > 0 {
-	// 	// Set the public key if the account is present on chain.
-	// 	// If not, the public key will need to be set by caller.
-	// 	err = input.SetPublicKeyFromStr(getAddrResponse.Stack[0].Value)
-	// 	if err != nil {
-	// 		logrus.WithError(err).Warn("could not set public key from remote")
-	// 	}
-	// }
-
-	return input, nil
+	tokenWallet, err := client.GetTokenWallet(ctx, from, contract)
+	if err != nil {
+		return nil, xc.AmountBlockchain{}, err
+	}
+	maxFee, err := client.EstimateMaxFee(ctx, tokenWallet, to, string(contract))
+	if err != nil {
+		return nil, xc.AmountBlockchain{}, err
+	}
+	return &TokenWalletInput{
+		Contract:         contract,
+		TokenWallet:      tokenWallet,
+		JettonWalletCode: walletCode,
+	}, xc.NewAmountBlockchainFromUint64(maxFee), nil
 }
 
 // SubmitTx submits a Template tx
@@ -413,70 +564,212 @@ func (client *Client) ParseJetton(c *cell.Cell, tokenWallet *address.Address, bo
 	return sources, dests, true, nil
 }
 
-// This detects any JettonMessage in the nest of "InternalMessage"
-// This may need to be expanded as Jetton transfer could be nested deeper in more 'InternalMessages'
-func (client *Client) DetectJettonMovements(tx *api.Transaction, book api.AddressBook) ([]*txinfo.LegacyTxInfoEndpoint, []*txinfo.LegacyTxInfoEndpoint, error) {
-	boc, err := base64.StdEncoding.DecodeString(tx.InMsg.MessageContent.Body)
+// ParseJettonBurn parses a burn sent to a token wallet, which removes the jettons from the owner
+func (client *Client) ParseJettonBurn(c *cell.Cell, tokenWallet *address.Address, book api.AddressBook) ([]*txinfo.LegacyTxInfoEndpoint, bool, error) {
+	net := client.Asset.GetChain().Network
+	burnMaybe := &jetton.BurnPayload{}
+	err := tlb.LoadFromCell(burnMaybe, c.BeginParse())
+	if err != nil {
+		logrus.WithError(err).Debug("no jetton burn detected")
+		return nil, false, nil
+	}
+	resp := &api.JettonWalletsResponse{}
+	err = client.get(fmt.Sprintf("/api/v3/jetton/wallets?address=%s&limit=1&offset=0", tokenWallet.String()), resp)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not resolve token master address: %v", err)
+	}
+	if len(resp.JettonWallets) == 0 {
+		return nil, false, fmt.Errorf("could not resolve token master address: unknown token wallet %s", tokenWallet.String())
+	}
+	masterAddr, err := tonaddress.ParseAddress(xc.Address(resp.JettonWallets[0].Jetton), net)
+	if err != nil {
+		return nil, false, err
+	}
+	ownerAddr, err := client.substituteOrParse(book, resp.JettonWallets[0].Owner)
+	if err != nil {
+		return nil, false, err
+	}
+	sources := []*txinfo.LegacyTxInfoEndpoint{
+		{
+			Address:         xc.Address(ownerAddr.String()),
+			Amount:          xc.AmountBlockchain(*burnMaybe.Amount.Nano()),
+			ContractAddress: xc.ContractAddress(masterAddr.String()),
+			NativeAsset:     client.Asset.GetChain().Chain,
+		},
+	}
+	return sources, true, nil
+}
+
+// This detects a jetton transfer or burn sent by the wallet to its token wallet
+func (client *Client) DetectJettonMovements(msg *api.OutMsg, book api.AddressBook) ([]*txinfo.LegacyTxInfoEndpoint, []*txinfo.LegacyTxInfoEndpoint, error) {
+	if msg.Destination == nil || *msg.Destination == "" || msg.MessageContent.Body == "" {
+		return nil, nil, nil
+	}
+	boc, err := base64.StdEncoding.DecodeString(msg.MessageContent.Body)
 	if err != nil {
 		return nil, nil, fmt.Errorf("invalid base64: %v", err)
 	}
-	inMsg, err := cell.FromBOC(boc)
+	body, err := cell.FromBOC(boc)
 	if err != nil {
 		return nil, nil, fmt.Errorf("invalid boc: %v", err)
 	}
-	internalMsg := &tlb.InternalMessage{}
-	nextMsg, err := inMsg.BeginParse().LoadRefCell()
+	tokenWallet, err := client.substituteOrParse(book, *msg.Destination)
 	if err != nil {
-		err = tlb.LoadFromCell(internalMsg, inMsg.BeginParse())
-	} else {
-		err = tlb.LoadFromCell(internalMsg, nextMsg.BeginParse())
+		return nil, nil, fmt.Errorf("invalid address %s: %v", *msg.Destination, err)
+	}
+
+	sources, dests, ok, err := client.ParseJetton(body, tokenWallet, book)
+	if err != nil || ok {
+		return sources, dests, err
 	}
+	sources, _, err = client.ParseJettonBurn(body, tokenWallet, book)
+	return sources, nil, err
+}
+
+// fetchTxByMessage returns the transaction that processed a message, if any
+func (client *Client) fetchTxByMessage(msgHash string) (*api.Transaction, api.AddressBook, error) {
+	transactions := &api.TransactionsData{}
+	// Filter by 'in' direction as this matches messages submitted by the user vs "bounced" transactions created by the chain.
+	err := client.get(fmt.Sprintf("api/v3/transactionsByMessage?direction=in&msg_hash=%s", url.QueryEscape(msgHash)), transactions)
 	if err != nil {
-		return nil, nil, nil
+		return nil, nil, err
 	}
-	if internalMsg.DstAddr == nil {
+	if len(transactions.Transactions) == 0 {
 		return nil, nil, nil
 	}
+	return &transactions.Transactions[0], transactions.AddressBook, nil
+}
 
-	next := internalMsg.Body
-	for next != nil {
-		sources, dests, ok, err := client.ParseJetton(next, internalMsg.DstAddr, book)
+// Number of messages looked up per transactionsByMessage request
+const messagesPerLookup = 100
+
+// fetchTxsByMessages returns the transactions that processed the messages, by message hash.  Messages that
+// were not processed yet have no transaction.
+func (client *Client) fetchTxsByMessages(msgHashes []string) (map[string]*api.Transaction, api.AddressBook, error) {
+	txs := map[string]*api.Transaction{}
+	book := api.AddressBook{}
+	for start := 0; start < len(msgHashes); start += messagesPerLookup {
+		batch := msgHashes[start:min(start+messagesPerLookup, len(msgHashes))]
+		query := url.Values{}
+		query.Set("direction", "in")
+		query.Set("limit", strconv.Itoa(len(batch)))
+		for _, msgHash := range batch {
+			query.Add("msg_hash", msgHash)
+		}
+		transactions := &api.TransactionsData{}
+		err := client.get("api/v3/transactionsByMessage?"+query.Encode(), transactions)
 		if err != nil {
-			return nil, nil, fmt.Errorf("%v", err)
+			return nil, nil, err
 		}
-		if ok {
-			return sources, dests, nil
+		for i := range transactions.Transactions {
+			tx := &transactions.Transactions[i]
+			txs[tx.InMsg.Hash] = tx
 		}
-		nextMsg, err := next.BeginParse().LoadRefCell()
-		if err != nil {
-			break
-		} else {
-			next = nextMsg
+		for raw, entry := range transactions.AddressBook {
+			book[raw] = entry
 		}
 	}
+	return txs, book, nil
+}
 
-	return nil, nil, nil
+// FetchMessageBounces checks if bounceable messages failed to be processed by their destination.  The messages
+// then bounce back to the sender, reverting any transfer they carried.  The failures are returned by message hash.
+func (client *Client) FetchMessageBounces(ctx context.Context, msgs []api.OutMsg, book api.AddressBook) (map[string]string, error) {
+	msgHashes := []string{}
+	for _, msg := range msgs {
+		if msg.Bounce != nil && *msg.Bounce && msg.Destination != nil {
+			msgHashes = append(msgHashes, msg.Hash)
+		}
+	}
+	failures := map[string]string{}
+	if len(msgHashes) == 0 {
+		return failures, nil
+	}
+	txs, _, err := client.fetchTxsByMessages(msgHashes)
+	if err != nil {
+		return nil, fmt.Errorf("could not check for bounce of messages: %v", err)
+	}
+	for _, msg := range msgs {
+		tx, ok := txs[msg.Hash]
+		if !ok || msg.Destination == nil || !tx.Description.Aborted {
+			// not processed yet, or succeeded
+			continue
+		}
+		dest := *msg.Destination
+		if addr, err := client.substituteOrParse(book, dest); err == nil {
+			dest = addr.String()
+		}
+		failures[msg.Hash] = errors.TransactionFailuref("message to %s bounced (exit code %d)", dest, tx.Description.ComputePh.ExitCode).Error()
+	}
+	return failures, nil
+}
+
+// isHighloadInternalTransfer reports whether a message is a batch that a highload wallet sent to itself
+func isHighloadInternalTransfer(tx *api.Transaction, msg *api.OutMsg) bool {
+	if msg.Destination == nil || !strings.EqualFold(*msg.Destination, tx.Account) {
+		return false
+	}
+	opcode, err := strconv.ParseUint(strings.TrimPrefix(msg.Opcode, "0x"), 16, 32)
+	return err == nil && opcode == HighloadInternalTransferOp
+}
+
+// Highload wallets send batches of messages to themselves to process.  Follow these to find the
+// messages actually sent, along with the additional fees.
+func (client *Client) collectOutMessages(ctx context.Context, tx *api.Transaction, book api.AddressBook) ([]api.OutMsg, xc.AmountBlockchain, error) {
+	msgs := []api.OutMsg{}
+	fees := xc.NewAmountBlockchainFromUint64(0)
+
+	batchHashes := []string{}
+	for _, msg := range tx.OutMsgs {
+		if isHighloadInternalTransfer(tx, &msg) {
+			batchHashes = append(batchHashes, msg.Hash)
+		}
+	}
+	internalTxs, internalBook, err := client.fetchTxsByMessages(batchHashes)
+	if err != nil {
+		return nil, fees, err
+	}
+	for raw, entry := range internalBook {
+		book[raw] = entry
+	}
+
+	for _, msg := range tx.OutMsgs {
+		internalTx, ok := internalTxs[msg.Hash]
+		if !ok || !isHighloadInternalTransfer(tx, &msg) {
+			// not a batch, or not processed yet
+			msgs = append(msgs, msg)
+			continue
+		}
+		internalFee := xc.NewAmountBlockchainFromStr(internalTx.TotalFees)
+		fees = fees.Add(&internalFee)
+
+		internalMsgs, internalFees, err := client.collectOutMessages(ctx, internalTx, book)
+		if err != nil {
+			return nil, fees, err
+		}
+		fees = fees.Add(&internalFees)
+		msgs = append(msgs, internalMsgs...)
+	}
+	return msgs, fees, nil
 }
 
 // Prioritize getting tx by msg-hash as it's deterministic offline.  Fallback to using chain-calculated tx hash.
 func (client *Client) FetchTonTxByHash(ctx context.Context, txHash xc.TxHash) (api.Transaction, api.AddressBook, error) {
+	tx, book, err := client.fetchTxByMessage(string(txHash))
+	if err != nil {
+		return api.Transaction{}, nil, err
+	}
+	if tx != nil {
+		return *tx, book, nil
+	}
+	// try looking up by chain-issued hash
 	transactions := &api.TransactionsData{}
-
-	// Filter by 'in' direction as this matches messages submitted by the user vs "bounced" transactions created by the chain.
-	err := client.get(fmt.Sprintf("api/v3/transactionsByMessage?direction=in&msg_hash=%s", url.QueryEscape(string(txHash))), transactions)
+	err = client.get(fmt.Sprintf("api/v3/transactions?hash=%s", url.QueryEscape(string(txHash))), transactions)
 	if err != nil {
 		return api.Transaction{}, nil, err
 	}
 	if len(transactions.Transactions) == 0 {
-		// try looking up by chain-issued hash
-		err = client.get(fmt.Sprintf("api/v3/transactions?hash=%s", url.QueryEscape(string(txHash))), transactions)
-		if err != nil {
-			return api.Transaction{}, nil, err
-		}
-
-		if len(transactions.Transactions) == 0 {
-			return api.Transaction{}, nil, errors.TransactionNotFoundf("no TON transaction found by %s", txHash)
-		}
+		return api.Transaction{}, nil, errors.TransactionNotFoundf("no TON transaction found by %s", txHash)
 	}
 	return transactions.Transactions[0], transactions.AddressBook, nil
 }
@@ -509,54 +802,73 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 	dests := []*txinfo.LegacyTxInfoEndpoint{}
 	chain := client.Asset.GetChain().Chain
 
+	if addrBook == nil {
+		addrBook = api.AddressBook{}
+	}
 	totalFee := xc.NewAmountBlockchainFromStr(tx.TotalFees)
+	outMsgs, internalFees, err := client.collectOutMessages(ctx, &tx, addrBook)
+	if err != nil {
+		return txinfo.LegacyTxInfo{}, err
+	}
+	totalFee = totalFee.Add(&internalFees)
 
-	for _, msg := range tx.OutMsgs {
+	bounces, err := client.FetchMessageBounces(ctx, outMsgs, addrBook)
+	if err != nil {
+		return txinfo.LegacyTxInfo{}, err
+	}
+	failures := []string{}
+	for _, msg := range outMsgs {
 		if msg.Bounced != nil && *msg.Bounced {
 			// if the message bounced, do no add endpoints
-		} else {
-			memo := ""
-			if msg.MessageContent.Decoded != nil && msg.MessageContent.Decoded.Type == "text_comment" {
-				memo = msg.MessageContent.Decoded.Comment
-			}
-			if msg.Destination != nil && *msg.Destination != "" && msg.Value != nil {
-				addr, err := client.substituteOrParse(addrBook, *msg.Destination)
-				if err != nil {
-					return txinfo.LegacyTxInfo{}, fmt.Errorf("invalid address %s: %v", *msg.Destination, err)
-				}
-				value := xc.NewAmountBlockchainFromStr(*msg.Value)
-				dests = append(dests, &txinfo.LegacyTxInfoEndpoint{
-					Address:         xc.Address(addr.String()),
-					ContractAddress: "",
-					Amount:          value,
-					NativeAsset:     chain,
-					Memo:            memo,
-				})
+			continue
+		}
+		if failure, ok := bounces[msg.Hash]; ok {
+			// the message failed and bounced back, so nothing was transferred
+			failures = append(failures, failure)
+			continue
+		}
+
+		memo := ""
+		if msg.MessageContent.Decoded != nil && msg.MessageContent.Decoded.Type == "text_comment" {
+			memo = msg.MessageContent.Decoded.Comment
+		}
+		if msg.Destination != nil && *msg.Destination != "" && msg.Value != nil {
+			addr, err := client.substituteOrParse(addrBook, *msg.Destination)
+			if err != nil {
+				return txinfo.LegacyTxInfo{}, fmt.Errorf("invalid address %s: %v", *msg.Destination, err)
 			}
-			if msg.Source != nil && *msg.Source != "" && msg.Value != nil {
-				addr, err := client.substituteOrParse(addrBook, *msg.Source)
-				if err != nil {
-					return txinfo.LegacyTxInfo{}, fmt.Errorf("invalid address %s: %v", *msg.Source, err)
-				}
-				value := xc.NewAmountBlockchainFromStr(*msg.Value)
-				sources = append(sources, &txinfo.LegacyTxInfoEndpoint{
-					Address:         xc.Address(addr.String()),
-					ContractAddress: "",
-					Amount:          value,
-					NativeAsset:     chain,
-					Memo:            memo,
-				})
+			value := xc.NewAmountBlockchainFromStr(*msg.Value)
+			dests = append(dests, &txinfo.LegacyTxInfoEndpoint{
+				Address:         xc.Address(addr.String()),
+				ContractAddress: "",
+				Amount:          value,
+				NativeAsset:     chain,
+				Memo:            memo,
+			})
+		}
+		if msg.Source != nil && *msg.Source != "" && msg.Value != nil {
+			addr, err := client.substituteOrParse(addrBook, *msg.Source)
+			if err != nil {
+				return txinfo.LegacyTxInfo{}, fmt.Errorf("invalid address %s: %v", *msg.Source, err)
 			}
+			value := xc.NewAmountBlockchainFromStr(*msg.Value)
+			sources = append(sources, &txinfo.LegacyTxInfoEndpoint{
+				Address:         xc.Address(addr.String()),
+				ContractAddress: "",
+				Amount:          value,
+				NativeAsset:     chain,
+				Memo:            memo,
+			})
 		}
 
+		jettonSources, jettonDests, err := client.DetectJettonMovements(&msg, addrBook)
+		if err != nil {
+			return txinfo.LegacyTxInfo{}, fmt.Errorf("could not detect jetton movements: %v", err)
+		}
+		sources = append(sources, jettonSources...)
+		dests = append(dests, jettonDests...)
 	}
 
-	jettonSources, jettonDests, err := client.DetectJettonMovements(&tx, addrBook)
-	if err != nil {
-		return txinfo.LegacyTxInfo{}, fmt.Errorf("could not detect jetton movements: %v", err)
-	}
-	sources = append(sources, jettonSources...)
-	dests = append(dests, jettonDests...)
 	blockId := NewBlockId(tx.BlockRef.Workchain, tx.BlockRef.Shard, tx.BlockRef.Seqno)
 	info := txinfo.LegacyTxInfo{
 		// use the workchain ID as the blockhash
@@ -583,9 +895,13 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		FeeContract:     "",
 		Time:            0,
 		TimeReceived:    0,
+		Error:           strings.Join(failures, "; "),
 	}
 	if len(info.Sources) > 0 {
 		info.From = info.Sources[0].Address
+	} else if addr, err := client.substituteOrParse(addrBook, tx.Account); err == nil {
+		// nothing was sent, e.g. all messages bounced, but the wallet still paid the fee
+		info.From = xc.Address(addr.String())
 	}
 	if len(info.Destinations) > 0 {
 		info.To = info.Destinations[0].Address
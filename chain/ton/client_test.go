@@ -2,19 +2,27 @@ package ton_test
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/builder/buildertest"
 	"github.com/cordialsys/crosschain/chain/ton"
+	tonaddress "github.com/cordialsys/crosschain/chain/ton/address"
 	"github.com/cordialsys/crosschain/chain/ton/api"
+	tontx "github.com/cordialsys/crosschain/chain/ton/tx"
 	txinfo "github.com/cordialsys/crosschain/client/tx_info"
 	"github.com/cordialsys/crosschain/testutil"
 	testtypes "github.com/cordialsys/crosschain/testutil"
 	"github.com/stretchr/testify/require"
+	"github.com/xssnick/tonutils-go/tlb"
+	"github.com/xssnick/tonutils-go/ton/jetton"
 	"golang.org/x/time/rate"
 )
 
@@ -178,6 +186,7 @@ func TestFetchTxInput(t *testing.T) {
 func TestFetchTxInfo(t *testing.T) {
 
 	chain := xc.NewChainConfig(xc.TON).WithDecimals(9)
+	bouncedErr := "TransactionFailure: message to kQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9Wse1G bounced (exit code 709)"
 	vectors := []struct {
 		hash       string
 		desc       string
@@ -260,6 +269,8 @@ func TestFetchTxInfo(t *testing.T) {
 				`{"transactions":[],"address_book":{}}`,
 				// get transactions (normal index)
 				`{"transactions":[{"account":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","hash":"1GSVPzv9hwWLDztTHLzhG3eSE4dkLn6rzN0g5v9ugMs=","lt":"23694319000001","now":1721068303,"orig_status":"active","end_status":"active","total_fees":"2432322","prev_trans_hash":"ZIl+I3MgozmsPM20J2tQEC6ZD8uAqs2YOI/YbdxKzZ0=","prev_trans_lt":"23693735000001","description":{"type":"ord","action":{"valid":true,"success":true,"no_funds":false,"result_code":0,"tot_actions":1,"msgs_created":1,"spec_actions":0,"tot_msg_size":{"bits":"1433","cells":"3"},"status_change":"unchanged","total_fwd_fees":"771200","skipped_actions":0,"action_list_hash":"09sug8ZnYsaxKQ4Oig3JvW+b5zmI0nrrGrKVacNY3fs=","total_action_fees":"257062"},"aborted":false,"credit_ph":{"credit":"14424983580017492223"},"destroyed":false,"compute_ph":{"mode":0,"type":"vm","success":true,"gas_fees":"1197600","gas_used":"2994","vm_steps":66,"exit_code":0,"gas_limit":"0","gas_credit":"10000","msg_state_used":false,"account_activated":false,"vm_init_state_hash":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=","vm_final_state_hash":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},"storage_ph":{"status_change":"unchanged","storage_fees_collected":"60"},"credit_first":true},"block_ref":{"workchain":0,"shard":"2000000000000000","seqno":22626042},"in_msg":{"hash":"ziwO6w3WnVpdEIhp+in5lDBV5CwSe2clautu3baIAk8=","source":null,"destination":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","value":null,"fwd_fee":null,"ihr_fee":null,"created_lt":null,"created_at":null,"opcode":"0x34dc0fd1","ihr_disabled":null,"bounce":null,"bounced":null,"import_fee":"0","message_content":{"hash":"OdbHlUGU/Ta17opAIScvC3fLs7jLGQSnP0NjPDBH/V4=","body":"te6cckEBBAEA5wABmjTcD9FZwSoMNOO73pTZoc4G/qQ3Bv5UBtKx3aoqECRKL4FqCCheex116bIoj9gJyYT6QMobi2XtPAOwg5QWfQQpqaMXZpWHJwAAABIDAQFoYgAemgWKh1YByXr6FFX/754Z32tCNFP7+wPiUJ7ACferWKBfXhAAAAAAAAAAAAAAAAAAAQIBqA+KfqUAAAAAZpVrB0AU+xgIAUNFuUQFqR9VmgLZ4fnOpmVnv5bpipnZvzVtgrF6hjnHAAjflEZ/6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSoAMADgAAAABoaWk0d0kJ","decoded":null},"init_state":null},"out_msgs":[{"hash":"uO68NVBHlx6vHJ7IqIIp0qfeDszXtUkQi5bJoSPWL8g=","source":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","destination":"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1","value":"200000000","fwd_fee":"514138","ihr_fee":"0","created_lt":"23694319000002","created_at":"1721068303","opcode":"0x0f8a7ea5","ihr_disabled":true,"bounce":true,"bounced":false,"import_fee":null,"message_content":{"hash":"ulACZHpEYu49vd9fRZbSv25dPlQVqyuTtaFcC+Sm2Co=","body":"te6cckEBAgEAYAABqA+KfqUAAAAAZpVrB0AU+xgIAUNFuUQFqR9VmgLZ4fnOpmVnv5bpipnZvzVtgrF6hjnHAAjflEZ/6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSoAEADgAAAABoaWmTsiGj","decoded":null},"init_state":null}],"account_state_before":{"hash":"fvFnh/lTSqR5ek9cLBhwdWwt9gCX7w7fTbPbJLf8644=","balance":"563607278","account_status":"active","frozen_hash":null,"code_hash":"hNr6RJ+Ypph3ibojI1gHK8D3bcRSQAKl0JGLmnXS1Zk=","data_hash":"owTZCJ7DHIY5GC0P8u6S+1kZG49GbjbB5Ag5cI+MiQE="},"account_state_after":{"hash":"QqCCPF1ZarYkIqG0GPEfW7LOoHlHgl8AukzQiuaDqXo=","balance":"360660818","account_status":"active","frozen_hash":null,"code_hash":"hNr6RJ+Ypph3ibojI1gHK8D3bcRSQAKl0JGLmnXS1Zk=","data_hash":"Kz4Q0d9/FDEF4fBZLVi9XeGZUnu8g5iXEeLf0LPS7Yg="},"mc_block_seqno":21082496}],"address_book":{"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A":{"user_friendly":"0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5"},"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1":{"user_friendly":"kQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9Wse1G"}}}`,
+				// token wallet processed the jetton transfer (did not bounce)
+				`{"transactions":[{"account":"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1","hash":"r6Yp3Qh2xTgqyS0Ag3H9rYzE0IhdvGZyS5C1hKpPwf4=","lt":"23694320000001","now":1721068306,"orig_status":"active","end_status":"active","total_fees":"3621016","description":{"type":"ord","aborted":false,"destroyed":false,"credit_first":false,"compute_ph":{"mode":0,"type":"vm","success":true,"exit_code":0}},"block_ref":{"workchain":0,"shard":"2000000000000000","seqno":22626043},"in_msg":{"hash":"uO68NVBHlx6vHJ7IqIIp0qfeDszXtUkQi5bJoSPWL8g=","source":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","destination":"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1","value":"200000000","opcode":"0x0f8a7ea5","bounce":true,"bounced":false,"message_content":{"hash":"ulACZHpEYu49vd9fRZbSv25dPlQVqyuTtaFcC+Sm2Co=","body":""}},"out_msgs":[],"mc_block_seqno":21082497}],"address_book":{}}`,
 				// get jetton transfers (to resolve mint token addr)
 				`{"jetton_transfers":[{"query_id":"1721068295","source":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","destination":"0:A1A2DCA202D48FAACD016CF0FCE75332B3DFCB74C54CECDF9AB6C158BD431CE3","amount":"22000000","source_wallet":"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1","jetton_master":"0:226E80C4BFFA91ADC11DAD87706D52CD397047C128456ED2866D0549D8E2B163","transaction_hash":"A6ekLavrmSQOHKrmXCX396NJx0RPP4dtZlHVcrXxhlc=","transaction_lt":"23694319000003","transaction_now":1721068303,"response_destination":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","custom_payload":"te6cckEBAQEACQAADgAAAABoaWne1AAn","forward_ton_amount":null,"forward_payload":null}]}`,
 			},
@@ -340,6 +351,134 @@ func TestFetchTxInfo(t *testing.T) {
 				Confirmations: 168,
 			},
 		},
+		{
+			desc: "get_bounced_token_tx",
+			hash: "d464953f3bfd87058b0f3b531cbce11b77921387642e7eabccdd20e6ff6e80cb",
+			resp: []string{
+				// get chain info
+				`{"last":{"workchain":-1,"shard":"8000000000000000","seqno":21082664,"root_hash":"SMroEPt+MFtk85CpRUmyeogmrVDmHa6WbJm9Wz9OmMA=","file_hash":"TRya8nOmld4LaZVKlJC3Kq1apB4a4HmVYOxewte6a/k=","global_id":-3,"version":0,"after_merge":false,"before_split":false,"after_split":false,"want_merge":true,"want_split":false,"key_block":false,"vert_seqno_incr":false,"flags":1,"gen_utime":"1721068768","start_lt":"23694519000000","end_lt":"23694519000004","validator_list_hash_short":197321932,"gen_catchain_seqno":288848,"min_ref_mc_seqno":21082657,"prev_key_block_seqno":21082243,"vert_seqno":0,"master_ref_seqno":0,"rand_seed":"dN8oWdq3z/UfiufHNqwjHAA2J7fDhuqsZjz4ZsDKMMo=","created_by":"EIs7uyFACFwaIqs9Jw3Rm0LmtoEkV6GkIr/y9gnE/hk=","tx_count":3,"masterchain_block_ref":{"workchain":-1,"shard":"8000000000000000","seqno":21082664},"prev_blocks":[{"workchain":-1,"shard":"8000000000000000","seqno":21082663}]},"first":{"workchain":-1,"shard":"8000000000000000","seqno":3,"root_hash":"N1MtB3dREOndUsEfXY6U7EUmgG7KTawIjoeM69iLuCc=","file_hash":"MaP4koxBb5lcYfR9ubrBRUCyE7SEeakagA9Tg7aKK+A=","global_id":-3,"version":0,"after_merge":false,"before_split":false,"after_split":false,"want_merge":false,"want_split":false,"key_block":false,"vert_seqno_incr":false,"flags":1,"gen_utime":"1653238862","start_lt":"3000000","end_lt":"3000004","validator_list_hash_short":1253667756,"gen_catchain_seqno":0,"min_ref_mc_seqno":1,"prev_key_block_seqno":0,"vert_seqno":0,"master_ref_seqno":0,"rand_seed":"VyIDzkSrtLP+ji2OzWNhBmDZuPHdCDdeT8B/bhiwFuE=","created_by":"Bu4LLZ5LqTqQFFgS1P0DR4Fay0jcqNu1N34tZ9TFjMo=","tx_count":3,"masterchain_block_ref":{"workchain":-1,"shard":"8000000000000000","seqno":3},"prev_blocks":[{"workchain":-1,"shard":"8000000000000000","seqno":2}]}}`,
+				// not found via msg-hash index
+				`{"transactions":[],"address_book":{}}`,
+				`{"transactions":[{"account":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","hash":"1GSVPzv9hwWLDztTHLzhG3eSE4dkLn6rzN0g5v9ugMs=","lt":"23694319000001","now":1721068303,"orig_status":"active","end_status":"active","total_fees":"2432322","prev_trans_hash":"ZIl+I3MgozmsPM20J2tQEC6ZD8uAqs2YOI/YbdxKzZ0=","prev_trans_lt":"23693735000001","description":{"type":"ord","action":{"valid":true,"success":true,"no_funds":false,"result_code":0,"tot_actions":1,"msgs_created":1,"spec_actions":0,"tot_msg_size":{"bits":"1433","cells":"3"},"status_change":"unchanged","total_fwd_fees":"771200","skipped_actions":0,"action_list_hash":"09sug8ZnYsaxKQ4Oig3JvW+b5zmI0nrrGrKVacNY3fs=","total_action_fees":"257062"},"aborted":false,"credit_ph":{"credit":"14424983580017492223"},"destroyed":false,"compute_ph":{"mode":0,"type":"vm","success":true,"gas_fees":"1197600","gas_used":"2994","vm_steps":66,"exit_code":0,"gas_limit":"0","gas_credit":"10000","msg_state_used":false,"account_activated":false,"vm_init_state_hash":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=","vm_final_state_hash":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},"storage_ph":{"status_change":"unchanged","storage_fees_collected":"60"},"credit_first":true},"block_ref":{"workchain":0,"shard":"2000000000000000","seqno":22626042},"in_msg":{"hash":"ziwO6w3WnVpdEIhp+in5lDBV5CwSe2clautu3baIAk8=","source":null,"destination":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","value":null,"fwd_fee":null,"ihr_fee":null,"created_lt":null,"created_at":null,"opcode":"0x34dc0fd1","ihr_disabled":null,"bounce":null,"bounced":null,"import_fee":"0","message_content":{"hash":"OdbHlUGU/Ta17opAIScvC3fLs7jLGQSnP0NjPDBH/V4=","body":"te6cckEBBAEA5wABmjTcD9FZwSoMNOO73pTZoc4G/qQ3Bv5UBtKx3aoqECRKL4FqCCheex116bIoj9gJyYT6QMobi2XtPAOwg5QWfQQpqaMXZpWHJwAAABIDAQFoYgAemgWKh1YByXr6FFX/754Z32tCNFP7+wPiUJ7ACferWKBfXhAAAAAAAAAAAAAAAAAAAQIBqA+KfqUAAAAAZpVrB0AU+xgIAUNFuUQFqR9VmgLZ4fnOpmVnv5bpipnZvzVtgrF6hjnHAAjflEZ/6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSoAMADgAAAABoaWk0d0kJ","decoded":null},"init_state":null},"out_msgs":[{"hash":"uO68NVBHlx6vHJ7IqIIp0qfeDszXtUkQi5bJoSPWL8g=","source":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","destination":"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1","value":"200000000","fwd_fee":"514138","ihr_fee":"0","created_lt":"23694319000002","created_at":"1721068303","opcode":"0x0f8a7ea5","ihr_disabled":true,"bounce":true,"bounced":false,"import_fee":null,"message_content":{"hash":"ulACZHpEYu49vd9fRZbSv25dPlQVqyuTtaFcC+Sm2Co=","body":"te6cckEBAgEAYAABqA+KfqUAAAAAZpVrB0AU+xgIAUNFuUQFqR9VmgLZ4fnOpmVnv5bpipnZvzVtgrF6hjnHAAjflEZ/6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSoAEADgAAAABoaWmTsiGj","decoded":null},"init_state":null}],"account_state_before":{"hash":"fvFnh/lTSqR5ek9cLBhwdWwt9gCX7w7fTbPbJLf8644=","balance":"563607278","account_status":"active","frozen_hash":null,"code_hash":"hNr6RJ+Ypph3ibojI1gHK8D3bcRSQAKl0JGLmnXS1Zk=","data_hash":"owTZCJ7DHIY5GC0P8u6S+1kZG49GbjbB5Ag5cI+MiQE="},"account_state_after":{"hash":"QqCCPF1ZarYkIqG0GPEfW7LOoHlHgl8AukzQiuaDqXo=","balance":"360660818","account_status":"active","frozen_hash":null,"code_hash":"hNr6RJ+Ypph3ibojI1gHK8D3bcRSQAKl0JGLmnXS1Zk=","data_hash":"Kz4Q0d9/FDEF4fBZLVi9XeGZUnu8g5iXEeLf0LPS7Yg="},"mc_block_seqno":21082496}],"address_book":{"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A":{"user_friendly":"0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5"},"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1":{"user_friendly":"kQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9Wse1G"}}}`,
+				// token wallet failed to process the jetton transfer, so it bounced
+				`{"transactions":[{"account":"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1","hash":"r6Yp3Qh2xTgqyS0Ag3H9rYzE0IhdvGZyS5C1hKpPwf4=","lt":"23694320000001","now":1721068306,"orig_status":"active","end_status":"active","total_fees":"3621016","description":{"type":"ord","aborted":true,"destroyed":false,"credit_first":false,"compute_ph":{"mode":0,"type":"vm","success":false,"exit_code":709}},"block_ref":{"workchain":0,"shard":"2000000000000000","seqno":22626043},"in_msg":{"hash":"uO68NVBHlx6vHJ7IqIIp0qfeDszXtUkQi5bJoSPWL8g=","source":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","destination":"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1","value":"200000000","opcode":"0x0f8a7ea5","bounce":true,"bounced":false,"message_content":{"hash":"ulACZHpEYu49vd9fRZbSv25dPlQVqyuTtaFcC+Sm2Co=","body":""}},"out_msgs":[],"mc_block_seqno":21082497}],"address_book":{}}`,
+			},
+			tx: &txinfo.TxInfo{
+				Name:   "chains/TON/transactions/ce2c0eeb0dd69d5a5d108869fa29f9943055e42c127b67256aeb6eddb688024f",
+				Hash:   "ce2c0eeb0dd69d5a5d108869fa29f9943055e42c127b67256aeb6eddb688024f",
+				XChain: xc.TON,
+				State:  txinfo.Failed,
+				Final:  true,
+				Block: &txinfo.Block{
+					Chain:  "TON",
+					Height: xc.NewAmountBlockchainFromUint64(21082496),
+					Hash:   "0:2000000000000000:22626042",
+					Time:   testtypes.FromTimeStamp("2024-07-15T18:31:43Z"),
+				},
+				Movements: []*txinfo.Movement{
+					// only the fee is spent
+					{
+						XAsset:    "chains/TON/assets/TON",
+						XContract: "TON",
+						AssetId:   "TON",
+						To:        []*txinfo.BalanceChange{},
+						From: []*txinfo.BalanceChange{
+							{
+
+								Balance:   xc.NewAmountBlockchainFromUint64(2432322),
+								XAddress:  txinfo.NewAddressName(xc.TON, "0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5"),
+								AddressId: "0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5",
+							},
+						},
+						Event: txinfo.NewEventFromIndex(0, txinfo.MovementVariantFee),
+					},
+				},
+				Confirmations: 168,
+				Error:         &bouncedErr,
+			},
+		},
+		{
+			desc: "get_highload_batch_tx",
+			hash: "ce2c0eeb0dd69d5a5d108869fa29f9943055e42c127b67256aeb6eddb688024f",
+			resp: []string{
+				// get chain info
+				`{"last":{"workchain":-1,"shard":"8000000000000000","seqno":21082664,"root_hash":"SMroEPt+MFtk85CpRUmyeogmrVDmHa6WbJm9Wz9OmMA=","file_hash":"TRya8nOmld4LaZVKlJC3Kq1apB4a4HmVYOxewte6a/k=","global_id":-3,"version":0,"after_merge":false,"before_split":false,"after_split":false,"want_merge":true,"want_split":false,"key_block":false,"vert_seqno_incr":false,"flags":1,"gen_utime":"1721068768","start_lt":"23694519000000","end_lt":"23694519000004","validator_list_hash_short":197321932,"gen_catchain_seqno":288848,"min_ref_mc_seqno":21082657,"prev_key_block_seqno":21082243,"vert_seqno":0,"master_ref_seqno":0,"rand_seed":"dN8oWdq3z/UfiufHNqwjHAA2J7fDhuqsZjz4ZsDKMMo=","created_by":"EIs7uyFACFwaIqs9Jw3Rm0LmtoEkV6GkIr/y9gnE/hk=","tx_count":3,"masterchain_block_ref":{"workchain":-1,"shard":"8000000000000000","seqno":21082664},"prev_blocks":[{"workchain":-1,"shard":"8000000000000000","seqno":21082663}]},"first":{"workchain":-1,"shard":"8000000000000000","seqno":3,"root_hash":"N1MtB3dREOndUsEfXY6U7EUmgG7KTawIjoeM69iLuCc=","file_hash":"MaP4koxBb5lcYfR9ubrBRUCyE7SEeakagA9Tg7aKK+A=","global_id":-3,"version":0,"after_merge":false,"before_split":false,"after_split":false,"want_merge":false,"want_split":false,"key_block":false,"vert_seqno_incr":false,"flags":1,"gen_utime":"1653238862","start_lt":"3000000","end_lt":"3000004","validator_list_hash_short":1253667756,"gen_catchain_seqno":0,"min_ref_mc_seqno":1,"prev_key_block_seqno":0,"vert_seqno":0,"master_ref_seqno":0,"rand_seed":"VyIDzkSrtLP+ji2OzWNhBmDZuPHdCDdeT8B/bhiwFuE=","created_by":"Bu4LLZ5LqTqQFFgS1P0DR4Fay0jcqNu1N34tZ9TFjMo=","tx_count":3,"masterchain_block_ref":{"workchain":-1,"shard":"8000000000000000","seqno":3},"prev_blocks":[{"workchain":-1,"shard":"8000000000000000","seqno":2}]}}`,
+				// get transactions
+				`{"transactions":[{"account":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","hash":"1GSVPzv9hwWLDztTHLzhG3eSE4dkLn6rzN0g5v9ugMs=","lt":"23694319000001","now":1721068303,"orig_status":"active","end_status":"active","total_fees":"1500000","description":{"type":"ord","aborted":false,"destroyed":false,"credit_first":true,"compute_ph":{"mode":0,"type":"vm","success":true,"exit_code":0}},"block_ref":{"workchain":0,"shard":"2000000000000000","seqno":22626042},"in_msg":{"hash":"ziwO6w3WnVpdEIhp+in5lDBV5CwSe2clautu3baIAk8=","source":null,"destination":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","value":null,"opcode":null,"bounce":null,"bounced":null,"message_content":{"hash":"ziwO6w3WnVpdEIhp+in5lDBV5CwSe2clautu3baIAk8=","body":"","decoded":null}},"out_msgs":[{"hash":"8s5e0G1ZzKq9l0Q2Fh1x6j3X4rM7aWbYtV0nC2dPiE4=","source":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","destination":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","value":"300000000","opcode":"0xae42e5a4","bounce":false,"bounced":false,"message_content":{"hash":"8s5e0G1ZzKq9l0Q2Fh1x6j3X4rM7aWbYtV0nC2dPiE4=","body":"","decoded":null}}],"mc_block_seqno":21082496}],"address_book":{"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A":{"user_friendly":"0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5"},"0:A1A2DCA202D48FAACD016CF0FCE75332B3DFCB74C54CECDF9AB6C158BD431CE3":{"user_friendly":"0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm"},"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1":{"user_friendly":"kQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9Wse1G"}}}`,
+				// highload wallet processes the batch it sent to itself
+				`{"transactions":[{"account":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","hash":"Vj3b8qG5y0QwS7m2cX1eL4kZr9nT6pH0dA8uF3sJ2oY=","lt":"23694319000001","now":1721068303,"orig_status":"active","end_status":"active","total_fees":"900000","description":{"type":"ord","aborted":false,"destroyed":false,"credit_first":true,"compute_ph":{"mode":0,"type":"vm","success":true,"exit_code":0}},"block_ref":{"workchain":0,"shard":"2000000000000000","seqno":22626042},"in_msg":{"hash":"8s5e0G1ZzKq9l0Q2Fh1x6j3X4rM7aWbYtV0nC2dPiE4=","source":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","destination":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","value":"300000000","opcode":"0xae42e5a4","bounce":false,"bounced":false,"message_content":{"hash":"8s5e0G1ZzKq9l0Q2Fh1x6j3X4rM7aWbYtV0nC2dPiE4=","body":"","decoded":null}},"out_msgs":[{"hash":"Jq2kX8vR3n5pT0wL7yB1cZ4mH6sD9fA2gE0uN8iV5tQ=","source":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","destination":"0:A1A2DCA202D48FAACD016CF0FCE75332B3DFCB74C54CECDF9AB6C158BD431CE3","value":"100000000","opcode":null,"bounce":false,"bounced":false,"message_content":{"hash":"Jq2kX8vR3n5pT0wL7yB1cZ4mH6sD9fA2gE0uN8iV5tQ=","body":"","decoded":null}},{"hash":"Lr7nC2xP5vK9mQ1tB4zH8wE3jS6dF0aG7yU2oI5eR1M=","source":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","destination":"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1","value":"200000000","opcode":null,"bounce":false,"bounced":false,"message_content":{"hash":"Lr7nC2xP5vK9mQ1tB4zH8wE3jS6dF0aG7yU2oI5eR1M=","body":"","decoded":null}}],"mc_block_seqno":21082496}],"address_book":{"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A":{"user_friendly":"0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5"},"0:A1A2DCA202D48FAACD016CF0FCE75332B3DFCB74C54CECDF9AB6C158BD431CE3":{"user_friendly":"0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm"},"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1":{"user_friendly":"kQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9Wse1G"}}}`,
+			},
+			tx: &txinfo.TxInfo{
+				Name:   "chains/TON/transactions/ce2c0eeb0dd69d5a5d108869fa29f9943055e42c127b67256aeb6eddb688024f",
+				Hash:   "ce2c0eeb0dd69d5a5d108869fa29f9943055e42c127b67256aeb6eddb688024f",
+				XChain: xc.TON,
+				State:  txinfo.Succeeded,
+				Final:  true,
+				Block: &txinfo.Block{
+					Chain:  "TON",
+					Height: xc.NewAmountBlockchainFromUint64(21082496),
+					Hash:   "0:2000000000000000:22626042",
+					Time:   testtypes.FromTimeStamp("2024-07-15T18:31:43Z"),
+				},
+				Movements: []*txinfo.Movement{
+					// the messages sent by the internal batch, not the batch itself
+					{
+						XAsset:    "chains/TON/assets/TON",
+						XContract: "TON",
+						AssetId:   "TON",
+						From: []*txinfo.BalanceChange{
+							{
+								Balance:   xc.NewAmountBlockchainFromUint64(100000000),
+								XAddress:  txinfo.NewAddressName(xc.TON, "0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5"),
+								AddressId: "0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5",
+							},
+						},
+						To: []*txinfo.BalanceChange{
+							{
+								Balance:   xc.NewAmountBlockchainFromUint64(100000000),
+								XAddress:  txinfo.NewAddressName(xc.TON, "0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm"),
+								AddressId: "0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm",
+							},
+						},
+					},
+					{
+						XAsset:    "chains/TON/assets/TON",
+						XContract: "TON",
+						AssetId:   "TON",
+						From: []*txinfo.BalanceChange{
+							{
+								Balance:   xc.NewAmountBlockchainFromUint64(200000000),
+								XAddress:  txinfo.NewAddressName(xc.TON, "0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5"),
+								AddressId: "0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5",
+							},
+						},
+						To: []*txinfo.BalanceChange{
+							{
+								Balance:   xc.NewAmountBlockchainFromUint64(200000000),
+								XAddress:  txinfo.NewAddressName(xc.TON, "kQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9Wse1G"),
+								AddressId: "kQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9Wse1G",
+							},
+						},
+					},
+					// fee, including processing the internal batch
+					{
+						XAsset:    "chains/TON/assets/TON",
+						XContract: "TON",
+						AssetId:   "TON",
+						To:        []*txinfo.BalanceChange{},
+						From: []*txinfo.BalanceChange{
+							{
+								Balance:   xc.NewAmountBlockchainFromUint64(2400000),
+								XAddress:  txinfo.NewAddressName(xc.TON, "0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5"),
+								AddressId: "0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5",
+							},
+						},
+						Event: txinfo.NewEventFromIndex(0, txinfo.MovementVariantFee),
+					},
+				},
+				Confirmations: 168,
+			},
+		},
 		{
 			desc: "reports_error",
 			resp: []string{
@@ -390,3 +529,233 @@ func TestNewBlockId(t *testing.T) {
 	id = ton.NewBlockId(0, "2000000000000000", 10)
 	require.Equal(t, "0:2000000000000000:10", id)
 }
+
+func TestFetchHighloadTxInput(t *testing.T) {
+	chain := xc.NewChainConfig(xc.TON).WithDecimals(9)
+	publicKey := fromHex("c1172b7926116d2a396bd7d69b9880cc0657e8ba2db9f62b4c210c518321c8b1")
+	highloadAddr, err := tonaddress.AddressFromPublicKey(publicKey, tonaddress.AddressFormatHighloadV3)
+	require.NoError(t, err)
+	stateInit, err := tonaddress.GetStateInit(publicKey, tonaddress.AddressFormatHighloadV3)
+	require.NoError(t, err)
+	highloadCode := base64.StdEncoding.EncodeToString(stateInit.Code.ToBOC())
+
+	vectors := []struct {
+		desc      string
+		from      xc.Address
+		publicKey []byte
+		resp      []string
+		status    api.AccountStatus
+	}{
+		{
+			desc: "deployed",
+			from: xc.Address(highloadAddr.String()),
+			resp: []string{
+				// get account
+				fmt.Sprintf(`{"balance":"587833680","code":"%s","status":"active"}`, highloadCode),
+				// first query id is already processed
+				`{"gas_used":1005,"exit_code":0,"stack":[{"type":"num","value":"-0x1"}]}`,
+				`{"gas_used":1005,"exit_code":0,"stack":[{"type":"num","value":"0x0"}]}`,
+				// get public-key
+				`{"gas_used":549,"exit_code":11,"stack":[]}`,
+			},
+			status: api.Active,
+		},
+		{
+			desc:      "not_deployed",
+			from:      xc.Address(highloadAddr.String()),
+			publicKey: publicKey,
+			resp: []string{
+				// get account
+				`{"balance":"587833680","status":"uninit"}`,
+				// get public-key
+				`{"gas_used":549,"exit_code":-14,"stack":[{"type":"num","value":"0x0"}]}`,
+			},
+			status: api.Uninit,
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.desc, func(t *testing.T) {
+			server, close := testtypes.MockHTTP(t, v.resp, 200)
+			defer close()
+			chain.URL = server.URL
+			chain.Limiter = rate.NewLimiter(rate.Inf, 1)
+
+			client, err := ton.NewClient(chain)
+			require.NoError(t, err)
+			to := xc.Address("0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm")
+			args := buildertest.MustNewTransferArgs(chain.Base(), v.from, to, xc.NewAmountBlockchainFromUint64(1), buildertest.OptionPublicKey(v.publicKey))
+
+			inputI, err := client.FetchTransferInput(context.Background(), args)
+			require.NoError(t, err)
+			input := inputI.(*ton.TxInput)
+			require.EqualValues(t, tonaddress.AddressFormatHighloadV3, input.WalletFormat)
+			require.Equal(t, v.status, input.AccountStatus)
+			require.Less(t, input.QueryId, uint32(1<<23))
+			require.Zero(t, input.Sequence)
+		})
+	}
+}
+
+func TestFetchMultiTransferInput(t *testing.T) {
+	chain := xc.NewChainConfig(xc.TON).WithDecimals(9)
+	server, close := testtypes.MockHTTP(t, []string{
+		// get account
+		`{"balance":"587833680","code":"te6cckEBAQEAcQAA3v8AIN0gggFMl7ohggEznLqxn3Gw7UTQ0x/THzHXC//jBOCk8mCDCNcYINMf0x/TH/gjE7vyY+1E0NMf0x/T/9FRMrryoVFEuvKiBPkBVBBV+RDyo/gAkyDXSpbTB9QC+wDo0QGkyMsfyx/L/8ntVBC9ba0=","data":"te6cckEBAQEAKgAAUAAAABEpqaMXwRcreSYRbSo5a9fWm5iAzAZX6LotufYrTCEMUYMhyLF+KRtN","last_transaction_lt":"23693722000001","last_transaction_hash":"mVuNwFVC4eIWjS+lIAkfinkXUQz8k1lqFZ+lQqvAZK8=","frozen_hash":null,"status":"uninit"}`,
+		// get sequence
+		`{"gas_used":549,"exit_code":0,"stack":[{"type":"num","value":"0x11"}]}`,
+		// get jetton data
+		`{"gas_used":685,"exit_code":0,"stack":[{"type":"num","value":"0xc9f2c9cd04674ede89e2be380"},{"type":"num","value":"-0x1"},{"type":"cell","value":"te6cckEBAQEAJAAAQ4AAkIGoV1JlPdxhyj449EPdlQBht2uKB2Tr/j6D/wCdMjAO5J3r"},{"type":"cell","value":"te6cckEBDAEA0AABAwDAAQIBWAIDAgEgBAUCASAICQFBv0VGpv/ht5z92GutPbh0MT3N4vsF5qdKp/NVLZYXx50TBgFBv27U+UKnhIziywZrd6ESjGof+MQ/Q4otziRhK6n/q4sDBwAMAEFpb3R4AAwAQUlPVFgBQb9SCN70b1odT53OZqswn0qFEwXxZvke952SPvWONPmiCQoBQb9dAfpePAaQHEUEbGst3Opa92T+oO7XKhDUBPIxLOskfQsALABUZXN0bmV0IHRva2VuIHRvIHRlc3QABAA5QJmctQ=="},{"type":"cell","value":"te6cckECEQEAAyMAART/APSkE/S88sgLAQIBYgIDAgLMBAUAG6D2BdqJofQB9IH0gahhAgHUBgcCASAICQDDCDHAJJfBOAB0NMDAXGwlRNfA/AM4PpA+kAx+gAxcdch+gAx+gAwc6m0AALTH4IQD4p+pVIgupUxNFnwCeCCEBeNRRlSILqWMUREA/AK4DWCEFlfB7y6k1nwC+BfBIQP8vCAAET6RDBwuvLhTYAIBIAoLAIPUAQa5D2omh9AH0gfSBqGAJpj8EIC8aijKkQXUEIPe7L7wndCVj5cWLpn5j9ABgJ0CgR5CgCfQEsZ4sA54tmZPaqQB8VA9M/+gD6QCHwAe1E0PoA+kD6QNQwUTahUirHBfLiwSjC//LiwlQ0QnBUIBNUFAPIUAT6AljPFgHPFszJIsjLARL0APQAywDJIPkAcHTIywLKB8v/ydAE+kD0BDH6ACDXScIA8uLEd4AYyMsFUAjPFnD6AhfLaxPMgMAgEgDQ4AnoIQF41FGcjLHxnLP1AH+gIizxZQBs8WJfoCUAPPFslQBcwjkXKRceJQCKgToIIJycOAoBS88uLFBMmAQPsAECPIUAT6AljPFgHPFszJ7VQC9ztRND6APpA+kDUMAjTP/oAUVGgBfpA+kBTW8cFVHNtcFQgE1QUA8hQBPoCWM8WAc8WzMkiyMsBEvQA9ADLAMn5AHB0yMsCygfL/8nQUA3HBRyx8uLDCvoAUaihggiYloBmtgihggiYloCgGKEnlxBJEDg3XwTjDSXXCwGAPEADXO1E0PoA+kD6QNQwB9M/+gD6QDBRUaFSSccF8uLBJ8L/8uLCBYIJMS0AoBa88uLDghB73ZfeyMsfFcs/UAP6AiLPFgHPFslxgBjIywUkzxZw+gLLaszJgED7AEATyFAE+gJYzxYBzxbMye1UgAHBSeaAYoYIQc2LQnMjLH1Iwyz9Y+gJQB88WUAfPFslxgBDIywUkzxZQBvoCFctqFMzJcfsAECQQIwB8wwAjwgCwjiGCENUydttwgBDIywVQCM8WUAT6AhbLahLLHxLLP8ly+wCTNWwh4gPIUAT6AljPFgHPFszJ7VSV6u3X"}]}`,
+		// get token wallet
+		`{"gas_used":549,"exit_code":0,"stack":[{"type":"num","value":"te6cckEBAQEAJAAAQ4AHpoFiodWAcl6+hRV/++eGd9rQjRT+/sD4lCewAn3q1jDw9q/m"}]}`,
+		// estimate fees
+		`{"source_fees":{"in_fwd_fee":699200,"storage_fee":3549,"gas_fee":0,"fwd_fee":0},"destination_fees":[]}`,
+	}, 200)
+	defer close()
+	chain.URL = server.URL
+	chain.Limiter = rate.NewLimiter(rate.Inf, 1)
+
+	client, err := ton.NewClient(chain)
+	require.NoError(t, err)
+
+	from := xc.Address("EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2")
+	contract := xc.ContractAddress("kQAiboDEv_qRrcEdrYdwbVLNOXBHwShFbtKGbQVJ2OKxY_Di")
+	sender, err := xcbuilder.NewSender(from, nil)
+	require.NoError(t, err)
+	receiver1, err := xcbuilder.NewReceiver("0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm", xc.NewAmountBlockchainFromUint64(1))
+	require.NoError(t, err)
+	receiver2, err := xcbuilder.NewReceiver("0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm", xc.NewAmountBlockchainFromUint64(2), xcbuilder.OptionContractAddress(contract))
+	require.NoError(t, err)
+	// the token wallet is only looked up once
+	receiver3, err := xcbuilder.NewReceiver("kQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9Wse1G", xc.NewAmountBlockchainFromUint64(3), xcbuilder.OptionContractAddress(contract))
+	require.NoError(t, err)
+	args, err := xcbuilder.NewMultiTransferArgs(chain.Base(), []*xcbuilder.Sender{sender}, []*xcbuilder.Receiver{receiver1, receiver2, receiver3})
+	require.NoError(t, err)
+
+	inputI, err := client.FetchMultiTransferInput(context.Background(), *args)
+	require.NoError(t, err)
+	input := inputI.(*ton.MultiTransferInput)
+	require.Equal(t, uint64(0x11), input.Sequence)
+	require.Equal(t, xc.NewAmountBlockchainFromUint64(10*(699200+3549)), input.EstimatedMaxFee)
+	require.Equal(t, []*ton.TokenWalletInput{
+		{
+			Contract:         contract,
+			TokenWallet:      "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM",
+			JettonWalletCode: fromHex("b5ee9c7241021101000323000114ff00f4a413f4bcf2c80b0102016202030202cc0405001ba0f605da89a1f401f481f481a8610201d40607020120080900c30831c02497c138007434c0c05c6c2544d7c0fc03383e903e900c7e800c5c75c87e800c7e800c1cea6d0000b4c7e08403e29fa954882ea54c4d167c0278208405e3514654882ea58c511100fc02b80d60841657c1ef2ea4d67c02f817c12103fcbc2000113e910c1c2ebcb853600201200a0b0083d40106b90f6a2687d007d207d206a1802698fc1080bc6a28ca9105d41083deecbef09dd0958f97162e99f98fd001809d02811e428027d012c678b00e78b6664f6aa401f1503d33ffa00fa4021f001ed44d0fa00fa40fa40d4305136a1522ac705f2e2c128c2fff2e2c254344270542013541403c85004fa0258cf1601cf16ccc922c8cb0112f400f400cb00c920f9007074c8cb02ca07cbffc9d004fa40f40431fa0020d749c200f2e2c4778018c8cb055008cf1670fa0217cb6b13cc80c0201200d0e009e8210178d4519c8cb1f19cb3f5007fa0222cf165006cf1625fa025003cf16c95005cc2391729171e25008a813a08209c9c380a014bcf2e2c504c98040fb001023c85004fa0258cf1601cf16ccc9ed5402f73b51343e803e903e90350c0234cffe80145468017e903e9014d6f1c1551cdb5c150804d50500f214013e809633c58073c5b33248b232c044bd003d0032c0327e401c1d3232c0b281f2fff274140371c1472c7cb8b0c2be80146a2860822625a019ad822860822625a028062849e5c412440e0dd7c138c34975c2c0600f1000d73b51343e803e903e90350c01f4cffe803e900c145468549271c17cb8b049f0bffcb8b08160824c4b402805af3cb8b0e0841ef765f7b232c7c572cfd400fe8088b3c58073c5b25c60063232c14933c59c3e80b2dab33260103ec01004f214013e809633c58073c5b3327b552000705279a018a182107362d09cc8cb1f5230cb3f58fa025007cf165007cf16c9718010c8cb0524cf165006fa0215cb6a14ccc971fb0010241023007cc30023c200b08e218210d53276db708010c8cb055008cf165004fa0216cb6a12cb1f12cb3fc972fb0093356c21e203c85004fa0258cf1601cf16ccc9ed5495eaedd7"),
+		},
+	}, input.TokenWallets)
+}
+
+func TestFetchJettonBurnInput(t *testing.T) {
+	chain := xc.NewChainConfig(xc.TON).WithDecimals(9)
+	server, close := testtypes.MockHTTP(t, []string{
+		// get account
+		`{"balance":"587833680","code":"te6cckEBAQEAcQAA3v8AIN0gggFMl7ohggEznLqxn3Gw7UTQ0x/THzHXC//jBOCk8mCDCNcYINMf0x/TH/gjE7vyY+1E0NMf0x/T/9FRMrryoVFEuvKiBPkBVBBV+RDyo/gAkyDXSpbTB9QC+wDo0QGkyMsfyx/L/8ntVBC9ba0=","data":"te6cckEBAQEAKgAAUAAAABEpqaMXwRcreSYRbSo5a9fWm5iAzAZX6LotufYrTCEMUYMhyLF+KRtN","last_transaction_lt":"23693722000001","last_transaction_hash":"mVuNwFVC4eIWjS+lIAkfinkXUQz8k1lqFZ+lQqvAZK8=","frozen_hash":null,"status":"uninit"}`,
+		// get sequence
+		`{"gas_used":549,"exit_code":0,"stack":[{"type":"num","value":"0x11"}]}`,
+		// get jetton data
+		`{"gas_used":685,"exit_code":0,"stack":[{"type":"num","value":"0xc9f2c9cd04674ede89e2be380"},{"type":"num","value":"-0x1"},{"type":"cell","value":"te6cckEBAQEAJAAAQ4AAkIGoV1JlPdxhyj449EPdlQBht2uKB2Tr/j6D/wCdMjAO5J3r"},{"type":"cell","value":"te6cckEBDAEA0AABAwDAAQIBWAIDAgEgBAUCASAICQFBv0VGpv/ht5z92GutPbh0MT3N4vsF5qdKp/NVLZYXx50TBgFBv27U+UKnhIziywZrd6ESjGof+MQ/Q4otziRhK6n/q4sDBwAMAEFpb3R4AAwAQUlPVFgBQb9SCN70b1odT53OZqswn0qFEwXxZvke952SPvWONPmiCQoBQb9dAfpePAaQHEUEbGst3Opa92T+oO7XKhDUBPIxLOskfQsALABUZXN0bmV0IHRva2VuIHRvIHRlc3QABAA5QJmctQ=="},{"type":"cell","value":"te6cckECEQEAAyMAART/APSkE/S88sgLAQIBYgIDAgLMBAUAG6D2BdqJofQB9IH0gahhAgHUBgcCASAICQDDCDHAJJfBOAB0NMDAXGwlRNfA/AM4PpA+kAx+gAxcdch+gAx+gAwc6m0AALTH4IQD4p+pVIgupUxNFnwCeCCEBeNRRlSILqWMUREA/AK4DWCEFlfB7y6k1nwC+BfBIQP8vCAAET6RDBwuvLhTYAIBIAoLAIPUAQa5D2omh9AH0gfSBqGAJpj8EIC8aijKkQXUEIPe7L7wndCVj5cWLpn5j9ABgJ0CgR5CgCfQEsZ4sA54tmZPaqQB8VA9M/+gD6QCHwAe1E0PoA+kD6QNQwUTahUirHBfLiwSjC//LiwlQ0QnBUIBNUFAPIUAT6AljPFgHPFszJIsjLARL0APQAywDJIPkAcHTIywLKB8v/ydAE+kD0BDH6ACDXScIA8uLEd4AYyMsFUAjPFnD6AhfLaxPMgMAgEgDQ4AnoIQF41FGcjLHxnLP1AH+gIizxZQBs8WJfoCUAPPFslQBcwjkXKRceJQCKgToIIJycOAoBS88uLFBMmAQPsAECPIUAT6AljPFgHPFszJ7VQC9ztRND6APpA+kDUMAjTP/oAUVGgBfpA+kBTW8cFVHNtcFQgE1QUA8hQBPoCWM8WAc8WzMkiyMsBEvQA9ADLAMn5AHB0yMsCygfL/8nQUA3HBRyx8uLDCvoAUaihggiYloBmtgihggiYloCgGKEnlxBJEDg3XwTjDSXXCwGAPEADXO1E0PoA+kD6QNQwB9M/+gD6QDBRUaFSSccF8uLBJ8L/8uLCBYIJMS0AoBa88uLDghB73ZfeyMsfFcs/UAP6AiLPFgHPFslxgBjIywUkzxZw+gLLaszJgED7AEATyFAE+gJYzxYBzxbMye1UgAHBSeaAYoYIQc2LQnMjLH1Iwyz9Y+gJQB88WUAfPFslxgBDIywUkzxZQBvoCFctqFMzJcfsAECQQIwB8wwAjwgCwjiGCENUydttwgBDIywVQCM8WUAT6AhbLahLLHxLLP8ly+wCTNWwh4gPIUAT6AljPFgHPFszJ7VSV6u3X"}]}`,
+		// get token wallet
+		`{"gas_used":549,"exit_code":0,"stack":[{"type":"num","value":"te6cckEBAQEAJAAAQ4AHpoFiodWAcl6+hRV/++eGd9rQjRT+/sD4lCewAn3q1jDw9q/m"}]}`,
+		// estimate fees
+		`{"source_fees":{"in_fwd_fee":699200,"storage_fee":3549,"gas_fee":0,"fwd_fee":0},"destination_fees":[]}`,
+	}, 200)
+	defer close()
+	chain.URL = server.URL
+	chain.Limiter = rate.NewLimiter(rate.Inf, 1)
+
+	client, err := ton.NewClient(chain)
+	require.NoError(t, err)
+	builder, err := ton.NewTxBuilder(chain.Base())
+	require.NoError(t, err)
+
+	args := ton.JettonBurnArgs{
+		From:      xc.Address("EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2"),
+		PublicKey: fromHex("0xc1172b7926116d2a396bd7d69b9880cc0657e8ba2db9f62b4c210c518321c8b1"),
+		Contract:  xc.ContractAddress("kQAiboDEv_qRrcEdrYdwbVLNOXBHwShFbtKGbQVJ2OKxY_Di"),
+		Amount:    xc.NewAmountBlockchainFromUint64(22000000),
+	}
+	input, err := client.FetchJettonBurnInput(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, api.Uninit, input.AccountStatus)
+	require.Equal(t, uint64(0x11), input.Sequence)
+	require.EqualValues(t, "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM", input.TokenWallet)
+	require.Equal(t, fromHex("b5ee9c7241021101000323000114ff00f4a413f4bcf2c80b0102016202030202cc0405001ba0f605da89a1f401f481f481a8610201d40607020120080900c30831c02497c138007434c0c05c6c2544d7c0fc03383e903e900c7e800c5c75c87e800c7e800c1cea6d0000b4c7e08403e29fa954882ea54c4d167c0278208405e3514654882ea58c511100fc02b80d60841657c1ef2ea4d67c02f817c12103fcbc2000113e910c1c2ebcb853600201200a0b0083d40106b90f6a2687d007d207d206a1802698fc1080bc6a28ca9105d41083deecbef09dd0958f97162e99f98fd001809d02811e428027d012c678b00e78b6664f6aa401f1503d33ffa00fa4021f001ed44d0fa00fa40fa40d4305136a1522ac705f2e2c128c2fff2e2c254344270542013541403c85004fa0258cf1601cf16ccc922c8cb0112f400f400cb00c920f9007074c8cb02ca07cbffc9d004fa40f40431fa0020d749c200f2e2c4778018c8cb055008cf1670fa0217cb6b13cc80c0201200d0e009e8210178d4519c8cb1f19cb3f5007fa0222cf165006cf1625fa025003cf16c95005cc2391729171e25008a813a08209c9c380a014bcf2e2c504c98040fb001023c85004fa0258cf1601cf16ccc9ed5402f73b51343e803e903e90350c0234cffe80145468017e903e9014d6f1c1551cdb5c150804d50500f214013e809633c58073c5b33248b232c044bd003d0032c0327e401c1d3232c0b281f2fff274140371c1472c7cb8b0c2be80146a2860822625a019ad822860822625a028062849e5c412440e0dd7c138c34975c2c0600f1000d73b51343e803e903e90350c01f4cffe803e900c145468549271c17cb8b049f0bffcb8b08160824c4b402805af3cb8b0e0841ef765f7b232c7c572cfd400fe8088b3c58073c5b25c60063232c14933c59c3e80b2dab33260103ec01004f214013e809633c58073c5b3327b552000705279a018a182107362d09cc8cb1f5230cb3f58fa025007cf165007cf16c9718010c8cb0524cf165006fa0215cb6a14ccc971fb0010241023007cc30023c200b08e218210d53276db708010c8cb055008cf165004fa0216cb6a12cb1f12cb3fc972fb0093356c21e203c85004fa0258cf1601cf16ccc9ed5495eaedd7"), input.JettonWalletCode)
+	require.Equal(t, xc.NewAmountBlockchainFromUint64(10*(699200+3549)), input.EstimatedMaxFee)
+
+	// the fetched input is enough to build the burn, sent to the sender's token wallet
+	tx, err := builder.JettonBurn(args, input)
+	require.NoError(t, err)
+	// the sender's wallet is deployed by the same message
+	require.NotNil(t, tx.(*tontx.Tx).ExternalMessage.StateInit)
+	payload := tx.(*tontx.Tx).CellBuilder.EndCell().BeginParse()
+	payload.MustLoadUInt(32 + 32 + 32 + 8)
+	msg := &tlb.InternalMessage{}
+	require.NoError(t, tlb.LoadFromCell(msg, payload.MustLoadRef()))
+	require.Equal(t, "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM", msg.DstAddr.String())
+	// up to 0.2 TON is attached for the burn, with the excess returned to the sender
+	require.Equal(t, "200000000", msg.Amount.Nano().String())
+
+	burn := &jetton.BurnPayload{}
+	require.NoError(t, tlb.LoadFromCell(burn, msg.Body.BeginParse()))
+	require.EqualValues(t, 22000000, burn.Amount.Nano().Uint64())
+	require.Equal(t, "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2", burn.ResponseDestination.String())
+}
+
+func TestFetchTxInfoMessageLookups(t *testing.T) {
+	txHash := "ce2c0eeb0dd69d5a5d108869fa29f9943055e42c127b67256aeb6eddb688024f"
+	wallet := "0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A"
+	receiver := "0:A1A2DCA202D48FAACD016CF0FCE75332B3DFCB74C54CECDF9AB6C158BD431CE3"
+	tokenWallet := "0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1"
+	addressBook := `"address_book":{"` + wallet + `":{"user_friendly":"0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5"},"` + receiver + `":{"user_friendly":"0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm"},"` + tokenWallet + `":{"user_friendly":"kQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9Wse1G"}}`
+	outMsg := func(hash string, dest string, value string, opcode string, bounce bool) string {
+		return fmt.Sprintf(`{"hash":"%s","source":"%s","destination":"%s","value":"%s","opcode":"%s","bounce":%t,"bounced":false,"message_content":{"hash":"%s","body":""}}`, hash, wallet, dest, value, opcode, bounce, hash)
+	}
+	processedTx := func(account string, inMsgHash string, aborted bool, exitCode int) string {
+		return fmt.Sprintf(`{"account":"%s","hash":"%s","lt":"23694320000001","now":1721068306,"total_fees":"1000","description":{"type":"ord","aborted":%t,"compute_ph":{"success":%t,"exit_code":%d}},"block_ref":{"workchain":0,"shard":"2000000000000000","seqno":22626043},"in_msg":{"hash":"%s","source":"%s","destination":"%s","message_content":{"hash":"%s","body":""}},"out_msgs":[]}`, account, inMsgHash+"-tx", aborted, !aborted, exitCode, inMsgHash, wallet, account, inMsgHash)
+	}
+	walletTx := `{"account":"` + wallet + `","hash":"1GSVPzv9hwWLDztTHLzhG3eSE4dkLn6rzN0g5v9ugMs=","lt":"23694319000001","now":1721068303,"total_fees":"1500000","description":{"type":"ord","aborted":false,"compute_ph":{"success":true,"exit_code":0}},"block_ref":{"workchain":0,"shard":"2000000000000000","seqno":22626042},"in_msg":{"hash":"ziwO6w3WnVpdEIhp+in5lDBV5CwSe2clautu3baIAk8=","destination":"` + wallet + `","message_content":{"hash":"ziwO6w3WnVpdEIhp+in5lDBV5CwSe2clautu3baIAk8=","body":""}},"out_msgs":[` +
+		// a plain transfer to itself is not a highload batch, and is not followed
+		outMsg("self", wallet, "1000", "0x00000000", false) + "," +
+		outMsg("receiver", receiver, "2000", "0x00000000", true) + "," +
+		outMsg("token-wallet", tokenWallet, "3000", "0x0f8a7ea5", true) +
+		`],"mc_block_seqno":21082496}`
+
+	lookups := [][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/masterchainInfo":
+			_, _ = w.Write([]byte(`{"last":{"workchain":-1,"shard":"8000000000000000","seqno":21082664,"root_hash":"SMroEPt+MFtk85CpRUmyeogmrVDmHa6WbJm9Wz9OmMA=","file_hash":"TRya8nOmld4LaZVKlJC3Kq1apB4a4HmVYOxewte6a/k=","global_id":-3,"version":0,"after_merge":false,"before_split":false,"after_split":false,"want_merge":true,"want_split":false,"key_block":false,"vert_seqno_incr":false,"flags":1,"gen_utime":"1721068768","start_lt":"23694519000000","end_lt":"23694519000004","validator_list_hash_short":197321932,"gen_catchain_seqno":288848,"min_ref_mc_seqno":21082657,"prev_key_block_seqno":21082243,"vert_seqno":0,"master_ref_seqno":0,"rand_seed":"dN8oWdq3z/UfiufHNqwjHAA2J7fDhuqsZjz4ZsDKMMo=","created_by":"EIs7uyFACFwaIqs9Jw3Rm0LmtoEkV6GkIr/y9gnE/hk=","tx_count":3,"masterchain_block_ref":{"workchain":-1,"shard":"8000000000000000","seqno":21082664},"prev_blocks":[{"workchain":-1,"shard":"8000000000000000","seqno":21082663}]},"first":{"workchain":-1,"shard":"8000000000000000","seqno":3,"root_hash":"N1MtB3dREOndUsEfXY6U7EUmgG7KTawIjoeM69iLuCc=","file_hash":"MaP4koxBb5lcYfR9ubrBRUCyE7SEeakagA9Tg7aKK+A=","global_id":-3,"version":0,"after_merge":false,"before_split":false,"after_split":false,"want_merge":false,"want_split":false,"key_block":false,"vert_seqno_incr":false,"flags":1,"gen_utime":"1653238862","start_lt":"3000000","end_lt":"3000004","validator_list_hash_short":1253667756,"gen_catchain_seqno":0,"min_ref_mc_seqno":1,"prev_key_block_seqno":0,"vert_seqno":0,"master_ref_seqno":0,"rand_seed":"VyIDzkSrtLP+ji2OzWNhBmDZuPHdCDdeT8B/bhiwFuE=","created_by":"Bu4LLZ5LqTqQFFgS1P0DR4Fay0jcqNu1N34tZ9TFjMo=","tx_count":3,"masterchain_block_ref":{"workchain":-1,"shard":"8000000000000000","seqno":3},"prev_blocks":[{"workchain":-1,"shard":"8000000000000000","seqno":2}]}}`))
+		case "/api/v3/transactionsByMessage":
+			msgHashes := r.URL.Query()["msg_hash"]
+			if len(msgHashes) == 1 && msgHashes[0] == txHash {
+				_, _ = w.Write([]byte(`{"transactions":[` + walletTx + `],` + addressBook + `}`))
+				return
+			}
+			lookups = append(lookups, msgHashes)
+			_, _ = w.Write([]byte(`{"transactions":[` + processedTx(receiver, "receiver", false, 0) + `,` + processedTx(tokenWallet, "token-wallet", true, 709) + `],"address_book":{}}`))
+		default:
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	chain := xc.NewChainConfig(xc.TON).WithDecimals(9).WithUrl(server.URL)
+	chain.Limiter = rate.NewLimiter(rate.Inf, 1)
+	client, err := ton.NewClient(chain)
+	require.NoError(t, err)
+
+	info, err := client.FetchTxInfo(context.Background(), txinfo.NewArgs(xc.TxHash(txHash)))
+	require.NoError(t, err)
+	// the bounceable messages are checked together
+	require.Equal(t, [][]string{{"receiver", "token-wallet"}}, lookups)
+	require.NotNil(t, info.Error)
+	require.Contains(t, *info.Error, "message to kQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9Wse1G bounced (exit code 709)")
+
+	transferred := []string{}
+	for _, movement := range info.Movements {
+		for _, to := range movement.To {
+			transferred = append(transferred, to.Balance.String())
+		}
+	}
+	require.Equal(t, []string{"1000", "2000"}, transferred)
+}
@@ -16,7 +16,9 @@ import (
 type Tx struct {
 	CellBuilder     *cell.Builder
 	ExternalMessage *tlb.ExternalMessage
-	signatures      []xc.TxSignature
+	// Highload wallets expect the signed payload as a reference rather than inline
+	Highload   bool
+	signatures []xc.TxSignature
 }
 
 func NewTx(fromAddr *address.Address, cellBuilder *cell.Builder, stateInitMaybe *tlb.StateInit) *Tx {
@@ -33,6 +35,13 @@ func NewTx(fromAddr *address.Address, cellBuilder *cell.Builder, stateInitMaybe
 	}
 }
 
+// NewHighloadTx creates a tx for a highload v3 wallet
+func NewHighloadTx(fromAddr *address.Address, cellBuilder *cell.Builder, stateInitMaybe *tlb.StateInit) *Tx {
+	tx := NewTx(fromAddr, cellBuilder, stateInitMaybe)
+	tx.Highload = true
+	return tx
+}
+
 var _ xc.Tx = &Tx{}
 
 func (tx Tx) Hash() xc.TxHash {
@@ -71,8 +80,13 @@ func (tx *Tx) SetSignatures(sigs ...*xc.SignatureResponse) error {
 	for i, sig := range sigs {
 		tx.signatures[i] = sig.Signature
 	}
-	msg := cell.BeginCell().MustStoreSlice(tx.signatures[0], 512).MustStoreBuilder(tx.CellBuilder).EndCell()
-	tx.ExternalMessage.Body = msg
+	body := cell.BeginCell().MustStoreSlice(tx.signatures[0], 512)
+	if tx.Highload {
+		body.MustStoreRef(tx.CellBuilder.EndCell())
+	} else {
+		body.MustStoreBuilder(tx.CellBuilder)
+	}
+	tx.ExternalMessage.Body = body.EndCell()
 	return nil
 }
 
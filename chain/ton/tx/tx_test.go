@@ -2,12 +2,21 @@ package tx_test
 
 import (
 	"encoding/hex"
+	"fmt"
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/builder/buildertest"
 	"github.com/cordialsys/crosschain/chain/ton"
+	tonaddress "github.com/cordialsys/crosschain/chain/ton/address"
+	"github.com/cordialsys/crosschain/chain/ton/api"
+	tontx "github.com/cordialsys/crosschain/chain/ton/tx"
 	"github.com/stretchr/testify/require"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
+	"github.com/xssnick/tonutils-go/ton/jetton"
+	"github.com/xssnick/tonutils-go/tvm/cell"
 )
 
 func TestNativeTx(t *testing.T) {
@@ -113,3 +122,186 @@ func TestTokenTx(t *testing.T) {
 		hex.EncodeToString(bz))
 
 }
+
+func newMultiTransferArgs(t *testing.T, chain *xc.ChainBaseConfig, from xc.Address, pubKey []byte, count int) xcbuilder.MultiTransferArgs {
+	sender, err := xcbuilder.NewSender(from, pubKey)
+	require.NoError(t, err)
+	receivers := []*xcbuilder.Receiver{}
+	for i := 0; i < count; i++ {
+		receiver, err := xcbuilder.NewReceiver("0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm", xc.NewAmountBlockchainFromUint64(uint64(i+1)))
+		require.NoError(t, err)
+		receivers = append(receivers, receiver)
+	}
+	args, err := xcbuilder.NewMultiTransferArgs(chain, []*xcbuilder.Sender{sender}, receivers)
+	require.NoError(t, err)
+	return *args
+}
+
+// countActions counts the messages sent by a highload action list, following nested batches
+func countActions(t *testing.T, list *cell.Slice, walletAddr *address.Address) int {
+	count := 0
+	for list.RefsNum() > 0 {
+		prev := list.MustLoadRef()
+		require.EqualValues(t, ton.ActionSendMsgOp, list.MustLoadUInt(32))
+		list.MustLoadUInt(8)
+		action := &tlb.InternalMessage{}
+		require.NoError(t, tlb.LoadFromCell(action, list.MustLoadRef()))
+		if action.DstAddr.String() == walletAddr.String() {
+			nested := action.Body.BeginParse()
+			require.EqualValues(t, ton.HighloadInternalTransferOp, nested.MustLoadUInt(32))
+			nested.MustLoadUInt(64)
+			count += countActions(t, nested.MustLoadRef(), walletAddr)
+		} else {
+			count++
+		}
+		list = prev
+	}
+	return count
+}
+
+func TestHighloadMultiTransferTx(t *testing.T) {
+	chain := xc.NewChainConfig(xc.TON).WithDecimals(9)
+	builder, err := ton.NewTxBuilder(chain.Base())
+	require.NoError(t, err)
+	pubKey, _ := hex.DecodeString("c1172b7926116d2a396bd7d69b9880cc0657e8ba2db9f62b4c210c518321c8b1")
+	walletAddr, err := tonaddress.AddressFromPublicKey(pubKey, tonaddress.AddressFormatHighloadV3)
+	require.NoError(t, err)
+
+	for _, count := range []int{1, 5, 300} {
+		t.Run(fmt.Sprintf("%d_messages", count), func(t *testing.T) {
+			input := ton.NewMultiTransferInput()
+			input.AccountStatus = api.Active
+			input.WalletFormat = tonaddress.AddressFormatHighloadV3
+			input.QueryId = 1234
+			input.Timestamp = 1721068303
+			args := newMultiTransferArgs(t, chain.Base(), xc.Address(walletAddr.String()), pubKey, count)
+
+			tx, err := builder.MultiTransfer(args, input)
+			require.NoError(t, err)
+			require.Nil(t, tx.(*tontx.Tx).ExternalMessage.StateInit)
+			hashes, err := tx.Sighashes()
+			require.NoError(t, err)
+			require.Len(t, hashes, 1)
+
+			sig := make([]byte, 64)
+			sig[0] = 1
+			err = tx.SetSignatures(&xc.SignatureResponse{Signature: sig})
+			require.NoError(t, err)
+			_, err = tx.Serialize()
+			require.NoError(t, err)
+
+			// highload wallets expect the signature followed by the signed payload as a reference
+			body := tx.(*tontx.Tx).ExternalMessage.Body.BeginParse()
+			require.Equal(t, sig, body.MustLoadSlice(512))
+			payloadCell := body.MustLoadRef()
+			require.Equal(t, hashes[0].Payload, payloadCell.MustToCell().Hash())
+
+			require.EqualValues(t, tonaddress.HighloadSubwalletId, payloadCell.MustLoadUInt(32))
+			msg := &tlb.InternalMessage{}
+			require.NoError(t, tlb.LoadFromCell(msg, payloadCell.MustLoadRef()))
+			payloadCell.MustLoadUInt(8)
+			require.EqualValues(t, 1234, payloadCell.MustLoadUInt(23))
+			require.EqualValues(t, 1721068303-30, payloadCell.MustLoadUInt(64))
+			require.EqualValues(t, tonaddress.HighloadMessageTtl, payloadCell.MustLoadUInt(22))
+
+			if count == 1 {
+				// a single message is sent directly
+				require.Equal(t, "EQChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc4yQp", msg.DstAddr.String())
+				return
+			}
+			// batches are sent by the wallet to itself
+			require.Equal(t, walletAddr.String(), msg.DstAddr.String())
+			msgBody := msg.Body.BeginParse()
+			require.EqualValues(t, ton.HighloadInternalTransferOp, msgBody.MustLoadUInt(32))
+			require.EqualValues(t, 1234, msgBody.MustLoadUInt(64))
+			require.Equal(t, count, countActions(t, msgBody.MustLoadRef(), walletAddr))
+		})
+	}
+}
+
+func TestMultiTransferV3MaxMessages(t *testing.T) {
+	chain := xc.NewChainConfig(xc.TON).WithDecimals(9)
+	builder, err := ton.NewTxBuilder(chain.Base())
+	require.NoError(t, err)
+
+	input := ton.NewMultiTransferInput()
+	input.AccountStatus = api.Active
+	from := xc.Address("EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2")
+
+	_, err = builder.MultiTransfer(newMultiTransferArgs(t, chain.Base(), from, nil, 4), input)
+	require.NoError(t, err)
+	_, err = builder.MultiTransfer(newMultiTransferArgs(t, chain.Base(), from, nil, 5), input)
+	require.ErrorContains(t, err, "max 4 messages")
+}
+
+func TestHighloadDeployTx(t *testing.T) {
+	chain := xc.NewChainConfig(xc.TON).WithDecimals(9)
+	builder, err := ton.NewTxBuilder(chain.Base())
+	require.NoError(t, err)
+	pubKey, _ := hex.DecodeString("c1172b7926116d2a396bd7d69b9880cc0657e8ba2db9f62b4c210c518321c8b1")
+	walletAddr, err := tonaddress.AddressFromPublicKey(pubKey, tonaddress.AddressFormatHighloadV3)
+	require.NoError(t, err)
+
+	input := ton.NewTxInput()
+	input.AccountStatus = api.Uninit
+	input.WalletFormat = tonaddress.AddressFormatHighloadV3
+	args := buildertest.MustNewTransferArgs(
+		chain.Base(),
+		xc.Address(walletAddr.String()),
+		xc.Address("0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm"),
+		xc.NewAmountBlockchainFromUint64(10),
+	)
+	_, err = builder.Transfer(args, input)
+	require.ErrorContains(t, err, "must set from-public-key")
+
+	args.SetPublicKey(pubKey)
+	tx, err := builder.Transfer(args, input)
+	require.NoError(t, err)
+
+	// deploys the highload wallet to the sender's address
+	stateInit := tx.(*tontx.Tx).ExternalMessage.StateInit
+	require.NotNil(t, stateInit)
+	stateInitCell, err := tlb.ToCell(stateInit)
+	require.NoError(t, err)
+	require.Equal(t, walletAddr.Data(), stateInitCell.Hash())
+}
+
+func TestJettonBurnTx(t *testing.T) {
+	chain := xc.NewChainConfig(xc.TON).WithDecimals(9)
+	builder, err := ton.NewTxBuilder(chain.Base())
+	require.NoError(t, err)
+
+	from := xc.Address("0QAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSvD5")
+	input := ton.NewTxInput()
+	input.AccountStatus = api.Active
+	input.Timestamp = 1721068303
+	input.TokenWallet = "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM"
+	input.JettonWalletCode, err = hex.DecodeString("b5ee9c7241021101000323000114ff00f4a413f4bcf2c80b0102016202030202cc0405001ba0f605da89a1f401f481f481a8610201d40607020120080900c30831c02497c138007434c0c05c6c2544d7c0fc03383e903e900c7e800c5c75c87e800c7e800c1cea6d0000b4c7e08403e29fa954882ea54c4d167c0278208405e3514654882ea58c511100fc02b80d60841657c1ef2ea4d67c02f817c12103fcbc2000113e910c1c2ebcb853600201200a0b0083d40106b90f6a2687d007d207d206a1802698fc1080bc6a28ca9105d41083deecbef09dd0958f97162e99f98fd001809d02811e428027d012c678b00e78b6664f6aa401f1503d33ffa00fa4021f001ed44d0fa00fa40fa40d4305136a1522ac705f2e2c128c2fff2e2c254344270542013541403c85004fa0258cf1601cf16ccc922c8cb0112f400f400cb00c920f9007074c8cb02ca07cbffc9d004fa40f40431fa0020d749c200f2e2c4778018c8cb055008cf1670fa0217cb6b13cc80c0201200d0e009e8210178d4519c8cb1f19cb3f5007fa0222cf165006cf1625fa025003cf16c95005cc2391729171e25008a813a08209c9c380a014bcf2e2c504c98040fb001023c85004fa0258cf1601cf16ccc9ed5402f73b51343e803e903e90350c0234cffe80145468017e903e9014d6f1c1551cdb5c150804d50500f214013e809633c58073c5b33248b232c044bd003d0032c0327e401c1d3232c0b281f2fff274140371c1472c7cb8b0c2be80146a2860822625a019ad822860822625a028062849e5c412440e0dd7c138c34975c2c0600f1000d73b51343e803e903e90350c01f4cffe803e900c145468549271c17cb8b049f0bffcb8b08160824c4b402805af3cb8b0e0841ef765f7b232c7c572cfd400fe8088b3c58073c5b25c60063232c14933c59c3e80b2dab33260103ec01004f214013e809633c58073c5b3327b552000705279a018a182107362d09cc8cb1f5230cb3f58fa025007cf165007cf16c9718010c8cb0524cf165006fa0215cb6a14ccc971fb0010241023007cc30023c200b08e218210d53276db708010c8cb055008cf165004fa0216cb6a12cb1f12cb3fc972fb0093356c21e203c85004fa0258cf1601cf16ccc9ed5495eaedd7")
+	require.NoError(t, err)
+
+	args := ton.JettonBurnArgs{
+		From:     from,
+		Contract: xc.ContractAddress("kQAiboDEv_qRrcEdrYdwbVLNOXBHwShFbtKGbQVJ2OKxY_Di"),
+		Amount:   xc.NewAmountBlockchainFromUint64(22000000),
+	}
+	tx, err := builder.JettonBurn(args, input)
+	require.NoError(t, err)
+
+	// v3 payload: subwallet, expiration, seqno, mode, then the message
+	payload := tx.(*tontx.Tx).CellBuilder.EndCell().BeginParse()
+	payload.MustLoadUInt(32 + 32 + 32 + 8)
+	msg := &tlb.InternalMessage{}
+	require.NoError(t, tlb.LoadFromCell(msg, payload.MustLoadRef()))
+	require.Equal(t, "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM", msg.DstAddr.String())
+
+	burn := &jetton.BurnPayload{}
+	require.NoError(t, tlb.LoadFromCell(burn, msg.Body.BeginParse()))
+	require.EqualValues(t, 22000000, burn.Amount.Nano().Uint64())
+	require.EqualValues(t, 1721068303, burn.QueryID)
+	require.Equal(t, "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2", burn.ResponseDestination.String())
+
+	// the token wallet is validated
+	args.Contract = "EQChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc4yQp"
+	_, err = builder.JettonBurn(args, input)
+	require.ErrorContains(t, err, "could not validate token wallet")
+}
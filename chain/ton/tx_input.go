@@ -2,6 +2,7 @@ package ton
 
 import (
 	xc "github.com/cordialsys/crosschain"
+	tonaddress "github.com/cordialsys/crosschain/chain/ton/address"
 	"github.com/cordialsys/crosschain/chain/ton/api"
 	"github.com/cordialsys/crosschain/factory/drivers/registry"
 	"github.com/shopspring/decimal"
@@ -18,13 +19,33 @@ type TxInput struct {
 	JettonWalletCode []byte              `json:"jetton_wallet_code"`
 	EstimatedMaxFee  xc.AmountBlockchain `json:"estimated_max_fee"`
 	TonBalance       xc.AmountBlockchain `json:"ton_balance"`
+	// Format of the sender's wallet, either v3 (default) or highload-v3
+	WalletFormat xc.AddressFormat `json:"wallet_format,omitempty"`
+	// Highload wallets use an unprocessed query id instead of a sequence
+	QueryId uint32 `json:"query_id,omitempty"`
+}
+
+// Token wallet information needed for each jetton sent in a multi-transfer
+type TokenWalletInput struct {
+	Contract         xc.ContractAddress `json:"contract"`
+	TokenWallet      xc.Address         `json:"token_wallet"`
+	JettonWalletCode []byte             `json:"jetton_wallet_code"`
+}
+
+type MultiTransferInput struct {
+	TxInput
+	// One entry per jetton being sent
+	TokenWallets []*TokenWalletInput `json:"token_wallets,omitempty"`
 }
 
 var _ xc.TxInput = &TxInput{}
 var _ xc.TxInputWithUnix = &TxInput{}
+var _ xc.TxVariantInput = &MultiTransferInput{}
+var _ xc.MultiTransferInput = &MultiTransferInput{}
 
 func init() {
 	registry.RegisterTxBaseInput(&TxInput{})
+	registry.RegisterTxVariantInput(&MultiTransferInput{})
 }
 
 func NewTxInput() *TxInput {
@@ -35,6 +56,32 @@ func NewTxInput() *TxInput {
 	}
 }
 
+func NewMultiTransferInput() *MultiTransferInput {
+	return &MultiTransferInput{
+		TxInput: *NewTxInput(),
+	}
+}
+
+func (*MultiTransferInput) MultiTransfer() {}
+
+func (*MultiTransferInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewMultiTransferInputType(xc.DriverTon, "batch")
+}
+
+// GetTokenWallet returns the token wallet input for a jetton in the multi-transfer
+func (input *MultiTransferInput) GetTokenWallet(contract xc.ContractAddress) (*TokenWalletInput, bool) {
+	for _, tokenWallet := range input.TokenWallets {
+		if tokenWallet.Contract == contract {
+			return tokenWallet, true
+		}
+	}
+	return nil, false
+}
+
+func (input *TxInput) IsHighload() bool {
+	return input.WalletFormat == tonaddress.AddressFormatHighloadV3
+}
+
 func (input *TxInput) GetDriver() xc.Driver {
 	return xc.DriverTon
 }
@@ -64,6 +111,10 @@ func (input *TxInput) IsFeeLimitAccurate() bool {
 func (input *TxInput) IndependentOf(other xc.TxInput) (independent bool) {
 	// different sequence means independence
 	if evmOther, ok := other.(*TxInput); ok {
+		if input.IsHighload() || evmOther.IsHighload() {
+			// highload wallets may process any number of query id's concurrently
+			return evmOther.WalletFormat == input.WalletFormat && evmOther.QueryId != input.QueryId
+		}
 		return evmOther.Sequence != input.Sequence
 	}
 	return
@@ -72,6 +123,9 @@ func (input *TxInput) SafeFromDoubleSend(other xc.TxInput) (safe bool) {
 	if !xc.IsTypeOf(other, input) {
 		return false
 	}
+	if tonOther, ok := other.(*TxInput); ok && tonOther.WalletFormat != input.WalletFormat {
+		return false
+	}
 	// all same sequence means no double send
 	if input.IndependentOf(other) {
 		return false
@@ -36,6 +36,8 @@ var ErrVotingInputRequired error = errors.New("voting required but no vote input
 var ErrFreezeInputRequired error = errors.New("freezing required but no vote input provided")
 
 const TRC20_TRANSFER_FUNCTION = "transfer(address,uint256)"
+const TRC20_APPROVE_FUNCTION = "approve(address,uint256)"
+const TRC20_INCREASE_ALLOWANCE_FUNCTION = "increaseAllowance(address,uint256)"
 
 // TxBuilder for Template
 type TxBuilder struct {
@@ -44,6 +46,7 @@ type TxBuilder struct {
 
 var _ xcbuilder.FullTransferBuilder = &TxBuilder{}
 var _ xcbuilder.Staking = &TxBuilder{}
+var _ xcbuilder.Approval = &TxBuilder{}
 
 // NewTxBuilder creates a new Template TxBuilder
 func NewTxBuilder(cfgI *xc.ChainBaseConfig) (TxBuilder, error) {
@@ -156,6 +159,15 @@ func trc20TransferData(to xc.Address, amount xc.AmountBlockchain) ([]byte, error
 
 // NewTokenTransfer creates a new transfer for a token asset
 func (txBuilder TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, contract xc.ContractAddress, input xc.TxInput) (xc.Tx, error) {
+	data, err := trc20TransferData(to, amount)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.NewContractCall(from, contract, data, input.(*txinput.TxInput))
+}
+
+// NewContractCall creates a new TriggerSmartContract transaction, limited by the max-fee of the input
+func (txBuilder TxBuilder) NewContractCall(from xc.Address, contract xc.ContractAddress, data []byte, input *txinput.TxInput) (xc.Tx, error) {
 	from_bytes, err := GetAddressHash(string(from))
 	if err != nil {
 		return nil, fmt.Errorf("invalid from address: %v", err)
@@ -166,11 +178,6 @@ func (txBuilder TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amou
 		return nil, fmt.Errorf("invalid contract address: %v", err)
 	}
 
-	data, err := trc20TransferData(to, amount)
-	if err != nil {
-		return nil, err
-	}
-
 	params := &core.TriggerSmartContract{}
 	params.ContractAddress = contract_bytes
 	params.Data = data
@@ -185,11 +192,10 @@ func (txBuilder TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amou
 	}
 	contractParam.Parameter = param
 
-	i := input.(*txinput.TxInput)
-	tx := i.ToTronTx(contractParam)
+	tx := input.ToTronTx(contractParam)
 
 	// set limit for token contracts
-	tx.RawData.FeeLimit = int64(i.MaxFee.Uint64())
+	tx.RawData.FeeLimit = int64(input.MaxFee.Uint64())
 	if tx.RawData.FeeLimit == 0 {
 		logrus.Warn("tron max-fee missing from tx-input")
 		// 200 tron sanity limit
@@ -199,7 +205,48 @@ func (txBuilder TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amou
 	return NewTx([]*core.Transaction{tx})
 }
 
+// The TRC-20 function and parameter used to change the allowance of a spender
+func trc20ApprovalFunctionAndParameter(action xcbuilder.ApprovalAction, spender xc.Address, amount xc.AmountBlockchain) (string, []byte, error) {
+	var function string
+	switch action {
+	case xcbuilder.ApprovalActionApprove:
+		function = TRC20_APPROVE_FUNCTION
+	case xcbuilder.ApprovalActionIncrease:
+		function = TRC20_INCREASE_ALLOWANCE_FUNCTION
+	case xcbuilder.ApprovalActionRevoke:
+		function = TRC20_APPROVE_FUNCTION
+		amount = xc.NewAmountBlockchainFromUint64(0)
+	default:
+		return "", nil, fmt.Errorf("unsupported approval action: %s", action)
+	}
+	// same (address,uint256) encoding as transfers
+	paramBz, err := trc20TransferParameter(spender, amount)
+	if err != nil {
+		return "", nil, err
+	}
+	return function, paramBz, nil
+}
+
+// Approve changes the allowance of a spender over the owner's TRC-20 tokens
+func (txBuilder TxBuilder) Approve(args xcbuilder.ApprovalArgs, input xc.ApprovalTxInput) (xc.Tx, error) {
+	approvalInput, ok := input.(*txinput.ApprovalInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	function, paramBz, err := trc20ApprovalFunctionAndParameter(args.GetAction(), args.GetSpender(), args.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+	data := append(Signature(function), paramBz...)
+	return txBuilder.NewContractCall(args.GetFrom(), args.GetContract(), data, &approvalInput.TxInput)
+}
+
 func (txBuilder TxBuilder) NewFreeze(from xc.Address, balance xc.AmountBlockchain, input xc.TxInput) (*core.Transaction, error) {
+	return txBuilder.NewFreezeResource(from, balance, core.ResourceCode_BANDWIDTH, input)
+}
+
+// NewFreezeResource stakes TRX to obtain either bandwidth or energy
+func (txBuilder TxBuilder) NewFreezeResource(from xc.Address, balance xc.AmountBlockchain, resource core.ResourceCode, input xc.TxInput) (*core.Transaction, error) {
 	from_bytes, err := GetAddressHash(string(from))
 	if err != nil {
 		return nil, err
@@ -208,7 +255,7 @@ func (txBuilder TxBuilder) NewFreeze(from xc.Address, balance xc.AmountBlockchai
 	contract := &core.FreezeBalanceV2Contract{}
 	contract.OwnerAddress = from_bytes
 	contract.FrozenBalance = balance.Int().Int64()
-	contract.Resource = core.ResourceCode_BANDWIDTH
+	contract.Resource = resource
 
 	params, err := ptypes.MarshalAny(contract)
 	if err != nil {
@@ -0,0 +1,138 @@
+package tron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/tron/core"
+	"github.com/cordialsys/crosschain/chain/tron/txinput"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Blocks are produced every 3 seconds
+const BLOCKS_PER_DAY = 28_800
+
+// Longest period that a delegation can be locked for, in blocks
+const MAX_DELEGATE_LOCK_PERIOD = 14 * BLOCKS_PER_DAY
+
+func ParseResource(resource string) (core.ResourceCode, error) {
+	switch strings.ToLower(resource) {
+	case "energy":
+		return core.ResourceCode_ENERGY, nil
+	case "bandwidth", "net":
+		return core.ResourceCode_BANDWIDTH, nil
+	default:
+		return 0, fmt.Errorf("invalid resource '%s', must be 'energy' or 'bandwidth'", resource)
+	}
+}
+
+func validateDelegatedResource(resource core.ResourceCode, balance xc.AmountBlockchain) error {
+	if resource != core.ResourceCode_ENERGY && resource != core.ResourceCode_BANDWIDTH {
+		return fmt.Errorf("resource %s cannot be delegated", resource)
+	}
+	if balance.Sign() <= 0 {
+		return errors.New("balance to delegate must be greater than 0")
+	}
+	if !balance.Int().IsInt64() {
+		return errors.New("balance to delegate is too large")
+	}
+	return nil
+}
+
+// FreezeResource stakes TRX to obtain bandwidth or energy, which can then be delegated to other accounts
+func (txBuilder TxBuilder) FreezeResource(from xc.Address, balance xc.AmountBlockchain, resource core.ResourceCode, input xc.TxInput) (xc.Tx, error) {
+	if _, ok := input.(*txinput.TxInput); !ok {
+		return nil, errors.New("invalid input type")
+	}
+	if err := validateDelegatedResource(resource, balance); err != nil {
+		return nil, err
+	}
+	tx, err := txBuilder.NewFreezeResource(from, balance, resource, input)
+	if err != nil {
+		return nil, err
+	}
+	return NewTx([]*core.Transaction{tx})
+}
+
+// DelegateResource delegates the resources obtained by staking balance TRX to the receiver.
+// If the lock period (in blocks) is set, the delegation cannot be undelegated until it passes.
+func (txBuilder TxBuilder) DelegateResource(from xc.Address, receiver xc.Address, resource core.ResourceCode, balance xc.AmountBlockchain, lockPeriod int64, input xc.TxInput) (xc.Tx, error) {
+	i, ok := input.(*txinput.TxInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	if err := validateDelegatedResource(resource, balance); err != nil {
+		return nil, err
+	}
+	if lockPeriod < 0 || lockPeriod > MAX_DELEGATE_LOCK_PERIOD {
+		return nil, fmt.Errorf("lock period must be between 0 and %d blocks", MAX_DELEGATE_LOCK_PERIOD)
+	}
+	if from == receiver {
+		return nil, errors.New("cannot delegate resources to self")
+	}
+	from_bytes, err := GetAddressHash(string(from))
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %v", err)
+	}
+	receiver_bytes, err := GetAddressHash(string(receiver))
+	if err != nil {
+		return nil, fmt.Errorf("invalid receiver address: %v", err)
+	}
+
+	contract := &core.DelegateResourceContract{
+		OwnerAddress:    from_bytes,
+		ReceiverAddress: receiver_bytes,
+		Resource:        resource,
+		Balance:         balance.Int().Int64(),
+		Lock:            lockPeriod > 0,
+		LockPeriod:      lockPeriod,
+	}
+	params, err := ptypes.MarshalAny(contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal any params: %w", err)
+	}
+
+	txContract := &core.Transaction_Contract{
+		Type:      core.Transaction_Contract_DelegateResourceContract,
+		Parameter: params,
+	}
+	return NewTx([]*core.Transaction{i.ToTronTx(txContract)})
+}
+
+// UnDelegateResource reclaims resources previously delegated to the receiver
+func (txBuilder TxBuilder) UnDelegateResource(from xc.Address, receiver xc.Address, resource core.ResourceCode, balance xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
+	i, ok := input.(*txinput.TxInput)
+	if !ok {
+		return nil, errors.New("invalid input type")
+	}
+	if err := validateDelegatedResource(resource, balance); err != nil {
+		return nil, err
+	}
+	from_bytes, err := GetAddressHash(string(from))
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %v", err)
+	}
+	receiver_bytes, err := GetAddressHash(string(receiver))
+	if err != nil {
+		return nil, fmt.Errorf("invalid receiver address: %v", err)
+	}
+
+	contract := &core.UnDelegateResourceContract{
+		OwnerAddress:    from_bytes,
+		ReceiverAddress: receiver_bytes,
+		Resource:        resource,
+		Balance:         balance.Int().Int64(),
+	}
+	params, err := ptypes.MarshalAny(contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal any params: %w", err)
+	}
+
+	txContract := &core.Transaction_Contract{
+		Type:      core.Transaction_Contract_UnDelegateResourceContract,
+		Parameter: params,
+	}
+	return NewTx([]*core.Transaction{i.ToTronTx(txContract)})
+}
@@ -0,0 +1,102 @@
+package tron_test
+
+import (
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/tron"
+	"github.com/cordialsys/crosschain/chain/tron/core"
+	"github.com/cordialsys/crosschain/chain/tron/txinput"
+	"github.com/cordialsys/crosschain/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegateResource(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.TRX).WithDecimals(6).Base()
+	txBuilder, err := tron.NewTxBuilder(chainCfg)
+	require.NoError(t, err)
+
+	owner := xc.Address("TFmgAF3HfTJZk2aHkvSu8FDtVArbqp4XE5")
+	receiver := xc.Address("TUz4nTU75z5oK4pYaVipkSDQ3Bi2DXdQT8")
+	ownerHash, err := tron.GetAddressHash(string(owner))
+	require.NoError(t, err)
+	receiverHash, err := tron.GetAddressHash(string(receiver))
+	require.NoError(t, err)
+	balance := xc.NewAmountBlockchainFromUint64(100_000_000)
+
+	input := &txinput.TxInput{
+		TxInputEnvelope: txinput.NewTxInput().TxInputEnvelope,
+		RefBlockBytes:   testutil.FromHex("5273"),
+		RefBlockHash:    testutil.FromHex("40c45983779ab5f8"),
+		Expiration:      200,
+		Timestamp:       100,
+	}
+
+	t.Run("delegate", func(t *testing.T) {
+		txI, err := txBuilder.DelegateResource(owner, receiver, core.ResourceCode_ENERGY, balance, 0, input)
+		require.NoError(t, err)
+		tx := txI.(*tron.Tx)
+		require.Len(t, tx.TronTxs, 1)
+		contractParam := tx.TronTxs[0].RawData.Contract[0]
+		require.Equal(t, core.Transaction_Contract_DelegateResourceContract, contractParam.Type)
+		delegate := &core.DelegateResourceContract{}
+		require.NoError(t, contractParam.Parameter.UnmarshalTo(delegate))
+		require.Equal(t, ownerHash, delegate.OwnerAddress)
+		require.Equal(t, receiverHash, delegate.ReceiverAddress)
+		require.Equal(t, core.ResourceCode_ENERGY, delegate.Resource)
+		require.EqualValues(t, 100_000_000, delegate.Balance)
+		require.False(t, delegate.Lock)
+	})
+
+	t.Run("delegate locked", func(t *testing.T) {
+		txI, err := txBuilder.DelegateResource(owner, receiver, core.ResourceCode_BANDWIDTH, balance, tron.BLOCKS_PER_DAY, input)
+		require.NoError(t, err)
+		delegate := &core.DelegateResourceContract{}
+		require.NoError(t, txI.(*tron.Tx).TronTxs[0].RawData.Contract[0].Parameter.UnmarshalTo(delegate))
+		require.Equal(t, core.ResourceCode_BANDWIDTH, delegate.Resource)
+		require.True(t, delegate.Lock)
+		require.EqualValues(t, tron.BLOCKS_PER_DAY, delegate.LockPeriod)
+	})
+
+	t.Run("undelegate", func(t *testing.T) {
+		txI, err := txBuilder.UnDelegateResource(owner, receiver, core.ResourceCode_ENERGY, balance, input)
+		require.NoError(t, err)
+		contractParam := txI.(*tron.Tx).TronTxs[0].RawData.Contract[0]
+		require.Equal(t, core.Transaction_Contract_UnDelegateResourceContract, contractParam.Type)
+		undelegate := &core.UnDelegateResourceContract{}
+		require.NoError(t, contractParam.Parameter.UnmarshalTo(undelegate))
+		require.Equal(t, receiverHash, undelegate.ReceiverAddress)
+		require.EqualValues(t, 100_000_000, undelegate.Balance)
+	})
+
+	t.Run("freeze energy", func(t *testing.T) {
+		txI, err := txBuilder.FreezeResource(owner, balance, core.ResourceCode_ENERGY, input)
+		require.NoError(t, err)
+		freeze := &core.FreezeBalanceV2Contract{}
+		require.NoError(t, txI.(*tron.Tx).TronTxs[0].RawData.Contract[0].Parameter.UnmarshalTo(freeze))
+		require.Equal(t, core.ResourceCode_ENERGY, freeze.Resource)
+		require.EqualValues(t, 100_000_000, freeze.FrozenBalance)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := txBuilder.DelegateResource(owner, receiver, core.ResourceCode_TRON_POWER, balance, 0, input)
+		require.ErrorContains(t, err, "cannot be delegated")
+		_, err = txBuilder.DelegateResource(owner, receiver, core.ResourceCode_ENERGY, xc.NewAmountBlockchainFromUint64(0), 0, input)
+		require.ErrorContains(t, err, "greater than 0")
+		_, err = txBuilder.DelegateResource(owner, receiver, core.ResourceCode_ENERGY, balance, tron.MAX_DELEGATE_LOCK_PERIOD+1, input)
+		require.ErrorContains(t, err, "lock period")
+		_, err = txBuilder.DelegateResource(owner, owner, core.ResourceCode_ENERGY, balance, 0, input)
+		require.ErrorContains(t, err, "self")
+	})
+}
+
+func TestParseResource(t *testing.T) {
+	resource, err := tron.ParseResource("Energy")
+	require.NoError(t, err)
+	require.Equal(t, core.ResourceCode_ENERGY, resource)
+	resource, err = tron.ParseResource("bandwidth")
+	require.NoError(t, err)
+	require.Equal(t, core.ResourceCode_BANDWIDTH, resource)
+	_, err = tron.ParseResource("tron_power")
+	require.Error(t, err)
+}
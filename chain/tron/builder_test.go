@@ -3,6 +3,7 @@ package tron_test
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"math/big"
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
@@ -547,3 +548,54 @@ func TestNewTxBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestApprove(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.TRX).WithDecimals(6).Base()
+	txBuilder, err := tron.NewTxBuilder(chainCfg)
+	require.NoError(t, err)
+
+	owner := xc.Address("TFmgAF3HfTJZk2aHkvSu8FDtVArbqp4XE5")
+	spender := xc.Address("TUz4nTU75z5oK4pYaVipkSDQ3Bi2DXdQT8")
+	contract := xc.ContractAddress("TG3XXyExBkPp9nzdajDZsozEu4BkaSJozs")
+
+	for _, tc := range []struct {
+		action           builder.ApprovalAction
+		expectedSelector string
+		expectedAmount   uint64
+	}{
+		{builder.ApprovalActionApprove, "095ea7b3", 10000},
+		{builder.ApprovalActionIncrease, "39509351", 10000},
+		{builder.ApprovalActionRevoke, "095ea7b3", 0},
+	} {
+		t.Run(string(tc.action), func(t *testing.T) {
+			args, err := builder.NewApprovalArgs(xc.TRX, tc.action, owner, spender, contract, xc.NewAmountBlockchainFromUint64(10000))
+			require.NoError(t, err)
+			input := &txinput.ApprovalInput{
+				TxInput: txinput.TxInput{
+					TxInputEnvelope: txinput.NewTxInput().TxInputEnvelope,
+					RefBlockBytes:   testutil.FromHex("5273"),
+					RefBlockHash:    testutil.FromHex("40c45983779ab5f8"),
+					Expiration:      200,
+					Timestamp:       100,
+					MaxFee:          xc.NewAmountBlockchainFromUint64(1000000),
+				},
+			}
+
+			txI, err := txBuilder.Approve(args, input)
+			require.NoError(t, err)
+			tx := txI.(*tron.Tx)
+			require.Len(t, tx.TronTxs, 1)
+			require.EqualValues(t, 1000000, tx.TronTxs[0].RawData.FeeLimit)
+
+			contractParam := tx.TronTxs[0].RawData.Contract[0]
+			require.Equal(t, core.Transaction_Contract_TriggerSmartContract, contractParam.Type)
+			trigger := &core.TriggerSmartContract{}
+			require.NoError(t, contractParam.Parameter.UnmarshalTo(trigger))
+
+			data := trigger.Data
+			require.Len(t, data, 4+32+32)
+			require.Equal(t, tc.expectedSelector, hex.EncodeToString(data[:4]))
+			require.EqualValues(t, tc.expectedAmount, new(big.Int).SetBytes(data[36:]).Uint64())
+		})
+	}
+}
@@ -132,33 +132,49 @@ func (client *Client) EstimateTransactionFee(ctx context.Context, transaction xc
 		return 0, fmt.Errorf("failed to get chain parameters: %w", err)
 	}
 
-	availableBandwidth := accountResources.GetAvailableBandwith()
-	bandwidthRequired := txSize
-
+	fee, err := bandwidthFee(chainParameters, accountResources, txSize)
+	if err != nil {
+		return 0, err
+	}
 	// free transfer
-	if bandwidthRequired <= availableBandwidth {
+	if fee == 0 {
 		return 0, nil
 	}
 
+	// Include AccountActivation fee if neccessary
+	activationFee, err := client.accountCreationFee(chainParameters, receiver)
+	if err != nil {
+		return 0, err
+	}
+	return fee + activationFee, nil
+}
+
+// bandwidthFee is the TRX burned for the bandwidth of a transaction, which is either fully
+// covered by the bandwidth available to the account or fully burned.
+func bandwidthFee(chainParameters *httpclient.ChainParameters, accountResources *httpclient.GetAccountResourcesResponse, bandwidthRequired int) (uint64, error) {
+	if bandwidthRequired <= accountResources.GetAvailableBandwith() {
+		return 0, nil
+	}
 	feePerBandwidth, ok := chainParameters.GetParam(KEY_FEE_PER_BANDWIDTH)
 	if !ok {
 		return 0, errors.New("failed to get bandwidth price")
 	}
-	fee := uint64(bandwidthRequired * feePerBandwidth)
+	return uint64(bandwidthRequired * feePerBandwidth), nil
+}
 
-	// Include AccountActivation fee if neccessary
-	_, err = client.client.GetAccount(string(receiver))
+// accountCreationFee is the TRX burned for activating the receiver, if it does not exist yet
+func (client *Client) accountCreationFee(chainParameters *httpclient.ChainParameters, receiver xc.Address) (uint64, error) {
+	_, err := client.client.GetAccount(string(receiver))
 	if err != nil && strings.Contains(err.Error(), "could not find account") {
 		accountCreationFee, ok := chainParameters.GetParam(KEY_CREATE_ACCOUNT_FEE)
 		if !ok {
 			return 0, errors.New("failed to get account creation fee")
 		}
-		fee = uint64(accountCreationFee) + fee
+		return uint64(accountCreationFee), nil
 	} else if err != nil {
 		return 0, fmt.Errorf("failed to get recipient account: %w", err)
 	}
-
-	return fee, nil
+	return 0, nil
 }
 
 func (client *Client) EstimateTokenTransferFee(ctx context.Context, args xcbuilder.TransferArgs) (uint64, error) {
@@ -172,11 +188,16 @@ func (client *Client) EstimateTokenTransferFee(ctx context.Context, args xcbuild
 		return 0, err
 	}
 
+	return client.EstimateContractFee(ctx, args.GetFrom(), contract, TRC20_TRANSFER_FUNCTION, paramBz)
+}
+
+// Estimate the TRX that would be burned for the energy needed by a contract call
+func (client *Client) EstimateContractFee(ctx context.Context, owner xc.Address, contract xc.ContractAddress, functionSelector string, paramBz []byte) (uint64, error) {
 	energyRequired, err := client.EstimateContractEnergy(
 		ctx,
-		args.GetFrom(),
+		owner,
 		contract,
-		TRC20_TRANSFER_FUNCTION,
+		functionSelector,
 		hex.EncodeToString(paramBz),
 	)
 	if err != nil {
@@ -190,15 +211,19 @@ func (client *Client) EstimateTokenTransferFee(ctx context.Context, args xcbuild
 	if err != nil {
 		return 0, fmt.Errorf("failed to get chain parameters: %w", err)
 	}
-	energyFee, ok := chainParameters.GetParam(KEY_ENERGY_FEE)
-	if !ok || energyFee <= 0 {
+	return energyFee(chainParameters, energyRequired)
+}
+
+// energyFee is the TRX burned for energy
+func energyFee(chainParameters *httpclient.ChainParameters, energy int64) (uint64, error) {
+	feePerEnergy, ok := chainParameters.GetParam(KEY_ENERGY_FEE)
+	if !ok || feePerEnergy <= 0 {
 		return 0, errors.New("failed to get energy price")
 	}
-	if uint64(energyRequired) > ^uint64(0)/uint64(energyFee) {
+	if uint64(energy) > ^uint64(0)/uint64(feePerEnergy) {
 		return 0, fmt.Errorf("energy fee overflow")
 	}
-
-	return uint64(energyRequired) * uint64(energyFee), nil
+	return uint64(energy) * uint64(feePerEnergy), nil
 }
 
 func (client *Client) EstimateContractEnergy(ctx context.Context, owner xc.Address, contract xc.ContractAddress, functionSelector string, parameter string) (int64, error) {
@@ -293,6 +318,10 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	if multiplier > 0.01 {
 		baseInput.MaxFee = xc.MultiplyByFloat(baseInput.MaxFee, multiplier)
 	}
+
+	if err := client.checkMaxBurn(ctx, args, dummyTx); err != nil {
+		return nil, err
+	}
 	return baseInput, nil
 }
 
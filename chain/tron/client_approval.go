@@ -0,0 +1,43 @@
+package tron
+
+import (
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/tron/txinput"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+var _ xclient.ApprovalClient = &Client{}
+
+func (client *Client) FetchAllowance(ctx context.Context, owner xc.Address, spender xc.Address, contract xc.ContractAddress) (xc.AmountBlockchain, error) {
+	allowance, err := client.client.ReadTrc20Allowance(string(owner), string(spender), string(contract))
+	if err != nil {
+		return xc.AmountBlockchain{}, err
+	}
+	return xc.AmountBlockchain(*allowance), nil
+}
+
+func (client *Client) FetchApprovalInput(ctx context.Context, args xcbuilder.ApprovalArgs) (xc.ApprovalTxInput, error) {
+	baseInput, err := client.FetchBaseInputFromLatestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	function, paramBz, err := trc20ApprovalFunctionAndParameter(args.GetAction(), args.GetSpender(), args.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+	fee, err := client.EstimateContractFee(ctx, args.GetFrom(), args.GetContract(), function, paramBz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate approval fee: %w", err)
+	}
+	baseInput.MaxFee = xc.NewAmountBlockchainFromUint64(fee)
+
+	multiplier := client.chain.ChainGasMultiplier
+	if multiplier > 0.01 {
+		baseInput.MaxFee = xc.MultiplyByFloat(baseInput.MaxFee, multiplier)
+	}
+	return &txinput.ApprovalInput{TxInput: *baseInput}, nil
+}
@@ -0,0 +1,238 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/tron/core"
+)
+
+// Resources delegated from one account to another
+type ResourceDelegation struct {
+	From   xc.Address          `json:"from"`
+	To     xc.Address          `json:"to"`
+	Energy xc.AmountBlockchain `json:"energy_balance"`
+	// Staked TRX delegated for bandwidth
+	Bandwidth xc.AmountBlockchain `json:"bandwidth_balance"`
+	// Set if the delegation is locked
+	EnergyLockedUntil    *time.Time `json:"energy_locked_until,omitempty"`
+	BandwidthLockedUntil *time.Time `json:"bandwidth_locked_until,omitempty"`
+}
+
+// Energy and bandwidth of an account, and the staked TRX that it delegated or acquired
+type AccountResources struct {
+	Address            xc.Address `json:"address"`
+	EnergyLimit        uint64     `json:"energy_limit"`
+	EnergyUsed         uint64     `json:"energy_used"`
+	AvailableEnergy    uint64     `json:"available_energy"`
+	AvailableBandwidth uint64     `json:"available_bandwidth"`
+
+	// Staked TRX delegated to other accounts
+	DelegatedEnergy    xc.AmountBlockchain `json:"delegated_energy_balance"`
+	DelegatedBandwidth xc.AmountBlockchain `json:"delegated_bandwidth_balance"`
+	// Staked TRX delegated to this account by other accounts
+	AcquiredEnergy    xc.AmountBlockchain `json:"acquired_energy_balance"`
+	AcquiredBandwidth xc.AmountBlockchain `json:"acquired_bandwidth_balance"`
+	// Staked TRX that can still be delegated
+	DelegatableEnergy    xc.AmountBlockchain `json:"delegatable_energy_balance"`
+	DelegatableBandwidth xc.AmountBlockchain `json:"delegatable_bandwidth_balance"`
+
+	// Delegations from this account to others
+	Delegations []*ResourceDelegation `json:"delegations"`
+}
+
+func lockedUntil(expireTimeMs int64) *time.Time {
+	if expireTimeMs <= 0 {
+		return nil
+	}
+	t := time.UnixMilli(expireTimeMs).UTC()
+	return &t
+}
+
+// FetchResources fetches the available energy and bandwidth of an account, and the
+// resources it delegated to other accounts.
+func (client *Client) FetchResources(ctx context.Context, address xc.Address) (*AccountResources, error) {
+	account, err := client.client.GetAccount(string(address))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+	accountResources, err := client.client.GetAccountResources(string(address))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account resources: %w", err)
+	}
+
+	resources := &AccountResources{
+		Address:            address,
+		EnergyLimit:        uint64(max(accountResources.EnergyLimit, 0)),
+		EnergyUsed:         uint64(max(accountResources.EnergyUsed, 0)),
+		AvailableEnergy:    uint64(accountResources.GetAvailableEnergy()),
+		AvailableBandwidth: uint64(accountResources.GetAvailableBandwith()),
+		DelegatedEnergy:    xc.NewAmountBlockchainFromUint64(account.AccountResource.DelegatedFrozenBalanceForEnergy),
+		DelegatedBandwidth: xc.NewAmountBlockchainFromUint64(account.DelegatedFrozenBalanceForBandwidth),
+		AcquiredEnergy:     xc.NewAmountBlockchainFromUint64(account.AccountResource.AcquiredDelegatedFrozenBalanceForEnergy),
+		AcquiredBandwidth:  xc.NewAmountBlockchainFromUint64(account.AcquiredDelegatedFrozenBalanceForBandwidth),
+		Delegations:        []*ResourceDelegation{},
+	}
+
+	maxEnergy, err := client.client.GetCanDelegatedMaxSize(string(address), int(core.ResourceCode_ENERGY))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delegatable energy: %w", err)
+	}
+	resources.DelegatableEnergy = xc.NewAmountBlockchainFromUint64(maxEnergy.MaxSize)
+	maxBandwidth, err := client.client.GetCanDelegatedMaxSize(string(address), int(core.ResourceCode_BANDWIDTH))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delegatable bandwidth: %w", err)
+	}
+	resources.DelegatableBandwidth = xc.NewAmountBlockchainFromUint64(maxBandwidth.MaxSize)
+
+	index, err := client.client.GetDelegatedResourceAccountIndex(string(address))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delegated resource accounts: %w", err)
+	}
+	for _, to := range index.ToAccounts {
+		delegated, err := client.client.GetDelegatedResource(string(address), to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resources delegated to %s: %w", to, err)
+		}
+		for _, d := range delegated.DelegatedResource {
+			resources.Delegations = append(resources.Delegations, &ResourceDelegation{
+				From:                 xc.Address(d.From),
+				To:                   xc.Address(d.To),
+				Energy:               xc.NewAmountBlockchainFromUint64(d.FrozenBalanceForEnergy),
+				Bandwidth:            xc.NewAmountBlockchainFromUint64(d.FrozenBalanceForBandwidth),
+				EnergyLockedUntil:    lockedUntil(d.ExpireTimeForEnergy),
+				BandwidthLockedUntil: lockedUntil(d.ExpireTimeForBandwidth),
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+// FetchDelegateResourceInput fetches the input for delegating resources, checking that
+// the from-address has enough staked TRX that is not already delegated.
+func (client *Client) FetchDelegateResourceInput(ctx context.Context, from xc.Address, resource core.ResourceCode, balance xc.AmountBlockchain) (xc.TxInput, error) {
+	maxSize, err := client.client.GetCanDelegatedMaxSize(string(from), int(resource))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delegatable balance: %w", err)
+	}
+	delegatable := xc.NewAmountBlockchainFromUint64(maxSize.MaxSize)
+	if balance.Cmp(&delegatable) > 0 {
+		return nil, fmt.Errorf(
+			"cannot delegate %s TRX of %s, only %s TRX is staked and not yet delegated",
+			balance.ToHuman(client.chain.Decimals).String(),
+			strings.ToLower(resource.String()),
+			delegatable.ToHuman(client.chain.Decimals).String(),
+		)
+	}
+	return client.FetchBaseInputFromLatestBlock(ctx)
+}
+
+// FetchUnDelegateResourceInput fetches the input for undelegating resources, checking that
+// enough resources are delegated to the receiver and that the delegation is not locked.
+func (client *Client) FetchUnDelegateResourceInput(ctx context.Context, from xc.Address, receiver xc.Address, resource core.ResourceCode, balance xc.AmountBlockchain) (xc.TxInput, error) {
+	delegated, err := client.client.GetDelegatedResource(string(from), string(receiver))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delegated resources: %w", err)
+	}
+	total := uint64(0)
+	var expireTime int64
+	for _, d := range delegated.DelegatedResource {
+		switch resource {
+		case core.ResourceCode_ENERGY:
+			total += d.FrozenBalanceForEnergy
+			expireTime = max(expireTime, d.ExpireTimeForEnergy)
+		case core.ResourceCode_BANDWIDTH:
+			total += d.FrozenBalanceForBandwidth
+			expireTime = max(expireTime, d.ExpireTimeForBandwidth)
+		}
+	}
+	totalDelegated := xc.NewAmountBlockchainFromUint64(total)
+	if balance.Cmp(&totalDelegated) > 0 {
+		return nil, fmt.Errorf(
+			"cannot undelegate %s TRX of %s, only %s TRX is delegated to %s",
+			balance.ToHuman(client.chain.Decimals).String(),
+			strings.ToLower(resource.String()),
+			totalDelegated.ToHuman(client.chain.Decimals).String(),
+			receiver,
+		)
+	}
+	if until := lockedUntil(expireTime); until != nil && until.After(time.Now()) {
+		return nil, fmt.Errorf("delegation to %s is locked until %s", receiver, until.Format(time.RFC3339))
+	}
+	return client.FetchBaseInputFromLatestBlock(ctx)
+}
+
+// EstimateTransferBurn estimates the TRX that would be burned by a transfer, after using the
+// energy and bandwidth that the sender has available.
+func (client *Client) EstimateTransferBurn(ctx context.Context, args xcbuilder.TransferArgs, transferTx xc.Tx) (xc.AmountBlockchain, error) {
+	bz, err := transferTx.Serialize()
+	if err != nil {
+		return xc.AmountBlockchain{}, fmt.Errorf("failed to serialize transfer for fee estimation: %w", err)
+	}
+	accountResources, err := client.client.GetAccountResources(string(args.GetFrom()))
+	if err != nil {
+		return xc.AmountBlockchain{}, fmt.Errorf("failed to get account resources: %w", err)
+	}
+	chainParameters, err := client.client.GetChainParameters()
+	if err != nil {
+		return xc.AmountBlockchain{}, fmt.Errorf("failed to get chain parameters: %w", err)
+	}
+
+	burn, err := bandwidthFee(chainParameters, accountResources, len(bz)+TRANSACTION_RESULT_OVERHEAD)
+	if err != nil {
+		return xc.AmountBlockchain{}, err
+	}
+
+	if contract, ok := args.GetContract(); ok {
+		paramBz, err := trc20TransferParameter(args.GetTo(), args.GetAmount())
+		if err != nil {
+			return xc.AmountBlockchain{}, err
+		}
+		energyRequired, err := client.EstimateContractEnergy(ctx, args.GetFrom(), contract, TRC20_TRANSFER_FUNCTION, hex.EncodeToString(paramBz))
+		if err != nil {
+			return xc.AmountBlockchain{}, err
+		}
+		missingEnergy := energyRequired - int64(accountResources.GetAvailableEnergy())
+		if missingEnergy > 0 {
+			fee, err := energyFee(chainParameters, missingEnergy)
+			if err != nil {
+				return xc.AmountBlockchain{}, err
+			}
+			burn += fee
+		}
+	} else {
+		// activating a new account burns a fixed fee
+		fee, err := client.accountCreationFee(chainParameters, args.GetTo())
+		if err != nil {
+			return xc.AmountBlockchain{}, err
+		}
+		burn += fee
+	}
+
+	return xc.NewAmountBlockchainFromUint64(burn), nil
+}
+
+func (client *Client) checkMaxBurn(ctx context.Context, args xcbuilder.TransferArgs, transferTx xc.Tx) error {
+	maxBurn, ok := args.GetMaxBurn()
+	if !ok {
+		return nil
+	}
+	burn, err := client.EstimateTransferBurn(ctx, args, transferTx)
+	if err != nil {
+		return fmt.Errorf("failed to estimate TRX burn: %w", err)
+	}
+	if burn.Cmp(&maxBurn) > 0 {
+		return fmt.Errorf(
+			"transfer would burn %s TRX for resources, exceeding the limit of %s TRX",
+			burn.ToHuman(client.chain.Decimals).String(),
+			maxBurn.ToHuman(client.chain.Decimals).String(),
+		)
+	}
+	return nil
+}
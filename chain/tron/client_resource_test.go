@@ -0,0 +1,162 @@
+package tron
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/tron/core"
+	httpclient "github.com/cordialsys/crosschain/chain/tron/http_client"
+	"github.com/stretchr/testify/require"
+)
+
+func newResourceTestServer(t *testing.T, availableEnergy int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wallet/getblockbylatestnum":
+			_, _ = w.Write([]byte(`{
+				"block": [{
+					"blockID": "00000000015e527340c45983779ab5f83e76cc37b2f899df372709f6938d322a",
+					"block_header": {"raw_data": {"number": 22958707, "timestamp": 1710000000000}}
+				}]
+			}`))
+		case "/wallet/estimateenergy":
+			_, _ = w.Write([]byte(`{"result": {"result": true}, "energy_required": 65000}`))
+		case "/wallet/getchainparameters":
+			_, _ = w.Write([]byte(`{
+				"chainParameter": [
+					{"key": "getEnergyFee", "value": 420},
+					{"key": "getTransactionFee", "value": 1000}
+				]
+			}`))
+		case "/wallet/getaccountresource":
+			_, _ = w.Write([]byte(`{
+				"freeNetLimit": 600,
+				"freeNetUsed": 0,
+				"EnergyLimit": ` + strconv.Itoa(availableEnergy+1000) + `,
+				"EnergyUsed": 1000
+			}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestFetchTransferInputMaxBurn(t *testing.T) {
+	from := xc.Address("TLxb4uoV9EedCe4CzPZhPqVbCEUGvuipuX")
+	to := xc.Address("TRU3VXqPAtyMr2RNMiZnfVXwNfJzg9oKh8")
+	contract := xc.ContractAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+
+	for _, tc := range []struct {
+		name            string
+		availableEnergy int
+		maxBurn         uint64
+		err             string
+	}{
+		// 65000 energy * 420 sun = 27.3 TRX
+		{"no energy", 0, 10_000_000, "would burn 27.3 TRX for resources, exceeding the limit of 10 TRX"},
+		{"no energy within limit", 0, 30_000_000, ""},
+		{"partial energy", 45000, 10_000_000, ""},
+		{"partial energy over limit", 45000, 5_000_000, "would burn 8.4 TRX"},
+		{"sponsored energy", 65000, 0, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newResourceTestServer(t, tc.availableEnergy)
+			defer server.Close()
+
+			cfg := xc.NewChainConfig(xc.TRX).
+				WithDecimals(6).
+				WithUrl(server.URL).
+				WithGasBudgetDefault(xc.NewAmountHumanReadableFromFloat(50))
+			client, err := NewClient(cfg)
+			require.NoError(t, err)
+
+			args, err := builder.NewTransferArgs(cfg.Base(), from, to, xc.NewAmountBlockchainFromUint64(1_000_000),
+				builder.OptionContractAddress(contract, 6),
+				builder.OptionMaxBurn(xc.NewAmountBlockchainFromUint64(tc.maxBurn)),
+			)
+			require.NoError(t, err)
+
+			_, err = client.FetchTransferInput(context.Background(), args)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEnergyFeeOverflow(t *testing.T) {
+	chainParameters := &httpclient.ChainParameters{Inner: []httpclient.ChainParameter{{Key: KEY_ENERGY_FEE, Value: 420}}}
+	fee, err := energyFee(chainParameters, 65000)
+	require.NoError(t, err)
+	require.EqualValues(t, 27_300_000, fee)
+
+	_, err = energyFee(chainParameters, math.MaxInt64)
+	require.ErrorContains(t, err, "energy fee overflow")
+}
+
+func TestFetchResources(t *testing.T) {
+	owner := "TLxb4uoV9EedCe4CzPZhPqVbCEUGvuipuX"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wallet/getaccount":
+			_, _ = w.Write([]byte(`{
+				"address": "` + owner + `",
+				"balance": 1000000,
+				"account_resource": {"delegated_frozenV2_balance_for_energy": 500000000},
+				"delegated_frozenV2_balance_for_bandwidth": 0,
+				"acquired_delegated_frozenV2_balance_for_bandwidth": 2000000
+			}`))
+		case "/wallet/getaccountresource":
+			_, _ = w.Write([]byte(`{"freeNetLimit": 600, "freeNetUsed": 100, "EnergyLimit": 1000, "EnergyUsed": 200}`))
+		case "/wallet/getcandelegatedmaxsize":
+			_, _ = w.Write([]byte(`{"max_size": 300000000}`))
+		case "/wallet/getdelegatedresourceaccountindexv2":
+			_, _ = w.Write([]byte(`{"account": "` + owner + `", "toAccounts": ["TRU3VXqPAtyMr2RNMiZnfVXwNfJzg9oKh8"]}`))
+		case "/wallet/getdelegatedresourcev2":
+			_, _ = w.Write([]byte(`{"delegatedResource": [{
+				"from": "` + owner + `",
+				"to": "TRU3VXqPAtyMr2RNMiZnfVXwNfJzg9oKh8",
+				"frozen_balance_for_energy": 500000000,
+				"expire_time_for_energy": 4102444800000
+			}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := xc.NewChainConfig(xc.TRX).
+		WithDecimals(6).
+		WithUrl(server.URL).
+		WithGasBudgetDefault(xc.NewAmountHumanReadableFromFloat(50))
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+
+	resources, err := client.FetchResources(context.Background(), xc.Address(owner))
+	require.NoError(t, err)
+	require.EqualValues(t, 800, resources.AvailableEnergy)
+	require.EqualValues(t, 500, resources.AvailableBandwidth)
+	require.EqualValues(t, 500_000_000, resources.DelegatedEnergy.Uint64())
+	require.EqualValues(t, 2_000_000, resources.AcquiredBandwidth.Uint64())
+	require.EqualValues(t, 300_000_000, resources.DelegatableEnergy.Uint64())
+	require.Len(t, resources.Delegations, 1)
+	require.Equal(t, xc.Address("TRU3VXqPAtyMr2RNMiZnfVXwNfJzg9oKh8"), resources.Delegations[0].To)
+	require.EqualValues(t, 500_000_000, resources.Delegations[0].Energy.Uint64())
+	require.Equal(t, time.UnixMilli(4102444800000).UTC(), *resources.Delegations[0].EnergyLockedUntil)
+	require.Nil(t, resources.Delegations[0].BandwidthLockedUntil)
+
+	_, err = client.FetchDelegateResourceInput(context.Background(), xc.Address(owner), core.ResourceCode_ENERGY, xc.NewAmountBlockchainFromUint64(400_000_000))
+	require.ErrorContains(t, err, "only 300 TRX is staked and not yet delegated")
+
+	_, err = client.FetchUnDelegateResourceInput(context.Background(), xc.Address(owner), "TRU3VXqPAtyMr2RNMiZnfVXwNfJzg9oKh8", core.ResourceCode_ENERGY, xc.NewAmountBlockchainFromUint64(100_000_000))
+	require.ErrorContains(t, err, "is locked until")
+}
@@ -191,6 +191,8 @@ type UnfreezeV2 struct {
 
 type AccountResource struct {
 	DelegatedFrozenBalanceForEnergy uint64 `json:"delegated_frozenV2_balance_for_energy"`
+	// energy delegated to this account by other accounts
+	AcquiredDelegatedFrozenBalanceForEnergy uint64 `json:"acquired_delegated_frozenV2_balance_for_energy"`
 }
 
 type Vote struct {
@@ -207,7 +209,9 @@ type GetAccountResponse struct {
 	AccountResource                    AccountResource `json:"account_resource"`
 	Votes                              []*Vote         `json:"votes"`
 	DelegatedFrozenBalanceForBandwidth uint64          `json:"delegated_frozenV2_balance_for_bandwidth"`
-	Allowance                          uint64          `json:"allowance"`
+	// bandwidth delegated to this account by other accounts
+	AcquiredDelegatedFrozenBalanceForBandwidth uint64 `json:"acquired_delegated_frozenV2_balance_for_bandwidth"`
+	Allowance                                  uint64 `json:"allowance"`
 }
 
 func (a GetAccountResponse) GetFrozenBalance() uint64 {
@@ -572,6 +576,32 @@ func (c *Client) ReadTrc20Balance(fromAddress string, contract string) (*big.Int
 	return value.SetBytes(response.ConstantResult[0]), nil
 }
 
+func (c *Client) ReadTrc20Allowance(ownerAddress string, spenderAddress string, contract string) (*big.Int, error) {
+	ownerB, _, err := base58.CheckDecode(ownerAddress)
+	if err != nil {
+		return &big.Int{}, fmt.Errorf("invalid owner address: %w", err)
+	}
+	spenderB, _, err := base58.CheckDecode(spenderAddress)
+	if err != nil {
+		return &big.Int{}, fmt.Errorf("invalid spender address: %w", err)
+	}
+	ownerHex := hex.EncodeToString(ownerB)
+	spenderHex := hex.EncodeToString(spenderB)
+	padding := "0000000000000000000000000000000000000000000000000000000000000000"
+	req := padding[len(ownerHex):] + ownerHex + padding[len(spenderHex):] + spenderHex
+
+	response, err := c.TriggerConstantContracts(ownerAddress, contract, "allowance(address,address)", req)
+	if err != nil {
+		return &big.Int{}, err
+	}
+
+	value := big.NewInt(0)
+	if len(response.ConstantResult) == 0 {
+		return value, fmt.Errorf("no allowance returned reading contract %s", contract)
+	}
+	return value.SetBytes(response.ConstantResult[0]), nil
+}
+
 func (c *Client) ReadTrc20Decimals(contract string) (*big.Int, error) {
 	// need to put some junk address or it fails
 	const randomPlaceholder = "TRGhNNfnmgLegT4zHNjEqDSADjgmnHvubJ"
@@ -662,6 +692,15 @@ type GetAccountResourcesResponse struct {
 	FreeNetUsed  int `json:"freeNetUsed"`
 	NetLimit     int `json:"NetLimit"`
 	NetUsed      int `json:"NetUsed"`
+	EnergyLimit  int `json:"EnergyLimit"`
+	EnergyUsed   int `json:"EnergyUsed"`
+}
+
+func (a GetAccountResourcesResponse) GetAvailableEnergy() int {
+	if a.EnergyLimit > a.EnergyUsed {
+		return a.EnergyLimit - a.EnergyUsed
+	}
+	return 0
 }
 
 func (a GetAccountResourcesResponse) GetAvailableBandwith() int {
@@ -703,3 +742,113 @@ func (c *Client) GetAccountResources(address string) (*GetAccountResourcesRespon
 
 	return parsed, nil
 }
+
+type DelegatedResourceAccountIndex struct {
+	Error
+	Account string `json:"account"`
+	// accounts that delegated resources to this account
+	FromAccounts []string `json:"fromAccounts"`
+	// accounts that this account delegated resources to
+	ToAccounts []string `json:"toAccounts"`
+}
+
+func (c *Client) GetDelegatedResourceAccountIndex(address string) (*DelegatedResourceAccountIndex, error) {
+	req, err := postRequest(c.Url("wallet/getdelegatedresourceaccountindexv2"), map[string]interface{}{
+		"value":   address,
+		"visible": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseResponse(resp, &DelegatedResourceAccountIndex{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkError(parsed.Error)
+	if err != nil {
+		return parsed, err
+	}
+
+	return parsed, nil
+}
+
+type DelegatedResource struct {
+	From                      string `json:"from"`
+	To                        string `json:"to"`
+	FrozenBalanceForBandwidth uint64 `json:"frozen_balance_for_bandwidth"`
+	FrozenBalanceForEnergy    uint64 `json:"frozen_balance_for_energy"`
+	// unix milliseconds that a locked delegation can be undelegated after
+	ExpireTimeForBandwidth int64 `json:"expire_time_for_bandwidth"`
+	ExpireTimeForEnergy    int64 `json:"expire_time_for_energy"`
+}
+
+type GetDelegatedResourceResponse struct {
+	Error
+	DelegatedResource []*DelegatedResource `json:"delegatedResource"`
+}
+
+func (c *Client) GetDelegatedResource(fromAddress string, toAddress string) (*GetDelegatedResourceResponse, error) {
+	req, err := postRequest(c.Url("wallet/getdelegatedresourcev2"), map[string]interface{}{
+		"fromAddress": fromAddress,
+		"toAddress":   toAddress,
+		"visible":     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseResponse(resp, &GetDelegatedResourceResponse{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkError(parsed.Error)
+	if err != nil {
+		return parsed, err
+	}
+
+	return parsed, nil
+}
+
+type GetCanDelegatedMaxSizeResponse struct {
+	Error
+	MaxSize uint64 `json:"max_size"`
+}
+
+// The max amount of staked TRX that the owner can delegate for the resource type (0 = bandwidth, 1 = energy)
+func (c *Client) GetCanDelegatedMaxSize(ownerAddress string, resourceType int) (*GetCanDelegatedMaxSizeResponse, error) {
+	req, err := postRequest(c.Url("wallet/getcandelegatedmaxsize"), map[string]interface{}{
+		"owner_address": ownerAddress,
+		"type":          resourceType,
+		"visible":       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseResponse(resp, &GetCanDelegatedMaxSizeResponse{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkError(parsed.Error)
+	if err != nil {
+		return parsed, err
+	}
+
+	return parsed, nil
+}
@@ -42,6 +42,7 @@ func init() {
 	registry.RegisterTxVariantInput(&StakeInput{})
 	registry.RegisterTxVariantInput(&UnstakeInput{})
 	registry.RegisterTxVariantInput(&WithdrawInput{})
+	registry.RegisterTxVariantInput(&ApprovalInput{})
 }
 
 func (i TxInput) GetTimestamp() int64 {
@@ -197,3 +198,14 @@ func (*WithdrawInput) Withdrawing() {}
 func (*WithdrawInput) GetVariant() xc.TxVariantInputType {
 	return xc.NewWithdrawingInputType(xc.DriverTron, string(xc.Native))
 }
+
+type ApprovalInput struct {
+	TxInput
+}
+
+var _ xc.ApprovalTxInput = &ApprovalInput{}
+
+func (*ApprovalInput) Approving() {}
+func (*ApprovalInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewApprovalInputType(xc.DriverTron, "trc20")
+}
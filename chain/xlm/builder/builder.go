@@ -206,14 +206,23 @@ func (builder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput)
 				xdrAsset.Type = xdr.AssetTypeAssetTypeNative
 			}
 
-			xdrPayment := xdr.PaymentOp{
-				Destination: destinationMuxedAccount,
-				Amount:      xdrAmount,
-				Asset:       xdrAsset,
-			}
-			xdrOperationBody, err := xdr.NewOperationBody(xdr.OperationTypePayment, xdrPayment)
-			if err != nil {
-				return &xlmtx.Tx{}, fmt.Errorf("failed to create operation body: %w", err)
+			var xdrOperationBody xdr.OperationBody
+			if isToken && txInput.CreateClaimableBalance {
+				// The recipient has no trustline, so the payment is held in a claimable balance
+				xdrOperationBody, err = newCreateClaimableBalanceBody(xdrAsset, xdrAmount, from, to)
+				if err != nil {
+					return &xlmtx.Tx{}, err
+				}
+			} else {
+				xdrPayment := xdr.PaymentOp{
+					Destination: destinationMuxedAccount,
+					Amount:      xdrAmount,
+					Asset:       xdrAsset,
+				}
+				xdrOperationBody, err = xdr.NewOperationBody(xdr.OperationTypePayment, xdrPayment)
+				if err != nil {
+					return &xlmtx.Tx{}, fmt.Errorf("failed to create operation body: %w", err)
+				}
 			}
 			// Skip the payment operation when amount is 0 (trustline-only transaction)
 			if xdrAmount > 0 {
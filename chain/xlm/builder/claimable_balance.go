@@ -0,0 +1,122 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/xlm"
+	common "github.com/cordialsys/crosschain/chain/xlm/common"
+	xlmtx "github.com/cordialsys/crosschain/chain/xlm/tx"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// CreateClaimableBalance holds the transfer amount in a claimable balance for the recipient.
+// Unlike a Payment, this does not require the recipient to have a trustline (or even exist).
+// The sender is added as a second claimant so that unclaimed balances may be reclaimed.
+func (builder TxBuilder) CreateClaimableBalance(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*TxInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T", input)
+	}
+	from := args.GetFrom()
+	contract, _ := args.GetContract()
+	xdrAsset, err := common.CreateAssetFromContract(contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create asset: %w", err)
+	}
+	amount := args.GetAmount()
+	if amount.IsZero() {
+		return nil, fmt.Errorf("claimable balance amount must be greater than 0")
+	}
+	body, err := newCreateClaimableBalanceBody(xdrAsset, xdr.Int64(amount.Int().Int64()), from, args.GetTo())
+	if err != nil {
+		return nil, err
+	}
+	memo, _ := args.GetMemo()
+	return builder.newTx(from, txInput, memo, []xdr.Operation{{Body: body}})
+}
+
+// ClaimClaimableBalance claims a balance (by its hex or strkey id) that lists `from` as a claimant.
+func (builder TxBuilder) ClaimClaimableBalance(from xc.Address, balanceId string, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*TxInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T", input)
+	}
+	xdrBalanceId, err := common.ClaimableBalanceIdFromString(balanceId)
+	if err != nil {
+		return nil, err
+	}
+	body, err := xdr.NewOperationBody(xdr.OperationTypeClaimClaimableBalance, xdr.ClaimClaimableBalanceOp{
+		BalanceId: xdrBalanceId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create claim claimable balance operation: %w", err)
+	}
+	return builder.newTx(from, txInput, "", []xdr.Operation{{Body: body}})
+}
+
+func newCreateClaimableBalanceBody(asset xdr.Asset, amount xdr.Int64, from xc.Address, to xc.Address) (xdr.OperationBody, error) {
+	claimants := []xdr.Claimant{}
+	for _, claimant := range []xc.Address{to, from} {
+		accountId, err := xdr.AddressToAccountId(string(claimant))
+		if err != nil {
+			return xdr.OperationBody{}, fmt.Errorf("invalid claimant address %s: %w", claimant, err)
+		}
+		claimants = append(claimants, xdr.Claimant{
+			Type: xdr.ClaimantTypeClaimantTypeV0,
+			V0: &xdr.ClaimantV0{
+				Destination: accountId,
+				Predicate: xdr.ClaimPredicate{
+					Type: xdr.ClaimPredicateTypeClaimPredicateUnconditional,
+				},
+			},
+		})
+	}
+	body, err := xdr.NewOperationBody(xdr.OperationTypeCreateClaimableBalance, xdr.CreateClaimableBalanceOp{
+		Asset:     asset,
+		Amount:    amount,
+		Claimants: claimants,
+	})
+	if err != nil {
+		return xdr.OperationBody{}, fmt.Errorf("failed to create claimable balance operation: %w", err)
+	}
+	return body, nil
+}
+
+// newTx wraps operations, sourced from the sender, into a transaction envelope
+func (builder TxBuilder) newTx(from xc.Address, txInput *TxInput, memo string, operations []xdr.Operation) (xc.Tx, error) {
+	sourceAccount, err := common.MuxedAccountFromAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid `from` address: %w", err)
+	}
+	preconditions := xlm.Preconditions{
+		TimeBounds: xlm.NewTimeout(time.Unix(txInput.Timestamp, 0), txInput.TransactionActiveTime),
+	}
+	xdrMemo := xdr.Memo{}
+	if memo != "" {
+		xdrMemo, err = xdr.NewMemo(xdr.MemoTypeMemoText, memo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create memo: %w", err)
+		}
+	}
+	txe := xdr.TransactionV1Envelope{
+		Tx: xdr.Transaction{
+			SourceAccount: sourceAccount,
+			Fee:           xdr.Uint32(txInput.MaxFee),
+			SeqNum:        xdr.SequenceNumber(txInput.GetXlmSequence()),
+			Cond:          preconditions.BuildXDR(),
+			Memo:          xdrMemo,
+			Operations:    operations,
+		},
+	}
+	envelope, err := xdr.NewTransactionEnvelope(xdr.EnvelopeTypeEnvelopeTypeTx, txe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction envelope: %w", err)
+	}
+	return &xlmtx.Tx{
+		TxEnvelope:        &envelope,
+		NetworkPassphrase: txInput.Passphrase,
+	}, nil
+}
@@ -0,0 +1,113 @@
+package builder_test
+
+import (
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/builder/buildertest"
+	"github.com/cordialsys/crosschain/chain/xlm/builder"
+	"github.com/cordialsys/crosschain/chain/xlm/tx_input"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+const usdc = xc.ContractAddress("USDC-GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5")
+
+func TestTransferCreatesClaimableBalance(t *testing.T) {
+	chain := xc.NewChainConfig(xc.XLM)
+	txBuilder, _ := builder.NewTxBuilder(chain.Base())
+	from := xc.Address("GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H")
+	to := xc.Address("GCITKPHEIYPB743IM4DYB23IOZIRBAQ76J6QNKPPXVI2N575JZ3Z65DI")
+	input := &tx_input.TxInput{DestinationFunded: true, CreateClaimableBalance: true, MaxFee: 100}
+	args := buildertest.MustNewTransferArgs(chain.Base(), from, to, xc.NewAmountBlockchainFromUint64(10), buildertest.OptionContractAddress(usdc))
+
+	nt, err := txBuilder.Transfer(args, input)
+	require.NoError(t, err)
+	ops := nt.(*Tx).TxEnvelope.Operations()
+	require.Len(t, ops, 1)
+	create, ok := ops[0].Body.GetCreateClaimableBalanceOp()
+	require.True(t, ok)
+	require.EqualValues(t, 10, create.Amount)
+	require.Equal(t, "USDC", create.Asset.GetCode())
+	require.Len(t, create.Claimants, 2)
+	require.Equal(t, string(to), create.Claimants[0].V0.Destination.Address())
+	require.Equal(t, string(from), create.Claimants[1].V0.Destination.Address())
+
+	// native transfers are unaffected
+	args = buildertest.MustNewTransferArgs(chain.Base(), from, to, xc.NewAmountBlockchainFromUint64(10))
+	nt, err = txBuilder.Transfer(args, input)
+	require.NoError(t, err)
+	require.Equal(t, xdr.OperationTypePayment, nt.(*Tx).TxEnvelope.Operations()[0].Body.Type)
+}
+
+func TestClaimableBalanceOperations(t *testing.T) {
+	chain := xc.NewChainConfig(xc.XLM)
+	txBuilder, _ := builder.NewTxBuilder(chain.Base())
+	from := xc.Address("GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H")
+	to := xc.Address("GCITKPHEIYPB743IM4DYB23IOZIRBAQ76J6QNKPPXVI2N575JZ3Z65DI")
+	input := &tx_input.TxInput{Sequence: 1213, MaxFee: 100, Passphrase: "Test SDF Network ; September 2015"}
+
+	args := buildertest.MustNewTransferArgs(chain.Base(), from, to, xc.NewAmountBlockchainFromUint64(25), buildertest.OptionMemo("hi"))
+	nt, err := txBuilder.CreateClaimableBalance(args, input)
+	require.NoError(t, err)
+	env := nt.(*Tx).TxEnvelope
+	require.EqualValues(t, 1213, env.SeqNum())
+	source := env.SourceAccount()
+	require.Equal(t, string(from), source.Address())
+	text, _ := env.Memo().GetText()
+	require.Equal(t, "hi", text)
+	create, ok := env.Operations()[0].Body.GetCreateClaimableBalanceOp()
+	require.True(t, ok)
+	require.Equal(t, xdr.AssetTypeAssetTypeNative, create.Asset.Type)
+
+	args = buildertest.MustNewTransferArgs(chain.Base(), from, to, xc.NewAmountBlockchainFromUint64(0))
+	_, err = txBuilder.CreateClaimableBalance(args, input)
+	require.ErrorContains(t, err, "greater than 0")
+
+	balanceId := "00000000da0d57da7d4850e7fc10d2a9d0ebc731f7afb40574c03395b17d49149b91f5be"
+	nt, err = txBuilder.ClaimClaimableBalance(to, balanceId, input)
+	require.NoError(t, err)
+	claim, ok := nt.(*Tx).TxEnvelope.Operations()[0].Body.GetClaimClaimableBalanceOp()
+	require.True(t, ok)
+	encoded, err := xdr.MarshalHex(claim.BalanceId)
+	require.NoError(t, err)
+	require.Equal(t, balanceId, encoded)
+
+	_, err = txBuilder.ClaimClaimableBalance(to, "invalid", input)
+	require.Error(t, err)
+}
+
+func TestPathPayments(t *testing.T) {
+	chain := xc.NewChainConfig(xc.XLM)
+	txBuilder, _ := builder.NewTxBuilder(chain.Base())
+	input := &tx_input.TxInput{Sequence: 1213, MaxFee: 100}
+	args := builder.PathPaymentArgs{
+		From:       "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H",
+		To:         "GCITKPHEIYPB743IM4DYB23IOZIRBAQ76J6QNKPPXVI2N575JZ3Z65DI",
+		SendAsset:  "XLM",
+		DestAsset:  usdc,
+		SendAmount: xc.NewAmountBlockchainFromUint64(1000),
+		DestAmount: xc.NewAmountBlockchainFromUint64(90),
+		Path:       []xc.ContractAddress{"EURC-GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5"},
+	}
+
+	nt, err := txBuilder.PathPaymentStrictSend(args, input)
+	require.NoError(t, err)
+	send, ok := nt.(*Tx).TxEnvelope.Operations()[0].Body.GetPathPaymentStrictSendOp()
+	require.True(t, ok)
+	require.EqualValues(t, 1000, send.SendAmount)
+	require.EqualValues(t, 90, send.DestMin)
+	require.Len(t, send.Path, 1)
+	require.Equal(t, "EURC", send.Path[0].GetCode())
+
+	nt, err = txBuilder.PathPaymentStrictReceive(args, input)
+	require.NoError(t, err)
+	receive, ok := nt.(*Tx).TxEnvelope.Operations()[0].Body.GetPathPaymentStrictReceiveOp()
+	require.True(t, ok)
+	require.EqualValues(t, 1000, receive.SendMax)
+	require.EqualValues(t, 90, receive.DestAmount)
+
+	args.DestAsset = "invalid"
+	_, err = txBuilder.PathPaymentStrictSend(args, input)
+	require.ErrorContains(t, err, "invalid destination asset")
+}
@@ -0,0 +1,101 @@
+package builder
+
+import (
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	common "github.com/cordialsys/crosschain/chain/xlm/common"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// PathPaymentArgs describes a cross-asset payment.  Assets use the same "CODE-ISSUER" (or "XLM")
+// format as contract addresses.
+type PathPaymentArgs struct {
+	From      xc.Address
+	To        xc.Address
+	SendAsset xc.ContractAddress
+	DestAsset xc.ContractAddress
+	// For strict-send, the exact amount sent; for strict-receive, the maximum amount sent.
+	SendAmount xc.AmountBlockchain
+	// For strict-send, the minimum amount received; for strict-receive, the exact amount received.
+	DestAmount xc.AmountBlockchain
+	// Intermediate assets to trade through, as discovered via Horizon's /paths endpoints.
+	Path []xc.ContractAddress
+	Memo string
+}
+
+func (args PathPaymentArgs) assets() (xdr.Asset, xdr.Asset, []xdr.Asset, xdr.MuxedAccount, error) {
+	sendAsset, err := common.CreateAssetFromContract(args.SendAsset)
+	if err != nil {
+		return xdr.Asset{}, xdr.Asset{}, nil, xdr.MuxedAccount{}, fmt.Errorf("invalid send asset: %w", err)
+	}
+	destAsset, err := common.CreateAssetFromContract(args.DestAsset)
+	if err != nil {
+		return xdr.Asset{}, xdr.Asset{}, nil, xdr.MuxedAccount{}, fmt.Errorf("invalid destination asset: %w", err)
+	}
+	path := []xdr.Asset{}
+	for _, contract := range args.Path {
+		asset, err := common.CreateAssetFromContract(contract)
+		if err != nil {
+			return xdr.Asset{}, xdr.Asset{}, nil, xdr.MuxedAccount{}, fmt.Errorf("invalid path asset: %w", err)
+		}
+		path = append(path, asset)
+	}
+	// At most 5 intermediate assets are permitted
+	if len(path) > 5 {
+		return xdr.Asset{}, xdr.Asset{}, nil, xdr.MuxedAccount{}, fmt.Errorf("path may have at most 5 assets, got %d", len(path))
+	}
+	destination, err := common.MuxedAccountFromAddress(args.To)
+	if err != nil {
+		return xdr.Asset{}, xdr.Asset{}, nil, xdr.MuxedAccount{}, fmt.Errorf("invalid `to` address: %w", err)
+	}
+	return sendAsset, destAsset, path, destination, nil
+}
+
+// PathPaymentStrictSend sends exactly SendAmount, requiring at least DestAmount to be received.
+func (builder TxBuilder) PathPaymentStrictSend(args PathPaymentArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*TxInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T", input)
+	}
+	sendAsset, destAsset, path, destination, err := args.assets()
+	if err != nil {
+		return nil, err
+	}
+	body, err := xdr.NewOperationBody(xdr.OperationTypePathPaymentStrictSend, xdr.PathPaymentStrictSendOp{
+		SendAsset:   sendAsset,
+		SendAmount:  xdr.Int64(args.SendAmount.Int().Int64()),
+		Destination: destination,
+		DestAsset:   destAsset,
+		DestMin:     xdr.Int64(args.DestAmount.Int().Int64()),
+		Path:        path,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create path payment operation: %w", err)
+	}
+	return builder.newTx(args.From, txInput, args.Memo, []xdr.Operation{{Body: body}})
+}
+
+// PathPaymentStrictReceive receives exactly DestAmount, spending at most SendAmount.
+func (builder TxBuilder) PathPaymentStrictReceive(args PathPaymentArgs, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*TxInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T", input)
+	}
+	sendAsset, destAsset, path, destination, err := args.assets()
+	if err != nil {
+		return nil, err
+	}
+	body, err := xdr.NewOperationBody(xdr.OperationTypePathPaymentStrictReceive, xdr.PathPaymentStrictReceiveOp{
+		SendAsset:   sendAsset,
+		SendMax:     xdr.Int64(args.SendAmount.Int().Int64()),
+		Destination: destination,
+		DestAsset:   destAsset,
+		DestAmount:  xdr.Int64(args.DestAmount.Int().Int64()),
+		Path:        path,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create path payment operation: %w", err)
+	}
+	return builder.newTx(args.From, txInput, args.Memo, []xdr.Operation{{Body: body}})
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/xlm/client/types"
+	"github.com/cordialsys/crosschain/chain/xlm/common"
+	xlminput "github.com/cordialsys/crosschain/chain/xlm/tx_input"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	"github.com/cordialsys/crosschain/pkg/integer"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// FetchClaimableBalances lists the claimable balances that the address may claim
+func (client *Client) FetchClaimableBalances(ctx context.Context, address xc.Address) ([]types.ClaimableBalance, error) {
+	url := fmt.Sprintf("%s/claimable_balances?claimant=%s&limit=200", client.Url, string(address))
+	var result types.GetClaimableBalancesResult
+	if err := client.Get(url, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch claimable balances: %w", err)
+	}
+	return result.Embedded.Records, nil
+}
+
+// FetchOperationInput returns the input for a transaction from `from` with the given number of operations,
+// e.g. for claiming a claimable balance or a path payment.
+func (client *Client) FetchOperationInput(ctx context.Context, from xc.Address, operations uint32) (*xlminput.TxInput, error) {
+	config := client.Asset.GetChain()
+	txInput := xlminput.NewTxInput(config.ChainID.AsString())
+	accountDetails, err := client.FetchAccountDetails(from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account details: %w", err)
+	}
+	currentSequence, err := strconv.ParseInt(accountDetails.Sequence, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sequence number: %w", err)
+	}
+	ledger, err := client.FetchLatestLedgerInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ledger info: %w", err)
+	}
+	baseFee := uint32(100)
+	if ledger.BaseFeeInStroops > 0 {
+		baseFee = uint32(ledger.BaseFeeInStroops)
+	}
+	if operations == 0 {
+		operations = 1
+	}
+
+	nextSequence := currentSequence + 1
+	txInput.Sequence = integer.Int64(nextSequence)
+	txInput.SequenceOld = nextSequence
+	txInput.MinLedgerSequence = ledger.Sequence
+	txInput.TransactionActiveTime = config.TransactionActiveTime
+	txInput.MaxFee = baseFee * operations
+	if config.ChainGasMultiplier > 0 && config.ChainGasMultiplier != 1.0 {
+		txInput.MaxFee = uint32(float64(txInput.MaxFee) * config.ChainGasMultiplier)
+	}
+	return txInput, nil
+}
+
+// FetchStrictSendPaths discovers paths for sending exactly `sendAmount` of `sendAsset`, to any of the `destAssets`.
+func (client *Client) FetchStrictSendPaths(ctx context.Context, sendAsset xc.ContractAddress, sendAmount xc.AmountBlockchain, destAssets []xc.ContractAddress) ([]types.PaymentPath, error) {
+	params := url.Values{}
+	if err := addAssetParams(params, "source_", sendAsset); err != nil {
+		return nil, err
+	}
+	params.Set("source_amount", sendAmount.ToHuman(types.Decimals).String())
+	params.Set("destination_assets", joinAssets(destAssets))
+
+	var result types.GetPathsResult
+	if err := client.Get(fmt.Sprintf("%s/paths/strict-send?%s", client.Url, params.Encode()), &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch strict-send paths: %w", err)
+	}
+	return result.Embedded.Records, nil
+}
+
+// FetchStrictReceivePaths discovers paths for receiving exactly `destAmount` of `destAsset`, from any of the `sourceAssets`.
+func (client *Client) FetchStrictReceivePaths(ctx context.Context, sourceAssets []xc.ContractAddress, destAsset xc.ContractAddress, destAmount xc.AmountBlockchain) ([]types.PaymentPath, error) {
+	params := url.Values{}
+	if err := addAssetParams(params, "destination_", destAsset); err != nil {
+		return nil, err
+	}
+	params.Set("destination_amount", destAmount.ToHuman(types.Decimals).String())
+	params.Set("source_assets", joinAssets(sourceAssets))
+
+	var result types.GetPathsResult
+	if err := client.Get(fmt.Sprintf("%s/paths/strict-receive?%s", client.Url, params.Encode()), &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch strict-receive paths: %w", err)
+	}
+	return result.Embedded.Records, nil
+}
+
+func addAssetParams(params url.Values, prefix string, contract xc.ContractAddress) error {
+	asset, err := common.CreateAssetFromContract(contract)
+	if err != nil {
+		return fmt.Errorf("invalid asset %s: %w", contract, err)
+	}
+	var assetType, code, issuer string
+	if err := asset.Extract(&assetType, &code, &issuer); err != nil {
+		return fmt.Errorf("invalid asset %s: %w", contract, err)
+	}
+	params.Set(prefix+"asset_type", assetType)
+	if asset.Type != xdr.AssetTypeAssetTypeNative {
+		params.Set(prefix+"asset_code", code)
+		params.Set(prefix+"asset_issuer", issuer)
+	}
+	return nil
+}
+
+func joinAssets(contracts []xc.ContractAddress) string {
+	assets := []string{}
+	for _, contract := range contracts {
+		assets = append(assets, types.ContractToAsset(contract))
+	}
+	return strings.Join(assets, ",")
+}
+
+// FetchTxEffects returns the effects of a transaction, used to resolve amounts not present in the envelope
+func (client *Client) FetchTxEffects(txHash xc.TxHash) ([]types.Effect, error) {
+	url := fmt.Sprintf("%s/transactions/%s/effects?limit=200", client.Url, string(txHash))
+	var result types.GetEffectsResult
+	if err := client.Get(url, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction effects: %w", err)
+	}
+	return result.Embedded.Records, nil
+}
+
+// Created claimable balances produce a movement from the sender to the balance id.
+func ProcessCreateClaimableBalance(txInfo *txinfo.TxInfo, source xdr.MuxedAccount, balanceId xdr.ClaimableBalanceId, op xdr.CreateClaimableBalanceOp) error {
+	id, err := common.ClaimableBalanceIdToString(balanceId)
+	if err != nil {
+		return fmt.Errorf("failed to encode claimable balance id: %w", err)
+	}
+	sourceAccount, err := source.GetAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get source account: %w", err)
+	}
+	xcAmount, ok := xc.NewAmountBlockchainFromInt64(int64(op.Amount))
+	if !ok {
+		return fmt.Errorf("failed to construct new blockchain amount from: %v", int64(op.Amount))
+	}
+	movement := txinfo.NewMovement(GetAssetCode(op.Asset), "")
+	movement.AddSource(xc.Address(sourceAccount), xcAmount, nil)
+	movement.AddDestination(xc.Address(id), xcAmount, nil)
+	txInfo.AddMovement(movement)
+	return nil
+}
+
+// Claimed balances produce a movement from the balance id to the claimant.
+func ProcessClaimClaimableBalance(txInfo *txinfo.TxInfo, claimant xdr.MuxedAccount, op xdr.ClaimClaimableBalanceOp, effects []types.Effect) error {
+	id, err := common.ClaimableBalanceIdToString(op.BalanceId)
+	if err != nil {
+		return fmt.Errorf("failed to encode claimable balance id: %w", err)
+	}
+	claimantAddress, err := claimant.GetAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get claimant account: %w", err)
+	}
+	for _, effect := range effects {
+		if effect.Type != types.EffectClaimableBalanceClaimed || effect.BalanceId != id {
+			continue
+		}
+		amount, err := xc.NewAmountHumanReadableFromStr(effect.Amount)
+		if err != nil {
+			return fmt.Errorf("failed to parse claimed amount: %w", err)
+		}
+		xcAmount := amount.ToBlockchain(types.Decimals)
+		movement := txinfo.NewMovement(xc.NativeAsset(types.AssetToContract(effect.Asset)), "")
+		movement.AddSource(xc.Address(id), xcAmount, nil)
+		movement.AddDestination(xc.Address(claimantAddress), xcAmount, nil)
+		txInfo.AddMovement(movement)
+	}
+	return nil
+}
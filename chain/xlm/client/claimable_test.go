@@ -0,0 +1,192 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/builder"
+	client "github.com/cordialsys/crosschain/chain/xlm/client"
+	"github.com/cordialsys/crosschain/chain/xlm/client/types"
+	"github.com/cordialsys/crosschain/chain/xlm/common"
+	txinput "github.com/cordialsys/crosschain/chain/xlm/tx_input"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testFrom   = "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H"
+	testTo     = "GCITKPHEIYPB743IM4DYB23IOZIRBAQ76J6QNKPPXVI2N575JZ3Z65DI"
+	testIssuer = "GBBD47IF6LWK7P7MDEVSCWR7DPUWV3NY3DTQEVFL4NAT4AQH3ZLLFLA5"
+)
+
+func newTestClient(t *testing.T, url string) *client.Client {
+	chain := xc.NewChainConfig(xc.XLM).
+		WithUrl(url).
+		WithTransactionActiveTime(2 * time.Hour).
+		WithDecimals(7).
+		WithChainID("Test SDF Network ; September 2015").
+		WithGasBudgetDefault(xc.NewAmountHumanReadableFromFloat(0.00001))
+	c, err := client.NewClient(chain)
+	require.NoError(t, err)
+	return c
+}
+
+func TestFetchTransferInputClaimableBalance(t *testing.T) {
+	vectors := []struct {
+		name                 string
+		recipientBalances    []types.Balance
+		recipientMissing     bool
+		expectClaimable      bool
+		expectDestinationFun bool
+	}{
+		{
+			name:                 "RecipientHasTrustline",
+			recipientBalances:    []types.Balance{{Balance: "1.0", AssetType: "credit_alphanum4", AssetCode: "USDC", AssetIssuer: testIssuer}},
+			expectDestinationFun: true,
+		},
+		{
+			name:                 "RecipientMissingTrustline",
+			recipientBalances:    []types.Balance{{Balance: "1.0", AssetType: "native"}},
+			expectClaimable:      true,
+			expectDestinationFun: true,
+		},
+		{
+			name:             "RecipientNotFunded",
+			recipientMissing: true,
+			expectClaimable:  true,
+		},
+	}
+	for _, vector := range vectors {
+		t.Run(vector.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var err error
+				switch {
+				case strings.Contains(r.URL.Path, "/accounts/"+testFrom):
+					err = json.NewEncoder(w).Encode(types.GetAccountResult{
+						Sequence: "1212",
+						Balances: []types.Balance{
+							{Balance: "2.0", AssetType: "native"},
+							{Balance: "5.0", AssetType: "credit_alphanum4", AssetCode: "USDC", AssetIssuer: testIssuer},
+						},
+					})
+				case strings.Contains(r.URL.Path, "/accounts/"+testTo):
+					if vector.recipientMissing {
+						w.WriteHeader(http.StatusNotFound)
+						_, err = w.Write([]byte(`{"type":"https://stellar.org/horizon-errors/not_found","title":"Resource Missing","status":404}`))
+					} else {
+						err = json.NewEncoder(w).Encode(types.GetAccountResult{Sequence: "1", Balances: vector.recipientBalances})
+					}
+				case strings.Contains(r.URL.Path, "/ledgers"):
+					err = json.NewEncoder(w).Encode(types.GetLatestLedgerResult{Embedded: types.Records{Records: []types.GetLedgerResult{{Sequence: 1111}}}})
+				default:
+					t.Errorf("unexpected url: %s", r.URL)
+				}
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			c := newTestClient(t, server.URL)
+			args, err := builder.NewTransferArgs(c.Asset.GetChain().Base(), testFrom, testTo, xc.NewAmountBlockchainFromUint64(10),
+				builder.OptionContractAddress("USDC-"+testIssuer))
+			require.NoError(t, err)
+			input, err := c.FetchTransferInput(context.Background(), args)
+			require.NoError(t, err)
+			txInput := input.(*txinput.TxInput)
+			require.Equal(t, vector.expectClaimable, txInput.CreateClaimableBalance)
+			require.Equal(t, vector.expectDestinationFun, txInput.DestinationFunded)
+			require.False(t, txInput.NeedsCreateTrustline)
+		})
+	}
+}
+
+func TestFetchClaimableBalances(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/claimable_balances", r.URL.Path)
+		require.Equal(t, testTo, r.URL.Query().Get("claimant"))
+		_, err := w.Write([]byte(`{"_embedded":{"records":[{"id":"00000000da0d57da7d4850e7fc10d2a9d0ebc731f7afb40574c03395b17d49149b91f5be","asset":"USDC:` + testIssuer + `","amount":"10.0000000","sponsor":"` + testFrom + `","claimants":[{"destination":"` + testTo + `","predicate":{"unconditional":true}}]}]}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL)
+	balances, err := c.FetchClaimableBalances(context.Background(), testTo)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	require.Equal(t, "10.0000000", balances[0].Amount)
+	require.Equal(t, xc.ContractAddress("USDC-"+testIssuer), types.AssetToContract(balances[0].Asset))
+	require.Equal(t, testTo, balances[0].Claimants[0].Destination)
+}
+
+func TestFetchPaths(t *testing.T) {
+	pathsResponse := `{"_embedded":{"records":[{"source_asset_type":"native","source_amount":"100.0000000","destination_asset_type":"credit_alphanum4","destination_asset_code":"USDC","destination_asset_issuer":"` + testIssuer + `","destination_amount":"9.5000000","path":[{"asset_type":"credit_alphanum4","asset_code":"EURC","asset_issuer":"` + testIssuer + `"}]}]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/paths/strict-send":
+			require.Equal(t, "native", query.Get("source_asset_type"))
+			require.Equal(t, "100", query.Get("source_amount"))
+			require.Equal(t, "USDC:"+testIssuer, query.Get("destination_assets"))
+		case "/paths/strict-receive":
+			require.Equal(t, "credit_alphanum4", query.Get("destination_asset_type"))
+			require.Equal(t, "USDC", query.Get("destination_asset_code"))
+			require.Equal(t, testIssuer, query.Get("destination_asset_issuer"))
+			require.Equal(t, "9.5", query.Get("destination_amount"))
+			require.Equal(t, "native", query.Get("source_assets"))
+		default:
+			t.Errorf("unexpected url: %s", r.URL)
+		}
+		_, err := w.Write([]byte(pathsResponse))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL)
+	usdc := xc.ContractAddress("USDC-" + testIssuer)
+	paths, err := c.FetchStrictSendPaths(context.Background(), "XLM", xc.NewAmountBlockchainFromUint64(1_000_000_000), []xc.ContractAddress{usdc})
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	require.Equal(t, []xc.ContractAddress{xc.ContractAddress("EURC-" + testIssuer)}, paths[0].GetPath())
+
+	paths, err = c.FetchStrictReceivePaths(context.Background(), []xc.ContractAddress{"XLM"}, usdc, xc.NewAmountBlockchainFromUint64(95_000_000))
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	require.Equal(t, "9.5000000", paths[0].DestinationAmount)
+}
+
+func TestProcessClaimableBalances(t *testing.T) {
+	source := common.MustMuxedAccountFromAddres(testFrom)
+	claimant := common.MustMuxedAccountFromAddres(testTo)
+	balanceId, err := common.ComputeClaimableBalanceId(source, 1213, 0)
+	require.NoError(t, err)
+	id, err := common.ClaimableBalanceIdToString(balanceId)
+	require.NoError(t, err)
+	asset, err := common.CreateAssetFromContract(xc.ContractAddress("USDC-" + testIssuer))
+	require.NoError(t, err)
+
+	info := txinfo.TxInfo{}
+	err = client.ProcessCreateClaimableBalance(&info, source, balanceId, xdr.CreateClaimableBalanceOp{Asset: asset, Amount: 100})
+	require.NoError(t, err)
+	require.Len(t, info.Movements, 1)
+	require.Equal(t, xc.Address(testFrom), info.Movements[0].From[0].AddressId)
+	require.Equal(t, xc.Address(id), info.Movements[0].To[0].AddressId)
+	require.EqualValues(t, 100, info.Movements[0].To[0].Balance.Uint64())
+
+	effects := []types.Effect{
+		{Type: "account_credited", Account: testTo, Asset: "USDC:" + testIssuer, Amount: "0.0000100"},
+		{Type: types.EffectClaimableBalanceClaimed, Account: testTo, BalanceId: id, Asset: "USDC:" + testIssuer, Amount: "0.0000100"},
+	}
+	err = client.ProcessClaimClaimableBalance(&info, claimant, xdr.ClaimClaimableBalanceOp{BalanceId: balanceId}, effects)
+	require.NoError(t, err)
+	require.Len(t, info.Movements, 2)
+	require.Equal(t, xc.Address(id), info.Movements[1].From[0].AddressId)
+	require.Equal(t, xc.Address(testTo), info.Movements[1].To[0].AddressId)
+	require.EqualValues(t, 100, info.Movements[1].To[0].Balance.Uint64())
+	require.Equal(t, xc.ContractAddress("USDC-"+testIssuer), info.Movements[1].XContract)
+}
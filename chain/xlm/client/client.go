@@ -135,7 +135,7 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	} else {
 		// Check if destination account exists on the network.
 		// Stellar requires CreateAccount for new accounts instead of Payment.
-		_, destErr := client.FetchAccountDetails(args.GetTo())
+		destinationDetails, destErr := client.FetchAccountDetails(args.GetTo())
 		if destErr != nil {
 			var queryErr *types.QueryProblem
 			if stderrors.As(destErr, &queryErr) && queryErr.Status == 404 {
@@ -146,6 +146,18 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		} else {
 			txInput.DestinationFunded = true
 		}
+
+		// Recipients without a trustline cannot receive a Payment of the token, so
+		// it's sent as a claimable balance instead.
+		if contract, ok := args.GetContract(); ok {
+			contractDetails, err := common.GetAssetAndIssuerFromContract(string(contract))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse contract: %w", err)
+			}
+			if !hasTrustline(destinationDetails, contractDetails) {
+				txInput.CreateClaimableBalance = true
+			}
+		}
 	}
 
 	// Check if the sender needs a trustline for the token asset.
@@ -155,15 +167,10 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse contract: %w", err)
 		}
-		hasTrustline := false
-		for _, bal := range accountDetails.Balances {
-			if bal.AssetCode == contractDetails.AssetCode && bal.AssetIssuer == string(contractDetails.Issuer) {
-				hasTrustline = true
-				break
-			}
-		}
-		if !hasTrustline {
+		if !hasTrustline(accountDetails, contractDetails) {
 			txInput.NeedsCreateTrustline = true
+			// The sender is only setting up their own trustline
+			txInput.CreateClaimableBalance = false
 		}
 	}
 
@@ -250,6 +257,15 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	return txInput, nil
 }
 
+func hasTrustline(account types.GetAccountResult, asset common.AssetDetails) bool {
+	for _, bal := range account.Balances {
+		if bal.AssetCode == asset.AssetCode && bal.AssetIssuer == string(asset.Issuer) {
+			return true
+		}
+	}
+	return false
+}
+
 // countOperations mirrors the branches in chain/xlm/builder/builder.go so the
 // inclusion fee (base_fee * num_operations) can be computed without building
 // the tx. Soroban paths return 1; resource fees are tracked separately.
@@ -414,7 +430,8 @@ func (client *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinf
 	}
 
 	// Populate movements depending on operation type
-	for _, operation := range operations {
+	var effects []types.Effect
+	for opIndex, operation := range operations {
 		sourceAccountMaybe := operation.SourceAccount
 		if sourceAccountMaybe == nil {
 			// Use source account of the enveloping transaction if not present in the operation
@@ -441,6 +458,32 @@ func (client *Client) FetchTxInfo(ctx context.Context, args *txinfo.Args) (txinf
 			}
 		}
 
+		// Claimable balances are held by the balance id until claimed
+		createClaimable, isCreateClaimable := operation.Body.GetCreateClaimableBalanceOp()
+		if isCreateClaimable {
+			balanceId, err := common.ComputeClaimableBalanceId(envelope.SourceAccount(), envelope.SeqNum(), opIndex)
+			if err != nil {
+				return txinfo.TxInfo{}, FailedToProceedClaimableBalance(err)
+			}
+			if err := ProcessCreateClaimableBalance(&txInfo, sourceAccount, balanceId, createClaimable); err != nil {
+				return txinfo.TxInfo{}, FailedToProceedClaimableBalance(err)
+			}
+		}
+
+		// The claimed amount is not in the envelope, so it's looked up from the effects
+		claimClaimable, isClaimClaimable := operation.Body.GetClaimClaimableBalanceOp()
+		if isClaimClaimable && response.Successful {
+			if effects == nil {
+				effects, err = client.FetchTxEffects(txHash)
+				if err != nil {
+					return txinfo.TxInfo{}, FailedToProceedClaimableBalance(err)
+				}
+			}
+			if err := ProcessClaimClaimableBalance(&txInfo, sourceAccount, claimClaimable, effects); err != nil {
+				return txinfo.TxInfo{}, FailedToProceedClaimableBalance(err)
+			}
+		}
+
 		// PathPayments involve different source and destination assets
 		pathPaymentSend, isPathSend := operation.Body.GetPathPaymentStrictSendOp()
 		if isPathSend {
@@ -747,3 +790,7 @@ func FailedToProceedPayment(err error) error {
 func FailedToProceedPathPayment(err error) error {
 	return fmt.Errorf("failed to process path payment: %w", err)
 }
+
+func FailedToProceedClaimableBalance(err error) error {
+	return fmt.Errorf("failed to process claimable balance: %w", err)
+}
@@ -2,7 +2,9 @@ package types
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	xc "github.com/cordialsys/crosschain"
 	"github.com/stellar/go-stellar-sdk/xdr"
@@ -244,3 +246,104 @@ type GetLedgerTransactionResult struct {
 type TransactionRecords struct {
 	Records []GetTransactionResult `json:"records"`
 }
+
+// ClaimableBalance is a balance held on chain until one of its claimants claims it
+type ClaimableBalance struct {
+	Id string `json:"id"`
+	// Asset is "native" or "{code}:{issuer}"
+	Asset     string     `json:"asset"`
+	Amount    string     `json:"amount"`
+	Sponsor   string     `json:"sponsor"`
+	Claimants []Claimant `json:"claimants"`
+}
+
+type Claimant struct {
+	Destination string          `json:"destination"`
+	Predicate   json.RawMessage `json:"predicate"`
+}
+
+type ClaimableBalanceRecords struct {
+	Records []ClaimableBalance `json:"records"`
+}
+
+type GetClaimableBalancesResult struct {
+	Embedded ClaimableBalanceRecords `json:"_embedded"`
+}
+
+type PathAsset struct {
+	AssetType   string `json:"asset_type"`
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+}
+
+// PaymentPath is a route between two assets, as returned by the /paths endpoints
+type PaymentPath struct {
+	SourceAssetType        string      `json:"source_asset_type"`
+	SourceAssetCode        string      `json:"source_asset_code"`
+	SourceAssetIssuer      string      `json:"source_asset_issuer"`
+	SourceAmount           string      `json:"source_amount"`
+	DestinationAssetType   string      `json:"destination_asset_type"`
+	DestinationAssetCode   string      `json:"destination_asset_code"`
+	DestinationAssetIssuer string      `json:"destination_asset_issuer"`
+	DestinationAmount      string      `json:"destination_amount"`
+	Path                   []PathAsset `json:"path"`
+}
+
+type PaymentPathRecords struct {
+	Records []PaymentPath `json:"records"`
+}
+
+type GetPathsResult struct {
+	Embedded PaymentPathRecords `json:"_embedded"`
+}
+
+const EffectClaimableBalanceClaimed = "claimable_balance_claimed"
+
+type Effect struct {
+	Type      string `json:"type"`
+	Account   string `json:"account"`
+	BalanceId string `json:"balance_id"`
+	// Asset is "native" or "{code}:{issuer}"
+	Asset  string `json:"asset"`
+	Amount string `json:"amount"`
+}
+
+type EffectRecords struct {
+	Records []Effect `json:"records"`
+}
+
+type GetEffectsResult struct {
+	Embedded EffectRecords `json:"_embedded"`
+}
+
+// AssetToContract converts a Horizon asset ("native" or "{code}:{issuer}") to a contract ("XLM" or "{code}-{issuer}")
+func AssetToContract(asset string) xc.ContractAddress {
+	if asset == AssetTypeNative || asset == "" {
+		return "XLM"
+	}
+	return xc.ContractAddress(strings.Replace(asset, ":", "-", 1))
+}
+
+// ContractToAsset converts a contract ("XLM" or "{code}-{issuer}") to a Horizon asset ("native" or "{code}:{issuer}")
+func ContractToAsset(contract xc.ContractAddress) string {
+	if contract == "XLM" || contract == "" {
+		return AssetTypeNative
+	}
+	return strings.Replace(string(contract), "-", ":", 1)
+}
+
+func (asset PathAsset) Contract() xc.ContractAddress {
+	if asset.AssetType == AssetTypeNative {
+		return "XLM"
+	}
+	return xc.ContractAddress(asset.AssetCode + "-" + asset.AssetIssuer)
+}
+
+// GetPath returns the intermediate assets of the path as contracts
+func (path PaymentPath) GetPath() []xc.ContractAddress {
+	contracts := []xc.ContractAddress{}
+	for _, asset := range path.Path {
+		contracts = append(contracts, asset.Contract())
+	}
+	return contracts
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// ClaimableBalanceIdFromString parses a claimable balance id, either in the hex format
+// used by Horizon ("00000000...") or in the strkey format ("B...").
+func ClaimableBalanceIdFromString(id string) (xdr.ClaimableBalanceId, error) {
+	var balanceId xdr.ClaimableBalanceId
+	if strings.HasPrefix(id, "B") {
+		if err := balanceId.DecodeFromStrkey(id); err != nil {
+			return balanceId, fmt.Errorf("invalid claimable balance id: %w", err)
+		}
+		return balanceId, nil
+	}
+	if err := xdr.SafeUnmarshalHex(id, &balanceId); err != nil {
+		return balanceId, fmt.Errorf("invalid claimable balance id: %w", err)
+	}
+	return balanceId, nil
+}
+
+// ClaimableBalanceIdToString encodes a claimable balance id in the hex format used by Horizon.
+func ClaimableBalanceIdToString(id xdr.ClaimableBalanceId) (string, error) {
+	return xdr.MarshalHex(id)
+}
+
+// ComputeClaimableBalanceId derives the id of a balance created by a CreateClaimableBalance operation.
+// It is the hash of the (unmuxed) transaction source account, sequence and operation index.
+func ComputeClaimableBalanceId(txSource xdr.MuxedAccount, sequence int64, operationIndex int) (xdr.ClaimableBalanceId, error) {
+	preimage := xdr.HashIdPreimage{
+		Type: xdr.EnvelopeTypeEnvelopeTypeOpId,
+		OperationId: &xdr.HashIdPreimageOperationId{
+			SourceAccount: txSource.ToAccountId(),
+			SeqNum:        xdr.SequenceNumber(sequence),
+			OpNum:         xdr.Uint32(operationIndex),
+		},
+	}
+	bz, err := preimage.MarshalBinary()
+	if err != nil {
+		return xdr.ClaimableBalanceId{}, fmt.Errorf("failed to marshal operation id: %w", err)
+	}
+	hash := xdr.Hash(sha256.Sum256(bz))
+	return xdr.NewClaimableBalanceId(xdr.ClaimableBalanceIdTypeClaimableBalanceIdTypeV0, hash)
+}
@@ -0,0 +1,35 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/cordialsys/crosschain/chain/xlm/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimableBalanceId(t *testing.T) {
+	source := common.MustMuxedAccountFromAddres("GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H")
+	id, err := common.ComputeClaimableBalanceId(source, 1213, 0)
+	require.NoError(t, err)
+	other, err := common.ComputeClaimableBalanceId(source, 1213, 1)
+	require.NoError(t, err)
+	require.NotEqual(t, id, other)
+
+	hexId, err := common.ClaimableBalanceIdToString(id)
+	require.NoError(t, err)
+	require.Len(t, hexId, 72)
+	require.Equal(t, "00000000", hexId[:8])
+
+	parsed, err := common.ClaimableBalanceIdFromString(hexId)
+	require.NoError(t, err)
+	require.Equal(t, id, parsed)
+
+	strkeyId, err := id.EncodeToStrkey()
+	require.NoError(t, err)
+	parsed, err = common.ClaimableBalanceIdFromString(strkeyId)
+	require.NoError(t, err)
+	require.Equal(t, id, parsed)
+
+	_, err = common.ClaimableBalanceIdFromString("not-an-id")
+	require.Error(t, err)
+}
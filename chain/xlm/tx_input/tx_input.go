@@ -70,6 +70,10 @@ type TxInput struct {
 	// NeedsCreateTrustline indicates that the sender needs a trustline for the token asset.
 	// When true, a ChangeTrust operation is prepended to the transaction.
 	NeedsCreateTrustline bool `json:"needs_create_trustline,omitempty"`
+	// CreateClaimableBalance indicates that the recipient has no trustline for the token asset.
+	// When true, the builder creates a claimable balance for the recipient instead of a Payment,
+	// which the recipient may claim once they've added the trustline.
+	CreateClaimableBalance bool `json:"create_claimable_balance,omitempty"`
 	// SorobanResourceFee is the resource fee (in stroops) for Soroban InvokeHostFunction transactions.
 	// It is added to MaxFee (the inclusion fee) to form the total transaction fee.
 	SorobanResourceFee uint32 `json:"soroban_resource_fee,omitempty"`
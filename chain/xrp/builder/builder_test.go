@@ -1,11 +1,14 @@
 package builder_test
 
 import (
+	"encoding/hex"
 	"testing"
+	"time"
 
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/builder/buildertest"
 	"github.com/cordialsys/crosschain/chain/xrp/builder"
+	"github.com/cordialsys/crosschain/chain/xrp/client/types"
 	"github.com/cordialsys/crosschain/chain/xrp/tx"
 	"github.com/cordialsys/crosschain/chain/xrp/tx_input"
 	"github.com/stretchr/testify/require"
@@ -154,3 +157,153 @@ func TestNewTransfer(t *testing.T) {
 	require.Equal(t, xrpTx.Amount.TokenAmount.Issuer, "rKcAJWccYkYr7Mh2ZYmZFyLzhZD23DvTvB")
 	require.Equal(t, xrpTx.Amount.TokenAmount.Value, "12")
 }
+
+func TestEscrow(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.XRP).Base()
+	txBuilder, _ := builder.NewTxBuilder(chainCfg)
+	from := xc.Address("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	to := xc.Address("rMCcNuTcajgw7YTgBy1sys3b89QqjUrMpH")
+	input := &tx_input.TxInput{
+		V2Sequence:           5,
+		V2LastLedgerSequence: 100,
+		Fee:                  xc.NewAmountBlockchainFromUint64(10),
+	}
+	condition, fulfillment, err := tx.NewPreimageSha256Condition([]byte("secret"))
+	require.NoError(t, err)
+
+	finishAfter := time.Unix(types.XRP_EPOCH+800_000_000, 0)
+	nt, err := txBuilder.EscrowCreate(builder.EscrowCreateArgs{
+		From:        from,
+		To:          to,
+		Amount:      xc.NewAmountBlockchainFromUint64(1_000_000),
+		PublicKey:   make([]byte, 33),
+		FinishAfter: finishAfter,
+		CancelAfter: finishAfter.Add(24 * time.Hour),
+		Condition:   condition,
+	}, input)
+	require.NoError(t, err)
+	xrpTx := nt.(*Tx).XRPTx
+	require.EqualValues(t, tx.ESCROW_CREATE, xrpTx.TransactionType)
+	require.Equal(t, from, xrpTx.Account)
+	require.Equal(t, to, xrpTx.Destination)
+	require.Equal(t, "1000000", xrpTx.Amount.XRPAmount)
+	require.EqualValues(t, 800_000_000, xrpTx.FinishAfter)
+	require.EqualValues(t, 800_086_400, xrpTx.CancelAfter)
+	require.Equal(t, condition, xrpTx.Condition)
+	require.EqualValues(t, 5, xrpTx.Sequence)
+
+	_, err = txBuilder.EscrowCreate(builder.EscrowCreateArgs{
+		From: from, To: to, Amount: xc.NewAmountBlockchainFromUint64(1), PublicKey: make([]byte, 33),
+	}, input)
+	require.ErrorContains(t, err, "finish-after time or a condition")
+
+	_, err = txBuilder.EscrowCreate(builder.EscrowCreateArgs{
+		From: from, To: to, Amount: xc.NewAmountBlockchainFromUint64(1), PublicKey: make([]byte, 33),
+		FinishAfter: finishAfter, CancelAfter: finishAfter,
+	}, input)
+	require.ErrorContains(t, err, "cancel-after time must be after")
+
+	// fulfilling a condition has a larger fee
+	nt, err = txBuilder.EscrowFinish(builder.EscrowFinishArgs{
+		From:          to,
+		PublicKey:     make([]byte, 33),
+		Owner:         from,
+		OfferSequence: 5,
+		Condition:     condition,
+		Fulfillment:   fulfillment,
+	}, input)
+	require.NoError(t, err)
+	xrpTx = nt.(*Tx).XRPTx
+	require.EqualValues(t, tx.ESCROW_FINISH, xrpTx.TransactionType)
+	require.Equal(t, from, xrpTx.Owner)
+	require.EqualValues(t, 5, xrpTx.OfferSequence)
+	require.Equal(t, "340", xrpTx.Fee)
+
+	_, err = txBuilder.EscrowFinish(builder.EscrowFinishArgs{
+		From: to, PublicKey: make([]byte, 33), Owner: from, OfferSequence: 5, Condition: condition,
+	}, input)
+	require.ErrorContains(t, err, "must be set together")
+
+	nt, err = txBuilder.EscrowCancel(builder.EscrowCancelArgs{
+		From:          from,
+		PublicKey:     make([]byte, 33),
+		Owner:         from,
+		OfferSequence: 5,
+	}, input)
+	require.NoError(t, err)
+	xrpTx = nt.(*Tx).XRPTx
+	require.EqualValues(t, tx.ESCROW_CANCEL, xrpTx.TransactionType)
+	require.Equal(t, "10", xrpTx.Fee)
+}
+
+func TestPaymentChannel(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.XRP).Base()
+	txBuilder, _ := builder.NewTxBuilder(chainCfg)
+	from := xc.Address("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	to := xc.Address("rMCcNuTcajgw7YTgBy1sys3b89QqjUrMpH")
+	channel := "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3"
+	input := &tx_input.TxInput{
+		V2Sequence:           5,
+		V2LastLedgerSequence: 100,
+		Fee:                  xc.NewAmountBlockchainFromUint64(10),
+	}
+	pubKey := make([]byte, 33)
+	pubKey[0] = 2
+
+	nt, err := txBuilder.PaymentChannelCreate(builder.PaymentChannelCreateArgs{
+		From:           from,
+		To:             to,
+		Amount:         xc.NewAmountBlockchainFromUint64(1_000_000),
+		PublicKey:      pubKey,
+		DestinationTag: 7,
+		SettleDelay:    time.Hour,
+	}, input)
+	require.NoError(t, err)
+	xrpTx := nt.(*Tx).XRPTx
+	require.EqualValues(t, tx.PAYMENT_CHANNEL_CREATE, xrpTx.TransactionType)
+	require.EqualValues(t, 3600, xrpTx.SettleDelay)
+	require.Equal(t, hex.EncodeToString(pubKey), xrpTx.PublicKey)
+	require.EqualValues(t, 7, xrpTx.DestinationTag)
+
+	// destination redeems a signed claim
+	signature := make([]byte, 65)
+	signature[31] = 1
+	signature[63] = 1
+	nt, err = txBuilder.PaymentChannelClaim(builder.PaymentChannelClaimArgs{
+		From:             to,
+		PublicKey:        make([]byte, 33),
+		Channel:          channel,
+		Balance:          xc.NewAmountBlockchainFromUint64(250_000),
+		Signature:        signature,
+		ChannelPublicKey: pubKey,
+	}, input)
+	require.NoError(t, err)
+	xrpTx = nt.(*Tx).XRPTx
+	require.EqualValues(t, tx.PAYMENT_CHANNEL_CLAIM, xrpTx.TransactionType)
+	require.Equal(t, "250000", xrpTx.Balance)
+	require.Equal(t, "250000", xrpTx.Amount.XRPAmount)
+	require.Equal(t, "3006020101020101", xrpTx.ClaimSignature)
+	require.EqualValues(t, 0, xrpTx.Flags)
+
+	// owner closes the channel
+	nt, err = txBuilder.PaymentChannelClaim(builder.PaymentChannelClaimArgs{
+		From:      from,
+		PublicKey: pubKey,
+		Channel:   channel,
+		Close:     true,
+	}, input)
+	require.NoError(t, err)
+	xrpTx = nt.(*Tx).XRPTx
+	require.Equal(t, tx.TF_CLOSE, xrpTx.Flags)
+	require.Empty(t, xrpTx.Balance)
+
+	_, err = txBuilder.PaymentChannelClaim(builder.PaymentChannelClaimArgs{
+		From: to, PublicKey: pubKey, Channel: channel,
+	}, input)
+	require.ErrorContains(t, err, "must set a balance")
+
+	_, err = txBuilder.PaymentChannelClaim(builder.PaymentChannelClaimArgs{
+		From: to, PublicKey: pubKey, Channel: channel, Balance: xc.NewAmountBlockchainFromUint64(1), Signature: signature,
+	}, input)
+	require.ErrorContains(t, err, "requires the channel public-key")
+}
@@ -0,0 +1,154 @@
+package builder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/xrp/client/types"
+	xrptx "github.com/cordialsys/crosschain/chain/xrp/tx"
+)
+
+// EscrowCreateArgs locks XRP until it is released to the destination, or returned to the sender.
+// At least one of FinishAfter or Condition must be set.
+type EscrowCreateArgs struct {
+	From           xc.Address
+	To             xc.Address
+	Amount         xc.AmountBlockchain
+	PublicKey      []byte
+	DestinationTag int64
+	// The escrow may be finished (released to To) only after this time
+	FinishAfter time.Time
+	// The escrow may be cancelled (returned to From) only after this time
+	CancelAfter time.Time
+	// Hex crypto-condition that must be fulfilled to finish the escrow, see xrptx.NewPreimageSha256Condition
+	Condition string
+}
+
+// EscrowFinishArgs releases an escrow, identified by its owner and the sequence of its EscrowCreate.
+type EscrowFinishArgs struct {
+	From          xc.Address
+	PublicKey     []byte
+	Owner         xc.Address
+	OfferSequence uint32
+	// Hex condition and fulfillment, required if the escrow was created with a condition
+	Condition   string
+	Fulfillment string
+}
+
+// EscrowCancelArgs returns an expired escrow to its owner.
+type EscrowCancelArgs struct {
+	From          xc.Address
+	PublicKey     []byte
+	Owner         xc.Address
+	OfferSequence uint32
+}
+
+// EscrowCreate builds an EscrowCreate transaction
+func (txBuilder TxBuilder) EscrowCreate(args EscrowCreateArgs, input xc.TxInput) (xc.Tx, error) {
+	if args.Amount.IsZero() {
+		return nil, fmt.Errorf("escrow amount must be greater than 0")
+	}
+	if args.FinishAfter.IsZero() && args.Condition == "" {
+		return nil, fmt.Errorf("escrow must set a finish-after time or a condition")
+	}
+	if !args.FinishAfter.IsZero() && !args.CancelAfter.IsZero() && !args.CancelAfter.After(args.FinishAfter) {
+		return nil, fmt.Errorf("escrow cancel-after time must be after the finish-after time")
+	}
+	if _, err := hex.DecodeString(args.Condition); err != nil {
+		return nil, fmt.Errorf("escrow condition must be hex: %w", err)
+	}
+	finishAfter, err := rippleTime(args.FinishAfter)
+	if err != nil {
+		return nil, err
+	}
+	cancelAfter, err := rippleTime(args.CancelAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	return txBuilder.newTx(args.From, args.PublicKey, input, &xrptx.XRPTransaction{
+		TransactionType: xrptx.ESCROW_CREATE,
+		Amount: xrptx.AmountBlockchain{
+			XRPAmount: args.Amount.String(),
+		},
+		Destination:    args.To,
+		DestinationTag: args.DestinationTag,
+		FinishAfter:    finishAfter,
+		CancelAfter:    cancelAfter,
+		Condition:      args.Condition,
+	})
+}
+
+// EscrowFinish builds an EscrowFinish transaction.  Fulfilling a condition costs an
+// additional fee, proportional to the size of the fulfillment.
+func (txBuilder TxBuilder) EscrowFinish(args EscrowFinishArgs, input xc.TxInput) (xc.Tx, error) {
+	if (args.Condition == "") != (args.Fulfillment == "") {
+		return nil, fmt.Errorf("escrow condition and fulfillment must be set together")
+	}
+	fulfillment, err := hex.DecodeString(args.Fulfillment)
+	if err != nil {
+		return nil, fmt.Errorf("escrow fulfillment must be hex: %w", err)
+	}
+
+	tx, err := txBuilder.newTx(args.From, args.PublicKey, input, &xrptx.XRPTransaction{
+		TransactionType: xrptx.ESCROW_FINISH,
+		Owner:           args.Owner,
+		OfferSequence:   args.OfferSequence,
+		Condition:       args.Condition,
+		Fulfillment:     args.Fulfillment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(fulfillment) > 0 {
+		// https://xrpl.org/docs/references/protocol/transactions/types/escrowfinish#escrowfinish-fields
+		fee := input.(*TxInput).Fee
+		multiplier := xc.NewAmountBlockchainFromUint64(uint64(33 + (len(fulfillment)+15)/16))
+		tx.(*xrptx.Tx).XRPTx.Fee = fee.Mul(&multiplier).String()
+	}
+	return tx, nil
+}
+
+// EscrowCancel builds an EscrowCancel transaction
+func (txBuilder TxBuilder) EscrowCancel(args EscrowCancelArgs, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.newTx(args.From, args.PublicKey, input, &xrptx.XRPTransaction{
+		TransactionType: xrptx.ESCROW_CANCEL,
+		Owner:           args.Owner,
+		OfferSequence:   args.OfferSequence,
+	})
+}
+
+// newTx sets the common account, fee and sequence fields on a transaction sent by `from`
+func (txBuilder TxBuilder) newTx(from xc.Address, pubKey []byte, input xc.TxInput, xrpTx *xrptx.XRPTransaction) (xc.Tx, error) {
+	txInput, ok := input.(*TxInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T", input)
+	}
+	if len(pubKey) == 0 {
+		return nil, fmt.Errorf("must set from public-key: %s", from)
+	}
+	xrpTx.Account = from
+	xrpTx.Fee = txInput.Fee.String()
+	xrpTx.LastLedgerSequence = txInput.V2LastLedgerSequence
+	xrpTx.Sequence = txInput.V2Sequence
+	xrpTx.SigningPubKey = hex.EncodeToString(pubKey)
+
+	return &xrptx.Tx{
+		XRPTx:      xrpTx,
+		SignPubKey: pubKey,
+	}, nil
+}
+
+// rippleTime converts to seconds since the XRP epoch, or 0 if unset
+func rippleTime(t time.Time) (uint32, error) {
+	if t.IsZero() {
+		return 0, nil
+	}
+	seconds := t.Unix() - types.XRP_EPOCH
+	if seconds <= 0 || seconds > int64(^uint32(0)) {
+		return 0, fmt.Errorf("time %s cannot be represented on XRP", t)
+	}
+	return uint32(seconds), nil
+}
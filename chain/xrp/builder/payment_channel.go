@@ -0,0 +1,125 @@
+package builder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xrptx "github.com/cordialsys/crosschain/chain/xrp/tx"
+)
+
+// PaymentChannelCreateArgs funds a unidirectional payment channel from the sender to the destination.
+type PaymentChannelCreateArgs struct {
+	From           xc.Address
+	To             xc.Address
+	Amount         xc.AmountBlockchain
+	PublicKey      []byte
+	DestinationTag int64
+	// Public key that will sign claims against the channel.  Defaults to PublicKey.
+	ChannelPublicKey []byte
+	// How long the sender must wait, after requesting to close the channel, before it closes
+	SettleDelay time.Duration
+	// Optional immutable expiration of the channel
+	CancelAfter time.Time
+}
+
+// PaymentChannelClaimArgs redeems a claim against a channel and/or requests to close it.
+type PaymentChannelClaimArgs struct {
+	From      xc.Address
+	PublicKey []byte
+	// Hex id of the channel
+	Channel string
+	// Total amount delivered by the channel after this claim
+	Balance xc.AmountBlockchain
+	// Signature of the channel key authorizing Balance, required when the destination claims.
+	// May be DER or [R || S || V] encoded.
+	Signature []byte
+	// Public key of the channel, required with Signature
+	ChannelPublicKey []byte
+	// Request to close the channel
+	Close bool
+	// Clear the channel expiration (sender only)
+	Renew bool
+}
+
+// PaymentChannelCreate builds a PaymentChannelCreate transaction
+func (txBuilder TxBuilder) PaymentChannelCreate(args PaymentChannelCreateArgs, input xc.TxInput) (xc.Tx, error) {
+	if args.Amount.IsZero() {
+		return nil, fmt.Errorf("payment channel amount must be greater than 0")
+	}
+	if args.SettleDelay < 0 {
+		return nil, fmt.Errorf("payment channel settle delay must not be negative")
+	}
+	channelPubKey := args.ChannelPublicKey
+	if len(channelPubKey) == 0 {
+		channelPubKey = args.PublicKey
+	}
+	cancelAfter, err := rippleTime(args.CancelAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	return txBuilder.newTx(args.From, args.PublicKey, input, &xrptx.XRPTransaction{
+		TransactionType: xrptx.PAYMENT_CHANNEL_CREATE,
+		Amount: xrptx.AmountBlockchain{
+			XRPAmount: args.Amount.String(),
+		},
+		Destination:    args.To,
+		DestinationTag: args.DestinationTag,
+		SettleDelay:    uint32(args.SettleDelay / time.Second),
+		PublicKey:      hex.EncodeToString(channelPubKey),
+		CancelAfter:    cancelAfter,
+	})
+}
+
+// PaymentChannelClaim builds a PaymentChannelClaim transaction
+func (txBuilder TxBuilder) PaymentChannelClaim(args PaymentChannelClaimArgs, input xc.TxInput) (xc.Tx, error) {
+	if channel, err := hex.DecodeString(args.Channel); err != nil || len(channel) != 32 {
+		return nil, fmt.Errorf("invalid payment channel id: %s", args.Channel)
+	}
+	if args.Balance.IsZero() && !args.Close && !args.Renew {
+		return nil, fmt.Errorf("payment channel claim must set a balance, or close or renew the channel")
+	}
+	if args.Close && args.Renew {
+		return nil, fmt.Errorf("payment channel claim cannot both close and renew the channel")
+	}
+
+	xrpTx := &xrptx.XRPTransaction{
+		TransactionType: xrptx.PAYMENT_CHANNEL_CLAIM,
+		Channel:         args.Channel,
+	}
+	if !args.Balance.IsZero() {
+		xrpTx.Balance = args.Balance.String()
+	}
+	if len(args.Signature) > 0 {
+		if args.Balance.IsZero() {
+			return nil, fmt.Errorf("payment channel claim signature requires a balance")
+		}
+		if len(args.ChannelPublicKey) == 0 {
+			return nil, fmt.Errorf("payment channel claim signature requires the channel public-key")
+		}
+		signature := hex.EncodeToString(args.Signature)
+		if len(args.Signature) == 64 || len(args.Signature) == 65 {
+			var err error
+			signature, err = xrptx.EncodeSignature(args.Signature)
+			if err != nil {
+				return nil, fmt.Errorf("invalid payment channel claim signature: %w", err)
+			}
+		}
+		xrpTx.ClaimSignature = signature
+		xrpTx.PublicKey = hex.EncodeToString(args.ChannelPublicKey)
+		// The signature authorizes exactly the claimed balance
+		xrpTx.Amount = xrptx.AmountBlockchain{
+			XRPAmount: args.Balance.String(),
+		}
+	}
+	if args.Close {
+		xrpTx.Flags |= xrptx.TF_CLOSE
+	}
+	if args.Renew {
+		xrpTx.Flags |= xrptx.TF_RENEW
+	}
+
+	return txBuilder.newTx(args.From, args.PublicKey, input, xrpTx)
+}
@@ -95,6 +95,11 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		txInput.ReserveAmount = reserveAmount
 	}
 
+	memo, hasDestinationTag := args.GetMemo()
+	if err := client.ValidateDestinationTag(ctx, args.GetTo(), hasDestinationTag && memo != ""); err != nil {
+		return nil, err
+	}
+
 	// Check if the sender needs a trustline for the token asset.
 	if contractAddr, ok := args.GetContract(); ok {
 		tokenAsset, tokenContract, err := xrpcontract.ExtractAssetAndContract(contractAddr)
@@ -129,14 +134,11 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		)
 	}
 
-	ledger, err := client.getLatestLedger(false)
+	ledgerSequencePtr, err := client.fetchCurrentLedgerIndex()
 	if err != nil {
 		return nil, err
 	}
-	ledgerSequencePtr := ledger.Result.LedgerCurrentIndex
-	ledgerOffset := int64(20) // Ledger offset
-	lastLedgerSequence := ledgerSequencePtr + ledgerOffset
-	txInput.V2LastLedgerSequence = lastLedgerSequence
+	txInput.V2LastLedgerSequence = ledgerSequencePtr + LedgerOffset
 
 	if remainder.Cmp(&txInput.ReserveAmount) <= 0 {
 		// The user is trying to send (almost) their entire balance.  XRP requires
@@ -168,23 +170,62 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		}
 	}
 
-	feeInfo, err := client.getFee()
+	txInput.Fee, err = client.fetchFee()
 	if err != nil {
 		return nil, err
 	}
 
+	return txInput, nil
+}
+
+// Number of ledgers a transaction remains valid for
+const LedgerOffset = int64(20)
+
+func (client *Client) fetchCurrentLedgerIndex() (int64, error) {
+	ledger, err := client.getLatestLedger(false)
+	if err != nil {
+		return 0, err
+	}
+	return ledger.Result.LedgerCurrentIndex, nil
+}
+
+func (client *Client) fetchFee() (xc.AmountBlockchain, error) {
+	feeInfo, err := client.getFee()
+	if err != nil {
+		return xc.AmountBlockchain{}, err
+	}
+
 	// XRP has very confusing method of going about prioritization.
 	// But fee itself is at least a simple fixed fee.
 	// Current approach:
 	// - Use the median fee, based on recent ledger
 	// - Use the minimum base fee if it's greater than the median fee, as a sanity check
-	txInput.Fee = feeInfo.Result.Drops.MedianFee
-	if feeInfo.Result.Drops.BaseFee.Cmp(&txInput.Fee) > 0 {
+	fee := feeInfo.Result.Drops.MedianFee
+	if feeInfo.Result.Drops.BaseFee.Cmp(&fee) > 0 {
 		// Somehow the median is less than the base fee -> use the base fee
-		txInput.Fee = feeInfo.Result.Drops.BaseFee
+		fee = feeInfo.Result.Drops.BaseFee
 	}
+	return fee, nil
+}
 
-	return txInput, nil
+// ValidateDestinationTag rejects sending to an account that has the RequireDest flag set
+// without a destination tag, which the network would otherwise reject.
+func (client *Client) ValidateDestinationTag(ctx context.Context, to xc.Address, hasDestinationTag bool) error {
+	if hasDestinationTag {
+		return nil
+	}
+	accountInfo, err := client.getAccountInfo(to)
+	if err != nil {
+		if xrpErr, ok := err.(*XrpError); ok && xrpErr.Result.ErrorStatus == "actNotFound" {
+			// new accounts have no flags set
+			return nil
+		}
+		return fmt.Errorf("failed to fetch destination account info: %w", err)
+	}
+	if accountInfo.Result.AccountData.Flags&types.LSF_REQUIRE_DEST_TAG != 0 {
+		return errors.FailedPreconditionf("destination %s requires a destination tag (memo)", to)
+	}
+	return nil
 }
 
 // Deprecated method - use FetchTransferInput
@@ -341,6 +382,15 @@ func (client *Client) GetTxInfo(ctx context.Context, txHash xc.TxHash) (txinfo.T
 	// based on asset.
 	txInfo.Coalesece()
 
+	// Escrowed funds are reported separately so they are not mistaken for fees
+	escrowed, err := escrowMovements(chain, affectedNodes)
+	if err != nil {
+		return txinfo.TxInfo{}, err
+	}
+	for _, movement := range escrowed {
+		txInfo.AddMovement(movement)
+	}
+
 	txInfo.Fees = txInfo.CalculateFees()
 
 	return *txInfo, nil
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xrpcontract "github.com/cordialsys/crosschain/chain/xrp/address/contract"
+	xrpbuilder "github.com/cordialsys/crosschain/chain/xrp/builder"
+	"github.com/cordialsys/crosschain/chain/xrp/client/types"
+	xrptxinput "github.com/cordialsys/crosschain/chain/xrp/tx_input"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+)
+
+const (
+	LedgerEntryEscrow     = "Escrow"
+	LedgerEntryPayChannel = "PayChannel"
+)
+
+// FetchOperationInput returns the input for a non-payment transaction sent by `from`,
+// e.g. for escrows and payment channels.
+func (client *Client) FetchOperationInput(ctx context.Context, from xc.Address) (*xrptxinput.TxInput, error) {
+	txInput := xrptxinput.NewTxInput()
+
+	accountInfo, err := client.getAccountInfo(from)
+	if err != nil {
+		return nil, err
+	}
+	txInput.V2Sequence = accountInfo.Result.AccountData.Sequence
+	txInput.XrpBalance = xc.NewAmountBlockchainFromStr(accountInfo.Result.AccountData.Balance)
+
+	ledgerIndex, err := client.fetchCurrentLedgerIndex()
+	if err != nil {
+		return nil, err
+	}
+	txInput.V2LastLedgerSequence = ledgerIndex + LedgerOffset
+
+	txInput.Fee, err = client.fetchFee()
+	if err != nil {
+		return nil, err
+	}
+	return txInput, nil
+}
+
+// FetchEscrowCreateInput returns the input for an EscrowCreate, rejecting a destination that
+// requires a destination tag when none is set.
+func (client *Client) FetchEscrowCreateInput(ctx context.Context, args xrpbuilder.EscrowCreateArgs) (*xrptxinput.TxInput, error) {
+	if err := client.ValidateDestinationTag(ctx, args.To, args.DestinationTag != 0); err != nil {
+		return nil, err
+	}
+	return client.FetchOperationInput(ctx, args.From)
+}
+
+// FetchPaymentChannelCreateInput returns the input for a PaymentChannelCreate, rejecting a destination
+// that requires a destination tag when none is set.
+func (client *Client) FetchPaymentChannelCreateInput(ctx context.Context, args xrpbuilder.PaymentChannelCreateArgs) (*xrptxinput.TxInput, error) {
+	if err := client.ValidateDestinationTag(ctx, args.To, args.DestinationTag != 0); err != nil {
+		return nil, err
+	}
+	return client.FetchOperationInput(ctx, args.From)
+}
+
+// FetchEscrows lists the escrows that the address owns or may receive
+func (client *Client) FetchEscrows(ctx context.Context, address xc.Address) ([]types.Escrow, error) {
+	response, err := client.getAccountEscrows(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch escrows: %w", err)
+	}
+	return response.Result.AccountObjects, nil
+}
+
+// FetchPaymentChannels lists the payment channels that the address has opened
+func (client *Client) FetchPaymentChannels(ctx context.Context, address xc.Address) ([]types.PaymentChannel, error) {
+	response, err := client.getAccountChannels(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch payment channels: %w", err)
+	}
+	return response.Result.Channels, nil
+}
+
+// lockedFields are the fields of an Escrow or PayChannel ledger entry that determine the amount locked in it
+type lockedFields struct {
+	destinationTag int64
+	amount         *types.Balance
+	// amount already paid out of a channel
+	balance *types.Balance
+}
+
+func (fields *lockedFields) lockedAmount() (xc.AmountBlockchain, error) {
+	if fields == nil || fields.amount == nil {
+		return xc.NewAmountBlockchainFromUint64(0), nil
+	}
+	locked, err := balanceAmount(fields.amount)
+	if err != nil {
+		return locked, err
+	}
+	if fields.balance != nil && fields.balance.Valid() {
+		paid, err := balanceAmount(fields.balance)
+		if err != nil {
+			return locked, err
+		}
+		locked = locked.Sub(&paid)
+	}
+	return locked, nil
+}
+
+func (fields *lockedFields) contract() xc.ContractAddress {
+	if fields.amount != nil && fields.amount.TokenAmount != nil {
+		return xrpcontract.NewContract(fields.amount.TokenAmount.Currency, fields.amount.TokenAmount.Issuer)
+	}
+	return ""
+}
+
+func balanceAmount(balance *types.Balance) (xc.AmountBlockchain, error) {
+	if balance.TokenAmount != nil {
+		// XRP node reports token balance adjusted for decimals
+		tokenValue, err := xc.NewAmountHumanReadableFromStr(balance.TokenAmount.Value)
+		if err != nil {
+			return xc.AmountBlockchain{}, err
+		}
+		return tokenValue.ToBlockchain(types.TRUSTLINE_DECIMALS), nil
+	}
+	return xc.NewAmountBlockchainFromStr(balance.XRPAmount), nil
+}
+
+// escrowMovements reports funds locked into, or released from, escrows and payment channels
+// as movements of the ledger entry, distinct from the account balance changes.  Locked funds
+// are credited to the ledger entry rather than the destination, as they may still be cancelled;
+// the owner's debit is its own balance change.  Released funds are debited from the ledger entry
+// and credited by the balance change of the receiving account, the destination on EscrowFinish
+// or a channel claim and the owner on EscrowCancel.
+func escrowMovements(chain xc.NativeAsset, affectedNodes []types.AffectedNodes) ([]*txinfo.Movement, error) {
+	movements := []*txinfo.Movement{}
+	for _, node := range affectedNodes {
+		var entryType, index string
+		var before, after *lockedFields
+		if node.CreatedNode != nil && node.CreatedNode.NewFields != nil {
+			fields := node.CreatedNode.NewFields
+			entryType, index = node.CreatedNode.LedgerEntryType, node.CreatedNode.LedgerIndex
+			after = &lockedFields{fields.DestinationTag, fields.Amount, fields.Balance}
+		} else if node.ModifiedNode != nil && node.ModifiedNode.FinalFields != nil {
+			fields := node.ModifiedNode.FinalFields
+			entryType, index = node.ModifiedNode.LedgerEntryType, node.ModifiedNode.LedgerIndex
+			after = &lockedFields{fields.DestinationTag, fields.Amount, fields.Balance}
+			before = previousLockedFields(after, node.ModifiedNode.PreviousFields)
+		} else if node.DeletedNode != nil && node.DeletedNode.FinalFields != nil {
+			fields := node.DeletedNode.FinalFields
+			entryType, index = node.DeletedNode.LedgerEntryType, node.DeletedNode.LedgerIndex
+			final := &lockedFields{fields.DestinationTag, fields.Amount, fields.Balance}
+			before = previousLockedFields(final, node.DeletedNode.PreviousFields)
+		}
+		if entryType != LedgerEntryEscrow && entryType != LedgerEntryPayChannel {
+			continue
+		}
+
+		lockedBefore, err := before.lockedAmount()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s %s: %w", entryType, index, err)
+		}
+		lockedAfter, err := after.lockedAmount()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s %s: %w", entryType, index, err)
+		}
+		entry := after
+		if entry == nil {
+			entry = before
+		}
+		contract := entry.contract()
+
+		variant := txinfo.MovementVariantNative
+		if contract != "" {
+			variant = txinfo.MovementVariantToken
+		}
+		movement := txinfo.NewMovement(chain, contract)
+		movement.AddEventMeta(txinfo.NewEvent(index, variant))
+		delta := lockedAfter.Sub(&lockedBefore)
+		switch delta.Sign() {
+		case 1:
+			movement.AddDestination(xc.Address(index), delta, nil)
+			if entry.destinationTag != 0 {
+				movement.SetMemo(fmt.Sprintf("%d", entry.destinationTag))
+			}
+		case -1:
+			movement.AddSource(xc.Address(index), delta.Abs(), nil)
+		default:
+			continue
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
+
+// previousLockedFields overlays the changed fields of a ledger entry onto its final fields
+func previousLockedFields(final *lockedFields, previous *types.PreviousFields) *lockedFields {
+	before := *final
+	if previous != nil {
+		if previous.Amount != nil {
+			before.amount = previous.Amount
+		}
+		if previous.Balance.Valid() {
+			before.balance = &previous.Balance
+		}
+	}
+	return &before
+}
@@ -0,0 +1,234 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/builder/buildertest"
+	xrpbuilder "github.com/cordialsys/crosschain/chain/xrp/builder"
+	xrpClient "github.com/cordialsys/crosschain/chain/xrp/client"
+	"github.com/cordialsys/crosschain/chain/xrp/client/types"
+	txinfo "github.com/cordialsys/crosschain/client/tx_info"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	escrowOwner       = "rHzsdt8NDw1R4YTDHvJgW8zt15AEKSgf1S"
+	escrowDestination = "rLETt614usCXtkc8YcQmrzachrCaDjACjP"
+	escrowIndex       = "DC5F3851D8A1AB622F957761E5963BC5BD439D5C24AC6AD7AC4523F0640244AC"
+)
+
+func escrowTxResponse(account string, txType string, nodes string) string {
+	return fmt.Sprintf(`{
+	  "result": {
+		"Account": "%s",
+		"Fee": "12",
+		"Sequence": 5,
+		"TransactionType": "%s",
+		"hash": "3F27C0AF1993AF63E3438BA903B981AA095B6C81AB23976A9729B44AB39719BA",
+		"meta": {
+		  "AffectedNodes": [%s],
+		  "TransactionResult": "tesSUCCESS"
+		},
+		"validated": true,
+		"date": 777656992,
+		"ledger_index": 94494
+	  }
+	}`, account, txType, nodes)
+}
+
+func accountRootNode(account string, previous string, final string) string {
+	return fmt.Sprintf(`{
+	  "ModifiedNode": {
+		"FinalFields": {"Account": "%s", "Balance": "%s", "Flags": 0, "OwnerCount": 1, "Sequence": 6},
+		"LedgerEntryType": "AccountRoot",
+		"LedgerIndex": "18F34B88295C6BBD378F0F94E600660C8B7CDEAC89A3C41236910B3334F352FE",
+		"PreviousFields": {"Balance": "%s"}
+	  }
+	}`, account, final, previous)
+}
+
+func TestFetchTxInfoEscrow(t *testing.T) {
+	vectors := []struct {
+		name     string
+		txResp   string
+		expected []*txinfo.Movement
+	}{
+		{
+			name: "escrow create",
+			txResp: escrowTxResponse(escrowOwner, "EscrowCreate", accountRootNode(escrowOwner, "10000000", "8999988")+`,{
+			  "CreatedNode": {
+				"LedgerEntryType": "Escrow",
+				"LedgerIndex": "`+escrowIndex+`",
+				"NewFields": {"Account": "`+escrowOwner+`", "Amount": "1000000", "Destination": "`+escrowDestination+`", "DestinationTag": 99, "FinishAfter": 800000000}
+			  }
+			}`),
+			expected: []*txinfo.Movement{
+				{From: []*txinfo.BalanceChange{{AddressId: escrowOwner, Balance: xc.NewAmountBlockchainFromUint64(1000012)}}},
+				// locked funds are not received by the destination until the escrow is finished
+				{To: []*txinfo.BalanceChange{{AddressId: escrowIndex, Balance: xc.NewAmountBlockchainFromUint64(1000000)}}, Memo: "99"},
+			},
+		},
+		{
+			name: "escrow finish by destination",
+			txResp: escrowTxResponse(escrowDestination, "EscrowFinish", accountRootNode(escrowDestination, "5000000", "5999988")+`,{
+			  "DeletedNode": {
+				"LedgerEntryType": "Escrow",
+				"LedgerIndex": "`+escrowIndex+`",
+				"FinalFields": {"Account": "`+escrowOwner+`", "Amount": "1000000", "Destination": "`+escrowDestination+`", "Flags": 0}
+			  }
+			}`),
+			expected: []*txinfo.Movement{
+				{To: []*txinfo.BalanceChange{{AddressId: escrowDestination, Balance: xc.NewAmountBlockchainFromUint64(999988)}}},
+				{From: []*txinfo.BalanceChange{{AddressId: escrowIndex, Balance: xc.NewAmountBlockchainFromUint64(1000000)}}},
+			},
+		},
+		{
+			name: "escrow cancel returns funds to the owner",
+			txResp: escrowTxResponse(escrowOwner, "EscrowCancel", accountRootNode(escrowOwner, "8999988", "9999976")+`,{
+			  "DeletedNode": {
+				"LedgerEntryType": "Escrow",
+				"LedgerIndex": "`+escrowIndex+`",
+				"FinalFields": {"Account": "`+escrowOwner+`", "Amount": "1000000", "Destination": "`+escrowDestination+`", "Flags": 0}
+			  }
+			}`),
+			expected: []*txinfo.Movement{
+				{To: []*txinfo.BalanceChange{{AddressId: escrowOwner, Balance: xc.NewAmountBlockchainFromUint64(999988)}}},
+				{From: []*txinfo.BalanceChange{{AddressId: escrowIndex, Balance: xc.NewAmountBlockchainFromUint64(1000000)}}},
+			},
+		},
+		{
+			name: "payment channel claim",
+			txResp: escrowTxResponse(escrowOwner, "PaymentChannelClaim", accountRootNode(escrowOwner, "10000000", "9999988")+`,`+
+				accountRootNode(escrowDestination, "5000000", "5250000")+`,{
+			  "ModifiedNode": {
+				"LedgerEntryType": "PayChannel",
+				"LedgerIndex": "`+escrowIndex+`",
+				"FinalFields": {"Account": "`+escrowOwner+`", "Amount": "1000000", "Balance": "500000", "Destination": "`+escrowDestination+`", "Flags": 0},
+				"PreviousFields": {"Balance": "250000"}
+			  }
+			}`),
+			expected: []*txinfo.Movement{
+				{
+					From: []*txinfo.BalanceChange{{AddressId: escrowOwner, Balance: xc.NewAmountBlockchainFromUint64(12)}},
+					To:   []*txinfo.BalanceChange{{AddressId: escrowDestination, Balance: xc.NewAmountBlockchainFromUint64(250000)}},
+				},
+				{From: []*txinfo.BalanceChange{{AddressId: escrowIndex, Balance: xc.NewAmountBlockchainFromUint64(250000)}}},
+			},
+		},
+	}
+
+	for _, vector := range vectors {
+		t.Run(vector.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var reqBody map[string]interface{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&reqBody))
+				switch reqBody["method"] {
+				case "tx":
+					_, _ = w.Write([]byte(vector.txResp))
+				case "ledger":
+					_ = json.NewEncoder(w).Encode(types.LedgerResponse{Result: types.LedgerResult{LedgerCurrentIndex: 94500}})
+				default:
+					t.Errorf("unexpected method: %s", reqBody["method"])
+				}
+			}))
+			defer server.Close()
+			asset := xc.NewChainConfig(xc.XRP)
+			asset.URL = server.URL
+			client, _ := xrpClient.NewClient(asset)
+
+			info, err := client.FetchTxInfo(context.Background(), txinfo.NewArgs("3F27C0AF1993AF63E3438BA903B981AA095B6C81AB23976A9729B44AB39719BA"))
+			require.NoError(t, err)
+			require.Len(t, info.Movements, len(vector.expected))
+			for i, expected := range vector.expected {
+				movement := info.Movements[i]
+				require.Len(t, movement.From, len(expected.From))
+				require.Len(t, movement.To, len(expected.To))
+				for j := range expected.From {
+					require.EqualValues(t, expected.From[j].AddressId, movement.From[j].AddressId)
+					require.Equal(t, expected.From[j].Balance.String(), movement.From[j].Balance.String())
+				}
+				for j := range expected.To {
+					require.EqualValues(t, expected.To[j].AddressId, movement.To[j].AddressId)
+					require.Equal(t, expected.To[j].Balance.String(), movement.To[j].Balance.String())
+				}
+				require.Equal(t, expected.Memo, movement.Memo)
+			}
+			// the escrow movement is identified by the ledger entry
+			escrowed := info.Movements[len(info.Movements)-1]
+			require.Equal(t, escrowIndex, escrowed.Event.Id)
+
+			require.Len(t, info.Fees, 1)
+			require.Equal(t, "12", info.Fees[0].Balance.String())
+		})
+	}
+}
+
+func TestFetchTransferInputRequireDest(t *testing.T) {
+	from := xc.Address(escrowOwner)
+	to := xc.Address(escrowDestination)
+	asset := xc.NewChainConfig(xc.XRP).WithDecimals(types.XRP_NATIVE_DECIMALS)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody struct {
+			Method string                   `json:"method"`
+			Params []map[string]interface{} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reqBody))
+		switch reqBody.Method {
+		case "account_info":
+			data := types.AccountData{Sequence: 5, Balance: "10000000"}
+			if reqBody.Params[0]["account"] == string(to) {
+				data.Flags = types.LSF_REQUIRE_DEST_TAG
+			}
+			_ = json.NewEncoder(w).Encode(types.AccountInfoResponse{Result: types.AccountInfoResultDetails{AccountData: data}})
+		case "server_info":
+			_ = json.NewEncoder(w).Encode(types.ServerInfoResponse{})
+		case "ledger":
+			_ = json.NewEncoder(w).Encode(types.LedgerResponse{Result: types.LedgerResult{LedgerCurrentIndex: 100}})
+		case "fee":
+			_ = json.NewEncoder(w).Encode(types.FeeResponse{Result: types.FeeResult{Drops: types.FeeDrops{
+				BaseFee:   xc.NewAmountBlockchainFromUint64(10),
+				MedianFee: xc.NewAmountBlockchainFromUint64(10),
+			}}})
+		default:
+			t.Errorf("unexpected method: %s", reqBody.Method)
+		}
+	}))
+	defer server.Close()
+	asset.URL = server.URL
+	client, _ := xrpClient.NewClient(asset)
+
+	args := buildertest.MustNewTransferArgs(asset.Base(), from, to, xc.NewAmountBlockchainFromUint64(100))
+	_, err := client.FetchTransferInput(context.Background(), args)
+	require.ErrorContains(t, err, "requires a destination tag")
+
+	args = buildertest.MustNewTransferArgs(asset.Base(), from, to, xc.NewAmountBlockchainFromUint64(100), buildertest.OptionMemo("123"))
+	_, err = client.FetchTransferInput(context.Background(), args)
+	require.NoError(t, err)
+
+	// the sender does not require a destination tag
+	args = buildertest.MustNewTransferArgs(asset.Base(), to, from, xc.NewAmountBlockchainFromUint64(100))
+	_, err = client.FetchTransferInput(context.Background(), args)
+	require.NoError(t, err)
+
+	escrowArgs := xrpbuilder.EscrowCreateArgs{From: from, To: to, Amount: xc.NewAmountBlockchainFromUint64(100)}
+	_, err = client.FetchEscrowCreateInput(context.Background(), escrowArgs)
+	require.ErrorContains(t, err, "requires a destination tag")
+	escrowArgs.DestinationTag = 123
+	input, err := client.FetchEscrowCreateInput(context.Background(), escrowArgs)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, input.V2Sequence)
+
+	channelArgs := xrpbuilder.PaymentChannelCreateArgs{From: from, To: to, Amount: xc.NewAmountBlockchainFromUint64(100)}
+	_, err = client.FetchPaymentChannelCreateInput(context.Background(), channelArgs)
+	require.ErrorContains(t, err, "requires a destination tag")
+	channelArgs.DestinationTag = 123
+	_, err = client.FetchPaymentChannelCreateInput(context.Background(), channelArgs)
+	require.NoError(t, err)
+}
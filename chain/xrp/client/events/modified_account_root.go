@@ -38,7 +38,14 @@ func (mnw *EventModifiedAccountRoot) IsSource(txResponse *types.TransactionRespo
 
 	if mnw.node.FinalFields.Account != txResponse.Result.Account {
 		return false, nil
-	} else {
-		return true, nil
 	}
+	// The sender may still be credited, e.g. when finishing an escrow or claiming from a payment channel
+	if mnw.node.PreviousFields != nil && mnw.node.FinalFields.Balance != nil {
+		previous := xc.NewAmountBlockchainFromStr(mnw.node.PreviousFields.Balance.XRPAmount)
+		final := xc.NewAmountBlockchainFromStr(mnw.node.FinalFields.Balance.XRPAmount)
+		if final.Cmp(&previous) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
 }
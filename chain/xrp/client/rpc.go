@@ -200,3 +200,42 @@ func (client *Client) getServerInfo() (*types.ServerInfoResponse, error) {
 	}
 	return &response, nil
 }
+
+func (client *Client) getAccountEscrows(address xc.Address) (*types.AccountEscrowsResponse, error) {
+	request := types.AccountObjectsRequest{
+		Method: "account_objects",
+		Params: []types.AccountObjectsParamEntry{
+			{
+				Account:     address,
+				Type:        "escrow",
+				LedgerIndex: types.Validated,
+			},
+		},
+	}
+
+	var response types.AccountEscrowsResponse
+	err := client.Send(MethodPost, request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (client *Client) getAccountChannels(address xc.Address) (*types.AccountChannelsResponse, error) {
+	request := types.AccountChannelsRequest{
+		Method: "account_channels",
+		Params: []types.AccountChannelsParamEntry{
+			{
+				Account:     address,
+				LedgerIndex: types.Validated,
+			},
+		},
+	}
+
+	var response types.AccountChannelsResponse
+	err := client.Send(MethodPost, request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
@@ -15,6 +15,10 @@ const XRP_NATIVE_DECIMALS = 6
 // https://xrpl.org/docs/references/protocol/data-types/basic-data-types#specifying-time
 const XRP_EPOCH = 946684800
 
+// Account flag requiring incoming payments to specify a destination tag
+// https://xrpl.org/docs/references/protocol/ledger-data/ledger-entry-types/accountroot#accountroot-flags
+const LSF_REQUIRE_DEST_TAG int64 = 0x00020000
+
 type LedgerIndex string
 
 const Validated LedgerIndex = "validated"
@@ -293,6 +297,10 @@ type NewFields struct {
 	LowNode       string   `json:"LowNode,omitempty"`
 	Owner         string   `json:"Owner,omitempty"`
 	RootIndex     string   `json:"RootIndex,omitempty"`
+	// Escrow and PayChannel fields
+	Amount         *Balance `json:"Amount,omitempty"`
+	Destination    string   `json:"Destination,omitempty"`
+	DestinationTag int64    `json:"DestinationTag,omitempty"`
 }
 
 type ModifiedNode struct {
@@ -331,6 +339,10 @@ type FinalFields struct {
 	TakerPaysCurrency string         `json:"TakerPaysCurrency,omitempty"`
 	TakerPaysIssuer   string         `json:"TakerPaysIssuer,omitempty"`
 	IndexNext         string         `json:"IndexNext,omitempty"`
+	// Escrow and PayChannel fields
+	Amount         *Balance `json:"Amount,omitempty"`
+	Destination    string   `json:"Destination,omitempty"`
+	DestinationTag int64    `json:"DestinationTag,omitempty"`
 }
 
 // The contract and recipient could be in other high or low limit.
@@ -410,6 +422,8 @@ type PreviousFields struct {
 	Sequence      int64   `json:"Sequence,omitempty"`
 	IndexNext     string  `json:"IndexNext,omitempty"`
 	IndexPrevious string  `json:"IndexPrevious,omitempty"`
+	// PayChannel funding changes
+	Amount *Balance `json:"Amount,omitempty"`
 }
 
 type AccountLinesResponse struct {
@@ -455,3 +469,69 @@ type AccountData struct {
 	Sequence          int64  `json:"Sequence"`
 	Index             string `json:"Index"`
 }
+
+type AccountObjectsRequest struct {
+	Method string                     `json:"method"`
+	Params []AccountObjectsParamEntry `json:"params"`
+}
+
+type AccountObjectsParamEntry struct {
+	Account     xc.Address  `json:"account"`
+	Type        string      `json:"type,omitempty"`
+	LedgerIndex LedgerIndex `json:"ledger_index"`
+}
+
+type AccountEscrowsResponse struct {
+	Result struct {
+		Account        string   `json:"account"`
+		AccountObjects []Escrow `json:"account_objects"`
+	} `json:"result"`
+}
+
+// https://xrpl.org/docs/references/protocol/ledger-data/ledger-entry-types/escrow
+type Escrow struct {
+	Account         string  `json:"Account"`
+	Amount          Balance `json:"Amount"`
+	Destination     string  `json:"Destination"`
+	DestinationTag  int64   `json:"DestinationTag,omitempty"`
+	Condition       string  `json:"Condition,omitempty"`
+	CancelAfter     uint32  `json:"CancelAfter,omitempty"`
+	FinishAfter     uint32  `json:"FinishAfter,omitempty"`
+	LedgerEntryType string  `json:"LedgerEntryType"`
+	// The EscrowCreate transaction, whose sequence is needed to finish or cancel the escrow
+	PreviousTxnID string `json:"PreviousTxnID"`
+	Index         string `json:"index"`
+}
+
+type AccountChannelsRequest struct {
+	Method string                      `json:"method"`
+	Params []AccountChannelsParamEntry `json:"params"`
+}
+
+type AccountChannelsParamEntry struct {
+	Account            xc.Address  `json:"account"`
+	DestinationAccount xc.Address  `json:"destination_account,omitempty"`
+	LedgerIndex        LedgerIndex `json:"ledger_index"`
+}
+
+type AccountChannelsResponse struct {
+	Result struct {
+		Account  string           `json:"account"`
+		Channels []PaymentChannel `json:"channels"`
+	} `json:"result"`
+}
+
+// https://xrpl.org/docs/references/http-websocket-apis/public-api-methods/account-methods/account_channels
+type PaymentChannel struct {
+	Account            string `json:"account"`
+	Amount             string `json:"amount"`
+	Balance            string `json:"balance"`
+	ChannelId          string `json:"channel_id"`
+	DestinationAccount string `json:"destination_account"`
+	DestinationTag     int64  `json:"destination_tag,omitempty"`
+	PublicKey          string `json:"public_key"`
+	PublicKeyHex       string `json:"public_key_hex"`
+	SettleDelay        uint32 `json:"settle_delay"`
+	Expiration         uint32 `json:"expiration,omitempty"`
+	CancelAfter        uint32 `json:"cancel_after,omitempty"`
+}
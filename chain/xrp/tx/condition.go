@@ -0,0 +1,54 @@
+package tx
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+)
+
+// Prefix of the message signed by a payment channel key to authorize a claim
+const CHANNEL_CLAIM_PREFIX = "434C4D00"
+
+// The largest preimage that keeps the DER encoding in short-form lengths
+const MAX_PREIMAGE_LENGTH = 125
+
+// NewPreimageSha256Condition returns the hex encoded PREIMAGE-SHA-256 crypto-condition and fulfillment
+// for the given preimage, as used by EscrowCreate and EscrowFinish.
+// https://xrpl.org/docs/concepts/payment-types/escrow#crypto-conditions
+func NewPreimageSha256Condition(preimage []byte) (condition string, fulfillment string, err error) {
+	if len(preimage) > MAX_PREIMAGE_LENGTH {
+		return "", "", fmt.Errorf("preimage must be at most %d bytes, got %d", MAX_PREIMAGE_LENGTH, len(preimage))
+	}
+	digest := sha256.Sum256(preimage)
+
+	// fulfillment ::= [0] { [0] preimage }
+	fulfillmentBz := []byte{0xA0, byte(len(preimage) + 2), 0x80, byte(len(preimage))}
+	fulfillmentBz = append(fulfillmentBz, preimage...)
+
+	// condition ::= [0] { [0] fingerprint, [1] cost }, where the cost is the preimage length
+	conditionBz := []byte{0xA0, 0x25, 0x80, 0x20}
+	conditionBz = append(conditionBz, digest[:]...)
+	conditionBz = append(conditionBz, 0x81, 0x01, byte(len(preimage)))
+
+	return hex.EncodeToString(conditionBz), hex.EncodeToString(fulfillmentBz), nil
+}
+
+// ChannelClaimSighash returns the digest that the payment channel key signs to authorize
+// the destination to claim `amount` drops from the channel.
+// https://xrpl.org/docs/references/http-websocket-apis/public-api-methods/payment-channel-methods/channel_authorize
+func ChannelClaimSighash(channel string, amount xc.AmountBlockchain) ([]byte, error) {
+	channelBz, err := hex.DecodeString(channel)
+	if err != nil || len(channelBz) != 32 {
+		return nil, fmt.Errorf("invalid payment channel id: %s", channel)
+	}
+	message, _ := hex.DecodeString(CHANNEL_CLAIM_PREFIX)
+	message = append(message, channelBz...)
+	message = binary.BigEndian.AppendUint64(message, amount.Uint64())
+
+	digest := sha512.Sum512(message)
+	return digest[:32], nil
+}
@@ -0,0 +1,97 @@
+package tx_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/xrp/tx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPreimageSha256Condition(t *testing.T) {
+	// empty preimage example from the XRP docs
+	condition, fulfillment, err := tx.NewPreimageSha256Condition([]byte{})
+	require.NoError(t, err)
+	require.Equal(t, "A0258020E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855810100", strings.ToUpper(condition))
+	require.Equal(t, "A0028000", strings.ToUpper(fulfillment))
+
+	preimage := make([]byte, 32)
+	condition, fulfillment, err = tx.NewPreimageSha256Condition(preimage)
+	require.NoError(t, err)
+	require.Len(t, condition, 39*2)
+	require.True(t, strings.HasSuffix(condition, "810120"))
+	require.Equal(t, "a0228020"+hex.EncodeToString(preimage), fulfillment)
+
+	_, _, err = tx.NewPreimageSha256Condition(make([]byte, 126))
+	require.ErrorContains(t, err, "preimage must be at most")
+}
+
+func TestChannelClaimSighash(t *testing.T) {
+	channel := "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3"
+	sighash, err := tx.ChannelClaimSighash(channel, xc.NewAmountBlockchainFromUint64(1_000_000))
+	require.NoError(t, err)
+	require.Len(t, sighash, 32)
+
+	other, err := tx.ChannelClaimSighash(channel, xc.NewAmountBlockchainFromUint64(1_000_001))
+	require.NoError(t, err)
+	require.NotEqual(t, sighash, other)
+
+	_, err = tx.ChannelClaimSighash("abcd", xc.NewAmountBlockchainFromUint64(1))
+	require.ErrorContains(t, err, "invalid payment channel id")
+}
+
+func TestEscrowAndChannelSighashes(t *testing.T) {
+	condition, fulfillment, err := tx.NewPreimageSha256Condition([]byte("secret"))
+	require.NoError(t, err)
+	pubKey := "0391e85c96feab1c71250308ef99375bb3fa9b846fc2c8b906976fa9ac4bed0857"
+	for _, xrpTx := range []*tx.XRPTransaction{
+		{
+			TransactionType: tx.ESCROW_CREATE,
+			Amount:          tx.AmountBlockchain{XRPAmount: "1000000"},
+			Destination:     "rs2x5gvFupB22myz86BUu7m5F4YuizsFna",
+			FinishAfter:     800000000,
+			CancelAfter:     800086400,
+			Condition:       condition,
+		},
+		{
+			TransactionType: tx.ESCROW_FINISH,
+			Owner:           "r92tsEZEjK82wra6xaDvjZocKnR78VqpEM",
+			OfferSequence:   7,
+			Condition:       condition,
+			Fulfillment:     fulfillment,
+		},
+		{
+			TransactionType: tx.ESCROW_CANCEL,
+			Owner:           "r92tsEZEjK82wra6xaDvjZocKnR78VqpEM",
+			OfferSequence:   7,
+		},
+		{
+			TransactionType: tx.PAYMENT_CHANNEL_CREATE,
+			Amount:          tx.AmountBlockchain{XRPAmount: "1000000"},
+			Destination:     "rs2x5gvFupB22myz86BUu7m5F4YuizsFna",
+			SettleDelay:     86400,
+			PublicKey:       pubKey,
+		},
+		{
+			TransactionType: tx.PAYMENT_CHANNEL_CLAIM,
+			Flags:           tx.TF_CLOSE,
+			Channel:         "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3",
+			Balance:         "500000",
+		},
+	} {
+		xrpTx.Account = "r92tsEZEjK82wra6xaDvjZocKnR78VqpEM"
+		xrpTx.Fee = "10"
+		xrpTx.Sequence = 8
+		xrpTx.LastLedgerSequence = 100
+		xrpTx.SigningPubKey = pubKey
+		xrpTx.TxnSignature = "304402200b92d0b3a651877e89ec2904691637116e06ccacfeeafe47e901d4d6fa91b4c302207dcd149e8226a46b3c15baa6509fe423eb9ce27c0f136bbacd1988bd0c988c1b"
+
+		tx := tx.Tx{XRPTx: xrpTx}
+		sighashes, err := tx.Sighashes()
+		require.NoError(t, err, xrpTx.TransactionType)
+		require.Len(t, sighashes, 1)
+		require.NotEmpty(t, tx.Hash(), xrpTx.TransactionType)
+	}
+}
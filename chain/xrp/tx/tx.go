@@ -17,9 +17,21 @@ const (
 	PAYMENT                 TransactionType = "Payment"
 	ACCOUNT_DELETE          TransactionType = "AccountDelete"
 	TRUST_SET               TransactionType = "TrustSet"
+	ESCROW_CREATE           TransactionType = "EscrowCreate"
+	ESCROW_FINISH           TransactionType = "EscrowFinish"
+	ESCROW_CANCEL           TransactionType = "EscrowCancel"
+	PAYMENT_CHANNEL_CREATE  TransactionType = "PaymentChannelCreate"
+	PAYMENT_CHANNEL_CLAIM   TransactionType = "PaymentChannelClaim"
 	TRANSACTION_HASH_PREFIX                 = "54584E00"
 )
 
+// PaymentChannelClaim flags
+// https://xrpl.org/docs/references/protocol/transactions/types/paymentchannelclaim#paymentchannelclaim-flags
+const (
+	TF_RENEW int64 = 0x00010000
+	TF_CLOSE int64 = 0x00020000
+)
+
 type TransactionType string
 
 type XRPTransaction struct {
@@ -37,6 +49,22 @@ type XRPTransaction struct {
 	TxnSignature       string           `json:"TxnSignature"`
 	// LimitAmount is used for TrustSet transactions to define the trustline.
 	LimitAmount *Amount `json:"LimitAmount,omitempty"`
+
+	// Escrow fields. Times are in seconds since the XRP epoch.
+	FinishAfter   uint32     `json:"FinishAfter,omitempty"`
+	CancelAfter   uint32     `json:"CancelAfter,omitempty"`
+	Condition     string     `json:"Condition,omitempty"`
+	Fulfillment   string     `json:"Fulfillment,omitempty"`
+	Owner         xc.Address `json:"Owner,omitempty"`
+	OfferSequence uint32     `json:"OfferSequence,omitempty"`
+
+	// Payment channel fields
+	SettleDelay uint32 `json:"SettleDelay,omitempty"`
+	PublicKey   string `json:"PublicKey,omitempty"`
+	Channel     string `json:"Channel,omitempty"`
+	Balance     string `json:"Balance,omitempty"`
+	// Signature of the channel key authorizing a claim (not the transaction signature)
+	ClaimSignature string `json:"Signature,omitempty"`
 }
 
 type AmountBlockchain struct {
@@ -118,14 +146,10 @@ func (tx *Tx) SetSignatures(signatures ...*xc.SignatureResponse) error {
 	}
 
 	for _, rsvBytes := range signatures {
-		r, s, err := btctx.DecodeEcdsaSignature(rsvBytes.Signature)
+		signatureHex, err := EncodeSignature(rsvBytes.Signature)
 		if err != nil {
 			return err
 		}
-
-		signature := ecdsa.NewSignature(&r, &s)
-		signatureBytes := signature.Serialize()
-		signatureHex := hex.EncodeToString(signatureBytes)
 		tx.XRPTx.TxnSignature = signatureHex
 		tx.TransactionSignature = append(tx.TransactionSignature, rsvBytes.Signature)
 	}
@@ -174,6 +198,16 @@ func (tx Tx) Serialize() ([]byte, error) {
 	return decodedBytes, nil
 }
 
+// EncodeSignature converts a 64 or 65 byte [R || S || V] signature to the hex DER encoding used by XRP
+func EncodeSignature(rsv []byte) (string, error) {
+	r, s, err := btctx.DecodeEcdsaSignature(rsv)
+	if err != nil {
+		return "", err
+	}
+	signature := ecdsa.NewSignature(&r, &s)
+	return hex.EncodeToString(signature.Serialize()), nil
+}
+
 func HashFromTx(encodeTx string) (string, error) {
 	encodeTxWithPrefix := TRANSACTION_HASH_PREFIX + string(encodeTx)
 
@@ -200,7 +234,8 @@ func RenderToMap(xrpTx XRPTransaction) (map[string]interface{}, error) {
 	result["TransactionType"] = string(xrpTx.TransactionType)
 	result["TxnSignature"] = xrpTx.TxnSignature
 
-	if xrpTx.TransactionType == TRUST_SET {
+	switch xrpTx.TransactionType {
+	case TRUST_SET:
 		if xrpTx.LimitAmount != nil {
 			result["LimitAmount"] = map[string]interface{}{
 				"currency": xrpTx.LimitAmount.Currency,
@@ -208,7 +243,54 @@ func RenderToMap(xrpTx XRPTransaction) (map[string]interface{}, error) {
 				"value":    xrpTx.LimitAmount.Value,
 			}
 		}
-	} else {
+	case ESCROW_CREATE, PAYMENT_CHANNEL_CREATE:
+		result["Destination"] = string(xrpTx.Destination)
+		if xrpTx.DestinationTag != 0 {
+			result["DestinationTag"] = int(xrpTx.DestinationTag)
+		}
+		if xrpTx.Amount.XRPAmount != "" {
+			RenderXrpAmount(result, xrpTx.Amount.XRPAmount)
+		} else if xrpTx.Amount.TokenAmount != nil {
+			RenderTokenAmount(result, xrpTx.Amount.TokenAmount)
+		}
+		if xrpTx.TransactionType == ESCROW_CREATE {
+			if xrpTx.FinishAfter != 0 {
+				result["FinishAfter"] = int(xrpTx.FinishAfter)
+			}
+			if xrpTx.Condition != "" {
+				result["Condition"] = xrpTx.Condition
+			}
+		} else {
+			result["SettleDelay"] = int(xrpTx.SettleDelay)
+			result["PublicKey"] = xrpTx.PublicKey
+		}
+		if xrpTx.CancelAfter != 0 {
+			result["CancelAfter"] = int(xrpTx.CancelAfter)
+		}
+	case ESCROW_FINISH, ESCROW_CANCEL:
+		result["Owner"] = string(xrpTx.Owner)
+		result["OfferSequence"] = int(xrpTx.OfferSequence)
+		if xrpTx.Condition != "" {
+			result["Condition"] = xrpTx.Condition
+		}
+		if xrpTx.Fulfillment != "" {
+			result["Fulfillment"] = xrpTx.Fulfillment
+		}
+	case PAYMENT_CHANNEL_CLAIM:
+		result["Channel"] = xrpTx.Channel
+		if xrpTx.Balance != "" {
+			result["Balance"] = xrpTx.Balance
+		}
+		if xrpTx.Amount.XRPAmount != "" {
+			RenderXrpAmount(result, xrpTx.Amount.XRPAmount)
+		}
+		if xrpTx.ClaimSignature != "" {
+			result["Signature"] = xrpTx.ClaimSignature
+		}
+		if xrpTx.PublicKey != "" {
+			result["PublicKey"] = xrpTx.PublicKey
+		}
+	default:
 		result["Destination"] = string(xrpTx.Destination)
 		result["DestinationTag"] = int(xrpTx.DestinationTag)
 
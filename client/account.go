@@ -34,6 +34,9 @@ type CreateAccountArgs struct {
 	address xc.Address
 	// Raw public key bytes of the account owner.
 	publicKey []byte
+	// Token that the account should be able to receive, on chains that require
+	// registering each token (e.g. hedera token association).
+	contract *xc.ContractAddress
 }
 
 func NewCreateAccountArgs(address xc.Address, publicKey []byte) *CreateAccountArgs {
@@ -43,5 +46,17 @@ func NewCreateAccountArgs(address xc.Address, publicKey []byte) *CreateAccountAr
 	}
 }
 
+// WithContract sets the token that the account should be registered for.
+func (a *CreateAccountArgs) WithContract(contract xc.ContractAddress) *CreateAccountArgs {
+	a.contract = &contract
+	return a
+}
+
 func (a *CreateAccountArgs) GetAddress() xc.Address { return a.address }
 func (a *CreateAccountArgs) GetPublicKey() []byte   { return a.publicKey }
+func (a *CreateAccountArgs) GetContract() (xc.ContractAddress, bool) {
+	if a.contract == nil {
+		return "", false
+	}
+	return *a.contract, true
+}
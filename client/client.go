@@ -56,6 +56,29 @@ type CallClient interface {
 	FetchCallInput(ctx context.Context, call xc.TxCall, args builder.CallArgs) (xc.CallTxInput, error)
 }
 
+type ApprovalClient interface {
+	// Fetch the amount of the token contract that the spender is allowed to transfer from the owner
+	FetchAllowance(ctx context.Context, owner xc.Address, spender xc.Address, contract xc.ContractAddress) (xc.AmountBlockchain, error)
+
+	// Fetch inputs required for an approval transaction
+	FetchApprovalInput(ctx context.Context, args builder.ApprovalArgs) (xc.ApprovalTxInput, error)
+}
+
+type AccessKeyClient interface {
+	// Fetch inputs required for adding or deleting an access key
+	FetchAccessKeyInput(ctx context.Context, args builder.AccessKeyArgs) (xc.AccessKeyTxInput, error)
+}
+
+type NftClient interface {
+	// Fetch inputs required for transferring one or more NFTs
+	FetchNftTransferInput(ctx context.Context, args builder.NftTransferArgs) (xc.NftTransferTxInput, error)
+}
+
+type IbcClient interface {
+	// Fetch inputs required for an IBC transfer
+	FetchIbcTransferInput(ctx context.Context, args builder.IbcTransferArgs) (xc.TxInput, error)
+}
+
 type OfferClient interface {
 	ListPendingOffers(ctx context.Context, args *OfferArgs) ([]*Offer, error)
 	ListSettlements(ctx context.Context, args *OfferArgs) ([]*Settlement, error)
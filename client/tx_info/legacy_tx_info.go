@@ -14,6 +14,9 @@ type LegacyTxInfoEndpoint struct {
 	// Set only when there's a contract ID for native asset (and conflicts with our chosen identifier)
 	ContractId xc.ContractAddress `json:"contract_id,omitempty"`
 
+	// Set only for NFT transfers
+	TokenId string `json:"token_id,omitempty"`
+
 	// event details, if known
 	Event *Event `json:"event,omitempty"`
 }
@@ -29,6 +29,8 @@ const (
 	MovementVariantInternal MovementVariant = "internal"
 	// For separate fee payment
 	MovementVariantFee MovementVariant = "fee"
+	// For transferring non-fungible (or semi-fungible) tokens, identified by a token id
+	MovementVariantNft MovementVariant = "nft"
 )
 
 func NewTransactionName(chain xc.NativeAsset, txHash string) TransactionName {
@@ -127,6 +129,9 @@ type Movement struct {
 	// identifier used by the chain for the native asset.  It should be blank otherwise.
 	ContractId xc.ContractAddress `json:"contract_id,omitempty"`
 
+	// TokenId is set only for NFT movements, to identify the token within the contract or collection.
+	TokenId string `json:"token_id,omitempty"`
+
 	// required: source debits
 	From []*BalanceChange `json:"from"`
 	// required: destination credits
@@ -187,6 +192,41 @@ func (s *Unstake) GetValidator() string {
 	return s.Validator
 }
 
+// State of an IBC packet, as seen from the sending chain
+type IbcPacketState string
+
+const (
+	// The packet has not been acknowledged or timed out yet
+	IbcPacketPending IbcPacketState = "pending"
+	// The receiving chain acknowledged the packet successfully
+	IbcPacketAcknowledged IbcPacketState = "acknowledged"
+	// The receiving chain acknowledged the packet with an error; the tokens are refunded
+	IbcPacketFailed IbcPacketState = "failed"
+	// The packet timed out before being received; the tokens are refunded
+	IbcPacketTimedOut IbcPacketState = "timed_out"
+)
+
+// An ICS-20 transfer sent to another chain over IBC
+type IbcTransfer struct {
+	Sequence           uint64 `json:"sequence"`
+	SourcePort         string `json:"source_port"`
+	SourceChannel      string `json:"source_channel"`
+	DestinationPort    string `json:"destination_port"`
+	DestinationChannel string `json:"destination_channel"`
+
+	Sender   xc.Address          `json:"sender"`
+	Receiver xc.Address          `json:"receiver"`
+	Denom    string              `json:"denom"`
+	Amount   xc.AmountBlockchain `json:"amount"`
+	Memo     string              `json:"memo,omitempty"`
+
+	State IbcPacketState `json:"state"`
+	// Hash of the transaction that acknowledged or timed out the packet
+	ResolvedBy string `json:"resolved_by,omitempty"`
+	// Error acknowledged by the receiving chain, if any
+	Error string `json:"error,omitempty"`
+}
+
 type State string
 
 const Succeeded State = "succeeded"
@@ -224,6 +264,9 @@ type TxInfo struct {
 	Stakes   []*Stake   `json:"stakes,omitempty"`
 	Unstakes []*Unstake `json:"unstakes,omitempty"`
 
+	// Transfers sent to other chains over IBC
+	IbcTransfers []*IbcTransfer `json:"ibc_transfers,omitempty"`
+
 	// required: set the confirmations at time of querying the info
 	Confirmations uint64 `json:"confirmations"`
 	// optional: set the error of the transaction if there was an error
@@ -264,6 +307,7 @@ func NewTxInfo(block *Block, chainCfg *xc.ChainConfig, hash string, confirmation
 	fees := []*Balance{}
 	var stakes []*Stake = nil
 	var unstakes []*Unstake = nil
+	var ibcTransfers []*IbcTransfer = nil
 	name := NewTransactionName(chainCfg.Chain, hash)
 
 	state := Succeeded
@@ -288,6 +332,7 @@ func NewTxInfo(block *Block, chainCfg *xc.ChainConfig, hash string, confirmation
 		fees,
 		stakes,
 		unstakes,
+		ibcTransfers,
 		confirmations,
 		err,
 	}
@@ -301,6 +346,15 @@ func (info *TxInfo) AddSimpleTransfer(from xc.Address, to xc.Address, contract x
 	return tf
 }
 
+func (info *TxInfo) AddNftTransfer(from xc.Address, to xc.Address, contract xc.ContractAddress, tokenId string, amount xc.AmountBlockchain) *Movement {
+	tf := NewMovement(info.XChain, contract)
+	tf.SetTokenId(tokenId)
+	tf.AddSource(from, amount, nil)
+	tf.AddDestination(to, amount, nil)
+	info.Movements = append(info.Movements, tf)
+	return tf
+}
+
 func (info *TxInfo) AddFee(from xc.Address, contract xc.ContractAddress, balance xc.AmountBlockchain, decimals *int) {
 	tf := NewMovement(info.XChain, contract)
 	tf.AddSource(from, balance, decimals)
@@ -308,6 +362,9 @@ func (info *TxInfo) AddFee(from xc.Address, contract xc.ContractAddress, balance
 	// no destination
 	info.Movements = append(info.Movements, tf)
 }
+func (info *TxInfo) AddIbcTransfer(transfer *IbcTransfer) {
+	info.IbcTransfers = append(info.IbcTransfers, transfer)
+}
 func (info *TxInfo) AddMovement(transfer *Movement) {
 	info.Movements = append(info.Movements, transfer)
 }
@@ -384,12 +441,13 @@ func NewMovement(chain xc.NativeAsset, contract xc.ContractAddress) *Movement {
 	to := []*BalanceChange{}
 	memo := ""
 	contractId := xc.ContractAddress("")
+	tokenId := ""
 	assetId := contract
 	xcontract := contract
 
 	var event *Event = nil
 
-	return &Movement{xasset, xcontract, assetId, contractId, from, to, memo, event, chain}
+	return &Movement{xasset, xcontract, assetId, contractId, tokenId, from, to, memo, event, chain}
 }
 
 func (tf *Movement) AddSource(from xc.Address, balance xc.AmountBlockchain, decimals *int) *BalanceChange {
@@ -410,14 +468,25 @@ func (tf *Movement) AddDestination(to xc.Address, balance xc.AmountBlockchain, d
 func (tf *Movement) SetMemo(memo string) {
 	tf.Memo = memo
 }
+func (tf *Movement) SetTokenId(tokenId string) {
+	tf.TokenId = tokenId
+}
+
+// NFT movements of the same contract are distinct assets when the token id differs
+func (tf *Movement) assetKey() xc.ContractAddress {
+	if tf.TokenId != "" {
+		return xc.ContractAddress(fmt.Sprintf("%s/%s", tf.AssetId, tf.TokenId))
+	}
+	return tf.AssetId
+}
 
 // Merge together movements that have the same asset
 func coalesece(movements []*Movement) (coaleseced []*Movement) {
 	// use btree map to get deterministic order
 	var mapping = btree.NewMap[xc.ContractAddress, []*Movement](1)
 	for _, m := range movements {
-		arr, _ := mapping.Get(m.AssetId)
-		mapping.Set(m.AssetId, append(arr, m))
+		arr, _ := mapping.Get(m.assetKey())
+		mapping.Set(m.assetKey(), append(arr, m))
 	}
 
 	mapping.Ascend("", func(_ xc.ContractAddress, value []*Movement) bool {
@@ -464,6 +533,7 @@ func TxInfoFromLegacy(chainCfg *xc.ChainConfig, legacyTx LegacyTxInfo, mappingTy
 		for _, source := range legacyTx.Sources {
 			tf := NewMovement(chain, source.ContractAddress)
 			tf.ContractId = source.ContractId
+			tf.TokenId = source.TokenId
 			balanceChange := tf.AddSource(source.Address, source.Amount, nil)
 			if source.Event != nil {
 				balanceChange.AddEventMeta(source.Event)
@@ -474,6 +544,7 @@ func TxInfoFromLegacy(chainCfg *xc.ChainConfig, legacyTx LegacyTxInfo, mappingTy
 		for _, dest := range legacyTx.Destinations {
 			tf := NewMovement(chain, dest.ContractAddress)
 			tf.ContractId = dest.ContractId
+			tf.TokenId = dest.TokenId
 			balanceChange := tf.AddDestination(dest.Address, dest.Amount, nil)
 			if dest.Event != nil {
 				balanceChange.AddEventMeta(dest.Event)
@@ -498,6 +569,7 @@ func TxInfoFromLegacy(chainCfg *xc.ChainConfig, legacyTx LegacyTxInfo, mappingTy
 			}
 			movement := txInfo.AddSimpleTransfer(fromAddr, dest.Address, dest.ContractAddress, dest.Amount, nil, dest.Memo)
 			movement.ContractId = dest.ContractId
+			movement.TokenId = dest.TokenId
 			if eventMeta != nil {
 				movement.AddEventMeta(eventMeta)
 			}
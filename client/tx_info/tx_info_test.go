@@ -119,6 +119,32 @@ func TestTxInfoMultiLegCoalesce(t *testing.T) {
 	require.EqualValues(t, "BTC", tx.CalculateFees()[0].Contract)
 }
 
+func TestTxInfoNftMovements(t *testing.T) {
+	chainCfg := xc.NewChainConfig(xc.ETH)
+	legacyTx := LegacyTxInfo{
+		TxID: "0x1234",
+		From: "a",
+		Sources: []*LegacyTxInfoEndpoint{
+			{Address: "a", ContractAddress: "0xnft", TokenId: "1", Amount: xc.NewAmountBlockchainFromUint64(1)},
+			{Address: "a", ContractAddress: "0xnft", TokenId: "2", Amount: xc.NewAmountBlockchainFromUint64(1)},
+		},
+		Destinations: []*LegacyTxInfoEndpoint{
+			{Address: "b", ContractAddress: "0xnft", TokenId: "1", Amount: xc.NewAmountBlockchainFromUint64(1)},
+			{Address: "b", ContractAddress: "0xnft", TokenId: "2", Amount: xc.NewAmountBlockchainFromUint64(1)},
+		},
+	}
+	for _, mappingType := range []LegacyTxInfoMappingType{Account, Utxo} {
+		tx := TxInfoFromLegacy(chainCfg, legacyTx, mappingType)
+		tx.Coalesece()
+		// different token ids of the same contract should not be merged
+		require.Len(t, tx.Movements, 2)
+		require.Equal(t, "1", tx.Movements[0].TokenId)
+		require.Equal(t, "2", tx.Movements[1].TokenId)
+		require.EqualValues(t, "0xnft", tx.Movements[0].AssetId)
+		require.Len(t, tx.CalculateFees(), 0)
+	}
+}
+
 func TestTxInfoState(t *testing.T) {
 	chainCfg := xc.NewChainConfig(xc.BTC)
 	chainCfg.Confirmations.Final = 3
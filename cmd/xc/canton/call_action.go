@@ -1,6 +1,7 @@
 package canton
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -58,49 +59,17 @@ func newCallActionCommand(use string, short string, method xccall.Method) *cobra
 				return err
 			}
 
-			signerCollection := fsigner.NewCollection()
-			signerCollection.AddMainSigner(xcSigner, address)
-
-			rpcClient, err := xcFactory.NewClient(chainConfig)
-			if err != nil {
-				return fmt.Errorf("could not load client: %v", err)
-			}
-			callClient, ok := rpcClient.(client.CallClient)
-			if !ok {
-				return fmt.Errorf("chain %s does not support call actions", chainConfig.Chain)
-			}
-
 			payload, err := json.Marshal(xccall.SomeContractCall{ContractID: contractID})
 			if err != nil {
 				return err
 			}
-			callTx, err := drivers.NewCall(chainConfig.Base(), method, payload, []xc.Address{address})
+			hash, err := submitCallAction(ctx, xcFactory, chainConfig, xcSigner, address, method, payload, timeout)
 			if err != nil {
-				return fmt.Errorf("could not build call transaction: %v", err)
-			}
-
-			callArgs, err := builder.NewCallArgs(chainConfig.Base())
-			if err != nil {
-				return fmt.Errorf("could not build call arguments: %v", err)
-			}
-
-			input, err := callClient.FetchCallInput(ctx, callTx, callArgs)
-			if err != nil {
-				return fmt.Errorf("could not fetch call input: %v", err)
-			}
-
-			tx, err := commands.PrepareCallForSubmit(callTx, input, signerCollection)
-			if err != nil {
-				return fmt.Errorf("could not prepare call transaction: %v", err)
-			}
-
-			if err := commands.SubmitTransaction(chainConfig.Chain, rpcClient, tx, timeout); err != nil {
-				return fmt.Errorf("could not submit call transaction: %v", err)
+				return err
 			}
 
-			logrus.WithField("hash", tx.Hash()).Info("submitted tx")
 			fmt.Println(asJSON(map[string]any{
-				"hash":       tx.Hash(),
+				"hash":       hash,
 				"method":     method,
 				"address":    address,
 				"contractId": contractID,
@@ -114,6 +83,48 @@ func newCallActionCommand(use string, short string, method xccall.Method) *cobra
 	return cmd
 }
 
+// submitCallAction signs and submits a call exercising a choice on a single contract.
+func submitCallAction(ctx context.Context, xcFactory *factory.Factory, chainConfig *xc.ChainConfig, xcSigner *signer.Signer, address xc.Address, method xccall.Method, payload json.RawMessage, timeout time.Duration) (xc.TxHash, error) {
+	signerCollection := fsigner.NewCollection()
+	signerCollection.AddMainSigner(xcSigner, address)
+
+	rpcClient, err := xcFactory.NewClient(chainConfig)
+	if err != nil {
+		return "", fmt.Errorf("could not load client: %v", err)
+	}
+	callClient, ok := rpcClient.(client.CallClient)
+	if !ok {
+		return "", fmt.Errorf("chain %s does not support call actions", chainConfig.Chain)
+	}
+
+	callTx, err := drivers.NewCall(chainConfig.Base(), method, payload, []xc.Address{address})
+	if err != nil {
+		return "", fmt.Errorf("could not build call transaction: %v", err)
+	}
+
+	callArgs, err := builder.NewCallArgs(chainConfig.Base())
+	if err != nil {
+		return "", fmt.Errorf("could not build call arguments: %v", err)
+	}
+
+	input, err := callClient.FetchCallInput(ctx, callTx, callArgs)
+	if err != nil {
+		return "", fmt.Errorf("could not fetch call input: %v", err)
+	}
+
+	tx, err := commands.PrepareCallForSubmit(callTx, input, signerCollection)
+	if err != nil {
+		return "", fmt.Errorf("could not prepare call transaction: %v", err)
+	}
+
+	if err := commands.SubmitTransaction(chainConfig.Chain, rpcClient, tx, timeout); err != nil {
+		return "", fmt.Errorf("could not submit call transaction: %v", err)
+	}
+
+	logrus.WithField("hash", tx.Hash()).Info("submitted tx")
+	return tx.Hash(), nil
+}
+
 func signerAndAddressFromArgs(xcFactory *factory.Factory, chainConfig *xc.ChainConfig, args []string, keyRef string) (*signer.Signer, xc.Address, error) {
 	if len(args) == 0 {
 		return commands.SignerAndAddress(xcFactory, chainConfig, keyRef)
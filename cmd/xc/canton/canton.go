@@ -13,5 +13,6 @@ func CmdCanton() *cobra.Command {
 	cmd.AddCommand(CmdTraffic())
 	cmd.AddCommand(CmdOfferAccept())
 	cmd.AddCommand(CmdSettlementComplete())
+	cmd.AddCommand(CmdOffers())
 	return cmd
 }
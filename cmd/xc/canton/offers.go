@@ -0,0 +1,208 @@
+package canton
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xccall "github.com/cordialsys/crosschain/call"
+	"github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/cmd/xc/setup"
+	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// Contract id argument of `offers expire` that sweeps every expired offer
+const expireAll = "all"
+
+func CmdOffers() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "offers",
+		Short: "Reject, withdraw or expire pending Canton offers",
+		Args:  cobra.ExactArgs(0),
+	}
+	cmd.AddCommand(CmdOfferReject())
+	cmd.AddCommand(CmdOfferWithdraw())
+	cmd.AddCommand(CmdOfferExpire())
+	return cmd
+}
+
+func CmdOfferReject() *cobra.Command {
+	return newOfferActionCommand(
+		"reject <contract-id> [address]",
+		"Reject an incoming Canton offer or token transfer instruction by contract id.",
+		xccall.OfferReject,
+	)
+}
+
+func CmdOfferWithdraw() *cobra.Command {
+	return newOfferActionCommand(
+		"withdraw <contract-id> [address]",
+		"Withdraw an outgoing Canton offer, or an accepted offer pending settlement, by contract id.",
+		xccall.OfferWithdraw,
+	)
+}
+
+func newOfferActionCommand(use string, short string, method xccall.Method) *cobra.Command {
+	var privateKeyRef string
+	var reason string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			xcFactory := setup.UnwrapXc(ctx)
+			chainConfig := setup.UnwrapChain(ctx)
+			if chainConfig.Driver != xc.DriverCanton {
+				return fmt.Errorf("canton offers %s requires --chain CANTON, got driver %q", cmd.Name(), chainConfig.Driver)
+			}
+
+			contractID := args[0]
+			xcSigner, address, err := signerAndAddressFromArgs(xcFactory, chainConfig, args[1:], privateKeyRef)
+			if err != nil {
+				return err
+			}
+
+			payload, err := json.Marshal(xccall.OfferCall{ContractID: contractID, Reason: reason})
+			if err != nil {
+				return err
+			}
+			hash, err := submitCallAction(ctx, xcFactory, chainConfig, xcSigner, address, method, payload, timeout)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(asJSON(map[string]any{
+				"hash":       hash,
+				"method":     method,
+				"address":    address,
+				"contractId": contractID,
+			}))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&privateKeyRef, "key", "env:"+signer.EnvPrivateKey, "Private key reference")
+	if method == xccall.OfferWithdraw {
+		cmd.Flags().StringVar(&reason, "reason", "", "Reason recorded for the counterparty")
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 1*time.Minute, "Amount of time to wait for transaction submission.")
+	return cmd
+}
+
+func CmdOfferExpire() *cobra.Command {
+	var privateKeyRef string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "expire <contract-id|all> [address]",
+		Short: "Expire a Canton offer past its expiry by contract id, or sweep all expired offers of an address.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			xcFactory := setup.UnwrapXc(ctx)
+			chainConfig := setup.UnwrapChain(ctx)
+			if chainConfig.Driver != xc.DriverCanton {
+				return fmt.Errorf("canton offers %s requires --chain CANTON, got driver %q", cmd.Name(), chainConfig.Driver)
+			}
+
+			xcSigner, address, err := signerAndAddressFromArgs(xcFactory, chainConfig, args[1:], privateKeyRef)
+			if err != nil {
+				return err
+			}
+
+			if args[0] != expireAll {
+				contractID := args[0]
+				payload, err := json.Marshal(xccall.OfferCall{ContractID: contractID})
+				if err != nil {
+					return err
+				}
+				hash, err := submitCallAction(ctx, xcFactory, chainConfig, xcSigner, address, xccall.OfferExpire, payload, timeout)
+				if err != nil {
+					return err
+				}
+				fmt.Println(asJSON(map[string]any{
+					"hash":       hash,
+					"method":     xccall.OfferExpire,
+					"address":    address,
+					"contractId": contractID,
+				}))
+				return nil
+			}
+
+			rpcClient, err := xcFactory.NewClient(chainConfig)
+			if err != nil {
+				return fmt.Errorf("could not load client: %v", err)
+			}
+			offerClient, ok := rpcClient.(client.OfferClient)
+			if !ok {
+				return fmt.Errorf("chain %s does not support listing offers", chainConfig.Chain)
+			}
+			contractIDs, err := expiredOfferIDs(ctx, offerClient, address, time.Now())
+			if err != nil {
+				return err
+			}
+
+			results := []map[string]any{}
+			for _, contractID := range contractIDs {
+				payload, err := json.Marshal(xccall.OfferCall{ContractID: contractID})
+				if err != nil {
+					return err
+				}
+				result := map[string]any{
+					"method":     xccall.OfferExpire,
+					"address":    address,
+					"contractId": contractID,
+				}
+				hash, err := submitCallAction(ctx, xcFactory, chainConfig, xcSigner, address, xccall.OfferExpire, payload, timeout)
+				if err != nil {
+					// keep sweeping the remaining offers
+					logrus.WithError(err).WithField("contract_id", contractID).Warn("could not expire offer")
+					result["error"] = err.Error()
+				} else {
+					result["hash"] = hash
+				}
+				results = append(results, result)
+			}
+			fmt.Println(asJSON(results))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&privateKeyRef, "key", "env:"+signer.EnvPrivateKey, "Private key reference")
+	cmd.Flags().DurationVar(&timeout, "timeout", 1*time.Minute, "Amount of time to wait for each transaction submission.")
+	return cmd
+}
+
+// expiredOfferIDs lists the pending offers and settlements of an address that are past their expiry.
+// This includes token-standard transfer instructions, which are expired by rejecting or withdrawing them.
+func expiredOfferIDs(ctx context.Context, offerClient client.OfferClient, address xc.Address, now time.Time) ([]string, error) {
+	args := client.NewOfferArgs(address)
+	offers, err := offerClient.ListPendingOffers(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("could not list offers for address %s: %v", address, err)
+	}
+	settlements, err := offerClient.ListSettlements(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("could not list settlements for address %s: %v", address, err)
+	}
+
+	contractIDs := []string{}
+	for _, offer := range offers {
+		if offer.ExpiresAt != nil && offer.ExpiresAt.Before(now) {
+			contractIDs = append(contractIDs, offer.ID)
+		}
+	}
+	for _, settlement := range settlements {
+		if settlement.ExpiresAt != nil && settlement.ExpiresAt.Before(now) {
+			contractIDs = append(contractIDs, settlement.ID)
+		}
+	}
+	return contractIDs, nil
+}
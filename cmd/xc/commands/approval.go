@@ -0,0 +1,238 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/cmd/xc/setup"
+	"github.com/cordialsys/crosschain/factory/drivers"
+	"github.com/cordialsys/crosschain/factory/signer"
+	fsigner "github.com/cordialsys/crosschain/factory/signer"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func CmdAllowance() *cobra.Command {
+	var contract string
+	var privateKeyRef string
+	var decimal bool
+	cmd := &cobra.Command{
+		Use:   "allowance <spender> [owner]",
+		Short: "Check the amount of a token that the spender is allowed to transfer from the owner.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			xcFactory := setup.UnwrapXc(ctx)
+			chainConfig := setup.UnwrapChain(ctx)
+
+			if contract == "" {
+				return fmt.Errorf("--contract is required")
+			}
+			spender := xc.Address(args[0])
+			owner, err := inputAddressOrDerived(xcFactory, chainConfig, args[1:], privateKeyRef, "")
+			if err != nil {
+				return err
+			}
+
+			rpcClient, err := xcFactory.NewClient(chainConfig)
+			if err != nil {
+				return err
+			}
+			approvalClient, ok := rpcClient.(xclient.ApprovalClient)
+			if !ok {
+				return fmt.Errorf("chain %s does not support approvals", chainConfig.Chain)
+			}
+
+			allowance, err := approvalClient.FetchAllowance(ctx, owner, spender, xc.ContractAddress(contract))
+			if err != nil {
+				return fmt.Errorf("could not fetch allowance: %v", err)
+			}
+			if decimal {
+				decimals, err := rpcClient.FetchDecimals(ctx, xc.ContractAddress(contract))
+				if err != nil {
+					return fmt.Errorf("could not fetch decimals: %v", err)
+				}
+				fmt.Println(allowance.ToHuman(int32(decimals)).String())
+			} else {
+				fmt.Println(allowance.String())
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&privateKeyRef, "key", "env:"+signer.EnvPrivateKey, "Private key reference, used to derive the owner if not provided")
+	cmd.Flags().StringVar(&contract, "contract", "", "Contract of the token")
+	cmd.Flags().BoolVar(&decimal, "decimal", false, "Report allowance as a decimal.  If set, the decimals will be looked up.")
+	return cmd
+}
+
+func CmdApprove() *cobra.Command {
+	var contract string
+	var privateKeyRef string
+	var increase bool
+	var revoke bool
+	var timeout time.Duration
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "approve <spender> [amount]",
+		Short: "Approve a spender to transfer a token on behalf of the owner, or revoke an approval.  The amount should be a decimal amount.",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			xcFactory := setup.UnwrapXc(ctx)
+			chainConfig := setup.UnwrapChain(ctx)
+
+			if contract == "" {
+				return fmt.Errorf("--contract is required")
+			}
+			spender := xc.Address(args[0])
+			action := xcbuilder.ApprovalActionApprove
+			if increase {
+				action = xcbuilder.ApprovalActionIncrease
+			}
+			if revoke {
+				if increase {
+					return fmt.Errorf("cannot use both --increase and --revoke")
+				}
+				action = xcbuilder.ApprovalActionRevoke
+			} else if len(args) < 2 {
+				return fmt.Errorf("amount is required, unless revoking")
+			}
+
+			mainSigner, owner, err := SignerAndAddress(xcFactory, chainConfig, privateKeyRef)
+			if err != nil {
+				return err
+			}
+			signerCollection := fsigner.NewCollection()
+			signerCollection.AddMainSigner(mainSigner, owner)
+			publicKey, err := mainSigner.PublicKey()
+			if err != nil {
+				return fmt.Errorf("could not create public key: %v", err)
+			}
+
+			rpcClient, err := xcFactory.NewClient(chainConfig)
+			if err != nil {
+				return fmt.Errorf("could not load client: %v", err)
+			}
+			approvalClient, ok := rpcClient.(xclient.ApprovalClient)
+			if !ok {
+				return fmt.Errorf("chain %s does not support approvals", chainConfig.Chain)
+			}
+			txBuilder, err := xcFactory.NewTxBuilder(chainConfig.Base())
+			if err != nil {
+				return fmt.Errorf("could not load tx-builder: %v", err)
+			}
+			approvalBuilder, ok := txBuilder.(xcbuilder.Approval)
+			if !ok {
+				return fmt.Errorf("chain %s does not support approval transactions", chainConfig.Chain)
+			}
+
+			amount := xc.NewAmountBlockchainFromUint64(0)
+			if action != xcbuilder.ApprovalActionRevoke {
+				decimals, err := rpcClient.FetchDecimals(ctx, xc.ContractAddress(contract))
+				if err != nil {
+					return fmt.Errorf("could not fetch decimals: %v", err)
+				}
+				amountHuman, err := xc.NewAmountHumanReadableFromStr(args[1])
+				if err != nil {
+					return err
+				}
+				amount = amountHuman.ToBlockchain(int32(decimals))
+			}
+
+			approvalArgs, err := xcbuilder.NewApprovalArgs(
+				chainConfig.Chain, action, owner, spender, xc.ContractAddress(contract), amount,
+				xcbuilder.OptionPublicKey(publicKey),
+				xcbuilder.OptionTimestamp(time.Now().Unix()),
+			)
+			if err != nil {
+				return err
+			}
+
+			current, err := approvalClient.FetchAllowance(ctx, owner, spender, xc.ContractAddress(contract))
+			if err != nil {
+				return fmt.Errorf("could not fetch allowance: %v", err)
+			}
+			logrus.WithFields(logrus.Fields{
+				"owner":     owner,
+				"spender":   spender,
+				"action":    action,
+				"amount":    amount.String(),
+				"allowance": current.String(),
+			}).Info("changing allowance")
+
+			input, err := approvalClient.FetchApprovalInput(ctx, approvalArgs)
+			if err != nil {
+				return fmt.Errorf("could not fetch approval input: %v", err)
+			}
+			// verify we can marshal/unmarshal the input
+			inputBz, err := drivers.MarshalVariantInput(input)
+			if err != nil {
+				return fmt.Errorf("could not marshal approval input: %v", err)
+			}
+			input, err = drivers.UnmarshalApprovalInput(inputBz)
+			if err != nil {
+				return fmt.Errorf("could not unmarshal approval input: %v", err)
+			}
+			logrus.WithField("input", string(inputBz)).Debug("approval input")
+
+			tx, err := prepareApprovalForSubmit(approvalBuilder, approvalArgs, input, signerCollection)
+			if err != nil {
+				return fmt.Errorf("could not prepare approval tx: %v", err)
+			}
+			if dryRun {
+				bz, err := tx.Serialize()
+				if err != nil {
+					return err
+				}
+				fmt.Println(asJson(map[string]any{
+					"hash":        tx.Hash(),
+					"transaction": fmt.Sprintf("%x", bz),
+				}))
+				return nil
+			}
+
+			if err := SubmitTransaction(chainConfig.Chain, rpcClient, tx, timeout); err != nil {
+				return fmt.Errorf("could not submit approval tx: %v", err)
+			}
+			logrus.WithField("hash", tx.Hash()).Info("submitted tx")
+			fmt.Println(asJson(map[string]any{
+				"hash": tx.Hash(),
+			}))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&privateKeyRef, "key", "env:"+signer.EnvPrivateKey, "Secret reference for the owner private key")
+	cmd.Flags().StringVar(&contract, "contract", "", "Contract of the token")
+	cmd.Flags().BoolVar(&increase, "increase", false, "Increase the current allowance by the amount, rather than setting it")
+	cmd.Flags().BoolVar(&revoke, "revoke", false, "Revoke the approval by setting the allowance to zero")
+	cmd.Flags().DurationVar(&timeout, "timeout", 1*time.Minute, "Amount of time to wait for transaction submission")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the signed transaction without submitting it")
+	return cmd
+}
+
+func prepareApprovalForSubmit(approvalBuilder xcbuilder.Approval, args xcbuilder.ApprovalArgs, input xc.ApprovalTxInput, signerCollection *fsigner.Collection) (xc.Tx, error) {
+	tx, err := approvalBuilder.Approve(args, input)
+	if err != nil {
+		return nil, fmt.Errorf("could not build approval tx: %v", err)
+	}
+
+	signatures := []*xc.SignatureResponse{}
+	sighashes, err := tx.Sighashes()
+	if err != nil {
+		return nil, fmt.Errorf("could not create payloads to sign: %v", err)
+	}
+	for _, sighash := range sighashes {
+		signature, err := signerCollection.Sign(sighash.Signer, sighash.Payload)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, signature)
+	}
+	if err := tx.SetSignatures(signatures...); err != nil {
+		return nil, fmt.Errorf("could not add signature(s): %v", err)
+	}
+	return tx, nil
+}
@@ -120,13 +120,20 @@ func CmdCallTx() *cobra.Command {
 				logrus.WithField("hash", tx.Hash()).Info("submitted tx")
 			}
 
-			fmt.Println(asJson(map[string]any{
+			output := map[string]any{
 				"hash":             tx.Hash(),
 				"method":           method,
 				"signingAddresses": signingAddresses,
 				"submitted":        submit,
 				"transaction":      hex.EncodeToString(serialized),
-			}))
+			}
+			// e.g. typed-data that is recognized as a token permit
+			if decoder, ok := callTx.(interface{ Decode() any }); ok {
+				if decoded := decoder.Decode(); decoded != nil {
+					output["decoded"] = decoded
+				}
+			}
+			fmt.Println(asJson(output))
 			return nil
 		},
 	}
@@ -21,6 +21,7 @@ import (
 func CmdCreateAccount() *cobra.Command {
 	var privateKeyRef string
 	var timeout time.Duration
+	var contract string
 
 	cmd := &cobra.Command{
 		Use:   "create-account",
@@ -79,7 +80,12 @@ func CmdCreateAccount() *cobra.Command {
 			}
 
 			createArgs := xclient.NewCreateAccountArgs(address, publicKey)
-			builderArgs, err := xcbuilder.NewCreateAccountArgs(chainConfig.Chain, address, publicKey)
+			builderOptions := []xcbuilder.BuilderOption{}
+			if contract != "" {
+				createArgs = createArgs.WithContract(xc.ContractAddress(contract))
+				builderOptions = append(builderOptions, xcbuilder.OptionContractAddress(xc.ContractAddress(contract)))
+			}
+			builderArgs, err := xcbuilder.NewCreateAccountArgs(chainConfig.Chain, address, publicKey, builderOptions...)
 			if err != nil {
 				return fmt.Errorf("could not build create-account args: %v", err)
 			}
@@ -178,6 +184,7 @@ func CmdCreateAccount() *cobra.Command {
 
 	cmd.Flags().StringVar(&privateKeyRef, "key", "env:"+signer.EnvPrivateKey, "Secret reference for the private key")
 	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Amount of time to wait for account creation to complete.")
+	cmd.Flags().StringVar(&contract, "contract", "", "Token contract to register the account for, on chains that require it (e.g. hedera token association).")
 	return cmd
 }
 
@@ -15,6 +15,9 @@ func CmdTools() *cobra.Command {
 
 	cmd.AddCommand(tools.CmdDebug())
 	cmd.AddCommand(tools.CmdEos())
+	cmd.AddCommand(tools.CmdSolana())
+	cmd.AddCommand(tools.CmdSui())
+	cmd.AddCommand(tools.CmdTon())
 
 	return cmd
 }
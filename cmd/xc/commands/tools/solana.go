@@ -0,0 +1,19 @@
+package tools
+
+import (
+	"github.com/cordialsys/crosschain/cmd/xc/commands/tools/solanatools"
+	"github.com/spf13/cobra"
+)
+
+func CmdSolana() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "solana",
+		Short:        "Utilities for Solana chain",
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(solanatools.CmdAlt())
+
+	return cmd
+}
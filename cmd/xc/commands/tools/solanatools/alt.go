@@ -0,0 +1,225 @@
+package solanatools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/solana/builder"
+	"github.com/cordialsys/crosschain/chain/solana/client"
+	"github.com/cordialsys/crosschain/chain/solana/tx_input"
+	xctypes "github.com/cordialsys/crosschain/client/types"
+	"github.com/cordialsys/crosschain/cmd/xc/setup"
+	"github.com/cordialsys/crosschain/config"
+	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+)
+
+func CmdAlt() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "alt",
+		Short:        "Manage address lookup tables, used to compress v0 transactions",
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(CmdAltCreate())
+	cmd.AddCommand(CmdAltExtend())
+	cmd.AddCommand(CmdAltDeactivate())
+	cmd.AddCommand(CmdAltClose())
+
+	return cmd
+}
+
+func CmdAltCreate() *cobra.Command {
+	var dryRun bool
+	var fromSecretRef string
+	cmd := &cobra.Command{
+		Use:   "create [address...]",
+		Short: "Create a new address lookup table, optionally adding an initial set of addresses.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addresses, err := parseAddresses(args)
+			if err != nil {
+				return err
+			}
+			return runAltTx(cmd.Context(), fromSecretRef, dryRun, func(txBuilder builder.TxBuilder, from xc.Address, input *tx_input.TxInput, recentSlot uint64) (xc.Tx, error) {
+				tx, table, err := txBuilder.CreateLookupTable(from, recentSlot, addresses, input)
+				if err != nil {
+					return nil, err
+				}
+				fmt.Printf("address lookup table: %s\n", table)
+				return tx, nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&fromSecretRef, "from", "env:"+signer.EnvPrivateKey, "Secret reference for the lookup table authority private key")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Dry run the transaction, printing it, but not submitting it.")
+	return cmd
+}
+
+func CmdAltExtend() *cobra.Command {
+	var dryRun bool
+	var fromSecretRef string
+	cmd := &cobra.Command{
+		Use:   "extend <table> <address...>",
+		Short: "Add addresses to an address lookup table.",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			table, err := solana.PublicKeyFromBase58(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid lookup table address: %v", err)
+			}
+			addresses, err := parseAddresses(args[1:])
+			if err != nil {
+				return err
+			}
+			return runAltTx(cmd.Context(), fromSecretRef, dryRun, func(txBuilder builder.TxBuilder, from xc.Address, input *tx_input.TxInput, _ uint64) (xc.Tx, error) {
+				return txBuilder.ExtendLookupTable(from, table, addresses, input)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&fromSecretRef, "from", "env:"+signer.EnvPrivateKey, "Secret reference for the lookup table authority private key")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Dry run the transaction, printing it, but not submitting it.")
+	return cmd
+}
+
+func CmdAltDeactivate() *cobra.Command {
+	var dryRun bool
+	var fromSecretRef string
+	cmd := &cobra.Command{
+		Use:   "deactivate <table>",
+		Short: "Deactivate an address lookup table.  It may be closed once the deactivation slot is no longer recent.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			table, err := solana.PublicKeyFromBase58(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid lookup table address: %v", err)
+			}
+			return runAltTx(cmd.Context(), fromSecretRef, dryRun, func(txBuilder builder.TxBuilder, from xc.Address, input *tx_input.TxInput, _ uint64) (xc.Tx, error) {
+				return txBuilder.DeactivateLookupTable(from, table, input)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&fromSecretRef, "from", "env:"+signer.EnvPrivateKey, "Secret reference for the lookup table authority private key")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Dry run the transaction, printing it, but not submitting it.")
+	return cmd
+}
+
+func CmdAltClose() *cobra.Command {
+	var dryRun bool
+	var fromSecretRef string
+	cmd := &cobra.Command{
+		Use:   "close <table>",
+		Short: "Close a deactivated address lookup table, reclaiming the rent.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			table, err := solana.PublicKeyFromBase58(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid lookup table address: %v", err)
+			}
+			return runAltTx(cmd.Context(), fromSecretRef, dryRun, func(txBuilder builder.TxBuilder, from xc.Address, input *tx_input.TxInput, _ uint64) (xc.Tx, error) {
+				return txBuilder.CloseLookupTable(from, table, input)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&fromSecretRef, "from", "env:"+signer.EnvPrivateKey, "Secret reference for the lookup table authority private key")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Dry run the transaction, printing it, but not submitting it.")
+	return cmd
+}
+
+func parseAddresses(args []string) ([]solana.PublicKey, error) {
+	addresses := make([]solana.PublicKey, len(args))
+	for i, arg := range args {
+		address, err := solana.PublicKeyFromBase58(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %s: %v", arg, err)
+		}
+		addresses[i] = address
+	}
+	return addresses, nil
+}
+
+type buildAltTx func(txBuilder builder.TxBuilder, from xc.Address, input *tx_input.TxInput, recentSlot uint64) (xc.Tx, error)
+
+func runAltTx(ctx context.Context, fromSecretRef string, dryRun bool, build buildAltTx) error {
+	xcFactory := setup.UnwrapXc(ctx)
+	chainConfig := setup.UnwrapChain(ctx)
+	if chainConfig.Driver != xc.DriverSolana {
+		return fmt.Errorf("chain %s is not a solana chain", chainConfig.Chain)
+	}
+
+	privateKeyInput, err := config.GetSecret(fromSecretRef)
+	if err != nil {
+		return fmt.Errorf("could not get from-address secret: %v", err)
+	}
+	if privateKeyInput == "" {
+		return fmt.Errorf("must set env %s", signer.EnvPrivateKey)
+	}
+	mainSigner, err := xcFactory.NewSigner(chainConfig.Base(), privateKeyInput)
+	if err != nil {
+		return fmt.Errorf("could not import private key: %v", err)
+	}
+	publicKey, err := mainSigner.PublicKey()
+	if err != nil {
+		return fmt.Errorf("could not create public key: %v", err)
+	}
+	from, err := xcFactory.GetAddressFromPublicKey(chainConfig.Base(), publicKey)
+	if err != nil {
+		return err
+	}
+
+	rpcClient, err := client.NewClient(chainConfig)
+	if err != nil {
+		return fmt.Errorf("could not load client: %v", err)
+	}
+	input, recentSlot, err := rpcClient.FetchLookupTableInput(ctx, from)
+	if err != nil {
+		return fmt.Errorf("could not fetch input: %v", err)
+	}
+	txBuilder, err := builder.NewTxBuilder(chainConfig.Base())
+	if err != nil {
+		return err
+	}
+	tx, err := build(txBuilder, from, input, recentSlot)
+	if err != nil {
+		return err
+	}
+
+	sighashes, err := tx.Sighashes()
+	if err != nil {
+		return fmt.Errorf("could not create payloads to sign: %v", err)
+	}
+	signatures, err := mainSigner.SignAll(sighashes)
+	if err != nil {
+		return fmt.Errorf("could not sign: %v", err)
+	}
+	if err = tx.SetSignatures(signatures...); err != nil {
+		return fmt.Errorf("could not add signature(s): %v", err)
+	}
+	serialized, err := tx.Serialize()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("transaction id: %s\n", tx.Hash())
+
+	if dryRun {
+		bz, _ := json.MarshalIndent(map[string]interface{}{
+			"hash": tx.Hash(),
+			"tx":   serialized,
+		}, "", "  ")
+		fmt.Println(string(bz))
+		return nil
+	}
+
+	err = rpcClient.SubmitTx(ctx, xctypes.SubmitTxReq{
+		Chain:  chainConfig.Chain,
+		TxData: serialized,
+	})
+	if err != nil {
+		return fmt.Errorf("could not broadcast: %v", err)
+	}
+	fmt.Printf("%s submitted\n", tx.Hash())
+	return nil
+}
@@ -0,0 +1,20 @@
+package tools
+
+import (
+	"github.com/cordialsys/crosschain/cmd/xc/commands/tools/suitools"
+	"github.com/spf13/cobra"
+)
+
+func CmdSui() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "sui",
+		Short:        "Utilities for Sui chain",
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(suitools.CmdMergeCoins())
+	cmd.AddCommand(suitools.CmdSplitCoins())
+
+	return cmd
+}
@@ -0,0 +1,200 @@
+package suitools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/sui"
+	xctypes "github.com/cordialsys/crosschain/client/types"
+	"github.com/cordialsys/crosschain/cmd/xc/setup"
+	"github.com/cordialsys/crosschain/config"
+	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/spf13/cobra"
+)
+
+func CmdMergeCoins() *cobra.Command {
+	var dryRun bool
+	var fromSecretRef string
+	var contract string
+	var maxTransactions int
+	cmd := &cobra.Command{
+		Use:   "merge-coins",
+		Short: "Merge all coins of an address into a single object, using as many transactions as needed.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			account, err := loadAccount(ctx, fromSecretRef)
+			if err != nil {
+				return err
+			}
+			for i := 0; i < maxTransactions; i++ {
+				input, err := account.client.FetchMergeCoinsInput(ctx, account.address, account.publicKey, xc.ContractAddress(contract))
+				if err != nil {
+					return fmt.Errorf("could not fetch input: %v", err)
+				}
+				if !input.HasCoinsToMerge() {
+					fmt.Println("nothing to merge")
+					return nil
+				}
+				tx, err := account.builder.MergeCoins(account.address, account.publicKey, input)
+				if err != nil {
+					return err
+				}
+				if err = account.signAndSubmit(ctx, tx, dryRun); err != nil {
+					return err
+				}
+				if dryRun {
+					// the next page depends on this transaction
+					return nil
+				}
+			}
+			return fmt.Errorf("coins remain after %d transactions", maxTransactions)
+		},
+	}
+	cmd.Flags().StringVar(&fromSecretRef, "from", "env:"+signer.EnvPrivateKey, "Secret reference for the coin owner private key")
+	cmd.Flags().StringVar(&contract, "contract", "", "Coin type to merge, defaults to SUI")
+	cmd.Flags().IntVar(&maxTransactions, "max-transactions", 10, "Maximum number of merge transactions to submit")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Dry run the first transaction, printing it, but not submitting it.")
+	return cmd
+}
+
+func CmdSplitCoins() *cobra.Command {
+	var dryRun bool
+	var fromSecretRef string
+	var contract string
+	var amountStr string
+	var count int
+	cmd := &cobra.Command{
+		Use:   "split",
+		Short: "Split new coins of an exact amount off of the coins of an address.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if amountStr == "" {
+				return fmt.Errorf("must set --amount")
+			}
+			if count <= 0 {
+				return fmt.Errorf("--count must be positive")
+			}
+			account, err := loadAccount(ctx, fromSecretRef)
+			if err != nil {
+				return err
+			}
+			decimals, err := account.client.FetchDecimals(ctx, xc.ContractAddress(contract))
+			if err != nil {
+				return fmt.Errorf("could not fetch decimals: %v", err)
+			}
+			amountHuman, err := xc.NewAmountHumanReadableFromStr(amountStr)
+			if err != nil {
+				return fmt.Errorf("invalid amount: %v", err)
+			}
+			amounts := make([]xc.AmountBlockchain, count)
+			for i := range amounts {
+				amounts[i] = amountHuman.ToBlockchain(int32(decimals))
+			}
+
+			input, err := account.client.FetchSplitCoinsInput(ctx, account.address, account.publicKey, xc.ContractAddress(contract), amounts)
+			if err != nil {
+				return fmt.Errorf("could not fetch input: %v", err)
+			}
+			tx, err := account.builder.SplitCoins(account.address, account.publicKey, amounts, input)
+			if err != nil {
+				return err
+			}
+			return account.signAndSubmit(ctx, tx, dryRun)
+		},
+	}
+	cmd.Flags().StringVar(&fromSecretRef, "from", "env:"+signer.EnvPrivateKey, "Secret reference for the coin owner private key")
+	cmd.Flags().StringVar(&contract, "contract", "", "Coin type to split, defaults to SUI")
+	cmd.Flags().StringVar(&amountStr, "amount", "", "Amount of each new coin, in human-readable units")
+	cmd.Flags().IntVar(&count, "count", 1, "Number of new coins")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Dry run the transaction, printing it, but not submitting it.")
+	return cmd
+}
+
+type account struct {
+	signer    *signer.Signer
+	address   xc.Address
+	publicKey []byte
+	client    *sui.Client
+	builder   *sui.TxBuilder
+	chain     xc.NativeAsset
+}
+
+func loadAccount(ctx context.Context, fromSecretRef string) (*account, error) {
+	xcFactory := setup.UnwrapXc(ctx)
+	chainConfig := setup.UnwrapChain(ctx)
+	if chainConfig.Driver != xc.DriverSui {
+		return nil, fmt.Errorf("chain %s is not a sui chain", chainConfig.Chain)
+	}
+
+	privateKeyInput, err := config.GetSecret(fromSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("could not get from-address secret: %v", err)
+	}
+	if privateKeyInput == "" {
+		return nil, fmt.Errorf("must set env %s", signer.EnvPrivateKey)
+	}
+	mainSigner, err := xcFactory.NewSigner(chainConfig.Base(), privateKeyInput)
+	if err != nil {
+		return nil, fmt.Errorf("could not import private key: %v", err)
+	}
+	publicKey, err := mainSigner.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("could not create public key: %v", err)
+	}
+	from, err := xcFactory.GetAddressFromPublicKey(chainConfig.Base(), publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcClient, err := sui.NewClient(chainConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not load client: %v", err)
+	}
+	txBuilder, err := sui.NewTxBuilder(chainConfig.Base())
+	if err != nil {
+		return nil, err
+	}
+	return &account{
+		signer:    mainSigner,
+		address:   from,
+		publicKey: publicKey,
+		client:    rpcClient,
+		builder:   txBuilder,
+		chain:     chainConfig.Chain,
+	}, nil
+}
+
+func (account *account) signAndSubmit(ctx context.Context, tx xc.Tx, dryRun bool) error {
+	sighashes, err := tx.Sighashes()
+	if err != nil {
+		return fmt.Errorf("could not create payloads to sign: %v", err)
+	}
+	signatures, err := account.signer.SignAll(sighashes)
+	if err != nil {
+		return fmt.Errorf("could not sign: %v", err)
+	}
+	if err = tx.SetSignatures(signatures...); err != nil {
+		return fmt.Errorf("could not add signature(s): %v", err)
+	}
+	req, err := xctypes.SubmitTxReqFromTx(account.chain, tx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("transaction id: %s\n", tx.Hash())
+
+	if dryRun {
+		bz, _ := json.MarshalIndent(req, "", "  ")
+		fmt.Println(string(bz))
+		return nil
+	}
+
+	if err = account.client.SubmitTx(ctx, req); err != nil {
+		return fmt.Errorf("could not broadcast: %v", err)
+	}
+	fmt.Printf("%s submitted\n", tx.Hash())
+	return nil
+}
@@ -0,0 +1,19 @@
+package tools
+
+import (
+	"github.com/cordialsys/crosschain/cmd/xc/commands/tools/tontools"
+	"github.com/spf13/cobra"
+)
+
+func CmdTon() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "ton",
+		Short:        "Utilities for TON chain",
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(tontools.CmdJettonBurn())
+
+	return cmd
+}
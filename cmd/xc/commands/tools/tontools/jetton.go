@@ -0,0 +1,149 @@
+package tontools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/ton"
+	xctypes "github.com/cordialsys/crosschain/client/types"
+	"github.com/cordialsys/crosschain/cmd/xc/setup"
+	"github.com/cordialsys/crosschain/config"
+	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/spf13/cobra"
+)
+
+func CmdJettonBurn() *cobra.Command {
+	var dryRun bool
+	var fromSecretRef string
+	var contract string
+	cmd := &cobra.Command{
+		Use:   "jetton-burn <amount>",
+		Short: "Burn jettons held by an address, e.g. to redeem them with the issuer. The amount should be a decimal amount.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if contract == "" {
+				return fmt.Errorf("must set --contract")
+			}
+			account, err := loadAccount(ctx, fromSecretRef)
+			if err != nil {
+				return err
+			}
+			decimals, err := account.client.FetchDecimals(ctx, xc.ContractAddress(contract))
+			if err != nil {
+				return fmt.Errorf("could not fetch decimals: %v", err)
+			}
+			amountHuman, err := xc.NewAmountHumanReadableFromStr(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid amount: %v", err)
+			}
+
+			burnArgs := ton.JettonBurnArgs{
+				From:      account.address,
+				PublicKey: account.publicKey,
+				Contract:  xc.ContractAddress(contract),
+				Amount:    amountHuman.ToBlockchain(int32(decimals)),
+			}
+			input, err := account.client.FetchJettonBurnInput(ctx, burnArgs)
+			if err != nil {
+				return fmt.Errorf("could not fetch input: %v", err)
+			}
+			tx, err := account.builder.JettonBurn(burnArgs, input)
+			if err != nil {
+				return err
+			}
+			return account.signAndSubmit(ctx, tx, dryRun)
+		},
+	}
+	cmd.Flags().StringVar(&fromSecretRef, "from", "env:"+signer.EnvPrivateKey, "Secret reference for the jetton owner private key")
+	cmd.Flags().StringVar(&contract, "contract", "", "Jetton master address of the jettons to burn")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Dry run the transaction, printing it, but not submitting it.")
+	return cmd
+}
+
+type account struct {
+	signer    *signer.Signer
+	address   xc.Address
+	publicKey []byte
+	client    *ton.Client
+	builder   ton.TxBuilder
+	chain     xc.NativeAsset
+}
+
+func loadAccount(ctx context.Context, fromSecretRef string) (*account, error) {
+	xcFactory := setup.UnwrapXc(ctx)
+	chainConfig := setup.UnwrapChain(ctx)
+	if chainConfig.Driver != xc.DriverTon {
+		return nil, fmt.Errorf("chain %s is not a ton chain", chainConfig.Chain)
+	}
+
+	privateKeyInput, err := config.GetSecret(fromSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("could not get from-address secret: %v", err)
+	}
+	if privateKeyInput == "" {
+		return nil, fmt.Errorf("must set env %s", signer.EnvPrivateKey)
+	}
+	mainSigner, err := xcFactory.NewSigner(chainConfig.Base(), privateKeyInput)
+	if err != nil {
+		return nil, fmt.Errorf("could not import private key: %v", err)
+	}
+	publicKey, err := mainSigner.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("could not create public key: %v", err)
+	}
+	from, err := xcFactory.GetAddressFromPublicKey(chainConfig.Base(), publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcClient, err := ton.NewClient(chainConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not load client: %v", err)
+	}
+	txBuilder, err := ton.NewTxBuilder(chainConfig.Base())
+	if err != nil {
+		return nil, err
+	}
+	return &account{
+		signer:    mainSigner,
+		address:   from,
+		publicKey: publicKey,
+		client:    rpcClient,
+		builder:   txBuilder,
+		chain:     chainConfig.Chain,
+	}, nil
+}
+
+func (account *account) signAndSubmit(ctx context.Context, tx xc.Tx, dryRun bool) error {
+	sighashes, err := tx.Sighashes()
+	if err != nil {
+		return fmt.Errorf("could not create payloads to sign: %v", err)
+	}
+	signatures, err := account.signer.SignAll(sighashes)
+	if err != nil {
+		return fmt.Errorf("could not sign: %v", err)
+	}
+	if err = tx.SetSignatures(signatures...); err != nil {
+		return fmt.Errorf("could not add signature(s): %v", err)
+	}
+	req, err := xctypes.SubmitTxReqFromTx(account.chain, tx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("transaction id: %s\n", tx.Hash())
+
+	if dryRun {
+		bz, _ := json.MarshalIndent(req, "", "  ")
+		fmt.Println(string(bz))
+		return nil
+	}
+
+	if err = account.client.SubmitTx(ctx, req); err != nil {
+		return fmt.Errorf("could not broadcast: %v", err)
+	}
+	fmt.Printf("%s submitted\n", tx.Hash())
+	return nil
+}
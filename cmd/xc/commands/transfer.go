@@ -38,6 +38,9 @@ func CmdTxTransfer() *cobra.Command {
 	var addressFormat string
 	var nonDeterministic bool
 	var transferInputFile string
+	var smartAccount string
+	var smartAccountFactory string
+	var smartAccountFactoryData string
 
 	cmd := &cobra.Command{
 		Use:     "transfer <to> <amount>",
@@ -139,6 +142,19 @@ func CmdTxTransfer() *cobra.Command {
 			}
 			signerCollection := signer.NewCollection()
 			signerCollection.AddMainSigner(mainSigner, from)
+			if smartAccount != "" {
+				// the derived address owns the smart account, which becomes the sender
+				logrus.WithField("owner", from).Info("using smart account")
+				tfOptions = append(tfOptions, builder.OptionSmartAccountOwner(from))
+				if smartAccountFactory != "" {
+					factoryData, err := hex.DecodeString(strings.TrimPrefix(smartAccountFactoryData, "0x"))
+					if err != nil {
+						return fmt.Errorf("invalid --smart-account-factory-data: %v", err)
+					}
+					tfOptions = append(tfOptions, builder.OptionSmartAccountFactory(xc.Address(smartAccountFactory), factoryData))
+				}
+				from = xc.Address(smartAccount)
+			}
 
 			txBuilder, err := xcFactory.NewTxBuilder(chainConfig.GetChain().Base())
 			if err != nil {
@@ -400,6 +416,9 @@ func CmdTxTransfer() *cobra.Command {
 	cmd.Flags().Int64Var(&txTime, "tx-time", 0, "Block time of the transaction")
 	cmd.Flags().StringVar(&addressFormat, "address-format", "", "format of the address")
 	cmd.Flags().BoolVar(&nonDeterministic, "non-deterministic", false, "Skip implementation checks for determinism (only important in for consensus sensitive contexts)")
+	cmd.Flags().StringVar(&smartAccount, "smart-account", "", "Send from an ERC-4337 smart account owned by the from-address private key (requires a bundler-url).")
+	cmd.Flags().StringVar(&smartAccountFactory, "smart-account-factory", "", "Factory that deploys the --smart-account with this transfer, if it is not deployed yet.")
+	cmd.Flags().StringVar(&smartAccountFactoryData, "smart-account-factory-data", "", "Hex call data for the --smart-account-factory.")
 	cmd.Flags().StringVar(&transferInputFile, "input", "", "File containing the transfer input.  If used, will skip fetching the input from the RPC.")
 	return cmd
 }
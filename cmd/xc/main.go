@@ -101,6 +101,8 @@ func CmdXc() *cobra.Command {
 	cmd.AddCommand(commands.CmdSign())
 	cmd.AddCommand(commands.CmdRpcSubmit())
 	cmd.AddCommand(commands.CmdCreateAccount())
+	cmd.AddCommand(commands.CmdAllowance())
+	cmd.AddCommand(commands.CmdApprove())
 	cmd.AddCommand(canton.CmdCanton())
 	cmd.AddCommand(staking.CmdStaking())
 	cmd.AddCommand(commands.CmdTools())
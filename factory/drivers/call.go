@@ -19,6 +19,9 @@ func NewCall(cfg *xc.ChainBaseConfig, method call.Method, msg json.RawMessage, s
 	case xc.DriverCanton:
 		return cantoncall.NewCall(cfg, method, msg, signingAddresses)
 	case xc.DriverEVM:
+		if method == call.EthSignTypedDataV4 {
+			return evmcall.NewTypedDataCall(cfg, method, msg, signingAddresses)
+		}
 		return evmcall.NewCall(cfg, method, msg, signingAddresses)
 	case xc.DriverSolana:
 		return solanacall.NewCall(cfg, method, msg, signingAddresses)
@@ -220,6 +220,15 @@ func (s *CrosschainTestSuite) TestAllNewStakingInput() {
 			case "create-account":
 				_, err := drivers.UnmarshalCreateAccountInput(bz)
 				require.NoError(err)
+			case "approval":
+				_, err := drivers.UnmarshalApprovalInput(bz)
+				require.NoError(err)
+			case "nft-transfer":
+				_, err := drivers.UnmarshalNftTransferInput(bz)
+				require.NoError(err)
+			case "access-key":
+				_, err := drivers.UnmarshalAccessKeyInput(bz)
+				require.NoError(err)
 			default:
 				require.Fail("unexpected txType ", inputType)
 			}
@@ -233,7 +242,7 @@ func (s *CrosschainTestSuite) TestStakingVariants() {
 	for _, variant := range registry.GetSupportedTxVariants() {
 		variantType := variant.GetVariant()
 		parts := strings.Split(string(variantType), "/")
-		inputColumns := []string{"staking", "unstaking", "withdrawing", "multi-transfer", "calling", "create-account"}
+		inputColumns := []string{"staking", "unstaking", "withdrawing", "multi-transfer", "calling", "create-account", "approval", "nft-transfer", "access-key"}
 		require.Len(parts, 4, "variant must be in format drivers/:driver/[ "+strings.Join(inputColumns, "|")+" ]/:id")
 		require.Equal("drivers", parts[0])
 		require.Contains(inputColumns, parts[2], "input type column must be one of: "+strings.Join(inputColumns, ", "))
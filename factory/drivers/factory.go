@@ -196,6 +196,8 @@ func NewStakingClient(servicesConfig *services.ServicesConfig, cfg *xc.ChainConf
 		return sui.NewClient(cfg)
 	case xc.DriverTron:
 		return tron.NewClient(cfg)
+	case xc.DriverInternetComputerProtocol:
+		return icpclient.NewClient(cfg)
 	}
 	return nil, fmt.Errorf("no staking client defined for %s on %s", provider, driver)
 }
@@ -297,7 +299,7 @@ func NewAddressBuilder(cfg *xc.ChainBaseConfig, options ...xcaddress.AddressOpti
 	case xc.DriverTron:
 		return tron.NewAddressBuilder(cfg)
 	case xc.DriverTon:
-		return tonaddress.NewAddressBuilder(cfg)
+		return tonaddress.NewAddressBuilder(cfg, options...)
 	case xc.DriverXrp:
 		return xrpaddress.NewAddressBuilder(cfg)
 	case xc.DriverXlm:
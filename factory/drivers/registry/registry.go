@@ -33,7 +33,10 @@ func RegisterTxVariantInput(variant xc.TxVariantInput) {
 	_, ok4 := variant.(xc.MultiTransferInput)
 	_, ok5 := variant.(xc.CallTxInput)
 	_, ok6 := variant.(xc.CreateAccountTxInput)
-	if !ok1 && !ok2 && !ok3 && !ok4 && !ok5 && !ok6 {
+	_, ok7 := variant.(xc.ApprovalTxInput)
+	_, ok8 := variant.(xc.NftTransferTxInput)
+	_, ok9 := variant.(xc.AccessKeyTxInput)
+	if !ok1 && !ok2 && !ok3 && !ok4 && !ok5 && !ok6 && !ok7 && !ok8 && !ok9 {
 		panic(fmt.Sprintf("staking input %T must implement one of known variants", variant))
 	}
 
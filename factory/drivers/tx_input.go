@@ -209,3 +209,39 @@ func UnmarshalCreateAccountInput(data []byte) (xc.CreateAccountTxInput, error) {
 	}
 	return createAccount, nil
 }
+
+func UnmarshalApprovalInput(data []byte) (xc.ApprovalTxInput, error) {
+	inp, err := UnmarshalVariantInput(data)
+	if err != nil {
+		return nil, err
+	}
+	approval, ok := inp.(xc.ApprovalTxInput)
+	if !ok {
+		return approval, fmt.Errorf("not an approval input: %T", inp)
+	}
+	return approval, nil
+}
+
+func UnmarshalNftTransferInput(data []byte) (xc.NftTransferTxInput, error) {
+	inp, err := UnmarshalVariantInput(data)
+	if err != nil {
+		return nil, err
+	}
+	nftTransfer, ok := inp.(xc.NftTransferTxInput)
+	if !ok {
+		return nftTransfer, fmt.Errorf("not an nft-transfer input: %T", inp)
+	}
+	return nftTransfer, nil
+}
+
+func UnmarshalAccessKeyInput(data []byte) (xc.AccessKeyTxInput, error) {
+	inp, err := UnmarshalVariantInput(data)
+	if err != nil {
+		return nil, err
+	}
+	accessKey, ok := inp.(xc.AccessKeyTxInput)
+	if !ok {
+		return accessKey, fmt.Errorf("not an access-key input: %T", inp)
+	}
+	return accessKey, nil
+}
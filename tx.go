@@ -126,6 +126,18 @@ type CallTxInput interface {
 	TxVariantInput
 	Calling()
 }
+type ApprovalTxInput interface {
+	TxVariantInput
+	Approving()
+}
+type NftTransferTxInput interface {
+	TxVariantInput
+	NftTransferring()
+}
+type AccessKeyTxInput interface {
+	TxVariantInput
+	ManagingAccessKey()
+}
 
 // TxStatus is the status of a tx on chain, currently success or failure.
 type TxStatus uint8